@@ -166,6 +166,10 @@ type StoreSpec struct {
 	Size       SizeSpec
 	InMemory   bool
 	Attributes roachpb.Attributes
+	// BallastSize is the size of the emergency ballast file maintained in the
+	// store's auxiliary directory. If nil, the default ballast size is used;
+	// see storage.BallastSizeBytes. A size of zero disables the ballast.
+	BallastSize *SizeSpec
 	// StickyInMemoryEngineID is a unique identifier associated with a given
 	// store which will remain in memory even after the default Engine close
 	// until it has been explicitly cleaned up by CleanupStickyInMemEngine[s]
@@ -202,6 +206,13 @@ func (ss StoreSpec) String() string {
 	if ss.Size.Percent > 0 {
 		fmt.Fprintf(&buffer, "size=%s%%,", humanize.Ftoa(ss.Size.Percent))
 	}
+	if ss.BallastSize != nil {
+		if ss.BallastSize.Percent > 0 {
+			fmt.Fprintf(&buffer, "ballast-size=%s%%,", humanize.Ftoa(ss.BallastSize.Percent))
+		} else {
+			fmt.Fprintf(&buffer, "ballast-size=%s,", humanizeutil.IBytes(ss.BallastSize.InBytes))
+		}
+	}
 	if len(ss.Attributes.Attrs) > 0 {
 		fmt.Fprint(&buffer, "attrs=")
 		for i, attr := range ss.Attributes.Attrs {
@@ -255,6 +266,9 @@ var fractionRegex = regexp.MustCompile(`^([-]?([0-9]+\.[0-9]*|[0-9]*\.[0-9]+|[0-
 //   - 20%             -> 20% of the available space
 //   - 0.2             -> 20% of the available space
 // - attrs=xxx:yyy:zzz A colon separated list of optional attributes.
+// - ballast-size=xxx The size of the emergency ballast file, in the same
+//   formats as size. Percentages are relative to the total disk space and
+//   cannot exceed 50%. Set to 0 to disable the ballast.
 // Note that commas are forbidden within any field name or value.
 func NewStoreSpec(value string) (StoreSpec, error) {
 	const pathField = "path"
@@ -309,6 +323,19 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 			if err != nil {
 				return StoreSpec{}, err
 			}
+		case "ballast-size":
+			var minBytesAllowed int64
+			var minPercent float64
+			var maxPercent float64 = 50
+			ballastSize, err := NewSizeSpec(
+				value,
+				&intInterval{min: &minBytesAllowed},
+				&floatInterval{min: &minPercent, max: &maxPercent},
+			)
+			if err != nil {
+				return StoreSpec{}, err
+			}
+			ss.BallastSize = &ballastSize
 		case "attrs":
 			// Check to make sure there are no duplicate attributes.
 			attrMap := make(map[string]struct{})
@@ -378,6 +405,9 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 		if ss.Size.Percent == 0 && ss.Size.InBytes == 0 {
 			return StoreSpec{}, fmt.Errorf("size must be specified for an in memory store")
 		}
+		if ss.BallastSize != nil {
+			return StoreSpec{}, fmt.Errorf("ballast-size specified for in memory store")
+		}
 	} else if ss.Path == "" {
 		return StoreSpec{}, fmt.Errorf("no path specified")
 	}
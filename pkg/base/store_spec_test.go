@@ -128,6 +128,14 @@ target_file_size=2097152`
 		{"size=20GiB,path=/mnt/hda1,size=20GiB", "size field was used twice in store definition", StoreSpec{}},
 		{"size=123TB", "no path specified", StoreSpec{}},
 
+		// ballast size
+		{"path=/mnt/hda1,ballast-size=671088640", "", StoreSpec{Path: "/mnt/hda1", BallastSize: &SizeSpec{InBytes: 671088640}}},
+		{"path=/mnt/hda1,ballast-size=2GiB", "", StoreSpec{Path: "/mnt/hda1", BallastSize: &SizeSpec{InBytes: 2147483648}}},
+		{"path=/mnt/hda1,ballast-size=5%", "", StoreSpec{Path: "/mnt/hda1", BallastSize: &SizeSpec{Percent: 5}}},
+		{"path=/mnt/hda1,ballast-size=0", "", StoreSpec{Path: "/mnt/hda1", BallastSize: &SizeSpec{}}},
+		{"path=/mnt/hda1,ballast-size=50.1%", "store size (50.1%) must be between 0.000000% and 50.000000%", StoreSpec{}},
+		{"type=mem,size=20GiB,ballast-size=1GiB", "ballast-size specified for in memory store", StoreSpec{}},
+
 		// type
 		{"type=mem,size=20GiB", "", StoreSpec{Size: SizeSpec{InBytes: 21474836480}, InMemory: true}},
 		{"size=20GiB,type=mem", "", StoreSpec{Size: SizeSpec{InBytes: 21474836480}, InMemory: true}},
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/cloudimpl"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -238,6 +239,11 @@ func backup(
 
 	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
 	g.GoCtx(func(ctx context.Context) error {
+		// exportedBytes counts the bytes exported since lastRateUpdate, and is
+		// used to report the current export rate in the job's running status.
+		var exportedBytes int64
+		lastRateUpdate := timeutil.Now()
+
 		// When a processor is done exporting a span, it will send a progress update
 		// to progCh.
 		for progress := range progCh {
@@ -251,6 +257,7 @@ func backup(
 			for _, file := range progDetails.Files {
 				backupManifest.Files = append(backupManifest.Files, file)
 				backupManifest.EntryCounts.add(file.EntryCounts)
+				exportedBytes += file.EntryCounts.DataSize
 			}
 
 			// Signal that an ExportRequest finished to update job progress.
@@ -265,6 +272,17 @@ func backup(
 
 				lastCheckpoint = timeutil.Now()
 			}
+			if elapsed := timeutil.Since(lastRateUpdate); elapsed > BackupCheckpointInterval {
+				status := jobs.RunningStatus(fmt.Sprintf("exporting data at %s/s",
+					humanizeutil.IBytes(int64(float64(exportedBytes)/elapsed.Seconds()))))
+				if err := job.RunningStatus(ctx, func(_ context.Context, _ jobspb.Details) (jobs.RunningStatus, error) {
+					return status, nil
+				}); err != nil {
+					log.Warningf(ctx, "failed to update running status of job %d: %+v", *job.ID(), err)
+				}
+				exportedBytes = 0
+				lastRateUpdate = timeutil.Now()
+			}
 		}
 		return nil
 	})
@@ -143,6 +143,9 @@ func runBackupProcessor(
 	//  *2). See #49798.
 	numSenders := int(kvserver.ExportRequestsLimit.Get(&settings.SV)) * 2
 	targetFileSize := storageccl.ExportRequestTargetFileSize.Get(&settings.SV)
+	// The limiter is shared by all of this processor's senders, and applies to
+	// the data returned by their ExportRequests.
+	limiter := storageccl.NewSettingRateLimiter("backup", storageccl.BackupMaxRate, &settings.SV)
 
 	// For all backups, partitioned or not, the main BACKUP manifest is stored at
 	// details.URI.
@@ -249,6 +252,7 @@ func runBackupProcessor(
 				var prog execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
 				progDetails := BackupManifest_Progress{}
 				progDetails.RevStartTime = res.StartTime
+				var exportedBytes int64
 				for _, file := range res.Files {
 					f := BackupManifest_File{
 						Span:        file.Span,
@@ -262,6 +266,7 @@ func runBackupProcessor(
 						f.EndTime = span.end
 					}
 					files = append(files, f)
+					exportedBytes += file.Exported.DataSize
 				}
 				progDetails.Files = files
 				details, err := gogotypes.MarshalAny(&progDetails)
@@ -270,6 +275,11 @@ func runBackupProcessor(
 				}
 				prog.ProgressDetails = *details
 				progCh <- prog
+				// Wait out the data we just exported before sending the next request,
+				// so that the export rate stays under bulkio.backup.max_rate.
+				if err := limiter.WaitN(ctx, exportedBytes); err != nil {
+					return err
+				}
 			default:
 				// No work left to do, so we can exit. Note that another worker could
 				// still be running and may still push new work (a retry) on to todo but
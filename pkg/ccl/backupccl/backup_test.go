@@ -641,7 +641,7 @@ func TestBackupRestoreAppend(t *testing.T) {
 				tc.Servers[0].ClusterSettings(),
 				blobs.TestEmptyBlobClientFactory,
 				security.RootUserName(),
-				tc.Servers[0].InternalExecutor().(*sql.InternalExecutor), tc.Servers[0].DB())
+				tc.Servers[0].InternalExecutor().(*sql.InternalExecutor), tc.Servers[0].DB(), nil)
 			require.NoError(t, err)
 			defer store.Close()
 			files, err := store.ListFiles(ctx, "*/*/*/"+backupManifestName)
@@ -1648,7 +1648,7 @@ func TestBackupRestoreResume(t *testing.T) {
 func getHighWaterMark(jobID int64, sqlDB *gosql.DB) (roachpb.Key, error) {
 	var progressBytes []byte
 	if err := sqlDB.QueryRow(
-		`SELECT progress FROM system.jobs WHERE id = $1`, jobID,
+		`SELECT `+jobs.JobInfoProgressColumn+` FROM system.jobs WHERE id = $1`, jobID,
 	).Scan(&progressBytes); err != nil {
 		return nil, err
 	}
@@ -1845,6 +1845,80 @@ func TestBackupRestoreControlJob(t *testing.T) {
 	})
 }
 
+// TestRestoreRunningStatus checks that a RESTORE job reports that it is
+// pre-splitting and scattering until the first span has been ingested, and
+// that it is ingesting data afterwards.
+func TestRestoreRunningStatus(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// processingEntry is signaled when a span entry has been processed, before
+	// its progress is reported to the coordinator. The entry is then blocked
+	// until allowEntry is signaled or closed.
+	processingEntry := make(chan struct{})
+	allowEntry := make(chan struct{})
+	params := base.TestClusterArgs{}
+	params.ServerArgs.Knobs.DistSQL = &execinfra.TestingKnobs{
+		BackupRestoreTestingKnobs: &sql.BackupRestoreTestingKnobs{
+			RunAfterProcessingRestoreSpanEntry: func(ctx context.Context) {
+				select {
+				case processingEntry <- struct{}{}:
+				case <-allowEntry:
+					return
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case <-allowEntry:
+				case <-ctx.Done():
+				}
+			},
+		},
+	}
+
+	const numAccounts = 1000
+	_, _, sqlDB, _, cleanup := backupRestoreTestSetupWithParams(t, singleNode, numAccounts,
+		InitManualReplication, params)
+	defer cleanup()
+	defer func() {
+		select {
+		case <-allowEntry:
+		default:
+			close(allowEntry)
+		}
+	}()
+
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, LocalFoo)
+	sqlDB.Exec(t, `CREATE DATABASE restoredb`)
+	var jobID int64
+	sqlDB.QueryRow(t, `RESTORE data.bank FROM $1 WITH into_db = 'restoredb', detached`,
+		LocalFoo).Scan(&jobID)
+
+	runningStatus := func() string {
+		var status string
+		sqlDB.QueryRow(t, `SELECT running_status FROM crdb_internal.jobs WHERE job_id = $1`,
+			jobID).Scan(&status)
+		return status
+	}
+
+	// No span has been ingested while the first one is blocked.
+	<-processingEntry
+	require.Regexp(t, `^pre-splitting and scattering \d+ spans$`, runningStatus())
+
+	// Once the first span has been ingested, the status moves on.
+	allowEntry <- struct{}{}
+	<-processingEntry
+	testutils.SucceedsSoon(t, func() error {
+		if status := runningStatus(); status != string(runningStatusRestoreIngest) {
+			return errors.Newf("expected running status %q, got %q", runningStatusRestoreIngest, status)
+		}
+		return nil
+	})
+
+	close(allowEntry)
+	jobutils.WaitForJob(t, sqlDB, jobID)
+}
+
 func TestRestoreFailCleansUpTypeBackReferences(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
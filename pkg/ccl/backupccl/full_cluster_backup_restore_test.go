@@ -111,6 +111,8 @@ CREATE TABLE data2.foo (a int);
 	sqlDB.Exec(t, `CREATE ROLE system_ops;`)
 	sqlDB.Exec(t, `GRANT CREATE, SELECT ON DATABASE data TO system_ops;`)
 	sqlDB.Exec(t, `GRANT system_ops TO maxroach1;`)
+	// Populate system.database_role_settings.
+	sqlDB.Exec(t, `ALTER ROLE system_ops IN DATABASE data SET timezone = 'America/New_York'`)
 
 	// Populate system.scheduled_jobs table.
 	sqlDB.Exec(t, `CREATE SCHEDULE FOR BACKUP data.bank INTO $1 RECURRING '@hourly' FULL BACKUP ALWAYS`, LocalFoo)
@@ -168,6 +170,7 @@ CREATE TABLE data2.foo (a int);
 		// jobs are created during the RESTORE process.
 		systemTablesToVerify := []string{
 			systemschema.CommentsTable.Name,
+			systemschema.DatabaseRoleSettingsTable.Name,
 			systemschema.LocationsTable.Name,
 			systemschema.RoleMembersTable.Name,
 			systemschema.RoleOptionsTable.Name,
@@ -510,6 +513,8 @@ func TestClusterRestoreFailCleanup(t *testing.T) {
 			[][]string{
 				{"bank"},
 				{"comments"},
+				{"database_role_settings"},
+				{"job_info"},
 				{"jobs"},
 				{"locations"},
 				{"role_members"},
@@ -550,6 +555,8 @@ func TestClusterRestoreFailCleanup(t *testing.T) {
 			[][]string{
 				{"bank"},
 				{"comments"},
+				{"database_role_settings"},
+				{"job_info"},
 				{"jobs"},
 				{"locations"},
 				{"role_members"},
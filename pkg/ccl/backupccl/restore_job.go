@@ -479,6 +479,16 @@ func rewriteBackupSpanKey(
 	return newKey, nil
 }
 
+const (
+	// runningStatusRestoreSplitAndScatter is the running status of a RESTORE
+	// job while it splits and scatters the ranges it restores into, before
+	// the first span has been ingested.
+	runningStatusRestoreSplitAndScatter = "pre-splitting and scattering %d spans"
+	// runningStatusRestoreIngest is the running status of a RESTORE job once it
+	// has started ingesting data.
+	runningStatusRestoreIngest jobs.RunningStatus = "ingesting data"
+)
+
 // restore imports a SQL table (or tables) from sets of non-overlapping sstable
 // files.
 func restore(
@@ -586,12 +596,31 @@ func restore(
 		return progressLogger.Loop(ctx, requestFinishedCh)
 	})
 
+	// The spans are split and scattered before any of them are ingested, which
+	// can take minutes on large clusters. Surface this phase in the running
+	// status until the first span has been ingested so that the job does not
+	// look stuck at 0%.
+	if err := job.RunningStatus(restoreCtx, func(_ context.Context, _ jobspb.Details) (jobs.RunningStatus, error) {
+		return jobs.RunningStatus(fmt.Sprintf(runningStatusRestoreSplitAndScatter, len(importSpans))), nil
+	}); err != nil {
+		return emptyRowCount, errors.Wrapf(err, "failed to update running status of job %d", errors.Safe(*job.ID()))
+	}
+
 	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
 
 	g.GoCtx(func(ctx context.Context) error {
+		ingesting := false
 		// When a processor is done importing a span, it will send a progress update
 		// to progCh.
 		for progress := range progCh {
+			if !ingesting {
+				ingesting = true
+				if err := job.RunningStatus(ctx, func(_ context.Context, _ jobspb.Details) (jobs.RunningStatus, error) {
+					return runningStatusRestoreIngest, nil
+				}); err != nil {
+					log.Warningf(ctx, "failed to update running status of job %d: %+v", *job.ID(), err)
+				}
+			}
 			mu.Lock()
 			var progDetails RestoreProgress
 			if err := types.UnmarshalAny(&progress.ProgressDetails, &progDetails); err != nil {
@@ -166,9 +166,16 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 		includeInClusterBackup: optInToClusterBackup,
 		customRestoreFunc:      jobsRestoreFunc,
 	},
+	systemschema.JobInfoTable.Name: {
+		includeInClusterBackup: optInToClusterBackup,
+		customRestoreFunc:      jobsRestoreFunc,
+	},
 	systemschema.ScheduledJobsTable.Name: {
 		includeInClusterBackup: optInToClusterBackup,
 	},
+	systemschema.DatabaseRoleSettingsTable.Name: {
+		includeInClusterBackup: optInToClusterBackup,
+	},
 	systemschema.TableStatisticsTable.Name: {
 		// Table statistics are backed up in the backup descriptor for now.
 		includeInClusterBackup: optOutOfClusterBackup,
@@ -64,7 +64,7 @@ func runLoadShow(cmd *cobra.Command, args []string) error {
 	externalStorageFromURI := func(ctx context.Context, uri string,
 		user security.SQLUsername) (cloud.ExternalStorage, error) {
 		return cloudimpl.ExternalStorageFromURI(ctx, uri, base.ExternalIODirConfig{},
-			cluster.NoSettings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+			cluster.NoSettings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	}
 	// This reads the raw backup descriptor (with table descriptors possibly not
 	// upgraded from the old FK representation, or even older formats). If more
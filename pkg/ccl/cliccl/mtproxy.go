@@ -39,7 +39,7 @@ one of the following holds:
 
 1. the supplied database name is prefixed with 'prancing-pony.'; this prefix
    will then be removed for the connection to the backend server, and/or
-2. the options parameter is 'prancing-pony'.
+2. the options parameter specifies '--cluster=prancing-pony'.
 
 Connections to the target address use TLS but do not identify the identity of
 the peer, making them susceptible to MITM attacks.
@@ -157,9 +157,13 @@ Uuwb2FVdh76ZK0AVd3Jh3KJs4+hr2u9syHaa7UPKXTcZsFWlGwZuu6X5A+0SO0S2
 		BackendDialer: func(msg *pgproto3.StartupMessage) (net.Conn, error) {
 			params := msg.Parameters
 			const magic = "prancing-pony"
+			clusterName, err := sqlproxyccl.ClusterNameFromParams(params)
+			if err != nil {
+				return nil, err
+			}
 			if strings.HasPrefix(params["database"], magic+".") {
 				params["database"] = params["database"][len(magic)+1:]
-			} else if params["options"] != "--cluster="+magic {
+			} else if clusterName != magic {
 				return nil, errors.Errorf("client failed to pass '%s' via database or options", magic)
 			}
 			conn, err := sqlproxyccl.BackendDial(msg, sqlProxyTargetAddr, outgoingConf)
@@ -29,10 +29,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
+	gogotypes "github.com/gogo/protobuf/types"
 )
 
 var csvOutputTypes = []*types.T{
@@ -277,13 +279,15 @@ func ingestKvs(
 	// will hog memory as it tries to grow more aggressively.
 	minBufferSize, maxBufferSize, stepSize := storageccl.ImportBufferConfigSizes(flowCtx.Cfg.Settings, true /* isPKAdder */)
 	pkIndexAdder, err := flowCtx.Cfg.BulkAdder(ctx, flowCtx.Cfg.DB, writeTS, kvserverbase.BulkAdderOptions{
-		Name:              "pkAdder",
-		DisallowShadowing: true,
-		SkipDuplicates:    true,
-		MinBufferSize:     minBufferSize,
-		MaxBufferSize:     maxBufferSize,
-		StepBufferSize:    stepSize,
-		SSTSize:           flushSize,
+		Name:                   "pkAdder",
+		DisallowShadowing:      true,
+		SkipDuplicates:         true,
+		CheckExistingKeys:      spec.DetectConflicts,
+		SkipExistingDuplicates: spec.SkipDuplicates,
+		MinBufferSize:          minBufferSize,
+		MaxBufferSize:          maxBufferSize,
+		StepBufferSize:         stepSize,
+		SSTSize:                flushSize,
 	})
 	if err != nil {
 		return nil, err
@@ -292,13 +296,15 @@ func ingestKvs(
 
 	minBufferSize, maxBufferSize, stepSize = storageccl.ImportBufferConfigSizes(flowCtx.Cfg.Settings, false /* isPKAdder */)
 	indexAdder, err := flowCtx.Cfg.BulkAdder(ctx, flowCtx.Cfg.DB, writeTS, kvserverbase.BulkAdderOptions{
-		Name:              "indexAdder",
-		DisallowShadowing: true,
-		SkipDuplicates:    true,
-		MinBufferSize:     minBufferSize,
-		MaxBufferSize:     maxBufferSize,
-		StepBufferSize:    stepSize,
-		SSTSize:           flushSize,
+		Name:                   "indexAdder",
+		DisallowShadowing:      true,
+		SkipDuplicates:         true,
+		CheckExistingKeys:      spec.DetectConflicts,
+		SkipExistingDuplicates: spec.SkipDuplicates,
+		MinBufferSize:          minBufferSize,
+		MaxBufferSize:          maxBufferSize,
+		StepBufferSize:         stepSize,
+		SSTSize:                flushSize,
 	})
 	if err != nil {
 		return nil, err
@@ -346,8 +352,21 @@ func ingestKvs(
 		offset++
 	}
 
+	// ingestedBytes counts the bytes added to the adders since the last progress
+	// update, and is reported so the coordinator can display the ingest rate.
+	var ingestedBytes int64
+	limiter := storageccl.NewSettingRateLimiter("import", storageccl.ImportMaxRate, &flowCtx.Cfg.Settings.SV)
+
 	pushProgress := func() {
 		var prog execinfrapb.RemoteProducerMetadata_BulkProcessorProgress
+		details, err := gogotypes.MarshalAny(&roachpb.BulkOpSummary{
+			DataSize: atomic.SwapInt64(&ingestedBytes, 0),
+		})
+		if err != nil {
+			log.Warningf(ctx, "failed to marshal import progress details: %+v", err)
+		} else {
+			prog.ProgressDetails = *details
+		}
 		prog.ResumePos = make(map[int32]int64)
 		prog.CompletedFraction = make(map[int32]float32)
 		for file, offset := range offsets {
@@ -408,6 +427,14 @@ func ingestKvs(
 		// results in flushing a much larger number of small SSTs. This increases the
 		// number of L0 (and total) files, but with a lower memory usage.
 		for kvBatch := range kvCh {
+			var batchSize int64
+			for _, kv := range kvBatch.KVs {
+				batchSize += int64(len(kv.Key) + len(kv.Value.RawBytes))
+			}
+			if err := limiter.WaitN(ctx, batchSize); err != nil {
+				return err
+			}
+			atomic.AddInt64(&ingestedBytes, batchSize)
 			for _, kv := range kvBatch.KVs {
 				_, _, indexID, indexErr := flowCtx.Codec().DecodeIndexPrefix(kv.Key)
 				if indexErr != nil {
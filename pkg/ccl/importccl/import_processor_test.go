@@ -601,7 +601,8 @@ func queryJob(db sqlutils.DBHandle, jobID int64) (js jobState) {
 	}
 	var progressBytes, payloadBytes []byte
 	js.err = db.QueryRowContext(
-		context.Background(), "SELECT status, payload, progress FROM system.jobs WHERE id = $1", jobID).Scan(
+		context.Background(),
+		"SELECT status, payload, "+jobs.JobInfoProgressColumn+" FROM system.jobs WHERE id = $1", jobID).Scan(
 		&js.status, &payloadBytes, &progressBytes)
 	if js.err != nil {
 		return
@@ -899,7 +900,7 @@ func externalStorageFactory(
 		return nil, err
 	}
 	return cloudimpl.MakeExternalStorage(ctx, dest, base.ExternalIODirConfig{},
-		nil, blobs.TestBlobServiceClient(workdir), nil, nil)
+		nil, blobs.TestBlobServiceClient(workdir), nil, nil, nil)
 }
 
 // Helper to create and initialize testSpec.
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/bulk"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -80,6 +81,7 @@ const (
 	importOptionSkipFKs          = "skip_foreign_keys"
 	importOptionDisableGlobMatch = "disable_glob_matching"
 	importOptionSaveRejected     = "experimental_save_rejected"
+	importOptionConflictMode     = "conflict_mode"
 
 	pgCopyDelimiter = "delimiter"
 	pgCopyNull      = "nullif"
@@ -124,6 +126,7 @@ var importOptionExpectValues = map[string]sql.KVStringOptValidate{
 
 	importOptionSkipFKs:          sql.KVStringOptRequireNoValue,
 	importOptionDisableGlobMatch: sql.KVStringOptRequireNoValue,
+	importOptionConflictMode:     sql.KVStringOptRequireValue,
 
 	optMaxRowSize: sql.KVStringOptRequireValue,
 
@@ -146,7 +149,7 @@ func makeStringSet(opts ...string) map[string]struct{} {
 // Options common to all formats.
 var allowedCommonOptions = makeStringSet(
 	importOptionSSTSize, importOptionDecompress, importOptionOversample,
-	importOptionSaveRejected, importOptionDisableGlobMatch)
+	importOptionSaveRejected, importOptionDisableGlobMatch, importOptionConflictMode)
 
 // Format specific allowed options.
 var avroAllowedOptions = makeStringSet(
@@ -655,6 +658,25 @@ func importPlanHook(
 			skipFKs = true
 		}
 
+		// conflict_mode makes IMPORT INTO check the data it ingests against the
+		// existing rows of the table as it goes, rather than failing on the first
+		// collision found when the data is ingested.
+		var detectConflicts, skipDuplicates bool
+		if mode, ok := opts[importOptionConflictMode]; ok {
+			if !importStmt.Into {
+				return errors.Newf("%s is only supported by IMPORT INTO", importOptionConflictMode)
+			}
+			switch strings.ToLower(mode) {
+			case "error":
+				detectConflicts = true
+			case "skip_duplicates":
+				detectConflicts, skipDuplicates = true, true
+			default:
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value %q for %s, must be 'error' or 'skip_duplicates'", mode, importOptionConflictMode)
+			}
+		}
+
 		if override, ok := opts[importOptionDecompress]; ok {
 			found := false
 			for name, value := range roachpb.IOFileFormat_Compression_value {
@@ -861,6 +883,8 @@ func importPlanHook(
 			Oversample:        oversample,
 			SkipFKs:           skipFKs,
 			ParseBundleSchema: importStmt.Bundle,
+			DetectConflicts:   detectConflicts,
+			SkipDuplicates:    skipDuplicates,
 		}
 
 		// Prepare the protected timestamp record.
@@ -1232,7 +1256,8 @@ func (r *importResumer) prepareTableDescsForIngestion(
 }
 
 // parseAndCreateBundleTableDescs parses and creates the table
-// descriptors for bundle formats.
+// descriptors for bundle formats. The clauses of the schema that had to be
+// rewritten or dropped are recorded in report.
 func parseAndCreateBundleTableDescs(
 	ctx context.Context,
 	p sql.JobExecContext,
@@ -1244,6 +1269,7 @@ func parseAndCreateBundleTableDescs(
 	format roachpb.IOFileFormat,
 	walltime int64,
 	owner security.SQLUsername,
+	report *conversionReport,
 ) ([]*tabledesc.Mutable, error) {
 
 	var tableDescs []*tabledesc.Mutable
@@ -1277,10 +1303,10 @@ func parseAndCreateBundleTableDescs(
 	switch format.Format {
 	case roachpb.IOFileFormat_Mysqldump:
 		evalCtx := &p.ExtendedEvalContext().EvalContext
-		tableDescs, err = readMysqlCreateTable(ctx, reader, evalCtx, p, defaultCSVTableID, parentID, tableName, fks, seqVals, owner, walltime)
+		tableDescs, err = readMysqlCreateTable(ctx, reader, evalCtx, p, defaultCSVTableID, parentID, tableName, fks, seqVals, owner, walltime, report)
 	case roachpb.IOFileFormat_PgDump:
 		evalCtx := &p.ExtendedEvalContext().EvalContext
-		tableDescs, err = readPostgresCreateTable(ctx, reader, evalCtx, p, tableName, parentID, walltime, fks, int(format.PgDump.MaxRowSize), owner)
+		tableDescs, err = readPostgresCreateTable(ctx, reader, evalCtx, p, tableName, parentID, walltime, fks, int(format.PgDump.MaxRowSize), owner, report)
 	default:
 		return tableDescs, errors.Errorf("non-bundle format %q does not support reading schemas", format.Format.String())
 	}
@@ -1315,11 +1341,12 @@ func (r *importResumer) parseBundleSchemaIfNeeded(ctx context.Context, phs inter
 		}
 
 		var tableDescs []*tabledesc.Mutable
+		var report conversionReport
 		var err error
 		walltime := p.ExecCfg().Clock.Now().WallTime
 
 		if tableDescs, err = parseAndCreateBundleTableDescs(
-			ctx, p, details, seqVals, skipFKs, parentID, files, format, walltime, owner); err != nil {
+			ctx, p, details, seqVals, skipFKs, parentID, files, format, walltime, owner, &report); err != nil {
 			return err
 		}
 
@@ -1332,6 +1359,7 @@ func (r *importResumer) parseBundleSchemaIfNeeded(ctx context.Context, phs inter
 			}
 		}
 		details.Tables = tableDetails
+		details.ConversionReport = report.notes
 
 		for _, tbl := range tableDescs {
 			// For reasons relating to #37691, we disallow user defined types in
@@ -1441,6 +1469,10 @@ func (r *importResumer) Resume(
 	res, err := sql.DistIngest(ctx, p, r.job, tables, files, format, details.Walltime,
 		r.testingKnobs.alwaysFlushJobProgress)
 	if err != nil {
+		if errors.Is(err, bulk.ErrKeyConflict) && !details.SkipDuplicates {
+			err = errors.WithHint(err,
+				"use the conflict_mode = 'skip_duplicates' option to skip rows identical to existing ones")
+		}
 		return err
 	}
 	pkIDs := make(map[uint64]struct{}, len(details.Tables))
@@ -2366,7 +2366,7 @@ func TestImportObjectLevelRBAC(t *testing.T) {
 		// Write to userfile storage now that testuser has CREATE privileges.
 		ie := tc.Server(0).InternalExecutor().(*sql.InternalExecutor)
 		fileTableSystem1, err := cloudimpl.ExternalStorageFromURI(ctx, dest, base.ExternalIODirConfig{},
-			cluster.NoSettings, blobs.TestEmptyBlobClientFactory, security.TestUserName(), ie, tc.Server(0).DB())
+			cluster.NoSettings, blobs.TestEmptyBlobClientFactory, security.TestUserName(), ie, tc.Server(0).DB(), nil)
 		require.NoError(t, err)
 		require.NoError(t, fileTableSystem1.WriteFile(ctx, filename, bytes.NewReader([]byte("1,aaa"))))
 	}
@@ -3359,6 +3359,58 @@ func TestImportIntoCSV(t *testing.T) {
 		)
 	})
 
+	// With conflict_mode set, collisions are detected before the data is
+	// ingested and reported with a sample of the conflicting keys.
+	t.Run("import-into-conflict-mode-error", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b STRING)`)
+		defer sqlDB.Exec(t, `DROP TABLE t`)
+
+		sqlDB.Exec(t,
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s)`, testFiles.files[0]),
+		)
+
+		sqlDB.ExpectErr(
+			t, `ingested keys collide with existing data: \d+ conflicting keys, including \[/Table/\d+/1/0/0`,
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s) WITH conflict_mode = 'error'`, testFiles.files[0]),
+		)
+	})
+
+	// Rows identical to existing ones are skipped with conflict_mode =
+	// 'skip_duplicates', while rows with a different value are still conflicts.
+	t.Run("import-into-conflict-mode-skip-duplicates", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b STRING)`)
+		defer sqlDB.Exec(t, `DROP TABLE t`)
+
+		sqlDB.Exec(t,
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s)`, testFiles.files[0]),
+		)
+		sqlDB.Exec(t, fmt.Sprintf(
+			`IMPORT INTO t (a, b) CSV DATA (%s) WITH conflict_mode = 'skip_duplicates'`, testFiles.files[0]),
+		)
+
+		var result int
+		sqlDB.QueryRow(t, `SELECT count(*) FROM t`).Scan(&result)
+		if result != rowsPerFile {
+			t.Fatalf("expected %d rows, got %d", rowsPerFile, result)
+		}
+
+		sqlDB.Exec(t, `UPDATE t SET b = 'changed' WHERE a = 0`)
+		sqlDB.ExpectErr(
+			t, `ingested keys collide with existing data: 1 conflicting keys, including \[/Table/\d+/1/0/0\]`,
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s) WITH conflict_mode = 'skip_duplicates'`, testFiles.files[0]),
+		)
+	})
+
+	t.Run("import-into-conflict-mode-invalid", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, b STRING)`)
+		defer sqlDB.Exec(t, `DROP TABLE t`)
+
+		sqlDB.ExpectErr(
+			t, `invalid value "upsert" for conflict_mode`,
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s) WITH conflict_mode = 'upsert'`, testFiles.files[0]),
+		)
+	})
+
 	// Tests that IMPORT INTO invalidates FK and CHECK constraints.
 	t.Run("import-into-invalidate-constraints", func(t *testing.T) {
 
@@ -4951,7 +5003,7 @@ func TestImportLivenessWithRestart(t *testing.T) {
 	{
 		var expectedLeaseBytes []byte
 		sqlDB.QueryRow(
-			t, `SELECT id, progress FROM system.jobs ORDER BY created DESC LIMIT 1`,
+			t, `SELECT id, `+jobs.JobInfoProgressColumn+` FROM system.jobs ORDER BY created DESC LIMIT 1`,
 		).Scan(&jobID, &expectedLeaseBytes)
 		if err := protoutil.Unmarshal(expectedLeaseBytes, originalLease); err != nil {
 			t.Fatal(err)
@@ -5834,6 +5886,46 @@ func TestImportPgDumpDropTable(t *testing.T) {
 	})
 }
 
+func TestImportPgDumpConversionReport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+	const data = `
+SELECT pg_catalog.set_config('search_path', '', false);
+CREATE TABLE t (a INT PRIMARY KEY, b INT);
+CREATE TABLE u (a INT PRIMARY KEY);
+ALTER TABLE t OWNER TO postgres;
+ALTER TABLE t ADD CONSTRAINT t_b_fkey FOREIGN KEY (b) REFERENCES u (a);
+ALTER TABLE t VALIDATE CONSTRAINT t_b_fkey;
+`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_, _ = w.Write([]byte(data))
+		}
+	}))
+	defer srv.Close()
+
+	var jobID int64
+	var unused interface{}
+	sqlDB.QueryRow(t, `IMPORT PGDUMP ($1) WITH skip_foreign_keys`, srv.URL).Scan(
+		&jobID, &unused, &unused, &unused, &unused, &unused,
+	)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT conversion_report FROM [SHOW JOB %d WITH DETAILS]`, jobID),
+		[][]string{{`[` +
+			`{"action": "ignored", "clause": "set_config('search_path', '', false)", "object": ""}, ` +
+			`{"action": "ignored; imported tables are owned by the user running IMPORT", "clause": "OWNER TO postgres", "object": "t"}, ` +
+			`{"action": "dropped because skip_foreign_keys was specified", "clause": "ADD CONSTRAINT t_b_fkey FOREIGN KEY (b) REFERENCES u (a)", "object": "t"}, ` +
+			`{"action": "ignored", "clause": "VALIDATE CONSTRAINT t_b_fkey", "object": "t"}` +
+			`]`}},
+	)
+}
+
 func TestImportCockroachDump(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
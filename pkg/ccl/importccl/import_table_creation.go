@@ -14,6 +14,7 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -83,6 +84,27 @@ type fkHandler struct {
 // NoFKs is used by formats that do not support FKs.
 var NoFKs = fkHandler{resolver: make(fkResolver)}
 
+// conversionReport collects the clauses of a dump's schema that could not be
+// imported as-is, along with what was done with them, so that they can be
+// recorded in the job details instead of being silently dropped. A nil
+// *conversionReport discards everything added to it.
+type conversionReport struct {
+	notes []jobspb.ImportDetails_ConversionNote
+}
+
+// add records that the given clause of object was rewritten or dropped, as
+// described by action.
+func (r *conversionReport) add(object, clause, action string) {
+	if r == nil {
+		return
+	}
+	r.notes = append(r.notes, jobspb.ImportDetails_ConversionNote{
+		Object: object,
+		Clause: clause,
+		Action: action,
+	})
+}
+
 // MakeSimpleTableDescriptor creates a Mutable from a CreateTable parse
 // node without the full machinery. Many parts of the syntax are unsupported
 // (see the implementation and TestMakeSimpleTableDescriptorErrors for details),
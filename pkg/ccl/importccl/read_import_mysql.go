@@ -288,7 +288,8 @@ func mysqlValueToDatum(
 // if a matching table is not found in the input. Otherwise, if match is empty,
 // all tables encountered are returned (or an error is returned if no tables are
 // found). Returned tables are given dummy, placeholder IDs -- it is up to the
-// caller to allocate and assign real IDs.
+// caller to allocate and assign real IDs. Clauses that are rewritten or dropped
+// along the way are recorded in report.
 func readMysqlCreateTable(
 	ctx context.Context,
 	input io.Reader,
@@ -300,6 +301,7 @@ func readMysqlCreateTable(
 	seqVals map[descpb.ID]int64,
 	owner security.SQLUsername,
 	walltime int64,
+	report *conversionReport,
 ) ([]*tabledesc.Mutable, error) {
 	match = lexbase.NormalizeName(match)
 	r := bufio.NewReaderSize(input, 1024*64)
@@ -331,7 +333,7 @@ func readMysqlCreateTable(
 				continue
 			}
 			id := descpb.ID(int(startingID) + len(ret))
-			tbl, moreFKs, err := mysqlTableToCockroach(ctx, evalCtx, p, parentID, id, name, i.TableSpec, fks, seqVals, owner, walltime)
+			tbl, moreFKs, err := mysqlTableToCockroach(ctx, evalCtx, p, parentID, id, name, i.TableSpec, fks, seqVals, owner, walltime, report)
 			if err != nil {
 				return nil, err
 			}
@@ -379,6 +381,7 @@ func mysqlTableToCockroach(
 	seqVals map[descpb.ID]int64,
 	owner security.SQLUsername,
 	walltime int64,
+	report *conversionReport,
 ) ([]*tabledesc.Mutable, []delayedFK, error) {
 	if in == nil {
 		return nil, nil, errors.Errorf("could not read definition for table %q (possible unsupported type?)", name)
@@ -389,16 +392,22 @@ func mysqlTableToCockroach(
 	const seqOpt = "auto_increment="
 	var seqName string
 	var startingValue int64
-	for _, opt := range strings.Fields(strings.ToLower(in.Options)) {
-		if strings.HasPrefix(opt, seqOpt) {
+	var ignoredOpts []string
+	for _, opt := range strings.Fields(in.Options) {
+		if seqName == "" && strings.HasPrefix(strings.ToLower(opt), seqOpt) {
 			seqName = name + "_auto_inc"
-			i, err := strconv.Atoi(strings.TrimPrefix(opt, seqOpt))
+			i, err := strconv.Atoi(opt[len(seqOpt):])
 			if err != nil {
 				return nil, nil, errors.Wrapf(err, "parsing AUTO_INCREMENT value")
 			}
 			startingValue = int64(i)
-			break
+			report.add(name, opt, fmt.Sprintf("rewritten as the start value of sequence %s", seqName))
+			continue
 		}
+		ignoredOpts = append(ignoredOpts, opt)
+	}
+	if len(ignoredOpts) > 0 {
+		report.add(name, strings.Join(ignoredOpts, " "), "ignored")
 	}
 
 	if seqName == "" {
@@ -460,7 +469,8 @@ func mysqlTableToCockroach(
 	checks := make(map[string]*tree.CheckConstraintTableDef)
 
 	for _, raw := range in.Columns {
-		def, err := mysqlColToCockroach(safeString(raw.Name), raw.Type, checks)
+		colName := safeString(raw.Name)
+		def, err := mysqlColToCockroach(colName, raw.Type, checks, name, report)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -471,6 +481,8 @@ func mysqlTableToCockroach(
 				return nil, nil, err
 			}
 			def.DefaultExpr.Expr = expr
+			report.add(name+"."+colName, "AUTO_INCREMENT",
+				fmt.Sprintf("rewritten as DEFAULT %s", tree.AsString(expr)))
 		}
 		stmt.Defs = append(stmt.Defs, def)
 	}
@@ -513,6 +525,7 @@ func mysqlTableToCockroach(
 				return nil, nil, errors.Errorf("foreign keys not supported: %s", mysql.String(raw))
 			}
 			if fks.skip {
+				report.add(name, mysql.String(raw), "dropped because skip_foreign_keys was specified")
 				continue
 			}
 			fromCols := i.Source
@@ -596,9 +609,14 @@ func toNameList(cols mysql.Columns) tree.NameList {
 // counterpart (or returning an error if it is unable to do so).
 // To the extent possible, parameters such as length or precision are preseved
 // even if they have only cosmetic (i.e. when viewing schemas) effects compared
-// to their behavior in MySQL.
+// to their behavior in MySQL. Clauses of the definition that are rewritten or
+// dropped are recorded in report against the given table.
 func mysqlColToCockroach(
-	name string, col mysql.ColumnType, checks map[string]*tree.CheckConstraintTableDef,
+	name string,
+	col mysql.ColumnType,
+	checks map[string]*tree.CheckConstraintTableDef,
+	table string,
+	report *conversionReport,
 ) (*tree.ColumnTableDef, error) {
 	def := &tree.ColumnTableDef{Name: tree.Name(name)}
 
@@ -668,6 +686,7 @@ func mysqlColToCockroach(
 	case mysqltypes.Date:
 		def.Type = types.Date
 		if col.Default != nil && bytes.Equal(col.Default.Val, []byte(zeroDate)) {
+			report.add(table+"."+name, fmt.Sprintf("DEFAULT '%s'", zeroDate), "dropped")
 			col.Default = nil
 		}
 	case mysqltypes.Time:
@@ -675,11 +694,13 @@ func mysqlColToCockroach(
 	case mysqltypes.Timestamp:
 		def.Type = types.TimestampTZ
 		if col.Default != nil && bytes.Equal(col.Default.Val, []byte(zeroTime)) {
+			report.add(table+"."+name, fmt.Sprintf("DEFAULT '%s'", zeroTime), "dropped")
 			col.Default = nil
 		}
 	case mysqltypes.Datetime:
 		def.Type = types.TimestampTZ
 		if col.Default != nil && bytes.Equal(col.Default.Val, []byte(zeroTime)) {
+			report.add(table+"."+name, fmt.Sprintf("DEFAULT '%s'", zeroTime), "dropped")
 			col.Default = nil
 		}
 	case mysqltypes.Year:
@@ -696,6 +717,8 @@ func mysqlColToCockroach(
 			Name: tree.Name(fmt.Sprintf("imported_from_enum_%s", name)),
 			Expr: expr,
 		}
+		report.add(table+"."+name, fmt.Sprintf("ENUM(%s)", strings.Join(col.EnumValues, ",")),
+			fmt.Sprintf("rewritten as STRING with CHECK constraint %s", checks[name].Name))
 
 	case mysqltypes.TypeJSON:
 		def.Type = types.Jsonb
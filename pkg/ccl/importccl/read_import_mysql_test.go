@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catformat"
@@ -125,7 +126,7 @@ func readMysqlCreateFrom(
 	}
 	defer f.Close()
 	walltime := testEvalCtx.StmtTimestamp.UnixNano()
-	tbl, err := readMysqlCreateTable(context.Background(), f, testEvalCtx, nil, id, expectedParent, name, fks, map[descpb.ID]int64{}, security.RootUserName(), walltime)
+	tbl, err := readMysqlCreateTable(context.Background(), f, testEvalCtx, nil, id, expectedParent, name, fks, map[descpb.ID]int64{}, security.RootUserName(), walltime, nil /* report */)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -181,6 +182,42 @@ func TestMysqldumpSchemaReader(t *testing.T) {
 	})
 }
 
+func TestMysqldumpConversionReport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const dump = "CREATE TABLE `t` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+		"  `e` enum('a','b') DEFAULT NULL,\n" +
+		"  `d` date DEFAULT '0000-00-00',\n" +
+		"  `p` int DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  CONSTRAINT `fk_p` FOREIGN KEY (`p`) REFERENCES `t` (`id`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=latin1;\n"
+
+	var report conversionReport
+	skip := fkHandler{allowed: true, skip: true, resolver: make(fkResolver)}
+	walltime := testEvalCtx.StmtTimestamp.UnixNano()
+	if _, err := readMysqlCreateTable(
+		context.Background(), strings.NewReader(dump), testEvalCtx, nil, 51, expectedParent, "",
+		skip, map[descpb.ID]int64{}, security.RootUserName(), walltime, &report,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []jobspb.ImportDetails_ConversionNote{
+		{Object: "t", Clause: "auto_increment=10", Action: "rewritten as the start value of sequence t_auto_inc"},
+		{Object: "t", Clause: "ENGINE=InnoDB default charset=latin1", Action: "ignored"},
+		{Object: "t.id", Clause: "AUTO_INCREMENT", Action: "rewritten as DEFAULT nextval('t_auto_inc':::STRING)"},
+		{Object: "t.e", Clause: "ENUM('a','b')", Action: "rewritten as STRING with CHECK constraint imported_from_enum_e"},
+		{Object: "t.d", Clause: "DEFAULT '0000-00-00'", Action: "dropped"},
+		{Object: "t", Clause: "constraint fk_p foreign key (p) references t (id)", Action: "dropped because skip_foreign_keys was specified"},
+	}
+	if !reflect.DeepEqual(expected, report.notes) {
+		t.Fatalf("expected\n%+v\ngot\n%+v", expected, report.notes)
+	}
+}
+
 func compareTables(t *testing.T, expected, got *descpb.TableDescriptor) {
 	colNames := func(cols []descpb.ColumnDescriptor) string {
 		names := make([]string, len(cols))
@@ -218,7 +218,8 @@ func removeDefaultRegclass(create *tree.CreateTable) {
 }
 
 // readPostgresCreateTable returns table descriptors for all tables or the
-// matching table from SQL statements.
+// matching table from SQL statements. Clauses that are rewritten or dropped
+// along the way are recorded in report.
 func readPostgresCreateTable(
 	ctx context.Context,
 	input io.Reader,
@@ -230,6 +231,7 @@ func readPostgresCreateTable(
 	fks fkHandler,
 	max int,
 	owner security.SQLUsername,
+	report *conversionReport,
 ) ([]*tabledesc.Mutable, error) {
 	// Modify the CreateTable stmt with the various index additions. We do this
 	// instead of creating a full table descriptor first and adding indexes
@@ -311,7 +313,7 @@ func readPostgresCreateTable(
 		if err != nil {
 			return nil, errors.Wrap(err, "postgres parse error")
 		}
-		if err := readPostgresStmt(ctx, evalCtx, match, fks, createTbl, createSeq, tableFKs, stmt, p, parentID); err != nil {
+		if err := readPostgresStmt(ctx, evalCtx, match, fks, createTbl, createSeq, tableFKs, stmt, p, parentID, report); err != nil {
 			return nil, err
 		}
 	}
@@ -328,6 +330,7 @@ func readPostgresStmt(
 	stmt interface{},
 	p sql.JobExecContext,
 	parentID descpb.ID,
+	report *conversionReport,
 ) error {
 	switch stmt := stmt.(type) {
 	case *tree.CreateTable:
@@ -378,7 +381,9 @@ func readPostgresStmt(
 			case *tree.AlterTableAddConstraint:
 				switch con := cmd.ConstraintDef.(type) {
 				case *tree.ForeignKeyConstraintTableDef:
-					if !fks.skip {
+					if fks.skip {
+						report.add(name, strings.TrimSpace(tree.AsString(cmd)), "dropped because skip_foreign_keys was specified")
+					} else {
 						tableFKs[name] = append(tableFKs[name], con)
 					}
 				default:
@@ -424,9 +429,9 @@ func readPostgresStmt(
 					return colinfo.NewUndefinedColumnError(cmd.Column.String())
 				}
 			case *tree.AlterTableValidateConstraint:
-				// ignore
+				report.add(name, strings.TrimSpace(tree.AsString(cmd)), "ignored")
 			case *tree.AlterTableOwner:
-				// ignore
+				report.add(name, strings.TrimSpace(tree.AsString(cmd)), "ignored; imported tables are owned by the user running IMPORT")
 			default:
 				return errors.Errorf("unsupported statement: %s", stmt)
 			}
@@ -463,7 +468,10 @@ func readPostgresStmt(
 					fn := ov.SQLFn
 					if fn == nil {
 						switch f := expr.Func.String(); f {
-						case "set_config", "setval":
+						case "set_config":
+							report.add("", tree.AsString(expr), "ignored")
+							continue
+						case "setval":
 							continue
 						default:
 							return errors.Errorf("unsupported function call: %s", expr.Func.String())
@@ -494,7 +502,7 @@ func readPostgresStmt(
 					for _, fnStmt := range fnStmts {
 						switch ast := fnStmt.AST.(type) {
 						case *tree.AlterTable:
-							if err := readPostgresStmt(ctx, evalCtx, match, fks, createTbl, createSeq, tableFKs, ast, p, parentID); err != nil {
+							if err := readPostgresStmt(ctx, evalCtx, match, fks, createTbl, createSeq, tableFKs, ast, p, parentID, report); err != nil {
 								return err
 							}
 						default:
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 	"github.com/coreos/go-oidc"
 	"golang.org/x/oauth2"
 )
@@ -104,7 +105,8 @@ var (
 //    `server.oidc_authentication.claim_json_key`. The key is then passed through a regular
 //    expression to transform its value to a DB principal (this is to support the typical workflow
 //    of stripping a realm or domain name from an email address principal). The regular expression
-//    is set using the `server.oidc_authentication.principal_regex` cluster setting.
+//    is set using the `server.oidc_authentication.principal_regex` cluster setting. If the claim
+//    is a list of principals, the first one matched by the regular expression is used.
 //
 //    If the username we compute exists in the DB, we create a web session for them in the usual
 //    manner, bypassing any password validation requirements, and redirect them to `/` so they can
@@ -120,6 +122,10 @@ type oidcAuthenticationServer struct {
 	// to help us gracefully recover from auth provider downtime without operator intervention.
 	enabled     bool
 	initialized bool
+	// generation is incremented whenever the configuration is reloaded. The
+	// OIDC provider is initialized without holding the mutex, and the result
+	// is discarded if the configuration was reloaded in the meantime.
+	generation int64
 }
 
 type oidcAuthenticationConf struct {
@@ -139,23 +145,53 @@ type oidcAuthenticationConf struct {
 // configuration at run-time for embedding into the
 // Admin UI HTML in order to manage the login experience
 // the UI provides.
+//
+// Visitors are only redirected to the auth provider automatically once the
+// provider has been initialized successfully; until then, the Admin UI falls
+// back to password logins, and the login button can be used to retry.
 func (s *oidcAuthenticationServer) GetOIDCConf() ui.OIDCUIConf {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return ui.OIDCUIConf{
 		ButtonText: s.conf.buttonText,
 		Enabled:    s.enabled,
-		AutoLogin:  s.conf.autoLogin,
+		AutoLogin:  s.conf.autoLogin && s.initialized,
 	}
 }
 
-func reloadConfig(ctx context.Context, server *oidcAuthenticationServer, st *cluster.Settings) {
-	server.mutex.Lock()
-	defer server.mutex.Unlock()
-	reloadConfigLocked(ctx, server, st)
+// extractUsernameFromClaims returns the SQL username of the principal found in
+// the claims of an ID token under the given key. The claim is either a single
+// principal, or a list of principals in which case the first one matched by
+// principalRegex is used. The username is the first group of the match.
+func extractUsernameFromClaims(
+	claims map[string]json.RawMessage, claimKey string, principalRegex *regexp.Regexp,
+) (string, error) {
+	claim, ok := claims[claimKey]
+	if !ok {
+		return "", errors.Newf("claim key %s not found in ID token", claimKey)
+	}
+	var principals []string
+	var principal string
+	if err := json.Unmarshal(claim, &principal); err == nil {
+		principals = []string{principal}
+	} else if err := json.Unmarshal(claim, &principals); err != nil {
+		return "", errors.Wrapf(err, "failed to extract claim key %s", claimKey)
+	}
+
+	for _, principal := range principals {
+		match := principalRegex.FindStringSubmatch(principal)
+		if match == nil {
+			continue
+		}
+		if numGroups := len(match); numGroups != 2 {
+			return "", errors.Newf("expected one group in regexp, got %d", numGroups)
+		}
+		return match[1], nil
+	}
+	return "", errors.Newf("no principal in claim key %s matches the principal regexp", claimKey)
 }
 
-func reloadConfigLocked(
-	ctx context.Context, server *oidcAuthenticationServer, st *cluster.Settings,
-) {
+func reloadConfig(ctx context.Context, server *oidcAuthenticationServer, st *cluster.Settings) {
 	conf := oidcAuthenticationConf{
 		clientID:     OIDCClientID.Get(&st.SV),
 		clientSecret: OIDCClientSecret.Get(&st.SV),
@@ -170,48 +206,88 @@ func reloadConfigLocked(
 		autoLogin:      OIDCAutoLogin.Get(&st.SV),
 	}
 
+	server.mutex.Lock()
 	if !server.conf.enabled && conf.enabled {
 		telemetry.Inc(enableUseCounter)
 	}
-
-	server.initialized = false
 	server.conf = conf
-	if server.conf.enabled {
-		// `enabled` stores the configuration state and records the operator's _intent_ that the feature
-		// be enabled. Since the call to `NewProvider` below makes an HTTP request and could fail for
-		// many reasons, we record the successful configuration of a provider using the `initialized`
-		// flag which is set at the bottom of this function.
-		// If `enabled` is true and `initialized` is false, the HTTP handlers for OIDC will attempt
-		// to initialize the OIDC provider.
-		server.enabled = true
-	} else {
-		server.enabled = false
+	// `enabled` stores the configuration state and records the operator's _intent_ that the feature
+	// be enabled. Since the initialization of the provider makes an HTTP request and could fail for
+	// many reasons, we record the successful configuration of a provider using the `initialized`
+	// flag which is set by `initialize`.
+	// If `enabled` is true and `initialized` is false, the HTTP handlers for OIDC will attempt
+	// to initialize the OIDC provider.
+	server.enabled = conf.enabled
+	server.initialized = false
+	server.generation++
+	server.mutex.Unlock()
+
+	server.initialize(ctx)
+}
+
+// initialize initializes the OIDC provider of the current configuration if
+// OIDC is enabled. Looking up the provider makes an HTTP request to the auth
+// provider, so it is done without holding the mutex; the provider is then
+// swapped in unless the configuration was reloaded in the meantime.
+func (s *oidcAuthenticationServer) initialize(ctx context.Context) {
+	s.mutex.RLock()
+	conf, enabled, generation := s.conf, s.enabled, s.generation
+	s.mutex.RUnlock()
+	if !enabled {
 		return
 	}
 
-	provider, err := oidc.NewProvider(ctx, server.conf.providerURL)
+	provider, err := oidc.NewProvider(ctx, conf.providerURL)
 	if err != nil {
 		log.Warningf(ctx, "unable to initialize OIDC provider, disabling OIDC: %v", err)
 		return
 	}
 
 	// Validation of the scope setting will require that we have the `openid` scope
-	scopesForOauth := strings.Split(server.conf.scopes, " ")
+	scopesForOauth := strings.Split(conf.scopes, " ")
 
-	server.oauth2Config = oauth2.Config{
-		ClientID:     server.conf.clientID,
-		ClientSecret: server.conf.clientSecret,
-		RedirectURL:  server.conf.redirectURL,
+	oauth2Config := oauth2.Config{
+		ClientID:     conf.clientID,
+		ClientSecret: conf.clientSecret,
+		RedirectURL:  conf.redirectURL,
 
 		Endpoint: provider.Endpoint(),
 		Scopes:   scopesForOauth,
 	}
+	verifier := provider.Verifier(&oidc.Config{ClientID: conf.clientID})
 
-	server.verifier = provider.Verifier(&oidc.Config{ClientID: server.conf.clientID})
-	server.initialized = true
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.generation != generation {
+		// The configuration was reloaded, which initializes the provider of the
+		// new configuration.
+		return
+	}
+	s.oauth2Config = oauth2Config
+	s.verifier = verifier
+	s.initialized = true
 	log.Infof(ctx, "initialized oidc server")
 }
 
+// state returns the configuration of the server for use by the HTTP handlers,
+// which must not hold the mutex while talking to the auth provider. If OIDC is
+// enabled but the provider isn't initialized yet, its initialization is
+// attempted first.
+func (s *oidcAuthenticationServer) state(
+	ctx context.Context,
+) (conf oidcAuthenticationConf, oauth2Config oauth2.Config, verifier *oidc.IDTokenVerifier) {
+	s.mutex.RLock()
+	needsInit := s.enabled && !s.initialized
+	s.mutex.RUnlock()
+	if needsInit {
+		s.initialize(ctx)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.conf, s.oauth2Config, s.verifier
+}
+
 // ConfigureOIDC attaches handlers to the server `mux` that
 // can initiate and complete an OIDC authentication flow.
 // This flow consists of an initial login request that triggers
@@ -235,14 +311,9 @@ var ConfigureOIDC = func(
 		ctx := r.Context()
 
 		// Verify state and errors.
-		oidcAuthentication.mutex.Lock()
-		defer oidcAuthentication.mutex.Unlock()
-
-		if oidcAuthentication.enabled && !oidcAuthentication.initialized {
-			reloadConfigLocked(ctx, oidcAuthentication, st)
-		}
+		conf, oauth2Config, verifier := oidcAuthentication.state(ctx)
 
-		if !oidcAuthentication.enabled {
+		if !conf.enabled {
 			http.Error(w, "OIDC: disabled", http.StatusBadRequest)
 			return
 		}
@@ -277,7 +348,7 @@ var ConfigureOIDC = func(
 			return
 		}
 
-		oauth2Token, err := oidcAuthentication.oauth2Config.Exchange(ctx, r.URL.Query().Get(codeKey))
+		oauth2Token, err := oauth2Config.Exchange(ctx, r.URL.Query().Get(codeKey))
 		if err != nil {
 			log.Errorf(ctx, "OIDC: failed to exchange code for token: %v", err)
 			http.Error(w, genericCallbackHTTPError, http.StatusInternalServerError)
@@ -291,7 +362,7 @@ var ConfigureOIDC = func(
 			return
 		}
 
-		idToken, err := oidcAuthentication.verifier.Verify(ctx, rawIDToken)
+		idToken, err := verifier.Verify(ctx, rawIDToken)
 		if err != nil {
 			log.Errorf(ctx, "OIDC: unable to verify ID token: %v", err)
 			http.Error(w, genericCallbackHTTPError, http.StatusInternalServerError)
@@ -305,23 +376,15 @@ var ConfigureOIDC = func(
 			return
 		}
 
-		var principal string
-		claim := claims[oidcAuthentication.conf.claimJSONKey]
-		if err := json.Unmarshal(claim, &principal); err != nil {
-			log.Errorf(ctx, "OIDC: failed to complete authentication: failed to extract claim key %s: %v", oidcAuthentication.conf.claimJSONKey, err)
-			http.Error(w, genericCallbackHTTPError, http.StatusInternalServerError)
-			return
-		}
-
-		match := oidcAuthentication.conf.principalRegex.FindStringSubmatch(principal)
-		numGroups := len(match)
-		if numGroups != 2 {
-			log.Errorf(ctx, "OIDC: failed to complete authentication: expected one group in regexp, got %d", numGroups)
+		username, err := extractUsernameFromClaims(
+			claims, conf.claimJSONKey, conf.principalRegex,
+		)
+		if err != nil {
+			log.Errorf(ctx, "OIDC: failed to complete authentication: %v", err)
 			http.Error(w, genericCallbackHTTPError, http.StatusInternalServerError)
 			return
 		}
 
-		username := match[1]
 		cookie, err := userLoginFromSSO(ctx, username)
 		if err != nil {
 			log.Errorf(ctx, "OIDC: failed to complete authentication: unable to create session for %s: %v", username, err)
@@ -344,14 +407,9 @@ var ConfigureOIDC = func(
 	mux.HandleFunc(oidcLoginPath, func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		oidcAuthentication.mutex.Lock()
-		defer oidcAuthentication.mutex.Unlock()
-
-		if oidcAuthentication.enabled && !oidcAuthentication.initialized {
-			reloadConfigLocked(ctx, oidcAuthentication, st)
-		}
+		conf, oauth2Config, _ := oidcAuthentication.state(ctx)
 
-		if !oidcAuthentication.enabled {
+		if !conf.enabled {
 			http.Error(w, "OIDC: disabled", http.StatusBadRequest)
 			return
 		}
@@ -367,7 +425,7 @@ var ConfigureOIDC = func(
 
 		http.SetCookie(w, kast.secretKeyCookie)
 		http.Redirect(
-			w, r, oidcAuthentication.oauth2Config.AuthCodeURL(kast.signedTokenEncoded), http.StatusFound,
+			w, r, oauth2Config.AuthCodeURL(kast.signedTokenEncoded), http.StatusFound,
 		)
 	})
 
@@ -13,11 +13,13 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -287,3 +289,107 @@ func TestOIDCStateEncodeDecode(t *testing.T) {
 		t.Fatal("state didn't match when decoded")
 	}
 }
+
+func TestOIDCExtractUsernameFromClaims(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		desc           string
+		claims         string
+		principalRegex string
+		expected       string
+		expectedErr    string
+	}{
+		{"string claim", `{"email": "alice@example.com"}`, `^([^@]+)@example\.com$`, "alice", ""},
+		{"list claim", `{"email": ["alice@other.com", "bob@example.com"]}`, `^([^@]+)@example\.com$`, "bob", ""},
+		{"first match of list claim", `{"email": ["carl@example.com", "bob@example.com"]}`, `^([^@]+)@example\.com$`, "carl", ""},
+		{"missing claim", `{"sub": "alice"}`, `(.+)`, "", "claim key email not found"},
+		{"invalid claim", `{"email": 123}`, `(.+)`, "", "failed to extract claim key email"},
+		{"no match", `{"email": ["alice@other.com"]}`, `^([^@]+)@example\.com$`, "", "no principal in claim key email"},
+		{"no group", `{"email": "alice@example.com"}`, `^[^@]+@example\.com$`, "", "expected one group in regexp, got 1"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var claims map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal([]byte(tc.claims), &claims))
+			username, err := extractUsernameFromClaims(claims, "email", regexp.MustCompile(tc.principalRegex))
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, username)
+		})
+	}
+}
+
+// TestOIDCAutoLoginFallback checks that the Admin UI doesn't redirect
+// visitors to an auth provider which couldn't be initialized.
+func TestOIDCAutoLoginFallback(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s := &oidcAuthenticationServer{
+		conf:    oidcAuthenticationConf{enabled: true, autoLogin: true, buttonText: "Login"},
+		enabled: true,
+	}
+	conf := s.GetOIDCConf()
+	require.True(t, conf.Enabled)
+	require.False(t, conf.AutoLogin)
+
+	s.initialized = true
+	require.True(t, s.GetOIDCConf().AutoLogin)
+}
+
+// TestOIDCInitializeWithoutLock checks that the configuration of the server
+// can be read while the auth provider is being contacted, and that a provider
+// initialized for a configuration which was reloaded in the meantime is
+// discarded.
+func TestOIDCInitializeWithoutLock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	requested := make(chan struct{}, 2)
+	unblock := make(chan struct{})
+	var issuer string
+	testOIDCServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested <- struct{}{}
+		<-unblock
+		w.Header().Set("content-type", "application/json")
+		_, _ = fmt.Fprintf(w, `{
+ "issuer": %q,
+ "authorization_endpoint": "https://accounts.cockroachlabs.com/o/oauth2/v2/auth",
+ "token_endpoint": "https://oauth2.cockroachlabsapis.com/token",
+ "jwks_uri": "https://www.cockroachlabsapis.com/oauth2/v3/certs"
+}`, issuer)
+	}))
+	defer testOIDCServer.Close()
+	issuer = testOIDCServer.URL
+
+	s := &oidcAuthenticationServer{
+		conf: oidcAuthenticationConf{
+			enabled: true, autoLogin: true, providerURL: issuer, scopes: "openid",
+		},
+		enabled: true,
+	}
+	initialize := func() chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.initialize(context.Background())
+		}()
+		return done
+	}
+
+	done := initialize()
+	<-requested
+	require.False(t, s.GetOIDCConf().AutoLogin)
+	// Reload the configuration while the auth provider is being contacted.
+	s.mutex.Lock()
+	s.generation++
+	s.mutex.Unlock()
+	close(unblock)
+	<-done
+	require.False(t, s.GetOIDCConf().AutoLogin)
+
+	<-initialize()
+	require.True(t, s.GetOIDCConf().AutoLogin)
+}
@@ -128,7 +128,8 @@ var OIDCClaimJSONKey = func() *settings.StringSetting {
 	s := settings.RegisterStringSetting(
 		OIDCClaimJSONKeySettingName,
 		"sets JSON key of principal to extract from payload after OIDC authentication completes "+
-			"(usually email or sid) (this feature is experimental)",
+			"(usually email or sid; if the claim is a list, the first principal matched by "+
+			"principal_regex is used) (this feature is experimental)",
 		"",
 	).WithPublic()
 	return s
@@ -0,0 +1,27 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package sqlproxyccl
+
+import "github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+
+// ClusterNameFromParams returns the cluster name specified by the client with
+// the --cluster option of the options connection parameter, or an empty
+// string if the client didn't specify one. This allows routing connections
+// to a tenant without relying on the SNI header.
+func ClusterNameFromParams(params map[string]string) (string, error) {
+	options, ok := params["options"]
+	if !ok {
+		return "", nil
+	}
+	opts, err := pgwirebase.ParseOptions(options)
+	if err != nil {
+		return "", NewErrorf(CodeParamsRoutingFailed, "invalid options parameter: %v", err)
+	}
+	return opts[pgwirebase.ClusterNameOption], nil
+}
@@ -570,3 +570,73 @@ func TestProxyAgainstSecureCRDBWithIdleTimeout(t *testing.T) {
 	err = conn.QueryRow(context.Background(), "SELECT $1::int", 1).Scan(&n)
 	require.EqualError(t, err, "FATAL: terminating connection due to idle timeout (SQLSTATE 57P01)")
 }
+
+func TestClusterNameFromParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	name, err := ClusterNameFromParams(map[string]string{"user": "root"})
+	require.NoError(t, err)
+	require.Equal(t, "", name)
+
+	name, err = ClusterNameFromParams(map[string]string{
+		"options": "--cluster=tenant-cluster-28 -c search_path=public",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-cluster-28", name)
+
+	_, err = ClusterNameFromParams(map[string]string{"options": "cluster=foo"})
+	require.Error(t, err)
+	var codeErr *CodeError
+	require.True(t, errors.As(err, &codeErr))
+	require.Equal(t, CodeParamsRoutingFailed, codeErr.code)
+}
+
+func TestProxyRoutesByClusterName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	tc := serverutils.StartNewTestCluster(t, 1, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	outgoingTLSConfig, err := tc.Server(0).RPCContext().GetClientTLSConfig()
+	require.NoError(t, err)
+	outgoingTLSConfig.InsecureSkipVerify = true
+
+	ac := makeAssertCtx()
+	opts := Options{
+		BackendDialer: func(msg *pgproto3.StartupMessage) (net.Conn, error) {
+			clusterName, err := ClusterNameFromParams(msg.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			if clusterName != "tenant-cluster-28" {
+				return nil, NewErrorf(CodeParamsRoutingFailed, "unknown cluster %q", clusterName)
+			}
+			return BackendDial(
+				msg, tc.Server(0).ServingSQLAddr(), outgoingTLSConfig,
+			)
+		},
+		OnSendErrToClient: ac.onSendErrToClient,
+	}
+	s, addr, done := setupTestProxyWithCerts(t, &opts)
+	defer done()
+
+	prefix := fmt.Sprintf("postgres://root:admin@%s/defaultdb?sslmode=require&options=", addr)
+	conn, err := pgx.Connect(ctx, prefix+"--cluster=tenant-cluster-28")
+	require.NoError(t, err)
+	var n int
+	require.NoError(t, conn.QueryRow(ctx, "SELECT $1::int", 1).Scan(&n))
+	require.EqualValues(t, 1, n)
+	require.NoError(t, conn.Close(ctx))
+	require.Equal(t, int64(1), s.metrics.SuccessfulConnCount.Count())
+
+	ac.assertConnectErr(
+		t, prefix, "--cluster=tenant-cluster-29",
+		CodeParamsRoutingFailed, `unknown cluster "tenant-cluster-29"`,
+	)
+	ac.assertConnectErr(
+		t, prefix, "cluster=tenant-cluster-28",
+		CodeParamsRoutingFailed, "invalid options parameter",
+	)
+	require.Equal(t, int64(2), s.metrics.RoutingErrCount.Count())
+}
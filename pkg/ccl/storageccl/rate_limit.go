@@ -0,0 +1,87 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package storageccl
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+)
+
+// BackupMaxRate limits the rate at which each node exports data for a backup.
+var BackupMaxRate = settings.RegisterByteSizeSetting(
+	"bulkio.backup.max_rate",
+	"the rate limit (bytes/sec) at which each node exports data during BACKUP; 0 for no limit",
+	0,
+	settings.NonNegativeInt,
+)
+
+// ImportMaxRate limits the rate at which each node ingests data for an import.
+var ImportMaxRate = settings.RegisterByteSizeSetting(
+	"bulkio.import.max_rate",
+	"the rate limit (bytes/sec) at which each node ingests data during IMPORT; 0 for no limit",
+	0,
+	settings.NonNegativeInt,
+)
+
+// SettingRateLimiter is a byte rate limiter whose rate is controlled by a
+// cluster setting. The setting is re-read on every call to WaitN so that
+// changes take effect on jobs that are already running.
+type SettingRateLimiter struct {
+	setting *settings.ByteSizeSetting
+	sv      *settings.Values
+
+	mu struct {
+		sync.Mutex
+		rate    int64
+		limiter *quotapool.RateLimiter
+	}
+}
+
+// NewSettingRateLimiter returns a SettingRateLimiter whose rate is read from
+// the given setting.
+func NewSettingRateLimiter(
+	name string, setting *settings.ByteSizeSetting, sv *settings.Values,
+) *SettingRateLimiter {
+	l := &SettingRateLimiter{setting: setting, sv: sv}
+	l.mu.rate = setting.Get(sv)
+	// The burst is one second worth of data. A rate of zero means unlimited,
+	// which WaitN handles without consulting the limiter.
+	l.mu.limiter = quotapool.NewRateLimiter(name, quotapool.Limit(l.mu.rate), l.mu.rate)
+	return l
+}
+
+// WaitN blocks until n bytes may be processed under the current rate limit.
+// Requests larger than a second's worth of data are admitted once the limiter
+// is full and put it into debt, so callers may pass whole batches.
+func (l *SettingRateLimiter) WaitN(ctx context.Context, n int64) error {
+	limiter := l.update()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.WaitN(ctx, n)
+}
+
+// update picks up changes to the setting and returns the limiter to use, or
+// nil if throttling is disabled.
+func (l *SettingRateLimiter) update() *quotapool.RateLimiter {
+	rate := l.setting.Get(l.sv)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rate != l.mu.rate {
+		l.mu.rate = rate
+		l.mu.limiter.UpdateLimit(quotapool.Limit(rate), rate)
+	}
+	if rate == 0 {
+		return nil
+	}
+	return l.mu.limiter
+}
@@ -0,0 +1,43 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package storageccl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingRateLimiter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	l := NewSettingRateLimiter("test", ImportMaxRate, &st.SV)
+
+	// The default of 0 disables throttling entirely.
+	require.NoError(t, l.WaitN(ctx, 1<<40))
+
+	// Lowering the rate affects a limiter that already exists. The first second
+	// worth of data is admitted immediately, which puts the limiter in debt so
+	// the next request has to wait well beyond the timeout.
+	ImportMaxRate.Override(&st.SV, 1<<10)
+	require.NoError(t, l.WaitN(ctx, 1<<20))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, l.WaitN(timeoutCtx, 1))
+
+	// Setting it back to 0 lifts the limit again.
+	ImportMaxRate.Override(&st.SV, 0)
+	require.NoError(t, l.WaitN(ctx, 1<<20))
+}
@@ -93,8 +93,18 @@ func createAuthSessionToken(username string) (sessionID int64, httpCookie *http.
 	}
 	defer sqlConn.Close()
 
-	// First things first. Does the user exist?
-	_, rows, err := runQuery(sqlConn,
+	// Only admins can create sessions. The session table is not writable by
+	// other users anyway; check upfront to report a clearer error.
+	_, rows, err := runQuery(sqlConn, makeQuery(`SELECT crdb_internal.is_admin()`), false)
+	if err != nil {
+		return -1, nil, err
+	}
+	if rows[0][0] != "true" {
+		return -1, nil, errors.New("only users with the admin role are allowed to create HTTP sessions")
+	}
+
+	// Does the user exist?
+	_, rows, err = runQuery(sqlConn,
 		makeQuery(`SELECT count(username) FROM system.users WHERE username = $1 AND NOT "isRole"`, username), false)
 	if err != nil {
 		return -1, nil, err
@@ -28,12 +28,13 @@ import (
 	_ "github.com/cockroachdb/cockroach/pkg/workload/bank"       // registers workloads
 	_ "github.com/cockroachdb/cockroach/pkg/workload/bulkingest" // registers workloads
 	workloadcli "github.com/cockroachdb/cockroach/pkg/workload/cli"
-	_ "github.com/cockroachdb/cockroach/pkg/workload/examples" // registers workloads
-	_ "github.com/cockroachdb/cockroach/pkg/workload/kv"       // registers workloads
-	_ "github.com/cockroachdb/cockroach/pkg/workload/movr"     // registers workloads
-	_ "github.com/cockroachdb/cockroach/pkg/workload/tpcc"     // registers workloads
-	_ "github.com/cockroachdb/cockroach/pkg/workload/tpch"     // registers workloads
-	_ "github.com/cockroachdb/cockroach/pkg/workload/ycsb"     // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/examples"   // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/geospatial" // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/kv"         // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/movr"       // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/tpcc"       // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/tpch"       // registers workloads
+	_ "github.com/cockroachdb/cockroach/pkg/workload/ycsb"       // registers workloads
 	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 )
@@ -59,18 +60,20 @@ func Main() {
 	err := doMain(cmd, cmdName)
 	errCode := exit.Success()
 	if err != nil {
-		// Display the error and its details/hints.
-		cliOutputError(stderr, err, true /*showSeverity*/, false /*verbose*/)
-
-		// Remind the user of which command was being run.
-		fmt.Fprintf(stderr, "Failed running %q\n", cmdName)
-
-		// Finally, extract the error code, as optionally specified
-		// by the sub-command.
-		errCode = exit.UnspecifiedError()
-		var cliErr *cliError
-		if errors.As(err, &cliErr) {
-			errCode = cliErr.exitCode
+		// Extract the error code, as optionally specified by the
+		// sub-command, or derived from the class of the error.
+		errCode = errorExitCode(err)
+
+		if cliCtx.tableDisplayFormat == tableDisplayJSON {
+			// Display the error as a JSON object, so that automation can
+			// branch on its class without parsing the text.
+			cliOutputErrorJSON(stderr, err, cmdName, errCode)
+		} else {
+			// Display the error and its details/hints.
+			cliOutputError(stderr, err, true /*showSeverity*/, false /*verbose*/)
+
+			// Remind the user of which command was being run.
+			fmt.Fprintf(stderr, "Failed running %q\n", cmdName)
 		}
 	}
 
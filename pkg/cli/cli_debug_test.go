@@ -68,3 +68,50 @@ func TestDebugKeysHex(t *testing.T) {
 		t.Fatalf("%q", out)
 	}
 }
+
+func TestDebugDiagnosticsReport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	c := newCLITest(cliTestParams{t: t})
+	defer c.cleanup()
+
+	out, err := c.RunWithCapture("debug diagnostics-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "use --dry-run") {
+		t.Fatalf("expected error about --dry-run, got %q", out)
+	}
+
+	out, err = c.RunWithCapture("debug diagnostics-report --dry-run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"Reporting URL: ", "nodeid=1", `"node": {`, `"build": {`} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, out)
+		}
+	}
+}
+
+func TestDebugFuzzSQL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	c := newCLITest(cliTestParams{t: t})
+	defer c.cleanup()
+
+	out, err := c.RunWithCapture("debug fuzz-sql --seed 1 --num-statements 10 --setup seed --setting no-ddl --verbose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"seed: 1\n", "CREATE TABLE IF NOT EXISTS seed AS"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "ERROR") {
+		t.Errorf("unexpected error in output:\n%s", out)
+	}
+}
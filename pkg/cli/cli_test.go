@@ -620,6 +620,41 @@ func Example_sql_watch() {
 	// SQLSTATE: 22012
 }
 
+func Example_sql_retries() {
+	c := newCLITest(cliTestParams{})
+	defer c.cleanup()
+
+	c.RunWithArgs([]string{`sql`, `-e`, `create sequence s; create sequence t`})
+	c.RunWithArgs([]string{`sql`, `--retries`, `3`, `-e`,
+		`select if(nextval('s') < 3, crdb_internal.force_error('40001', 'boom'), 0) as x`})
+	c.RunWithArgs([]string{`sql`, `--retries`, `1`, `-e`,
+		`select if(nextval('t') < 3, crdb_internal.force_error('40001', 'boom'), 0) as x`})
+	c.RunWithArgs([]string{`sql`, `--retries`, `3`, `-e`,
+		`select crdb_internal.force_error('22012', 'not transient')`})
+
+	// Output:
+	// sql -e create sequence s; create sequence t
+	// CREATE SEQUENCE
+	// sql --retries 3 -e select if(nextval('s') < 3, crdb_internal.force_error('40001', 'boom'), 0) as x
+	// ERROR: boom
+	// SQLSTATE: 40001
+	// warning: retrying statement (retry 1 of 3)
+	// ERROR: boom
+	// SQLSTATE: 40001
+	// warning: retrying statement (retry 2 of 3)
+	// x
+	// 0
+	// sql --retries 1 -e select if(nextval('t') < 3, crdb_internal.force_error('40001', 'boom'), 0) as x
+	// ERROR: boom
+	// SQLSTATE: 40001
+	// warning: retrying statement (retry 1 of 1)
+	// ERROR: boom
+	// SQLSTATE: 40001
+	// sql --retries 3 -e select crdb_internal.force_error('22012', 'not transient')
+	// ERROR: not transient
+	// SQLSTATE: 22012
+}
+
 func Example_sql_format() {
 	c := newCLITest(cliTestParams{})
 	defer c.cleanup()
@@ -792,6 +827,8 @@ thenshort`,
 	// ## 1
 	// 0
 	// # 1 row
+	// sql --format=json -e select * from t.u
+	// {"f\"oo":"0","f'oo":"0","f\\oo":"0","short\nvery very long\nnot much":"0","very very long\nthenshort":"0","κόσμε":"0","a|b":"0","܈85":"0"}
 }
 
 func Example_sql_empty_table() {
@@ -834,6 +871,7 @@ func Example_sql_empty_table() {
 	// sql --format=raw -e select * from t.norows
 	// # 1 column
 	// # 0 rows
+	// sql --format=json -e select * from t.norows
 	// sql --format=tsv -e select * from t.nocols
 	// # no columns
 	// # empty
@@ -872,6 +910,10 @@ func Example_sql_empty_table() {
 	// # row 2
 	// # row 3
 	// # 3 rows
+	// sql --format=json -e select * from t.nocols
+	// {}
+	// {}
+	// {}
 	// sql --format=tsv -e select * from t.nocolsnorows
 	// # no columns
 	// sql --format=csv -e select * from t.nocolsnorows
@@ -894,6 +936,7 @@ func Example_sql_empty_table() {
 	// sql --format=raw -e select * from t.nocolsnorows
 	// # 0 columns
 	// # 0 rows
+	// sql --format=json -e select * from t.nocolsnorows
 }
 
 func Example_csv_tsv_quoting() {
@@ -1935,8 +1978,10 @@ func TestWorkload(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if !strings.Contains(out, `startrek`) {
-		t.Fatalf(`startrek workload failed to register got: %s`, out)
+	for _, name := range []string{`startrek`, `geospatial`} {
+		if !strings.Contains(out, name) {
+			t.Fatalf(`%s workload failed to register got: %s`, name, out)
+		}
 	}
 }
 
@@ -2117,3 +2162,23 @@ func Example_includes() {
 	// \i: too many recursion levels (max 10)
 	// ERROR: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: testdata/i_maxrecursion.sql: \i: too many recursion levels (max 10)
 }
+
+// Example_sql_copy tests the \copy command.
+func Example_sql_copy() {
+	c := newCLITest(cliTestParams{})
+	defer c.cleanup()
+
+	c.RunWithArgs([]string{"sql", "-f", "testdata/copy.sql"})
+
+	// Output:
+	// sql -f testdata/copy.sql
+	// CREATE TABLE
+	// COPY 3
+	// COPY 2
+	// id	s	f
+	// 1	a,b	1.5
+	// 2	NULL	2.5
+	// 3	c d	NULL
+	// 4	d	NULL
+	// 5	e	NULL
+}
@@ -275,6 +275,18 @@ with the specified period. The client will stop watching
 if an execution of the SQL statement(s) fail.`,
 	}
 
+	SQLRetries = FlagInfo{
+		Name: "retries",
+		Description: `
+Retry the SQL statement(s) specified with --execute or read with --file
+up to the specified number of times if they fail with a transient error:
+a transaction retry error (SQLSTATE 40001) or a lost connection. A
+statement is only retried if no transaction was open when it started.
+The statements are re-executed from the beginning, so this flag must
+only be used with statements that are safe to execute more than once.
+The flag is ignored in interactive sessions.`,
+	}
+
 	EchoSQL = FlagInfo{
 		Name: "echo-sql",
 		Description: `
@@ -312,8 +324,11 @@ shell. This flag may be specified multiple times.`,
 		Name: "format",
 		Description: `
 Selects how to display table rows in results. Possible values: tsv,
-csv, table, records, sql, raw, html. If left unspecified, defaults to
-tsv for non-interactive sessions and table for interactive sessions.`,
+csv, table, records, sql, raw, html, json. If left unspecified, defaults
+to tsv for non-interactive sessions and table for interactive sessions.
+With json, each row is printed as a JSON object on its own line, and
+errors are printed on stderr as a JSON object with the class of the
+error and the exit code of the command.`,
 	}
 
 	ClusterName = FlagInfo{
@@ -789,7 +804,9 @@ strings separated by colons, for example:
 
 </PRE>
 The store size in the "size" field is not a guaranteed maximum but is used when
-calculating free space for rebalancing purposes. The size can be specified
+calculating free space for rebalancing purposes. As a store approaches its size,
+replicas are moved off it, and once nearly full it rejects writes to user data
+until space is freed. The size can be specified
 either in a bytes-based unit or as a percentage of hard drive space,
 for example:
 <PRE>
@@ -802,6 +819,17 @@ for example:
   --store=path=/mnt/ssd01,size=0.2             -> 20% of available space
   --store=path=/mnt/ssd01,size=.2              -> 20% of available space
 
+</PRE>
+Each on-disk store reserves an emergency ballast file in its auxiliary
+directory, which is removed when the node is restarted with a nearly full disk
+so that it can start up and free space. The "ballast-size" field configures
+its size in bytes or as a percentage of the disk, and defaults to 1% of the disk
+up to 1GiB. A size of 0 disables the ballast, for example:
+<PRE>
+
+  --store=path=/mnt/ssd01,ballast-size=5GiB
+  --store=path=/mnt/ssd01,ballast-size=0
+
 </PRE>
 For an in-memory store, the "type" and "size" fields are required, and the
 "path" field is forbidden. The "type" field must be set to "mem", and the
@@ -959,12 +987,98 @@ If specified, print the system config contents. Beware that the output will be
 long and not particularly human-readable.`,
 	}
 
+	DiagnosticsReportDryRun = FlagInfo{
+		Name: "dry-run",
+		Description: `
+Print the diagnostics report instead of sending it.`,
+	}
+
+	FuzzSQLSeed = FlagInfo{
+		Name: "seed",
+		Description: `
+Seed of the random generation of tables and statements. A random seed is used
+if not specified.`,
+	}
+
+	FuzzSQLNumStatements = FlagInfo{
+		Name: "num-statements",
+		Description: `
+Number of random statements to execute.`,
+	}
+
+	FuzzSQLSetup = FlagInfo{
+		Name: "setup",
+		Description: `
+Name of the setup used to create the initial tables: one of empty, rand-tables,
+seed or seed-vec.`,
+	}
+
+	FuzzSQLSetting = FlagInfo{
+		Name: "setting",
+		Description: `
+Name of the setting which determines the kinds of statements generated, such as
+default, no-ddl or ddl-nodrop.`,
+	}
+
+	FuzzSQLVerbose = FlagInfo{
+		Name: "verbose",
+		Description: `
+Print every statement before it is executed.`,
+	}
+
 	DecodeAsTable = FlagInfo{
 		Name: "decode-as-table",
 		Description: `
 Base64-encoded Descriptor to use as the table when decoding KVs.`,
 	}
 
+	DecodeSQLRows = FlagInfo{
+		Name: "decode-sql-rows",
+		Description: `
+Decode the values of table keys into column name=value pairs, using the table
+descriptors found in the store being inspected.`,
+	}
+
+	DescriptorsFile = FlagInfo{
+		Name: "descriptors",
+		Description: `
+File containing the table descriptors to use when decoding the values of table
+keys into column name=value pairs, in the format of the system.descriptor.txt
+file of a debug zip.`,
+	}
+
+	TSDumpFormat = FlagInfo{
+		Name: "format",
+		Description: `
+Selects the output format of the dumped time series. Possible values: text,
+csv, tsv, openmetrics. The openmetrics format can be ingested into Prometheus
+with promtool, for example with "promtool tsdb create-blocks-from openmetrics".`,
+	}
+
+	TSDumpMetrics = FlagInfo{
+		Name: "metrics",
+		Description: `
+Comma-separated list of the names of the metrics to dump, for example
+cr.node.sql.query.count,cr.store.livebytes. The default is to dump all
+metrics.`,
+	}
+
+	TSDumpFrom = FlagInfo{
+		Name: "from",
+		Description: `
+Only dump the datapoints recorded at or after this time, in RFC3339 format
+(for example 2021-01-02T15:04:05Z). The default is to dump from the oldest
+datapoint.`,
+	}
+
+	TSDumpTo = FlagInfo{
+		Name: "to",
+		Description: `
+Only dump the datapoints recorded before this time, in RFC3339 format
+(for example 2021-01-02T15:04:05Z). The default is to dump up to the newest
+datapoint.`,
+	}
+
 	DrainWait = FlagInfo{
 		Name: "drain-wait",
 		Description: `
@@ -1217,21 +1331,69 @@ list of node IDs or ranges of node IDs, for example: 5,10-20,23.
 The default is to not exclude any node.`,
 	}
 
+	ZipIncludeTables = FlagInfo{
+		Name: "include",
+		Description: `
+List of tables to retrieve with SQL. Can be specified as a comma-delimited
+list of glob patterns matched against the qualified table names, for
+example: crdb_internal.jobs,system.job*. The default is to retrieve all
+tables. The tables which are skipped are recorded in the manifest of the
+zip file.`,
+	}
+
+	ZipExcludeTables = FlagInfo{
+		Name: "exclude",
+		Description: `
+List of tables not to retrieve with SQL, for example
+crdb_internal.node_metrics. Uses the same syntax as --include, and takes
+precedence over it. The default is to not exclude any table.`,
+	}
+
 	ZipRedactLogs = FlagInfo{
 		Name: "redact-logs",
 		Description: `
 Redact text that may contain confidential data or PII from retrieved
 log entries. Note that this flag only operates on log entries;
 other items retrieved by the zip command may still consider
-confidential data or PII.
+confidential data or PII. Use --redact to also redact them.
+`,
+	}
+
+	ZipRedact = FlagInfo{
+		Name: "redact",
+		Description: `
+Redact user data from all the retrieved items while preserving their
+structure: constants are removed from SQL statements, log entries are
+redacted as with --redact-logs, and the indexed values in range keys are
+replaced by a hash. The table descriptors, which contain column
+expressions and partitioning values, are omitted; the names of the schema
+objects are retained.
 `,
 	}
 
-	ZipCPUProfileDuration = FlagInfo{
-		Name: "cpu-profile-duration",
+	ZipProfileDuration = FlagInfo{
+		Name: "profile-duration",
+		Description: `
+Fetch CPU and mutex profiles from the live nodes of the cluster with the
+specified sample duration. The mutex profile only reports the contention
+observed during the sample duration. The zip command will block for the
+duration specified. Zero disables this feature. Heap profiles and goroutine
+dumps are collected regardless of this setting.
+`,
+	}
+
+	DeprecatedZipCPUProfileDuration = FlagInfo{
+		Name:        "cpu-profile-duration",
+		Description: `Deprecated in favor of --profile-duration.`,
+	}
+
+	ZipConcurrency = FlagInfo{
+		Name: "concurrency",
 		Description: `
-Fetch CPU profiles from the cluster with the specified sample duration.
-The zip command will block for the duration specified. Zero disables this feature.
+The number of nodes whose details are retrieved in parallel. The data of each
+node is buffered in a temporary file until it is written to the zip file in the
+order of the nodes, so the contents of the zip file do not depend on this
+setting. A value of 1 retrieves the nodes one at a time without buffering.
 `,
 	}
 
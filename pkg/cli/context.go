@@ -43,6 +43,8 @@ func initCLIDefaults() {
 	setZipContextDefaults()
 	setDumpContextDefaults()
 	setDebugContextDefaults()
+	setTSDumpContextDefaults()
+	setFuzzSQLContextDefaults()
 	setStartContextDefaults()
 	setQuitContextDefaults()
 	setNodeContextDefaults()
@@ -244,6 +246,11 @@ var sqlCtx = struct {
 	// the watch.
 	repeatDelay time.Duration
 
+	// retries is the number of times a statement executed with
+	// --execute or --file is retried when it fails with a transient
+	// error. Zero disables retries.
+	retries int
+
 	// safeUpdates indicates whether to set sql_safe_updates in the CLI
 	// shell.
 	safeUpdates bool
@@ -288,6 +295,7 @@ func setSQLContextDefaults() {
 	sqlCtx.execStmts = nil
 	sqlCtx.inputFile = ""
 	sqlCtx.repeatDelay = 0
+	sqlCtx.retries = 0
 	sqlCtx.safeUpdates = false
 	sqlCtx.showTimes = false
 	sqlCtx.debugMode = false
@@ -305,12 +313,24 @@ func setSQLContextDefaults() {
 var zipCtx struct {
 	nodes nodeSelection
 
+	// tables selects the tables dumped with SQL.
+	tables tableSelection
+
 	// redactLogs indicates whether log files should be redacted
 	// server-side during retrieval.
 	redactLogs bool
 
-	// Duration (in seconds) to run CPU profile for.
-	cpuProfDuration time.Duration
+	// redact indicates whether user data should be redacted from all the
+	// retrieved data, not only from log files.
+	redact bool
+
+	// profDuration is the duration of the CPU and mutex profiles
+	// collected from each node. Zero disables the profiles.
+	profDuration time.Duration
+
+	// concurrency is the number of nodes whose data is retrieved in
+	// parallel.
+	concurrency int
 }
 
 // setZipContextDefaults set the default values in zipCtx.  This
@@ -318,8 +338,11 @@ var zipCtx struct {
 // test that exercises command-line parsing.
 func setZipContextDefaults() {
 	zipCtx.nodes = nodeSelection{}
+	zipCtx.tables = tableSelection{}
 	zipCtx.redactLogs = false
-	zipCtx.cpuProfDuration = 5 * time.Second
+	zipCtx.redact = false
+	zipCtx.profDuration = 5 * time.Second
+	zipCtx.concurrency = 15
 }
 
 // dumpCtx captures the command-line parameters of the `dump` command.
@@ -371,6 +394,9 @@ var debugCtx struct {
 	printSystemConfig bool
 	maxResults        int
 	decodeAsTableDesc string
+	decodeSQLRows     bool
+	descriptorsFile   string
+	diagnosticsDryRun bool
 }
 
 // setDebugContextDefaults set the default values in debugCtx.  This
@@ -387,6 +413,48 @@ func setDebugContextDefaults() {
 	debugCtx.maxResults = 0
 	debugCtx.printSystemConfig = false
 	debugCtx.decodeAsTableDesc = ""
+	debugCtx.decodeSQLRows = false
+	debugCtx.descriptorsFile = ""
+	debugCtx.diagnosticsDryRun = false
+}
+
+// tsDumpCtx captures the command-line parameters of the `debug tsdump`
+// command. See below for defaults.
+var tsDumpCtx struct {
+	format   tsDumpFormat
+	metrics  []string
+	from, to timestampValue
+}
+
+// setTSDumpContextDefaults set the default values in tsDumpCtx. This
+// function is called by initCLIDefaults() and thus re-called in every
+// test that exercises command-line parsing.
+func setTSDumpContextDefaults() {
+	tsDumpCtx.format = tsDumpText
+	tsDumpCtx.metrics = nil
+	tsDumpCtx.from = timestampValue{}
+	tsDumpCtx.to = timestampValue{}
+}
+
+// fuzzSQLCtx captures the command-line parameters of the `debug fuzz-sql`
+// command. See below for defaults.
+var fuzzSQLCtx struct {
+	seed          int64
+	numStatements int
+	setup         string
+	setting       string
+	verbose       bool
+}
+
+// setFuzzSQLContextDefaults set the default values in fuzzSQLCtx. This
+// function is called by initCLIDefaults() and thus re-called in every
+// test that exercises command-line parsing.
+func setFuzzSQLContextDefaults() {
+	fuzzSQLCtx.seed = 0
+	fuzzSQLCtx.numStatements = 1000
+	fuzzSQLCtx.setup = "rand-tables"
+	fuzzSQLCtx.setting = "ddl-nodrop"
+	fuzzSQLCtx.verbose = false
 }
 
 // startCtx captures the command-line arguments for the `start` command.
@@ -14,7 +14,6 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/base64"
 	gohex "encoding/hex"
 	"fmt"
 	"math"
@@ -41,10 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
-	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/flagutil"
@@ -69,7 +65,8 @@ var debugKeysCmd = &cobra.Command{
 	Use:   "keys <directory>",
 	Short: "dump all the keys in a store",
 	Long: `
-Pretty-prints all keys in a store.
+Pretty-prints all keys in a store. With --values, the values of table keys can
+be decoded into SQL rows using --decode-sql-rows or --descriptors.
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: MaybeDecorateGRPCError(runDebugKeys),
@@ -180,31 +177,8 @@ func runDebugKeys(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if debugCtx.decodeAsTableDesc != "" {
-		bytes, err := base64.StdEncoding.DecodeString(debugCtx.decodeAsTableDesc)
-		if err != nil {
-			return err
-		}
-		var desc descpb.Descriptor
-		if err := protoutil.Unmarshal(bytes, &desc); err != nil {
-			return err
-		}
-		table := tabledesc.NewImmutable(*descpb.TableFromDescriptor(&desc, hlc.Timestamp{}))
-
-		fn := func(kv storage.MVCCKeyValue) (string, error) {
-			var v roachpb.Value
-			v.RawBytes = kv.Value
-			_, names, values, err := row.DecodeRowInfo(context.Background(), table, kv.Key.Key, &v, true)
-			if err != nil {
-				return "", err
-			}
-			pairs := make([]string, len(names))
-			for i := range pairs {
-				pairs[i] = fmt.Sprintf("%s=%s", names[i], values[i])
-			}
-			return strings.Join(pairs, ", "), nil
-		}
-		kvserver.DebugSprintKeyValueDecoders = append(kvserver.DebugSprintKeyValueDecoders, fn)
+	if err := maybeAddSQLRowDecoder(context.Background(), db); err != nil {
+		return err
 	}
 	printer := printKey
 	if debugCtx.values {
@@ -291,7 +265,8 @@ var debugRangeDataCmd = &cobra.Command{
 	Long: `
 Pretty-prints all keys and values in a range. By default, includes unreplicated
 state like the raft HardState. With --replicated, only includes data covered by
- the consistency checker.
+ the consistency checker. The values of table keys can be decoded into SQL rows
+using --decode-sql-rows or --descriptors.
 `,
 	Args: cobra.ExactArgs(2),
 	RunE: MaybeDecorateGRPCError(runDebugRangeData),
@@ -316,6 +291,10 @@ func runDebugRangeData(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := maybeAddSQLRowDecoder(context.Background(), db); err != nil {
+		return err
+	}
+
 	iter := rditer.NewReplicaEngineDataIterator(&desc, db, debugCtx.replicated)
 	defer iter.Close()
 	results := 0
@@ -912,7 +891,12 @@ func runDebugUnsafeRemoveDeadReplicas(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	defer batch.Close()
+	return commitBatchOnConfirmation(batch)
+}
 
+// commitBatchOnConfirmation prompts for confirmation and commits the batch if
+// the user agrees.
+func commitBatchOnConfirmation(batch storage.Batch) error {
 	fmt.Printf("Proceed with the above rewrites? [y/N] ")
 
 	reader := bufio.NewReader(os.Stdin)
@@ -1012,7 +996,15 @@ func removeDeadReplicas(
 	if len(newDescs) == 0 {
 		return nil, nil
 	}
+	return rewriteRangeDescriptors(ctx, db, clock, newDescs)
+}
 
+// rewriteRangeDescriptors returns a batch that overwrites the range-local
+// descriptors of the given ranges, aborting any transaction that left an
+// intent on them.
+func rewriteRangeDescriptors(
+	ctx context.Context, db storage.Engine, clock *hlc.Clock, newDescs []roachpb.RangeDescriptor,
+) (storage.Batch, error) {
 	batch := db.NewBatch()
 	for _, desc := range newDescs {
 		// Write the rewritten descriptor to the range-local descriptor
@@ -1181,6 +1173,8 @@ var debugCmds = append(DebugCmdsForRocksDB,
 	debugZipCmd,
 	debugMergeLogsCommand,
 	debugResetQuorumCmd,
+	debugDiagnosticsReportCmd,
+	debugFuzzSQLCmd,
 )
 
 // DebugCmd is the root of all debug commands. Exported to allow modification by CCL code.
@@ -1235,6 +1229,9 @@ func init() {
 	debugDoctorCmd.AddCommand(debugDoctorCmds...)
 	DebugCmd.AddCommand(debugDoctorCmd)
 
+	debugRecoverCmd.AddCommand(debugRecoverCmds...)
+	DebugCmd.AddCommand(debugRecoverCmd)
+
 	f := debugSyncBenchCmd.Flags()
 	f.IntVarP(&syncBenchOpts.Concurrency, "concurrency", "c", syncBenchOpts.Concurrency,
 		"number of concurrent writers")
@@ -0,0 +1,64 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugDiagnosticsReportCmd = &cobra.Command{
+	Use:   "diagnostics-report --dry-run",
+	Short: "print the diagnostics report the node would send",
+	Long: `
+Print the anonymized diagnostics report that the node the command connects to
+would send to Cockroach Labs next, if diagnostics reporting is enabled with the
+diagnostics.reporting.enabled cluster setting. The URL the report would be sent
+to is printed first, as its query parameters are sent along with the report.
+
+The report is collected without resetting the counters it contains, so this
+does not change what the node reports afterwards. The --dry-run flag is
+required; sending a report on demand is not supported.
+
+The user running the command must have the admin role.
+`,
+	Args: cobra.NoArgs,
+	RunE: MaybeDecorateGRPCError(runDebugDiagnosticsReport),
+}
+
+func runDebugDiagnosticsReport(cmd *cobra.Command, args []string) error {
+	if !debugCtx.diagnosticsDryRun {
+		return errors.New("sending a diagnostics report on demand is not supported; use --dry-run")
+	}
+
+	conn, err := makeSQLClient("cockroach debug diagnostics-report", useSystemDb)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	vals, err := conn.QueryRow(
+		`SELECT reporting_url, jsonb_pretty(report) FROM crdb_internal.node_diagnostics_report`,
+		nil, /* args */
+	)
+	if err != nil {
+		return err
+	}
+	reportingURL := "(none; reporting URL disabled)"
+	if vals[0] != nil {
+		reportingURL = vals[0].(string)
+	}
+	fmt.Fprintf(os.Stdout, "Reporting URL: %s\n%s\n", reportingURL, vals[1].(string))
+	return nil
+}
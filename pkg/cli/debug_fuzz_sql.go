@@ -0,0 +1,105 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/cliflags"
+	"github.com/cockroachdb/cockroach/pkg/internal/sqlsmith"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugFuzzSQLCmd = &cobra.Command{
+	Use:   "fuzz-sql",
+	Short: "execute random SQL statements against a cluster",
+	Long: `
+Create a database, initialize it with random tables and execute random SQL
+statements in it, until a statement fails with an internal error, the
+connection to the server is lost, or the output of SHOW CREATE for a table
+doesn't create an identical table.
+
+The seed is printed first; running the command again with the same --seed
+and flags generates the same statements. When a failure is found, the
+statements are shrunk to a smaller sequence which fails the same way, and
+printed. The database is named after the seed, and is left in place when a
+failure is found.
+
+This command creates and drops databases, and executes statements which can
+be expensive. Do not run it against a cluster that serves production traffic.
+`,
+	Args: cobra.NoArgs,
+	RunE: MaybeDecorateGRPCError(runDebugFuzzSQL),
+}
+
+func runDebugFuzzSQL(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	seed := fuzzSQLCtx.seed
+	if !cmd.Flags().Changed(cliflags.FuzzSQLSeed.Name) {
+		seed = randutil.NewPseudoSeed()
+	}
+	fmt.Fprintf(stderr, "seed: %d\n", seed)
+
+	conn, err := makeSQLClient("cockroach debug fuzz-sql", useSystemDb)
+	if err != nil {
+		return err
+	}
+	conn.Close()
+	baseURL, err := url.Parse(conn.url)
+	if err != nil {
+		return err
+	}
+	open := func(dbName string) (*gosql.DB, error) {
+		u := *baseURL
+		u.Path = dbName
+		return gosql.Open("postgres", u.String())
+	}
+	db, err := open(baseURL.Path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cfg := sqlsmith.FuzzConfig{
+		Seed:            seed,
+		Setup:           fuzzSQLCtx.setup,
+		Setting:         fuzzSQLCtx.setting,
+		NumStatements:   fuzzSQLCtx.numStatements,
+		CheckShowCreate: true,
+		Log:             ioutil.Discard,
+	}
+	if fuzzSQLCtx.verbose {
+		cfg.Log = os.Stdout
+	}
+	err = sqlsmith.Fuzz(ctx, db, open, cfg)
+	var failure *sqlsmith.FuzzFailure
+	if !errors.As(err, &failure) {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "found a failure after %d statements, shrinking\n", len(failure.Statements))
+	shrunk, err := sqlsmith.Shrink(ctx, db, open, failure)
+	if err != nil {
+		return errors.CombineErrors(failure, err)
+	}
+	for _, stmt := range shrunk.Statements {
+		fmt.Fprintf(os.Stdout, "%s;\n\n", stmt)
+	}
+	return shrunk
+}
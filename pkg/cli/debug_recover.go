@@ -0,0 +1,409 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+var debugRecoverCmd = &cobra.Command{
+	Use:   "recover [command]",
+	Short: "commands to recover unavailable ranges in case of quorum loss",
+	Long: `
+Set of commands to recover unavailable ranges after the permanent loss of a
+quorum of their replicas.
+
+These commands are UNSAFE and should only be used with the supervision of
+a Cockroach Labs engineer. They are a last-resort option to restore the
+availability of ranges after multiple node failures. The recovered data is
+not guaranteed to be consistent: writes committed by the lost replicas but
+not received by the surviving ones are lost.
+
+Recovery is a multi-step process, performed while all surviving nodes are
+stopped:
+
+1. Run 'cockroach debug recover collect-info' on every surviving node to
+   collect information about the replicas on its stores.
+2. Run 'cockroach debug recover make-plan' on the collected files to decide
+   which surviving replica of each unavailable range becomes its sole voter.
+   The command reports the ranges that will be recovered and the data that
+   may be lost.
+3. Run 'cockroach debug recover apply-plan' with the plan on every surviving
+   node, then restart the nodes.
+
+The lost nodes must never rejoin the cluster after a plan has been applied,
+otherwise data may be corrupted.
+`,
+	RunE: usageAndErr,
+}
+
+var debugRecoverCmds = []*cobra.Command{
+	debugRecoverCollectInfoCmd,
+	debugRecoverMakePlanCmd,
+	debugRecoverApplyPlanCmd,
+}
+
+var debugRecoverCollectInfoCmd = &cobra.Command{
+	Use:   "collect-info --store=<store dir> [--store=<store dir> ...]",
+	Short: "collect information about the replicas on the stores of a stopped node",
+	Long: `
+Collects information about the replicas found on the given stores of a stopped
+node and prints it to stdout in the format expected by make-plan.
+`,
+	Args: cobra.NoArgs,
+	RunE: MaybeDecorateGRPCError(runDebugRecoverCollectInfo),
+}
+
+var debugRecoverMakePlanCmd = &cobra.Command{
+	Use:   "make-plan <replica info file> [<replica info file> ...]",
+	Short: "generate a plan to recover the ranges that lost quorum",
+	Long: `
+Reads the replica information collected from all surviving stores and prints a
+plan to recover the ranges that lost quorum to stdout. Every store whose replica
+information is not provided is considered permanently lost.
+
+For each range that lost quorum, the surviving replica that applied the most
+raft log entries is picked to become the sole voter of the range. A report of
+the recovered ranges and of the data that may be lost is printed to stderr.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runDebugRecoverMakePlan,
+}
+
+var debugRecoverApplyPlanCmd = &cobra.Command{
+	Use:   "apply-plan --store=<store dir> [--store=<store dir> ...] <plan file>",
+	Short: "apply a recovery plan to the stores of a stopped node",
+	Long: `
+Rewrites the descriptors of the replicas on the given stores of a stopped node
+that the plan picked to recover their range. The command prompts for
+confirmation before committing its changes.
+
+After this command is used, the node should not be restarted until at least 10
+seconds have passed since it was stopped.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: MaybeDecorateGRPCError(runDebugRecoverApplyPlan),
+}
+
+// recoveryReplicaInfo describes a replica found on a store by collect-info.
+type recoveryReplicaInfo struct {
+	NodeID  roachpb.NodeID
+	StoreID roachpb.StoreID
+	Desc    roachpb.RangeDescriptor
+	// RaftAppliedIndex is the index of the last raft log entry applied by the
+	// replica.
+	RaftAppliedIndex uint64
+	// HasUncommittedDescriptor is set if the range-local descriptor has an
+	// intent, i.e. a split, merge or replication change was in progress.
+	HasUncommittedDescriptor bool
+}
+
+// recoveryPlanUpdate describes the rewrite of the descriptor of a replica that
+// becomes the sole voter of its range.
+type recoveryPlanUpdate struct {
+	RangeID  roachpb.RangeID
+	StartKey roachpb.RKey
+	// OldReplicaID is the ID of the replica in the range descriptor that was
+	// collected. The descriptor must not have changed when the plan is applied.
+	OldReplicaID roachpb.ReplicaID
+	// NewReplica is the only replica of the rewritten range descriptor.
+	NewReplica roachpb.ReplicaDescriptor
+}
+
+// recoveryPlan is the output of make-plan and the input of apply-plan.
+type recoveryPlan struct {
+	Updates []recoveryPlanUpdate
+}
+
+func runDebugRecoverCollectInfo(cmd *cobra.Command, args []string) error {
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+
+	var replicas []recoveryReplicaInfo
+	for _, spec := range serverCfg.Stores.Specs {
+		db, err := OpenExistingStore(spec.Path, stopper, true /* readOnly */)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open store at %s", spec.Path)
+		}
+		storeReplicas, err := collectReplicaInfo(context.Background(), db)
+		if err != nil {
+			return err
+		}
+		replicas = append(replicas, storeReplicas...)
+	}
+	return writeJSON(os.Stdout, replicas)
+}
+
+// collectReplicaInfo returns information about all replicas on the store.
+func collectReplicaInfo(ctx context.Context, db storage.Reader) ([]recoveryReplicaInfo, error) {
+	storeIdent, err := kvserver.ReadStoreIdent(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var replicas []recoveryReplicaInfo
+	err = kvserver.IterateRangeDescriptors(ctx, db, func(desc roachpb.RangeDescriptor) error {
+		raftAppliedIndex, _, err := stateloader.Make(desc.RangeID).LoadAppliedIndex(ctx, db)
+		if err != nil {
+			return errors.Wrapf(err, "loading applied index of r%d", desc.RangeID)
+		}
+		_, intent, err := storage.MVCCGet(ctx, db, keys.RangeDescriptorKey(desc.StartKey),
+			hlc.MaxTimestamp, storage.MVCCGetOptions{Inconsistent: true})
+		if err != nil {
+			return errors.Wrapf(err, "reading descriptor of r%d", desc.RangeID)
+		}
+		replicas = append(replicas, recoveryReplicaInfo{
+			NodeID:                   storeIdent.NodeID,
+			StoreID:                  storeIdent.StoreID,
+			Desc:                     desc,
+			RaftAppliedIndex:         raftAppliedIndex,
+			HasUncommittedDescriptor: intent != nil,
+		})
+		return nil
+	})
+	return replicas, err
+}
+
+func runDebugRecoverMakePlan(cmd *cobra.Command, args []string) error {
+	var replicas []recoveryReplicaInfo
+	for _, filename := range args {
+		var fileReplicas []recoveryReplicaInfo
+		if err := readJSON(filename, &fileReplicas); err != nil {
+			return err
+		}
+		replicas = append(replicas, fileReplicas...)
+	}
+
+	plan, warnings := makeRecoveryPlan(replicas)
+	for _, update := range plan.Updates {
+		fmt.Fprintf(stderr, "Range r%d starting at %s will be recovered from its replica on s%d.\n",
+			update.RangeID, update.StartKey, update.NewReplica.StoreID)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning)
+	}
+	if len(plan.Updates) == 0 {
+		fmt.Fprintf(stderr, "No ranges lost quorum, nothing to do.\n")
+	} else {
+		fmt.Fprintf(stderr, "Writes committed by lost replicas of the recovered ranges "+
+			"and not received by the surviving ones are lost.\n")
+	}
+	return writeJSON(os.Stdout, plan)
+}
+
+// makeRecoveryPlan picks a surviving replica to become the sole voter of each
+// range that lost quorum. Every store that has no replicas in the given
+// replica information is considered lost. It also returns warnings about the
+// data that may be lost.
+func makeRecoveryPlan(replicas []recoveryReplicaInfo) (recoveryPlan, []string) {
+	survivingStores := make(map[roachpb.StoreID]struct{})
+	for _, r := range replicas {
+		survivingStores[r.StoreID] = struct{}{}
+	}
+	isLive := func(rep roachpb.ReplicaDescriptor) bool {
+		_, ok := survivingStores[rep.StoreID]
+		return ok
+	}
+
+	// Pick the most recent replica of each range: the one with the newest
+	// descriptor which applied the most raft log entries, with ties broken by
+	// the highest store ID.
+	best := make(map[roachpb.RangeID]recoveryReplicaInfo)
+	for _, r := range replicas {
+		if _, ok := r.Desc.GetReplicaDescriptor(r.StoreID); !ok {
+			// The replica was removed from its range but not garbage collected.
+			continue
+		}
+		b, ok := best[r.Desc.RangeID]
+		if !ok || r.Desc.Generation > b.Desc.Generation ||
+			(r.Desc.Generation == b.Desc.Generation && (r.RaftAppliedIndex > b.RaftAppliedIndex ||
+				(r.RaftAppliedIndex == b.RaftAppliedIndex && r.StoreID > b.StoreID))) {
+			best[r.Desc.RangeID] = r
+		}
+	}
+	ranges := make([]recoveryReplicaInfo, 0, len(best))
+	for _, r := range best {
+		ranges = append(ranges, r)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Desc.StartKey.Less(ranges[j].Desc.StartKey)
+	})
+
+	var plan recoveryPlan
+	var warnings []string
+	// Check that the surviving ranges cover the keyspace and plan the recovery
+	// of the ones that lost quorum. Ranges overlapping with the previous range
+	// are left alone: one of the two has a stale descriptor.
+	prevEnd := roachpb.RKeyMin
+	var prev roachpb.RangeDescriptor
+	for _, r := range ranges {
+		desc := r.Desc
+		if desc.StartKey.Less(prevEnd) {
+			warnings = append(warnings, fmt.Sprintf(
+				"range r%d %s overlaps with range r%d %s, not recovering it",
+				desc.RangeID, desc.RSpan(), prev.RangeID, prev.RSpan()))
+			continue
+		}
+		if prevEnd.Less(desc.StartKey) {
+			warnings = append(warnings, fmt.Sprintf(
+				"no replicas of the ranges in %s survived, their data is lost",
+				roachpb.RSpan{Key: prevEnd, EndKey: desc.StartKey}))
+		}
+		prevEnd, prev = desc.EndKey, desc
+
+		if desc.Replicas().CanMakeProgress(isLive) {
+			continue
+		}
+		rep, _ := desc.GetReplicaDescriptor(r.StoreID)
+		if rep.GetType() != roachpb.VOTER_FULL {
+			warnings = append(warnings, fmt.Sprintf(
+				"the only surviving replica of range r%d is a %s and may be missing data",
+				desc.RangeID, rep.GetType()))
+		}
+		if r.HasUncommittedDescriptor {
+			warnings = append(warnings, fmt.Sprintf(
+				"range r%d has an uncommitted split, merge or replication change, "+
+					"which will be aborted", desc.RangeID))
+		}
+		plan.Updates = append(plan.Updates, recoveryPlanUpdate{
+			RangeID:      desc.RangeID,
+			StartKey:     desc.StartKey,
+			OldReplicaID: rep.ReplicaID,
+			NewReplica: roachpb.ReplicaDescriptor{
+				NodeID:    r.NodeID,
+				StoreID:   r.StoreID,
+				ReplicaID: desc.NextReplicaID,
+			},
+		})
+	}
+	if prevEnd.Less(roachpb.RKeyMax) {
+		warnings = append(warnings, fmt.Sprintf(
+			"no replicas of the ranges in %s survived, their data is lost",
+			roachpb.RSpan{Key: prevEnd, EndKey: roachpb.RKeyMax}))
+	}
+	return plan, warnings
+}
+
+func runDebugRecoverApplyPlan(cmd *cobra.Command, args []string) error {
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+
+	var plan recoveryPlan
+	if err := readJSON(args[0], &plan); err != nil {
+		return err
+	}
+	for _, spec := range serverCfg.Stores.Specs {
+		db, err := OpenExistingStore(spec.Path, stopper, false /* readOnly */)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open store at %s", spec.Path)
+		}
+		batch, err := applyRecoveryPlan(context.Background(), db, plan)
+		if err != nil {
+			return err
+		} else if batch == nil {
+			fmt.Printf("Nothing to do on store %s\n", spec.Path)
+			continue
+		}
+		err = commitBatchOnConfirmation(batch)
+		batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRecoveryPlan returns a batch that rewrites the descriptors of the
+// replicas on the store that the plan picked to recover their range, or nil
+// if the plan does not concern the store.
+func applyRecoveryPlan(
+	ctx context.Context, db storage.Engine, plan recoveryPlan,
+) (storage.Batch, error) {
+	storeIdent, err := kvserver.ReadStoreIdent(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	updates := make(map[roachpb.RangeID]recoveryPlanUpdate)
+	for _, update := range plan.Updates {
+		if update.NewReplica.StoreID == storeIdent.StoreID {
+			updates[update.RangeID] = update
+		}
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	var newDescs []roachpb.RangeDescriptor
+	err = kvserver.IterateRangeDescriptors(ctx, db, func(desc roachpb.RangeDescriptor) error {
+		update, ok := updates[desc.RangeID]
+		if !ok {
+			return nil
+		}
+		delete(updates, desc.RangeID)
+		rep, ok := desc.GetReplicaDescriptor(storeIdent.StoreID)
+		if !desc.StartKey.Equal(update.StartKey) || !ok || rep.ReplicaID != update.OldReplicaID ||
+			desc.NextReplicaID != update.NewReplica.ReplicaID {
+			return errors.Errorf("descriptor of r%d changed since the plan was made: %s",
+				desc.RangeID, &desc)
+		}
+		newDesc := desc
+		newDesc.SetReplicas(roachpb.MakeReplicaDescriptors(
+			[]roachpb.ReplicaDescriptor{update.NewReplica}))
+		newDesc.NextReplicaID++
+		fmt.Printf("Replica %s -> %s\n", &desc, &newDesc)
+		newDescs = append(newDescs, newDesc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(updates) > 0 {
+		var missing []roachpb.RangeID
+		for rangeID := range updates {
+			missing = append(missing, rangeID)
+		}
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+		return nil, errors.Errorf("replicas of ranges %v not found on store %s", missing, storeIdent.String())
+	}
+	return rewriteRangeDescriptors(ctx, db, hlc.NewClock(hlc.UnixNano, 0), newDescs)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+func readJSON(filename string, v interface{}) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(data, v), "failed to parse %s", filename)
+}
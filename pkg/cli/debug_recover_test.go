@@ -0,0 +1,88 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeRecoveryPlan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	makeDesc := func(
+		rangeID roachpb.RangeID, start, end roachpb.RKey, storeIDs ...roachpb.StoreID,
+	) roachpb.RangeDescriptor {
+		var replicas []roachpb.ReplicaDescriptor
+		for _, storeID := range storeIDs {
+			replicas = append(replicas, roachpb.ReplicaDescriptor{
+				NodeID:  roachpb.NodeID(storeID),
+				StoreID: storeID,
+			})
+		}
+		desc := roachpb.NewRangeDescriptor(rangeID, start, end, roachpb.MakeReplicaDescriptors(replicas))
+		return *desc
+	}
+	info := func(
+		storeID roachpb.StoreID, desc roachpb.RangeDescriptor, appliedIndex uint64,
+	) recoveryReplicaInfo {
+		return recoveryReplicaInfo{
+			NodeID:           roachpb.NodeID(storeID),
+			StoreID:          storeID,
+			Desc:             desc,
+			RaftAppliedIndex: appliedIndex,
+		}
+	}
+
+	// Stores 1 and 4 survived, stores 2, 3 and 5 were lost.
+	r1 := makeDesc(1, roachpb.RKeyMin, roachpb.RKey("b"), 1, 2, 3)
+	r2 := makeDesc(2, roachpb.RKey("b"), roachpb.RKey("d"), 1, 4, 5)
+	r3 := makeDesc(3, roachpb.RKey("d"), roachpb.RKey("f"), 2, 3, 4)
+	r4 := makeDesc(4, roachpb.RKey("f"), roachpb.RKey("h"), 1, 2, 3, 4, 5)
+	// The ranges to the right of "h" had no replicas on surviving stores.
+	plan, warnings := makeRecoveryPlan([]recoveryReplicaInfo{
+		info(1, r1, 10),
+		info(1, r2, 10),
+		info(4, r2, 10),
+		info(4, r3, 10),
+		info(1, r4, 10),
+		info(4, r4, 12),
+	})
+
+	require.Equal(t, []recoveryPlanUpdate{
+		{
+			RangeID:      1,
+			StartKey:     roachpb.RKeyMin,
+			OldReplicaID: 1,
+			NewReplica:   roachpb.ReplicaDescriptor{NodeID: 1, StoreID: 1, ReplicaID: 4},
+		},
+		{
+			RangeID:      3,
+			StartKey:     roachpb.RKey("d"),
+			OldReplicaID: 3,
+			NewReplica:   roachpb.ReplicaDescriptor{NodeID: 4, StoreID: 4, ReplicaID: 4},
+		},
+		{
+			// The replica on s4 applied more of the raft log than the one on s1.
+			RangeID:      4,
+			StartKey:     roachpb.RKey("f"),
+			OldReplicaID: 4,
+			NewReplica:   roachpb.ReplicaDescriptor{NodeID: 4, StoreID: 4, ReplicaID: 6},
+		},
+	}, plan.Updates)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "their data is lost")
+}
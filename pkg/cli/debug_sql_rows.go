@@ -0,0 +1,175 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	hx "encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// debugTableDescriptors maps table IDs to the descriptors used to decode the
+// SQL rows printed by the debug keys and range-data commands.
+type debugTableDescriptors map[descpb.ID]catalog.TableDescriptor
+
+// add adds the descriptor to the map if it describes a table. The
+// timestamp is the MVCC timestamp at which the descriptor was read; it
+// must be set for descriptors which have been modified since their
+// creation.
+func (t debugTableDescriptors) add(desc *descpb.Descriptor, ts hlc.Timestamp) {
+	if table := descpb.TableFromDescriptor(desc, ts); table != nil {
+		t[table.ID] = tabledesc.NewImmutable(*table)
+	}
+}
+
+// maybeAddSQLRowDecoder installs a debug decoder that prints the values of
+// table keys as SQL rows, if any of --decode-as-table, --descriptors and
+// --decode-sql-rows were specified. The store is only read when
+// --decode-sql-rows is specified.
+func maybeAddSQLRowDecoder(ctx context.Context, reader storage.Reader) error {
+	tables := make(debugTableDescriptors)
+	if debugCtx.decodeSQLRows {
+		if err := readTableDescriptorsFromStore(ctx, reader, tables); err != nil {
+			return err
+		}
+		if len(tables) == 0 {
+			fmt.Fprintln(stderr, "warning: no table descriptors found in the store; "+
+				"use --descriptors to decode SQL rows of other stores")
+		}
+	}
+	if debugCtx.descriptorsFile != "" {
+		if err := readTableDescriptorsFromFile(debugCtx.descriptorsFile, tables); err != nil {
+			return err
+		}
+	}
+	if debugCtx.decodeAsTableDesc != "" {
+		bytes, err := base64.StdEncoding.DecodeString(debugCtx.decodeAsTableDesc)
+		if err != nil {
+			return err
+		}
+		var desc descpb.Descriptor
+		if err := protoutil.Unmarshal(bytes, &desc); err != nil {
+			return err
+		}
+		// The MVCC timestamp of the descriptor is not known.
+		tables.add(&desc, hlc.Timestamp{WallTime: timeutil.Now().UnixNano()})
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	kvserver.DebugSprintKeyValueDecoders = append(kvserver.DebugSprintKeyValueDecoders,
+		sqlRowDecoder(ctx, tables))
+	return nil
+}
+
+// readTableDescriptorsFromStore adds the latest version of every table
+// descriptor stored in system.descriptor on the given store. Nothing is added
+// if the store does not hold a replica of the system.descriptor range.
+func readTableDescriptorsFromStore(
+	ctx context.Context, reader storage.Reader, tables debugTableDescriptors,
+) error {
+	start := keys.SystemSQLCodec.DescMetadataPrefix()
+	res, err := storage.MVCCScan(ctx, reader, start, start.PrefixEnd(), hlc.MaxTimestamp,
+		storage.MVCCScanOptions{Inconsistent: true})
+	if err != nil {
+		return errors.Wrap(err, "reading descriptors from store")
+	}
+	for _, kv := range res.KVs {
+		var desc descpb.Descriptor
+		if err := kv.Value.GetProto(&desc); err != nil {
+			return errors.Wrapf(err, "decoding descriptor at %s", kv.Key)
+		}
+		tables.add(&desc, kv.Value.Timestamp)
+	}
+	return nil
+}
+
+// readTableDescriptorsFromFile adds the table descriptors found in the given
+// file, which is expected to be in the format of the system.descriptor.txt
+// file of a debug zip.
+func readTableDescriptorsFromFile(path string, tables debugTableDescriptors) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tableMap(f, func(row string) error {
+		fields := strings.Fields(row)
+		if len(fields) == 0 {
+			return nil
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return errors.Errorf("failed to parse descriptor id %s: %v", fields[0], err)
+		}
+		descBytes, err := hx.DecodeString(strings.TrimPrefix(fields[len(fields)-1], `\x`))
+		if err != nil {
+			return errors.Errorf("failed to decode hex descriptor %d: %v", id, err)
+		}
+		var desc descpb.Descriptor
+		if err := protoutil.Unmarshal(descBytes, &desc); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal descriptor %d", id)
+		}
+		// The debug zip does not include the MVCC timestamp of the
+		// descriptors. As in debug doctor, use the current time.
+		tables.add(&desc, hlc.Timestamp{WallTime: timeutil.Now().UnixNano()})
+		return nil
+	})
+}
+
+// sqlRowDecoder returns a debug decoder that prints the value of a key
+// belonging to one of the given tables as column name=value pairs.
+func sqlRowDecoder(
+	ctx context.Context, tables debugTableDescriptors,
+) func(kv storage.MVCCKeyValue) (string, error) {
+	return func(kv storage.MVCCKeyValue) (string, error) {
+		// Intents are stored at the zero timestamp and their value is an
+		// MVCCMetadata, which is left to the intent decoder.
+		if kv.Key.Timestamp.IsEmpty() {
+			return "", errors.New("not a versioned value")
+		}
+		_, tableID, err := keys.SystemSQLCodec.DecodeTablePrefix(kv.Key.Key)
+		if err != nil {
+			return "", err
+		}
+		table, ok := tables[descpb.ID(tableID)]
+		if !ok {
+			return "", errors.Errorf("unknown table %d", tableID)
+		}
+		v := roachpb.Value{RawBytes: kv.Value}
+		_, names, values, err := row.DecodeRowInfo(ctx, table, kv.Key.Key, &v, true /* allColumns */)
+		if err != nil {
+			return "", err
+		}
+		pairs := make([]string, len(names))
+		for i := range pairs {
+			pairs[i] = fmt.Sprintf("%s=%s", names[i], values[i])
+		}
+		return strings.Join(pairs, ", "), nil
+	}
+}
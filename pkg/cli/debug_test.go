@@ -13,6 +13,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -29,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -40,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
 )
 
 func createStore(t *testing.T, path string) {
@@ -449,3 +452,63 @@ func TestParseGossipValues(t *testing.T) {
 			len(debugLines), len(gossipInfo.Infos), debugOutput, strings.Join(gossipInfoKeys, "\n"))
 	}
 }
+
+func TestDebugSQLRowDecoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	baseDir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+	storePath := filepath.Join(baseDir, "store")
+
+	var tableID int
+	var descHex string
+	func() {
+		s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+			StoreSpecs: []base.StoreSpec{{Path: storePath}},
+		})
+		defer s.Stopper().Stop(ctx)
+		runner := sqlutils.MakeSQLRunner(sqlDB)
+		runner.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+		runner.Exec(t, `INSERT INTO t VALUES (1, 'a'), (2, 'b')`)
+		// Bump the descriptor version: the modification time of descriptors
+		// with a version above 1 must be derived from their MVCC timestamp.
+		runner.Exec(t, `ALTER TABLE t RENAME COLUMN v TO s`)
+		runner.QueryRow(t, `SELECT id, encode(descriptor, 'hex') FROM system.descriptor WHERE id = 't'::REGCLASS::OID`).
+			Scan(&tableID, &descHex)
+	}()
+
+	descFile := filepath.Join(baseDir, "system.descriptor.txt")
+	require.NoError(t, ioutil.WriteFile(
+		descFile, []byte(fmt.Sprintf("id\tdescriptor\n%d\t%s\n", tableID, descHex)), 0644))
+	fromFile := make(debugTableDescriptors)
+	require.NoError(t, readTableDescriptorsFromFile(descFile, fromFile))
+	require.Len(t, fromFile, 1)
+
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	db, err := OpenExistingStore(storePath, stopper, true /* readOnly */)
+	require.NoError(t, err)
+	fromStore := make(debugTableDescriptors)
+	require.NoError(t, readTableDescriptorsFromStore(ctx, db, fromStore))
+	require.Contains(t, fromStore, descpb.ID(tableID))
+
+	for name, tables := range map[string]debugTableDescriptors{"file": fromFile, "store": fromStore} {
+		t.Run(name, func(t *testing.T) {
+			decode := sqlRowDecoder(ctx, tables)
+			var rows []string
+			span := keys.SystemSQLCodec.TablePrefix(uint32(tableID))
+			require.NoError(t, db.MVCCIterate(span, span.PrefixEnd(), storage.MVCCKeyIterKind,
+				func(kv storage.MVCCKeyValue) error {
+					out, err := decode(kv)
+					if err != nil {
+						return err
+					}
+					rows = append(rows, out)
+					return nil
+				}))
+			require.Equal(t, []string{"k=1, s='a'", "k=2, s='b'"}, rows)
+		})
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/workload"
 	"github.com/cockroachdb/cockroach/pkg/workload/histogram"
@@ -60,6 +61,66 @@ type transientCluster struct {
 
 	adminPassword string
 	adminUser     security.SQLUsername
+
+	// latencies is used to simulate the latencies between nodes in
+	// different regions when --global is used.
+	latencies demoLatencies
+}
+
+// demoLatencies maps the RPC addresses of the demo nodes to their regions,
+// to compute the artificial latencies between them. It is consulted by the
+// nodes every time they dial each other, and updated as nodes are added or
+// restarted.
+type demoLatencies struct {
+	syncutil.RWMutex
+	addrToRegion map[string]string
+}
+
+// setRegion records the region of the node serving RPCs at addr.
+func (l *demoLatencies) setRegion(addr string, locality roachpb.Locality) {
+	region, ok := locality.Find("region")
+	if !ok {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.addrToRegion == nil {
+		l.addrToRegion = make(map[string]string)
+	}
+	l.addrToRegion[addr] = region
+}
+
+// latencyFn returns a function computing the artificial latency from a node
+// with the given locality to the node serving RPCs at a target address.
+func (l *demoLatencies) latencyFn(locality roachpb.Locality) func(target string) int {
+	srcRegion, _ := locality.Find("region")
+	return func(target string) int {
+		l.RLock()
+		defer l.RUnlock()
+		dstRegion, ok := l.addrToRegion[target]
+		if !ok {
+			return 0
+		}
+		return regionToRegionToLatency[srcRegion][dstRegion]
+	}
+}
+
+// latencyKnobs returns the testing knobs that make a server with the given
+// locality simulate latencies to the other nodes. The server signals
+// rpcReadyCh once it knows its RPC address, and then waits until pauseCh is
+// closed.
+func (c *transientCluster) latencyKnobs(
+	locality roachpb.Locality, pauseCh, rpcReadyCh chan struct{},
+) base.TestingKnobs {
+	return base.TestingKnobs{
+		Server: &server.TestingKnobs{
+			PauseAfterGettingRPCAddress:  pauseCh,
+			SignalAfterGettingRPCAddress: rpcReadyCh,
+			ContextTestingKnobs: rpc.ContextTestingKnobs{
+				ArtificialLatencyFn: c.latencies.latencyFn(locality),
+			},
+		},
+	}
 }
 
 func (c *transientCluster) checkConfigAndSetupLogging(
@@ -149,15 +210,7 @@ func (c *transientCluster) start(
 		servRPCReadyCh := make(chan struct{})
 
 		if demoCtx.simulateLatency {
-			args.Knobs = base.TestingKnobs{
-				Server: &server.TestingKnobs{
-					PauseAfterGettingRPCAddress:  latencyMapWaitCh,
-					SignalAfterGettingRPCAddress: servRPCReadyCh,
-					ContextTestingKnobs: rpc.ContextTestingKnobs{
-						ArtificialLatencyMap: make(map[string]int),
-					},
-				},
-			}
+			args.Knobs = c.latencyKnobs(args.Locality, latencyMapWaitCh, servRPCReadyCh)
 		}
 
 		s, err := serverFactory.New(args)
@@ -234,28 +287,10 @@ func (c *transientCluster) start(
 
 	if demoCtx.simulateLatency {
 		// Now, all servers have been started enough to know their own RPC serving
-		// addresses, but nothing else. Assemble the artificial latency map.
-		for i, src := range servers {
-			latencyMap := src.Cfg.TestingKnobs.Server.(*server.TestingKnobs).ContextTestingKnobs.ArtificialLatencyMap
-			srcLocality, ok := src.Cfg.Locality.Find("region")
-			if !ok {
-				continue
-			}
-			srcLocalityMap, ok := regionToRegionToLatency[srcLocality]
-			if !ok {
-				continue
-			}
-			for j, dst := range servers {
-				if i == j {
-					continue
-				}
-				dstLocality, ok := dst.Cfg.Locality.Find("region")
-				if !ok {
-					continue
-				}
-				latency := srcLocalityMap[dstLocality]
-				latencyMap[dst.ServingRPCAddr()] = latency
-			}
+		// addresses, but nothing else. Record the regions of these addresses so
+		// that the artificial latencies between the nodes can be computed.
+		for _, serv := range servers {
+			c.latencies.setRegion(serv.ServingRPCAddr(), serv.Cfg.Locality)
 		}
 	}
 
@@ -501,9 +536,16 @@ func (c *transientCluster) RestartNode(nodeID roachpb.NodeID) error {
 		return errors.Errorf("node %d is already running", nodeID)
 	}
 
-	// TODO(#42243): re-compute the latency mapping.
 	args := testServerArgsForTransientCluster(c.sockForServer(nodeID), nodeID, c.s.ServingRPCAddr(), c.demoDir,
 		c.sqlFirstPort, c.httpFirstPort)
+	// If latency simulation is requested, the server needs to wait until its
+	// RPC address has been registered before it can start talking to the
+	// other nodes.
+	pauseCh := make(chan struct{})
+	rpcReadyCh := make(chan struct{})
+	if demoCtx.simulateLatency {
+		args.Knobs = c.latencyKnobs(args.Locality, pauseCh, rpcReadyCh)
+	}
 	s, err := server.TestServerFactory.New(args)
 	if err != nil {
 		return err
@@ -516,7 +558,22 @@ func (c *transientCluster) RestartNode(nodeID roachpb.NodeID) error {
 		close(readyCh)
 	}
 
-	if err := serv.Start(); err != nil {
+	startErrCh := make(chan error, 1)
+	if demoCtx.simulateLatency {
+		go func() {
+			startErrCh <- serv.Start()
+		}()
+		select {
+		case <-rpcReadyCh:
+		case err := <-startErrCh:
+			return err
+		}
+		c.latencies.setRegion(serv.ServingRPCAddr(), args.Locality)
+		close(pauseCh)
+	} else {
+		startErrCh <- serv.Start()
+	}
+	if err := <-startErrCh; err != nil {
 		return err
 	}
 
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/stretchr/testify/assert"
@@ -98,3 +99,42 @@ func TestTestServerArgsForTransientCluster(t *testing.T) {
 		})
 	}
 }
+
+// TestDemoLatencies checks that the simulated latencies apply to nodes that
+// are added or restarted after the latency functions have been handed out to
+// the servers.
+func TestDemoLatencies(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	east := roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: "us-east1"}}}
+	west := roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: "us-west1"}}}
+	europe := roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: "europe-west1"}}}
+
+	var c transientCluster
+	c.latencies.setRegion("127.0.0.1:1", east)
+	c.latencies.setRegion("127.0.0.1:2", west)
+	eastFn := c.latencies.latencyFn(east)
+	assert.Equal(t, regionToRegionToLatency["us-east1"]["us-west1"], eastFn("127.0.0.1:2"))
+	assert.Equal(t, 0, eastFn("127.0.0.1:1"))
+
+	// A node added with \demo add is not known yet to the existing nodes.
+	assert.Equal(t, 0, eastFn("127.0.0.1:3"))
+	c.latencies.setRegion("127.0.0.1:3", europe)
+	assert.Equal(t, regionToRegionToLatency["us-east1"]["europe-west1"], eastFn("127.0.0.1:3"))
+
+	// The knobs of the added node simulate latencies towards the existing
+	// nodes.
+	knobs := c.latencyKnobs(europe, make(chan struct{}), make(chan struct{}))
+	europeFn := knobs.Server.(*server.TestingKnobs).ContextTestingKnobs.ArtificialLatencyFn
+	assert.Equal(t, regionToRegionToLatency["europe-west1"]["us-west1"], europeFn("127.0.0.1:2"))
+
+	// A restarted node may come back on a different RPC address.
+	c.latencies.setRegion("127.0.0.1:4", west)
+	assert.Equal(t, regionToRegionToLatency["us-east1"]["us-west1"], eastFn("127.0.0.1:4"))
+	assert.Equal(t, regionToRegionToLatency["europe-west1"]["us-west1"], europeFn("127.0.0.1:4"))
+
+	// Nodes without a region don't have simulated latencies.
+	c.latencies.setRegion("127.0.0.1:5", roachpb.Locality{})
+	assert.Equal(t, 0, eastFn("127.0.0.1:5"))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
 	"github.com/lib/pq"
 	"github.com/spf13/cobra"
 )
@@ -110,6 +111,22 @@ func wrapExamine(
 	return nil
 }
 
+// hasJobInfoTable returns whether the table system.job_info exists. On versions
+// before 21.1, it does not exist. Otherwise, the progress of jobs is stored
+// there once they've been updated after the upgrade, see
+// jobs.JobInfoProgressColumn.
+func hasJobInfoTable(sqlConn *sqlConn) (bool, error) {
+	rows, err := sqlConn.Query(`SELECT 1 FROM system.job_info LIMIT 0`, nil)
+	if err == nil {
+		return true, rows.Close()
+	}
+	if pqErr := (*pq.Error)(nil); errors.As(err, &pqErr) &&
+		pgcode.MakeCode(string(pqErr.Code)) == pgcode.UndefinedTable {
+		return false, nil
+	}
+	return false, err
+}
+
 // runClusterDoctor runs the doctors tool reading data from a live cluster.
 func runClusterDoctor(
 	_ *cobra.Command, _ []string, sqlConn *sqlConn, out io.Writer, timeout time.Duration,
@@ -212,6 +229,11 @@ FROM system.namespace`
 	}
 
 	stmt = `SELECT id, status, payload, progress FROM system.jobs`
+	if hasJobInfo, err := hasJobInfoTable(sqlConn); err != nil {
+		return err
+	} else if hasJobInfo {
+		stmt = `SELECT id, status, payload, ` + jobs.JobInfoProgressColumn + ` FROM system.jobs`
+	}
 	jobsTable := make(doctor.JobsTable, 0)
 
 	if err := selectRowsMap(sqlConn, stmt, make([]driver.Value, 4), func(vals []driver.Value) error {
@@ -305,6 +327,33 @@ func runZipDirDoctor(cmd *cobra.Command, args []string) (retErr error) {
 		return err
 	}
 
+	// Debug zips of clusters running 21.1 or later contain the progress of jobs
+	// updated after the upgrade in system.job_info.
+	progressByJobID := make(map[int][]byte)
+	if jobInfoFile, err := os.Open(path.Join(args[0], "system.job_info.txt")); err == nil {
+		defer jobInfoFile.Close()
+		if err := tableMap(jobInfoFile, func(row string) error {
+			fields := strings.Fields(row)
+			if fields[1] != "progress" {
+				return nil
+			}
+			id, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return errors.Errorf("failed to parse job id %s: %v", fields[0], err)
+			}
+			progressBytes, err := hx.DecodeString(fields[len(fields)-1])
+			if err != nil {
+				return errors.Errorf("job %d: failed to decode hex progress: %v", id, err)
+			}
+			progressByJobID[id] = progressBytes
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else if !oserror.IsNotExist(err) {
+		return err
+	}
+
 	jobsFile, err := os.Open(path.Join(args[0], "system.jobs.txt"))
 	if err != nil {
 		return err
@@ -339,6 +388,9 @@ func runZipDirDoctor(cmd *cobra.Command, args []string) (retErr error) {
 		if err != nil {
 			return errors.Errorf("job %d: failed to decode hex progress: %v", id, err)
 		}
+		if b, ok := progressByJobID[id]; ok {
+			progressBytes = b
+		}
 		md.Progress = &jobspb.Progress{}
 		if err := protoutil.Unmarshal(progressBytes, md.Progress); err != nil {
 			return errors.Wrap(err, "failed unmarshalling job progress")
@@ -13,6 +13,8 @@ package cli
 import (
 	"context"
 	"crypto/x509"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -20,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -66,45 +69,21 @@ func (f *formattedError) Error() string {
 	}
 	var buf strings.Builder
 
-	// If the severity is missing, we're going to assume it's an error.
-	severity := "ERROR"
-
-	// Extract the fields.
-	var message, hint, detail, location, constraintName string
-	var code pgcode.Code
-	if pqErr := (*pq.Error)(nil); errors.As(f.err, &pqErr) {
-		if pqErr.Severity != "" {
-			severity = pqErr.Severity
-		}
-		constraintName = pqErr.Constraint
-		message = pqErr.Message
-		code = pgcode.MakeCode(string(pqErr.Code))
-		hint, detail = pqErr.Hint, pqErr.Detail
-		location = formatLocation(pqErr.File, pqErr.Line, pqErr.Routine)
-	} else {
-		message = f.err.Error()
-		code = pgerror.GetPGCode(f.err)
-		// Extract the standard hint and details.
-		hint = errors.FlattenHints(f.err)
-		detail = errors.FlattenDetails(f.err)
-		if file, line, fn, ok := errors.GetOneLineSource(f.err); ok {
-			location = formatLocation(file, strconv.FormatInt(int64(line), 10), fn)
-		}
-	}
+	ef := extractErrorFields(f.err)
 
 	// The order of the printing goes from most to less important.
 
-	if f.showSeverity && severity != "" {
-		fmt.Fprintf(&buf, "%s: ", severity)
+	if f.showSeverity && ef.severity != "" {
+		fmt.Fprintf(&buf, "%s: ", ef.severity)
 	}
-	fmt.Fprintln(&buf, message)
+	fmt.Fprintln(&buf, ef.message)
 
 	// Avoid printing the code for NOTICE, as the code is always 00000.
-	if severity != "NOTICE" && code.String() != "" {
+	if ef.severity != "NOTICE" && ef.code.String() != "" {
 		// In contrast to `psql` we print the code even when printing
 		// non-verbosely, because we want to promote users reporting codes
 		// when interacting with support.
-		if code == pgcode.Uncategorized && !f.verbose {
+		if ef.code == pgcode.Uncategorized && !f.verbose {
 			// An exception is made for the "uncategorized" code, because we
 			// also don't want users to get the idea they can rely on XXUUU
 			// in their apps. That code is special, as we typically seek to
@@ -113,21 +92,21 @@ func (f *formattedError) Error() string {
 			// So in this case, if not printing verbosely, we don't display
 			// the code.
 		} else {
-			fmt.Fprintln(&buf, "SQLSTATE:", code)
+			fmt.Fprintln(&buf, "SQLSTATE:", ef.code)
 		}
 	}
 
-	if detail != "" {
-		fmt.Fprintln(&buf, "DETAIL:", detail)
+	if ef.detail != "" {
+		fmt.Fprintln(&buf, "DETAIL:", ef.detail)
 	}
-	if constraintName != "" {
-		fmt.Fprintln(&buf, "CONSTRAINT:", constraintName)
+	if ef.constraintName != "" {
+		fmt.Fprintln(&buf, "CONSTRAINT:", ef.constraintName)
 	}
-	if hint != "" {
-		fmt.Fprintln(&buf, "HINT:", hint)
+	if ef.hint != "" {
+		fmt.Fprintln(&buf, "HINT:", ef.hint)
 	}
-	if f.verbose && location != "" {
-		fmt.Fprintln(&buf, "LOCATION:", location)
+	if f.verbose && ef.location != "" {
+		fmt.Fprintln(&buf, "LOCATION:", ef.location)
 	}
 
 	// The code above is easier to read and write by stripping the
@@ -136,6 +115,162 @@ func (f *formattedError) Error() string {
 	return strings.TrimRight(buf.String(), "\n")
 }
 
+// errorFields are the fields of an error displayed by cliOutputError.
+type errorFields struct {
+	severity, message, hint, detail, location, constraintName string
+	code                                                      pgcode.Code
+}
+
+func extractErrorFields(err error) errorFields {
+	// If the severity is missing, we're going to assume it's an error.
+	ef := errorFields{severity: "ERROR"}
+	if pqErr := (*pq.Error)(nil); errors.As(err, &pqErr) {
+		if pqErr.Severity != "" {
+			ef.severity = pqErr.Severity
+		}
+		ef.constraintName = pqErr.Constraint
+		ef.message = pqErr.Message
+		ef.code = pgcode.MakeCode(string(pqErr.Code))
+		ef.hint, ef.detail = pqErr.Hint, pqErr.Detail
+		ef.location = formatLocation(pqErr.File, pqErr.Line, pqErr.Routine)
+	} else {
+		ef.message = err.Error()
+		ef.code = pgerror.GetPGCode(err)
+		// Extract the standard hint and details.
+		ef.hint = errors.FlattenHints(err)
+		ef.detail = errors.FlattenDetails(err)
+		if file, line, fn, ok := errors.GetOneLineSource(err); ok {
+			ef.location = formatLocation(file, strconv.FormatInt(int64(line), 10), fn)
+		}
+	}
+	return ef
+}
+
+// cliOutputErrorJSON prints out an error object on the given writer as a
+// single-line JSON object, for use by automation. The object contains
+// the class of the error, which is derived from the exit code of the
+// command, alongside the fields printed by cliOutputError.
+func cliOutputErrorJSON(w io.Writer, err error, cmdName string, exitCode exit.Code) {
+	// The error may have been wrapped for display already; extract the
+	// error it displays to report its fields individually.
+	for f := (*formattedError)(nil); errors.As(err, &f); {
+		err = f.err
+	}
+	ef := extractErrorFields(err)
+	type jsonError struct {
+		Class      string      `json:"class"`
+		ExitCode   json.Number `json:"exit_code"`
+		Command    string      `json:"command"`
+		Severity   string      `json:"severity"`
+		Message    string      `json:"message"`
+		SQLState   string      `json:"sqlstate,omitempty"`
+		Detail     string      `json:"detail,omitempty"`
+		Constraint string      `json:"constraint,omitempty"`
+		Hint       string      `json:"hint,omitempty"`
+	}
+	e := jsonError{
+		Class:      errorClass(exitCode),
+		ExitCode:   json.Number(exitCode.String()),
+		Command:    cmdName,
+		Severity:   ef.severity,
+		Message:    ef.message,
+		Detail:     ef.detail,
+		Constraint: ef.constraintName,
+		Hint:       ef.hint,
+	}
+	if ef.code != pgcode.Uncategorized {
+		e.SQLState = ef.code.String()
+	}
+	b, jsonErr := json.Marshal(struct {
+		Error jsonError `json:"error"`
+	}{e})
+	if jsonErr != nil {
+		// This can't happen with the types above; fall back to the text
+		// output if it does.
+		cliOutputError(w, err, true /*showSeverity*/, false /*verbose*/)
+		return
+	}
+	fmt.Fprintln(w, string(b))
+}
+
+// errorExitCode returns the exit code of a command which failed with
+// err: the code specified by the command with a cliError, if any, or
+// else the code corresponding to the class of the error.
+func errorExitCode(err error) exit.Code {
+	if cliErr := (*cliError)(nil); errors.As(err, &cliErr) {
+		return cliErr.exitCode
+	}
+
+	// Errors which prevented the connection to the server from being
+	// established or authenticated.
+	if errors.Is(err, pq.ErrSSLNotSupported) || errors.Is(err, driver.ErrBadConn) {
+		return exit.ConnectionError()
+	}
+	if wErr := (*security.Error)(nil); errors.As(err, &wErr) {
+		return exit.AuthenticationError()
+	}
+	if wErr := (*x509.UnknownAuthorityError)(nil); errors.As(err, &wErr) {
+		return exit.AuthenticationError()
+	}
+	if wErr := (*initialSQLConnectionError)(nil); errors.As(err, &wErr) {
+		return exit.ConnectionError()
+	}
+	if wErr := (*pq.Error)(nil); errors.As(err, &wErr) {
+		switch wErr.Code.Class() {
+		case "28":
+			// Invalid authorization specification.
+			return exit.AuthenticationError()
+		case "08":
+			// Connection exception.
+			return exit.ConnectionError()
+		}
+		return exit.SQLError()
+	}
+	if wErr := (*net.OpError)(nil); errors.As(err, &wErr) {
+		if strings.HasPrefix(wErr.Err.Error(), "tls: ") {
+			return exit.AuthenticationError()
+		}
+		return exit.ConnectionError()
+	}
+	if wErr := (*netutil.InitialHeartbeatFailedError)(nil); errors.As(err, &wErr) {
+		if reGRPCAuthFailure.MatchString(wErr.Error()) {
+			return exit.AuthenticationError()
+		}
+		return exit.ConnectionError()
+	}
+	switch status.Code(errors.Cause(err)) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return exit.AuthenticationError()
+	case codes.Unavailable:
+		return exit.ConnectionError()
+	}
+	if grpcutil.IsClosedConnection(err) {
+		return exit.ConnectionError()
+	}
+	return exit.UnspecifiedError()
+}
+
+// errorClass returns the name of the class of errors which cause a
+// command to terminate with the given exit code.
+func errorClass(exitCode exit.Code) string {
+	switch exitCode {
+	case exit.ConnectionError():
+		return "connection"
+	case exit.AuthenticationError():
+		return "authentication"
+	case exit.SQLError():
+		return "sql"
+	case exit.PartialSuccess():
+		return "partial_success"
+	case exit.CommandLineFlagError():
+		return "command_line"
+	case exit.Interrupted():
+		return "interrupted"
+	default:
+		return "unspecified"
+	}
+}
+
 // formatLocation spells out the error's location in a format
 // similar to psql: routine then file:num. The routine part is
 // skipped if empty.
@@ -197,6 +332,10 @@ func MaybeDecorateGRPCError(
 			return nil
 		}
 
+		// The decorations below do not preserve the original error, so
+		// its exit code is determined first.
+		exitCode := errorExitCode(err)
+
 		defer func() {
 			// We want to flatten the error to reveal the hints, details etc.
 			// However we can't do it twice, so we need to detect first if
@@ -205,6 +344,7 @@ func MaybeDecorateGRPCError(
 			if !errors.As(err, &f) {
 				err = &formattedError{err: err, showSeverity: true}
 			}
+			err = &cliError{exitCode: exitCode, cause: err}
 		}()
 
 		extraInsecureHint := func() string {
@@ -13,6 +13,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -85,6 +87,68 @@ func TestOutputError(t *testing.T) {
 	}
 }
 
+func TestOutputErrorJSON(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		err      error
+		exitCode exit.Code
+		exp      string
+	}{
+		{errors.New("woo"), exit.UnspecifiedError(),
+			`{"error":{"class":"unspecified","exit_code":1,"command":"sql","severity":"ERROR","message":"woo"}}`},
+		{&pq.Error{Code: "42P01", Message: "relation \"t\" does not exist", Hint: "hello"}, exit.SQLError(),
+			`{"error":{"class":"sql","exit_code":11,"command":"sql","severity":"ERROR","message":"relation \"t\" does not exist","sqlstate":"42P01","hint":"hello"}}`},
+		// The fields of errors already wrapped for display are reported
+		// individually.
+		{&cliError{
+			exitCode: exit.PartialSuccess(),
+			cause:    &formattedError{err: errors.WithDetail(errors.New("woo"), "hello"), showSeverity: true},
+		}, exit.PartialSuccess(),
+			`{"error":{"class":"partial_success","exit_code":12,"command":"sql","severity":"ERROR","message":"woo","detail":"hello"}}`},
+	}
+
+	for _, tc := range testData {
+		var buf strings.Builder
+		cliOutputErrorJSON(&buf, tc.err, "sql", tc.exitCode)
+		assert.Equal(t, tc.exp+"\n", buf.String())
+	}
+}
+
+func TestErrorExitCode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		err error
+		exp exit.Code
+	}{
+		{errors.New("woo"), exit.UnspecifiedError()},
+		{&cliError{exitCode: exit.DoctorValidationFailed(), cause: errors.New("woo")}, exit.DoctorValidationFailed()},
+		{errors.Wrap(&pq.Error{Code: "42P01"}, "woo"), exit.SQLError()},
+		{&pq.Error{Code: "28P01"}, exit.AuthenticationError()},
+		{&pq.Error{Code: "08006"}, exit.ConnectionError()},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, exit.ConnectionError()},
+		{&net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")}, exit.AuthenticationError()},
+		{&initialSQLConnectionError{errors.New("woo")}, exit.ConnectionError()},
+		{pq.ErrSSLNotSupported, exit.ConnectionError()},
+	}
+
+	for _, tc := range testData {
+		assert.Equal(t, tc.exp, errorExitCode(tc.err), "%v", tc.err)
+	}
+
+	// The exit code is preserved by MaybeDecorateGRPCError, even though
+	// the error is decorated.
+	fn := MaybeDecorateGRPCError(func(*cobra.Command, []string) error {
+		return &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	})
+	err := fn(nil, nil)
+	assert.Regexp(t, "cannot dial server", err.Error())
+	assert.Equal(t, exit.ConnectionError(), errorExitCode(err))
+}
+
 func TestFormatLocation(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -54,6 +54,24 @@ func FatalError() Code { return Code{7} }
 // in the logging system.
 func TimeoutAfterFatalError() Code { return Code{8} }
 
+// ConnectionError (9) indicates that a client command could not
+// connect to the server, or lost its connection to the server.
+func ConnectionError() Code { return Code{9} }
+
+// AuthenticationError (10) indicates that a client command could not
+// authenticate to the server, for example because of an invalid
+// password or certificate.
+func AuthenticationError() Code { return Code{10} }
+
+// SQLError (11) indicates that a client command was connected to the
+// server, but a SQL statement it executed failed.
+func SQLError() Code { return Code{11} }
+
+// PartialSuccess (12) indicates that a client command completed, but
+// some of the operations it performed failed; for example, some of
+// the files of 'debug zip' could not be retrieved.
+func PartialSuccess() Code { return Code{12} }
+
 // Codes that are specific to client commands follow. It's possible
 // for codes to be reused across separate client or server commands.
 // Command-specific exit codes should be allocated down from 125.
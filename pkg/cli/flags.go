@@ -116,6 +116,14 @@ func intFlag(f *pflag.FlagSet, valPtr *int, flagInfo cliflags.FlagInfo) {
 	registerEnvVarDefault(f, flagInfo)
 }
 
+// int64Flag creates an int64 flag and registers it with the FlagSet.
+// The default value is taken from the variable pointed to by valPtr.
+// See context.go to initialize defaults.
+func int64Flag(f *pflag.FlagSet, valPtr *int64, flagInfo cliflags.FlagInfo) {
+	f.Int64VarP(valPtr, flagInfo.Name, flagInfo.Shorthand, *valPtr, flagInfo.Usage())
+	registerEnvVarDefault(f, flagInfo)
+}
+
 // boolFlag creates a bool flag and registers it with the FlagSet.
 // The default value is taken from the variable pointed to by valPtr.
 // See context.go to initialize defaults.
@@ -539,6 +547,8 @@ func init() {
 	clientCmds = append(clientCmds, userFileCmds...)
 	clientCmds = append(clientCmds, stmtDiagCmds...)
 	clientCmds = append(clientCmds, debugResetQuorumCmd)
+	clientCmds = append(clientCmds, debugDiagnosticsReportCmd)
+	clientCmds = append(clientCmds, debugFuzzSQLCmd)
 	for _, cmd := range clientCmds {
 		f := cmd.PersistentFlags()
 		varFlag(f, addrSetter{&cliCtx.clientConnHost, &cliCtx.clientConnPort}, cliflags.ClientHost)
@@ -615,8 +625,20 @@ func init() {
 		f := debugZipCmd.Flags()
 		varFlag(f, &zipCtx.nodes.inclusive, cliflags.ZipNodes)
 		varFlag(f, &zipCtx.nodes.exclusive, cliflags.ZipExcludeNodes)
+		stringSliceFlag(f, &zipCtx.tables.include, cliflags.ZipIncludeTables)
+		stringSliceFlag(f, &zipCtx.tables.exclude, cliflags.ZipExcludeTables)
 		boolFlag(f, &zipCtx.redactLogs, cliflags.ZipRedactLogs)
-		durationFlag(f, &zipCtx.cpuProfDuration, cliflags.ZipCPUProfileDuration)
+		boolFlag(f, &zipCtx.redact, cliflags.ZipRedact)
+		durationFlag(f, &zipCtx.profDuration, cliflags.ZipProfileDuration)
+		// Pre-v21.1 name of --profile-duration. Deprecated.
+		// TODO(knz): Remove this.
+		durationFlag(f, &zipCtx.profDuration, cliflags.DeprecatedZipCPUProfileDuration)
+		_ = f.MarkDeprecated(cliflags.DeprecatedZipCPUProfileDuration.Name,
+			"use --"+cliflags.ZipProfileDuration.Name+" instead.")
+		intFlag(f, &zipCtx.concurrency, cliflags.ZipConcurrency)
+		// The zip command does not display tables, but --format=json
+		// selects the JSON output of errors like for the other commands.
+		varFlag(f, &cliCtx.tableDisplayFormat, cliflags.TableDisplayFormat)
 	}
 
 	// Decommission command.
@@ -641,6 +663,7 @@ func init() {
 		varFlag(f, &sqlCtx.execStmts, cliflags.Execute)
 		stringFlag(f, &sqlCtx.inputFile, cliflags.File)
 		durationFlag(f, &sqlCtx.repeatDelay, cliflags.Watch)
+		intFlag(f, &sqlCtx.retries, cliflags.SQLRetries)
 		boolFlag(f, &sqlCtx.safeUpdates, cliflags.SafeUpdates)
 		boolFlag(f, &sqlCtx.debugMode, cliflags.CliDebugMode)
 	}
@@ -652,6 +675,8 @@ func init() {
 		doctorClusterCmd,
 		lsNodesCmd,
 		statusNodeCmd,
+		debugDiagnosticsReportCmd,
+		debugFuzzSQLCmd,
 	}
 	sqlCmds = append(sqlCmds, authCmds...)
 	sqlCmds = append(sqlCmds, demoCmd.Commands()...)
@@ -704,7 +729,7 @@ func init() {
 
 	// Commands that print tables.
 	tableOutputCommands := append(
-		[]*cobra.Command{sqlShellCmd, genSettingsListCmd, demoCmd, debugTimeSeriesDumpCmd},
+		[]*cobra.Command{sqlShellCmd, genSettingsListCmd, demoCmd},
 		demoCmd.Commands()...)
 	tableOutputCommands = append(tableOutputCommands, nodeCmds...)
 	tableOutputCommands = append(tableOutputCommands, authCmds...)
@@ -792,15 +817,23 @@ func init() {
 		boolFlag(f, &debugCtx.values, cliflags.Values)
 		boolFlag(f, &debugCtx.sizes, cliflags.Sizes)
 		stringFlag(f, &debugCtx.decodeAsTableDesc, cliflags.DecodeAsTable)
+		boolFlag(f, &debugCtx.decodeSQLRows, cliflags.DecodeSQLRows)
+		stringFlag(f, &debugCtx.descriptorsFile, cliflags.DescriptorsFile)
 	}
 	{
 		f := debugCheckLogConfigCmd.Flags()
 		varFlag(f, &serverCfg.Stores, cliflags.Store)
 	}
+	for _, cmd := range []*cobra.Command{debugRecoverCollectInfoCmd, debugRecoverApplyPlanCmd} {
+		f := cmd.Flags()
+		varFlag(f, &serverCfg.Stores, cliflags.Store)
+	}
 	{
 		f := debugRangeDataCmd.Flags()
 		boolFlag(f, &debugCtx.replicated, cliflags.Replicated)
 		intFlag(f, &debugCtx.maxResults, cliflags.Limit)
+		boolFlag(f, &debugCtx.decodeSQLRows, cliflags.DecodeSQLRows)
+		stringFlag(f, &debugCtx.descriptorsFile, cliflags.DescriptorsFile)
 	}
 	{
 		f := debugGossipValuesCmd.Flags()
@@ -811,6 +844,25 @@ func init() {
 		f := debugBallastCmd.Flags()
 		varFlag(f, &debugCtx.ballastSize, cliflags.Size)
 	}
+	{
+		f := debugDiagnosticsReportCmd.Flags()
+		boolFlag(f, &debugCtx.diagnosticsDryRun, cliflags.DiagnosticsReportDryRun)
+	}
+	{
+		f := debugFuzzSQLCmd.Flags()
+		int64Flag(f, &fuzzSQLCtx.seed, cliflags.FuzzSQLSeed)
+		intFlag(f, &fuzzSQLCtx.numStatements, cliflags.FuzzSQLNumStatements)
+		stringFlag(f, &fuzzSQLCtx.setup, cliflags.FuzzSQLSetup)
+		stringFlag(f, &fuzzSQLCtx.setting, cliflags.FuzzSQLSetting)
+		boolFlag(f, &fuzzSQLCtx.verbose, cliflags.FuzzSQLVerbose)
+	}
+	{
+		f := debugTimeSeriesDumpCmd.Flags()
+		varFlag(f, &tsDumpCtx.format, cliflags.TSDumpFormat)
+		stringSliceFlag(f, &tsDumpCtx.metrics, cliflags.TSDumpMetrics)
+		varFlag(f, &tsDumpCtx.from, cliflags.TSDumpFrom)
+		varFlag(f, &tsDumpCtx.to, cliflags.TSDumpTo)
+	}
 
 	// Multi-tenancy commands.
 	{
@@ -17,6 +17,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -168,6 +169,76 @@ func (m *dumpMode) Set(s string) error {
 	return nil
 }
 
+type tsDumpFormat int
+
+const (
+	tsDumpText tsDumpFormat = iota
+	tsDumpCSV
+	tsDumpTSV
+	tsDumpOpenMetrics
+)
+
+// Type implements the pflag.Value interface.
+func (m *tsDumpFormat) Type() string { return "string" }
+
+// String implements the pflag.Value interface.
+func (m *tsDumpFormat) String() string {
+	switch *m {
+	case tsDumpText:
+		return "text"
+	case tsDumpCSV:
+		return "csv"
+	case tsDumpTSV:
+		return "tsv"
+	case tsDumpOpenMetrics:
+		return "openmetrics"
+	}
+	return ""
+}
+
+// Set implements the pflag.Value interface.
+func (m *tsDumpFormat) Set(s string) error {
+	switch s {
+	case "text":
+		*m = tsDumpText
+	case "csv":
+		*m = tsDumpCSV
+	case "tsv":
+		*m = tsDumpTSV
+	case "openmetrics":
+		*m = tsDumpOpenMetrics
+	default:
+		return fmt.Errorf("invalid value for --format: %s "+
+			"(possible values: text, csv, tsv, openmetrics)", s)
+	}
+	return nil
+}
+
+// timestampValue is a flag that accepts a time in RFC3339 format. The zero
+// value means that the flag was not set.
+type timestampValue time.Time
+
+// Type implements the pflag.Value interface.
+func (t *timestampValue) Type() string { return "timestamp" }
+
+// String implements the pflag.Value interface.
+func (t *timestampValue) String() string {
+	if (*time.Time)(t).IsZero() {
+		return ""
+	}
+	return (*time.Time)(t).Format(time.RFC3339)
+}
+
+// Set implements the pflag.Value interface.
+func (t *timestampValue) Set(s string) error {
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*t = timestampValue(v)
+	return nil
+}
+
 type mvccKey storage.MVCCKey
 
 // Type implements the pflag.Value interface.
@@ -324,6 +395,7 @@ const (
 	tableDisplaySQL
 	tableDisplayHTML
 	tableDisplayRaw
+	tableDisplayJSON
 	tableDisplayLastFormat
 )
 
@@ -347,6 +419,8 @@ func (f *tableDisplayFormat) String() string {
 		return "html"
 	case tableDisplayRaw:
 		return "raw"
+	case tableDisplayJSON:
+		return "json"
 	}
 	return ""
 }
@@ -368,9 +442,11 @@ func (f *tableDisplayFormat) Set(s string) error {
 		*f = tableDisplayHTML
 	case "raw":
 		*f = tableDisplayRaw
+	case "json":
+		*f = tableDisplayJSON
 	default:
 		return fmt.Errorf("invalid table display format: %s "+
-			"(possible values: tsv, csv, table, records, sql, html, raw)", s)
+			"(possible values: tsv, csv, table, records, sql, html, raw, json)", s)
 	}
 	return nil
 }
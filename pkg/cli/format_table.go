@@ -12,6 +12,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"html"
 	"io"
@@ -606,6 +607,48 @@ func (p *sqlReporter) doneRows(w io.Writer, seenRows int) error {
 	return nil
 }
 
+// jsonReporter prints each row as a JSON object on its own line, with
+// the column names as keys, in the order of the columns.
+type jsonReporter struct {
+	cols []string
+}
+
+func (p *jsonReporter) describe(w io.Writer, cols []string) error {
+	p.cols = make([]string, len(cols))
+	for i, col := range cols {
+		b, err := json.Marshal(col)
+		if err != nil {
+			return err
+		}
+		p.cols[i] = string(b)
+	}
+	return nil
+}
+
+func (p *jsonReporter) iter(w io.Writer, _ int, row []string) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, r := range row {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(p.cols[i])
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+	buf.WriteString("}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (p *jsonReporter) beforeFirstRow(_ io.Writer, _ rowStrIter) error { return nil }
+func (p *jsonReporter) doneNoRows(_ io.Writer) error                   { return nil }
+func (p *jsonReporter) doneRows(_ io.Writer, _ int) error              { return nil }
+
 // makeReporter instantiates a table formatter. It returns the
 // formatter and a cleanup function that must be called in all cases
 // when the formatting completes.
@@ -632,6 +675,9 @@ func makeReporter(w io.Writer) (rowReporter, func(), error) {
 	case tableDisplaySQL:
 		return &sqlReporter{}, nil, nil
 
+	case tableDisplayJSON:
+		return &jsonReporter{}, nil, nil
+
 	default:
 		return nil, nil, errors.Errorf("unhandled display format: %d", cliCtx.tableDisplayFormat)
 	}
@@ -257,6 +257,7 @@ var genCmds = []*cobra.Command{
 	genHAProxyCmd,
 	genSettingsListCmd,
 	genEncryptionKeyCmd,
+	genMigrateSchemaCmd,
 }
 
 func init() {
@@ -271,6 +272,10 @@ func init() {
 		"AES key size for encryption at rest (one of: 128, 192, 256)")
 	genEncryptionKeyCmd.PersistentFlags().BoolVar(&overwriteKey, "overwrite", false,
 		"Overwrite key if it exists")
+	genMigrateSchemaCmd.PersistentFlags().StringVar(&migrateSchemaSource, "source", "",
+		"connection URL of the Postgres database to introspect")
+	genMigrateSchemaCmd.PersistentFlags().StringVar(&migrateSchemaName, "schema", "public",
+		"name of the Postgres schema to translate")
 
 	genCmd.AddCommand(genCmds...)
 }
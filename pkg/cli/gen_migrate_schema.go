@@ -0,0 +1,538 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+	"github.com/spf13/cobra"
+)
+
+var migrateSchemaSource string
+var migrateSchemaName string
+
+var genMigrateSchemaCmd = &cobra.Command{
+	Use:   "migrate-schema --source=<postgres-url>",
+	Short: "generate CockroachDB DDL from an existing Postgres schema",
+	Long: `
+Connect to the Postgres database specified by --source, introspect the schema
+specified by --schema, and output CockroachDB-compatible DDL that recreates its
+enum types, sequences, tables, constraints, indexes and views.
+
+The output starts with a report of the features that could not be translated,
+such as triggers, functions and unsupported column types. Statements that
+CockroachDB cannot parse are included as comments. Review the output before
+running it against a CockroachDB cluster.
+`,
+	Example: `  cockroach gen migrate-schema --source='postgres://user@host:5432/db' > schema.sql`,
+	Args:    cobra.NoArgs,
+	RunE:    runGenMigrateSchema,
+}
+
+func runGenMigrateSchema(cmd *cobra.Command, args []string) error {
+	if migrateSchemaSource == "" {
+		return errors.New("--source must be specified")
+	}
+	db, err := gosql.Open("postgres", migrateSchemaSource)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	desc, err := describePostgresSchema(context.Background(), db, migrateSchemaName)
+	if err != nil {
+		return errors.Wrap(err, "introspecting the source schema")
+	}
+	stmts, report := generateMigrationDDL(desc)
+	return writeMigrationDDL(os.Stdout, stmts, report)
+}
+
+// pgSchemaDesc describes the objects of a Postgres schema from which
+// CockroachDB DDL is generated.
+type pgSchemaDesc struct {
+	name      string
+	enums     []pgEnum
+	sequences []pgSequence
+	tables    []pgTable
+	views     []pgView
+	// unsupported lists the objects that have no CockroachDB equivalent, such
+	// as triggers and functions. They only appear in the report.
+	unsupported []string
+}
+
+type pgEnum struct {
+	name   string
+	labels []string
+}
+
+type pgSequence struct {
+	name                       string
+	start, increment, min, max int64
+}
+
+type pgTable struct {
+	name string
+	// partitioned is set for tables using declarative partitioning.
+	partitioned bool
+	columns     []pgColumn
+	constraints []pgConstraint
+	// indexes contains the definitions of the indexes that don't back a
+	// constraint, as returned by pg_get_indexdef.
+	indexes []string
+}
+
+type pgColumn struct {
+	name string
+	// typ is the type of the column as returned by format_type. For columns
+	// using a domain, domainBase is the type of the domain.
+	typ        string
+	domainBase string
+	notNull    bool
+	// def is the default expression of the column, if any.
+	def string
+	// identitySeq is the name of the sequence backing an identity column.
+	identitySeq string
+}
+
+type pgConstraint struct {
+	name string
+	// kind is the contype of the constraint in pg_constraint: 'p' for
+	// primary keys, 'u' for unique constraints, 'c' for check constraints,
+	// 'f' for foreign keys and 'x' for exclusion constraints.
+	kind byte
+	// def is the definition of the constraint as returned by
+	// pg_get_constraintdef.
+	def string
+}
+
+type pgView struct {
+	name         string
+	materialized bool
+	query        string
+}
+
+// pgTypeReplacements maps Postgres types that CockroachDB doesn't support to
+// the closest supported type. Other unsupported types are replaced by STRING.
+var pgTypeReplacements = map[string]string{
+	"money": "DECIMAL(19,2)",
+	"cidr":  "INET",
+}
+
+// describePostgresSchema introspects the given schema of a Postgres database.
+func describePostgresSchema(
+	ctx context.Context, db *gosql.DB, schema string,
+) (*pgSchemaDesc, error) {
+	desc := &pgSchemaDesc{name: schema}
+
+	if err := forEachRow(ctx, db, `
+SELECT t.typname, array_agg(e.enumlabel ORDER BY e.enumsortorder)
+  FROM pg_type t
+  JOIN pg_namespace n ON n.oid = t.typnamespace
+  JOIN pg_enum e ON e.enumtypid = t.oid
+ WHERE n.nspname = $1
+ GROUP BY t.typname
+ ORDER BY t.typname`, []interface{}{schema}, func(rows *gosql.Rows) error {
+		var e pgEnum
+		if err := rows.Scan(&e.name, pq.Array(&e.labels)); err != nil {
+			return err
+		}
+		desc.enums = append(desc.enums, e)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := forEachRow(ctx, db, `
+SELECT sequencename, start_value, increment_by, min_value, max_value
+  FROM pg_sequences
+ WHERE schemaname = $1
+ ORDER BY sequencename`, []interface{}{schema}, func(rows *gosql.Rows) error {
+		var s pgSequence
+		if err := rows.Scan(&s.name, &s.start, &s.increment, &s.min, &s.max); err != nil {
+			return err
+		}
+		desc.sequences = append(desc.sequences, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// tableOIDs contains the OID of each table in desc.tables.
+	var tableOIDs []int64
+	if err := forEachRow(ctx, db, `
+SELECT c.oid, c.relname, c.relkind = 'p', c.relispartition
+  FROM pg_class c
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relkind IN ('r', 'p')
+ ORDER BY c.relname`, []interface{}{schema}, func(rows *gosql.Rows) error {
+		var oid int64
+		var isPartition bool
+		var t pgTable
+		if err := rows.Scan(&oid, &t.name, &t.partitioned, &isPartition); err != nil {
+			return err
+		}
+		if isPartition {
+			desc.unsupported = append(desc.unsupported, fmt.Sprintf(
+				"table %s is a partition of another table and was skipped", t.name))
+			return nil
+		}
+		desc.tables = append(desc.tables, t)
+		tableOIDs = append(tableOIDs, oid)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i, oid := range tableOIDs {
+		table := &desc.tables[i]
+		if err := forEachRow(ctx, db, `
+SELECT a.attname,
+       format_type(a.atttypid, a.atttypmod),
+       CASE WHEN ty.typtype = 'd' THEN format_type(ty.typbasetype, ty.typtypmod) ELSE '' END,
+       a.attnotnull,
+       COALESCE(pg_get_expr(d.adbin, d.adrelid), ''),
+       CASE WHEN a.attidentity <> ''
+         THEN COALESCE(pg_get_serial_sequence(quote_ident($2) || '.' || quote_ident($3), a.attname), '')
+         ELSE '' END
+  FROM pg_attribute a
+  JOIN pg_type ty ON ty.oid = a.atttypid
+  LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+ WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+ ORDER BY a.attnum`, []interface{}{oid, schema, table.name}, func(rows *gosql.Rows) error {
+			var c pgColumn
+			if err := rows.Scan(
+				&c.name, &c.typ, &c.domainBase, &c.notNull, &c.def, &c.identitySeq,
+			); err != nil {
+				return err
+			}
+			table.columns = append(table.columns, c)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := forEachRow(ctx, db, `
+SELECT conname, contype, pg_get_constraintdef(oid)
+  FROM pg_constraint
+ WHERE conrelid = $1 AND contype IN ('p', 'u', 'c', 'f', 'x')
+ ORDER BY contype = 'f', conname`, []interface{}{oid}, func(rows *gosql.Rows) error {
+			var c pgConstraint
+			var kind string
+			if err := rows.Scan(&c.name, &kind, &c.def); err != nil {
+				return err
+			}
+			c.kind = kind[0]
+			table.constraints = append(table.constraints, c)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := forEachRow(ctx, db, `
+SELECT pg_get_indexdef(i.indexrelid)
+  FROM pg_index i
+ WHERE i.indrelid = $1
+   AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid)
+ ORDER BY i.indexrelid`, []interface{}{oid}, func(rows *gosql.Rows) error {
+			var def string
+			if err := rows.Scan(&def); err != nil {
+				return err
+			}
+			table.indexes = append(table.indexes, def)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := forEachRow(ctx, db, `
+SELECT c.relname, c.relkind = 'm', pg_get_viewdef(c.oid)
+  FROM pg_class c
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND c.relkind IN ('v', 'm')
+ ORDER BY c.oid`, []interface{}{schema}, func(rows *gosql.Rows) error {
+		var v pgView
+		if err := rows.Scan(&v.name, &v.materialized, &v.query); err != nil {
+			return err
+		}
+		desc.views = append(desc.views, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Finally, collect the objects that cannot be translated at all.
+	for _, q := range []struct {
+		query  string
+		format string
+		args   []interface{}
+	}{
+		{
+			query: `
+SELECT c.relname || '.' || t.tgname
+  FROM pg_trigger t
+  JOIN pg_class c ON c.oid = t.tgrelid
+  JOIN pg_namespace n ON n.oid = c.relnamespace
+ WHERE n.nspname = $1 AND NOT t.tgisinternal
+ ORDER BY 1`,
+			format: "trigger %s is not supported",
+			args:   []interface{}{schema},
+		},
+		{
+			query: `
+SELECT p.proname
+  FROM pg_proc p
+  JOIN pg_namespace n ON n.oid = p.pronamespace
+ WHERE n.nspname = $1
+   AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')
+ ORDER BY 1`,
+			format: "function %s is not supported",
+			args:   []interface{}{schema},
+		},
+		{
+			query:  `SELECT extname FROM pg_extension WHERE extname <> 'plpgsql' ORDER BY 1`,
+			format: "extension %s is not supported",
+		},
+	} {
+		if err := forEachRow(ctx, db, q.query, q.args, func(rows *gosql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			desc.unsupported = append(desc.unsupported, fmt.Sprintf(q.format, name))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return desc, nil
+}
+
+// forEachRow runs the query and calls fn for each of the resulting rows.
+func forEachRow(
+	ctx context.Context,
+	db *gosql.DB,
+	query string,
+	args []interface{},
+	fn func(rows *gosql.Rows) error,
+) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// generateMigrationDDL generates the CockroachDB statements that recreate the
+// objects of the given Postgres schema. It also returns a report of the
+// features that could not be translated faithfully.
+func generateMigrationDDL(desc *pgSchemaDesc) (stmts []string, report []string) {
+	report = append(report, desc.unsupported...)
+	reportf := func(format string, args ...interface{}) {
+		report = append(report, fmt.Sprintf(format, args...))
+	}
+	// addStmt adds a statement to the output if CockroachDB can parse it, and
+	// adds it as a comment otherwise.
+	addStmt := func(stmt string) {
+		if _, err := parser.Parse(stmt); err != nil {
+			reportf("could not translate %q: %v", firstLine(stmt), err)
+			stmt = "-- " + strings.ReplaceAll(stmt, "\n", "\n-- ")
+		}
+		stmts = append(stmts, stmt)
+	}
+	qualify := func(name string) string {
+		return qualifyPostgresName(desc.name, name)
+	}
+
+	if desc.name != "public" {
+		addStmt(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", tree.NameString(desc.name)))
+	}
+
+	enums := make(map[string]bool, len(desc.enums))
+	for _, e := range desc.enums {
+		enums[e.name] = true
+		labels := make([]string, len(e.labels))
+		for i, l := range e.labels {
+			labels[i] = lex.EscapeSQLString(l)
+		}
+		addStmt(fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)",
+			qualify(e.name), strings.Join(labels, ", ")))
+	}
+
+	for _, s := range desc.sequences {
+		addStmt(fmt.Sprintf("CREATE SEQUENCE %s MINVALUE %d MAXVALUE %d INCREMENT %d START %d",
+			qualify(s.name), s.min, s.max, s.increment, s.start))
+	}
+
+	var foreignKeys []string
+	for _, t := range desc.tables {
+		if t.partitioned {
+			reportf("table %s uses declarative partitioning, which was not translated", t.name)
+		}
+		var defs []string
+		for _, c := range t.columns {
+			typ := c.typ
+			if c.domainBase != "" {
+				reportf("column %s.%s uses domain %s, which was replaced by its base type %s",
+					t.name, c.name, c.typ, c.domainBase)
+				typ = c.domainBase
+			}
+			typ, ok := translatePostgresType(typ, desc.name, enums)
+			if !ok {
+				reportf("column %s.%s has unsupported type %s, which was replaced by %s",
+					t.name, c.name, c.typ, typ)
+			}
+			def := fmt.Sprintf("%s %s", tree.NameString(c.name), typ)
+			if c.notNull {
+				def += " NOT NULL"
+			}
+			switch {
+			case c.identitySeq != "":
+				reportf("identity column %s.%s was replaced by a default using sequence %s",
+					t.name, c.name, c.identitySeq)
+				def += fmt.Sprintf(" DEFAULT nextval(%s)", lex.EscapeSQLString(c.identitySeq))
+			case c.def != "":
+				if _, err := parser.ParseExpr(c.def); err != nil {
+					reportf("default expression %q of column %s.%s was dropped: %v",
+						c.def, t.name, c.name, err)
+				} else {
+					def += " DEFAULT " + c.def
+				}
+			}
+			defs = append(defs, def)
+		}
+		for _, c := range t.constraints {
+			def := fmt.Sprintf("CONSTRAINT %s %s", tree.NameString(c.name), c.def)
+			switch c.kind {
+			case 'f':
+				foreignKeys = append(foreignKeys,
+					fmt.Sprintf("ALTER TABLE %s ADD %s", qualify(t.name), def))
+				continue
+			case 'x':
+				reportf("exclusion constraint %s on table %s is not supported", c.name, t.name)
+				continue
+			}
+			if _, err := parser.Parse(fmt.Sprintf("ALTER TABLE t ADD %s", def)); err != nil {
+				reportf("constraint %s on table %s was dropped: %v", c.name, t.name, err)
+				continue
+			}
+			defs = append(defs, def)
+		}
+		addStmt(fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", qualify(t.name), strings.Join(defs, ",\n\t")))
+	}
+	for _, fk := range foreignKeys {
+		addStmt(fk)
+	}
+	for _, t := range desc.tables {
+		for _, idx := range t.indexes {
+			addStmt(idx)
+		}
+	}
+
+	for _, v := range desc.views {
+		kind := "VIEW"
+		if v.materialized {
+			kind = "MATERIALIZED VIEW"
+		}
+		query := strings.TrimSuffix(strings.TrimSpace(v.query), ";")
+		addStmt(fmt.Sprintf("CREATE %s %s AS %s", kind, qualify(v.name), query))
+	}
+	return stmts, report
+}
+
+// qualifyPostgresName returns the name of an object of the given schema, as
+// used in the generated DDL. Objects of the public schema are not qualified.
+func qualifyPostgresName(schema, name string) string {
+	if schema == "public" {
+		return tree.NameString(name)
+	}
+	return tree.NameString(schema) + "." + tree.NameString(name)
+}
+
+// translatePostgresType returns the CockroachDB type to use for a column with
+// the given Postgres type, as returned by format_type. enums contains the
+// unquoted names of the enum types of the given schema. format_type quotes
+// enum names and qualifies them with their schema unless the schema is in the
+// search path, so references to enums are normalized to the name used in the
+// generated CREATE TYPE statement. The second return value is false if the
+// type had to be replaced.
+func translatePostgresType(typ string, schema string, enums map[string]bool) (string, bool) {
+	elem := typ
+	var arraySuffix string
+	for strings.HasSuffix(elem, "[]") {
+		elem = strings.TrimSuffix(elem, "[]")
+		arraySuffix += "[]"
+	}
+	if repl, ok := pgTypeReplacements[elem]; ok {
+		return repl + arraySuffix, false
+	}
+	ref, err := parser.GetTypeFromValidSQLSyntax(elem)
+	if err != nil {
+		return "STRING" + arraySuffix, false
+	}
+	switch t := ref.(type) {
+	case *types.T:
+		return typ, true
+	case *tree.UnresolvedObjectName:
+		if t.NumParts <= 2 && (!t.HasExplicitSchema() || t.Schema() == schema) && enums[t.Object()] {
+			return qualifyPostgresName(schema, t.Object()) + arraySuffix, true
+		}
+	}
+	return "STRING" + arraySuffix, false
+}
+
+// writeMigrationDDL writes the report, as comments, followed by the
+// statements.
+func writeMigrationDDL(w io.Writer, stmts []string, report []string) error {
+	if len(report) > 0 {
+		if _, err := fmt.Fprintln(w, "-- The following features could not be translated:"); err != nil {
+			return err
+		}
+		for _, r := range report {
+			if _, err := fmt.Fprintf(w, "--   - %s\n", strings.ReplaceAll(r, "\n", " ")); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	for _, stmt := range stmts {
+		if _, err := fmt.Fprintf(w, "%s;\n\n", stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " ..."
+	}
+	return s
+}
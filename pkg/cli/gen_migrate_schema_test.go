@@ -0,0 +1,162 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslatePostgresType(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	enums := map[string]bool{"mood": true, "Weather": true}
+	for _, tc := range []struct {
+		typ      string
+		schema   string
+		expected string
+		ok       bool
+	}{
+		{"integer", "public", "integer", true},
+		{"character varying(255)", "public", "character varying(255)", true},
+		{"timestamp with time zone", "public", "timestamp with time zone", true},
+		{"numeric(10,2)", "public", "numeric(10,2)", true},
+		{"text[]", "public", "text[]", true},
+		{"jsonb", "public", "jsonb", true},
+		{"mood", "public", "mood", true},
+		{"mood[]", "public", "mood[]", true},
+		{"public.mood", "public", "mood", true},
+		{`"Weather"`, "public", `"Weather"`, true},
+		{"app.mood", "app", "app.mood", true},
+		{"app.mood[]", "app", "app.mood[]", true},
+		{`app."Weather"`, "app", `app."Weather"`, true},
+		{"mood", "app", "app.mood", true},
+		{"other.mood", "app", "STRING", false},
+		{"weather", "public", "STRING", false},
+		{"money", "public", "DECIMAL(19,2)", false},
+		{"cidr[]", "public", "INET[]", false},
+		{"tsvector", "public", "STRING", false},
+		{"point", "public", "STRING", false},
+		{"int4range[]", "public", "STRING[]", false},
+	} {
+		t.Run(tc.schema+"/"+tc.typ, func(t *testing.T) {
+			typ, ok := translatePostgresType(tc.typ, tc.schema, enums)
+			require.Equal(t, tc.expected, typ)
+			require.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestGenerateMigrationDDL(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := &pgSchemaDesc{
+		name:      "public",
+		enums:     []pgEnum{{name: "mood", labels: []string{"sad", "it's ok"}}},
+		sequences: []pgSequence{{name: "users_id_seq", start: 1, increment: 1, min: 1, max: 1000}},
+		tables: []pgTable{
+			{
+				name: "users",
+				columns: []pgColumn{
+					{name: "id", typ: "integer", notNull: true, def: "nextval('users_id_seq'::regclass)"},
+					{name: "email", typ: "email_address", domainBase: "text"},
+					{name: "balance", typ: "money"},
+					{name: "mood", typ: "mood"},
+				},
+				constraints: []pgConstraint{
+					{name: "users_pkey", kind: 'p', def: "PRIMARY KEY (id)"},
+					{name: "positive", kind: 'c', def: "CHECK ((id > 0))"},
+				},
+				indexes: []string{
+					"CREATE INDEX users_email ON users USING btree (email)",
+					"CREATE INDEX users_hash ON users USING hash (email)",
+				},
+			},
+			{
+				name: "orders",
+				columns: []pgColumn{
+					{name: "id", typ: "bigint", notNull: true, identitySeq: "public.orders_id_seq"},
+					{name: "user_id", typ: "integer"},
+				},
+				constraints: []pgConstraint{
+					{name: "orders_user_fk", kind: 'f', def: "FOREIGN KEY (user_id) REFERENCES users(id)"},
+					{name: "no_overlap", kind: 'x', def: "EXCLUDE USING gist (id WITH =)"},
+				},
+			},
+		},
+		views:       []pgView{{name: "sad_users", query: " SELECT users.id\n   FROM users\n  WHERE (users.mood = 'sad'::mood);"}},
+		unsupported: []string{"trigger users.audit is not supported"},
+	}
+
+	stmts, report := generateMigrationDDL(desc)
+	require.Equal(t, []string{
+		`CREATE TYPE mood AS ENUM ('sad', e'it\'s ok')`,
+		`CREATE SEQUENCE users_id_seq MINVALUE 1 MAXVALUE 1000 INCREMENT 1 START 1`,
+		"CREATE TABLE users (\n" +
+			"\tid integer NOT NULL DEFAULT nextval('users_id_seq'::regclass),\n" +
+			"\temail text,\n" +
+			"\tbalance DECIMAL(19,2),\n" +
+			"\tmood mood,\n" +
+			"\tCONSTRAINT users_pkey PRIMARY KEY (id),\n" +
+			"\tCONSTRAINT positive CHECK ((id > 0))\n" +
+			")",
+		"CREATE TABLE orders (\n" +
+			"\tid bigint NOT NULL DEFAULT nextval('public.orders_id_seq'),\n" +
+			"\tuser_id integer\n" +
+			")",
+		`ALTER TABLE orders ADD CONSTRAINT orders_user_fk FOREIGN KEY (user_id) REFERENCES users(id)`,
+		`CREATE INDEX users_email ON users USING btree (email)`,
+		`-- CREATE INDEX users_hash ON users USING hash (email)`,
+		"CREATE VIEW sad_users AS SELECT users.id\n   FROM users\n  WHERE (users.mood = 'sad'::mood)",
+	}, stmts)
+
+	require.Len(t, report, 6)
+	for i, expected := range []string{
+		"trigger users.audit is not supported",
+		"column users.email uses domain email_address",
+		"column users.balance has unsupported type money",
+		"identity column orders.id was replaced",
+		"exclusion constraint no_overlap on table orders is not supported",
+		`could not translate "CREATE INDEX users_hash ON users USING hash (email)"`,
+	} {
+		require.True(t, strings.HasPrefix(report[i], expected), "%q", report[i])
+	}
+}
+
+func TestGenerateMigrationDDLQualifiedEnum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := &pgSchemaDesc{
+		name:  "app",
+		enums: []pgEnum{{name: "Mood", labels: []string{"sad", "happy"}}},
+		tables: []pgTable{{
+			name: "users",
+			columns: []pgColumn{
+				{name: "mood", typ: `app."Mood"`},
+				{name: "past_moods", typ: `app."Mood"[]`},
+			},
+		}},
+	}
+
+	stmts, report := generateMigrationDDL(desc)
+	require.Empty(t, report)
+	require.Equal(t, []string{
+		`CREATE SCHEMA IF NOT EXISTS app`,
+		`CREATE TYPE app."Mood" AS ENUM ('sad', 'happy')`,
+		"CREATE TABLE app.users (\n" +
+			"\tmood app.\"Mood\",\n" +
+			"\tpast_moods app.\"Mood\"[]\n" +
+			")",
+	}, stmts)
+}
@@ -117,6 +117,12 @@ eexpect $prompt
 
 end_test
 
+start_test "Check that an auth cookie can only be created by an admin."
+send "$argv auth-session login root --url 'postgres://eisen:hunter2@?host=$mywd&port=26257&sslmode=require'\r"
+eexpect "only users with the admin role are allowed to create HTTP sessions"
+eexpect $prompt
+end_test
+
 start_test "Check that the auth cookie creation works and reports useful output."
 send "$argv auth-session login eisen --certs-dir=$certs_dir\r"
 eexpect "authentication cookie"
@@ -14,8 +14,10 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"os/exec"
@@ -37,8 +39,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/errors"
 	readline "github.com/knz/go-libedit"
+	"github.com/lib/pq"
 	isatty "github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
@@ -71,6 +75,8 @@ Input/Output
   \echo [STRING]    write the provided string to standard output.
   \i                execute commands from the specified file.
   \ir               as \i, but relative to the location of the current script.
+  \copy TABLE FROM 'FILE' [CSV] [HEADER] [DELIMITER 'C']
+                    load the contents of a local CSV file into a table.
 
 Informational
   \l                list all databases in the CockroachDB cluster.
@@ -561,11 +567,6 @@ func (c *cliState) handleDemoAddNode(cmd []string, nextState, errState cliStateE
 		return c.internalServerError(errState, fmt.Errorf("bad call to handleDemoAddNode"))
 	}
 
-	if demoCtx.simulateLatency {
-		fmt.Printf("add command is not supported in --global configurations")
-		return nextState
-	}
-
 	if err := demoCtx.transientCluster.AddNode(cmd[1]); err != nil {
 		return c.internalServerError(errState, err)
 	}
@@ -1101,6 +1102,9 @@ func (c *cliState) doHandleCliCmd(loopState, nextState cliStateEnum) cliStateEnu
 	case `\ir`:
 		return c.runInclude(cmd[1:], loopState, errState, true /* relative */)
 
+	case `\copy`:
+		return c.runCopy(line, loopState, errState)
+
 	case `\p`:
 		// This is analogous to \show but does not need a special case.
 		// Implemented for compatibility with psql.
@@ -1374,7 +1378,7 @@ func (c *cliState) doRunStatements(nextState cliStateEnum) cliStateEnum {
 	}
 
 	// Now run the statement/query.
-	c.exitErr = runQueryAndFormatResults(c.conn, os.Stdout, makeQuery(c.concatLines))
+	c.exitErr = c.runQueryWithRetries(c.concatLines)
 	if c.exitErr != nil {
 		cliOutputError(stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
 	}
@@ -1609,6 +1613,78 @@ func (c *cliState) configurePreShellDefaults(cmdIn *os.File) (cleanupFn func(),
 	return cleanupFn, nil
 }
 
+// runQueryWithRetries runs the given statement(s) and formats the
+// results. If --retries was specified and the shell is not
+// interactive, the statement(s) are re-executed when they fail with a
+// transient error. Retries are only attempted if no transaction was
+// open prior to the statement(s), as the effects of the earlier
+// statements in the transaction would otherwise be lost.
+func (c *cliState) runQueryWithRetries(stmt string) error {
+	if sqlCtx.retries <= 0 || cliCtx.isInteractive || !c.noTxnOpen() {
+		return runQueryAndFormatResults(c.conn, os.Stdout, makeQuery(stmt))
+	}
+
+	opts := retry.Options{
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		MaxRetries:     sqlCtx.retries,
+	}
+	var err error
+	attempt := 0
+	for r := retry.Start(opts); r.Next(); attempt++ {
+		if attempt > 0 {
+			fmt.Fprintf(stderr, "warning: retrying statement (retry %d of %d)\n",
+				attempt, sqlCtx.retries)
+		}
+		err = runQueryAndFormatResults(c.conn, os.Stdout, makeQuery(stmt))
+		if err == nil || !isTransientSQLError(err) || attempt >= sqlCtx.retries {
+			return err
+		}
+		cliOutputError(stderr, err, true /*showSeverity*/, false /*verbose*/)
+		if pqErr := (*pq.Error)(nil); !errors.As(err, &pqErr) || pqErr.Code.Class() == "08" {
+			// The connection was lost. The driver only closes it by itself
+			// on driver.ErrBadConn, so force a new connection for the next
+			// attempt.
+			c.conn.reconnecting = true
+			c.conn.Close()
+		} else if !c.noTxnOpen() {
+			// The statement(s) opened a transaction which was aborted by
+			// the error, e.g. BEGIN; ...; COMMIT. Roll it back so that
+			// the next attempt starts from a clean state.
+			if rbErr := c.conn.Exec("ROLLBACK", nil); rbErr != nil {
+				return errors.CombineErrors(err, rbErr)
+			}
+		}
+	}
+	return err
+}
+
+// noTxnOpen returns true if the session is not in a transaction.
+func (c *cliState) noTxnOpen() bool {
+	dbVal, hasVal := c.conn.getServerValue("transaction status", `SHOW TRANSACTION STATUS`)
+	if !hasVal {
+		return false
+	}
+	txnString := formatVal(dbVal,
+		false /* showPrintableUnicode */, false /* shownewLinesAndTabs */)
+	return txnString == sql.NoTxnStateStr
+}
+
+// isTransientSQLError returns true if the error is a transaction
+// retry error or indicates that the connection to the server was
+// lost.
+func isTransientSQLError(err error) bool {
+	if pqErr := (*pq.Error)(nil); errors.As(err, &pqErr) {
+		code := pgcode.MakeCode(string(pqErr.Code))
+		return code == pgcode.SerializationFailure || pqErr.Code.Class() == "08"
+	}
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.HasType(err, (*net.OpError)(nil))
+}
+
 // runStatements executes the given statements and terminates
 // on error.
 func (c *cliState) runStatements(stmts []string) error {
@@ -1618,7 +1694,7 @@ func (c *cliState) runStatements(stmts []string) error {
 			// because we need a different error handling mechanism:
 			// the error, if any, must not be printed to stderr if
 			// we are returning directly.
-			c.exitErr = runQueryAndFormatResults(c.conn, os.Stdout, makeQuery(stmt))
+			c.exitErr = c.runQueryWithRetries(stmt)
 			if c.exitErr != nil {
 				if !sqlCtx.errExit && i < len(stmts)-1 {
 					// Print the error now because we don't get a chance later.
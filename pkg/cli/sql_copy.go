@@ -0,0 +1,291 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+)
+
+// copyFromSpec describes a client-side \copy command.
+type copyFromSpec struct {
+	// table is the target table, as specified by the user.
+	table string
+	// columns is the list of target columns, including the enclosing
+	// parentheses, as specified by the user. Empty if no column list
+	// was specified.
+	columns string
+	// filename is the local file to read the data from.
+	filename string
+	// header indicates that the first line of the file is a header
+	// and must be skipped.
+	header bool
+	// delimiter separates the fields in each line.
+	delimiter rune
+}
+
+var copyCmdRe = regexp.MustCompile(
+	`(?is)^\\copy\s+([^\s(]+)(?:\s*(\([^)]*\)))?\s+(from|to)\s+('(?:[^']|'')*'|\S+)(.*)$`)
+
+// parseCopyCmd parses a \copy command of the form:
+//
+//   \copy TABLE [(COLUMNS)] FROM 'FILE' [WITH] [CSV] [HEADER] [DELIMITER 'C']
+func parseCopyCmd(line string) (copyFromSpec, error) {
+	spec := copyFromSpec{delimiter: ','}
+	m := copyCmdRe.FindStringSubmatch(line)
+	if m == nil {
+		return spec, errors.New(`expected \copy TABLE [(COLUMNS)] FROM 'FILE' [WITH] [CSV] [HEADER] [DELIMITER 'C']`)
+	}
+	if !strings.EqualFold(m[3], "from") {
+		return spec, errors.New(`only \copy ... FROM is supported`)
+	}
+	spec.table = m[1]
+	spec.columns = m[2]
+	if !strings.HasPrefix(m[4], "'") {
+		return spec, errors.Newf(`\copy from %s is not supported, the file name must be quoted`, m[4])
+	}
+	spec.filename = strings.Replace(m[4][1:len(m[4])-1], "''", "'", -1)
+
+	opts := strings.Fields(m[5])
+	for i := 0; i < len(opts); i++ {
+		switch strings.ToUpper(opts[i]) {
+		case "WITH":
+		case "CSV":
+			// CSV is the only supported format.
+		case "HEADER":
+			spec.header = true
+		case "DELIMITER":
+			i++
+			if i >= len(opts) || len(opts[i]) < 3 ||
+				!strings.HasPrefix(opts[i], "'") || !strings.HasSuffix(opts[i], "'") {
+				return spec, errors.New(`DELIMITER must be followed by a quoted character`)
+			}
+			d := opts[i][1 : len(opts[i])-1]
+			r, size := utf8.DecodeRuneInString(d)
+			if size != len(d) {
+				return spec, errors.New(`DELIMITER must be a single character`)
+			}
+			spec.delimiter = r
+		default:
+			return spec, errors.Newf(`unsupported \copy option: %s`, opts[i])
+		}
+	}
+	return spec, nil
+}
+
+// copyProgressInterval is the number of rows after which the progress
+// of a \copy command is reported.
+const copyProgressInterval = 10000
+
+// copyInsertBatchSize is the number of rows inserted per statement
+// when COPY is not supported by the server.
+const copyInsertBatchSize = 100
+
+// runCopy executes a client-side \copy command, which streams the
+// contents of a local CSV file to the server.
+func (c *cliState) runCopy(line string, contState, errState cliStateEnum) cliStateEnum {
+	if len(c.partialLines) > 0 {
+		return c.invalidSyntax(errState, `cannot use \copy during multi-line entry.`)
+	}
+	spec, err := parseCopyCmd(line)
+	if err != nil {
+		return c.invalidSyntax(errState, `%s: %v. Try \? for help.`, line, err)
+	}
+
+	f, err := os.Open(spec.filename)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		c.exitErr = err
+		return errState
+	}
+	defer func() { _ = f.Close() }()
+
+	// Once we send something to the server, the txn status may change
+	// arbitrarily.
+	c.lastKnownTxnStatus = unknownTxnStatus
+	ownTxn := c.noTxnOpen()
+
+	r := csv.NewReader(f)
+	r.Comma = spec.delimiter
+	r.ReuseRecord = true
+	if spec.header {
+		if _, err := r.Read(); err != nil && err != io.EOF {
+			c.exitErr = errors.Wrapf(err, "reading %s", spec.filename)
+			cliOutputError(stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
+			return errState
+		}
+	}
+
+	rows, err := c.conn.copyFromCSV(r, spec, ownTxn, func(rows int) {
+		fmt.Fprintf(stderr, "%d rows copied...\n", rows)
+	})
+	if err != nil {
+		c.exitErr = err
+		cliOutputError(stderr, c.exitErr, true /*showSeverity*/, false /*verbose*/)
+		return errState
+	}
+	fmt.Printf("COPY %d\n", rows)
+	return contState
+}
+
+// copyFromCSV loads the records read from r into the table using the
+// COPY protocol. If the server rejects COPY for the table, the records
+// are inserted using batched INSERT statements instead. If ownTxn is
+// set, the data is loaded in a new transaction which is committed at
+// the end; otherwise, the transaction already open in the session is
+// used. Empty fields are loaded as NULL. The progress function is
+// called periodically with the number of rows loaded so far.
+func (c *sqlConn) copyFromCSV(
+	r *csv.Reader, spec copyFromSpec, ownTxn bool, progress func(rows int),
+) (rows int, err error) {
+	if err := c.ensureConn(); err != nil {
+		return 0, err
+	}
+	if ownTxn {
+		if err := c.Exec(`BEGIN`, nil); err != nil {
+			return 0, err
+		}
+		defer func() {
+			if err != nil {
+				if rbErr := c.Exec(`ROLLBACK`, nil); rbErr != nil {
+					err = errors.CombineErrors(err, rbErr)
+				}
+				return
+			}
+			err = c.Exec(`COMMIT`, nil)
+		}()
+	}
+
+	stmt, err := c.conn.Prepare(fmt.Sprintf("COPY %s %s FROM STDIN", spec.table, spec.columns))
+	if err != nil {
+		if pqErr := (*pq.Error)(nil); !ownTxn || !errors.As(err, &pqErr) ||
+			pgcode.MakeCode(string(pqErr.Code)) != pgcode.FeatureNotSupported {
+			return 0, err
+		}
+		// The server does not support COPY for this table. Restart the
+		// transaction and fall back to INSERT statements.
+		if err := c.Exec(`ROLLBACK`, nil); err != nil {
+			return 0, err
+		}
+		if err := c.Exec(`BEGIN`, nil); err != nil {
+			return 0, err
+		}
+		return c.insertFromCSV(r, spec, progress)
+	}
+	defer func() {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+	}()
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		if _, err := stmt.Exec(csvRecordValues(record)); err != nil {
+			return rows, err
+		}
+		rows++
+		if rows%copyProgressInterval == 0 {
+			progress(rows)
+		}
+	}
+	// Sync the COPY stream to retrieve any error from the pending data.
+	if _, err := stmt.Exec(nil); err != nil {
+		return rows, err
+	}
+	if err := stmt.Close(); err != nil {
+		return rows, err
+	}
+	stmt = nil
+	return rows, nil
+}
+
+// insertFromCSV loads the records read from r into the table using
+// batched INSERT statements.
+func (c *sqlConn) insertFromCSV(
+	r *csv.Reader, spec copyFromSpec, progress func(rows int),
+) (rows int, err error) {
+	var buf strings.Builder
+	var args []driver.Value
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+		err := c.Exec(buf.String(), args)
+		buf.Reset()
+		args = args[:0]
+		return err
+	}
+
+	batchRows := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		if batchRows == 0 {
+			fmt.Fprintf(&buf, "INSERT INTO %s %s VALUES ", spec.table, spec.columns)
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('(')
+		for i, v := range csvRecordValues(record) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			args = append(args, v)
+			fmt.Fprintf(&buf, "$%d", len(args))
+		}
+		buf.WriteByte(')')
+		batchRows++
+		rows++
+		if batchRows == copyInsertBatchSize {
+			if err := flush(); err != nil {
+				return rows, err
+			}
+			batchRows = 0
+		}
+		if rows%copyProgressInterval == 0 {
+			progress(rows)
+		}
+	}
+	return rows, flush()
+}
+
+// csvRecordValues converts a CSV record to the values sent to the
+// server. Empty fields are converted to NULL.
+func csvRecordValues(record []string) []driver.Value {
+	vals := make([]driver.Value, len(record))
+	for i, s := range record {
+		if s != "" {
+			vals[i] = s
+		}
+	}
+	return vals
+}
@@ -0,0 +1,9 @@
+--- input file for Example_sql_copy.
+
+--- don't report timestamps: it makes the output non-deterministic.
+\unset show_times
+
+CREATE TABLE t (id INT PRIMARY KEY, s STRING, f FLOAT);
+\copy t FROM 'testdata/copy.csv' CSV HEADER
+\copy t (id, s) FROM 'testdata/copy_pipe.csv' DELIMITER '|'
+SELECT * FROM t ORDER BY id;
@@ -16,11 +16,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -28,22 +32,40 @@ var debugTimeSeriesDumpCmd = &cobra.Command{
 	Use:   "tsdump",
 	Short: "dump all the raw timeseries values in a cluster",
 	Long: `
-Dumps all of the raw timeseries values in a cluster.
+Dumps all of the raw timeseries values in a cluster. The dump can be restricted
+to some metrics with --metrics and to a time range with --from and --to.
+
+With --format=openmetrics, the values are written in the OpenMetrics text
+format, which can be ingested into external monitoring systems such as
+Prometheus for offline analysis.
 `,
 	RunE: MaybeDecorateGRPCError(func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
+		req := &tspb.DumpRequest{Names: tsDumpCtx.metrics}
+		if from := time.Time(tsDumpCtx.from); !from.IsZero() {
+			req.StartNanos = from.UnixNano()
+		}
+		if to := time.Time(tsDumpCtx.to); !to.IsZero() {
+			req.EndNanos = to.UnixNano()
+			if req.StartNanos >= req.EndNanos {
+				return errors.New("--from must be before --to")
+			}
+		}
+
 		var w tsWriter
-		switch cliCtx.tableDisplayFormat {
-		case tableDisplayCSV:
+		switch tsDumpCtx.format {
+		case tsDumpCSV:
 			w = csvTSWriter{w: csv.NewWriter(os.Stdout)}
-		case tableDisplayTSV:
+		case tsDumpTSV:
 			cw := csvTSWriter{w: csv.NewWriter(os.Stdout)}
 			cw.w.Comma = '\t'
 			w = cw
+		case tsDumpOpenMetrics:
+			w = &openMetricsTSWriter{w: os.Stdout}
 		default:
-			w = rawTSWriter{w: os.Stdout}
+			w = &rawTSWriter{w: os.Stdout}
 		}
 
 		conn, _, finish, err := getClientGRPCConn(ctx, serverCfg)
@@ -53,7 +75,7 @@ Dumps all of the raw timeseries values in a cluster.
 		defer finish()
 
 		tsClient := tspb.NewTimeSeriesClient(conn)
-		stream, err := tsClient.Dump(context.Background(), &tspb.DumpRequest{})
+		stream, err := tsClient.Dump(context.Background(), req)
 		if err != nil {
 			log.Fatalf(context.Background(), "%v", err)
 		}
@@ -105,9 +127,9 @@ type rawTSWriter struct {
 	w io.Writer
 }
 
-func (w rawTSWriter) Flush() error { return nil }
+func (w *rawTSWriter) Flush() error { return nil }
 
-func (w rawTSWriter) Emit(data *tspb.TimeSeriesData) error {
+func (w *rawTSWriter) Emit(data *tspb.TimeSeriesData) error {
 	if w.last.name != data.Name || w.last.source != data.Source {
 		w.last.name, w.last.source = data.Name, data.Source
 		fmt.Fprintf(w.w, "%s %s\n", data.Name, data.Source)
@@ -117,3 +139,89 @@ func (w rawTSWriter) Emit(data *tspb.TimeSeriesData) error {
 	}
 	return nil
 }
+
+// openMetricsTSWriter writes the datapoints in the OpenMetrics text format.
+// Metric names are converted to valid OpenMetrics names and the source of
+// each series is exported as a label.
+//
+// OpenMetrics requires the samples of a metric to be contiguous and ordered
+// by time for each source. The dump returns the data of a metric in a single
+// run but interleaves its sources, so the datapoints of the current metric are
+// buffered until the dump moves on to the next one.
+type openMetricsTSWriter struct {
+	w io.Writer
+	// name is the name of the metric whose datapoints are buffered.
+	name    string
+	sources map[string][]tspb.TimeSeriesDatapoint
+}
+
+func (w *openMetricsTSWriter) Emit(data *tspb.TimeSeriesData) error {
+	if data.Name != w.name {
+		if err := w.writeMetric(); err != nil {
+			return err
+		}
+		w.name = data.Name
+		w.sources = make(map[string][]tspb.TimeSeriesDatapoint)
+	}
+	w.sources[data.Source] = append(w.sources[data.Source], data.Datapoints...)
+	return nil
+}
+
+func (w *openMetricsTSWriter) Flush() error {
+	if err := w.writeMetric(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w.w, "# EOF")
+	return err
+}
+
+// writeMetric writes the buffered datapoints of the current metric.
+func (w *openMetricsTSWriter) writeMetric() error {
+	if len(w.sources) == 0 {
+		return nil
+	}
+	name := openMetricsName(w.name)
+	if _, err := fmt.Fprintf(w.w, "# TYPE %s unknown\n", name); err != nil {
+		return err
+	}
+	sources := make([]string, 0, len(w.sources))
+	for source := range w.sources {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		labels := ""
+		if source != "" {
+			labels = fmt.Sprintf(`{source="%s"}`, openMetricsLabelEscaper.Replace(source))
+		}
+		for _, d := range w.sources[source] {
+			if _, err := fmt.Fprintf(w.w, "%s%s %s %s\n", name, labels,
+				strconv.FormatFloat(d.Value, 'g', -1, 64),
+				strconv.FormatFloat(float64(d.TimestampNanos)/1e9, 'f', -1, 64),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	w.sources = nil
+	return nil
+}
+
+var openMetricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// openMetricsName converts a metric name, for example cr.node.sql.query.count,
+// into a valid OpenMetrics metric name by replacing the characters that are not
+// allowed with underscores.
+func openMetricsName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenMetricsTSWriter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var buf bytes.Buffer
+	w := &openMetricsTSWriter{w: &buf}
+	for _, data := range []tspb.TimeSeriesData{
+		{
+			Name:   "cr.node.sql.query.count",
+			Source: "2",
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{TimestampNanos: 1610000000e9, Value: 3},
+			},
+		},
+		{
+			Name:   "cr.node.sql.query.count",
+			Source: "1",
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{TimestampNanos: 1610000000e9, Value: 1},
+				{TimestampNanos: 1610000010e9, Value: 2.5},
+			},
+		},
+		{
+			Name:   "cr.node.sql.query.count",
+			Source: "2",
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{TimestampNanos: 1610000010e9, Value: 4},
+			},
+		},
+		{
+			Name:   "cr.store.replicas-leaders",
+			Source: "1",
+			Datapoints: []tspb.TimeSeriesDatapoint{
+				{TimestampNanos: 1610000000e9, Value: 12},
+			},
+		},
+	} {
+		data := data
+		require.NoError(t, w.Emit(&data))
+	}
+	require.NoError(t, w.Flush())
+
+	require.Equal(t, `# TYPE cr_node_sql_query_count unknown
+cr_node_sql_query_count{source="1"} 1 1610000000
+cr_node_sql_query_count{source="1"} 2.5 1610000010
+cr_node_sql_query_count{source="2"} 3 1610000000
+cr_node_sql_query_count{source="2"} 4 1610000010
+# TYPE cr_store_replicas_leaders unknown
+cr_store_replicas_leaders{source="1"} 12 1610000000
+# EOF
+`, buf.String())
+}
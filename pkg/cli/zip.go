@@ -17,17 +17,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/heapprofiler"
@@ -59,66 +60,104 @@ requires the cluster to be live.
 	RunE: MaybeDecorateGRPCError(runDebugZip),
 }
 
-// Tables containing cluster-wide info that are collected in a debug zip.
-var debugZipTablesPerCluster = []string{
-	"crdb_internal.cluster_database_privileges",
-	"crdb_internal.cluster_queries",
-	"crdb_internal.cluster_sessions",
-	"crdb_internal.cluster_settings",
-	"crdb_internal.cluster_transactions",
-
-	"crdb_internal.jobs",
-	"system.jobs",       // get the raw, restorable jobs records too.
-	"system.descriptor", // descriptors also contain job-like mutation state.
-	"system.namespace",
-	"system.namespace2", // TODO(sqlexec): consider removing in 20.2 or later.
-
-	"crdb_internal.kv_node_status",
-	"crdb_internal.kv_store_status",
-
-	"crdb_internal.schema_changes",
-	"crdb_internal.partitions",
-	"crdb_internal.zones",
-	"crdb_internal.invalid_objects",
+type zipper struct {
+	f *os.File
+	z *zip.Writer
+	// out receives the progress messages.
+	out io.Writer
+
+	// files, spill and progress buffer the files and the progress messages
+	// of a zipper created with newBufferedZipper until they are flushed to
+	// another zipper. The contents of the files are written one after the
+	// other to the spill file, so that the data buffered for many nodes
+	// doesn't need to fit in memory.
+	files    []*bufferedZipFile
+	spill    *os.File
+	progress *bytes.Buffer
+
+	// warnings are printed once the zip file is complete, so that they
+	// don't get "drowned" as part of the main zip output.
+	warnings []string
+	// numErrors is the number of files which could not be retrieved and
+	// contain an error instead.
+	numErrors int
 }
 
-// Tables collected from each node in a debug zip.
-var debugZipTablesPerNode = []string{
-	"crdb_internal.feature_usage",
-
-	"crdb_internal.gossip_alerts",
-	"crdb_internal.gossip_liveness",
-	"crdb_internal.gossip_network",
-	"crdb_internal.gossip_nodes",
-
-	"crdb_internal.leases",
-
-	"crdb_internal.node_build_info",
-	"crdb_internal.node_metrics",
-	"crdb_internal.node_queries",
-	"crdb_internal.node_runtime_info",
-	"crdb_internal.node_sessions",
-	"crdb_internal.node_statement_statistics",
-	"crdb_internal.node_transaction_statistics",
-	"crdb_internal.node_transactions",
-	"crdb_internal.node_txn_stats",
+type bufferedZipFile struct {
+	header zip.FileHeader
+	// size is the length of the contents of the file in the spill file,
+	// where they follow the contents of the previous file.
+	size int64
 }
 
-// Override for the default SELECT * when dumping the table.
-var customSelectClause = map[string]string{
-	"system.jobs":       "*, to_hex(payload) AS hex_payload, to_hex(progress) AS hex_progress",
-	"system.descriptor": "*, to_hex(descriptor) AS hex_descriptor",
+// spillWriter appends the contents of a buffered file to the spill file.
+type spillWriter struct {
+	spill *os.File
+	f     *bufferedZipFile
 }
 
-type zipper struct {
-	f *os.File
-	z *zip.Writer
+func (w *spillWriter) Write(b []byte) (int, error) {
+	n, err := w.spill.Write(b)
+	w.f.size += int64(n)
+	return n, err
 }
 
 func newZipper(f *os.File) *zipper {
 	return &zipper{
-		f: f,
-		z: zip.NewWriter(f),
+		f:   f,
+		z:   zip.NewWriter(f),
+		out: os.Stdout,
+	}
+}
+
+// newBufferedZipper creates a zipper which buffers its files in a temporary
+// file and its progress messages in memory. They are written out by flushTo,
+// or dropped by discard.
+func newBufferedZipper() (*zipper, error) {
+	spill, err := ioutil.TempFile("", "cockroach-debug-zip-")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary file")
+	}
+	progress := &bytes.Buffer{}
+	return &zipper{out: progress, spill: spill, progress: progress}, nil
+}
+
+// flushTo writes the files, progress messages and warnings buffered by a
+// zipper created with newBufferedZipper to dst. The buffered zipper cannot
+// be used afterwards.
+func (z *zipper) flushTo(dst *zipper) error {
+	defer z.discard()
+	if _, err := dst.out.Write(z.progress.Bytes()); err != nil {
+		return err
+	}
+	if _, err := z.spill.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	for _, f := range z.files {
+		w, err := dst.z.CreateHeader(&f.header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.CopyN(w, z.spill, f.size); err != nil {
+			return err
+		}
+	}
+	dst.warnings = append(dst.warnings, z.warnings...)
+	z.warnings = nil
+	dst.numErrors += z.numErrors
+	z.numErrors = 0
+	return nil
+}
+
+// discard drops the data buffered by a zipper created with newBufferedZipper
+// and removes its temporary file.
+func (z *zipper) discard() {
+	z.files = nil
+	z.progress.Reset()
+	if z.spill != nil {
+		_ = z.spill.Close()
+		_ = os.Remove(z.spill.Name())
+		z.spill = nil
 	}
 }
 
@@ -129,15 +168,21 @@ func (z *zipper) close() error {
 }
 
 func (z *zipper) create(name string, mtime time.Time) (io.Writer, error) {
-	fmt.Printf("writing: %s\n", name)
+	fmt.Fprintf(z.out, "writing: %s\n", name)
 	if mtime.IsZero() {
 		mtime = timeutil.Now()
 	}
-	return z.z.CreateHeader(&zip.FileHeader{
+	header := zip.FileHeader{
 		Name:     name,
 		Method:   zip.Deflate,
 		Modified: mtime,
-	})
+	}
+	if z.z == nil {
+		f := &bufferedZipFile{header: header}
+		z.files = append(z.files, f)
+		return &spillWriter{spill: z.spill, f: f}, nil
+	}
+	return z.z.CreateHeader(&header)
 }
 
 func (z *zipper) createRaw(name string, b []byte) error {
@@ -165,8 +210,9 @@ func (z *zipper) createError(name string, e error) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("  ^- resulted in %s\n", e)
+	fmt.Fprintf(z.out, "  ^- resulted in %s\n", e)
 	fmt.Fprintf(w, "%s\n", e)
+	z.numErrors++
 	return nil
 }
 
@@ -201,13 +247,13 @@ func guessNodeURL(workingURL string, hostport string) *sqlConn {
 	return makeSQLConn(u.String())
 }
 
-func runZipRequestWithTimeout(
+func (z *zipper) runRequestWithTimeout(
 	ctx context.Context,
 	requestName string,
 	timeout time.Duration,
 	fn func(ctx context.Context) error,
 ) error {
-	fmt.Printf("%s... ", requestName)
+	fmt.Fprintf(z.out, "%s... ", requestName)
 	return contextutil.RunWithTimeout(ctx, requestName, timeout, fn)
 }
 
@@ -216,6 +262,7 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 		base          = "debug"
 		eventsName    = base + "/events"
 		livenessName  = base + "/liveness"
+		manifestName  = base + "/manifest.json"
 		nodesPrefix   = base + "/nodes"
 		rangelogName  = base + "/rangelog"
 		reportsPrefix = base + "/reports"
@@ -223,6 +270,12 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 		settingsName  = base + "/settings"
 	)
 
+	if err := zipCtx.tables.validate(); err != nil {
+		return err
+	}
+	clusterTables, skippedClusterTables := debugZipTablesPerCluster.filter(&zipCtx.tables)
+	nodeTables, skippedNodeTables := debugZipTablesPerNode.filter(&zipCtx.tables)
+
 	baseCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -283,6 +336,9 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 	defer func() {
 		cErr := z.close()
 		retErr = errors.CombineErrors(retErr, cErr)
+		for _, w := range z.warnings {
+			fmt.Fprintf(stderr, "WARNING: %s\n", w)
+		}
 	}()
 
 	timeout := 10 * time.Second
@@ -290,9 +346,23 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 		timeout = cliCtx.cmdTimeout
 	}
 
+	// Redacting all the data implies redacting the log files.
+	redactLogs := zipCtx.redactLogs || zipCtx.redact
+
+	if err := z.createJSON(manifestName, zipManifest{
+		IncludePatterns:      zipCtx.tables.include,
+		ExcludePatterns:      zipCtx.tables.exclude,
+		SkippedClusterTables: skippedClusterTables,
+		SkippedNodeTables:    skippedNodeTables,
+		Redacted:             zipCtx.redact,
+		RedactedLogs:         redactLogs,
+	}); err != nil {
+		return err
+	}
+
 	var runZipRequest = func(r zipRequest) error {
 		var data interface{}
-		err = runZipRequestWithTimeout(baseCtx, "requesting data for "+r.pathName, timeout, func(ctx context.Context) error {
+		err = z.runRequestWithTimeout(baseCtx, "requesting data for "+r.pathName, timeout, func(ctx context.Context) error {
 			data, err = r.fn(ctx)
 			return err
 		})
@@ -310,7 +380,11 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 		},
 		{
 			fn: func(ctx context.Context) (interface{}, error) {
-				return admin.RangeLog(ctx, &serverpb.RangeLogRequest{})
+				resp, err := admin.RangeLog(ctx, &serverpb.RangeLogRequest{})
+				if err == nil && zipCtx.redact {
+					redactRangeLog(resp)
+				}
+				return resp, err
 			},
 			pathName: rangelogName,
 		},
@@ -332,10 +406,18 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 		}
 	}
 
-	for _, table := range debugZipTablesPerCluster {
-		selectClause, ok := customSelectClause[table]
-		if !ok {
-			selectClause = "*"
+	// If the SQL connection doesn't work, assume a recent cluster; the
+	// tables can't be retrieved anyway.
+	hasJobInfo, err := hasJobInfoTable(sqlConn)
+	if err != nil {
+		hasJobInfo = true
+	}
+	for _, table := range clusterTables {
+		selectClause := zipSelectClause(table)
+		if !hasJobInfo && !zipCtx.redact {
+			if legacyClause, ok := legacySelectClause[table]; ok {
+				selectClause = legacyClause
+			}
 		}
 		if err := dumpTableDataForZip(z, sqlConn, timeout, base, table, selectClause); err != nil {
 			return errors.Wrapf(err, "fetching %s", table)
@@ -355,7 +437,7 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 
 	{
 		var nodes *serverpb.NodesResponse
-		err := runZipRequestWithTimeout(baseCtx, "requesting nodes", timeout, func(ctx context.Context) error {
+		err := z.runRequestWithTimeout(baseCtx, "requesting nodes", timeout, func(ctx context.Context) error {
 			nodes, err = status.Nodes(ctx, &serverpb.NodesRequest{})
 			return err
 		})
@@ -379,7 +461,7 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 
 		// We'll want livenesses to decide whether a node is decommissioned.
 		var lresponse *serverpb.LivenessResponse
-		err = runZipRequestWithTimeout(baseCtx, "requesting liveness", timeout, func(ctx context.Context) error {
+		err = z.runRequestWithTimeout(baseCtx, "requesting liveness", timeout, func(ctx context.Context) error {
 			lresponse, err = admin.Liveness(ctx, &serverpb.LivenessRequest{})
 			return err
 		})
@@ -391,11 +473,25 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 			livenessByNodeID = lresponse.Statuses
 		}
 
-		// Collect CPU profiles in parallel over all nodes (this is useful since
-		// these profiles contain profiler labels, which can then be correlated
-		// across nodes). Do this first and in isolation, before other zip
-		// operations possibly influence the node.
-		if zipCtx.cpuProfDuration > 0 {
+		// Collect CPU and mutex profiles in parallel over all nodes (this is
+		// useful since the CPU profiles contain profiler labels, which can
+		// then be correlated across nodes). Do this first and in isolation,
+		// before other zip operations possibly influence the node. The heap
+		// profiles and goroutine dumps are retrieved with the rest of the
+		// per-node data below.
+		if zipCtx.profDuration > 0 {
+			profiles := []struct {
+				typ  serverpb.ProfileRequest_Type
+				file string
+			}{
+				{typ: serverpb.ProfileRequest_CPU, file: "cpu.pprof"},
+				{typ: serverpb.ProfileRequest_MUTEX, file: "mutex.pprof"},
+			}
+			secs := int32(zipCtx.profDuration / time.Second)
+			if secs < 1 {
+				secs = 1
+			}
+
 			var wg sync.WaitGroup
 			type profData struct {
 				data []byte
@@ -403,61 +499,67 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 			}
 
 			// NB: this takes care not to produce non-deterministic log output.
-			resps := make([]profData, len(nodeList))
+			resps := make([][]profData, len(nodeList))
 			for i := range nodeList {
-				if livenessByNodeID[nodeList[i].Desc.NodeID] == livenesspb.NodeLivenessStatus_DECOMMISSIONED {
+				switch livenessByNodeID[nodeList[i].Desc.NodeID] {
+				case livenesspb.NodeLivenessStatus_DECOMMISSIONED, livenesspb.NodeLivenessStatus_DEAD:
+					// Only live nodes can be profiled.
 					continue
 				}
-				wg.Add(1)
-				go func(ctx context.Context, i int) {
-					defer wg.Done()
-
-					secs := int32(zipCtx.cpuProfDuration / time.Second)
-					if secs < 1 {
-						secs = 1
-					}
-
-					var pd profData
-					err := contextutil.RunWithTimeout(ctx, "fetch cpu profile", timeout+zipCtx.cpuProfDuration, func(ctx context.Context) error {
-						resp, err := status.Profile(ctx, &serverpb.ProfileRequest{
-							NodeId:  fmt.Sprintf("%d", nodeList[i].Desc.NodeID),
-							Type:    serverpb.ProfileRequest_CPU,
-							Seconds: secs,
+				resps[i] = make([]profData, len(profiles))
+				for j := range profiles {
+					wg.Add(1)
+					go func(ctx context.Context, i, j int) {
+						defer wg.Done()
+
+						var pd profData
+						err := contextutil.RunWithTimeout(ctx, "fetch profile", timeout+zipCtx.profDuration, func(ctx context.Context) error {
+							resp, err := status.Profile(ctx, &serverpb.ProfileRequest{
+								NodeId:  fmt.Sprintf("%d", nodeList[i].Desc.NodeID),
+								Type:    profiles[j].typ,
+								Seconds: secs,
+							})
+							if err != nil {
+								return err
+							}
+							pd = profData{data: resp.Data}
+							return nil
 						})
 						if err != nil {
-							return err
+							resps[i][j] = profData{err: err}
+						} else {
+							resps[i][j] = pd
 						}
-						pd = profData{data: resp.Data}
-						return nil
-					})
-					if err != nil {
-						resps[i] = profData{err: err}
-					} else {
-						resps[i] = pd
-					}
-				}(baseCtx, i)
+					}(baseCtx, i, j)
+				}
 			}
 
-			fmt.Print("requesting CPU profiles... ")
+			fmt.Print("requesting CPU and mutex profiles... ")
 			wg.Wait()
 			fmt.Println("ok")
 
-			for i, pd := range resps {
-				if len(pd.data) == 0 && pd.err == nil {
-					continue // skipped node
-				}
+			for i := range resps {
 				prefix := fmt.Sprintf("%s/%s", nodesPrefix, fmt.Sprintf("%d", nodeList[i].Desc.NodeID))
-				if err := z.createRawOrError(prefix+"/cpu.pprof", pd.data, pd.err); err != nil {
-					return err
+				// Skipped nodes have no profile data.
+				for j, pd := range resps[i] {
+					if err := z.createRawOrError(prefix+"/"+profiles[j].file, pd.data, pd.err); err != nil {
+						return err
+					}
 				}
 			}
 		}
 
+		c := nodeZipCollector{
+			status:     status,
+			sqlURL:     sqlConn.url,
+			tables:     nodeTables,
+			timeout:    timeout,
+			redactLogs: redactLogs,
+		}
+		var nodesToZip []statuspb.NodeStatus
+		var prefixes []string
 		for _, node := range nodeList {
-			nodeID := node.Desc.NodeID
-
-			liveness := livenessByNodeID[nodeID]
-			if liveness == livenesspb.NodeLivenessStatus_DECOMMISSIONED {
+			if livenessByNodeID[node.Desc.NodeID] == livenesspb.NodeLivenessStatus_DECOMMISSIONED {
 				// Decommissioned + process terminated. Let's not waste time
 				// on this node.
 				//
@@ -470,253 +572,16 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 				// still be up and willing to deliver some log files.
 				continue
 			}
-
-			id := fmt.Sprintf("%d", nodeID)
-			prefix := fmt.Sprintf("%s/%s", nodesPrefix, id)
-
-			if !zipCtx.nodes.isIncluded(nodeID) {
-				if err := z.createRaw(prefix+".skipped",
-					[]byte(fmt.Sprintf("skipping excluded node %d\n", nodeID))); err != nil {
-					return err
-				}
-				continue
-			}
-
-			// Don't use sqlConn because that's only for is the node `debug
-			// zip` was pointed at, but here we want to connect to nodes
-			// individually to grab node- local SQL tables. Try to guess by
-			// replacing the host in the connection string; this may or may
-			// not work and if it doesn't, we let the invalid curSQLConn get
-			// used anyway so that anything that does *not* need it will
-			// still happen.
-			sqlAddr := node.Desc.CheckedSQLAddress()
-			curSQLConn := guessNodeURL(sqlConn.url, sqlAddr.AddressField)
-			if err := z.createJSON(prefix+"/status.json", node); err != nil {
-				return err
-			}
-			fmt.Printf("using SQL connection URL for node %s: %s\n", id, curSQLConn.url)
-
-			for _, table := range debugZipTablesPerNode {
-				selectClause, ok := customSelectClause[table]
-				if !ok {
-					selectClause = "*"
-				}
-				if err := dumpTableDataForZip(z, curSQLConn, timeout, prefix, table, selectClause); err != nil {
-					return errors.Wrapf(err, "fetching %s", table)
-				}
-			}
-
-			for _, r := range []zipRequest{
-				{
-					fn: func(ctx context.Context) (interface{}, error) {
-						return status.Details(ctx, &serverpb.DetailsRequest{NodeId: id})
-					},
-					pathName: prefix + "/details",
-				},
-				{
-					fn: func(ctx context.Context) (interface{}, error) {
-						return status.Gossip(ctx, &serverpb.GossipRequest{NodeId: id})
-					},
-					pathName: prefix + "/gossip",
-				},
-				{
-					fn: func(ctx context.Context) (interface{}, error) {
-						return status.EngineStats(ctx, &serverpb.EngineStatsRequest{NodeId: id})
-					},
-					pathName: prefix + "/enginestats",
-				},
-			} {
-				if err := runZipRequest(r); err != nil {
-					return err
-				}
-			}
-
-			var stacksData []byte
-			err = runZipRequestWithTimeout(baseCtx, "requesting stacks for node "+id, timeout,
-				func(ctx context.Context) error {
-					stacks, err := status.Stacks(ctx, &serverpb.StacksRequest{
-						NodeId: id,
-						Type:   serverpb.StacksType_GOROUTINE_STACKS,
-					})
-					if err == nil {
-						stacksData = stacks.Data
-					}
-					return err
-				})
-			if err := z.createRawOrError(prefix+"/stacks.txt", stacksData, err); err != nil {
-				return err
-			}
-
-			var threadData []byte
-			err = runZipRequestWithTimeout(baseCtx, "requesting threads for node "+id, timeout,
-				func(ctx context.Context) error {
-					threads, err := status.Stacks(ctx, &serverpb.StacksRequest{
-						NodeId: id,
-						Type:   serverpb.StacksType_THREAD_STACKS,
-					})
-					if err == nil {
-						threadData = threads.Data
-					}
-					return err
-				})
-			if err := z.createRawOrError(prefix+"/threads.txt", threadData, err); err != nil {
-				return err
-			}
-
-			var heapData []byte
-			err = runZipRequestWithTimeout(baseCtx, "requesting heap profile for node "+id, timeout,
-				func(ctx context.Context) error {
-					heap, err := status.Profile(ctx, &serverpb.ProfileRequest{
-						NodeId: id,
-						Type:   serverpb.ProfileRequest_HEAP,
-					})
-					if err == nil {
-						heapData = heap.Data
-					}
-					return err
-				})
-			if err := z.createRawOrError(prefix+"/heap.pprof", heapData, err); err != nil {
-				return err
-			}
-
-			var profiles *serverpb.GetFilesResponse
-			if err := runZipRequestWithTimeout(baseCtx, "requesting heap files for node "+id, timeout,
-				func(ctx context.Context) error {
-					profiles, err = status.GetFiles(ctx, &serverpb.GetFilesRequest{
-						NodeId:   id,
-						Type:     serverpb.FileType_HEAP,
-						Patterns: []string{"*"},
-					})
-					return err
-				}); err != nil {
-				if err := z.createError(prefix+"/heapprof", err); err != nil {
-					return err
-				}
-			} else {
-				fmt.Printf("%d found\n", len(profiles.Files))
-				for _, file := range profiles.Files {
-					fName := maybeAddProfileSuffix(file.Name)
-					name := prefix + "/heapprof/" + fName
-					if err := z.createRaw(name, file.Contents); err != nil {
-						return err
-					}
-				}
-			}
-
-			var goroutinesResp *serverpb.GetFilesResponse
-			if err := runZipRequestWithTimeout(baseCtx, "requesting goroutine files for node "+id, timeout,
-				func(ctx context.Context) error {
-					goroutinesResp, err = status.GetFiles(ctx, &serverpb.GetFilesRequest{
-						NodeId:   id,
-						Type:     serverpb.FileType_GOROUTINES,
-						Patterns: []string{"*"},
-					})
-					return err
-				}); err != nil {
-				if err := z.createError(prefix+"/goroutines", err); err != nil {
-					return err
-				}
-			} else {
-				fmt.Printf("%d found\n", len(goroutinesResp.Files))
-				for _, file := range goroutinesResp.Files {
-					// NB: the files have a .txt.gz suffix already.
-					name := prefix + "/goroutines/" + file.Name
-					if err := z.createRawOrError(name, file.Contents, err); err != nil {
-						return err
-					}
-				}
-			}
-
-			var logs *serverpb.LogFilesListResponse
-			if err := runZipRequestWithTimeout(baseCtx, "requesting log files list", timeout,
-				func(ctx context.Context) error {
-					logs, err = status.LogFilesList(
-						ctx, &serverpb.LogFilesListRequest{NodeId: id})
-					return err
-				}); err != nil {
-				if err := z.createError(prefix+"/logs", err); err != nil {
-					return err
-				}
-			} else {
-				fmt.Printf("%d found\n", len(logs.Files))
-				for _, file := range logs.Files {
-					name := prefix + "/logs/" + file.Name
-					var entries *serverpb.LogEntriesResponse
-					if err := runZipRequestWithTimeout(baseCtx, fmt.Sprintf("requesting log file %s", file.Name), timeout,
-						func(ctx context.Context) error {
-							entries, err = status.LogFile(
-								ctx, &serverpb.LogFileRequest{
-									NodeId: id, File: file.Name, Redact: zipCtx.redactLogs,
-								})
-							return err
-						}); err != nil {
-						if err := z.createError(name, err); err != nil {
-							return err
-						}
-						continue
-					}
-					logOut, err := z.create(name, timeutil.Unix(0, file.ModTimeNanos))
-					if err != nil {
-						return err
-					}
-					warnRedactLeak := false
-					for _, e := range entries.Entries {
-						// If the user requests redaction, and some non-redactable
-						// data was found in the log, *despite KeepRedactable
-						// being set*, this means that this zip client is talking
-						// to a node that doesn't yet know how to redact. This
-						// also means that node may be leaking sensitive data.
-						//
-						// In that case, we do the redaction work ourselves in the
-						// most conservative way possible. (It's not great that
-						// possibly confidential data flew over the network, but
-						// at least it stops here.)
-						if zipCtx.redactLogs && !e.Redactable {
-							e.Message = "REDACTEDBYZIP"
-							// We're also going to print a warning at the end.
-							warnRedactLeak = true
-						}
-						if err := log.FormatEntry(e, logOut); err != nil {
-							return err
-						}
-					}
-					if warnRedactLeak {
-						// Defer the warning, so that it does not get "drowned" as
-						// part of the main zip output.
-						defer func(fileName string) {
-							fmt.Fprintf(stderr, "WARNING: server-side redaction failed for %s, completed client-side (--redact-logs=true)\n", fileName)
-						}(file.Name)
-					}
-				}
-			}
-
-			var ranges *serverpb.RangesResponse
-			if err := runZipRequestWithTimeout(baseCtx, "requesting ranges", timeout, func(ctx context.Context) error {
-				ranges, err = status.Ranges(ctx, &serverpb.RangesRequest{NodeId: id})
-				return err
-			}); err != nil {
-				if err := z.createError(prefix+"/ranges", err); err != nil {
-					return err
-				}
-			} else {
-				fmt.Printf("%d found\n", len(ranges.Ranges))
-				sort.Slice(ranges.Ranges, func(i, j int) bool {
-					return ranges.Ranges[i].State.Desc.RangeID <
-						ranges.Ranges[j].State.Desc.RangeID
-				})
-				for _, r := range ranges.Ranges {
-					name := fmt.Sprintf("%s/ranges/%s", prefix, r.State.Desc.RangeID)
-					if err := z.createJSON(name+".json", r); err != nil {
-						return err
-					}
-				}
-			}
+			nodesToZip = append(nodesToZip, node)
+			prefixes = append(prefixes, fmt.Sprintf("%s/%d", nodesPrefix, node.Desc.NodeID))
+		}
+		if err := c.zipNodes(baseCtx, z, nodesToZip, prefixes, zipCtx.concurrency); err != nil {
+			return err
 		}
 	}
-
 	{
 		var databases *serverpb.DatabasesResponse
-		if err := runZipRequestWithTimeout(baseCtx, "requesting list of SQL databases", timeout, func(ctx context.Context) error {
+		if err := z.runRequestWithTimeout(baseCtx, "requesting list of SQL databases", timeout, func(ctx context.Context) error {
 			databases, err = admin.Databases(ctx, &serverpb.DatabasesRequest{})
 			return err
 		}); err != nil {
@@ -729,7 +594,7 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 			for _, dbName := range databases.Databases {
 				prefix := schemaPrefix + "/" + dbEscaper.escape(dbName)
 				var database *serverpb.DatabaseDetailsResponse
-				requestErr := runZipRequestWithTimeout(baseCtx, fmt.Sprintf("requesting database details for %s", dbName), timeout,
+				requestErr := z.runRequestWithTimeout(baseCtx, fmt.Sprintf("requesting database details for %s", dbName), timeout,
 					func(ctx context.Context) error {
 						database, err = admin.DatabaseDetails(ctx, &serverpb.DatabaseDetailsRequest{Database: dbName})
 						return err
@@ -746,7 +611,7 @@ func runDebugZip(cmd *cobra.Command, args []string) (retErr error) {
 				for _, tableName := range database.TableNames {
 					name := prefix + "/" + tbEscaper.escape(tableName)
 					var table *serverpb.TableDetailsResponse
-					err := runZipRequestWithTimeout(baseCtx, fmt.Sprintf("requesting table details for %s.%s", dbName, tableName), timeout,
+					err := z.runRequestWithTimeout(baseCtx, fmt.Sprintf("requesting table details for %s.%s", dbName, tableName), timeout,
 						func(ctx context.Context) error {
 							table, err = admin.TableDetails(ctx, &serverpb.TableDetailsRequest{Database: dbName, Table: tableName})
 							return err
@@ -778,6 +643,15 @@ find . -path './nodes/*/ranges/*.json' -print0 | xargs -0 grep per_second | sort
 		}
 	}
 
+	if z.numErrors > 0 {
+		// The zip file is still useful, but automation should be able to
+		// tell that it is incomplete.
+		return &cliError{
+			exitCode: exit.PartialSuccess(),
+			cause: errors.New(
+				"some information could not be retrieved; see the .err.txt files in the zip file"),
+		}
+	}
 	return nil
 }
 
@@ -836,7 +710,7 @@ func dumpTableDataForZip(
 	query := fmt.Sprintf(`SET statement_timeout = '%s'; SELECT %s FROM %s`, timeout, selectClause, table)
 	baseName := base + "/" + table
 
-	fmt.Printf("retrieving SQL data for %s... ", table)
+	fmt.Fprintf(z.out, "retrieving SQL data for %s... ", table)
 	const maxRetries = 5
 	suffix := ""
 	for numRetries := 1; numRetries <= maxRetries; numRetries++ {
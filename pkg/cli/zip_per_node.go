@@ -0,0 +1,381 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// nodeZipCollector retrieves the per-node data of debug zip.
+type nodeZipCollector struct {
+	status serverpb.StatusClient
+	// sqlURL is the URL of the SQL connection to the node debug zip was
+	// pointed at. The URLs of the other nodes are derived from it.
+	sqlURL     string
+	tables     []string
+	timeout    time.Duration
+	redactLogs bool
+}
+
+// zipNodes retrieves the data of the given nodes, fetching the data of up to
+// concurrency nodes in parallel. The data of each node is buffered in a
+// temporary file and then written to z in the order of the nodes, so that the
+// contents of the zip file and the progress messages don't depend on the order
+// in which the nodes respond. At most concurrency nodes are buffered at any
+// time.
+func (c *nodeZipCollector) zipNodes(
+	ctx context.Context, z *zipper, nodes []statuspb.NodeStatus, prefixes []string, concurrency int,
+) error {
+	if concurrency <= 1 {
+		// Stream the data of each node directly into the zip file.
+		for i := range nodes {
+			if err := c.collect(ctx, z, nodes[i], prefixes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type nodeResult struct {
+		z   *zipper
+		err error
+	}
+	results := make([]chan nodeResult, len(nodes))
+	for i := range results {
+		results[i] = make(chan nodeResult, 1)
+	}
+	// sem bounds the number of nodes being retrieved or waiting to be written.
+	// A slot is released once the data of a node has been written.
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	defer func() {
+		// Stop the retrievals still in flight and remove the data which was
+		// not written to z.
+		cancel()
+		wg.Wait()
+		for i := range results {
+			select {
+			case res := <-results[i]:
+				if res.z != nil {
+					res.z.discard()
+				}
+			default:
+			}
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range nodes {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				nz, err := newBufferedZipper()
+				if err == nil {
+					err = c.collect(ctx, nz, nodes[i], prefixes[i])
+				}
+				results[i] <- nodeResult{z: nz, err: err}
+			}(i)
+		}
+	}()
+
+	for i := range nodes {
+		var res nodeResult
+		select {
+		case res = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if res.z != nil {
+			if err := res.z.flushTo(z); err != nil {
+				return err
+			}
+		}
+		if res.err != nil {
+			return res.err
+		}
+		<-sem
+	}
+	return nil
+}
+
+// runRequest runs the request and writes its result, or the error, to z.
+func (c *nodeZipCollector) runRequest(ctx context.Context, z *zipper, r zipRequest) error {
+	var data interface{}
+	err := z.runRequestWithTimeout(ctx, "requesting data for "+r.pathName, c.timeout, func(ctx context.Context) error {
+		var err error
+		data, err = r.fn(ctx)
+		return err
+	})
+	return z.createJSONOrError(r.pathName+".json", data, err)
+}
+
+// collect retrieves the data of the given node into z, under the given
+// prefix.
+func (c *nodeZipCollector) collect(
+	ctx context.Context, z *zipper, node statuspb.NodeStatus, prefix string,
+) error {
+	nodeID := node.Desc.NodeID
+	id := fmt.Sprintf("%d", nodeID)
+
+	if !zipCtx.nodes.isIncluded(nodeID) {
+		return z.createRaw(prefix+".skipped",
+			[]byte(fmt.Sprintf("skipping excluded node %d\n", nodeID)))
+	}
+
+	// Don't use sqlConn because that's only for is the node `debug
+	// zip` was pointed at, but here we want to connect to nodes
+	// individually to grab node- local SQL tables. Try to guess by
+	// replacing the host in the connection string; this may or may
+	// not work and if it doesn't, we let the invalid curSQLConn get
+	// used anyway so that anything that does *not* need it will
+	// still happen.
+	sqlAddr := node.Desc.CheckedSQLAddress()
+	curSQLConn := guessNodeURL(c.sqlURL, sqlAddr.AddressField)
+	if err := z.createJSON(prefix+"/status.json", node); err != nil {
+		return err
+	}
+	fmt.Fprintf(z.out, "using SQL connection URL for node %s: %s\n", id, curSQLConn.url)
+
+	for _, table := range c.tables {
+		if err := dumpTableDataForZip(z, curSQLConn, c.timeout, prefix, table, zipSelectClause(table)); err != nil {
+			return errors.Wrapf(err, "fetching %s", table)
+		}
+	}
+
+	for _, r := range []zipRequest{
+		{
+			fn: func(ctx context.Context) (interface{}, error) {
+				return c.status.Details(ctx, &serverpb.DetailsRequest{NodeId: id})
+			},
+			pathName: prefix + "/details",
+		},
+		{
+			fn: func(ctx context.Context) (interface{}, error) {
+				return c.status.Gossip(ctx, &serverpb.GossipRequest{NodeId: id})
+			},
+			pathName: prefix + "/gossip",
+		},
+		{
+			fn: func(ctx context.Context) (interface{}, error) {
+				return c.status.EngineStats(ctx, &serverpb.EngineStatsRequest{NodeId: id})
+			},
+			pathName: prefix + "/enginestats",
+		},
+	} {
+		if err := c.runRequest(ctx, z, r); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	var stacksData []byte
+	err = z.runRequestWithTimeout(ctx, "requesting stacks for node "+id, c.timeout,
+		func(ctx context.Context) error {
+			stacks, err := c.status.Stacks(ctx, &serverpb.StacksRequest{
+				NodeId: id,
+				Type:   serverpb.StacksType_GOROUTINE_STACKS,
+			})
+			if err == nil {
+				stacksData = stacks.Data
+			}
+			return err
+		})
+	if err := z.createRawOrError(prefix+"/stacks.txt", stacksData, err); err != nil {
+		return err
+	}
+
+	var threadData []byte
+	err = z.runRequestWithTimeout(ctx, "requesting threads for node "+id, c.timeout,
+		func(ctx context.Context) error {
+			threads, err := c.status.Stacks(ctx, &serverpb.StacksRequest{
+				NodeId: id,
+				Type:   serverpb.StacksType_THREAD_STACKS,
+			})
+			if err == nil {
+				threadData = threads.Data
+			}
+			return err
+		})
+	if err := z.createRawOrError(prefix+"/threads.txt", threadData, err); err != nil {
+		return err
+	}
+
+	var heapData []byte
+	err = z.runRequestWithTimeout(ctx, "requesting heap profile for node "+id, c.timeout,
+		func(ctx context.Context) error {
+			heap, err := c.status.Profile(ctx, &serverpb.ProfileRequest{
+				NodeId: id,
+				Type:   serverpb.ProfileRequest_HEAP,
+			})
+			if err == nil {
+				heapData = heap.Data
+			}
+			return err
+		})
+	if err := z.createRawOrError(prefix+"/heap.pprof", heapData, err); err != nil {
+		return err
+	}
+
+	var profiles *serverpb.GetFilesResponse
+	if err := z.runRequestWithTimeout(ctx, "requesting heap files for node "+id, c.timeout,
+		func(ctx context.Context) error {
+			profiles, err = c.status.GetFiles(ctx, &serverpb.GetFilesRequest{
+				NodeId:   id,
+				Type:     serverpb.FileType_HEAP,
+				Patterns: []string{"*"},
+			})
+			return err
+		}); err != nil {
+		if err := z.createError(prefix+"/heapprof", err); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(z.out, "%d found\n", len(profiles.Files))
+		for _, file := range profiles.Files {
+			fName := maybeAddProfileSuffix(file.Name)
+			name := prefix + "/heapprof/" + fName
+			if err := z.createRaw(name, file.Contents); err != nil {
+				return err
+			}
+		}
+	}
+
+	var goroutinesResp *serverpb.GetFilesResponse
+	if err := z.runRequestWithTimeout(ctx, "requesting goroutine files for node "+id, c.timeout,
+		func(ctx context.Context) error {
+			goroutinesResp, err = c.status.GetFiles(ctx, &serverpb.GetFilesRequest{
+				NodeId:   id,
+				Type:     serverpb.FileType_GOROUTINES,
+				Patterns: []string{"*"},
+			})
+			return err
+		}); err != nil {
+		if err := z.createError(prefix+"/goroutines", err); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(z.out, "%d found\n", len(goroutinesResp.Files))
+		for _, file := range goroutinesResp.Files {
+			// NB: the files have a .txt.gz suffix already.
+			name := prefix + "/goroutines/" + file.Name
+			if err := z.createRawOrError(name, file.Contents, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	var logs *serverpb.LogFilesListResponse
+	if err := z.runRequestWithTimeout(ctx, "requesting log files list", c.timeout,
+		func(ctx context.Context) error {
+			logs, err = c.status.LogFilesList(
+				ctx, &serverpb.LogFilesListRequest{NodeId: id})
+			return err
+		}); err != nil {
+		if err := z.createError(prefix+"/logs", err); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(z.out, "%d found\n", len(logs.Files))
+		for _, file := range logs.Files {
+			name := prefix + "/logs/" + file.Name
+			var entries *serverpb.LogEntriesResponse
+			if err := z.runRequestWithTimeout(ctx, fmt.Sprintf("requesting log file %s", file.Name), c.timeout,
+				func(ctx context.Context) error {
+					entries, err = c.status.LogFile(
+						ctx, &serverpb.LogFileRequest{
+							NodeId: id, File: file.Name, Redact: c.redactLogs,
+						})
+					return err
+				}); err != nil {
+				if err := z.createError(name, err); err != nil {
+					return err
+				}
+				continue
+			}
+			logOut, err := z.create(name, timeutil.Unix(0, file.ModTimeNanos))
+			if err != nil {
+				return err
+			}
+			warnRedactLeak := false
+			for _, e := range entries.Entries {
+				// If the user requests redaction, and some non-redactable
+				// data was found in the log, *despite KeepRedactable
+				// being set*, this means that this zip client is talking
+				// to a node that doesn't yet know how to redact. This
+				// also means that node may be leaking sensitive data.
+				//
+				// In that case, we do the redaction work ourselves in the
+				// most conservative way possible. (It's not great that
+				// possibly confidential data flew over the network, but
+				// at least it stops here.)
+				if c.redactLogs && !e.Redactable {
+					e.Message = redactedMarker
+					// We're also going to print a warning at the end.
+					warnRedactLeak = true
+				}
+				if err := log.FormatEntry(e, logOut); err != nil {
+					return err
+				}
+			}
+			if warnRedactLeak {
+				// Defer the warning, so that it does not get "drowned" as
+				// part of the main zip output.
+				z.warnings = append(z.warnings, fmt.Sprintf(
+					"server-side redaction failed for %s, completed client-side (--redact-logs=true)", file.Name))
+			}
+		}
+	}
+
+	var ranges *serverpb.RangesResponse
+	if err := z.runRequestWithTimeout(ctx, "requesting ranges", c.timeout, func(ctx context.Context) error {
+		ranges, err = c.status.Ranges(ctx, &serverpb.RangesRequest{NodeId: id})
+		return err
+	}); err != nil {
+		if err := z.createError(prefix+"/ranges", err); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(z.out, "%d found\n", len(ranges.Ranges))
+		sort.Slice(ranges.Ranges, func(i, j int) bool {
+			return ranges.Ranges[i].State.Desc.RangeID <
+				ranges.Ranges[j].State.Desc.RangeID
+		})
+		for _, r := range ranges.Ranges {
+			name := fmt.Sprintf("%s/ranges/%s", prefix, r.State.Desc.RangeID)
+			if zipCtx.redact {
+				redactRangeInfo(&r)
+			}
+			if err := z.createJSON(name+".json", r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
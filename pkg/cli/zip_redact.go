@@ -0,0 +1,171 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+// redactedMarker replaces the data which debug zip removes entirely when
+// redacting.
+const redactedMarker = "REDACTEDBYZIP"
+
+// Override for the select clause used to dump a table when --redact is
+// specified. The columns which can contain user data are replaced, and the
+// other columns are retained so that the structure of the data is preserved.
+var redactedSelectClause = map[string]string{
+	"crdb_internal.cluster_queries": redactedQueriesSelectClause,
+	"crdb_internal.node_queries":    redactedQueriesSelectClause,
+
+	"crdb_internal.cluster_sessions": redactedSessionsSelectClause,
+	"crdb_internal.node_sessions":    redactedSessionsSelectClause,
+
+	"crdb_internal.cluster_transactions": redactedTxnsSelectClause,
+	"crdb_internal.node_transactions":    redactedTxnsSelectClause,
+
+	"crdb_internal.node_statement_statistics": `node_id, application_name, flags, key,
+anonymized, count, first_attempt_count, max_retries,
+'` + redactedMarker + `' AS last_error, rows_avg, rows_var, parse_lat_avg, parse_lat_var,
+plan_lat_avg, plan_lat_var, run_lat_avg, run_lat_var, service_lat_avg, service_lat_var,
+overhead_lat_avg, overhead_lat_var, bytes_read_avg, bytes_read_var, rows_read_avg,
+rows_read_var, implicit_txn, timed_out_count, canceled_count`,
+
+	"crdb_internal.jobs": `job_id, job_type,
+crdb_internal.hide_sql_constants(description) AS description,
+crdb_internal.hide_sql_constants(statement) AS statement,
+user_name, descriptor_ids, status, running_status, created, started, finished, modified,
+fraction_completed, high_water_timestamp, '` + redactedMarker + `' AS error, coordinator_id,
+'` + redactedMarker + `' AS conversion_report, '` + redactedMarker + `' AS details,
+num_runs, last_run`,
+	// The payload and progress of jobs contain their descriptions, details and
+	// errors; they are omitted.
+	"system.jobs": `id, status, created, created_by_type, created_by_id,
+claim_session_id, claim_instance_id`,
+	"system.job_info": `job_id, info_key, length(value) AS value_length`,
+	// Descriptors contain the names of the schema objects, the default and
+	// computed expressions of their columns, their partitioning values, etc.;
+	// only their size is retained.
+	"system.descriptor": `id, length(descriptor) AS descriptor_length`,
+
+	"crdb_internal.partitions": `table_id, index_id, parent_name, name, columns, column_names,
+'` + redactedMarker + `' AS list_value, '` + redactedMarker + `' AS range_value,
+zone_id, subzone_id`,
+}
+
+const redactedQueriesSelectClause = `query_id, txn_id, node_id, session_id, user_name, start,
+crdb_internal.hide_sql_constants(query) AS query, client_address, application_name,
+distributed, phase`
+
+const redactedSessionsSelectClause = `node_id, session_id, user_name, client_address,
+application_name, crdb_internal.hide_sql_constants(active_queries) AS active_queries,
+crdb_internal.hide_sql_constants(last_active_query) AS last_active_query, session_start,
+oldest_query_start, kv_txn, alloc_bytes, max_alloc_bytes, cluster_name`
+
+// The string representation of a transaction contains its anchor key.
+const redactedTxnsSelectClause = `id, node_id, session_id, start,
+'` + redactedMarker + `' AS txn_string, application_name, num_stmts, num_retries,
+num_auto_retries, user_name, isolation, priority, idle`
+
+// zipSelectClause returns the select clause used to dump the given table.
+func zipSelectClause(table string) string {
+	if zipCtx.redact {
+		if selectClause, ok := redactedSelectClause[table]; ok {
+			return selectClause
+		}
+	}
+	if selectClause, ok := customSelectClause[table]; ok {
+		return selectClause
+	}
+	return "*"
+}
+
+// redactKey returns a copy of key in which the SQL data following the table
+// and index IDs, i.e. the values of the indexed columns, is replaced by a hash
+// of it. Keys outside of the SQL keyspace don't contain user data and are
+// returned as is.
+func redactKey(key roachpb.Key) roachpb.Key {
+	rest, _, err := keys.DecodeTenantPrefix(key)
+	if err != nil {
+		return roachpb.Key(redactedMarker)
+	}
+	// Skip the table and index IDs, if present.
+	data := rest
+	for i := 0; i < 2 && encoding.PeekType(data) == encoding.Int; i++ {
+		if data, _, err = encoding.DecodeUvarintAscending(data); err != nil {
+			return roachpb.Key(redactedMarker)
+		}
+	}
+	if len(data) == 0 || len(data) == len(rest) {
+		return key
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	prefix := key[:len(key)-len(data)]
+	redacted := make(roachpb.Key, len(prefix), len(prefix)+len(redactedMarker)+16)
+	copy(redacted, prefix)
+	return encoding.EncodeBytesAscending(redacted, []byte(fmt.Sprintf("%s-%08x", redactedMarker, h.Sum32())))
+}
+
+// redactRangeDescriptor redacts the keys of desc in place.
+func redactRangeDescriptor(desc *roachpb.RangeDescriptor) {
+	if desc == nil {
+		return
+	}
+	desc.StartKey = roachpb.RKey(redactKey(desc.StartKey.AsRawKey()))
+	desc.EndKey = roachpb.RKey(redactKey(desc.EndKey.AsRawKey()))
+}
+
+// redactRangeInfo redacts the keys of a range retrieved by debug zip in place.
+func redactRangeInfo(r *serverpb.RangeInfo) {
+	redactRangeDescriptor(r.State.Desc)
+	if r.State.Desc != nil {
+		r.Span = serverpb.PrettySpan{
+			StartKey: r.State.Desc.StartKey.String(),
+			EndKey:   r.State.Desc.EndKey.String(),
+		}
+	} else {
+		r.Span = serverpb.PrettySpan{StartKey: redactedMarker, EndKey: redactedMarker}
+	}
+	if r.ErrorMessage != "" {
+		r.ErrorMessage = redactedMarker
+	}
+}
+
+// redactRangeLog redacts the keys of the range log events retrieved by debug
+// zip in place.
+func redactRangeLog(resp *serverpb.RangeLogResponse) {
+	for i := range resp.Events {
+		e := &resp.Events[i]
+		if info := e.Event.Info; info != nil {
+			redactRangeDescriptor(info.UpdatedDesc)
+			redactRangeDescriptor(info.NewDesc)
+			redactRangeDescriptor(info.RemovedDesc)
+			if info.UpdatedDesc != nil {
+				e.PrettyInfo.UpdatedDesc = info.UpdatedDesc.String()
+			}
+			if info.NewDesc != nil {
+				e.PrettyInfo.NewDesc = info.NewDesc.String()
+			}
+			if info.Details != "" {
+				info.Details = redactedMarker
+			}
+		}
+		if e.PrettyInfo.Details != "" {
+			e.PrettyInfo.Details = redactedMarker
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipRedactKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rowKey := func(codec keys.SQLCodec, val string) roachpb.Key {
+		return encoding.EncodeStringAscending(codec.IndexPrefix(53, 1), val)
+	}
+	tenantCodec := keys.MakeSQLCodec(roachpb.MakeTenantID(5))
+
+	// Keys which don't contain user data are left alone.
+	for _, key := range []roachpb.Key{
+		roachpb.KeyMin,
+		roachpb.KeyMax,
+		keys.TimeseriesPrefix,
+		keys.SystemSQLCodec.TablePrefix(53),
+		keys.SystemSQLCodec.IndexPrefix(53, 1),
+		tenantCodec.IndexPrefix(53, 1),
+	} {
+		require.Equal(t, key, redactKey(key), "%s", key)
+	}
+
+	for _, tc := range []struct {
+		key    roachpb.Key
+		prefix string
+	}{
+		{rowKey(keys.SystemSQLCodec, "secret"), `/Table/53/1/"REDACTEDBYZIP-`},
+		{rowKey(tenantCodec, "secret"), `/Tenant/5/Table/53/1/"REDACTEDBYZIP-`},
+	} {
+		redacted := redactKey(tc.key).String()
+		require.True(t, strings.HasPrefix(redacted, tc.prefix), redacted)
+		require.NotContains(t, redacted, "secret")
+	}
+
+	// Equal keys are redacted the same way, different keys differently.
+	require.Equal(t,
+		redactKey(rowKey(keys.SystemSQLCodec, "a")), redactKey(rowKey(keys.SystemSQLCodec, "a")))
+	require.NotEqual(t,
+		redactKey(rowKey(keys.SystemSQLCodec, "a")), redactKey(rowKey(keys.SystemSQLCodec, "b")))
+}
+
+func TestZipRedactRangeInfo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	start := encoding.EncodeStringAscending(keys.SystemSQLCodec.IndexPrefix(53, 1), "secret")
+	end := keys.SystemSQLCodec.TablePrefix(54)
+	r := serverpb.RangeInfo{
+		Span: serverpb.PrettySpan{StartKey: start.String(), EndKey: end.String()},
+		State: kvserverpb.RangeInfo{ReplicaState: kvserverpb.ReplicaState{
+			Desc: &roachpb.RangeDescriptor{StartKey: roachpb.RKey(start), EndKey: roachpb.RKey(end)},
+		}},
+		ErrorMessage: `failed to split at "secret"`,
+	}
+	redactRangeInfo(&r)
+
+	require.Equal(t, redactKey(start), r.State.Desc.StartKey.AsRawKey())
+	require.Equal(t, end, r.State.Desc.EndKey.AsRawKey())
+	require.Equal(t, redactKey(start).String(), r.Span.StartKey)
+	require.Equal(t, "/Table/54", r.Span.EndKey)
+	require.Equal(t, redactedMarker, r.ErrorMessage)
+}
+
+func TestZipRedactSelectClause(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	defer func(redact bool) { zipCtx.redact = redact }(zipCtx.redact)
+	zipCtx.redact = true
+
+	// The tables dumped with a custom select clause contain encoded protos
+	// which can't be redacted column by column, so they must not be dumped
+	// as is.
+	for table, selectClause := range customSelectClause {
+		require.NotEqual(t, selectClause, zipSelectClause(table), table)
+	}
+
+	descClause := zipSelectClause("system.descriptor")
+	require.NotContains(t, descClause, "*")
+	require.NotContains(t, descClause, "hex_descriptor")
+	require.Equal(t, "*", zipSelectClause("crdb_internal.node_build_info"))
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/errors"
+)
+
+// zipTableRegistry lists the tables which debug zip dumps with SQL. The
+// tables actually dumped can be restricted with --include and --exclude.
+type zipTableRegistry []string
+
+// Tables containing cluster-wide info that are collected in a debug zip.
+var debugZipTablesPerCluster = zipTableRegistry{
+	"crdb_internal.cluster_database_privileges",
+	"crdb_internal.cluster_queries",
+	"crdb_internal.cluster_sessions",
+	"crdb_internal.cluster_settings",
+	"crdb_internal.cluster_transactions",
+
+	"crdb_internal.jobs",
+	"system.jobs",       // get the raw, restorable jobs records too.
+	"system.job_info",   // and the progress of jobs, see jobs.ProgressColumn.
+	"system.descriptor", // descriptors also contain job-like mutation state.
+	"system.namespace",
+	"system.namespace2", // TODO(sqlexec): consider removing in 20.2 or later.
+
+	"crdb_internal.kv_node_status",
+	"crdb_internal.kv_store_status",
+	"crdb_internal.kv_store_disk_status",
+	"crdb_internal.kv_raft_status",
+	"crdb_internal.kv_raft_progress",
+	"crdb_internal.kv_follower_read_status",
+
+	"crdb_internal.schema_changes",
+	"crdb_internal.partitions",
+	"crdb_internal.zones",
+	"crdb_internal.invalid_objects",
+}
+
+// Tables collected from each node in a debug zip.
+var debugZipTablesPerNode = zipTableRegistry{
+	"crdb_internal.feature_usage",
+
+	"crdb_internal.gossip_alerts",
+	"crdb_internal.gossip_liveness",
+	"crdb_internal.gossip_network",
+	"crdb_internal.gossip_nodes",
+
+	"crdb_internal.leases",
+
+	"crdb_internal.node_build_info",
+	"crdb_internal.node_memory_monitors",
+	"crdb_internal.node_metrics",
+	"crdb_internal.node_queries",
+	"crdb_internal.node_runtime_info",
+	"crdb_internal.node_sessions",
+	"crdb_internal.node_statement_statistics",
+	"crdb_internal.node_transaction_statistics",
+	"crdb_internal.node_transactions",
+	"crdb_internal.node_txn_stats",
+}
+
+// Override for the default SELECT * when dumping the table.
+var customSelectClause = map[string]string{
+	"system.jobs": "*, to_hex(payload) AS hex_payload, to_hex(" +
+		jobs.JobInfoProgressColumn + ") AS hex_progress",
+	"system.job_info":   "*, to_hex(value) AS hex_value",
+	"system.descriptor": "*, to_hex(descriptor) AS hex_descriptor",
+}
+
+// Override for customSelectClause when dumping the table from a cluster on
+// which system.job_info doesn't exist, see hasJobInfoTable.
+var legacySelectClause = map[string]string{
+	"system.jobs": "*, to_hex(payload) AS hex_payload, to_hex(progress) AS hex_progress",
+}
+
+// filter splits the tables of the registry into those selected by sel and
+// those skipped.
+func (r zipTableRegistry) filter(sel *tableSelection) (included, skipped []string) {
+	for _, table := range r {
+		if sel.isIncluded(table) {
+			included = append(included, table)
+		} else {
+			skipped = append(skipped, table)
+		}
+	}
+	return included, skipped
+}
+
+// tableSelection selects tables by name using glob patterns, e.g.
+// "crdb_internal.node_*" or "system.jobs". The patterns are matched with
+// path.Match.
+type tableSelection struct {
+	// include, if not empty, restricts the selected tables to those matching
+	// one of the patterns.
+	include []string
+	// exclude removes the tables matching one of the patterns from the
+	// selection.
+	exclude []string
+}
+
+// validate returns an error if one of the patterns is malformed.
+func (s *tableSelection) validate() error {
+	for _, patterns := range [][]string{s.include, s.exclude} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "invalid table pattern %q", pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *tableSelection) isIncluded(table string) bool {
+	isIncluded := len(s.include) == 0 || matchesAny(s.include, table)
+	return isIncluded && !matchesAny(s.exclude, table)
+}
+
+func matchesAny(patterns []string, table string) bool {
+	table = strings.ToLower(table)
+	for _, pattern := range patterns {
+		// Malformed patterns are rejected by validate.
+		if ok, _ := path.Match(strings.ToLower(pattern), table); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// zipManifest describes how a debug zip was collected. It is written at the
+// root of the zip so that whoever inspects the zip knows which data was left
+// out on purpose.
+type zipManifest struct {
+	IncludePatterns      []string `json:"include_patterns,omitempty"`
+	ExcludePatterns      []string `json:"exclude_patterns,omitempty"`
+	SkippedClusterTables []string `json:"skipped_cluster_tables,omitempty"`
+	SkippedNodeTables    []string `json:"skipped_node_tables,omitempty"`
+	Redacted             bool     `json:"redacted"`
+	RedactedLogs         bool     `json:"redacted_logs"`
+}
@@ -42,7 +42,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/datadriven"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestZipContainsAllInternalTables verifies that we don't add new internal tables
@@ -66,20 +68,32 @@ table_name NOT IN (
 	-- allowlisted tables that don't need to be in debug zip
 	'backward_dependencies',
 	'builtin_functions',
+	'compactions',
 	'create_statements',
 	'create_type_statements',
 	'databases',
 	'forward_dependencies',
 	'index_columns',
+	'kv_consistency_checks',
+	'lingering_intents',
+	'node_diagnostics_report',
+	'object_dependencies',
 	'table_columns',
+	'table_disk_usage',
 	'table_indexes',
+	'table_mvcc_stats',
 	'table_row_statistics',
 	'ranges',
 	'ranges_no_leases',
+	'cluster_role_memberships',
+	'cluster_upgrade_status',
+	'flows',
 	'predefined_comments',
+	'prepared_statements',
 	'session_trace',
 	'session_variables',
-	'tables'
+	'tables',
+	'web_sessions'
 )
 ORDER BY name ASC`)
 	assert.NoError(t, err)
@@ -93,6 +107,7 @@ ORDER BY name ASC`)
 	tables = append(
 		tables,
 		"system.jobs",
+		"system.job_info",
 		"system.descriptor",
 		"system.namespace",
 		"system.namespace2",
@@ -121,7 +136,7 @@ func TestZip(t *testing.T) {
 	})
 	defer c.cleanup()
 
-	out, err := c.RunWithCapture("debug zip --cpu-profile-duration=1s " + os.DevNull)
+	out, err := c.RunWithCapture("debug zip --profile-duration=1s " + os.DevNull)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -162,7 +177,7 @@ create table defaultdb."pg_catalog.pg_class"(x int);
 create table defaultdb."../system"(x int);
 `})
 
-	out, err := c.RunWithCapture("debug zip --cpu-profile-duration=0 " + os.DevNull)
+	out, err := c.RunWithCapture("debug zip --profile-duration=0 " + os.DevNull)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -235,7 +250,7 @@ func TestUnavailableZip(t *testing.T) {
 	stderr = os.Stdout
 
 	// Keep the timeout short so that the test doesn't take forever.
-	out, err := c.RunWithCapture("debug zip --cpu-profile-duration=0 " + os.DevNull + " --timeout=.5s")
+	out, err := c.RunWithCapture("debug zip --profile-duration=0 " + os.DevNull + " --timeout=.5s")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -306,7 +321,7 @@ func TestPartialZip(t *testing.T) {
 	defer func(prevStderr *os.File) { stderr = prevStderr }(stderr)
 	stderr = os.Stdout
 
-	out, err := c.RunWithCapture("debug zip --cpu-profile-duration=0s " + os.DevNull)
+	out, err := c.RunWithCapture("debug zip --profile-duration=0s " + os.DevNull)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -320,8 +335,17 @@ func TestPartialZip(t *testing.T) {
 			return out
 		})
 
+	// The output doesn't depend on whether the nodes are retrieved in
+	// parallel. The first line, which echoes the command, is skipped.
+	serialOut, err := c.RunWithCapture("debug zip --profile-duration=0s --concurrency=1 " + os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialOut = eraseNonDeterministicZipOutput(serialOut)
+	assert.Equal(t, strings.SplitN(out, "\n", 2)[1], strings.SplitN(serialOut, "\n", 2)[1])
+
 	// Now do it again and exclude the down node explicitly.
-	out, err = c.RunWithCapture("debug zip " + os.DevNull + " --exclude-nodes=2 --cpu-profile-duration=0")
+	out, err = c.RunWithCapture("debug zip " + os.DevNull + " --exclude-nodes=2 --profile-duration=0")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -356,7 +380,7 @@ func TestPartialZip(t *testing.T) {
 	datadriven.RunTest(t, "testdata/zip/partial2",
 		func(t *testing.T, td *datadriven.TestData) string {
 			f := func() string {
-				out, err := c.RunWithCapture("debug zip --cpu-profile-duration=0 " + os.DevNull)
+				out, err := c.RunWithCapture("debug zip --profile-duration=0 " + os.DevNull)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -442,6 +466,70 @@ test/generate_series(1,15000) as t(x).4.txt.err.txt
 	assert.Equal(t, expected, fileList.String())
 }
 
+func TestZipBufferedFlush(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, cleanupFn := testutils.TempDir(t)
+	defer cleanupFn()
+	zipName := filepath.Join(dir, "test.zip")
+
+	out, err := os.Create(zipName)
+	require.NoError(t, err)
+	z := newZipper(out)
+	var progress bytes.Buffer
+	z.out = &progress
+
+	// Fill the buffered zippers out of order, then flush them in order.
+	var buffered []*zipper
+	for i := 0; i < 2; i++ {
+		bz, err := newBufferedZipper()
+		require.NoError(t, err)
+		buffered = append(buffered, bz)
+	}
+	require.NoError(t, buffered[1].createRaw("b/1.txt", []byte("b1")))
+	require.NoError(t, buffered[0].createRaw("a/1.txt", []byte("a1")))
+	require.NoError(t, buffered[0].createError("a/2.txt", errors.New("boom")))
+	buffered[1].warnings = append(buffered[1].warnings, "b warning")
+	require.NoError(t, z.createRaw("first.txt", []byte("first")))
+	for _, bz := range buffered {
+		spillName := bz.spill.Name()
+		require.NoError(t, bz.flushTo(z))
+		// The temporary file is removed once its contents are written.
+		_, err := os.Stat(spillName)
+		require.True(t, oserror.IsNotExist(err), "%v", err)
+	}
+	require.Equal(t, []string{"b warning"}, z.warnings)
+	require.NoError(t, z.close())
+
+	require.Equal(t, `writing: first.txt
+writing: a/1.txt
+writing: a/2.txt.err.txt
+  ^- resulted in boom
+writing: b/1.txt
+`, progress.String())
+
+	r, err := zip.OpenReader(zipName)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+	var files []string
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		var contents bytes.Buffer
+		_, err = contents.ReadFrom(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files = append(files, fmt.Sprintf("%s: %s", f.Name, strings.TrimSpace(contents.String())))
+	}
+	require.Equal(t, []string{
+		"first.txt: first",
+		"a/1.txt: a1",
+		"a/2.txt.err.txt: boom",
+		"b/1.txt: b1",
+	}, files)
+}
+
 // This test the operation of zip over secure clusters.
 func TestToHex(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -459,7 +547,7 @@ func TestToHex(t *testing.T) {
 	// Create a job to have non-empty system.jobs table.
 	c.RunWithArgs([]string{"sql", "-e", "CREATE STATISTICS foo FROM system.namespace"})
 
-	_, err := c.RunWithCapture("debug zip --cpu-profile-duration=0 " + dir + "/debug.zip")
+	_, err := c.RunWithCapture("debug zip --profile-duration=0 " + dir + "/debug.zip")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -571,3 +659,53 @@ func TestNodeRangeSelection(t *testing.T) {
 		}
 	}
 }
+
+func TestZipTableSelection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Avoid leaking configuration changes after the tests end.
+	defer initCLIDefaults()
+
+	registry := zipTableRegistry{
+		"crdb_internal.jobs",
+		"crdb_internal.node_metrics",
+		"crdb_internal.node_queries",
+		"system.jobs",
+		"system.job_info",
+	}
+	testData := []struct {
+		args        []string
+		wantSkipped []string
+	}{
+		{nil, nil},
+		{[]string{"--exclude=crdb_internal.node_metrics"}, []string{"crdb_internal.node_metrics"}},
+		{[]string{"--exclude=crdb_internal.node_*"},
+			[]string{"crdb_internal.node_metrics", "crdb_internal.node_queries"}},
+		{[]string{"--include=*.jobs"},
+			[]string{"crdb_internal.node_metrics", "crdb_internal.node_queries", "system.job_info"}},
+		{[]string{"--include=system.*,crdb_internal.jobs", "--exclude=system.job_info"},
+			[]string{"crdb_internal.node_metrics", "crdb_internal.node_queries", "system.job_info"}},
+		{[]string{"--include=SYSTEM.JOBS"},
+			[]string{"crdb_internal.jobs", "crdb_internal.node_metrics", "crdb_internal.node_queries", "system.job_info"}},
+	}
+
+	f := debugZipCmd.Flags()
+	for _, tc := range testData {
+		initCLIDefaults()
+		if err := f.Parse(tc.args); err != nil {
+			t.Fatalf("Parse(%#v) got unexpected error: %v", tc.args, err)
+		}
+		assert.NoError(t, zipCtx.tables.validate())
+
+		included, skipped := registry.filter(&zipCtx.tables)
+		assert.Equal(t, tc.wantSkipped, skipped, "%v", tc.args)
+		assert.Equal(t, len(registry), len(included)+len(skipped), "%v", tc.args)
+	}
+
+	initCLIDefaults()
+	if err := f.Parse([]string{"--exclude=crdb_internal.[node"}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, zipCtx.tables.validate())
+}
@@ -206,6 +206,18 @@ const (
 	VirtualComputedColumns
 	// CPutInline is conditional put support for inline values.
 	CPutInline
+	// DatabaseRoleSettings adds the system.database_role_settings table, which
+	// stores the default session variables of roles and databases.
+	DatabaseRoleSettings
+	// JobInfoTable adds the system.job_info table, to which the progress of
+	// jobs is written instead of the progress column of system.jobs.
+	JobInfoTable
+	// SCRAMAuthentication allows passwords to be stored as SCRAM-SHA-256
+	// verifiers in system.users, which older nodes cannot verify.
+	SCRAMAuthentication
+	// OnUpdateExpressions is when ON UPDATE expressions are supported on
+	// columns.
+	OnUpdateExpressions
 
 	// Step (1): Add new versions here.
 )
@@ -340,6 +352,22 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     CPutInline,
 		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 10},
 	},
+	{
+		Key:     DatabaseRoleSettings,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 12},
+	},
+	{
+		Key:     JobInfoTable,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 14},
+	},
+	{
+		Key:     SCRAMAuthentication,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 16},
+	},
+	{
+		Key:     OnUpdateExpressions,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 18},
+	},
 
 	// Step (2): Add new versions here.
 })
@@ -372,6 +400,17 @@ func ListBetween(from, to ClusterVersion) []ClusterVersion {
 	return listBetweenInternal(from, to, versionsSingleton)
 }
 
+// KeyFor returns the key of the given version, and false if the version isn't
+// one of the versions known to this binary.
+func KeyFor(v roachpb.Version) (Key, bool) {
+	for _, keyedV := range versionsSingleton {
+		if keyedV.Version == v {
+			return keyedV.Key, true
+		}
+	}
+	return 0, false
+}
+
 func listBetweenInternal(from, to ClusterVersion, vs keyedVersions) []ClusterVersion {
 	var cvs []ClusterVersion
 	for _, keyedV := range vs {
@@ -129,3 +129,15 @@ func TestGetVersionsBetween(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyFor(t *testing.T) {
+	if k, ok := KeyFor(ByKey(DatabaseRoleSettings)); !ok || k != DatabaseRoleSettings {
+		t.Errorf("expected %s, got %s (found: %t)", DatabaseRoleSettings, k, ok)
+	}
+	// Fence versions aren't keyed.
+	fence := ByKey(DatabaseRoleSettings)
+	fence.Internal--
+	if k, ok := KeyFor(fence); ok {
+		t.Errorf("expected no key for %s, got %s", fence, k)
+	}
+}
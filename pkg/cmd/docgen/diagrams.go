@@ -799,7 +799,7 @@ var specs = []stmtSpec{
 		name:   "explain_stmt",
 		inline: []string{"explain_option_list"},
 		replace: map[string]string{
-			"explain_option_name": "( 'VERBOSE' | 'TYPES' | 'OPT' | 'DISTSQL' | 'VEC' )",
+			"explain_option": "( 'VERBOSE' | 'TYPES' | 'OPT' | 'DISTSQL' | 'VEC' | 'FORMAT' ( 'TEXT' | 'JSON' | 'DOT' ) )",
 		},
 		exclude: []*regexp.Regexp{
 			regexp.MustCompile("'ANALYZE'"),
@@ -811,7 +811,7 @@ var specs = []stmtSpec{
 		stmt:   "explain_stmt",
 		inline: []string{"explain_option_list"},
 		replace: map[string]string{
-			"explain_option_name": "( 'DISTSQL' | 'DEBUG' )",
+			"explain_option": "( 'DISTSQL' | 'DEBUG' )",
 		},
 		unlink: []string{"'DISTSQL'"},
 	},
@@ -1234,6 +1234,7 @@ var specs = []stmtSpec{
 	},
 	{
 		name:    "show_create_stmt",
+		inline:  []string{"with_original"},
 		replace: map[string]string{"table_name": "object_name"},
 		unlink:  []string{"object_name"},
 	},
@@ -1344,7 +1345,7 @@ var specs = []stmtSpec{
 	{
 		name:    "show_tables",
 		stmt:    "show_tables_stmt",
-		inline:  []string{"with_comment"},
+		inline:  []string{"opt_show_tables_with"},
 		replace: map[string]string{"'FROM' name": "'FROM' database_name", "'.' name": "'.' schema_name"},
 		unlink:  []string{"schema.name"},
 	},
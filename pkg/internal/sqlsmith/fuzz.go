@@ -0,0 +1,355 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlsmith
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+)
+
+// FuzzConfig configures a run of Fuzz.
+type FuzzConfig struct {
+	// Seed seeds the generation of the setup and of the statements. Two runs
+	// with the same seed and configuration generate the same statements, as
+	// long as the statements they execute behave the same way.
+	Seed int64
+	// Setup is the name of the entry of Setups used to create the initial
+	// tables.
+	Setup string
+	// Setting is the name of the entry of Settings used to configure the
+	// Smither.
+	Setting string
+	// NumStatements is the number of statements generated after the setup.
+	NumStatements int
+	// CheckShowCreate enables checking, after every statement that can modify
+	// the schema, that the output of SHOW CREATE for every table creates an
+	// identical table.
+	CheckShowCreate bool
+	// Log, if set, receives every statement before it is executed.
+	Log io.Writer
+}
+
+// FuzzFailure is the error returned by Fuzz when a statement fails with an
+// internal error, when the connection to the server is lost, or when the
+// output of SHOW CREATE doesn't round-trip.
+type FuzzFailure struct {
+	// Seed is the seed of the run which failed.
+	Seed int64
+	// Statements are the statements that were executed, starting with the
+	// setup and ending with the statement that failed.
+	Statements []string
+	// Cause is the error of the last statement.
+	Cause error
+
+	checkShowCreate bool
+}
+
+var _ error = (*FuzzFailure)(nil)
+
+// Error implements the error interface.
+func (f *FuzzFailure) Error() string {
+	return fmt.Sprintf("seed %d: statement %d: %v", f.Seed, len(f.Statements), f.Cause)
+}
+
+// Unwrap returns the error of the last statement.
+func (f *FuzzFailure) Unwrap() error {
+	return f.Cause
+}
+
+// Fuzz creates a new database through db, initializes it with the configured
+// setup and executes random statements in it. open must return connections
+// to the database it is passed the name of.
+//
+// Statements are expected to fail with user errors, which are ignored. If a
+// statement fails otherwise, a *FuzzFailure is returned and the database is
+// left in place to be inspected; it is dropped when the run succeeds.
+func Fuzz(
+	ctx context.Context,
+	db *gosql.DB,
+	open func(dbName string) (*gosql.DB, error),
+	cfg FuzzConfig,
+) error {
+	setup, ok := Setups[cfg.Setup]
+	if !ok {
+		return errors.Errorf("unknown setup %q", cfg.Setup)
+	}
+	settingFn, ok := Settings[cfg.Setting]
+	if !ok {
+		return errors.Errorf("unknown setting %q", cfg.Setting)
+	}
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	setting := settingFn(rnd)
+
+	dbName := "fuzz_" + strconv.FormatUint(uint64(cfg.Seed), 10)
+	fuzzDB, err := createFuzzDatabase(ctx, db, open, dbName)
+	if err != nil {
+		return err
+	}
+	defer fuzzDB.Close()
+
+	failure := &FuzzFailure{Seed: cfg.Seed, checkShowCreate: cfg.CheckShowCreate}
+	run := func(stmt string) (bool, error) {
+		if cfg.Log != nil {
+			fmt.Fprintf(cfg.Log, "%s;\n\n", stmt)
+		}
+		failure.Statements = append(failure.Statements, stmt)
+		cause, modifiesSchema, err := execFuzzStatement(ctx, fuzzDB, stmt, cfg.CheckShowCreate)
+		if err != nil {
+			return false, err
+		}
+		if cause != nil {
+			failure.Cause = cause
+			return false, failure
+		}
+		return modifiesSchema, nil
+	}
+
+	if _, err := run(setup(rnd)); err != nil {
+		return err
+	}
+	smither, err := NewSmither(fuzzDB, rnd, setting.Options...)
+	if err != nil {
+		return err
+	}
+	defer smither.Close()
+	for i := 0; i < cfg.NumStatements; i++ {
+		modifiesSchema, err := run(smither.Generate())
+		if err != nil {
+			return err
+		}
+		if modifiesSchema {
+			if err := smither.ReloadSchemas(); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE %s CASCADE", tree.NameString(dbName)))
+	return err
+}
+
+// Shrink returns a failure with a subset of the statements of f which still
+// fails with the same error, found by removing statements and replaying the
+// remaining ones in new databases. The last statement of f is never removed.
+func Shrink(
+	ctx context.Context,
+	db *gosql.DB,
+	open func(dbName string) (*gosql.DB, error),
+	f *FuzzFailure,
+) (*FuzzFailure, error) {
+	dbName := "fuzz_" + strconv.FormatUint(uint64(f.Seed), 10) + "_shrink"
+	expected := f.Cause.Error()
+	// reproduces replays stmts and returns the prefix of stmts which fails with
+	// the expected error, if any.
+	reproduces := func(stmts []string) ([]string, error) {
+		fuzzDB, err := createFuzzDatabase(ctx, db, open, dbName)
+		if err != nil {
+			return nil, err
+		}
+		defer fuzzDB.Close()
+		for i, stmt := range stmts {
+			cause, _, err := execFuzzStatement(ctx, fuzzDB, stmt, f.checkShowCreate)
+			if err != nil {
+				return nil, err
+			}
+			if cause != nil {
+				if cause.Error() == expected {
+					return stmts[:i+1], nil
+				}
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+
+	stmts := f.Statements
+	// Try to remove chunks of statements, halving the size of the chunks once
+	// none of the chunks of the current size can be removed.
+	for chunk := len(stmts) / 2; chunk >= 1; chunk /= 2 {
+		for start := 0; start < len(stmts)-1; {
+			end := start + chunk
+			if end > len(stmts)-1 {
+				end = len(stmts) - 1
+			}
+			candidate := append(append([]string(nil), stmts[:start]...), stmts[end:]...)
+			reduced, err := reproduces(candidate)
+			if err != nil {
+				return nil, err
+			}
+			if reduced != nil {
+				stmts = reduced
+			} else {
+				start = end
+			}
+		}
+	}
+
+	if _, err := db.ExecContext(
+		ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s CASCADE", tree.NameString(dbName)),
+	); err != nil {
+		return nil, err
+	}
+	return &FuzzFailure{
+		Seed:            f.Seed,
+		Statements:      stmts,
+		Cause:           f.Cause,
+		checkShowCreate: f.checkShowCreate,
+	}, nil
+}
+
+// createFuzzDatabase creates the database with the given name, dropping it
+// first if it exists, and opens connections to it.
+func createFuzzDatabase(
+	ctx context.Context,
+	db *gosql.DB,
+	open func(dbName string) (*gosql.DB, error),
+	dbName string,
+) (*gosql.DB, error) {
+	name := tree.NameString(dbName)
+	if _, err := db.ExecContext(
+		ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s CASCADE; CREATE DATABASE %s", name, name),
+	); err != nil {
+		return nil, err
+	}
+	return open(dbName)
+}
+
+// execFuzzStatement executes stmt and returns the cause of the failure it
+// triggered, if any, and whether stmt can modify the schema. The error is
+// only set if the failure couldn't be determined.
+func execFuzzStatement(
+	ctx context.Context, db *gosql.DB, stmt string, checkShowCreate bool,
+) (cause error, modifiesSchema bool, _ error) {
+	if stmts, err := parser.Parse(stmt); err == nil {
+		for i := range stmts {
+			if tree.CanModifySchema(stmts[i].AST) {
+				modifiesSchema = true
+			}
+		}
+	}
+	if _, err := db.ExecContext(ctx, stmt); err != nil && isFuzzFailure(err) {
+		return err, modifiesSchema, nil
+	}
+	if checkShowCreate && modifiesSchema {
+		cause, err := checkShowCreateRoundTrip(ctx, db)
+		return cause, modifiesSchema, err
+	}
+	return nil, modifiesSchema, nil
+}
+
+// isFuzzFailure returns whether err, returned by the execution of a random
+// statement, indicates a bug: internal errors, and errors which aren't
+// returned by the server, such as the loss of the connection to a server
+// which crashed.
+func isFuzzFailure(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return true
+	}
+	return pqErr.Code == "XX000"
+}
+
+// showCreateRoundTripTable is the name of the table created to check that the
+// output of SHOW CREATE round-trips.
+const showCreateRoundTripTable = "show_create_round_trip"
+
+// checkShowCreateRoundTrip checks that the output of SHOW CREATE for every
+// table of the current database creates a table with the same output, and
+// returns the cause of the failure otherwise. Foreign keys are not checked,
+// as the tables they reference can't always be created first.
+func checkShowCreateRoundTrip(ctx context.Context, db *gosql.DB) (cause error, _ error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT schema_name, descriptor_name, create_nofks
+  FROM crdb_internal.create_statements
+ WHERE database_name = current_database() AND descriptor_type = 'table'`)
+	if err != nil {
+		return nil, err
+	}
+	type table struct {
+		schema, name, create string
+	}
+	var tables []table
+	for rows.Next() {
+		var t table
+		if err := rows.Scan(&t.schema, &t.name, &t.create); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tables {
+		stmt, err := parser.ParseOne(t.create)
+		if err != nil {
+			return errors.Wrapf(err, "SHOW CREATE TABLE %s cannot be parsed", tree.NameString(t.name)), nil
+		}
+		create, ok := stmt.AST.(*tree.CreateTable)
+		if !ok {
+			return errors.Errorf("SHOW CREATE TABLE %s is not a CREATE TABLE statement", tree.NameString(t.name)), nil
+		}
+		expected := tree.AsString(create)
+		create.Table.ObjectName = showCreateRoundTripTable
+		if _, err := db.ExecContext(ctx, tree.AsString(create)); err != nil {
+			// Tables can fail to be created again for reasons unrelated to the
+			// output of SHOW CREATE, such as session settings which were set by
+			// other statements.
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code != "XX000" && pqErr.Code != "42601" {
+				continue
+			}
+			return errors.Wrapf(err, "SHOW CREATE TABLE %s cannot be executed", tree.NameString(t.name)), nil
+		}
+
+		var actualCreate string
+		if err := db.QueryRowContext(ctx, `
+SELECT create_nofks
+  FROM crdb_internal.create_statements
+ WHERE database_name = current_database() AND schema_name = $1 AND descriptor_name = $2`,
+			t.schema, showCreateRoundTripTable,
+		).Scan(&actualCreate); err != nil {
+			return nil, err
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			"DROP TABLE %s.%s",
+			tree.NameString(t.schema), tree.NameString(showCreateRoundTripTable),
+		)); err != nil {
+			return nil, err
+		}
+		stmt, err = parser.ParseOne(actualCreate)
+		if err != nil {
+			return errors.Wrapf(err, "SHOW CREATE TABLE %s cannot be parsed after a round trip", tree.NameString(t.name)), nil
+		}
+		create, ok = stmt.AST.(*tree.CreateTable)
+		if !ok {
+			return errors.Errorf("SHOW CREATE TABLE %s is not a CREATE TABLE statement after a round trip", tree.NameString(t.name)), nil
+		}
+		create.Table.ObjectName = tree.Name(t.name)
+		if actual := tree.AsString(create); actual != expected {
+			return errors.WithDetailf(
+				errors.Errorf("SHOW CREATE TABLE %s does not round-trip", tree.NameString(t.name)),
+				"before:\n%s\nafter:\n%s", expected, actual,
+			), nil
+		}
+	}
+	return nil, nil
+}
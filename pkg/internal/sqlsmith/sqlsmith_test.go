@@ -12,8 +12,10 @@ package sqlsmith
 
 import (
 	"context"
+	gosql "database/sql"
 	"flag"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -152,3 +154,84 @@ func TestGenerateParse(t *testing.T) {
 		}
 	}
 }
+
+// TestFuzz verifies that Fuzz executes random schema changes while checking
+// that the output of SHOW CREATE round-trips.
+func TestFuzz(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer utilccl.TestingEnableEnterprise()()
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	open := func(dbName string) (*gosql.DB, error) {
+		return serverutils.OpenDBConnE(s, base.TestServerArgs{UseDatabase: dbName}, s.Stopper())
+	}
+	_, seed := randutil.NewPseudoRand()
+	t.Log("seed:", seed)
+	var log strings.Builder
+	err := Fuzz(ctx, sqlDB, open, FuzzConfig{
+		Seed:            seed,
+		Setup:           "rand-tables",
+		Setting:         "ddl-nodrop",
+		NumStatements:   *flagNum,
+		CheckShowCreate: true,
+		Log:             &log,
+	})
+	if err != nil {
+		t.Log(log.String())
+		t.Fatal(err)
+	}
+}
+
+// TestShrink verifies that Shrink removes the statements which aren't needed
+// to reproduce a failure.
+func TestShrink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	open := func(dbName string) (*gosql.DB, error) {
+		return serverutils.OpenDBConnE(s, base.TestServerArgs{UseDatabase: dbName}, s.Stopper())
+	}
+	const failing = `SELECT crdb_internal.force_assertion_error(v) FROM t`
+	failure := &FuzzFailure{
+		Statements: []string{
+			`CREATE TABLE t (v STRING)`,
+			`CREATE TABLE u (k INT PRIMARY KEY)`,
+			`INSERT INTO u VALUES (1), (2)`,
+			`SELECT 1`,
+			`INSERT INTO t VALUES ('boom')`,
+			`SELECT k FROM u`,
+			failing,
+		},
+	}
+	_, err := sqlDB.Exec(`CREATE DATABASE shrink; CREATE TABLE shrink.t (v STRING); INSERT INTO shrink.t VALUES ('boom')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shrinkDB, err := open("shrink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, failure.Cause = shrinkDB.Exec(failing)
+	if failure.Cause == nil {
+		t.Fatal("expected an error")
+	}
+
+	shrunk, err := Shrink(ctx, sqlDB, open, failure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`CREATE TABLE t (v STRING)`,
+		`INSERT INTO t VALUES ('boom')`,
+		failing,
+	}
+	if !reflect.DeepEqual(shrunk.Statements, expected) {
+		t.Fatalf("expected %q, got %q", expected, shrunk.Statements)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -138,7 +139,7 @@ func (r *Registry) resumeJob(ctx context.Context, jobID int64, s sqlliveness.Ses
 	row, err := r.ex.QueryRowEx(
 		ctx, "get-job-row", nil,
 		sessiondata.InternalExecutorOverride{User: security.NodeUserName()}, `
-SELECT status, payload, progress, crdb_internal.sql_liveness_is_alive(claim_session_id)
+SELECT status, payload, `+ProgressColumn(ctx, r.settings)+`, crdb_internal.sql_liveness_is_alive(claim_session_id)
 FROM system.jobs WHERE id = $1 AND claim_session_id = $2`,
 		jobID, s.ID().UnsafeBytes(),
 	)
@@ -172,6 +173,17 @@ FROM system.jobs WHERE id = $1 AND claim_session_id = $2`,
 		return nil
 	}
 
+	// Jobs whose previous runs ended with retryable errors are only resumed
+	// after a backoff.
+	if delay := r.retryDelay(payload.NumRetries); delay > 0 {
+		resumeAt := timeutil.FromUnixMicros(payload.LastRetryMicros).Add(delay)
+		if r.clock.Now().GoTime().Before(resumeAt) {
+			log.VEventf(ctx, 2, "job %d: backing off until %s after %d retries",
+				jobID, resumeAt, payload.NumRetries)
+			return nil
+		}
+	}
+
 	progress, err := UnmarshalProgress(row[2])
 	if err != nil {
 		return err
@@ -255,7 +267,10 @@ func (r *Registry) runJob(
 	defer span.Finish()
 
 	// Run the actual job.
-	err := r.stepThroughStateMachine(ctx, execCtx, resumer, resultsCh, job, status, finalResumeError)
+	err := job.runStarted(ctx)
+	if err == nil {
+		err = r.stepThroughStateMachine(ctx, execCtx, resumer, resultsCh, job, status, finalResumeError)
+	}
 	// If the context has been canceled, disregard errors for the sake of logging
 	// as presumably they are due to the context cancellation which commonly
 	// happens during shutdown.
@@ -292,14 +307,25 @@ RETURNING id, status`,
 			statusString := *row[1].(*tree.DString)
 			switch Status(statusString) {
 			case StatusPaused:
+				if err := recordStatusTransition(
+					ctx, r.ex, r.settings, txn, id, StatusPauseRequested, StatusPaused,
+				); err != nil {
+					return err
+				}
 				r.unregister(id)
 				log.Infof(ctx, "job %d, session %s: paused", id, s.ID())
 			case StatusReverting:
+				if err := recordStatusTransition(
+					ctx, r.ex, r.settings, txn, id, StatusCancelRequested, StatusReverting,
+				); err != nil {
+					return err
+				}
 				if err := job.WithTxn(txn).Update(ctx, func(txn *kv.Txn, md JobMetadata, ju *JobUpdater) error {
 					r.unregister(id)
 					md.Payload.Error = errJobCanceled.Error()
 					encodedErr := errors.EncodeError(ctx, errJobCanceled)
 					md.Payload.FinalResumeError = &encodedErr
+					md.Payload.NumRetries = 0
 					ju.UpdatePayload(md.Payload)
 					return nil
 				}); err != nil {
@@ -0,0 +1,180 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// progressInfoKey is the key of the progress of a job in system.job_info.
+const progressInfoKey = "progress"
+
+// statusTransitionInfoKeyPrefix is the prefix of the keys of the status
+// transitions of a job in system.job_info. Each transition has its own key,
+// suffixed with the timestamp of the transaction which made it, so that the
+// keys of a job sort chronologically.
+const statusTransitionInfoKeyPrefix = "status/"
+
+// ProgressColumn returns the SQL expression reading the progress of a job in
+// a query over system.jobs.
+//
+// Once the JobInfoTable cluster version is active, the progress of a job is
+// written to its own row of system.job_info instead of the progress column of
+// system.jobs, which then holds the progress written before the upgrade. The
+// payload of jobs, which is written once and seldom updated, stays in
+// system.jobs.
+func ProgressColumn(ctx context.Context, st *cluster.Settings) string {
+	if !st.Version.IsActive(ctx, clusterversion.JobInfoTable) {
+		return "progress"
+	}
+	return JobInfoProgressColumn
+}
+
+// JobInfoProgressColumn is the SQL expression returned by ProgressColumn once
+// the JobInfoTable cluster version is active. It can be used by clients which
+// don't know the cluster version but know that system.job_info exists.
+const JobInfoProgressColumn = `COALESCE((SELECT value FROM system.job_info ` +
+	`WHERE job_id = jobs.id AND info_key = '` + progressInfoKey + `'), progress)`
+
+// writeJobInfo writes the value of the given key of the job in system.job_info.
+func writeJobInfo(
+	ctx context.Context,
+	ex sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	jobID int64,
+	key string,
+	value []byte,
+) error {
+	_, err := ex.ExecEx(
+		ctx, "write-job-info", txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPSERT INTO system.job_info (job_id, info_key, value) VALUES ($1, $2, $3)`,
+		jobID, key, value,
+	)
+	return err
+}
+
+// DeleteJobInfo deletes the rows of system.job_info of the jobs with the
+// given IDs, an array of INTs.
+func DeleteJobInfo(
+	ctx context.Context,
+	ex sqlutil.InternalExecutor,
+	st *cluster.Settings,
+	txn *kv.Txn,
+	ids *tree.DArray,
+) error {
+	if !st.Version.IsActive(ctx, clusterversion.JobInfoTable) {
+		return nil
+	}
+	_, err := ex.ExecEx(
+		ctx, "delete-job-info", txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`DELETE FROM system.job_info WHERE job_id = ANY($1)`,
+		ids,
+	)
+	return err
+}
+
+// StatusTransition is a change of the status of a job.
+type StatusTransition struct {
+	// Time is the time at which the transition was committed. It is derived
+	// from the key of the transition rather than stored in its value.
+	Time time.Time `json:"-"`
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+}
+
+func statusTransitionInfoKey(ts hlc.Timestamp) string {
+	return fmt.Sprintf("%s%019d.%010d", statusTransitionInfoKeyPrefix, ts.WallTime, ts.Logical)
+}
+
+func parseStatusTransitionInfoKey(key string) (time.Time, error) {
+	parts := strings.Split(strings.TrimPrefix(key, statusTransitionInfoKeyPrefix), ".")
+	if len(parts) != 2 {
+		return time.Time{}, errors.AssertionFailedf("malformed status transition key %q", key)
+	}
+	wallTime, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, errors.NewAssertionErrorWithWrappedErrf(err,
+			"malformed status transition key %q", key)
+	}
+	return timeutil.Unix(0, wallTime), nil
+}
+
+// recordStatusTransition records in system.job_info the change of the status
+// of the job from one status to another made by txn. It is a no-op before the
+// JobInfoTable cluster version is active.
+func recordStatusTransition(
+	ctx context.Context,
+	ex sqlutil.InternalExecutor,
+	st *cluster.Settings,
+	txn *kv.Txn,
+	jobID int64,
+	from, to Status,
+) error {
+	if from == to || !st.Version.IsActive(ctx, clusterversion.JobInfoTable) {
+		return nil
+	}
+	value, err := json.Marshal(StatusTransition{From: from, To: to})
+	if err != nil {
+		return err
+	}
+	return writeJobInfo(ctx, ex, txn, jobID, statusTransitionInfoKey(txn.ReadTimestamp()), value)
+}
+
+// StatusTransitions returns the status transitions of the job, oldest first.
+// Transitions are only recorded once the JobInfoTable cluster version is
+// active, so the transitions of older jobs may be missing.
+func StatusTransitions(
+	ctx context.Context, ex sqlutil.InternalExecutor, st *cluster.Settings, jobID int64,
+) ([]StatusTransition, error) {
+	if !st.Version.IsActive(ctx, clusterversion.JobInfoTable) {
+		return nil, nil
+	}
+	rows, err := ex.QueryEx(
+		ctx, "job-status-transitions", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT info_key, value FROM system.job_info
+WHERE job_id = $1 AND info_key LIKE '`+statusTransitionInfoKeyPrefix+`%'
+ORDER BY info_key`,
+		jobID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	transitions := make([]StatusTransition, len(rows))
+	for i, row := range rows {
+		t := &transitions[i]
+		if t.Time, err = parseStatusTransitionInfoKey(string(tree.MustBeDString(row[0]))); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(tree.MustBeDBytes(row[1])), t); err != nil {
+			return nil, errors.Wrapf(err, "job %d: failed to decode status transition", jobID)
+		}
+	}
+	return transitions, nil
+}
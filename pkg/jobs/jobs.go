@@ -234,6 +234,40 @@ func (j *Job) started(ctx context.Context) error {
 	})
 }
 
+// runStarted records that a new run of the job is starting, either because it
+// was just started or because it was resumed by a registry.
+func (j *Job) runStarted(ctx context.Context) error {
+	return j.Update(ctx, func(_ *kv.Txn, md JobMetadata, ju *JobUpdater) error {
+		md.Payload.NumRuns++
+		md.Payload.LastRunMicros = timeutil.ToUnixMicros(j.registry.clock.Now().GoTime())
+		ju.UpdatePayload(md.Payload)
+		return nil
+	})
+}
+
+// retried records that the current run of the job ended with a retryable
+// error and returns the number of retries recorded so far.
+func (j *Job) retried(ctx context.Context) (numRetries int64, _ error) {
+	err := j.Update(ctx, func(_ *kv.Txn, md JobMetadata, ju *JobUpdater) error {
+		md.Payload.NumRetries++
+		md.Payload.LastRetryMicros = timeutil.ToUnixMicros(j.registry.clock.Now().GoTime())
+		numRetries = md.Payload.NumRetries
+		ju.UpdatePayload(md.Payload)
+		return nil
+	})
+	return numRetries, err
+}
+
+// resetRetries clears the retries recorded for a job which checkpointed its
+// progress, so that only consecutive runs failing without making progress
+// count towards the backoff and jobs.registry.retry.max_retries.
+func resetRetries(md JobMetadata, ju *JobUpdater) {
+	if md.Payload.NumRetries != 0 {
+		md.Payload.NumRetries = 0
+		ju.UpdatePayload(md.Payload)
+	}
+}
+
 // CheckStatus verifies the status of the job and returns an error if the job's
 // status isn't Running or Reverting.
 func (j *Job) CheckStatus(ctx context.Context) error {
@@ -340,6 +374,7 @@ func (j *Job) FractionProgressed(ctx context.Context, progressedFn FractionProgr
 			FractionCompleted: fractionCompleted,
 		}
 		ju.UpdateProgress(md.Progress)
+		resetRetries(md, ju)
 		return nil
 	})
 }
@@ -366,6 +401,7 @@ func (j *Job) HighWaterProgressed(ctx context.Context, progressedFn HighWaterPro
 			HighWater: &highWater,
 		}
 		ju.UpdateProgress(md.Progress)
+		resetRetries(md, ju)
 		return nil
 	})
 }
@@ -415,6 +451,9 @@ func (j *Job) unpaused(ctx context.Context) error {
 		// NB: A nil lease indicates the job is not resumable, whereas an empty
 		// lease is always considered expired.
 		md.Payload.Lease = &jobspb.Lease{}
+		// A job resumed by the user shouldn't wait out the backoff of earlier
+		// retries.
+		md.Payload.NumRetries = 0
 		ju.UpdatePayload(md.Payload)
 		return nil
 	})
@@ -532,13 +571,16 @@ func (j *Job) reverted(
 			md.Payload.Error = err.Error()
 			encodedErr := errors.EncodeError(ctx, err)
 			md.Payload.FinalResumeError = &encodedErr
-			ju.UpdatePayload(md.Payload)
 		} else {
 			if md.Payload.FinalResumeError == nil {
 				return errors.AssertionFailedf(
 					"tried to mark job as reverting, but no error was provided or recorded")
 			}
 		}
+		// Retries while reverting are counted separately from the retries of
+		// the resumption that failed.
+		md.Payload.NumRetries = 0
+		ju.UpdatePayload(md.Payload)
 		ju.UpdateStatus(StatusReverting)
 		return nil
 	})
@@ -634,6 +676,7 @@ func (j *Job) SetProgress(ctx context.Context, details interface{}) error {
 		}
 		md.Progress.Details = jobspb.WrapProgressDetails(details)
 		ju.UpdateProgress(md.Progress)
+		resetRetries(md, ju)
 		return nil
 	})
 }
@@ -714,8 +757,9 @@ func (j *Job) load(ctx context.Context) error {
 	var createdBy *CreatedByInfo
 
 	if err := j.runInTxn(ctx, func(ctx context.Context, txn *kv.Txn) error {
-		const newStmt = "SELECT payload, progress, created_by_type, created_by_id FROM system.jobs WHERE id = $1"
-		const oldStmt = "SELECT payload, progress FROM system.jobs WHERE id = $1"
+		progressColumn := ProgressColumn(ctx, j.registry.settings)
+		newStmt := "SELECT payload, " + progressColumn + ", created_by_type, created_by_id FROM system.jobs WHERE id = $1"
+		oldStmt := "SELECT payload, " + progressColumn + " FROM system.jobs WHERE id = $1"
 		hasCreatedBy := j.registry.settings.Version.IsActive(ctx, clusterversion.AlterSystemJobsAddCreatedByColumns)
 		stmt := oldStmt
 		if hasCreatedBy {
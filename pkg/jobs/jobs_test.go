@@ -2502,7 +2502,10 @@ func TestMetrics(t *testing.T) {
 	})
 	setup := func(t *testing.T) (s serverutils.TestServerInterface, r *jobs.Registry, cleanup func()) {
 		jobConstructorCleanup := jobs.ResetConstructors()
-		s, _, _ = serverutils.StartServer(t, base.TestServerArgs{})
+		var db *gosql.DB
+		s, db, _ = serverutils.StartServer(t, base.TestServerArgs{})
+		// Retry jobs right away rather than after a backoff.
+		sqlutils.MakeSQLRunner(db).Exec(t, `SET CLUSTER SETTING jobs.registry.retry.initial_delay = '0s'`)
 		r = s.JobRegistry().(*jobs.Registry)
 		return s, r, func() {
 			jobConstructorCleanup()
@@ -2696,3 +2699,127 @@ func TestLoseLeaseDuringExecution(t *testing.T) {
 	registry.TestingNudgeAdoptionQueue()
 	require.Regexp(t, `expected session '\w+' but found NULL`, <-resumed)
 }
+
+// TestJobRetryBackoff tests that jobs failing with retryable errors are
+// resumed after a backoff, and failed once they exceed their retries.
+func TestJobRetryBackoff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer jobs.TestingSetAdoptAndCancelIntervals(10*time.Millisecond, 10*time.Millisecond)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	defer jobs.ResetConstructors()()
+
+	var resumes int64
+	jobs.RegisterConstructor(jobspb.TypeImport, func(_ *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return jobs.FakeResumer{
+			OnResume: func(context.Context, chan<- tree.Datums) error {
+				atomic.AddInt64(&resumes, 1)
+				return jobs.NewRetryJobError("injected retry error")
+			},
+		}
+	})
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	registry := s.JobRegistry().(*jobs.Registry)
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.registry.retry.initial_delay = '1h'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.registry.retry.max_retries = 3`)
+
+	rec := jobs.Record{
+		DescriptorIDs: []descpb.ID{1},
+		Details:       jobspb.ImportDetails{},
+		Progress:      jobspb.ImportProgress{},
+	}
+	j, err := registry.CreateAdoptableJobWithTxn(ctx, rec, nil /* txn */)
+	require.NoError(t, err)
+
+	// The first run fails and records its retry.
+	testutils.SucceedsSoon(t, func() error {
+		var numRuns int
+		var status string
+		sqlDB.QueryRow(t, `SELECT status, num_runs FROM [SHOW JOB $1]`, *j.ID()).Scan(&status, &numRuns)
+		if n := atomic.LoadInt64(&resumes); numRuns != 1 || n != 1 {
+			return errors.Errorf("expected a single run, found %d (%d resumes)", numRuns, n)
+		}
+		loaded, err := registry.LoadJob(ctx, *j.ID())
+		if err != nil {
+			return err
+		}
+		if numRetries := loaded.Payload().NumRetries; numRetries != 1 {
+			return errors.Errorf("expected a single retry, found %d", numRetries)
+		}
+		require.Equal(t, string(jobs.StatusRunning), status)
+		return nil
+	})
+
+	// The job isn't resumed again while backing off.
+	registry.TestingNudgeAdoptionQueue()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(1), atomic.LoadInt64(&resumes))
+
+	// Without a delay it is retried until it runs out of retries.
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.registry.retry.initial_delay = '0s'`)
+	sqlDB.CheckQueryResultsRetry(t,
+		fmt.Sprintf(`SELECT status, num_runs, error LIKE '%%giving up after 3 retries%%' FROM [SHOW JOB %d]`, *j.ID()),
+		[][]string{{string(jobs.StatusFailed), "4", "true"}},
+	)
+	require.Equal(t, int64(4), atomic.LoadInt64(&resumes))
+}
+
+// TestJobRetryResetOnProgress tests that the retries of a job are reset when
+// it checkpoints its progress, so that jobs which keep making progress do not
+// run out of retries.
+func TestJobRetryResetOnProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer jobs.TestingSetAdoptAndCancelIntervals(10*time.Millisecond, 10*time.Millisecond)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	defer jobs.ResetConstructors()()
+
+	const numFailures = 3
+	var resumes int64
+	jobs.RegisterConstructor(jobspb.TypeImport, func(j *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return jobs.FakeResumer{
+			OnResume: func(ctx context.Context, _ chan<- tree.Datums) error {
+				n := atomic.AddInt64(&resumes, 1)
+				if n > numFailures {
+					return nil
+				}
+				if err := j.FractionProgressed(ctx, jobs.FractionUpdater(float32(n)/10)); err != nil {
+					return err
+				}
+				return jobs.NewRetryJobError("injected retry error")
+			},
+		}
+	})
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	registry := s.JobRegistry().(*jobs.Registry)
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.registry.retry.initial_delay = '0s'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.registry.retry.max_retries = 1`)
+
+	rec := jobs.Record{
+		DescriptorIDs: []descpb.ID{1},
+		Details:       jobspb.ImportDetails{},
+		Progress:      jobspb.ImportProgress{},
+	}
+	j, err := registry.CreateAdoptableJobWithTxn(ctx, rec, nil /* txn */)
+	require.NoError(t, err)
+
+	// Every run makes progress before failing, so the job never exceeds its
+	// single retry.
+	sqlDB.CheckQueryResultsRetry(t,
+		fmt.Sprintf(`SELECT status, num_runs FROM [SHOW JOB %d]`, *j.ID()),
+		[][]string{{string(jobs.StatusSucceeded), fmt.Sprint(numFailures + 1)}},
+	)
+	loaded, err := registry.LoadJob(ctx, *j.ID())
+	require.NoError(t, err)
+	require.NotZero(t, loaded.Payload().LastRetryMicros)
+}
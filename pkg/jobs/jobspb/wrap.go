@@ -28,6 +28,8 @@ var _ Details = SchemaChangeDetails{}
 var _ Details = ChangefeedDetails{}
 var _ Details = CreateStatsDetails{}
 var _ Details = SchemaChangeGCDetails{}
+var _ Details = BatchedDMLDetails{}
+var _ Details = CompactionDetails{}
 
 // ProgressDetails is a marker interface for job progress details proto structs.
 type ProgressDetails interface{}
@@ -38,6 +40,8 @@ var _ ProgressDetails = SchemaChangeProgress{}
 var _ ProgressDetails = ChangefeedProgress{}
 var _ ProgressDetails = CreateStatsProgress{}
 var _ ProgressDetails = SchemaChangeGCProgress{}
+var _ ProgressDetails = BatchedDMLProgress{}
+var _ ProgressDetails = CompactionProgress{}
 
 // Type returns the payload's job type.
 func (p *Payload) Type() Type {
@@ -67,6 +71,10 @@ func DetailsType(d isPayload_Details) Type {
 		return TypeSchemaChangeGC
 	case *Payload_TypeSchemaChange:
 		return TypeTypeSchemaChange
+	case *Payload_BatchedDML:
+		return TypeBatchedDML
+	case *Payload_Compaction:
+		return TypeCompaction
 	default:
 		panic(errors.AssertionFailedf("Payload.Type called on a payload with an unknown details type: %T", d))
 	}
@@ -97,6 +105,10 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_SchemaChangeGC{SchemaChangeGC: &d}
 	case TypeSchemaChangeProgress:
 		return &Progress_TypeSchemaChange{TypeSchemaChange: &d}
+	case BatchedDMLProgress:
+		return &Progress_BatchedDML{BatchedDML: &d}
+	case CompactionProgress:
+		return &Progress_Compaction{Compaction: &d}
 	default:
 		panic(errors.AssertionFailedf("WrapProgressDetails: unknown details type %T", d))
 	}
@@ -122,6 +134,10 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.SchemaChangeGC
 	case *Payload_TypeSchemaChange:
 		return *d.TypeSchemaChange
+	case *Payload_BatchedDML:
+		return *d.BatchedDML
+	case *Payload_Compaction:
+		return *d.Compaction
 	default:
 		return nil
 	}
@@ -147,6 +163,10 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.SchemaChangeGC
 	case *Progress_TypeSchemaChange:
 		return *d.TypeSchemaChange
+	case *Progress_BatchedDML:
+		return *d.BatchedDML
+	case *Progress_Compaction:
+		return *d.Compaction
 	default:
 		return nil
 	}
@@ -185,6 +205,10 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_SchemaChangeGC{SchemaChangeGC: &d}
 	case TypeSchemaChangeDetails:
 		return &Payload_TypeSchemaChange{TypeSchemaChange: &d}
+	case BatchedDMLDetails:
+		return &Payload_BatchedDML{BatchedDML: &d}
+	case CompactionDetails:
+		return &Payload_Compaction{Compaction: &d}
 	default:
 		panic(errors.AssertionFailedf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
@@ -220,7 +244,7 @@ const (
 func (Type) SafeValue() {}
 
 // NumJobTypes is the number of jobs types.
-const NumJobTypes = 10
+const NumJobTypes = 12
 
 func init() {
 	if len(Type_name) != NumJobTypes {
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -36,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
+	"github.com/cockroachdb/cockroach/pkg/util/grpcutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
@@ -57,6 +59,30 @@ var (
 		"the amount of time to retain records for completed jobs before",
 		time.Hour*24*14,
 	).WithPublic()
+
+	retryInitialDelaySetting = settings.RegisterDurationSetting(
+		"jobs.registry.retry.initial_delay",
+		"the amount of time to wait before resuming a job whose last run failed with "+
+			"a retryable error; the delay doubles with every further retry",
+		30*time.Second,
+		settings.NonNegativeDuration,
+	).WithPublic()
+
+	retryMaxDelaySetting = settings.RegisterDurationSetting(
+		"jobs.registry.retry.max_delay",
+		"the maximum amount of time to wait before resuming a job whose last run "+
+			"failed with a retryable error",
+		time.Hour,
+		settings.NonNegativeDuration,
+	).WithPublic()
+
+	retryMaxRetriesSetting = settings.RegisterIntSetting(
+		"jobs.registry.retry.max_retries",
+		"the number of consecutive times a job failing with retryable errors without "+
+			"making progress is retried before it is failed; 0 retries it indefinitely",
+		20,
+		settings.NonNegativeInt,
+	).WithPublic()
 )
 
 // adoptedJobs represents a the epoch and cancelation of a job id being run
@@ -724,8 +750,9 @@ UPDATE system.jobs
 			}
 			return maxGCInterval
 		}
-		timer := timeutil.NewTimer()
-		lastGC := timeutil.Now()
+		timeSource := r.timeSource()
+		timer := timeSource.NewTimer()
+		lastGC := timeSource.Now()
 		// We'll jitter the first cleanup run to avoid contention in case multiple
 		// nodes restart at once.
 
@@ -737,16 +764,16 @@ UPDATE system.jobs
 		for {
 			select {
 			case <-settingChanged:
-				timer.Reset(timeutil.Until(lastGC.Add(gcInterval())))
+				timer.Reset(lastGC.Add(gcInterval()).Sub(timeSource.Now()))
 			case <-stopper.ShouldQuiesce():
 				return
-			case <-timer.C:
-				timer.Read = true
-				old := timeutil.Now().Add(-1 * gcSetting.Get(&r.settings.SV))
+			case <-timer.Ch():
+				timer.MarkRead()
+				old := timeSource.Now().Add(-1 * gcSetting.Get(&r.settings.SV))
 				if err := r.cleanupOldJobs(ctx, old); err != nil {
 					log.Warningf(ctx, "error cleaning up old job records: %v", err)
 				}
-				lastGC = timeutil.Now()
+				lastGC = timeSource.Now()
 				timer.Reset(gcInterval())
 			}
 		}
@@ -878,6 +905,14 @@ func (r *Registry) isOrphaned(ctx context.Context, payload *jobspb.Payload) (boo
 
 const cleanupPageSize = 100
 
+// timeSource returns the TimeSource used to garbage collect old job records.
+func (r *Registry) timeSource() timeutil.TimeSource {
+	if r.knobs.TimeSource != nil {
+		return r.knobs.TimeSource
+	}
+	return timeutil.DefaultTimeSource{}
+}
+
 func (r *Registry) cleanupOldJobs(ctx context.Context, olderThan time.Time) error {
 	var maxID int64
 	for {
@@ -942,9 +977,13 @@ func (r *Registry) cleanupOldJobsPage(
 		log.Infof(ctx, "cleaning up expired job records: %d", len(toDelete.Array))
 		const stmt = `DELETE FROM system.jobs WHERE id = ANY($1)`
 		var nDeleted int
-		if nDeleted, err = r.ex.Exec(
-			ctx, "gc-jobs", nil /* txn */, stmt, toDelete,
-		); err != nil {
+		if err := r.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+			var err error
+			if nDeleted, err = r.ex.Exec(ctx, "gc-jobs", txn, stmt, toDelete); err != nil {
+				return err
+			}
+			return DeleteJobInfo(ctx, r.ex, r.settings, txn, toDelete)
+		}); err != nil {
 			return false, 0, errors.Wrap(err, "deleting old jobs")
 		}
 		if nDeleted != len(toDelete.Array) {
@@ -1112,6 +1151,36 @@ func (r retryJobError) Error() string {
 	return string(r)
 }
 
+// isRetryableJobError returns whether err, returned by a Resumer, indicates
+// that the job should be resumed again later instead of failed. Besides errors
+// created with NewRetryJobError, this is the case for errors caused by losing
+// the connection to another node, as happens when nodes running parts of the
+// job restart.
+func isRetryableJobError(err error) bool {
+	return errors.Is(err, retryJobErrorSentinel) ||
+		grpcutil.IsClosedConnection(err) ||
+		errors.HasType(err, (*roachpb.NodeUnavailableError)(nil))
+}
+
+// retryDelay returns how long to wait after the last run of a job ended with a
+// retryable error before resuming it, given the number of consecutive runs
+// which ended with retryable errors. The delay grows exponentially from
+// jobs.registry.retry.initial_delay up to jobs.registry.retry.max_delay.
+func (r *Registry) retryDelay(numRetries int64) time.Duration {
+	if numRetries <= 0 {
+		return 0
+	}
+	delay := retryInitialDelaySetting.Get(&r.settings.SV)
+	maxDelay := retryMaxDelaySetting.Get(&r.settings.SV)
+	for i := int64(1); i < numRetries && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
 // stepThroughStateMachine implements the state machine of the job lifecycle.
 // The job is executed with the ctx, so ctx must only be canceled if the job
 // should also be canceled. resultsCh is passed to the resumable func and should
@@ -1161,13 +1230,26 @@ func (r *Registry) stepThroughStateMachine(
 			jm.ResumeRetryError.Inc(1)
 			return errors.Errorf("job %d: node liveness error: restarting in background", *job.ID())
 		}
-		// TODO(spaskob): enforce a limit on retries.
-		// TODO(spaskob,lucy): Add metrics on job retries. Consider having a backoff
-		// mechanism (possibly combined with a retry limit).
-		if errors.Is(err, retryJobErrorSentinel) {
+		if isRetryableJobError(err) && jobType == jobspb.TypeChangefeed {
+			// Changefeeds run indefinitely and are restarted whenever one of
+			// the nodes they run on drains, so they are retried right away
+			// and never run out of retries.
 			jm.ResumeRetryError.Inc(1)
 			return errors.Errorf("job %d: %s: restarting in background", *job.ID(), err)
 		}
+		if isRetryableJobError(err) {
+			numRetries, rErr := job.retried(ctx)
+			if rErr != nil {
+				return errors.Wrapf(rErr, "job %d: could not record retry after %s", *job.ID(), err)
+			}
+			maxRetries := retryMaxRetriesSetting.Get(&r.settings.SV)
+			if maxRetries == 0 || numRetries <= maxRetries {
+				jm.ResumeRetryError.Inc(1)
+				return errors.Errorf("job %d: %s: restarting in background after %s",
+					*job.ID(), err, r.retryDelay(numRetries))
+			}
+			err = errors.Wrapf(err, "job %d: giving up after %d retries", *job.ID(), maxRetries)
+		}
 		jm.ResumeFailed.Inc(1)
 		if sErr := (*InvalidStatusError)(nil); errors.As(err, &sErr) {
 			if sErr.status != StatusCancelRequested && sErr.status != StatusPauseRequested {
@@ -1234,9 +1316,16 @@ func (r *Registry) stepThroughStateMachine(
 			// mark the job as failed because it can be resumed by another node.
 			return errors.Errorf("job %d: node liveness error: restarting in background", *job.ID())
 		}
-		if errors.Is(err, retryJobErrorSentinel) {
+		if isRetryableJobError(err) {
+			// Unlike resumption, reverting is retried indefinitely since giving
+			// up could require manual cleanup.
+			numRetries, rErr := job.retried(ctx)
+			if rErr != nil {
+				return errors.Wrapf(rErr, "job %d: could not record retry after %s", *job.ID(), err)
+			}
 			jm.FailOrCancelRetryError.Inc(1)
-			return errors.Errorf("job %d: %s: restarting in background", *job.ID(), err)
+			return errors.Errorf("job %d: %s: restarting in background after %s",
+				*job.ID(), err, r.retryDelay(numRetries))
 		}
 		jm.FailOrCancelFailed.Inc(1)
 		if sErr := (*InvalidStatusError)(nil); errors.As(err, &sErr) {
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness/slinstance"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness/slstorage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
@@ -39,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -401,3 +403,46 @@ func TestRegistryGCPagination(t *testing.T) {
 	db.QueryRow(t, `SELECT count(1) FROM system.jobs`).Scan(&count)
 	require.Zero(t, count)
 }
+
+// TestRegistryGCTimeSource verifies that the garbage collection of old job
+// records is scheduled with the TimeSource testing knob.
+func TestRegistryGCTimeSource(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	timeSource := timeutil.NewManualTime(timeutil.Now())
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			JobsTestingKnobs: &TestingKnobs{TimeSource: timeSource},
+		},
+	})
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	defer s.Stopper().Stop(ctx)
+
+	payload, err := protoutil.Marshal(&jobspb.Payload{})
+	require.NoError(t, err)
+	db.Exec(t,
+		`INSERT INTO system.jobs (status, created, payload) VALUES ($1, $2, $3)`,
+		StatusSucceeded, timeutil.Now().Add(-time.Hour), payload)
+
+	// Wait for the registry to schedule the garbage collection.
+	testutils.SucceedsSoon(t, func() error {
+		if len(timeSource.Timers()) == 0 {
+			return errors.New("garbage collection of old jobs is not scheduled")
+		}
+		return nil
+	})
+
+	// Jobs are garbage collected after the retention time, which defaults to
+	// two weeks.
+	timeSource.Advance(15 * 24 * time.Hour)
+	testutils.SucceedsSoon(t, func() error {
+		var count int
+		db.QueryRow(t, `SELECT count(1) FROM system.jobs WHERE status = $1`, StatusSucceeded).Scan(&count)
+		if count != 0 {
+			return errors.Errorf("%d succeeded jobs were not garbage collected", count)
+		}
+		return nil
+	})
+}
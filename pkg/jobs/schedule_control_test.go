@@ -117,6 +117,29 @@ func TestScheduleControl(t *testing.T) {
 		th.sqlDB.Exec(t, "DROP SCHEDULES "+querySchedules)
 		require.Equal(t, 0, len(th.sqlDB.QueryStr(t, querySchedules)))
 	})
+
+	t.Run("alter-schedule", func(t *testing.T) {
+		scheduleID := makeSchedule("alter-schedule", "@daily")
+		th.sqlDB.Exec(t, "ALTER SCHEDULE $1 SET RECURRING '@hourly'", scheduleID)
+		schedule := th.loadSchedule(t, scheduleID)
+		require.Equal(t, "@hourly", schedule.ScheduleExpr())
+		require.False(t, schedule.IsPaused())
+		require.True(t, schedule.NextRun().Sub(th.env.Now()) <= time.Hour)
+
+		th.sqlDB.ExpectErr(t, "parsing schedule expression",
+			"ALTER SCHEDULE $1 SET RECURRING 'bogus'", scheduleID)
+		th.sqlDB.ExpectErr(t, "schedule 123 does not exist",
+			"ALTER SCHEDULE 123 SET RECURRING '@hourly'")
+	})
+
+	t.Run("alter-paused-schedule", func(t *testing.T) {
+		scheduleID := makeSchedule("alter-paused-schedule", "@daily")
+		th.sqlDB.Exec(t, "PAUSE SCHEDULE $1", scheduleID)
+		th.sqlDB.Exec(t, "ALTER SCHEDULE $1 SET RECURRING '@hourly'", scheduleID)
+		schedule := th.loadSchedule(t, scheduleID)
+		require.Equal(t, "@hourly", schedule.ScheduleExpr())
+		require.True(t, schedule.IsPaused())
+	})
 }
 
 func TestJobsControlForSchedules(t *testing.T) {
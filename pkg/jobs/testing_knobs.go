@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
 // TestingKnobs are base.ModuleTestingKnobs for testing jobs related infra.
@@ -51,6 +52,13 @@ type TestingKnobs struct {
 	// has run. If an error is returned, it will be propagated and the update will
 	// not be committed.
 	BeforeUpdate func(orig, updated JobMetadata) error
+
+	// TimeSource, if set, replaces the wall clock consulted by the registry to
+	// schedule the garbage collection of old job records, and to determine
+	// which records are older than the jobs.retention_time cluster setting.
+	// Tests can use a timeutil.ManualTime to trigger the garbage collection
+	// deterministically.
+	TimeSource timeutil.TimeSource
 }
 
 // ModuleTestingKnobs is part of the base.ModuleTestingKnobs interface.
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -106,9 +107,10 @@ func (j *Job) Update(ctx context.Context, updateFn UpdateFn) error {
 	var payload *jobspb.Payload
 	var progress *jobspb.Progress
 	if err := j.runInTxn(ctx, func(ctx context.Context, txn *kv.Txn) error {
-		stmt := "SELECT status, payload, progress FROM system.jobs WHERE id = $1 FOR UPDATE"
+		progressColumn := ProgressColumn(ctx, j.registry.settings)
+		stmt := "SELECT status, payload, " + progressColumn + " FROM system.jobs WHERE id = $1 FOR UPDATE"
 		if j.sessionID != "" {
-			stmt = "SELECT status, payload, progress, claim_session_id FROM system." +
+			stmt = "SELECT status, payload, " + progressColumn + ", claim_session_id FROM system." +
 				"jobs WHERE id = $1 FOR UPDATE"
 		}
 		var err error
@@ -190,6 +192,11 @@ func (j *Job) Update(ctx context.Context, updateFn UpdateFn) error {
 
 		if ju.md.Status != "" {
 			addSetter("status", ju.md.Status)
+			if err := recordStatusTransition(
+				ctx, j.registry.ex, j.registry.settings, txn, *j.id, status, ju.md.Status,
+			); err != nil {
+				return err
+			}
 		}
 
 		if ju.md.Payload != nil {
@@ -208,9 +215,20 @@ func (j *Job) Update(ctx context.Context, updateFn UpdateFn) error {
 			if err != nil {
 				return err
 			}
-			addSetter("progress", progressBytes)
+			if j.registry.settings.Version.IsActive(ctx, clusterversion.JobInfoTable) {
+				// Progress is updated frequently, write it to its own row rather than
+				// rewriting the row of the job.
+				if err := writeJobInfo(ctx, j.registry.ex, txn, *j.id, progressInfoKey, progressBytes); err != nil {
+					return err
+				}
+			} else {
+				addSetter("progress", progressBytes)
+			}
 		}
 
+		if len(setters) == 0 {
+			return nil
+		}
 		updateStmt := fmt.Sprintf(
 			"UPDATE system.jobs SET %s WHERE id = $1",
 			strings.Join(setters, ", "),
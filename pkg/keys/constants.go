@@ -397,6 +397,8 @@ const (
 	ScheduledJobsTableID                = 37
 	TenantsRangesID                     = 38 // pseudo
 	SqllivenessID                       = 39
+	DatabaseRoleSettingsTableID         = 40
+	JobInfoTableID                      = 41
 
 	// CommentType is type for system.comments
 	DatabaseCommentType = 0
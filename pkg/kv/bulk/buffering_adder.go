@@ -11,10 +11,12 @@
 package bulk
 
 import (
+	"bytes"
 	"context"
 	"sort"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -61,10 +63,35 @@ type BufferingAdder struct {
 	memAcc  mon.BoundAccount
 
 	onFlush func()
+
+	// existing, if set, is used to check the buffered keys against existing
+	// data before each flush. See kvserverbase.BulkAdderOptions.CheckExistingKeys.
+	existing existingDataReader
+	// skipExistingDuplicates drops buffered KVs identical to existing data
+	// instead of reporting them as conflicts.
+	skipExistingDuplicates bool
 }
 
 var _ kvserverbase.BulkAdder = &BufferingAdder{}
 
+// existingDataReader is implemented by SSTSenders, like *kv.DB, which can
+// read the data already present in the keyspace being ingested into.
+type existingDataReader interface {
+	Txn(ctx context.Context, retryable func(context.Context, *kv.Txn) error) error
+}
+
+// ErrKeyConflict marks the error returned by a BulkAdder configured to check
+// existing keys when buffered keys collide with existing data.
+var ErrKeyConflict = errors.New("ingested keys collide with existing data")
+
+// maxReportedConflicts is the number of conflicting keys included in the error
+// returned when buffered keys collide with existing data.
+const maxReportedConflicts = 10
+
+// existingKeysBatchSize is the number of buffered keys looked up per batch
+// when checking them against existing data.
+const existingKeysBatchSize = 1000
+
 // MakeBulkAdder makes a kvserverbase.BulkAdder that buffers and sorts K/Vs
 // passed to add into SSTs that are then ingested. rangeCache if set is
 // consulted to avoid generating an SST that will span a range boundary and thus
@@ -116,6 +143,15 @@ func MakeBulkAdder(
 		bulkMon:             bulkMon,
 	}
 
+	if opts.CheckExistingKeys {
+		existing, ok := db.(existingDataReader)
+		if !ok {
+			return nil, errors.AssertionFailedf("%T cannot read existing data to check for conflicts", db)
+		}
+		b.existing = existing
+		b.skipExistingDuplicates = opts.SkipExistingDuplicates
+	}
+
 	// If no monitor is attached to the instance of a bulk adder, we do not
 	// control its memory usage.
 	if bulkMon == nil {
@@ -224,10 +260,21 @@ func (b *BufferingAdder) Flush(ctx context.Context) error {
 	sort.Sort(&b.curBuf)
 	mvccKey := storage.MVCCKey{Timestamp: b.timestamp}
 
+	var skip []bool
+	if b.existing != nil {
+		var err error
+		if skip, err = b.checkExistingKeys(ctx); err != nil {
+			return err
+		}
+	}
+
 	beforeFlush := timeutil.Now()
 	b.flushCounts.totalSort += beforeFlush.Sub(beforeSort)
 
 	for i := range b.curBuf.entries {
+		if skip != nil && skip[i] {
+			continue
+		}
 		mvccKey.Key = b.curBuf.Key(i)
 		if err := b.sink.AddMVCCKey(ctx, mvccKey, b.curBuf.Value(i)); err != nil {
 			return err
@@ -271,6 +318,74 @@ func (b *BufferingAdder) Flush(ctx context.Context) error {
 	return nil
 }
 
+// checkExistingKeys looks up the buffered keys in the data already present.
+// It returns an error wrapping ErrKeyConflict if any buffered key already
+// exists, unless skipExistingDuplicates is set and the existing value is
+// identical, in which case the returned slice marks the entry as one to skip.
+// The returned slice is nil if no entries are to be skipped.
+//
+// Existing data is read as of just before the ingestion timestamp, so that the
+// KVs ingested by a previous attempt at the same timestamp, e.g. by an IMPORT
+// before it was paused and resumed, are not mistaken for conflicts. Only the
+// buffered keys are looked up, so that the work done by each flush is
+// proportional to the size of the buffer rather than to the amount of existing
+// data in the span it covers.
+func (b *BufferingAdder) checkExistingKeys(ctx context.Context) ([]bool, error) {
+	readTS := b.timestamp.Prev()
+	n := b.curBuf.Len()
+
+	var skip []bool
+	var conflicts []roachpb.Key
+	numConflicts := 0
+	for start := 0; start < n; start += existingKeysBatchSize {
+		end := start + existingKeysBatchSize
+		if end > n {
+			end = n
+		}
+		var batch *kv.Batch
+		if err := b.existing.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+			txn.SetFixedTimestamp(ctx, readTS)
+			batch = txn.NewBatch()
+			for i := start; i < end; i++ {
+				batch.Get(b.curBuf.Key(i))
+			}
+			return txn.Run(ctx, batch)
+		}); err != nil {
+			return nil, errors.Wrap(err, "reading existing data")
+		}
+		for j, res := range batch.Results {
+			i := start + j
+			existing := res.Rows[0]
+			if !existing.Exists() {
+				continue
+			}
+			if b.skipExistingDuplicates && bytes.Equal(
+				existing.Value.TagAndDataBytes(), roachpb.Value{RawBytes: b.curBuf.Value(i)}.TagAndDataBytes(),
+			) {
+				if skip == nil {
+					skip = make([]bool, n)
+				}
+				skip[i] = true
+				continue
+			}
+			numConflicts++
+			if len(conflicts) < maxReportedConflicts {
+				conflicts = append(conflicts, existing.Key)
+			}
+		}
+	}
+	if numConflicts > 0 {
+		return nil, errors.Mark(errors.Newf(
+			"ingested keys collide with existing data: %d conflicting keys, including %s",
+			numConflicts, conflicts,
+		), ErrKeyConflict)
+	}
+	if skip != nil {
+		log.VEventf(ctx, 2, "bulk adder %s skipping KVs identical to existing data", b.name)
+	}
+	return skip, nil
+}
+
 // GetSummary returns this batcher's total added rows/bytes/etc.
 func (b *BufferingAdder) GetSummary() roachpb.BulkOpSummary {
 	return b.sink.GetSummary()
@@ -0,0 +1,93 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package bulk_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/bulk"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBufferingAdderCheckExistingKeys checks that keys which existed before
+// the ingestion timestamp are reported as conflicts, while keys ingested at
+// that timestamp, e.g. by a job before it was resumed, are not.
+func TestBufferingAdderCheckExistingKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	prefix := keys.SystemSQLCodec.IndexPrefix(100, 1)
+	key := func(i int) roachpb.Key {
+		return encoding.EncodeStringAscending(append([]byte{}, prefix...), fmt.Sprintf("k%d", i))
+	}
+	value := func(k roachpb.Key, v string) []byte {
+		val := roachpb.MakeValueFromString(v)
+		val.InitChecksum(k)
+		return val.RawBytes
+	}
+	ingest := func(ts hlc.Timestamp, skipDuplicates bool, vals map[int]string) error {
+		b, err := bulk.MakeBulkAdder(
+			ctx, kvDB, nil /* rangeCache */, s.ClusterSettings(), ts, kvserverbase.BulkAdderOptions{
+				CheckExistingKeys:      true,
+				SkipExistingDuplicates: skipDuplicates,
+			}, nil, /* bulkMon */
+		)
+		require.NoError(t, err)
+		defer b.Close(ctx)
+		for i, v := range vals {
+			if err := b.Add(ctx, key(i), value(key(i), v)); err != nil {
+				return err
+			}
+		}
+		return b.Flush(ctx)
+	}
+
+	require.NoError(t, kvDB.Put(ctx, key(0), "existing"))
+	importTS := s.Clock().Now()
+
+	// The first attempt ingests new keys only.
+	require.NoError(t, ingest(importTS, false /* skipDuplicates */, map[int]string{1: "a", 2: "b", 3: "c"}))
+
+	// A resumed attempt ingests some of the same keys again, at the same
+	// timestamp, which are not conflicts.
+	require.NoError(t, ingest(importTS, false /* skipDuplicates */, map[int]string{2: "b", 3: "c", 4: "d"}))
+
+	// A key that existed before the ingestion timestamp is a conflict.
+	err := ingest(importTS, false /* skipDuplicates */, map[int]string{0: "a", 5: "e"})
+	require.True(t, errors.Is(err, bulk.ErrKeyConflict), "%+v", err)
+	require.Contains(t, err.Error(), "1 conflicting keys")
+
+	// Unless it is identical and duplicates are skipped.
+	require.NoError(t, ingest(importTS, true /* skipDuplicates */, map[int]string{0: "existing", 5: "e"}))
+
+	// A later ingestion sees the keys ingested at importTS as existing data.
+	err = ingest(s.Clock().Now(), false /* skipDuplicates */, map[int]string{1: "a", 3: "c", 6: "f"})
+	require.True(t, errors.Is(err, bulk.ErrKeyConflict), "%+v", err)
+	require.Contains(t, err.Error(), "2 conflicting keys")
+
+	got, err := kvDB.Get(ctx, key(6))
+	require.NoError(t, err)
+	require.False(t, got.Exists())
+}
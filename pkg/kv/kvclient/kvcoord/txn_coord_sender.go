@@ -974,6 +974,13 @@ func (tc *TxnCoordSender) Epoch() enginepb.TxnEpoch {
 	return tc.mu.txn.Epoch
 }
 
+// TrackedWriteBytes is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) TrackedWriteBytes() int64 {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.interceptorAlloc.txnPipeliner.trackedBytes()
+}
+
 // IsTracking returns true if the heartbeat loop is running.
 func (tc *TxnCoordSender) IsTracking() bool {
 	tc.mu.Lock()
@@ -690,6 +690,12 @@ func (tp *txnPipeliner) hasAcquiredLocks() bool {
 	return tp.ifWrites.len() > 0 || !tp.lockFootprint.empty()
 }
 
+// trackedBytes returns the size in bytes of the keys in the in-flight write
+// set and the lock footprint.
+func (tp *txnPipeliner) trackedBytes() int64 {
+	return tp.ifWrites.byteSize() + tp.lockFootprint.bytes
+}
+
 // inFlightWrites represent a commitment to proving (via QueryIntent) that
 // a point write succeeded in replicating an intent with a specific sequence
 // number.
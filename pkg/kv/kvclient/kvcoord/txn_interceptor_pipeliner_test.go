@@ -761,10 +761,12 @@ func TestTxnPipelinerEpochIncrement(t *testing.T) {
 	tp.ifWrites.insert(roachpb.Key("d"), 11)
 	require.Equal(t, 2, tp.ifWrites.len())
 	require.Equal(t, 0, len(tp.lockFootprint.asSlice()))
+	require.Equal(t, int64(2), tp.trackedBytes())
 
 	tp.epochBumpedLocked()
 	require.Equal(t, 0, tp.ifWrites.len())
 	require.Equal(t, 2, len(tp.lockFootprint.asSlice()))
+	require.Equal(t, int64(2), tp.trackedBytes())
 }
 
 // TestTxnPipelinerIntentMissingError tests that a txnPipeliner transforms an
@@ -764,6 +764,7 @@ func (a *Allocator) scorerOptions() scorerOptions {
 	return scorerOptions{
 		deterministic:           a.storePool.deterministic,
 		rangeRebalanceThreshold: rangeRebalanceThreshold.Get(&a.storePool.st.SV),
+		rebalanceFromNearlyFull: rebalanceFromNearlyFullStores.Get(&a.storePool.st.SV),
 	}
 }
 
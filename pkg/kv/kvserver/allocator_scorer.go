@@ -88,10 +88,26 @@ var rangeRebalanceThreshold = func() *settings.FloatSetting {
 	return s
 }()
 
+// rebalanceFromNearlyFullStores controls whether replicas are moved off stores
+// whose disk utilization exceeds rebalanceToMaxFractionUsedThreshold, i.e.
+// stores that no longer accept rebalanced replicas, rather than only off stores
+// that exceed maxFractionUsedThreshold. Since the capacity of a store is capped
+// by the size configured in its store spec, this moves data away from a store
+// as it approaches its size limit.
+var rebalanceFromNearlyFullStores = settings.RegisterBoolSetting(
+	"kv.allocator.rebalance_from_nearly_full_stores.enabled",
+	"if enabled, replicas are moved off stores whose disk utilization is too high "+
+		"for them to receive rebalanced replicas, before they fill up completely",
+	true,
+)
+
 type scorerOptions struct {
 	deterministic           bool
 	rangeRebalanceThreshold float64
 	qpsRebalanceThreshold   float64 // only considered if non-zero
+	// rebalanceFromNearlyFull treats the existing replicas on stores that fail
+	// rebalanceToMaxCapacityCheck as being on full disks when rebalancing.
+	rebalanceFromNearlyFull bool
 }
 
 type balanceDimensions struct {
@@ -547,7 +563,8 @@ func rebalanceCandidates(
 				continue
 			}
 			valid, necessary := removeConstraintsCheck(store, constraints)
-			fullDisk := !maxCapacityCheck(store)
+			fullDisk := !maxCapacityCheck(store) ||
+				(options.rebalanceFromNearlyFull && !rebalanceToMaxCapacityCheck(store))
 			if !valid {
 				if !needRebalanceFrom {
 					log.VEventf(ctx, 2, "s%d: should-rebalance(invalid): locality:%q",
@@ -1575,3 +1575,67 @@ func TestMaxCapacity(t *testing.T) {
 		}
 	}
 }
+
+func TestRebalanceCandidatesFromNearlyFullStore(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Store 1 is 93% full: too full to receive rebalanced replicas, but not
+	// full enough to fail maxCapacityCheck. The range counts are balanced.
+	var stores []roachpb.StoreDescriptor
+	for i := 1; i <= 4; i++ {
+		available := int64(50)
+		if i == 1 {
+			available = 7
+		}
+		stores = append(stores, roachpb.StoreDescriptor{
+			StoreID: roachpb.StoreID(i),
+			Node:    roachpb.NodeDescriptor{NodeID: roachpb.NodeID(i)},
+			Capacity: roachpb.StoreCapacity{
+				Capacity:   100,
+				Available:  available,
+				RangeCount: 10,
+			},
+		})
+	}
+	sl := makeStoreList(stores)
+	replicas := []roachpb.ReplicaDescriptor{
+		{NodeID: 1, StoreID: 1},
+		{NodeID: 2, StoreID: 2},
+		{NodeID: 3, StoreID: 3},
+	}
+
+	for _, rebalanceFromNearlyFull := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%t", rebalanceFromNearlyFull), func(t *testing.T) {
+			options := scorerOptions{
+				deterministic:           true,
+				rangeRebalanceThreshold: 0.05,
+				rebalanceFromNearlyFull: rebalanceFromNearlyFull,
+			}
+			results := rebalanceCandidates(
+				context.Background(),
+				sl,
+				constraint.AnalyzedConstraints{},
+				replicas,
+				map[roachpb.StoreID]roachpb.Locality{},
+				func(context.Context, roachpb.NodeID) bool { return true }, /* isNodeValidForRoutineReplicaTransfer */
+				options)
+			if !rebalanceFromNearlyFull {
+				if len(results) != 0 {
+					t.Fatalf("expected no rebalance options, got %v", results)
+				}
+				return
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected one set of rebalance options, got %v", results)
+			}
+			existing := results[0].existingCandidates
+			if worst := existing[len(existing)-1]; worst.store.StoreID != 1 || !worst.fullDisk {
+				t.Errorf("expected s1 to be the worst existing candidate, got %v", existing)
+			}
+			if !expectedStoreIDsMatch([]roachpb.StoreID{4}, results[0].candidates) {
+				t.Errorf("expected s4 as the only candidate, got %v", results[0].candidates)
+			}
+		})
+	}
+}
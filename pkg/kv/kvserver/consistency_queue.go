@@ -54,6 +54,10 @@ const consistencyCheckRateBurstFactor = 8
 // churn on timers.
 const consistencyCheckRateMinWait = 100 * time.Millisecond
 
+// consistencyQueueName is the name of the consistency checker queue, under
+// which it persists the last time it processed a range.
+const consistencyQueueName = "consistencyChecker"
+
 var testingAggressiveConsistencyChecks = envutil.EnvOrDefaultBool("COCKROACH_CONSISTENCY_AGGRESSIVE", false)
 
 type consistencyQueue struct {
@@ -80,7 +84,7 @@ func newConsistencyQueue(store *Store, gossip *gossip.Gossip) *consistencyQueue
 		replicaCountFn: store.ReplicaCount,
 	}
 	q.baseQueue = newBaseQueue(
-		"consistencyChecker", q, store, gossip,
+		consistencyQueueName, q, store, gossip,
 		queueConfig{
 			maxSize:              defaultQueueMaxSize,
 			needsLease:           true,
@@ -58,6 +58,20 @@ type BulkAdderOptions struct {
 	// DisallowShadowing controls whether shadowing of existing keys is permitted
 	// when the SSTables produced by this adder are ingested.
 	DisallowShadowing bool
+
+	// CheckExistingKeys makes the adder look up the keys of each flushed buffer
+	// in the data present before the adder's timestamp, before ingesting them.
+	// Buffered keys which already exist are conflicts, and the flush fails with
+	// an error listing a sample of them, instead of the AddSSTable request
+	// failing on the first collision. Keys ingested at the adder's timestamp,
+	// e.g. by a previous attempt of a resumed job, are not conflicts.
+	CheckExistingKeys bool
+
+	// SkipExistingDuplicates, when used with CheckExistingKeys, drops buffered
+	// KVs whose value is identical to the existing value of their key instead of
+	// reporting them as conflicts. Keys that exist with a different value are
+	// still conflicts.
+	SkipExistingDuplicates bool
 }
 
 // DisableExplicitSplits can be returned by a SplitAndScatterAfter function to
@@ -253,6 +253,18 @@ var (
 		Measurement: "Storage",
 		Unit:        metric.Unit_BYTES,
 	}
+	metaBallast = metric.Metadata{
+		Name:        "capacity.ballast",
+		Help:        "Disk space reserved by the emergency ballast file",
+		Measurement: "Storage",
+		Unit:        metric.Unit_BYTES,
+	}
+	metaReadOnly = metric.Metadata{
+		Name:        "capacity.read_only",
+		Help:        "1 if the store rejects writes to user data because its available disk space is low, 0 otherwise",
+		Measurement: "Read-only",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaSysBytes = metric.Metadata{
 		Name:        "sysbytes",
 		Help:        "Number of bytes in system KV pairs",
@@ -1076,6 +1088,8 @@ type StoreMetrics struct {
 	Available          *metric.Gauge
 	Used               *metric.Gauge
 	Reserved           *metric.Gauge
+	BallastBytes       *metric.Gauge
+	ReadOnly           *metric.Gauge
 
 	// Rebalancing metrics.
 	AverageQueriesPerSecond *metric.GaugeFloat64
@@ -1443,10 +1457,12 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		ResolveAbortCount:  metric.NewCounter(metaResolveAbort),
 		ResolvePoisonCount: metric.NewCounter(metaResolvePoison),
 
-		Capacity:  metric.NewGauge(metaCapacity),
-		Available: metric.NewGauge(metaAvailable),
-		Used:      metric.NewGauge(metaUsed),
-		Reserved:  metric.NewGauge(metaReserved),
+		Capacity:     metric.NewGauge(metaCapacity),
+		Available:    metric.NewGauge(metaAvailable),
+		Used:         metric.NewGauge(metaUsed),
+		Reserved:     metric.NewGauge(metaReserved),
+		BallastBytes: metric.NewGauge(metaBallast),
+		ReadOnly:     metric.NewGauge(metaReadOnly),
 
 		// Rebalancing metrics.
 		AverageQueriesPerSecond: metric.NewGaugeFloat64(metaAverageQueriesPerSecond),
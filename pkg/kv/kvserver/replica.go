@@ -455,6 +455,12 @@ type Replica struct {
 		// Computed checksum at a snapshot UUID.
 		checksums map[uuid.UUID]ReplicaChecksum
 
+		// lastConsistencyCheck is the result of the last consistency check
+		// coordinated by this replica, and lastConsistencyCheckTime the time at
+		// which it completed. The time is zero if there was none.
+		lastConsistencyCheck     roachpb.CheckConsistencyResponse_Result
+		lastConsistencyCheckTime hlc.Timestamp
+
 		// proposalQuota is the quota pool maintained by the lease holder where
 		// incoming writes acquire quota from a fixed quota pool before going
 		// through. If there is no quota available, the write is throttled
@@ -1037,6 +1043,9 @@ func (r *Replica) State() kvserverpb.RangeInfo {
 
 	ri.LockTable = r.concMgr.LockTableDebug()
 
+	// NB: this reads from the engine, so keep it out of the critical section.
+	ri.ConsistencyQueueLastProcessed, _ = r.getQueueLastProcessed(context.Background(), consistencyQueueName)
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	ri.ReplicaState = *(protoutil.Clone(&r.mu.state)).(*kvserverpb.ReplicaState)
@@ -1056,6 +1065,11 @@ func (r *Replica) State() kvserverpb.RangeInfo {
 		}
 	}
 	ri.RangeMaxBytes = *r.mu.zone.RangeMaxBytes
+	if !r.mu.lastConsistencyCheckTime.IsEmpty() {
+		ri.LastConsistencyCheckTime = r.mu.lastConsistencyCheckTime
+		ri.LastConsistencyCheckStatus = r.mu.lastConsistencyCheck.Status.String()
+		ri.LastConsistencyCheckDetail = r.mu.lastConsistencyCheck.Detail
+	}
 	if desc := ri.ReplicaState.Desc; desc != nil {
 		// Learner replicas don't serve follower reads, but they still receive
 		// closed timestamp updates, so include them here.
@@ -195,6 +195,11 @@ func (r *Replica) CheckConsistency(
 		// No inconsistency was detected, but we didn't manage to inspect all replicas.
 		res.Status = roachpb.CheckConsistencyResponse_RANGE_INDETERMINATE
 	}
+	r.mu.Lock()
+	r.mu.lastConsistencyCheck = res
+	r.mu.lastConsistencyCheckTime = r.store.Clock().Now()
+	r.mu.Unlock()
+
 	var resp roachpb.CheckConsistencyResponse
 	resp.Result = append(resp.Result, res)
 
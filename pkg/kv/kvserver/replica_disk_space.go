@@ -0,0 +1,104 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// readOnlyMinAvailableFraction is the fraction of the capacity of a store
+// that must remain available for the store to accept writes to user data.
+// Below it, the store enters a read-only mode in which such writes are
+// rejected, which leaves the remaining space to the system ranges and to
+// the deletions that free up space. Set to 0 to disable.
+var readOnlyMinAvailableFraction = settings.RegisterFloatSetting(
+	"kv.store.read_only_min_available_fraction",
+	"fraction of the capacity of a store that must remain available for the "+
+		"store to accept writes to user data, or 0 to disable",
+	0.01,
+	func(v float64) error {
+		if v < 0 || v >= 1 {
+			return errors.Errorf("cannot set to a value outside [0, 1): %f", v)
+		}
+		return nil
+	},
+)
+
+// updateReadOnly puts the store into or out of the read-only mode depending
+// on the available disk space in the given capacity.
+func (s *Store) updateReadOnly(ctx context.Context, capacity roachpb.StoreCapacity) {
+	var readOnly bool
+	if frac := readOnlyMinAvailableFraction.Get(&s.cfg.Settings.SV); frac > 0 &&
+		!s.engine.InMem() && capacity.Capacity > 0 {
+		readOnly = float64(capacity.Available) < frac*float64(capacity.Capacity)
+	}
+	var newVal, oldVal int32
+	if readOnly {
+		newVal = 1
+	}
+	if oldVal = atomic.SwapInt32(&s.readOnly, newVal); oldVal != newVal {
+		if readOnly {
+			log.Ops.Warningf(ctx, "store %s has only %s of %s available on disk; "+
+				"rejecting writes to user data until space is freed", s,
+				humanizeutil.IBytes(capacity.Available), humanizeutil.IBytes(capacity.Capacity))
+		} else {
+			log.Ops.Infof(ctx, "store %s has %s of %s available on disk; accepting writes again", s,
+				humanizeutil.IBytes(capacity.Available), humanizeutil.IBytes(capacity.Capacity))
+		}
+	}
+	s.metrics.ReadOnly.Update(int64(newVal))
+}
+
+// IsReadOnly returns whether the store rejects writes to user data because
+// its available disk space is low.
+func (s *Store) IsReadOnly() bool {
+	return atomic.LoadInt32(&s.readOnly) == 1
+}
+
+// consumesDiskSpace returns whether the provided BatchRequest contains a
+// request that adds data. Deletions, range clears, GC and transaction
+// records are allowed through so that space can be freed.
+func consumesDiskSpace(ba *roachpb.BatchRequest) bool {
+	for _, ru := range ba.Requests {
+		switch ru.GetInner().(type) {
+		case *roachpb.PutRequest, *roachpb.ConditionalPutRequest, *roachpb.InitPutRequest,
+			*roachpb.IncrementRequest, *roachpb.AddSSTableRequest:
+			return true
+		}
+	}
+	return false
+}
+
+// checkDiskSpace returns an error if the batch adds user data to a range on a
+// store that is in read-only mode because its disk is nearly full.
+func (r *Replica) checkDiskSpace(ba *roachpb.BatchRequest) error {
+	if !r.store.IsReadOnly() {
+		return nil
+	}
+	if r.Desc().StartKey.Less(roachpb.RKey(keys.UserTableDataMin)) {
+		return nil
+	}
+	if !consumesDiskSpace(ba) {
+		return nil
+	}
+	return errors.WithHint(
+		errors.Errorf("%s is out of disk space and rejects writes; "+
+			"deletes are allowed", r.store),
+		"Free up disk space or add capacity to the store.")
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumesDiskSpace(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		reqs     []roachpb.Request
+		expected bool
+	}{
+		{[]roachpb.Request{&roachpb.GetRequest{}}, false},
+		{[]roachpb.Request{&roachpb.DeleteRequest{}, &roachpb.DeleteRangeRequest{}}, false},
+		{[]roachpb.Request{&roachpb.ClearRangeRequest{}}, false},
+		{[]roachpb.Request{&roachpb.GCRequest{}}, false},
+		{[]roachpb.Request{&roachpb.EndTxnRequest{}}, false},
+		{[]roachpb.Request{&roachpb.PutRequest{}}, true},
+		{[]roachpb.Request{&roachpb.DeleteRequest{}, &roachpb.ConditionalPutRequest{}}, true},
+		{[]roachpb.Request{&roachpb.InitPutRequest{}}, true},
+		{[]roachpb.Request{&roachpb.IncrementRequest{}}, true},
+		{[]roachpb.Request{&roachpb.AddSSTableRequest{}}, true},
+	}
+	for _, tc := range testCases {
+		var ba roachpb.BatchRequest
+		ba.Add(tc.reqs...)
+		require.Equal(t, tc.expected, consumesDiskSpace(&ba), "%s", ba)
+	}
+}
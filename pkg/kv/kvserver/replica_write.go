@@ -83,6 +83,9 @@ func (r *Replica) executeWriteBatch(
 	if err != nil {
 		return nil, g, roachpb.NewError(err)
 	}
+	if err := r.checkDiskSpace(ba); err != nil {
+		return nil, g, roachpb.NewError(err)
+	}
 
 	minTS, untrack := r.store.cfg.ClosedTimestamp.Tracker.Track(ctx)
 	defer untrack(ctx, 0, 0, 0) // covers all error returns below
@@ -455,6 +455,10 @@ type Store struct {
 	gossipQueriesPerSecondVal syncutil.AtomicFloat64
 	gossipWritesPerSecondVal  syncutil.AtomicFloat64
 
+	// 1 if the store rejects writes to user data because its disk is nearly
+	// full, 0 otherwise. To be accessed using atomic ops.
+	readOnly int32
+
 	coalescedMu struct {
 		syncutil.Mutex
 		heartbeats         map[roachpb.StoreIdent][]RaftHeartbeat
@@ -154,6 +154,9 @@ func (m *MockTransactionalSender) ReleaseSavepoint(context.Context, SavepointTok
 // Epoch is part of the TxnSender interface.
 func (m *MockTransactionalSender) Epoch() enginepb.TxnEpoch { panic("unimplemented") }
 
+// TrackedWriteBytes is part of the TxnSender interface.
+func (m *MockTransactionalSender) TrackedWriteBytes() int64 { return 0 }
+
 // TestingCloneTxn is part of the TxnSender interface.
 func (m *MockTransactionalSender) TestingCloneTxn() *roachpb.Transaction {
 	return m.txn.Clone()
@@ -254,6 +254,15 @@ type TxnSender interface {
 	// Epoch returns the txn's epoch.
 	Epoch() enginepb.TxnEpoch
 
+	// TrackedWriteBytes returns the size in bytes of the keys the txn is
+	// tracking as written or locked, i.e. the in-flight pipelined writes
+	// and the lock spans that will be resolved when the txn finishes.
+	// Only keys are counted, not values. The figure includes locks acquired
+	// by locking reads (e.g. SELECT FOR UPDATE) and writes performed by
+	// earlier epochs, and it can shrink when the lock spans are condensed to
+	// stay under kv.transaction.max_intents_bytes.
+	TrackedWriteBytes() int64
+
 	// PrepareRetryableError generates a
 	// TransactionRetryWithProtoRefreshError with a payload initialized
 	// from this txn.
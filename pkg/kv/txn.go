@@ -310,6 +310,15 @@ func (txn *Txn) ProvisionalCommitTimestamp() hlc.Timestamp {
 	return txn.mu.sender.ProvisionalCommitTimestamp()
 }
 
+// TrackedWriteBytes returns the size in bytes of the keys written or locked by
+// the transaction, as tracked by the TxnSender. See
+// TxnSender.TrackedWriteBytes() for what is and isn't counted.
+func (txn *Txn) TrackedWriteBytes() int64 {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.TrackedWriteBytes()
+}
+
 // SetSystemConfigTrigger sets the system db trigger to true on this transaction.
 // This will impact the EndTxnRequest. Note that this method takes a boolean
 // argument indicating whether this transaction is intended for the system
@@ -118,6 +118,8 @@ func (t *TransactionStatistics) Add(other *TransactionStatistics) {
 // Add combines other into this StatementStatistics.
 func (s *StatementStatistics) Add(other *StatementStatistics) {
 	s.FirstAttemptCount += other.FirstAttemptCount
+	s.TimedOutCount += other.TimedOutCount
+	s.CanceledCount += other.CanceledCount
 	if other.MaxRetries > s.MaxRetries {
 		s.MaxRetries = other.MaxRetries
 	}
@@ -148,6 +150,8 @@ func (s *StatementStatistics) AlmostEqual(other *StatementStatistics, eps float6
 	return s.Count == other.Count &&
 		s.FirstAttemptCount == other.FirstAttemptCount &&
 		s.MaxRetries == other.MaxRetries &&
+		s.TimedOutCount == other.TimedOutCount &&
+		s.CanceledCount == other.CanceledCount &&
 		s.NumRows.AlmostEqual(other.NumRows, eps) &&
 		s.ParseLat.AlmostEqual(other.ParseLat, eps) &&
 		s.PlanLat.AlmostEqual(other.PlanLat, eps) &&
@@ -944,8 +944,8 @@ func (ctx *Context) grpcDialRaw(
 		redialChan: make(chan struct{}),
 	}
 	dialerFunc := dialer.dial
-	if ctx.Knobs.ArtificialLatencyMap != nil {
-		latency := ctx.Knobs.ArtificialLatencyMap[target]
+	if ctx.Knobs.ArtificialLatencyFn != nil {
+		latency := ctx.Knobs.ArtificialLatencyFn(target)
 		log.VEventf(ctx.masterCtx, 1, "connecting to node %s (%d) with simulated latency %dms", target, remoteNodeID,
 			latency)
 		dialer := artificialLatencyDialer{
@@ -39,11 +39,13 @@ type ContextTestingKnobs struct {
 	// for a given target and class.
 	StreamClientInterceptor func(target string, class ConnectionClass) grpc.StreamClientInterceptor
 
-	// ArtificialLatencyMap if non-nil contains a map from target address
-	// (server.RPCServingAddr() of a remote node) to artificial latency in
-	// milliseconds to inject. Setting this will cause the server to pause for
-	// the given amount of milliseconds on every network write.
-	ArtificialLatencyMap map[string]int
+	// ArtificialLatencyFn if non-nil returns the artificial latency in
+	// milliseconds to inject for a given target address
+	// (server.RPCServingAddr() of a remote node). Setting this will cause the
+	// server to pause for the given amount of milliseconds on every network
+	// write. It is called every time a connection is dialed, so it must be
+	// safe for concurrent use.
+	ArtificialLatencyFn func(target string) int
 
 	// ClusterID initializes the Context's ClusterID container to this value if
 	// non-nil at construction time.
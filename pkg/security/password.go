@@ -58,8 +58,11 @@ func appendEmptySha256(password string) []byte {
 
 // CompareHashAndPassword tests that the provided bytes are equivalent to the
 // hash of the supplied password. If they are not equivalent, returns an
-// error.
+// error. The hash can be either a bcrypt hash or a SCRAM-SHA-256 verifier.
 func CompareHashAndPassword(hashedPassword []byte, password string) error {
+	if IsScramHash(hashedPassword) {
+		return compareScramVerifierAndPassword(hashedPassword, password)
+	}
 	return bcrypt.CompareHashAndPassword(hashedPassword, appendEmptySha256(password))
 }
 
@@ -68,6 +71,19 @@ func HashPassword(password string) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(appendEmptySha256(password), BcryptCost)
 }
 
+// HashPasswordWithMethod takes a raw password and returns its hash computed
+// with the given method.
+func HashPasswordWithMethod(password string, method PasswordHashMethod) ([]byte, error) {
+	switch method {
+	case HashBCrypt:
+		return HashPassword(password)
+	case HashSCRAMSHA256:
+		return HashPasswordScram(password)
+	default:
+		return nil, errors.AssertionFailedf("unknown password hash method %d", method)
+	}
+}
+
 // PromptForPassword prompts for a password.
 // This is meant to be used when using a password.
 func PromptForPassword() (string, error) {
@@ -92,6 +108,33 @@ var MinPasswordLength = settings.RegisterIntSetting(
 	settings.NonNegativeInt,
 )
 
+// PasswordHashMethod is a method used to hash passwords.
+type PasswordHashMethod int64
+
+const (
+	// HashBCrypt hashes passwords with bcrypt. The hashes can only be used to
+	// verify cleartext passwords.
+	HashBCrypt PasswordHashMethod = 1
+	// HashSCRAMSHA256 computes SCRAM-SHA-256 verifiers, which can be used both
+	// to verify cleartext passwords and to authenticate clients using the
+	// SCRAM-SHA-256 SASL mechanism.
+	HashSCRAMSHA256 PasswordHashMethod = 2
+)
+
+// PasswordHashMethodSetting is the cluster setting that configures the method
+// used to hash the passwords set in cleartext via SQL.
+var PasswordHashMethodSetting = settings.RegisterEnumSetting(
+	"server.user_login.password_encryption",
+	"which hash method to use to encode cleartext passwords passed via "+
+		"ALTER/CREATE USER/ROLE WITH PASSWORD; scram-sha-256 is required for "+
+		"users authenticating with the scram-sha-256 HBA method",
+	"crdb-bcrypt",
+	map[int64]string{
+		int64(HashBCrypt):      "crdb-bcrypt",
+		int64(HashSCRAMSHA256): "scram-sha-256",
+	},
+).WithPublic()
+
 // GenerateRandomPassword generates a somewhat secure password
 // composed of alphanumeric characters.
 func GenerateRandomPassword() (string, error) {
@@ -0,0 +1,273 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// This file contains the computation of SCRAM-SHA-256 verifiers, as
+// defined in RFC 5802 and RFC 7677. The verifiers are stored in the
+// hashedPassword column of system.users using the same format as
+// PostgreSQL:
+//
+//   SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+//
+// where the salt and keys are encoded in base64. Unlike PostgreSQL, the
+// passwords are not normalized with SASLprep before being hashed, so
+// passwords containing non-ASCII characters may not be accepted by clients
+// which do normalize them.
+
+// ScramIterCount is the number of iterations used to compute SCRAM
+// verifiers. It is exposed for testing.
+var ScramIterCount = 4096
+
+// ScramMechanism is the name of the SASL mechanism implemented by
+// ScramServerConversation.
+const ScramMechanism = "SCRAM-SHA-256"
+
+const (
+	scramPrefix      = ScramMechanism + "$"
+	scramSaltLen     = 16
+	scramClientKey   = "Client Key"
+	scramServerKey   = "Server Key"
+	scramNonceLength = 18
+)
+
+// ScramVerifier is a SCRAM-SHA-256 password verifier. It is sufficient to
+// authenticate a client, but does not allow one to impersonate the user.
+type ScramVerifier struct {
+	Iterations int
+	Salt       []byte
+	StoredKey  []byte
+	ServerKey  []byte
+}
+
+// IsScramHash returns whether the hashed password is a SCRAM-SHA-256
+// verifier.
+func IsScramHash(hashedPassword []byte) bool {
+	return bytes.HasPrefix(hashedPassword, []byte(scramPrefix))
+}
+
+// HashPasswordScram computes a SCRAM-SHA-256 verifier for the password, with a
+// random salt.
+func HashPasswordScram(password string) ([]byte, error) {
+	salt := make([]byte, scramSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	v := makeScramVerifier(password, salt, ScramIterCount)
+	return []byte(v.String()), nil
+}
+
+func makeScramVerifier(password string, salt []byte, iterations int) ScramVerifier {
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+	storedKey := sha256.Sum256(scramHMAC(saltedPassword, scramClientKey))
+	return ScramVerifier{
+		Iterations: iterations,
+		Salt:       salt,
+		StoredKey:  storedKey[:],
+		ServerKey:  scramHMAC(saltedPassword, scramServerKey),
+	}
+}
+
+// String returns the representation of the verifier stored in
+// system.users.
+func (v ScramVerifier) String() string {
+	enc := base64.StdEncoding
+	return scramPrefix + strconv.Itoa(v.Iterations) + ":" + enc.EncodeToString(v.Salt) +
+		"$" + enc.EncodeToString(v.StoredKey) + ":" + enc.EncodeToString(v.ServerKey)
+}
+
+// ParseScramVerifier parses a SCRAM-SHA-256 verifier stored in system.users.
+func ParseScramVerifier(hashedPassword []byte) (ScramVerifier, error) {
+	var v ScramVerifier
+	if !IsScramHash(hashedPassword) {
+		return v, errors.New("password is not a SCRAM-SHA-256 verifier")
+	}
+	parts := strings.Split(string(hashedPassword[len(scramPrefix):]), "$")
+	if len(parts) != 2 {
+		return v, errors.New("malformed SCRAM-SHA-256 verifier")
+	}
+	iterAndSalt := strings.Split(parts[0], ":")
+	keys := strings.Split(parts[1], ":")
+	if len(iterAndSalt) != 2 || len(keys) != 2 {
+		return v, errors.New("malformed SCRAM-SHA-256 verifier")
+	}
+	var err error
+	if v.Iterations, err = strconv.Atoi(iterAndSalt[0]); err != nil || v.Iterations <= 0 {
+		return v, errors.New("malformed SCRAM-SHA-256 verifier: invalid iteration count")
+	}
+	enc := base64.StdEncoding
+	if v.Salt, err = enc.DecodeString(iterAndSalt[1]); err != nil {
+		return v, errors.Wrap(err, "malformed SCRAM-SHA-256 verifier: invalid salt")
+	}
+	if v.StoredKey, err = enc.DecodeString(keys[0]); err != nil || len(v.StoredKey) != sha256.Size {
+		return v, errors.New("malformed SCRAM-SHA-256 verifier: invalid stored key")
+	}
+	if v.ServerKey, err = enc.DecodeString(keys[1]); err != nil || len(v.ServerKey) != sha256.Size {
+		return v, errors.New("malformed SCRAM-SHA-256 verifier: invalid server key")
+	}
+	return v, nil
+}
+
+// compareScramVerifierAndPassword checks that the verifier was computed from
+// the password.
+func compareScramVerifierAndPassword(hashedPassword []byte, password string) error {
+	v, err := ParseScramVerifier(hashedPassword)
+	if err != nil {
+		return err
+	}
+	computed := makeScramVerifier(password, v.Salt, v.Iterations)
+	if !hmac.Equal(computed.StoredKey, v.StoredKey) || !hmac.Equal(computed.ServerKey, v.ServerKey) {
+		return errors.New("password does not match the SCRAM-SHA-256 verifier")
+	}
+	return nil
+}
+
+func scramHMAC(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(msg))
+	return h.Sum(nil)
+}
+
+// ScramServerConversation is the server side of a SCRAM-SHA-256
+// authentication exchange, without channel binding. The exchange consists of
+// the client-first message, answered by ServerFirst, and the client-final
+// message, answered by ServerFinal.
+type ScramServerConversation struct {
+	verifier ScramVerifier
+	// mock is set for conversations started with
+	// NewMockScramServerConversation.
+	mock bool
+
+	clientFirstBare string
+	serverFirst     string
+	nonce           string
+	gs2Header       string
+}
+
+// NewScramServerConversation starts an exchange authenticating a client
+// against the verifier.
+func NewScramServerConversation(verifier ScramVerifier) *ScramServerConversation {
+	return &ScramServerConversation{verifier: verifier}
+}
+
+// NewMockScramServerConversation starts an exchange for a user that cannot
+// be authenticated with SCRAM, because the user has no password or the
+// password is not stored as a SCRAM verifier. The exchange proceeds as it
+// would for a user with a verifier, except that ServerFinal always fails.
+// The salt is derived from the user name and the secret, so that the same
+// salt is presented to the client on each attempt.
+func NewMockScramServerConversation(username string, secret []byte) *ScramServerConversation {
+	return &ScramServerConversation{
+		verifier: ScramVerifier{
+			Iterations: ScramIterCount,
+			Salt:       scramHMAC(secret, username)[:scramSaltLen],
+		},
+		mock: true,
+	}
+}
+
+// ServerFirst processes the client-first message and returns the
+// server-first message.
+func (s *ScramServerConversation) ServerFirst(clientFirst string) (string, error) {
+	// client-first-message = gs2-header client-first-message-bare
+	// gs2-header = gs2-cbind-flag "," [ authzid ] ","
+	parts := strings.SplitN(clientFirst, ",", 3)
+	if len(parts) != 3 {
+		return "", errors.New("malformed SCRAM client-first message")
+	}
+	switch parts[0] {
+	case "n", "y":
+	default:
+		// "p=..." requests channel binding, which the server did not offer.
+		return "", errors.New("SCRAM channel binding is not supported")
+	}
+	if parts[1] != "" {
+		return "", errors.New("SCRAM authorization identities are not supported")
+	}
+	s.gs2Header = parts[0] + "," + parts[1] + ","
+	s.clientFirstBare = parts[2]
+
+	// The user name is ignored: the user is the one given in the startup
+	// message, as in PostgreSQL.
+	attrs := strings.Split(s.clientFirstBare, ",")
+	if len(attrs) < 2 || !strings.HasPrefix(attrs[0], "n=") || !strings.HasPrefix(attrs[1], "r=") {
+		return "", errors.New("malformed SCRAM client-first message")
+	}
+	clientNonce := attrs[1][len("r="):]
+	if clientNonce == "" {
+		return "", errors.New("malformed SCRAM client-first message: empty nonce")
+	}
+
+	serverNonce := make([]byte, scramNonceLength)
+	if _, err := rand.Read(serverNonce); err != nil {
+		return "", err
+	}
+	s.nonce = clientNonce + base64.StdEncoding.EncodeToString(serverNonce)
+	s.serverFirst = "r=" + s.nonce +
+		",s=" + base64.StdEncoding.EncodeToString(s.verifier.Salt) +
+		",i=" + strconv.Itoa(s.verifier.Iterations)
+	return s.serverFirst, nil
+}
+
+// ServerFinal processes the client-final message, which contains the proof
+// that the client knows the password, and returns the server-final message.
+// An error is returned if the proof is not valid.
+func (s *ScramServerConversation) ServerFinal(clientFinal string) (string, error) {
+	// client-final-message = channel-binding "," nonce ["," extensions] "," proof
+	idx := strings.LastIndex(clientFinal, ",p=")
+	if idx < 0 {
+		return "", errors.New("malformed SCRAM client-final message: missing proof")
+	}
+	withoutProof := clientFinal[:idx]
+	proof, err := base64.StdEncoding.DecodeString(clientFinal[idx+len(",p="):])
+	if err != nil || len(proof) != sha256.Size {
+		return "", errors.New("malformed SCRAM client-final message: invalid proof")
+	}
+	attrs := strings.Split(withoutProof, ",")
+	if len(attrs) < 2 || !strings.HasPrefix(attrs[0], "c=") || !strings.HasPrefix(attrs[1], "r=") {
+		return "", errors.New("malformed SCRAM client-final message")
+	}
+	if attrs[0][len("c="):] != base64.StdEncoding.EncodeToString([]byte(s.gs2Header)) {
+		return "", errors.New("SCRAM channel binding data does not match")
+	}
+	if attrs[1][len("r="):] != s.nonce {
+		return "", errors.New("SCRAM nonce does not match")
+	}
+	if s.mock {
+		return "", errors.New("invalid SCRAM client proof")
+	}
+
+	authMessage := s.clientFirstBare + "," + s.serverFirst + "," + withoutProof
+	clientSignature := scramHMAC(s.verifier.StoredKey, authMessage)
+	clientKey := make([]byte, len(proof))
+	for i := range proof {
+		clientKey[i] = proof[i] ^ clientSignature[i]
+	}
+	storedKey := sha256.Sum256(clientKey)
+	if !hmac.Equal(storedKey[:], s.verifier.StoredKey) {
+		return "", errors.New("invalid SCRAM client proof")
+	}
+
+	serverSignature := scramHMAC(s.verifier.ServerKey, authMessage)
+	return "v=" + base64.StdEncoding.EncodeToString(serverSignature), nil
+}
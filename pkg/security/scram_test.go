@@ -0,0 +1,130 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security_test
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/scram"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScramVerifier(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	hashed, err := security.HashPasswordScram("pencil")
+	require.NoError(t, err)
+	require.True(t, security.IsScramHash(hashed))
+
+	v, err := security.ParseScramVerifier(hashed)
+	require.NoError(t, err)
+	require.Equal(t, security.ScramIterCount, v.Iterations)
+	require.Equal(t, string(hashed), v.String())
+
+	// The verifier can be used to check cleartext passwords.
+	require.NoError(t, security.CompareHashAndPassword(hashed, "pencil"))
+	require.Error(t, security.CompareHashAndPassword(hashed, "pen"))
+
+	for _, malformed := range []string{
+		"SCRAM-SHA-256$4096:c2FsdA==",
+		"SCRAM-SHA-256$abc:c2FsdA==$a2V5:a2V5",
+		"SCRAM-SHA-256$4096:c2FsdA==$a2V5:a2V5",
+		"$2a$10$abcdefghijklmnopqrstuv",
+	} {
+		_, err := security.ParseScramVerifier([]byte(malformed))
+		require.Error(t, err, malformed)
+	}
+}
+
+func TestScramServerConversation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	hashed, err := security.HashPasswordScram("pencil")
+	require.NoError(t, err)
+	v, err := security.ParseScramVerifier(hashed)
+	require.NoError(t, err)
+
+	// exchange runs the SCRAM exchange between the server conversation and the
+	// client implementation of lib/pq.
+	exchange := func(password string) error {
+		client := scram.NewClient(sha256.New, "user", password)
+		server := security.NewScramServerConversation(v)
+		client.Step(nil)
+		require.NoError(t, client.Err())
+		serverFirst, err := server.ServerFirst(string(client.Out()))
+		if err != nil {
+			return err
+		}
+		client.Step([]byte(serverFirst))
+		require.NoError(t, client.Err())
+		serverFinal, err := server.ServerFinal(string(client.Out()))
+		if err != nil {
+			return err
+		}
+		// The client checks the server signature.
+		client.Step([]byte(serverFinal))
+		return client.Err()
+	}
+
+	require.NoError(t, exchange("pencil"))
+	err = exchange("pen")
+	require.True(t, testutils.IsError(err, "invalid SCRAM client proof"), "%v", err)
+
+	server := security.NewScramServerConversation(v)
+	_, err = server.ServerFirst("p=tls-server-end-point,,n=user,r=abc")
+	require.True(t, testutils.IsError(err, "channel binding is not supported"), "%v", err)
+}
+
+func TestMockScramServerConversation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	secret := []byte("secret")
+	// exchange runs the SCRAM exchange against a mock conversation and returns
+	// the server-first message.
+	exchange := func(user string) (string, error) {
+		client := scram.NewClient(sha256.New, user, "pencil")
+		server := security.NewMockScramServerConversation(user, secret)
+		client.Step(nil)
+		require.NoError(t, client.Err())
+		serverFirst, err := server.ServerFirst(string(client.Out()))
+		require.NoError(t, err)
+		client.Step([]byte(serverFirst))
+		require.NoError(t, client.Err())
+		_, err = server.ServerFinal(string(client.Out()))
+		return serverFirst, err
+	}
+	// salt extracts the salt from a server-first message.
+	salt := func(serverFirst string) string {
+		for _, attr := range strings.Split(serverFirst, ",") {
+			if strings.HasPrefix(attr, "s=") {
+				return attr
+			}
+		}
+		t.Fatalf("no salt in %q", serverFirst)
+		return ""
+	}
+
+	first, err := exchange("alice")
+	require.True(t, testutils.IsError(err, "invalid SCRAM client proof"), "%v", err)
+
+	// The salt is the same on each attempt, and differs across users.
+	again, err := exchange("alice")
+	require.Error(t, err)
+	require.Equal(t, salt(first), salt(again))
+	other, err := exchange("bob")
+	require.Error(t, err)
+	require.NotEqual(t, salt(first), salt(other))
+}
@@ -1378,6 +1378,10 @@ func (s *adminServer) checkReadinessForHealthCheck() error {
 		return s.serverError(errors.Newf("unknown mode: %v", serveMode))
 	}
 
+	if err := s.server.node.diskStallError(); err != nil {
+		return status.Errorf(codes.Unavailable, "node is not healthy: %v", err)
+	}
+
 	// TODO(knz): update this code when progress is made on
 	// https://github.com/cockroachdb/cockroach/issues/45123
 	l, ok := s.server.nodeLiveness.GetLiveness(s.server.NodeID())
@@ -0,0 +1,701 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiV2Path is the prefix of all the endpoints of the API v2. The API v2
+	// is a versioned REST API returning plain JSON, intended for programmatic
+	// use by operations tooling.
+	apiV2Path = "/api/v2/"
+
+	// APIV2AuthHeader is the header carrying the session token returned by
+	// the API v2 login endpoint. Requests may alternatively authenticate with
+	// the session cookie used by the DB Console.
+	APIV2AuthHeader = "X-Cockroach-API-Session"
+
+	apiV2LoginPath     = apiV2Path + "login/"
+	apiV2LogoutPath    = apiV2Path + "logout/"
+	apiV2NodesPath     = apiV2Path + "nodes/"
+	apiV2HotRangesPath = apiV2Path + "ranges/hot/"
+	apiV2JobsPath      = apiV2Path + "jobs/"
+	apiV2SessionsPath  = apiV2Path + "sessions/"
+
+	apiV2StmtDiagnosticsPath = apiV2Path + "statements/diagnostics/"
+
+	// apiV2DefaultLimit is the number of results returned by a paginated
+	// endpoint when the request doesn't specify a limit.
+	apiV2DefaultLimit = 100
+
+	// apiV2MaxLoginRequestSize is the maximum size of the body of a login
+	// request.
+	apiV2MaxLoginRequestSize = 16 << 10 // 16 KiB
+)
+
+// apiV2Server serves the API v2 endpoints. Each endpoint is a thin JSON
+// layer over the corresponding method of the status or admin server, which
+// remain responsible for checking the privileges of the user.
+type apiV2Server struct {
+	server *Server
+	mux    *http.ServeMux
+}
+
+// newAPIV2Server creates the API v2 server and registers its endpoints.
+// Endpoints other than login require an authenticated session, unless web
+// sessions aren't required, in which case requests without a session are
+// served as root.
+func newAPIV2Server(s *Server) *apiV2Server {
+	a := &apiV2Server{server: s, mux: http.NewServeMux()}
+	allowAnonymous := !s.cfg.RequireWebSession()
+	authenticated := func(h http.HandlerFunc) http.Handler {
+		return &apiV2AuthMux{server: s.authentication, inner: h, allowAnonymous: allowAnonymous}
+	}
+
+	a.mux.HandleFunc(apiV2LoginPath, apiV2Method(http.MethodPost, a.login))
+	a.mux.Handle(apiV2LogoutPath, authenticated(apiV2Method(http.MethodPost, a.logout)))
+	a.mux.Handle(apiV2NodesPath, authenticated(apiV2Method(http.MethodGet, a.listNodes)))
+	a.mux.Handle(apiV2HotRangesPath, authenticated(apiV2Method(http.MethodGet, a.listHotRanges)))
+	a.mux.Handle(apiV2JobsPath, authenticated(a.handleJobs))
+	a.mux.Handle(apiV2SessionsPath, authenticated(a.handleSessions))
+	a.mux.Handle(apiV2StmtDiagnosticsPath,
+		authenticated(apiV2Method(http.MethodGet, a.listStmtDiagnostics)))
+	a.mux.Handle(sqlAPIPath, authenticated(s.handleSQLAPI))
+	return a
+}
+
+// ServeHTTP implements http.Handler.
+func (a *apiV2Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	a.mux.ServeHTTP(w, req)
+}
+
+// apiV2Method returns a handler which calls h if the request uses the given
+// method and returns an error otherwise.
+func apiV2Method(method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			w.Header().Set("Allow", method)
+			writeAPIV2Error(req.Context(), w, http.StatusMethodNotAllowed,
+				fmt.Sprintf("only %s is supported", method))
+			return
+		}
+		h(w, req)
+	}
+}
+
+// apiV2AuthMux authenticates API v2 requests. The session is read from the
+// APIV2AuthHeader header or, failing that, from the session cookie. Like
+// authenticationMux, it calls its inner handler with the user and ID of the
+// session in the context.
+type apiV2AuthMux struct {
+	server *authenticationServer
+	inner  http.Handler
+
+	// allowAnonymous, if true, indicates that requests without a session are
+	// passed to the inner handler rather than rejected.
+	allowAnonymous bool
+}
+
+// ServeHTTP implements http.Handler.
+func (am *apiV2AuthMux) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	var rawCookie *http.Cookie
+	if token := req.Header.Get(APIV2AuthHeader); token != "" {
+		rawCookie = &http.Cookie{Name: SessionCookieName, Value: token}
+	} else if c, err := req.Cookie(SessionCookieName); err == nil {
+		rawCookie = c
+	}
+	if rawCookie == nil {
+		if !am.allowAnonymous {
+			writeAPIV2Error(ctx, w, http.StatusUnauthorized,
+				fmt.Sprintf("a session is required; log in at %s and pass the returned session in the %s header",
+					apiV2LoginPath, APIV2AuthHeader))
+			return
+		}
+		am.inner.ServeHTTP(w, req)
+		return
+	}
+
+	cookie, err := decodeSessionCookie(rawCookie)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusUnauthorized, "the provided session could not be decoded")
+		return
+	}
+	valid, username, err := am.server.verifySession(ctx, cookie)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, apiInternalError(ctx, err))
+		return
+	}
+	if !valid {
+		writeAPIV2Error(ctx, w, http.StatusUnauthorized, "the provided session could not be validated")
+		return
+	}
+	ctx = context.WithValue(ctx, webSessionUserKey{}, username)
+	ctx = context.WithValue(ctx, webSessionIDKey{}, cookie.ID)
+	am.inner.ServeHTTP(w, req.WithContext(ctx))
+}
+
+// authContext returns a context for calling the status and admin servers on
+// behalf of the user of the request's session.
+func (a *apiV2Server) authContext(req *http.Request) context.Context {
+	ctx := a.server.AnnotateCtx(req.Context())
+	return metadata.NewIncomingContext(ctx, forwardAuthenticationMetadata(ctx, req))
+}
+
+// apiV2LoginResponse is the response of the login endpoint.
+type apiV2LoginResponse struct {
+	// Session is the token to pass in the APIV2AuthHeader header of
+	// subsequent requests.
+	Session string `json:"session"`
+}
+
+// login creates a session for the user whose username and password are
+// passed as form values, and returns the session token.
+func (a *apiV2Server) login(w http.ResponseWriter, req *http.Request) {
+	ctx := a.server.AnnotateCtx(req.Context())
+	ctx = context.WithValue(ctx, webSessionClientAddrKey{}, httpClientAddr(req))
+	req.Body = http.MaxBytesReader(w, req.Body, apiV2MaxLoginRequestSize)
+	if err := req.ParseForm(); err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if req.PostForm.Get("username") == "" {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, "no username was provided")
+		return
+	}
+	// Usernames are case-insensitive; see UserLogin.
+	username, _ := security.MakeSQLUsernameFromUserInput(
+		req.PostForm.Get("username"), security.UsernameValidation,
+	)
+
+	verified, expired, err := a.server.authentication.verifyPassword(
+		ctx, username, req.PostForm.Get("password"),
+	)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, apiInternalError(ctx, err))
+		return
+	}
+	if expired {
+		writeAPIV2Error(ctx, w, http.StatusUnauthorized,
+			fmt.Sprintf("the password for %s has expired", username))
+		return
+	}
+	if !verified {
+		writeAPIV2ServerError(ctx, w, errWebAuthenticationFailure)
+		return
+	}
+
+	cookie, err := a.server.authentication.createSessionFor(ctx, username)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+	w.Header().Set(APIV2AuthHeader, cookie.Value)
+	writeAPIV2Response(ctx, w, http.StatusOK, &apiV2LoginResponse{Session: cookie.Value})
+}
+
+// apiV2LogoutResponse is the response of the logout endpoint.
+type apiV2LogoutResponse struct {
+	LoggedOut bool `json:"logged_out"`
+}
+
+// logout revokes the session of the request.
+func (a *apiV2Server) logout(w http.ResponseWriter, req *http.Request) {
+	ctx := a.server.AnnotateCtx(req.Context())
+	sessionID, ok := ctx.Value(webSessionIDKey{}).(int64)
+	if !ok {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, "the request has no session")
+		return
+	}
+	if _, err := a.server.sqlServer.internalExecutor.ExecEx(
+		ctx,
+		"revoke-auth-session",
+		nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPDATE system.web_sessions SET "revokedAt" = now() WHERE id = $1`,
+		sessionID,
+	); err != nil {
+		writeAPIV2ServerError(ctx, w, apiInternalError(ctx, err))
+		return
+	}
+	writeAPIV2Response(ctx, w, http.StatusOK, &apiV2LogoutResponse{LoggedOut: true})
+}
+
+// apiV2Node describes a node in the response of the nodes endpoint.
+type apiV2Node struct {
+	NodeID            roachpb.NodeID    `json:"node_id"`
+	Address           string            `json:"address"`
+	SQLAddress        string            `json:"sql_address"`
+	Locality          string            `json:"locality"`
+	ServerVersion     string            `json:"server_version"`
+	BuildTag          string            `json:"build_tag"`
+	StartedAt         time.Time         `json:"started_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	LivenessStatus    string            `json:"liveness_status"`
+	NumCPUs           int32             `json:"num_cpus"`
+	TotalSystemMemory int64             `json:"total_system_memory"`
+	StoreIDs          []roachpb.StoreID `json:"store_ids"`
+}
+
+// apiV2NodesResponse is the response of the nodes endpoint.
+type apiV2NodesResponse struct {
+	Nodes []apiV2Node `json:"nodes"`
+	// Next is the offset of the next page of results, if any.
+	Next int `json:"next,omitempty"`
+}
+
+// listNodes lists the nodes of the cluster, ordered by node ID.
+func (a *apiV2Server) listNodes(w http.ResponseWriter, req *http.Request) {
+	ctx := a.authContext(req)
+	p, err := parseAPIV2Pagination(req)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+	nodes, err := a.server.status.Nodes(ctx, &serverpb.NodesRequest{})
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+	sort.Slice(nodes.Nodes, func(i, j int) bool {
+		return nodes.Nodes[i].Desc.NodeID < nodes.Nodes[j].Desc.NodeID
+	})
+
+	start, end, next := p.page(len(nodes.Nodes))
+	resp := apiV2NodesResponse{Nodes: make([]apiV2Node, 0, end-start), Next: next}
+	for _, ns := range nodes.Nodes[start:end] {
+		n := apiV2Node{
+			NodeID:            ns.Desc.NodeID,
+			Address:           ns.Desc.Address.String(),
+			SQLAddress:        ns.Desc.SQLAddress.String(),
+			Locality:          ns.Desc.Locality.String(),
+			ServerVersion:     ns.Desc.ServerVersion.String(),
+			BuildTag:          ns.Desc.BuildTag,
+			StartedAt:         time.Unix(0, ns.StartedAt).UTC(),
+			UpdatedAt:         time.Unix(0, ns.UpdatedAt).UTC(),
+			LivenessStatus:    strings.TrimPrefix(nodes.LivenessByNodeID[ns.Desc.NodeID].String(), "NODE_STATUS_"),
+			NumCPUs:           ns.NumCpus,
+			TotalSystemMemory: ns.TotalSystemMemory,
+			StoreIDs:          make([]roachpb.StoreID, len(ns.StoreStatuses)),
+		}
+		for i := range ns.StoreStatuses {
+			n.StoreIDs[i] = ns.StoreStatuses[i].Desc.StoreID
+		}
+		resp.Nodes = append(resp.Nodes, n)
+	}
+	writeAPIV2Response(ctx, w, http.StatusOK, &resp)
+}
+
+// apiV2HotRange describes a range in the response of the hot ranges
+// endpoint.
+type apiV2HotRange struct {
+	RangeID          roachpb.RangeID `json:"range_id"`
+	NodeID           roachpb.NodeID  `json:"node_id"`
+	StoreID          roachpb.StoreID `json:"store_id"`
+	StartKey         string          `json:"start_key"`
+	EndKey           string          `json:"end_key"`
+	QueriesPerSecond float64         `json:"qps"`
+}
+
+// apiV2NodeError describes a node which failed to respond to a request
+// fanned out to all the nodes.
+type apiV2NodeError struct {
+	NodeID  roachpb.NodeID `json:"node_id"`
+	Message string         `json:"message"`
+}
+
+// apiV2HotRangesResponse is the response of the hot ranges endpoint.
+type apiV2HotRangesResponse struct {
+	Ranges []apiV2HotRange  `json:"ranges"`
+	Errors []apiV2NodeError `json:"errors,omitempty"`
+	Next   int              `json:"next,omitempty"`
+}
+
+// listHotRanges lists the hottest ranges of each store, ordered by
+// decreasing QPS. The node_id parameter restricts the report to one node.
+func (a *apiV2Server) listHotRanges(w http.ResponseWriter, req *http.Request) {
+	ctx := a.authContext(req)
+	p, err := parseAPIV2Pagination(req)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+	hotRanges, err := a.server.status.HotRanges(ctx, &serverpb.HotRangesRequest{
+		NodeID: req.URL.Query().Get("node_id"),
+	})
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+
+	var resp apiV2HotRangesResponse
+	for nodeID, nodeResp := range hotRanges.HotRangesByNodeID {
+		if nodeResp.ErrorMessage != "" {
+			resp.Errors = append(resp.Errors, apiV2NodeError{NodeID: nodeID, Message: nodeResp.ErrorMessage})
+		}
+		for _, store := range nodeResp.Stores {
+			for _, r := range store.HotRanges {
+				resp.Ranges = append(resp.Ranges, apiV2HotRange{
+					RangeID:          r.Desc.RangeID,
+					NodeID:           nodeID,
+					StoreID:          store.StoreID,
+					StartKey:         r.Desc.StartKey.String(),
+					EndKey:           r.Desc.EndKey.String(),
+					QueriesPerSecond: r.QueriesPerSecond,
+				})
+			}
+		}
+	}
+	sort.Slice(resp.Ranges, func(i, j int) bool {
+		ri, rj := &resp.Ranges[i], &resp.Ranges[j]
+		if ri.QueriesPerSecond != rj.QueriesPerSecond {
+			return ri.QueriesPerSecond > rj.QueriesPerSecond
+		}
+		if ri.RangeID != rj.RangeID {
+			return ri.RangeID < rj.RangeID
+		}
+		return ri.StoreID < rj.StoreID
+	})
+	sort.Slice(resp.Errors, func(i, j int) bool {
+		return resp.Errors[i].NodeID < resp.Errors[j].NodeID
+	})
+
+	start, end, next := p.page(len(resp.Ranges))
+	resp.Ranges, resp.Next = append([]apiV2HotRange{}, resp.Ranges[start:end]...), next
+	writeAPIV2Response(ctx, w, http.StatusOK, &resp)
+}
+
+// apiV2JobsResponse is the response of the jobs endpoint.
+type apiV2JobsResponse struct {
+	Jobs []serverpb.JobsResponse_Job `json:"jobs"`
+	Next int                         `json:"next,omitempty"`
+}
+
+// handleJobs serves the jobs endpoints:
+//
+//	GET /api/v2/jobs/                lists the jobs.
+//	GET /api/v2/jobs/{id}/timeline/  returns the timeline of a job.
+func (a *apiV2Server) handleJobs(w http.ResponseWriter, req *http.Request) {
+	rest := strings.TrimPrefix(req.URL.Path, apiV2JobsPath)
+	if rest == "" {
+		apiV2Method(http.MethodGet, a.listJobs)(w, req)
+		return
+	}
+	if id := strings.TrimSuffix(rest, "/timeline/"); id != rest && !strings.Contains(id, "/") {
+		apiV2Method(http.MethodGet, func(w http.ResponseWriter, req *http.Request) {
+			a.getJobTimeline(w, req, id)
+		})(w, req)
+		return
+	}
+	writeAPIV2Error(req.Context(), w, http.StatusNotFound, "not found")
+}
+
+// listJobs lists jobs, most recently created first. The status and type
+// parameters filter the jobs by status, e.g. "running", and by type, e.g.
+// "backup" or "schema_change". Automatic statistics jobs are only listed
+// when asked for by type.
+func (a *apiV2Server) listJobs(w http.ResponseWriter, req *http.Request) {
+	ctx := a.authContext(req)
+	p, err := parseAPIV2Pagination(req)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+	jobsReq := serverpb.JobsRequest{Status: req.URL.Query().Get("status")}
+	if typ := req.URL.Query().Get("type"); typ != "" {
+		t, ok := jobspb.Type_value[strings.ReplaceAll(strings.ToUpper(typ), " ", "_")]
+		if !ok || jobspb.Type(t) == jobspb.TypeUnspecified {
+			writeAPIV2Error(ctx, w, http.StatusBadRequest, fmt.Sprintf("unknown job type %q", typ))
+			return
+		}
+		jobsReq.Type = jobspb.Type(t)
+	}
+	// Fetch one job past the page to find out whether there is a next page.
+	if n := p.offset + p.limit + 1; n <= math.MaxInt32 {
+		jobsReq.Limit = int32(n)
+	}
+	jobs, err := a.server.admin.Jobs(ctx, &jobsReq)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+
+	start, end, next := p.page(len(jobs.Jobs))
+	writeAPIV2Response(ctx, w, http.StatusOK, &apiV2JobsResponse{
+		Jobs: append([]serverpb.JobsResponse_Job{}, jobs.Jobs[start:end]...),
+		Next: next,
+	})
+}
+
+// getJobTimeline returns the creation of the job with the given ID and the
+// changes of its status, with their timestamps.
+func (a *apiV2Server) getJobTimeline(w http.ResponseWriter, req *http.Request, id string) {
+	ctx := a.authContext(req)
+	jobID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, fmt.Sprintf("invalid job ID %q", id))
+		return
+	}
+	timeline, err := a.server.status.jobTimeline(ctx, jobID)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+	writeAPIV2Response(ctx, w, http.StatusOK, timeline)
+}
+
+// apiV2StmtDiagnosticsResponse is the response of the statement diagnostics
+// endpoint.
+type apiV2StmtDiagnosticsResponse struct {
+	Diagnostics []stmtDiagnosticsHistoryEntry `json:"diagnostics"`
+	Next        int                           `json:"next,omitempty"`
+}
+
+// listStmtDiagnostics lists the statement diagnostics requests, most recent
+// first, along with the diagnostics collected for them and the URL of their
+// statement bundle.
+func (a *apiV2Server) listStmtDiagnostics(w http.ResponseWriter, req *http.Request) {
+	ctx := a.authContext(req)
+	p, err := parseAPIV2Pagination(req)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Fetch one entry past the page to find out whether there is a next page.
+	history, err := a.server.status.statementDiagnosticsHistory(ctx, p.offset+p.limit+1)
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+
+	start, end, next := p.page(len(history))
+	writeAPIV2Response(ctx, w, http.StatusOK, &apiV2StmtDiagnosticsResponse{
+		Diagnostics: history[start:end],
+		Next:        next,
+	})
+}
+
+// apiV2Session describes a SQL session in the response of the sessions
+// endpoint.
+type apiV2Session struct {
+	// ID is the ID of the session, as shown by SHOW SESSIONS.
+	ID              string         `json:"id"`
+	NodeID          roachpb.NodeID `json:"node_id"`
+	Username        string         `json:"username"`
+	ClientAddress   string         `json:"client_address"`
+	ApplicationName string         `json:"application_name"`
+	Start           time.Time      `json:"start"`
+	ActiveQueries   []string       `json:"active_queries"`
+	LastActiveQuery string         `json:"last_active_query"`
+}
+
+// apiV2SessionsResponse is the response of the sessions endpoint.
+type apiV2SessionsResponse struct {
+	Sessions []apiV2Session   `json:"sessions"`
+	Errors   []apiV2NodeError `json:"errors,omitempty"`
+	Next     int              `json:"next,omitempty"`
+}
+
+// apiV2CancelSessionResponse is the response of the session cancellation
+// endpoint.
+type apiV2CancelSessionResponse struct {
+	Canceled bool `json:"canceled"`
+}
+
+// handleSessions serves the sessions endpoints:
+//
+//	GET  /api/v2/sessions/              lists the sessions.
+//	POST /api/v2/sessions/{id}/cancel/  cancels a session.
+func (a *apiV2Server) handleSessions(w http.ResponseWriter, req *http.Request) {
+	rest := strings.TrimPrefix(req.URL.Path, apiV2SessionsPath)
+	if rest == "" {
+		apiV2Method(http.MethodGet, a.listSessions)(w, req)
+		return
+	}
+	if id := strings.TrimSuffix(rest, "/cancel/"); id != rest && !strings.Contains(id, "/") {
+		apiV2Method(http.MethodPost, func(w http.ResponseWriter, req *http.Request) {
+			a.cancelSession(w, req, id)
+		})(w, req)
+		return
+	}
+	writeAPIV2Error(req.Context(), w, http.StatusNotFound, "not found")
+}
+
+// listSessions lists the SQL sessions of the cluster, oldest first. The
+// username and application_name parameters filter the sessions. Users which
+// are neither admins nor have the VIEWACTIVITY role option only see their own
+// sessions.
+func (a *apiV2Server) listSessions(w http.ResponseWriter, req *http.Request) {
+	ctx := a.authContext(req)
+	p, err := parseAPIV2Pagination(req)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var username string
+	if u := req.URL.Query().Get("username"); u != "" {
+		normalized, _ := security.MakeSQLUsernameFromUserInput(u, security.UsernameValidation)
+		username = normalized.Normalized()
+	}
+	sessions, err := a.server.status.ListSessions(ctx, &serverpb.ListSessionsRequest{
+		Username:        username,
+		ApplicationName: req.URL.Query().Get("application_name"),
+	})
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+	sort.Slice(sessions.Sessions, func(i, j int) bool {
+		si, sj := &sessions.Sessions[i], &sessions.Sessions[j]
+		if !si.Start.Equal(sj.Start) {
+			return si.Start.Before(sj.Start)
+		}
+		return string(si.ID) < string(sj.ID)
+	})
+
+	start, end, next := p.page(len(sessions.Sessions))
+	resp := apiV2SessionsResponse{Sessions: make([]apiV2Session, 0, end-start), Next: next}
+	for _, s := range sessions.Sessions[start:end] {
+		session := apiV2Session{
+			ID:              sql.BytesToClusterWideID(s.ID).String(),
+			NodeID:          s.NodeID,
+			Username:        s.Username,
+			ClientAddress:   s.ClientAddress,
+			ApplicationName: s.ApplicationName,
+			Start:           s.Start,
+			ActiveQueries:   make([]string, len(s.ActiveQueries)),
+			LastActiveQuery: s.LastActiveQuery,
+		}
+		for i := range s.ActiveQueries {
+			session.ActiveQueries[i] = s.ActiveQueries[i].Sql
+		}
+		resp.Sessions = append(resp.Sessions, session)
+	}
+	for _, e := range sessions.Errors {
+		resp.Errors = append(resp.Errors, apiV2NodeError{NodeID: e.NodeID, Message: e.Message})
+	}
+	writeAPIV2Response(ctx, w, http.StatusOK, &resp)
+}
+
+// cancelSession cancels the session with the given ID.
+func (a *apiV2Server) cancelSession(w http.ResponseWriter, req *http.Request, id string) {
+	ctx := a.authContext(req)
+	sessionID, err := sql.StringToClusterWideID(id)
+	if err != nil {
+		writeAPIV2Error(ctx, w, http.StatusBadRequest, fmt.Sprintf("invalid session ID %q", id))
+		return
+	}
+	resp, err := a.server.status.CancelSession(ctx, &serverpb.CancelSessionRequest{
+		NodeId:    strconv.Itoa(int(sessionID.GetNodeID())),
+		SessionID: sessionID.GetBytes(),
+	})
+	if err != nil {
+		writeAPIV2ServerError(ctx, w, err)
+		return
+	}
+	if !resp.Canceled {
+		writeAPIV2Error(ctx, w, http.StatusNotFound,
+			fmt.Sprintf("could not cancel session %s: %s", id, resp.Error))
+		return
+	}
+	writeAPIV2Response(ctx, w, http.StatusOK, &apiV2CancelSessionResponse{Canceled: true})
+}
+
+// apiV2Pagination holds the pagination parameters of a request to an
+// endpoint which returns a list of results. The limit parameter bounds the
+// number of results; the offset parameter is the number of results to skip,
+// which is returned as the next field of the previous page.
+type apiV2Pagination struct {
+	limit, offset int
+}
+
+func parseAPIV2Pagination(req *http.Request) (apiV2Pagination, error) {
+	p := apiV2Pagination{limit: apiV2DefaultLimit}
+	for _, param := range []struct {
+		name string
+		val  *int
+		min  int
+	}{
+		{"limit", &p.limit, 1},
+		{"offset", &p.offset, 0},
+	} {
+		s := req.URL.Query().Get(param.name)
+		if s == "" {
+			continue
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v < param.min {
+			return apiV2Pagination{}, errors.Newf("invalid %s %q", param.name, s)
+		}
+		*param.val = v
+	}
+	return p, nil
+}
+
+// page returns the bounds of the requested page within n results, and the
+// offset of the next page, or 0 if this is the last page.
+func (p apiV2Pagination) page(n int) (start, end, next int) {
+	start, end = p.offset, p.offset+p.limit
+	if start > n {
+		start = n
+	}
+	if end >= n {
+		return start, n, 0
+	}
+	return start, end, end
+}
+
+// apiV2ErrorResponse is the response of an API v2 request which failed. It
+// has the same shape as the errors returned by the SQL API.
+type apiV2ErrorResponse struct {
+	Error sqlAPIError `json:"error"`
+}
+
+func writeAPIV2Error(ctx context.Context, w http.ResponseWriter, code int, msg string) {
+	writeAPIV2Response(ctx, w, code, &apiV2ErrorResponse{Error: sqlAPIError{Message: msg}})
+}
+
+// writeAPIV2ServerError writes an error returned by the status or admin
+// server, using the HTTP status code corresponding to its gRPC code.
+func writeAPIV2ServerError(ctx context.Context, w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	writeAPIV2Error(ctx, w, gwruntime.HTTPStatusFromCode(s.Code()), s.Message())
+}
+
+func writeAPIV2Response(ctx context.Context, w http.ResponseWriter, code int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warningf(ctx, "failed to write API v2 response: %v", err)
+	}
+}
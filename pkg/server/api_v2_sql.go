@@ -0,0 +1,358 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// sqlAPIPath is the path of the HTTP endpoint which executes SQL
+	// statements on behalf of the authenticated user. See handleSQLAPI.
+	sqlAPIPath = "/api/v2/sql/"
+
+	// sqlAPIMaxRequestSize is the maximum size of a request body accepted by
+	// the SQL API.
+	sqlAPIMaxRequestSize = 1 << 20 // 1 MiB
+
+	// sqlAPIDefaultTimeout is the timeout applied to the execution of all the
+	// statements of a request when the request doesn't specify one.
+	sqlAPIDefaultTimeout = 5 * time.Second
+
+	// sqlAPIDefaultMaxResultSize is the maximum total size of the values
+	// returned for a request when the request doesn't specify one.
+	sqlAPIDefaultMaxResultSize = 10 << 20 // 10 MiB
+
+	// sqlAPIDefaultApplicationName is the application name under which
+	// statements run when the request doesn't specify one.
+	sqlAPIDefaultApplicationName = "$ api-v2-sql"
+
+	sqlAPIOpName = "run-sql-via-api"
+)
+
+// sqlAPIRequest is the JSON body of a request to the SQL API.
+type sqlAPIRequest struct {
+	// Database is the current database for the statements. It defaults to
+	// defaultdb.
+	Database string `json:"database"`
+	// ApplicationName is the application name the statements run under.
+	ApplicationName string `json:"application_name"`
+	// Timeout bounds the execution of all the statements of the request. It
+	// is parsed as a Go duration, e.g. "500ms" or "10s".
+	Timeout string `json:"timeout"`
+	// ReadOnly, if set, causes statements which write to fail.
+	ReadOnly bool `json:"read_only"`
+	// MaxResultSize bounds the total size in bytes of the returned values.
+	MaxResultSize int64 `json:"max_result_size"`
+	// Statements are the statements to execute, in order.
+	Statements []sqlAPIStatement `json:"statements"`
+}
+
+// sqlAPIStatement is a single statement of a sqlAPIRequest.
+type sqlAPIStatement struct {
+	// SQL is the text of the statement, which may contain placeholders.
+	SQL string `json:"sql"`
+	// Arguments are the values of the placeholders. JSON numbers, strings,
+	// booleans and null are accepted.
+	Arguments []interface{} `json:"arguments,omitempty"`
+
+	stmt parser.Statement
+	args []interface{}
+}
+
+// sqlAPIResponse is the JSON body of a response from the SQL API.
+type sqlAPIResponse struct {
+	// NumStatements is the number of statements in the request.
+	NumStatements int `json:"num_statements"`
+	// Results holds the results of the statements which executed
+	// successfully, in order.
+	Results []sqlAPIResult `json:"results"`
+	// Error is the error which stopped the execution, if any.
+	Error *sqlAPIError `json:"error,omitempty"`
+}
+
+// sqlAPIResult is the result of a single statement.
+type sqlAPIResult struct {
+	// Statement is the 1-based position of the statement in the request.
+	Statement int `json:"statement"`
+	// Tag is the statement tag, e.g. SELECT or INSERT.
+	Tag string `json:"tag"`
+	// Columns describes the columns of Rows, for statements which return
+	// rows.
+	Columns []sqlAPIColumn `json:"columns,omitempty"`
+	// Rows holds the returned rows, each value encoded as JSON.
+	Rows [][]json.RawMessage `json:"rows,omitempty"`
+	// RowsAffected is the number of rows affected, for statements which don't
+	// return rows.
+	RowsAffected int `json:"rows_affected"`
+	// Duration is the time the statement took to execute.
+	Duration string `json:"duration"`
+}
+
+// sqlAPIColumn describes a result column.
+type sqlAPIColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Oid  uint32 `json:"oid"`
+}
+
+// sqlAPIError describes an error returned by the SQL API.
+type sqlAPIError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	// Statement is the 1-based position of the statement which failed, if
+	// the error is specific to one.
+	Statement int `json:"statement,omitempty"`
+}
+
+// handleSQLAPI executes a batch of SQL statements as the authenticated user
+// and returns their results as JSON. It lets tooling query the cluster
+// without a Postgres driver.
+//
+// The statements execute in order, each in its own implicit transaction, and
+// execution stops at the first error. The results of the statements which
+// executed before the error are returned alongside it.
+func (s *Server) handleSQLAPI(w http.ResponseWriter, req *http.Request) {
+	ctx := s.AnnotateCtx(req.Context())
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeSQLAPIError(ctx, w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+	// Browsers attach the session cookie to requests forged by other sites,
+	// but such requests can neither set the APIV2AuthHeader header nor a JSON
+	// content type without a CORS preflight, which the server never allows.
+	// Requiring either of them protects the endpoint against CSRF.
+	if req.Header.Get(APIV2AuthHeader) == "" && !isJSONRequest(req) {
+		writeSQLAPIError(ctx, w, http.StatusUnsupportedMediaType,
+			fmt.Sprintf("requests must either set the %s header or have a Content-Type of application/json",
+				APIV2AuthHeader))
+		return
+	}
+
+	var r sqlAPIRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, req.Body, sqlAPIMaxRequestSize))
+	dec.UseNumber()
+	if err := dec.Decode(&r); err != nil {
+		writeSQLAPIError(ctx, w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	timeout, err := r.validate()
+	if err != nil {
+		writeSQLAPIError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	authCtx := metadata.NewIncomingContext(ctx, forwardAuthenticationMetadata(ctx, req))
+	username, err := userFromContext(authCtx)
+	if err != nil {
+		writeSQLAPIError(ctx, w, http.StatusInternalServerError, apiInternalError(ctx, err).Error())
+		return
+	}
+
+	sd := &sessiondata.SessionData{
+		SessionData: sessiondatapb.SessionData{
+			Database:        r.Database,
+			ApplicationName: r.ApplicationName,
+			UserProto:       username.EncodeProto(),
+		},
+		DefaultTxnReadOnly: r.ReadOnly,
+	}
+	ie := s.sqlServer.distSQLServer.ServerConfig.SessionBoundInternalExecutorFactory(ctx, sd)
+
+	resp := sqlAPIResponse{NumStatements: len(r.Statements), Results: []sqlAPIResult{}}
+	var resultSize int64
+	var failedStmt int
+	err = contextutil.RunWithTimeout(ctx, sqlAPIOpName, timeout, func(ctx context.Context) error {
+		for i := range r.Statements {
+			failedStmt = i + 1
+			res, err := runSQLAPIStatement(ctx, ie, &r.Statements[i], &resultSize, r.MaxResultSize)
+			if err != nil {
+				return err
+			}
+			res.Statement = i + 1
+			resp.Results = append(resp.Results, res)
+		}
+		return nil
+	})
+
+	code := http.StatusOK
+	if err != nil {
+		code = http.StatusInternalServerError
+		resp.Error = &sqlAPIError{Message: err.Error(), Statement: failedStmt}
+		if pgCode := pgerror.GetPGCode(err); pgCode != pgcode.Uncategorized {
+			resp.Error.Code = pgCode.String()
+		}
+	}
+	writeSQLAPIResponse(ctx, w, code, &resp)
+}
+
+// runSQLAPIStatement executes a single statement of a SQL API request.
+// resultSize accumulates the size of the values returned so far, which must
+// not exceed maxResultSize.
+func runSQLAPIStatement(
+	ctx context.Context,
+	ie sqlutil.InternalExecutor,
+	stmt *sqlAPIStatement,
+	resultSize *int64,
+	maxResultSize int64,
+) (sqlAPIResult, error) {
+	res := sqlAPIResult{Tag: stmt.stmt.AST.StatementTag()}
+	start := timeutil.Now()
+	if stmt.stmt.AST.StatementReturnType() != tree.Rows {
+		n, err := ie.ExecEx(
+			ctx, sqlAPIOpName, nil /* txn */, sessiondata.NoSessionDataOverride, stmt.SQL, stmt.args...,
+		)
+		if err != nil {
+			return sqlAPIResult{}, err
+		}
+		res.RowsAffected = n
+		res.Duration = timeutil.Since(start).String()
+		return res, nil
+	}
+
+	rows, cols, err := ie.QueryWithCols(
+		ctx, sqlAPIOpName, nil /* txn */, sessiondata.NoSessionDataOverride, stmt.SQL, stmt.args...,
+	)
+	if err != nil {
+		return sqlAPIResult{}, err
+	}
+	res.Duration = timeutil.Since(start).String()
+	res.Columns = make([]sqlAPIColumn, len(cols))
+	for i, col := range cols {
+		res.Columns[i] = sqlAPIColumn{Name: col.Name, Type: col.Typ.SQLString(), Oid: uint32(col.Typ.Oid())}
+	}
+	res.Rows = make([][]json.RawMessage, len(rows))
+	for i, row := range rows {
+		res.Rows[i] = make([]json.RawMessage, len(row))
+		for j, d := range row {
+			val, err := tree.AsJSON(d, time.UTC)
+			if err != nil {
+				return sqlAPIResult{}, err
+			}
+			encoded := val.String()
+			*resultSize += int64(len(encoded))
+			if *resultSize > maxResultSize {
+				return sqlAPIResult{}, errors.WithHint(
+					errors.Newf("result size exceeds the maximum of %d bytes", maxResultSize),
+					"Increase max_result_size or add a LIMIT clause to the query.",
+				)
+			}
+			res.Rows[i][j] = json.RawMessage(encoded)
+		}
+	}
+	return res, nil
+}
+
+// isJSONRequest returns whether the body of req is declared as JSON.
+func isJSONRequest(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// validate checks the request, fills in defaults and converts the statement
+// arguments to values the internal executor accepts. It returns the timeout
+// for the execution of the statements.
+func (r *sqlAPIRequest) validate() (time.Duration, error) {
+	if len(r.Statements) == 0 {
+		return 0, errors.New("no statements specified")
+	}
+	if r.Database == "" {
+		r.Database = catalogkeys.DefaultDatabaseName
+	}
+	if r.ApplicationName == "" {
+		r.ApplicationName = sqlAPIDefaultApplicationName
+	}
+	if r.MaxResultSize < 0 {
+		return 0, errors.New("max_result_size cannot be negative")
+	} else if r.MaxResultSize == 0 {
+		r.MaxResultSize = sqlAPIDefaultMaxResultSize
+	}
+	timeout := sqlAPIDefaultTimeout
+	if r.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(r.Timeout)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid timeout")
+		}
+		if timeout <= 0 {
+			return 0, errors.New("timeout must be positive")
+		}
+	}
+	for i := range r.Statements {
+		stmt := &r.Statements[i]
+		var err error
+		if stmt.stmt, err = parser.ParseOne(stmt.SQL); err != nil {
+			return 0, errors.Wrapf(err, "statement %d", i+1)
+		}
+		if stmt.stmt.NumPlaceholders != len(stmt.Arguments) {
+			return 0, errors.Newf("statement %d: expected %d arguments, got %d",
+				i+1, stmt.stmt.NumPlaceholders, len(stmt.Arguments))
+		}
+		stmt.args = make([]interface{}, len(stmt.Arguments))
+		for j, arg := range stmt.Arguments {
+			switch t := arg.(type) {
+			case nil, bool, string:
+				stmt.args[j] = t
+			case json.Number:
+				if n, err := t.Int64(); err == nil {
+					stmt.args[j] = n
+				} else if f, err := t.Float64(); err == nil {
+					stmt.args[j] = f
+				} else {
+					return 0, errors.Newf("statement %d: invalid number argument %s", i+1, t)
+				}
+			default:
+				return 0, errors.Newf("statement %d: unsupported argument type %T; "+
+					"only numbers, strings, booleans and null are accepted", i+1, arg)
+			}
+		}
+	}
+	return timeout, nil
+}
+
+// writeSQLAPIError responds to a SQL API request which could not be
+// executed.
+func writeSQLAPIError(ctx context.Context, w http.ResponseWriter, code int, msg string) {
+	writeSQLAPIResponse(ctx, w, code, &sqlAPIResponse{
+		Results: []sqlAPIResult{},
+		Error:   &sqlAPIError{Message: msg},
+	})
+}
+
+func writeSQLAPIResponse(
+	ctx context.Context, w http.ResponseWriter, code int, resp *sqlAPIResponse,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warningf(ctx, "failed to write SQL API response: %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLAPI(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE kv (k INT PRIMARY KEY, v STRING)`)
+
+	post := func(t *testing.T, isAdmin bool, req string) (int, sqlAPIResponse) {
+		client, err := s.GetAuthenticatedHTTPClient(isAdmin)
+		require.NoError(t, err)
+		httpResp, err := client.Post(
+			s.AdminURL()+sqlAPIPath, "application/json", bytes.NewBufferString(req),
+		)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		var resp sqlAPIResponse
+		require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&resp))
+		return httpResp.StatusCode, resp
+	}
+
+	t.Run("query", func(t *testing.T) {
+		code, resp := post(t, true /* isAdmin */, `{
+			"database": "defaultdb",
+			"statements": [
+				{"sql": "INSERT INTO kv VALUES ($1, $2), (2, NULL)", "arguments": [1, "a"]},
+				{"sql": "SELECT k, v, true AS b FROM kv ORDER BY k"}
+			]
+		}`)
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, resp.Error)
+		require.Equal(t, 2, resp.NumStatements)
+		require.Len(t, resp.Results, 2)
+
+		require.Equal(t, "INSERT", resp.Results[0].Tag)
+		require.Equal(t, 2, resp.Results[0].RowsAffected)
+
+		res := resp.Results[1]
+		require.Equal(t, 2, res.Statement)
+		require.Equal(t, "SELECT", res.Tag)
+		require.Equal(t, []sqlAPIColumn{
+			{Name: "k", Type: "INT8", Oid: 20},
+			{Name: "v", Type: "STRING", Oid: 25},
+			{Name: "b", Type: "BOOL", Oid: 16},
+		}, res.Columns)
+		require.Equal(t, [][]json.RawMessage{
+			{json.RawMessage(`1`), json.RawMessage(`"a"`), json.RawMessage(`true`)},
+			{json.RawMessage(`2`), json.RawMessage(`null`), json.RawMessage(`true`)},
+		}, res.Rows)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		code, resp := post(t, true /* isAdmin */, `{
+			"statements": [
+				{"sql": "SELECT 1"},
+				{"sql": "INSERT INTO kv VALUES (1, 'dup')"},
+				{"sql": "SELECT 2"}
+			]
+		}`)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Len(t, resp.Results, 1)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, 2, resp.Error.Statement)
+		require.Equal(t, "23505", resp.Error.Code)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		code, resp := post(t, true /* isAdmin */, `{
+			"read_only": true,
+			"statements": [{"sql": "DELETE FROM kv"}]
+		}`)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "25006", resp.Error.Code)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM kv`, [][]string{{"2"}})
+	})
+
+	t.Run("user", func(t *testing.T) {
+		code, resp := post(t, false /* isAdmin */, `{
+			"statements": [{"sql": "SELECT current_user()"}, {"sql": "SELECT * FROM kv"}]
+		}`)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Len(t, resp.Results, 1)
+		require.Equal(t, json.RawMessage(`"`+authenticatedUserNoAdmin+`"`), resp.Results[0].Rows[0][0])
+		require.NotNil(t, resp.Error)
+		require.Equal(t, "42501", resp.Error.Code)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		code, resp := post(t, true /* isAdmin */, `{
+			"timeout": "100ms",
+			"statements": [{"sql": "SELECT pg_sleep(5)"}]
+		}`)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, `operation "run-sql-via-api" timed out after 100ms`)
+	})
+
+	t.Run("max result size", func(t *testing.T) {
+		code, resp := post(t, true /* isAdmin */, `{
+			"max_result_size": 100,
+			"statements": [{"sql": "SELECT repeat('x', 1000)"}]
+		}`)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.NotNil(t, resp.Error)
+		require.Contains(t, resp.Error.Message, "result size exceeds the maximum of 100 bytes")
+	})
+
+	t.Run("csrf", func(t *testing.T) {
+		// A request authenticated only with the session cookie must declare a
+		// JSON body, which cross-site forms cannot do.
+		client, err := s.GetAuthenticatedHTTPClient(true /* isAdmin */)
+		require.NoError(t, err)
+		httpResp, err := client.Post(
+			s.AdminURL()+sqlAPIPath, "text/plain",
+			bytes.NewBufferString(`{"statements": [{"sql": "DELETE FROM kv"}]}`),
+		)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		require.Equal(t, http.StatusUnsupportedMediaType, httpResp.StatusCode)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM kv`, [][]string{{"2"}})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, req := range []string{
+			`{}`,
+			`{"statements": [{"sql": "SELEC 1"}]}`,
+			`{"statements": [{"sql": "SELECT 1; SELECT 2"}]}`,
+			`{"statements": [{"sql": "SELECT $1"}]}`,
+			`{"statements": [{"sql": "SELECT $1", "arguments": [{"a": 1}]}]}`,
+			`{"timeout": "soon", "statements": [{"sql": "SELECT 1"}]}`,
+		} {
+			code, resp := post(t, true /* isAdmin */, req)
+			require.Equal(t, http.StatusBadRequest, code, req)
+			require.NotNil(t, resp.Error, req)
+		}
+	})
+}
@@ -0,0 +1,313 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// apiV2Do sends a request to the API v2 and decodes the response into resp.
+// If session is not empty, it is passed in the APIV2AuthHeader header.
+func apiV2Do(
+	t *testing.T,
+	client http.Client,
+	s serverutils.TestServerInterface,
+	method, path, session string,
+	form url.Values,
+	resp interface{},
+) int {
+	t.Helper()
+	req, err := http.NewRequest(method, s.AdminURL()+path, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if session != "" {
+		req.Header.Set(APIV2AuthHeader, session)
+	}
+	httpResp, err := client.Do(req)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(resp))
+	return httpResp.StatusCode
+}
+
+func TestAPIV2Auth(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+	sqlutils.MakeSQLRunner(db).Exec(t, `CREATE USER api_user WITH PASSWORD 'hunter2'`)
+
+	// A client without the session cookie.
+	client, err := s.GetHTTPClient()
+	require.NoError(t, err)
+
+	var errResp apiV2ErrorResponse
+	code := apiV2Do(t, client, s, http.MethodGet, apiV2NodesPath, "", nil, &errResp)
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Contains(t, errResp.Error.Message, "a session is required")
+
+	code = apiV2Do(t, client, s, http.MethodPost, apiV2LoginPath, "",
+		url.Values{"username": {"api_user"}, "password": {"wrong"}}, &errResp)
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	var loginResp apiV2LoginResponse
+	code = apiV2Do(t, client, s, http.MethodPost, apiV2LoginPath, "",
+		url.Values{"username": {"API_User"}, "password": {"hunter2"}}, &loginResp)
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, loginResp.Session)
+
+	var nodesResp apiV2NodesResponse
+	code = apiV2Do(t, client, s, http.MethodGet, apiV2NodesPath, loginResp.Session, nil, &nodesResp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, nodesResp.Nodes, 1)
+	require.Equal(t, s.NodeID(), nodesResp.Nodes[0].NodeID)
+	require.Equal(t, "LIVE", nodesResp.Nodes[0].LivenessStatus)
+
+	// The hot ranges require the admin role.
+	code = apiV2Do(t, client, s, http.MethodGet, apiV2HotRangesPath, loginResp.Session, nil, &errResp)
+	require.Equal(t, http.StatusForbidden, code)
+
+	// The SQL API runs statements as the user of the session.
+	var sqlResp sqlAPIResponse
+	req, err := http.NewRequest(http.MethodPost, s.AdminURL()+sqlAPIPath,
+		strings.NewReader(`{"statements": [{"sql": "SELECT current_user()"}]}`))
+	require.NoError(t, err)
+	req.Header.Set(APIV2AuthHeader, loginResp.Session)
+	httpResp, err := client.Do(req)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&sqlResp))
+	require.Equal(t, http.StatusOK, httpResp.StatusCode)
+	require.Equal(t, json.RawMessage(`"api_user"`), sqlResp.Results[0].Rows[0][0])
+
+	var logoutResp apiV2LogoutResponse
+	code = apiV2Do(t, client, s, http.MethodPost, apiV2LogoutPath, loginResp.Session, nil, &logoutResp)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, logoutResp.LoggedOut)
+
+	code = apiV2Do(t, client, s, http.MethodGet, apiV2NodesPath, loginResp.Session, nil, &errResp)
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Contains(t, errResp.Error.Message, "could not be validated")
+}
+
+func TestAPIV2Jobs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	for i := 0; i < 3; i++ {
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER TABLE t ADD COLUMN c%d INT DEFAULT 1`, i))
+	}
+	client, err := s.GetAdminAuthenticatedHTTPClient()
+	require.NoError(t, err)
+
+	// Page through the schema change jobs, most recent first.
+	var descriptions []string
+	for offset := 0; ; {
+		var resp apiV2JobsResponse
+		code := apiV2Do(t, client, s, http.MethodGet,
+			fmt.Sprintf("%s?type=schema_change&status=succeeded&limit=2&offset=%d", apiV2JobsPath, offset),
+			"", nil, &resp)
+		require.Equal(t, http.StatusOK, code)
+		require.LessOrEqual(t, len(resp.Jobs), 2)
+		for _, j := range resp.Jobs {
+			require.Equal(t, "SCHEMA CHANGE", j.Type)
+			descriptions = append(descriptions, j.Description)
+		}
+		if resp.Next == 0 {
+			break
+		}
+		require.Equal(t, offset+2, resp.Next)
+		offset = resp.Next
+	}
+	require.Len(t, descriptions, 3)
+	for i := range descriptions {
+		require.Contains(t, descriptions[i], fmt.Sprintf("ADD COLUMN c%d", 2-i))
+	}
+
+	for _, path := range []string{
+		apiV2JobsPath + "?type=unknown",
+		apiV2JobsPath + "?limit=0",
+		apiV2JobsPath + "?offset=-1",
+	} {
+		var errResp apiV2ErrorResponse
+		code := apiV2Do(t, client, s, http.MethodGet, path, "", nil, &errResp)
+		require.Equal(t, http.StatusBadRequest, code, path)
+	}
+}
+
+func TestAPIV2Sessions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, `SET application_name = 'api-v2-test'`)
+	require.NoError(t, err)
+
+	client, err := s.GetAdminAuthenticatedHTTPClient()
+	require.NoError(t, err)
+
+	var resp apiV2SessionsResponse
+	code := apiV2Do(t, client, s, http.MethodGet,
+		apiV2SessionsPath+"?application_name=api-v2-test", "", nil, &resp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Sessions, 1)
+	session := resp.Sessions[0]
+	require.Equal(t, s.NodeID(), session.NodeID)
+	require.Equal(t, "root", session.Username)
+
+	var cancelResp apiV2CancelSessionResponse
+	code = apiV2Do(t, client, s, http.MethodPost,
+		apiV2SessionsPath+session.ID+"/cancel/", "", nil, &cancelResp)
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, cancelResp.Canceled)
+
+	_, err = conn.ExecContext(ctx, `SELECT 1`)
+	require.Error(t, err)
+
+	var errResp apiV2ErrorResponse
+	code = apiV2Do(t, client, s, http.MethodPost,
+		apiV2SessionsPath+"invalid/cancel/", "", nil, &errResp)
+	require.Equal(t, http.StatusBadRequest, code)
+	code = apiV2Do(t, client, s, http.MethodGet,
+		apiV2SessionsPath+session.ID+"/cancel/", "", nil, &errResp)
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestAPIV2JobTimeline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `ALTER TABLE t ADD COLUMN c INT DEFAULT 1`)
+	var jobID int64
+	sqlDB.QueryRow(t, `SELECT job_id FROM crdb_internal.jobs
+WHERE description LIKE 'ALTER TABLE %ADD COLUMN c%'`).Scan(&jobID)
+
+	client, err := s.GetAdminAuthenticatedHTTPClient()
+	require.NoError(t, err)
+
+	var timeline jobTimeline
+	code := apiV2Do(t, client, s, http.MethodGet,
+		fmt.Sprintf("%s%d/timeline/", apiV2JobsPath, jobID), "", nil, &timeline)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, jobID, timeline.JobID)
+	require.Equal(t, "succeeded", timeline.Status)
+	require.GreaterOrEqual(t, len(timeline.Events), 2)
+	require.Equal(t, timeline.Created.UTC(), timeline.Events[0].Time.UTC())
+	last := timeline.Events[len(timeline.Events)-1]
+	require.Equal(t, "succeeded", last.Status)
+	require.Equal(t, "running", last.PreviousStatus)
+	for i := 1; i < len(timeline.Events); i++ {
+		require.False(t, timeline.Events[i].Time.Before(timeline.Events[i-1].Time))
+		require.Equal(t, timeline.Events[i-1].Status, timeline.Events[i].PreviousStatus)
+	}
+
+	for path, expCode := range map[string]int{
+		apiV2JobsPath + "123/timeline/":     http.StatusNotFound,
+		apiV2JobsPath + "invalid/timeline/": http.StatusBadRequest,
+		apiV2JobsPath + "123/unknown/":      http.StatusNotFound,
+	} {
+		var errResp apiV2ErrorResponse
+		code := apiV2Do(t, client, s, http.MethodGet, path, "", nil, &errResp)
+		require.Equal(t, expCode, code, path)
+	}
+}
+
+func TestAPIV2StmtDiagnostics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	// A completed request, with a bundle, followed by a pending one.
+	for _, fingerprint := range []string{
+		"INSERT INTO t VALUES (_)",
+		"SELECT k FROM t WHERE k = _",
+	} {
+		req := &serverpb.CreateStatementDiagnosticsReportRequest{StatementFingerprint: fingerprint}
+		var resp serverpb.CreateStatementDiagnosticsReportResponse
+		require.NoError(t, postStatusJSONProto(s, "stmtdiagreports", req, &resp))
+	}
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1)`)
+
+	client, err := s.GetAdminAuthenticatedHTTPClient()
+	require.NoError(t, err)
+
+	var resp apiV2StmtDiagnosticsResponse
+	code := apiV2Do(t, client, s, http.MethodGet, apiV2StmtDiagnosticsPath+"?limit=1", "", nil, &resp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Diagnostics, 1)
+	require.Equal(t, 1, resp.Next)
+	pending := resp.Diagnostics[0]
+	require.False(t, pending.Completed)
+	require.Equal(t, "SELECT k FROM t WHERE k = _", pending.StatementFingerprint)
+	require.Empty(t, pending.BundleURL)
+
+	code = apiV2Do(t, client, s, http.MethodGet,
+		fmt.Sprintf("%s?limit=1&offset=%d", apiV2StmtDiagnosticsPath, resp.Next), "", nil, &resp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Diagnostics, 1)
+	require.Zero(t, resp.Next)
+	completed := resp.Diagnostics[0]
+	require.True(t, completed.Completed)
+	require.Equal(t, "INSERT INTO t VALUES (_)", completed.StatementFingerprint)
+	require.NotNil(t, completed.CollectedAt)
+	require.Equal(t, fmt.Sprintf("%s%d", stmtBundlePathPrefix, completed.DiagnosticsID), completed.BundleURL)
+}
+
+func TestAPIV2PaginationPage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		limit, offset, n          int
+		expStart, expEnd, expNext int
+	}{
+		{limit: 2, offset: 0, n: 5, expStart: 0, expEnd: 2, expNext: 2},
+		{limit: 2, offset: 2, n: 5, expStart: 2, expEnd: 4, expNext: 4},
+		{limit: 2, offset: 4, n: 5, expStart: 4, expEnd: 5, expNext: 0},
+		{limit: 2, offset: 3, n: 5, expStart: 3, expEnd: 5, expNext: 0},
+		{limit: 2, offset: 7, n: 5, expStart: 5, expEnd: 5, expNext: 0},
+		{limit: 10, offset: 0, n: 0, expStart: 0, expEnd: 0, expNext: 0},
+	} {
+		start, end, next := apiV2Pagination{limit: tc.limit, offset: tc.offset}.page(tc.n)
+		require.Equal(t, []int{tc.expStart, tc.expEnd, tc.expNext}, []int{start, end, next}, "%+v", tc)
+	}
+}
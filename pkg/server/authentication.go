@@ -17,6 +17,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
@@ -399,9 +400,16 @@ func (s *authenticationServer) newAuthSession(
 
 	expiration := s.server.clock.PhysicalTime().Add(webSessionTimeout.Get(&s.server.st.SV))
 
+	// The address of the client is recorded as the audit info of the session,
+	// if it is known.
+	var auditInfo interface{}
+	if addr := webSessionClientAddr(ctx); addr != "" {
+		auditInfo = addr
+	}
+
 	insertSessionStmt := `
-INSERT INTO system.web_sessions ("hashedSecret", username, "expiresAt")
-VALUES($1, $2, $3)
+INSERT INTO system.web_sessions ("hashedSecret", username, "expiresAt", "auditInfo")
+VALUES($1, $2, $3, $4)
 RETURNING id
 `
 	var id int64
@@ -415,6 +423,7 @@ RETURNING id
 		hashedSecret,
 		username.Normalized(),
 		expiration,
+		auditInfo,
 	)
 	if err != nil {
 		return 0, nil, err
@@ -468,9 +477,36 @@ func newAuthenticationMux(s *authenticationServer, inner http.Handler) *authenti
 
 type webSessionUserKey struct{}
 type webSessionIDKey struct{}
+type webSessionClientAddrKey struct{}
 
 const webSessionUserKeyStr = "websessionuser"
 const webSessionIDKeyStr = "websessionid"
+const webSessionClientAddrKeyStr = "websessionclientaddr"
+
+// httpClientAddr returns the IP address of the client which sent req.
+func httpClientAddr(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// webSessionClientAddr returns the address of the client for which a web
+// session is being created, or an empty string if it isn't known. The address
+// is either set as a context value by HTTP handlers, or forwarded as gRPC
+// metadata by the gateway.
+func webSessionClientAddr(ctx context.Context) string {
+	if addr, ok := ctx.Value(webSessionClientAddrKey{}).(string); ok {
+		return addr
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if addrs := md.Get(webSessionClientAddrKeyStr); len(addrs) == 1 {
+			return addrs[0]
+		}
+	}
+	return ""
+}
 
 func (am *authenticationMux) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	username, cookie, err := am.getSession(w, req)
@@ -512,6 +548,9 @@ func makeCookieWithValue(value string, forHTTPSOnly bool) *http.Cookie {
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   forHTTPSOnly,
+		// Don't send the cookie with cross-site subrequests or POST requests,
+		// which other sites could forge on behalf of a logged in user.
+		SameSite: http.SameSiteLaxMode,
 	}
 }
 
@@ -578,7 +617,7 @@ func authenticationHeaderMatcher(key string) (string, bool) {
 	return fmt.Sprintf("%s%s", gwruntime.MetadataHeaderPrefix, key), true
 }
 
-func forwardAuthenticationMetadata(ctx context.Context, _ *http.Request) metadata.MD {
+func forwardAuthenticationMetadata(ctx context.Context, req *http.Request) metadata.MD {
 	md := metadata.MD{}
 	if user := ctx.Value(webSessionUserKey{}); user != nil {
 		md.Set(webSessionUserKeyStr, user.(string))
@@ -586,5 +625,6 @@ func forwardAuthenticationMetadata(ctx context.Context, _ *http.Request) metadat
 	if sessionID := ctx.Value(webSessionIDKey{}); sessionID != nil {
 		md.Set(webSessionIDKeyStr, fmt.Sprintf("%v", sessionID))
 	}
+	md.Set(webSessionClientAddrKeyStr, httpClientAddr(req))
 	return md
 }
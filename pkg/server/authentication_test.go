@@ -18,6 +18,7 @@ import (
 	gosql "database/sql"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
@@ -520,26 +521,35 @@ func TestAuthenticationAPIUserLogin(t *testing.T) {
 	if len(cookies) == 0 {
 		t.Fatalf("good login got no cookies: %v", response)
 	}
+	if a, e := cookies[0].SameSite, http.SameSiteLaxMode; a != e {
+		t.Fatalf("session cookie has SameSite %v, wanted %v", a, e)
+	}
 
 	sessionCookie, err := decodeSessionCookie(cookies[0])
 	if err != nil {
 		t.Fatalf("failed to decode session cookie: %s", err)
 	}
 
-	// Look up session in database and verify hashed secret value and username.
-	query := `SELECT "hashedSecret", "username" FROM system.web_sessions WHERE id = $1`
+	// Look up session in database and verify hashed secret value, username and
+	// client address.
+	query := `SELECT "hashedSecret", "username", "auditInfo" FROM system.web_sessions WHERE id = $1`
 	result := db.QueryRow(query, sessionCookie.ID)
 	var (
 		sessHashedSecret []byte
 		sessUsername     string
+		sessAuditInfo    gosql.NullString
 	)
-	if err := result.Scan(&sessHashedSecret, &sessUsername); err != nil {
+	if err := result.Scan(&sessHashedSecret, &sessUsername, &sessAuditInfo); err != nil {
 		t.Fatalf("error querying auth session: %s", err)
 	}
 
 	if a, e := sessUsername, validUsername; a != e {
 		t.Fatalf("created auth session had username %s, wanted %s", a, e)
 	}
+	if ip := net.ParseIP(sessAuditInfo.String); ip == nil || !ip.IsLoopback() {
+		t.Fatalf("created auth session had client address %q, wanted a loopback address",
+			sessAuditInfo.String)
+	}
 
 	hasher := sha256.New()
 	_, _ = hasher.Write(sessionCookie.Secret)
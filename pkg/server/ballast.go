@@ -0,0 +1,134 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors/oserror"
+	"github.com/elastic/gosigar"
+)
+
+// ballastMaintenanceInterval is the interval at which the ballast files of
+// the stores are re-established.
+const ballastMaintenanceInterval = time.Minute
+
+// storeBallastFile returns the path of the ballast file of the given
+// on-disk store.
+func storeBallastFile(spec base.StoreSpec) string {
+	return storage.BallastFile(filepath.Join(spec.Path, base.AuxiliaryDir))
+}
+
+// diskUsage returns the total and available bytes of the disk holding path.
+func diskUsage(path string) (total, avail int64, _ error) {
+	fsu := gosigar.FileSystemUsage{}
+	if err := fsu.Get(path); err != nil {
+		return 0, 0, err
+	}
+	return int64(fsu.Total), int64(fsu.Avail), nil
+}
+
+// maybeReleaseBallast removes the ballast file of the given on-disk store if
+// its disk is full or nearly full, so that the store can be opened and space
+// can be freed without operator intervention.
+func maybeReleaseBallast(ctx context.Context, spec base.StoreSpec) error {
+	path := storeBallastFile(spec)
+	if _, err := os.Stat(path); err != nil {
+		if oserror.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	total, avail, err := diskUsage(spec.Path)
+	if err != nil {
+		return err
+	}
+	released, err := storage.MaybeReleaseBallast(path, storage.BallastSizeBytes(spec, total), avail)
+	if err != nil {
+		return err
+	}
+	if released {
+		log.Ops.Warningf(ctx, "disk of store %s is nearly full (%s available); "+
+			"removed the emergency ballast file %s to free up space", spec.Path,
+			humanizeutil.IBytes(avail), path)
+	}
+	return nil
+}
+
+// establishBallast creates or resizes the ballast file of the given on-disk
+// store and returns its resulting size.
+func establishBallast(spec base.StoreSpec) (int64, error) {
+	total, avail, err := diskUsage(spec.Path)
+	if err != nil {
+		return 0, err
+	}
+	return storage.MaybeEstablishBallast(
+		storeBallastFile(spec), storage.BallastSizeBytes(spec, total), avail)
+}
+
+// startBallastMaintenance starts a goroutine that periodically re-establishes
+// the ballast files of the on-disk stores, so that a ballast released because
+// the disk filled up is re-created once enough space is available again. The
+// size of each ballast is recorded in the metrics of its store.
+func (s *Server) startBallastMaintenance(ctx context.Context) {
+	specs := s.cfg.Stores.Specs
+	if len(specs) != len(s.engines) {
+		return
+	}
+	s.stopper.RunWorker(ctx, func(ctx context.Context) {
+		t := timeutil.NewTimer()
+		defer t.Stop()
+		t.Reset(0)
+		// The last warning for each store, to avoid logging the same error
+		// every interval.
+		lastErr := make([]string, len(specs))
+		for {
+			select {
+			case <-t.C:
+				t.Read = true
+				t.Reset(ballastMaintenanceInterval)
+				for i, spec := range specs {
+					if spec.InMemory {
+						continue
+					}
+					size, err := establishBallast(spec)
+					if err != nil {
+						if msg := err.Error(); msg != lastErr[i] {
+							lastErr[i] = msg
+							log.Ops.Warningf(ctx, "unable to establish the emergency ballast file of store %s: %v",
+								spec.Path, err)
+						}
+						continue
+					}
+					lastErr[i] = ""
+					eng := s.engines[i]
+					_ = s.node.stores.VisitStores(func(store *kvserver.Store) error {
+						if store.Engine() == eng {
+							store.Metrics().BallastBytes.Update(size)
+						}
+						return nil
+					})
+				}
+			case <-s.stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
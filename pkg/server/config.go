@@ -427,10 +427,18 @@ func (cfg *Config) String() string {
 // Report logs an overview of the server configuration parameters via
 // the given context.
 func (cfg *Config) Report(ctx context.Context) {
-	if memSize, err := status.GetTotalMemory(ctx); err != nil {
+	if memInfo, warning, err := status.GetMemoryInfo(); err != nil {
 		log.Infof(ctx, "unable to retrieve system total memory: %v", err)
 	} else {
-		log.Infof(ctx, "system total memory: %s", humanizeutil.IBytes(memSize))
+		if warning != "" {
+			log.Infof(ctx, "%s", warning)
+		}
+		log.Infof(ctx, "system total memory: %s", humanizeutil.IBytes(memInfo.SystemTotal))
+		if memInfo.CgroupLimit > 0 {
+			log.Infof(ctx, "cgroup memory limit: %s; percentage-based memory flags "+
+				"(--cache, --max-sql-memory) are computed against this limit",
+				humanizeutil.IBytes(memInfo.CgroupLimit))
+		}
 	}
 	log.Infof(ctx, "server configuration:\n%s", cfg)
 }
@@ -548,6 +556,9 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			if len(spec.RocksDBOptions) > 0 {
 				return nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
 			}
+			if err := maybeReleaseBallast(ctx, spec); err != nil {
+				return Engines{}, errors.Wrapf(err, "store %d: checking emergency ballast", i)
+			}
 			eng, err := storage.NewPebble(ctx, pebbleConfig)
 			if err != nil {
 				return Engines{}, err
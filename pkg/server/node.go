@@ -169,6 +169,10 @@ type Node struct {
 	additionalStoreInitCh chan struct{}
 
 	perReplicaServer kvserver.Server
+
+	// diskStalls tracks the directories on which a disk stall is currently
+	// detected. See startAssertEngineHealth.
+	diskStalls diskStalls
 }
 
 var _ roachpb.InternalServer = &Node{}
@@ -12,23 +12,45 @@ package server
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 )
 
+// diskProbeFilename is the name of the file that is written and synced in
+// each log directory to verify that the directory is writable.
+const diskProbeFilename = "cockroach-disk-probe"
+
+// diskStalls tracks the directories whose disk probe has not completed
+// within storage.max_sync_duration. While any directory is stalled, the
+// node reports itself as not ready in health checks.
+type diskStalls struct {
+	syncutil.Mutex
+	// stalled maps the stalled directories to the time at which their
+	// probe was started.
+	stalled map[string]time.Time
+}
+
 // startAssertEngineHealth starts a goroutine that periodically verifies that
-// syncing the engines is possible within maxSyncDuration. If not,
-// the process is terminated (with an attempt at a descriptive message).
+// syncing the engines and writing to the log directories is possible within
+// storage.max_sync_duration. If not, a disk_stall_detected event is logged and
+// the node fails health checks until the write completes. If
+// storage.max_sync_duration.fatal.enabled is set, the process is terminated
+// instead (with an attempt at a descriptive message).
 func (n *Node) startAssertEngineHealth(
 	ctx context.Context, engines []storage.Engine, settings *cluster.Settings,
 ) {
-	maxSyncDuration := storage.MaxSyncDuration.Get(&settings.SV)
-	fatalOnExceeded := storage.MaxSyncDurationFatalOnExceeded.Get(&settings.SV)
 	n.stopper.RunWorker(ctx, func(ctx context.Context) {
 		t := timeutil.NewTimer()
 		t.Reset(0)
@@ -38,7 +60,10 @@ func (n *Node) startAssertEngineHealth(
 			case <-t.C:
 				t.Read = true
 				t.Reset(10 * time.Second)
+				maxSyncDuration := storage.MaxSyncDuration.Get(&settings.SV)
+				fatalOnExceeded := storage.MaxSyncDurationFatalOnExceeded.Get(&settings.SV)
 				n.assertEngineHealth(ctx, engines, maxSyncDuration, fatalOnExceeded)
+				n.assertLogDirHealth(ctx, log.GetLogDirs(), maxSyncDuration, fatalOnExceeded)
 			case <-n.stopper.ShouldQuiesce():
 				return
 			}
@@ -55,23 +80,135 @@ func (n *Node) assertEngineHealth(
 	ctx context.Context, engines []storage.Engine, maxDuration time.Duration, fatalOnExceeded bool,
 ) {
 	for _, eng := range engines {
-		func() {
-			t := time.AfterFunc(maxDuration, func() {
-				n.metrics.DiskStalls.Inc(1)
-				stats := "\n" + eng.GetCompactionStats()
-				logger := log.Warningf
-				if fatalOnExceeded {
-					logger = guaranteedExitFatal
-				}
-				// NB: the disk-stall-detected roachtest matches on this message.
-				logger(ctx, "disk stall detected: unable to write to %s within %s %s",
-					eng, storage.MaxSyncDuration, stats,
-				)
-			})
-			defer t.Stop()
-			if err := storage.WriteSyncNoop(ctx, eng); err != nil {
-				log.Fatalf(ctx, "%v", err)
-			}
-		}()
+		eng := eng
+		if err := n.probeDisk(ctx, eng.String(), maxDuration, fatalOnExceeded,
+			func() string { return "\n" + eng.GetCompactionStats() },
+			func() error { return storage.WriteSyncNoop(ctx, eng) },
+		); err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+	}
+}
+
+// assertLogDirHealth verifies that a file can be written and synced in each
+// of the given log directories within maxDuration.
+func (n *Node) assertLogDirHealth(
+	ctx context.Context, logDirs []string, maxDuration time.Duration, fatalOnExceeded bool,
+) {
+	for _, dir := range logDirs {
+		dir := dir
+		if err := n.probeDisk(ctx, dir, maxDuration, fatalOnExceeded,
+			func() string { return "" },
+			func() error { return writeDiskProbe(dir) },
+		); err != nil {
+			log.Warningf(ctx, "unable to write to log directory %s: %v", dir, err)
+		}
+	}
+}
+
+// probeDisk runs the given probe of the directory at path and returns its
+// error. If the probe does not complete within maxDuration, the stall is
+// recorded and either logged or, if fatalOnExceeded is set, the process is
+// terminated.
+func (n *Node) probeDisk(
+	ctx context.Context,
+	path string,
+	maxDuration time.Duration,
+	fatalOnExceeded bool,
+	stats func() string,
+	probe func() error,
+) error {
+	start := timeutil.Now()
+	// The stall callback and the completion of the probe both run under the
+	// lock of n.diskStalls, so that a stall is only recorded while the probe is
+	// still running and is always cleared once it completes, even if the timer
+	// fires concurrently with the completion.
+	var done, stalled bool
+	t := time.AfterFunc(maxDuration, func() {
+		n.diskStalls.Lock()
+		if done {
+			n.diskStalls.Unlock()
+			return
+		}
+		stalled = true
+		n.metrics.DiskStalls.Inc(1)
+		if n.diskStalls.stalled == nil {
+			n.diskStalls.stalled = make(map[string]time.Time)
+		}
+		n.diskStalls.stalled[path] = start
+		n.diskStalls.Unlock()
+		n.recordDiskStallEvent(ctx, path, maxDuration, fatalOnExceeded)
+		logger := log.Warningf
+		if fatalOnExceeded {
+			logger = guaranteedExitFatal
+		}
+		// NB: the disk-stall-detected roachtest matches on this message.
+		logger(ctx, "disk stall detected: unable to write to %s within %s %s",
+			path, storage.MaxSyncDuration, stats(),
+		)
+	})
+	err := probe()
+	t.Stop()
+	n.diskStalls.Lock()
+	done = true
+	resolved := stalled
+	if resolved {
+		delete(n.diskStalls.stalled, path)
+	}
+	n.diskStalls.Unlock()
+	if resolved {
+		log.Warningf(ctx, "disk stall on %s resolved after %s", path, timeutil.Since(start))
+	}
+	return err
+}
+
+// recordDiskStallEvent logs a disk_stall_detected event. The event is not
+// written to system.eventlog, since the stalled disk may hold a replica of
+// the eventlog range or the write may otherwise be unable to complete.
+func (n *Node) recordDiskStallEvent(
+	ctx context.Context, path string, maxDuration time.Duration, fatal bool,
+) {
+	ev := &eventpb.DiskStallDetected{
+		Path:           path,
+		ThresholdNanos: maxDuration.Nanoseconds(),
+		Fatal:          fatal,
+	}
+	ev.CommonDetails().Timestamp = timeutil.Now().UnixNano()
+	ev.NodeID = int32(n.Descriptor.NodeID)
+	ev.StartedAt = n.startedAt
+	log.StructuredEvent(ctx, ev)
+}
+
+// diskStallError returns an error describing the directories whose disk
+// probe is currently stalled, or nil if there are none.
+func (n *Node) diskStallError() error {
+	n.diskStalls.Lock()
+	defer n.diskStalls.Unlock()
+	if len(n.diskStalls.stalled) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(n.diskStalls.stalled))
+	for path := range n.diskStalls.stalled {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return errors.Newf("disk stall detected on %s", strings.Join(paths, ", "))
+}
+
+// writeDiskProbe writes and syncs a small file in the given directory.
+func writeDiskProbe(dir string) error {
+	f, err := os.OpenFile(
+		filepath.Join(dir, diskProbeFilename), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(timeutil.Now().String()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
 	}
+	return f.Close()
 }
@@ -0,0 +1,93 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeDiskStall(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	n := &Node{metrics: makeNodeMetrics(metric.NewRegistry(), time.Minute)}
+	require.NoError(t, n.diskStallError())
+
+	// A probe that completes in time does not report a stall.
+	require.NoError(t, n.probeDisk(ctx, "fast", time.Minute, false, /* fatalOnExceeded */
+		func() string { return "" }, func() error { return nil }))
+	require.NoError(t, n.diskStallError())
+	require.Equal(t, int64(0), n.metrics.DiskStalls.Count())
+
+	// A stalled probe fails health checks until it completes.
+	unblock := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- n.probeDisk(ctx, "slow", time.Millisecond, false, /* fatalOnExceeded */
+			func() string { return "" },
+			func() error {
+				<-unblock
+				return errors.New("boom")
+			})
+	}()
+	testutils.SucceedsSoon(t, func() error {
+		if err := n.diskStallError(); err == nil {
+			return errors.New("disk stall not detected yet")
+		}
+		return nil
+	})
+	require.EqualError(t, n.diskStallError(), "disk stall detected on slow")
+	require.Equal(t, int64(1), n.metrics.DiskStalls.Count())
+
+	close(unblock)
+	require.EqualError(t, <-errCh, "boom")
+	require.NoError(t, n.diskStallError())
+}
+
+func TestProbeDiskStallRace(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	n := &Node{metrics: makeNodeMetrics(metric.NewRegistry(), time.Minute)}
+
+	// Probes which complete around the time the stall timer fires never leave
+	// a stall behind once they return.
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, n.probeDisk(ctx, "racy", time.Microsecond, false, /* fatalOnExceeded */
+			func() string { return "" },
+			func() error {
+				time.Sleep(time.Microsecond)
+				return nil
+			}))
+		require.NoError(t, n.diskStallError())
+	}
+}
+
+func TestWriteDiskProbe(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	require.NoError(t, writeDiskProbe(dir))
+	require.Error(t, writeDiskProbe(dir+"/missing"))
+}
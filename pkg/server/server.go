@@ -80,6 +80,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/sysutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -104,6 +105,14 @@ var (
 		false,
 	).WithPublic()
 
+	tzdataDirectory = settings.RegisterStringSetting(
+		"server.tzdata.directory",
+		"if nonempty, time zones are loaded from the time zone database in this directory "+
+			"(for example /usr/share/zoneinfo) in preference to the default lookup, which "+
+			"falls back to the database embedded in the binary",
+		"",
+	)
+
 	persistHLCUpperBoundInterval = settings.RegisterDurationSetting(
 		"server.clock.persist_upper_bound_interval",
 		"the interval between persisting the wall time upper bound of the clock. The clock "+
@@ -178,6 +187,10 @@ type externalStorageBuilder struct {
 	initCalled        bool
 	ie                *sql.InternalExecutor
 	db                *kv.DB
+	// httpMetrics records the requests made by HTTP external storage created
+	// by this builder. It is set at construction time, so that it can be
+	// added to the server's metric registry before init is called.
+	httpMetrics *cloudimpl.HTTPMetrics
 }
 
 func (e *externalStorageBuilder) init(
@@ -202,7 +215,7 @@ func (e *externalStorageBuilder) makeExternalStorage(
 		return nil, errors.New("cannot create external storage before init")
 	}
 	return cloudimpl.MakeExternalStorage(ctx, dest, e.conf, e.settings, e.blobClientFactory, e.ie,
-		e.db)
+		e.db, e.httpMetrics)
 }
 
 func (e *externalStorageBuilder) makeExternalStorageFromURI(
@@ -211,7 +224,7 @@ func (e *externalStorageBuilder) makeExternalStorageFromURI(
 	if !e.initCalled {
 		return nil, errors.New("cannot create external storage before init")
 	}
-	return cloudimpl.ExternalStorageFromURI(ctx, uri, e.conf, e.settings, e.blobClientFactory, user, e.ie, e.db)
+	return cloudimpl.ExternalStorageFromURI(ctx, uri, e.conf, e.settings, e.blobClientFactory, user, e.ie, e.db, e.httpMetrics)
 }
 
 // NewServer creates a Server from a server.Config.
@@ -472,7 +485,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 
 	// Create an ExternalStorageBuilder. This is only usable after Start() where
 	// we initialize all the configuration params.
-	externalStorageBuilder := &externalStorageBuilder{}
+	externalStorageBuilder := &externalStorageBuilder{httpMetrics: cloudimpl.MakeHTTPMetrics()}
+	registry.AddMetricStruct(externalStorageBuilder.httpMetrics)
 	externalStorage := func(ctx context.Context, dest roachpb.ExternalStorage) (cloud.
 		ExternalStorage, error) {
 		return externalStorageBuilder.makeExternalStorage(ctx, dest)
@@ -613,6 +627,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 	sqlServer, err := newSQLServer(ctx, sqlServerArgs{
 		sqlServerOptionalKVArgs: sqlServerOptionalKVArgs{
 			nodesStatusServer:      serverpb.MakeOptionalNodesStatusServer(sStatus),
+			tsServer:               &sTS,
 			nodeLiveness:           optionalnodeliveness.MakeContainer(nodeLiveness),
 			gossip:                 gossip.MakeOptionalGossip(g),
 			grpcServer:             grpcServer.Server,
@@ -855,6 +870,40 @@ func (s *Server) startMonitoringForwardClockJumps(ctx context.Context) error {
 	return nil
 }
 
+// startTZDataReloading applies the server.tzdata.directory cluster setting and
+// starts a background task reloading the time zone database when the setting
+// changes or the process receives a refresh signal (SIGHUP). The time zone
+// database in use is logged every time it is loaded, since nodes using
+// different releases of it can disagree on the result of time zone
+// conversions.
+func (s *Server) startTZDataReloading(ctx context.Context) error {
+	logTZData := func(ctx context.Context) {
+		source, version := timeutil.TZDataSource()
+		log.Ops.Infof(ctx, "time zone database: %s, version %s", source, version)
+	}
+	timeutil.SetTZDataDir(tzdataDirectory.Get(&s.st.SV))
+	logTZData(ctx)
+
+	tzdataDirectory.SetOnChange(&s.st.SV, func() {
+		timeutil.SetTZDataDir(tzdataDirectory.Get(&s.st.SV))
+		logTZData(context.Background())
+	})
+
+	return s.stopper.RunAsyncTask(ctx, "tzdata-reloader", func(ctx context.Context) {
+		ch := sysutil.RefreshSignaledChan()
+		for {
+			select {
+			case <-s.stopper.ShouldQuiesce():
+				return
+			case sig := <-ch:
+				log.Ops.Infof(ctx, "received signal %q, reloading the time zone database", sig)
+				timeutil.ReloadTZData()
+				logTZData(ctx)
+			}
+		}
+	})
+}
+
 // ensureClockMonotonicity sleeps till the wall time reaches
 // prevHLCUpperBound. prevHLCUpperBound > 0 implies we need to guarantee HLC
 // monotonicity across server restarts. prevHLCUpperBound is the last
@@ -1089,6 +1138,10 @@ func (s *Server) PreStart(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.startTZDataReloading(ctx); err != nil {
+		return err
+	}
+
 	// Connect the node as loopback handler for RPC requests to the
 	// local node.
 	s.rpcContext.SetLocalInternalServer(s.node)
@@ -1205,12 +1258,12 @@ func (s *Server) PreStart(ctx context.Context) error {
 	serverpb.RegisterMigrationServer(s.grpc.Server, migrationServer)
 	s.migrationServer = migrationServer // only for testing via TestServer
 
-	// Pebble does its own engine health checks, that call back into an event
+	// Start probing the disks of the stores and log directories. Pebble
+	// also does its own engine health checks, that call back into an event
 	// handler registered in storage/pebble.go when a slow disk event is
-	// detected. Starting a separate routine for Pebble is unnecessary.
-	if s.engines[0].Type() != enginepb.EngineTypePebble {
-		s.node.startAssertEngineHealth(ctx, s.engines, s.cfg.Settings)
-	}
+	// detected, but the probes are needed to report disk stalls in health
+	// checks.
+	s.node.startAssertEngineHealth(ctx, s.engines, s.cfg.Settings)
 
 	// Start the RPC server. This opens the RPC/SQL listen socket,
 	// and dispatches the server worker for the RPC.
@@ -1533,6 +1586,7 @@ func (s *Server) PreStart(ctx context.Context) error {
 		return err
 	}
 	s.replicationReporter.Start(ctx, s.stopper)
+	s.startBallastMaintenance(ctx)
 
 	sentry.ConfigureScope(func(scope *sentry.Scope) {
 		scope.SetTags(map[string]string{
@@ -1717,6 +1771,10 @@ func (s *Server) PreStart(ctx context.Context) error {
 	}
 	s.mux.Handle(debug.Endpoint, debugHandler)
 
+	// Register the API v2 endpoints, including the SQL API. Requests run as
+	// the user of the session, or as root if web sessions aren't required.
+	s.mux.Handle(apiV2Path, newAPIV2Server(s))
+
 	log.Event(ctx, "added http endpoints")
 
 	// Record node start in telemetry. Get the right counter for this storage
@@ -1847,7 +1905,7 @@ func (s *Server) startListenRPCAndSQL(
 
 	anyL := m.Match(cmux.Any())
 	if serverTestKnobs, ok := s.cfg.TestingKnobs.Server.(*TestingKnobs); ok {
-		if serverTestKnobs.ContextTestingKnobs.ArtificialLatencyMap != nil {
+		if serverTestKnobs.ContextTestingKnobs.ArtificialLatencyFn != nil {
 			anyL = rpc.NewDelayingListener(anyL)
 		}
 	}
@@ -1960,6 +2018,7 @@ func (s *sqlServer) startServeSQL(
 	tcpKeepAlive := tcpKeepAliveManager{
 		tcpKeepAlive: envutil.EnvOrDefaultDuration("COCKROACH_SQL_TCP_KEEP_ALIVE", time.Minute),
 	}
+	s.pgServer.SetTCPKeepAlive(tcpKeepAlive.tcpKeepAlive)
 
 	stopper.RunWorker(pgCtx, func(pgCtx context.Context) {
 		netutil.FatalIfUnexpected(connManager.ServeWith(pgCtx, stopper, pgL, func(conn net.Conn) {
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/diagnosticspb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
@@ -51,6 +52,8 @@ func MakeOptionalNodesStatusServer(s NodesStatusServer) OptionalNodesStatusServe
 // by the SQL subsystem but is unavailable to tenants.
 type NodesStatusServer interface {
 	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	Ranges(context.Context, *RangesRequest) (*RangesResponse, error)
+	Diagnostics(context.Context, *DiagnosticsRequest) (*diagnosticspb.DiagnosticReport, error)
 }
 
 // OptionalNodesStatusServer returns the wrapped NodesStatusServer, if it is
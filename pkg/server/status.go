@@ -64,6 +64,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
 	"github.com/cockroachdb/errors"
+	"github.com/google/pprof/profile"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"go.etcd.io/etcd/raft/v3"
 	"google.golang.org/grpc"
@@ -184,6 +185,9 @@ func (b *baseStatusServer) getLocalSessions(
 		if reqUsername.Normalized() != session.Username && !showAll {
 			continue
 		}
+		if req.ApplicationName != "" && req.ApplicationName != session.ApplicationName {
+			continue
+		}
 
 		userSessions = append(userSessions, session)
 	}
@@ -1222,11 +1226,67 @@ func (s *statusServer) Profile(
 			return nil, err
 		}
 		return &serverpb.JSONResponse{Data: buf.Bytes()}, nil
+	case serverpb.ProfileRequest_MUTEX:
+		duration := 30 * time.Second
+		if req.Seconds != 0 {
+			duration = time.Duration(req.Seconds) * time.Second
+		}
+		data, err := mutexProfileDelta(ctx, duration)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+		return &serverpb.JSONResponse{Data: data}, nil
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown profile: %s", req.Type)
 	}
 }
 
+// mutexProfileDelta returns a mutex profile of the contention recorded
+// during the given duration. The runtime only maintains a cumulative
+// mutex profile since the start of the process, so the profile
+// collected at the start of the interval is subtracted from the one
+// collected at the end.
+func mutexProfileDelta(ctx context.Context, duration time.Duration) ([]byte, error) {
+	p := pprof.Lookup("mutex")
+	if p == nil {
+		return nil, errors.New("unable to find profile: mutex")
+	}
+	collect := func() (*profile.Profile, error) {
+		var buf bytes.Buffer
+		if err := p.WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+		return profile.Parse(&buf)
+	}
+
+	p0, err := collect()
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(duration):
+	}
+	p1, err := collect()
+	if err != nil {
+		return nil, err
+	}
+
+	p0.Scale(-1)
+	delta, err := profile.Merge([]*profile.Profile{p0, p1})
+	if err != nil {
+		return nil, err
+	}
+	delta.TimeNanos = p1.TimeNanos
+	delta.DurationNanos = p1.TimeNanos - p0.TimeNanos
+	var buf bytes.Buffer
+	if err := delta.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Nodes returns all node statuses.
 //
 // The LivenessByNodeID in the response returns the known liveness
@@ -632,6 +632,37 @@ func GetTotalMemory(ctx context.Context) (int64, error) {
 // GetTotalMemoryWithoutLogging is the same as GetTotalMemory, but returns any warning
 // as a string instead of logging it.
 func GetTotalMemoryWithoutLogging() (int64, string, error) {
+	info, warning, err := GetMemoryInfo()
+	if err != nil {
+		return 0, warning, err
+	}
+	return info.Available(), warning, nil
+}
+
+// MemoryInfo describes the memory available to the process.
+type MemoryInfo struct {
+	// SystemTotal is the total memory of the system, in bytes.
+	SystemTotal int64
+	// CgroupLimit is the memory limit of the cgroup of the process, in bytes.
+	// It is zero if the process is not constrained by a cgroup limit lower
+	// than the system memory, or if the limit could not be determined.
+	CgroupLimit int64
+}
+
+// Available returns the memory available to the process, which is the basis
+// of percentage-based memory flags such as --cache and --max-sql-memory: the
+// cgroup memory limit if there is one, and the total system memory otherwise.
+func (m MemoryInfo) Available() int64 {
+	if m.CgroupLimit > 0 {
+		return m.CgroupLimit
+	}
+	return m.SystemTotal
+}
+
+// GetMemoryInfo returns the total system memory and the cgroup memory limit
+// of the process. Any warning encountered while determining the cgroup
+// memory limit is returned as a string.
+func GetMemoryInfo() (MemoryInfo, string, error) {
 	totalMem, err := func() (int64, error) {
 		mem := gosigar.Mem{}
 		if err := mem.Get(); err != nil {
@@ -644,28 +675,30 @@ func GetTotalMemoryWithoutLogging() (int64, string, error) {
 		return int64(mem.Total), nil
 	}()
 	if err != nil {
-		return 0, "", err
+		return MemoryInfo{}, "", err
 	}
-	checkTotal := func(x int64, warning string) (int64, string, error) {
-		if x <= 0 {
+	checkTotal := func(info MemoryInfo, warning string) (MemoryInfo, string, error) {
+		if x := info.Available(); x <= 0 {
 			// https://github.com/elastic/gosigar/issues/72
-			return 0, warning, fmt.Errorf("inferred memory size %d is suspicious, considering invalid", x)
+			return MemoryInfo{}, warning, fmt.Errorf("inferred memory size %d is suspicious, considering invalid", x)
 		}
-		return x, warning, nil
+		return info, warning, nil
 	}
+	info := MemoryInfo{SystemTotal: totalMem}
 	if runtime.GOOS != "linux" {
-		return checkTotal(totalMem, "")
+		return checkTotal(info, "")
 	}
 	cgAvlMem, warning, err := cgroups.GetMemoryLimit()
 	if err != nil {
-		return checkTotal(totalMem,
+		return checkTotal(info,
 			fmt.Sprintf("available memory from cgroups is unsupported, using system memory %s instead: %v",
 				humanizeutil.IBytes(totalMem), err))
 	}
 	if cgAvlMem == 0 || (totalMem > 0 && cgAvlMem > totalMem) {
-		return checkTotal(totalMem,
+		return checkTotal(info,
 			fmt.Sprintf("available memory from cgroups (%s) is unsupported, using system memory %s instead: %s",
 				humanize.IBytes(uint64(cgAvlMem)), humanizeutil.IBytes(totalMem), warning))
 	}
-	return checkTotal(cgAvlMem, "")
+	info.CgroupLimit = cgAvlMem
+	return checkTotal(info, "")
 }
@@ -0,0 +1,193 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stmtBundlePathPrefix is the path of the endpoint serving statement bundles,
+// followed by the ID of the statement diagnostics. See adminServer.
+const stmtBundlePathPrefix = "/_admin/v1/stmtbundle/"
+
+// stmtDiagnosticsHistoryEntry is a statement diagnostics request, along with
+// the diagnostics collected for it once it has completed.
+type stmtDiagnosticsHistoryEntry struct {
+	RequestID            int64     `json:"request_id"`
+	StatementFingerprint string    `json:"statement_fingerprint"`
+	RequestedAt          time.Time `json:"requested_at"`
+	Completed            bool      `json:"completed"`
+
+	// The fields below are set once the request has completed.
+	DiagnosticsID int64      `json:"diagnostics_id,omitempty"`
+	Statement     string     `json:"statement,omitempty"`
+	CollectedAt   *time.Time `json:"collected_at,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	// BundleURL is the path of the statement bundle, if one was collected.
+	BundleURL string `json:"bundle_url,omitempty"`
+}
+
+// statementDiagnosticsHistory returns the statement diagnostics requests,
+// most recent first, along with the diagnostics collected for them. At most
+// limit entries are returned.
+func (s *statusServer) statementDiagnosticsHistory(
+	ctx context.Context, limit int,
+) ([]stmtDiagnosticsHistoryEntry, error) {
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if _, err := s.privilegeChecker.requireViewActivityPermission(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.internalExecutor.QueryEx(ctx, "stmt-diag-history", nil, /* txn */
+		sessiondata.InternalExecutorOverride{
+			User: security.RootUserName(),
+		},
+		`SELECT
+			r.id,
+			r.statement_fingerprint,
+			r.requested_at,
+			r.completed,
+			d.id,
+			d.statement,
+			d.collected_at,
+			d.error,
+			COALESCE(array_length(d.bundle_chunks, 1), 0)
+		FROM
+			system.statement_diagnostics_requests AS r
+			LEFT JOIN system.statement_diagnostics AS d ON d.id = r.statement_diagnostics_id
+		ORDER BY
+			r.requested_at DESC, r.id DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]stmtDiagnosticsHistoryEntry, len(rows))
+	for i, row := range rows {
+		e := &history[i]
+		e.RequestID = int64(tree.MustBeDInt(row[0]))
+		e.StatementFingerprint = string(tree.MustBeDString(row[1]))
+		e.RequestedAt = tree.MustBeDTimestampTZ(row[2]).Time
+		e.Completed = bool(tree.MustBeDBool(row[3]))
+		if row[4] == tree.DNull {
+			continue
+		}
+		e.DiagnosticsID = int64(tree.MustBeDInt(row[4]))
+		e.Statement = string(tree.MustBeDString(row[5]))
+		collectedAt := tree.MustBeDTimestampTZ(row[6]).Time
+		e.CollectedAt = &collectedAt
+		if row[7] != tree.DNull {
+			e.Error = string(tree.MustBeDString(row[7]))
+		}
+		if tree.MustBeDInt(row[8]) > 0 {
+			e.BundleURL = fmt.Sprintf("%s%d", stmtBundlePathPrefix, e.DiagnosticsID)
+		}
+	}
+	return history, nil
+}
+
+// jobTimeline describes the execution of a job over time.
+type jobTimeline struct {
+	JobID       int64      `json:"job_id"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Created     *time.Time `json:"created,omitempty"`
+	Started     *time.Time `json:"started,omitempty"`
+	Finished    *time.Time `json:"finished,omitempty"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	NumRuns     int64      `json:"num_runs"`
+	// Events are the changes of the status of the job, oldest first. The
+	// first event is the creation of the job.
+	Events []jobTimelineEvent `json:"events"`
+}
+
+// jobTimelineEvent is a change of the status of a job.
+type jobTimelineEvent struct {
+	Time           time.Time `json:"time"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+}
+
+// jobTimeline returns the timeline of the given job. Like SHOW JOBS, it only
+// returns the jobs of other users to admins.
+func (s *statusServer) jobTimeline(ctx context.Context, jobID int64) (*jobTimeline, error) {
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	userName, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the job as the user, so that crdb_internal.jobs filters out the
+	// jobs the user isn't allowed to see.
+	rows, cols, err := s.internalExecutor.QueryWithCols(ctx, "job-timeline", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: userName},
+		`SELECT job_type, description, status, created, started, finished, last_run,
+		        COALESCE(num_runs, 0)
+		   FROM crdb_internal.jobs
+		  WHERE job_id = $1`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, status.Errorf(codes.NotFound, "job %d not found", jobID)
+	}
+
+	t := &jobTimeline{JobID: jobID}
+	scanner := makeResultScanner(cols)
+	if err := scanner.ScanAll(
+		rows[0],
+		&t.Type,
+		&t.Description,
+		&t.Status,
+		&t.Created,
+		&t.Started,
+		&t.Finished,
+		&t.LastRun,
+		&t.NumRuns,
+	); err != nil {
+		return nil, err
+	}
+
+	transitions, err := jobs.StatusTransitions(ctx, s.internalExecutor, s.st, jobID)
+	if err != nil {
+		return nil, err
+	}
+	t.Events = make([]jobTimelineEvent, 0, len(transitions)+1)
+	if t.Created != nil {
+		initialStatus := t.Status
+		if len(transitions) > 0 {
+			initialStatus = string(transitions[0].From)
+		}
+		t.Events = append(t.Events, jobTimelineEvent{Time: *t.Created, Status: initialStatus})
+	}
+	for _, tr := range transitions {
+		t.Events = append(t.Events, jobTimelineEvent{
+			Time:           tr.Time,
+			Status:         string(tr.To),
+			PreviousStatus: string(tr.From),
+		})
+	}
+	return t, nil
+}
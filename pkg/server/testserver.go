@@ -53,6 +53,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sqlmigrations"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloudimpl"
 	"github.com/cockroachdb/cockroach/pkg/ts"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -526,7 +527,8 @@ func makeSQLServerArgs(
 	runtime := status.NewRuntimeStatSampler(context.Background(), clock)
 	registry.AddMetricStruct(runtime)
 
-	esb := &externalStorageBuilder{}
+	esb := &externalStorageBuilder{httpMetrics: cloudimpl.MakeHTTPMetrics()}
+	registry.AddMetricStruct(esb.httpMetrics)
 	externalStorage := func(ctx context.Context, dest roachpb.ExternalStorage) (cloud.
 		ExternalStorage, error) {
 		return esb.makeExternalStorage(ctx, dest)
@@ -252,6 +252,25 @@ func TestClusterVersionUpgrade(t *testing.T) {
 		t.Fatalf("cluster version should still be %s, but get %s", oldVersion, curVersion)
 	}
 
+	// The upgrade can't be finalized manually either, and all of its steps are
+	// reported as blocked.
+	if _, err := tc.ServerConn(0).Exec(
+		"SET CLUSTER SETTING version = $1", newVersion.String(),
+	); !testutils.IsError(err, "cluster.preserve_downgrade_option is set to") {
+		t.Fatalf("expected the upgrade to be blocked, got %v", err)
+	}
+	var steps, blocked int
+	if err := tc.ServerConn(0).QueryRow(`
+SELECT count(*), count(*) FILTER (WHERE status = 'blocked')
+  FROM [SHOW CLUSTER UPGRADE STATUS]
+ WHERE component = 'migration'`,
+	).Scan(&steps, &blocked); err != nil {
+		t.Fatal(err)
+	}
+	if steps == 0 || blocked != steps {
+		t.Fatalf("expected all upgrade steps to be blocked, got %d of %d", blocked, steps)
+	}
+
 	// Reset cluster.preserve_downgrade_option to enable auto upgrade.
 	if err := tc.resetDowngrade(0); err != nil {
 		t.Fatalf("error resetting CLUSTER SETTING cluster.preserve_downgrade_option: %s", err)
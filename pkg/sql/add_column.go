@@ -11,6 +11,7 @@
 package sql
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -66,17 +67,38 @@ func (p *planner) addColumnImpl(
 	}
 	d = newDef
 
+	if d.IsVirtual() {
+		if err := checkAddVirtualColumn(params, d); err != nil {
+			return err
+		}
+	}
+	if d.HasOnUpdateExpr() && !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.OnUpdateExpressions) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use ON UPDATE",
+			clusterversion.OnUpdateExpressions)
+	}
+
 	col, idx, expr, err := tabledesc.MakeColumnDefDescs(params.ctx, d, &params.p.semaCtx, params.EvalContext())
 	if err != nil {
 		return err
 	}
 	incTelemetryForNewColumn(d, col)
 
-	// If the new column has a DEFAULT expression that uses a sequence, add references between
-	// its descriptor and this column descriptor.
+	// If the new column has a DEFAULT or ON UPDATE expression that uses a
+	// sequence, add references between its descriptor and this column
+	// descriptor.
+	var seqExprs []tree.TypedExpr
 	if d.HasDefaultExpr() {
+		seqExprs = append(seqExprs, expr)
+	}
+	if d.HasOnUpdateExpr() {
+		// MakeColumnDefDescs replaced the ON UPDATE expression with its
+		// type-checked form.
+		seqExprs = append(seqExprs, d.OnUpdateExpr.Expr.(tree.TypedExpr))
+	}
+	for _, seqExpr := range seqExprs {
 		changedSeqDescs, err := maybeAddSequenceDependencies(
-			params.ctx, params.p, n.tableDesc, col, expr, nil,
+			params.ctx, params.p, n.tableDesc, col, seqExpr, nil,
 		)
 		if err != nil {
 			return err
@@ -143,9 +165,6 @@ func (p *planner) addColumnImpl(
 	}
 
 	if d.IsComputed() {
-		if d.IsVirtual() {
-			return unimplemented.NewWithIssue(57608, "virtual computed columns")
-		}
 		computedColValidator := schemaexpr.MakeComputedColumnValidator(
 			params.ctx,
 			n.tableDesc,
@@ -159,3 +178,28 @@ func (p *planner) addColumnImpl(
 
 	return nil
 }
+
+// checkAddVirtualColumn checks that the virtual computed column d can be added
+// to an existing table. Virtual columns are not stored, so adding one does not
+// require a backfill. Adding a NOT NULL or UNIQUE virtual column would require
+// the existing rows to be validated or indexed, which is not supported yet.
+func checkAddVirtualColumn(params runParams, d *tree.ColumnTableDef) error {
+	if !params.SessionData().VirtualColumnsEnabled {
+		return unimplemented.NewWithIssue(57608, "virtual computed columns")
+	}
+	if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.VirtualComputedColumns) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use virtual columns",
+			clusterversion.VirtualComputedColumns)
+	}
+	if d.HasColumnFamily() {
+		return pgerror.Newf(pgcode.Syntax, "virtual columns cannot have family specifications")
+	}
+	if d.Nullable.Nullability == tree.NotNull {
+		return unimplemented.NewWithIssue(57608, "adding a NOT NULL virtual computed column")
+	}
+	if d.PrimaryKey.IsPrimaryKey || d.Unique.IsUnique {
+		return unimplemented.NewWithIssue(57608, "adding a virtual computed column with a PRIMARY KEY or UNIQUE constraint")
+	}
+	return nil
+}
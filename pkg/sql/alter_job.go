@@ -0,0 +1,123 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+const (
+	alterJobOptionBackfillBatchSize        = "backfill_batch_size"
+	alterJobOptionBackfillMaxRowsPerSecond = "backfill_max_rows_per_second"
+)
+
+var alterJobOptionExpectValues = map[string]KVStringOptValidate{
+	alterJobOptionBackfillBatchSize:        KVStringOptRequireValue,
+	alterJobOptionBackfillMaxRowsPerSecond: KVStringOptRequireValue,
+}
+
+type alterJobNode struct {
+	jobID   tree.TypedExpr
+	options func() (map[string]string, error)
+}
+
+// AlterJob changes the settings of a job.
+// (`ALTER JOB ... SET` statement)
+// Privileges: admin role or CONTROLJOB role option.
+func (p *planner) AlterJob(ctx context.Context, n *tree.AlterJob) (planNode, error) {
+	jobID, err := p.analyzeExpr(
+		ctx, n.Job, nil, tree.IndexedVarHelper{}, types.Int, true /* requireType */, "ALTER JOB",
+	)
+	if err != nil {
+		return nil, err
+	}
+	options, err := p.TypeAsStringOpts(ctx, n.Options, alterJobOptionExpectValues)
+	if err != nil {
+		return nil, err
+	}
+	return &alterJobNode{jobID: jobID, options: options}, nil
+}
+
+func (n *alterJobNode) startExec(params runParams) error {
+	userIsAdmin, err := params.p.checkCanControlJobs(params.ctx)
+	if err != nil {
+		return err
+	}
+
+	jobIDDatum, err := n.jobID.Eval(params.EvalContext())
+	if err != nil {
+		return err
+	}
+	if jobIDDatum == tree.DNull {
+		return pgerror.New(pgcode.InvalidParameterValue, "job ID cannot be NULL")
+	}
+	jobID := int64(tree.MustBeDInt(jobIDDatum))
+
+	options, err := n.options()
+	if err != nil {
+		return err
+	}
+	limits := make(map[string]int64, len(options))
+	for k, v := range options {
+		limit, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || limit < 0 {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"option %q requires a non-negative integer value, got %q", k, v)
+		}
+		limits[k] = limit
+	}
+
+	job, err := params.p.ExecCfg().JobRegistry.LoadJobWithTxn(params.ctx, jobID, params.p.Txn())
+	if err != nil {
+		return err
+	}
+	if err := params.p.checkCanControlJob(params.ctx, job, userIsAdmin); err != nil {
+		return err
+	}
+
+	return job.WithTxn(params.p.Txn()).Update(params.ctx, func(
+		_ *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		if md.Status.Terminal() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"job %d is %s and cannot be altered", jobID, md.Status)
+		}
+		details, ok := md.Payload.UnwrapDetails().(jobspb.SchemaChangeDetails)
+		if !ok {
+			return pgerror.Newf(pgcode.WrongObjectType,
+				"job %d is not a schema change job", jobID)
+		}
+		for k, limit := range limits {
+			switch k {
+			case alterJobOptionBackfillBatchSize:
+				details.BackfillBatchSize = limit
+			case alterJobOptionBackfillMaxRowsPerSecond:
+				details.BackfillMaxRowsPerSecond = limit
+			}
+		}
+		md.Payload.Details = jobspb.WrapPayloadDetails(details)
+		ju.UpdatePayload(md.Payload)
+		return nil
+	})
+}
+
+func (*alterJobNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterJobNode) Values() tree.Datums          { return nil }
+func (*alterJobNode) Close(context.Context)        {}
@@ -13,16 +13,23 @@ package sql
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -262,3 +269,334 @@ func (n *alterRoleNode) startExec(params runParams) error {
 func (*alterRoleNode) Next(runParams) (bool, error) { return false, nil }
 func (*alterRoleNode) Values() tree.Datums          { return tree.Datums{} }
 func (*alterRoleNode) Close(context.Context)        {}
+
+// roleSessionVarDefaults lists the session variables whose default value can
+// be configured with ALTER ROLE ... SET. The defaults are stored in
+// system.database_role_settings and are applied when a session is opened,
+// unless the client overrides them in its connection parameters.
+var roleSessionVarDefaults = []string{
+	"default_int_size",
+	"default_transaction_read_only",
+	"default_transaction_use_follower_reads",
+	"max_query_memory",
+	"statement_timeout",
+	"timezone",
+	"transaction_timeout",
+}
+
+// normalizeRoleSessionVarDefault validates the default value of a session
+// variable configured for a role, and returns it in its canonical form.
+func normalizeRoleSessionVarDefault(varName, value string) (string, error) {
+	switch varName {
+	case "default_int_size":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", wrapSetVarError(varName, value, "%v", err)
+		}
+		if i != 4 && i != 8 {
+			return "", pgerror.New(pgcode.InvalidParameterValue,
+				`only 4 or 8 are supported by default_int_size`)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case "max_query_memory":
+		size, err := parseMaxQueryMemory(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(size, 10), nil
+	case "statement_timeout", "transaction_timeout":
+		timeout, err := validateTimeoutVar(value, varName)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(timeout.Milliseconds(), 10), nil
+	case "timezone":
+		loc, err := timeutil.TimeZoneStringToLocation(
+			value,
+			timeutil.TimeZoneStringToLocationISO8601Standard,
+		)
+		if err != nil {
+			return "", wrapSetVarError("TimeZone", value, "%v", err)
+		}
+		return sessionDataTimeZoneFormat(loc), nil
+	default:
+		b, err := paramparse.ParseBoolVar(varName, value)
+		if err != nil {
+			return "", err
+		}
+		return formatBoolAsPostgresSetting(b), nil
+	}
+}
+
+func isRoleSessionVarDefault(name string) bool {
+	for _, v := range roleSessionVarDefaults {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// alterRoleSetNode represents an ALTER ROLE ... SET or ALTER ROLE ... RESET
+// statement.
+type alterRoleSetNode struct {
+	// userNameInfo is unused if allRoles is set.
+	userNameInfo
+	allRoles bool
+	ifExists bool
+	isRole   bool
+	// dbDescID is zero if the default applies to all databases.
+	dbDescID descpb.ID
+	dbName   string
+	// varName is the name of the session variable, or "all" for RESET ALL.
+	varName string
+	// typedValue is nil for RESET.
+	typedValue tree.TypedExpr
+}
+
+// AlterRoleSet changes the default value of a session variable for a role,
+// or for all roles, optionally only in a given database.
+// Privileges: CREATEROLE privilege.
+func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planNode, error) {
+	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+		return nil, err
+	}
+	// The defaults for all roles also apply to the admins, which users with
+	// CREATEROLE can't otherwise edit.
+	if n.AllRoles {
+		if err := p.RequireAdminRole(ctx, "ALTER ROLE ALL"); err != nil {
+			return nil, err
+		}
+	}
+	if !p.EvalContext().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettings) {
+		return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			`setting session variable defaults requires all nodes to be upgraded to %s`,
+			clusterversion.ByKey(clusterversion.DatabaseRoleSettings))
+	}
+
+	varName := strings.ToLower(n.SetOrReset.Name)
+	isReset := n.IsReset()
+	if !(isReset && varName == "all") && !isRoleSessionVarDefault(varName) {
+		if _, _, err := getSessionVar(varName, false /* missingOk */); err != nil {
+			return nil, err
+		}
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot set the default value of session variable %q for a role", varName)
+	}
+
+	var typedValue tree.TypedExpr
+	if !isReset {
+		if len(n.SetOrReset.Values) != 1 {
+			return nil, newSingleArgVarError(varName)
+		}
+		expr := paramparse.UnresolvedNameToStrVal(n.SetOrReset.Values[0])
+		var dummyHelper tree.IndexedVarHelper
+		var err error
+		typedValue, err = p.analyzeExpr(
+			ctx, expr, nil, dummyHelper, types.String, false, "ALTER ROLE SET "+varName)
+		if err != nil {
+			return nil, wrapSetVarError(varName, expr.String(), "%v", err)
+		}
+	}
+
+	var ua userNameInfo
+	if !n.AllRoles {
+		var err error
+		ua, err = p.getUserAuthInfo(ctx, n.Name, "ALTER ROLE")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var dbDescID descpb.ID
+	if n.DatabaseName != "" {
+		dbDesc, err := p.ResolveUncachedDatabaseByName(ctx, string(n.DatabaseName), true /* required */)
+		if err != nil {
+			return nil, err
+		}
+		dbDescID = dbDesc.GetID()
+	}
+
+	return &alterRoleSetNode{
+		userNameInfo: ua,
+		allRoles:     n.AllRoles,
+		ifExists:     n.IfExists,
+		isRole:       n.IsRole,
+		dbDescID:     dbDescID,
+		dbName:       string(n.DatabaseName),
+		varName:      varName,
+		typedValue:   typedValue,
+	}, nil
+}
+
+func (n *alterRoleSetNode) startExec(params runParams) error {
+	var opName string
+	if n.isRole {
+		sqltelemetry.IncIAMAlterCounter(sqltelemetry.Role)
+		opName = "alter-role"
+	} else {
+		sqltelemetry.IncIAMAlterCounter(sqltelemetry.User)
+		opName = "alter-user"
+	}
+
+	// The defaults that apply to all roles are stored with an empty role name.
+	var roleName string
+	if !n.allRoles {
+		normalizedUsername, err := n.resolveUsername()
+		if err != nil {
+			return err
+		}
+		if normalizedUsername.IsAdminRole() {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"cannot edit admin role")
+		}
+		// The defaults are not looked up when root logs in, so that root can log
+		// in even if system.database_role_settings is unavailable.
+		if normalizedUsername.IsRootUser() {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"cannot set session variable defaults for the %s user", normalizedUsername)
+		}
+
+		// Check if role exists.
+		row, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.QueryRowEx(
+			params.ctx,
+			opName,
+			params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			fmt.Sprintf("SELECT 1 FROM %s WHERE username = $1", userTableName),
+			normalizedUsername,
+		)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			if n.ifExists {
+				return nil
+			}
+			return errors.Newf("role/user %s does not exist", normalizedUsername)
+		}
+		roleName = normalizedUsername.Normalized()
+	}
+	dbID := tree.NewDOid(tree.DInt(n.dbDescID))
+
+	// The settings are stored as an array of "name=value" entries, like in
+	// Postgres' pg_db_role_setting.
+	row, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.QueryRowEx(
+		params.ctx,
+		opName,
+		params.p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		fmt.Sprintf(
+			`SELECT settings FROM %s WHERE database_id = $1 AND role_name = $2`,
+			DatabaseRoleSettingsTableName,
+		),
+		dbID, roleName,
+	)
+	if err != nil {
+		return err
+	}
+	var settings []string
+	if row != nil {
+		for _, d := range tree.MustBeDArray(row[0]).Array {
+			settings = append(settings, string(tree.MustBeDString(d)))
+		}
+	}
+
+	var optStr string
+	newSettings := make([]string, 0, len(settings)+1)
+	if n.varName != "all" {
+		for _, s := range settings {
+			if !strings.HasPrefix(s, n.varName+"=") {
+				newSettings = append(newSettings, s)
+			}
+		}
+	}
+	if n.typedValue != nil {
+		d, err := n.typedValue.Eval(params.EvalContext())
+		if err != nil {
+			return err
+		}
+		var strVal string
+		if _, v, err := getSessionVar(n.varName, false /* missingOk */); err != nil {
+			return err
+		} else if v.GetStringVal != nil {
+			strVal, err = v.GetStringVal(params.ctx, params.extendedEvalCtx, []tree.TypedExpr{d})
+			if err != nil {
+				return err
+			}
+		} else {
+			strVal, err = getStringVal(params.EvalContext(), n.varName, []tree.TypedExpr{d})
+			if err != nil {
+				return err
+			}
+		}
+		// The value is validated here so that an invalid default can't prevent
+		// the role from logging in.
+		strVal, err = normalizeRoleSessionVarDefault(n.varName, strVal)
+		if err != nil {
+			return err
+		}
+		newSettings = append(newSettings, n.varName+"="+strVal)
+		optStr = fmt.Sprintf("SET %s = %s", n.varName, strVal)
+	} else {
+		optStr = "RESET " + n.varName
+		if n.varName == "all" {
+			optStr = "RESET ALL"
+		}
+	}
+	if n.dbName != "" {
+		optStr = fmt.Sprintf("IN DATABASE %s %s", n.dbName, optStr)
+	}
+
+	if len(newSettings) == 0 {
+		if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+			params.ctx,
+			opName,
+			params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			fmt.Sprintf(
+				`DELETE FROM %s WHERE database_id = $1 AND role_name = $2`,
+				DatabaseRoleSettingsTableName,
+			),
+			dbID, roleName,
+		); err != nil {
+			return err
+		}
+	} else {
+		sort.Strings(newSettings)
+		settingsArr := tree.NewDArray(types.String)
+		for _, s := range newSettings {
+			if err := settingsArr.Append(tree.NewDString(s)); err != nil {
+				return err
+			}
+		}
+		if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+			params.ctx,
+			opName,
+			params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			fmt.Sprintf(
+				`UPSERT INTO %s (database_id, role_name, settings) VALUES ($1, $2, $3)`,
+				DatabaseRoleSettingsTableName,
+			),
+			dbID, roleName, settingsArr,
+		); err != nil {
+			return err
+		}
+	}
+
+	eventRoleName := roleName
+	if n.allRoles {
+		eventRoleName = "ALL"
+	}
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.AlterRole{
+			RoleName: eventRoleName,
+			Options:  []string{optStr},
+		})
+}
+
+func (*alterRoleSetNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterRoleSetNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterRoleSetNode) Close(context.Context)        {}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type alterScheduleNode struct {
+	scheduleID tree.TypedExpr
+	recurrence func() (string, error)
+}
+
+// AlterSchedule changes the recurrence of a schedule.
+// (`ALTER SCHEDULE ... SET RECURRING` statement)
+// Privileges: admin role.
+func (p *planner) AlterSchedule(ctx context.Context, n *tree.AlterSchedule) (planNode, error) {
+	if err := p.RequireAdminRole(ctx, "ALTER SCHEDULE"); err != nil {
+		return nil, err
+	}
+	scheduleID, err := p.analyzeExpr(
+		ctx, n.Schedule, nil, tree.IndexedVarHelper{}, types.Int, true /* requireType */, "ALTER SCHEDULE",
+	)
+	if err != nil {
+		return nil, err
+	}
+	recurrence, err := p.TypeAsString(ctx, n.Recurrence, "ALTER SCHEDULE")
+	if err != nil {
+		return nil, err
+	}
+	return &alterScheduleNode{scheduleID: scheduleID, recurrence: recurrence}, nil
+}
+
+func (n *alterScheduleNode) startExec(params runParams) error {
+	scheduleIDDatum, err := n.scheduleID.Eval(params.EvalContext())
+	if err != nil {
+		return err
+	}
+	if scheduleIDDatum == tree.DNull {
+		return pgerror.New(pgcode.InvalidParameterValue, "schedule ID cannot be NULL")
+	}
+	recurrence, err := n.recurrence()
+	if err != nil {
+		return err
+	}
+
+	schedule, err := loadSchedule(params, scheduleIDDatum)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"schedule %d does not exist", int64(tree.MustBeDInt(scheduleIDDatum)))
+	}
+
+	// Setting the schedule computes its next run, which would resume a paused
+	// schedule.
+	paused := schedule.IsPaused()
+	if err := schedule.SetSchedule(recurrence); err != nil {
+		return pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+	}
+	if paused {
+		schedule.Pause()
+	}
+	telemetry.Inc(sqltelemetry.ScheduledBackupControlCounter("alter"))
+	return updateSchedule(params, schedule)
+}
+
+func (*alterScheduleNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterScheduleNode) Values() tree.Datums          { return nil }
+func (*alterScheduleNode) Close(context.Context)        {}
@@ -144,6 +144,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 	// the list.
 	descriptorChanged := false
 	origNumMutations := len(n.tableDesc.Mutations)
+	firstNewColumnID, firstNewIndexID := n.tableDesc.NextColumnID, n.tableDesc.NextIndexID
 	var droppedViews []string
 	resolved := params.p.ResolvedName(n.n.Table)
 	tn, ok := resolved.(*tree.TableName)
@@ -216,6 +217,34 @@ func (n *alterTableNode) startExec(params runParams) error {
 					Unique:           true,
 					StoreColumnNames: d.Storing.ToStrings(),
 				}
+				if d.Sharded != nil {
+					if d.PartitionBy != nil {
+						return pgerror.New(pgcode.FeatureNotSupported, "sharded indexes don't support partitioning")
+					}
+					if d.Interleave != nil {
+						return pgerror.New(pgcode.FeatureNotSupported, "interleaved indexes cannot also be hash sharded")
+					}
+					shardCol, newColumn, err := setupShardedIndex(
+						params.ctx,
+						params.EvalContext(),
+						&params.p.semaCtx,
+						params.SessionData().HashShardedIndexesEnabled,
+						&d.Columns,
+						d.Sharded.ShardBuckets,
+						n.tableDesc,
+						&idx,
+						false /* isNewTable */)
+					if err != nil {
+						return err
+					}
+					if newColumn {
+						if err := params.p.setupFamilyAndConstraintForShard(params.ctx, n.tableDesc, shardCol,
+							idx.Sharded.ColumnNames, idx.Sharded.ShardBuckets); err != nil {
+							return err
+						}
+					}
+					telemetry.Inc(sqltelemetry.HashShardedIndexCounter)
+				}
 				if err := idx.FillColumns(d.Columns); err != nil {
 					return err
 				}
@@ -859,6 +888,12 @@ func (n *alterTableNode) startExec(params runParams) error {
 			return err
 		}
 	}
+	if err := checkTableSchemaLimits(
+		&params.ExecCfg().Settings.SV, n.tableDesc, firstNewColumnID, firstNewIndexID,
+	); err != nil {
+		return err
+	}
+
 	// Were some changes made?
 	//
 	// This is only really needed for the unittests that add dummy mutations
@@ -1001,6 +1036,36 @@ func applyColumnMutation(
 				}
 			}
 		}
+		if col.HasOnUpdate() {
+			// The references to the sequences used by the ON UPDATE expression
+			// were removed along with those of the previous default expression,
+			// so add them back.
+			onUpdateExpr, err := parser.ParseExpr(*col.OnUpdateExpr)
+			if err != nil {
+				return err
+			}
+			typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+				params.ctx, onUpdateExpr, col.Type, "ON UPDATE", &params.p.semaCtx, tree.VolatilityVolatile,
+			)
+			if err != nil {
+				return err
+			}
+			changedSeqDescs, err := maybeAddSequenceDependencies(
+				params.ctx, params.p, tableDesc, col, typedExpr, nil, /* backrefs */
+			)
+			if err != nil {
+				return err
+			}
+			for _, changedSeqDesc := range changedSeqDescs {
+				if err := params.p.writeSchemaChange(
+					params.ctx, changedSeqDesc, descpb.InvalidMutationID,
+					fmt.Sprintf("updating dependent sequence %s(%d) for table %s(%d)",
+						changedSeqDesc.Name, changedSeqDesc.ID, tableDesc.Name, tableDesc.ID,
+					)); err != nil {
+					return err
+				}
+			}
+		}
 
 	case *tree.AlterTableSetNotNull:
 		if !col.Nullable {
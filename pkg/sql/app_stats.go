@@ -163,6 +163,8 @@ func (s stmtKey) String() string {
 //
 // samplePlanDescription can be nil, as these are only sampled periodically
 // per unique fingerprint.
+// timedOut and canceled report whether the execution of the statement was
+// interrupted by statement_timeout or transaction_timeout, or by a client.
 // recordStatement always returns a valid stmtID corresponding to the given
 // stmt regardless of whether the statement is actually recorded or not.
 func (a *appStats) recordStatement(
@@ -174,6 +176,7 @@ func (a *appStats) recordStatement(
 	automaticRetryCount int,
 	numRows int,
 	err error,
+	timedOut, canceled bool,
 	parseLat, planLat, runLat, svcLat, ovhLat float64,
 	stats topLevelQueryStats,
 ) roachpb.StmtID {
@@ -206,6 +209,11 @@ func (a *appStats) recordStatement(
 	if err != nil {
 		s.mu.data.SensitiveInfo.LastErr = err.Error()
 	}
+	if timedOut {
+		s.mu.data.TimedOutCount++
+	} else if canceled {
+		s.mu.data.CanceledCount++
+	}
 	// Only update MostRecentPlanDescription if we sampled a new PlanDescription.
 	if samplePlanDescription != nil {
 		s.mu.data.SensitiveInfo.MostRecentPlanDescription = *samplePlanDescription
@@ -742,6 +750,8 @@ func quantizeCounts(d *roachpb.StatementStatistics) {
 	d.MaxRetries = telemetry.Bucket10(d.MaxRetries)
 
 	d.FirstAttemptCount = int64((float64(d.FirstAttemptCount) / float64(oldCount)) * float64(newCount))
+	d.TimedOutCount = int64((float64(d.TimedOutCount) / float64(oldCount)) * float64(newCount))
+	d.CanceledCount = int64((float64(d.CanceledCount) / float64(oldCount)) * float64(newCount))
 }
 
 // FailedHashedValue is used as a default return value for when HashForReporting
@@ -70,6 +70,16 @@ type applyJoinNode struct {
 		// done is true if the left side has been exhausted.
 		done bool
 	}
+
+	// stats are collected for EXPLAIN ANALYZE.
+	stats struct {
+		// rightSideExecutions is the number of times the right side was planned
+		// and executed, which is once per left row.
+		rightSideExecutions uint64
+		// rightRows is the total number of rows produced by all executions of the
+		// right side.
+		rightRows uint64
+	}
 }
 
 // Set to true to enable ultra verbose debug logging.
@@ -224,7 +234,12 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 func (a *applyJoinNode) runRightSidePlan(params runParams, plan *planComponents) error {
 	a.run.curRightRow = 0
 	a.run.rightRows.Clear(params.ctx)
-	return runPlanInsidePlan(params, plan, a.run.rightRows)
+	if err := runPlanInsidePlan(params, plan, a.run.rightRows); err != nil {
+		return err
+	}
+	a.stats.rightSideExecutions++
+	a.stats.rightRows += uint64(a.run.rightRows.Len())
+	return nil
 }
 
 // runPlanInsidePlan is used to run a plan and gather the results in a row
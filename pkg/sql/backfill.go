@@ -242,7 +242,11 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 		case descpb.DescriptorMutation_DROP:
 			switch t := m.Descriptor_.(type) {
 			case *descpb.DescriptorMutation_Column:
-				needColumnBackfill = true
+				// Virtual columns are not stored, so there is nothing to
+				// remove when dropping them.
+				if !t.Column.IsVirtual() {
+					needColumnBackfill = true
+				}
 			case *descpb.DescriptorMutation_Index:
 				if !canClearRangeForDrop(t.Index) {
 					droppedIndexDescs = append(droppedIndexDescs, *t.Index)
@@ -970,6 +974,17 @@ func (sc *SchemaChanger) distBackfill(
 				}
 			}
 
+			// The backfill limits of the job can be changed while the job is
+			// running, so they are read again before each run of the backfill.
+			details, err := sc.loadJobDetails(ctx, txn)
+			if err != nil {
+				return err
+			}
+			batchSize := chunkSize
+			if details.BackfillBatchSize > 0 {
+				batchSize = sc.getChunkSize(details.BackfillBatchSize)
+			}
+
 			tc := descs.NewCollection(sc.settings, sc.leaseMgr, nil /* hydratedTables */)
 			// Use a leased table descriptor for the backfill.
 			defer tc.ReleaseAll(ctx)
@@ -999,7 +1014,8 @@ func (sc *SchemaChanger) distBackfill(
 
 			planCtx := sc.distSQLPlanner.NewPlanningCtx(ctx, &evalCtx, nil /* planner */, txn, true /* distribute */)
 			plan, err := sc.distSQLPlanner.createBackfiller(
-				planCtx, backfillType, *tableDesc.TableDesc(), duration, chunkSize, todoSpans, readAsOf,
+				planCtx, backfillType, *tableDesc.TableDesc(), duration, batchSize,
+				details.BackfillMaxRowsPerSecond, todoSpans, readAsOf,
 			)
 			if err != nil {
 				return err
@@ -1027,6 +1043,24 @@ func (sc *SchemaChanger) distBackfill(
 	return nil
 }
 
+// loadJobDetails returns the details of the schema change job as currently
+// persisted, which may differ from the ones cached in sc.job if the job was
+// altered since it started.
+func (sc *SchemaChanger) loadJobDetails(
+	ctx context.Context, txn *kv.Txn,
+) (jobspb.SchemaChangeDetails, error) {
+	job, err := sc.jobRegistry.LoadJobWithTxn(ctx, *sc.job.ID(), txn)
+	if err != nil {
+		return jobspb.SchemaChangeDetails{}, err
+	}
+	details, ok := job.Details().(jobspb.SchemaChangeDetails)
+	if !ok {
+		return jobspb.SchemaChangeDetails{}, errors.AssertionFailedf(
+			"expected SchemaChangeDetails job type, got %T", job.Details())
+	}
+	return details, nil
+}
+
 // updateJobRunningStatus updates the status field in the job entry
 // with the given value.
 //
@@ -40,9 +40,9 @@ import (
 type MutationFilter func(descpb.DescriptorMutation) bool
 
 // ColumnMutationFilter is a filter that allows mutations that add or drop
-// columns.
+// columns. Virtual columns are skipped since they are not stored.
 func ColumnMutationFilter(m descpb.DescriptorMutation) bool {
-	return m.GetColumn() != nil &&
+	return m.GetColumn() != nil && !m.GetColumn().IsVirtual() &&
 		(m.Direction == descpb.DescriptorMutation_ADD || m.Direction == descpb.DescriptorMutation_DROP)
 }
 
@@ -265,6 +265,9 @@ func (cb *ColumnBackfiller) RunColumnBackfillChunk(
 		requestedCols,
 		row.UpdaterOnlyColumns,
 		&cb.alloc,
+		// Don't enforce the row size guardrails when backfilling: schema
+		// changes shouldn't fail because of rows that were already written.
+		nil, /* sv */
 	)
 	if err != nil {
 		return roachpb.Key{}, err
@@ -0,0 +1,449 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// batchedDMLDefaultSize is the maximum number of rows modified by each
+// transaction of a batched DELETE or UPDATE when no SIZE option is given.
+const batchedDMLDefaultSize = 1000
+
+// batchedDMLMinBackoff and batchedDMLMaxBackoff bound the delay added between
+// batches after a batch had to be retried because of contention.
+const (
+	batchedDMLMinBackoff = 10 * time.Millisecond
+	batchedDMLMaxBackoff = 10 * time.Second
+)
+
+const runningStatusBatchedDML = "%d rows affected in %d batches"
+
+// batchedDMLNode runs a DELETE or UPDATE statement with a WITH BATCH clause.
+// The statement is executed by a BatchedDML job, which applies it in a
+// sequence of bounded transactions, and the node waits for the job to finish.
+type batchedDMLNode struct {
+	record       jobs.Record
+	rowsAffected int
+}
+
+// BatchedDML plans a DELETE or UPDATE statement with a WITH BATCH clause.
+// Privileges: DELETE or UPDATE on the target table, as for the statement
+// being batched.
+func (p *planner) BatchedDML(ctx context.Context, n *tree.BatchedDML) (planNode, error) {
+	if !p.ExtendedEvalContext().TxnImplicit {
+		return nil, pgerror.Newf(pgcode.InvalidTransactionState,
+			"%s ... WITH BATCH cannot be used inside a transaction", n.StatementTag())
+	}
+
+	var tableExpr tree.TableExpr
+	var requiredPriv privilege.Kind
+	var updateExprs tree.UpdateExprs
+	switch stmt := n.Statement.(type) {
+	case *tree.Delete:
+		if err := checkBatchedDMLClauses(
+			stmt.With, stmt.OrderBy, stmt.Limit, stmt.Returning, nil, /* from */
+		); err != nil {
+			return nil, err
+		}
+		tableExpr, requiredPriv = stmt.Table, privilege.DELETE
+	case *tree.Update:
+		if err := checkBatchedDMLClauses(
+			stmt.With, stmt.OrderBy, stmt.Limit, stmt.Returning, stmt.From,
+		); err != nil {
+			return nil, err
+		}
+		tableExpr, requiredPriv, updateExprs = stmt.Table, privilege.UPDATE, stmt.Exprs
+	default:
+		return nil, errors.AssertionFailedf("unexpected batched statement %T", stmt)
+	}
+
+	var tn *tree.TableName
+	if aliased, ok := tableExpr.(*tree.AliasedTableExpr); ok {
+		tn, _ = aliased.Expr.(*tree.TableName)
+	}
+	if tn == nil {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"WITH BATCH requires the target table to be specified by name")
+	}
+	// Resolving the name in place makes the statement stored in the job refer
+	// to the fully qualified table name.
+	flags := tree.ObjectLookupFlagsWithRequiredTableKind(tree.ResolveRequireTableDesc)
+	tableDesc, err := resolver.ResolveExistingTableObject(ctx, p, tn, flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, requiredPriv); err != nil {
+		return nil, err
+	}
+
+	// The rows are paged through in primary key order, so the primary key of a
+	// row must not change while the statement runs.
+	keyColumns := tableDesc.GetPrimaryIndex().ColumnNames
+	for _, expr := range updateExprs {
+		for _, name := range expr.Names {
+			for _, col := range keyColumns {
+				if string(name) == col {
+					return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+						"WITH BATCH cannot be used to update primary key column %q", col)
+				}
+			}
+		}
+	}
+
+	batchSize := int64(batchedDMLDefaultSize)
+	if n.Options.Size != nil {
+		typedSize, err := p.analyzeExpr(
+			ctx, n.Options.Size, nil, tree.IndexedVarHelper{}, types.Int, true, "WITH BATCH SIZE",
+		)
+		if err != nil {
+			return nil, err
+		}
+		d, err := typedSize.Eval(p.EvalContext())
+		if err != nil {
+			return nil, err
+		}
+		size, ok := d.(*tree.DInt)
+		if !ok || *size < 1 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"batch size must be a positive integer, got %s", d)
+		}
+		batchSize = int64(*size)
+	}
+
+	var pacing time.Duration
+	if n.Options.Pacing != nil {
+		typedPacing, err := p.analyzeExpr(
+			ctx, n.Options.Pacing, nil, tree.IndexedVarHelper{}, types.Interval, true, "WITH BATCH PACING",
+		)
+		if err != nil {
+			return nil, err
+		}
+		d, err := typedPacing.Eval(p.EvalContext())
+		if err != nil {
+			return nil, err
+		}
+		interval, ok := d.(*tree.DInterval)
+		if !ok || interval.Months != 0 || interval.Days != 0 || interval.Nanos() < 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"batch pacing must be a non-negative interval without day or month specifiers, got %s", d)
+		}
+		pacing = time.Duration(interval.Nanos()) * time.Nanosecond
+	}
+
+	// The job runs the statement outside of this session, so the values of the
+	// placeholders must be stored along with it.
+	statement, err := p.formatWithPlaceholderValues(n.Statement)
+	if err != nil {
+		return nil, err
+	}
+	description, err := p.formatWithPlaceholderValues(n)
+	if err != nil {
+		return nil, err
+	}
+	return &batchedDMLNode{
+		record: jobs.Record{
+			Description:   description,
+			Username:      p.User(),
+			DescriptorIDs: []descpb.ID{tableDesc.GetID()},
+			Details: jobspb.BatchedDMLDetails{
+				Statement:  statement,
+				Database:   p.CurrentDatabase(),
+				KeyColumns: keyColumns,
+				BatchSize:  batchSize,
+				Pacing:     pacing,
+			},
+			Progress: jobspb.BatchedDMLProgress{},
+		},
+	}, nil
+}
+
+// formatWithPlaceholderValues formats a statement with fully qualified table
+// names, replacing its placeholders by their values.
+func (p *planner) formatWithPlaceholderValues(n tree.NodeFormatter) (string, error) {
+	var err error
+	f := tree.NewFmtCtxEx(tree.FmtAlwaysQualifyTableNames, p.EvalContext().Annotations)
+	f.SetPlaceholderFormat(func(f *tree.FmtCtx, placeholder *tree.Placeholder) {
+		d, evalErr := placeholder.Eval(p.EvalContext())
+		if evalErr != nil {
+			if err == nil {
+				err = evalErr
+			}
+			return
+		}
+		f.WriteString(tree.AsStringWithFlags(d, tree.FmtParsable))
+	})
+	f.FormatNode(n)
+	return f.CloseAndGetString(), err
+}
+
+// checkBatchedDMLClauses returns an error if the statement being batched uses
+// a clause that cannot be combined with WITH BATCH.
+func checkBatchedDMLClauses(
+	with *tree.With,
+	orderBy tree.OrderBy,
+	limit *tree.Limit,
+	returning tree.ReturningClause,
+	from tree.TableExprs,
+) error {
+	var clause string
+	switch {
+	case with != nil:
+		clause = "WITH"
+	case len(orderBy) > 0:
+		clause = "ORDER BY"
+	case limit != nil:
+		clause = "LIMIT"
+	case tree.HasReturningClause(returning):
+		clause = "RETURNING"
+	case len(from) > 0:
+		clause = "FROM"
+	default:
+		return nil
+	}
+	return pgerror.Newf(pgcode.FeatureNotSupported, "WITH BATCH cannot be combined with %s", clause)
+}
+
+func (n *batchedDMLNode) startExec(params runParams) error {
+	registry := params.p.ExecCfg().JobRegistry
+	job, errCh, err := registry.CreateAndStartJob(params.ctx, nil /* resultsCh */, n.record)
+	if err != nil {
+		return err
+	}
+	if err := <-errCh; err != nil {
+		return err
+	}
+	// Reload the job to pick up the progress written by the resumer.
+	job, err = registry.LoadJob(params.ctx, *job.ID())
+	if err != nil {
+		return err
+	}
+	n.rowsAffected = int(job.Progress().GetBatchedDML().RowsAffected)
+	return nil
+}
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *batchedDMLNode) FastPathResults() (int, bool) {
+	return n.rowsAffected, true
+}
+
+func (*batchedDMLNode) Next(runParams) (bool, error) { return false, nil }
+func (*batchedDMLNode) Values() tree.Datums          { return nil }
+func (*batchedDMLNode) Close(context.Context)        {}
+
+// makeBatchedDMLQuery returns the query for the next batch of a batched
+// statement. The batch is restricted to rows whose primary key is greater
+// than resumeKey, is limited to batchSize rows in primary key order, and
+// returns the primary key of each affected row.
+func makeBatchedDMLQuery(
+	stmt tree.Statement, keyColumns []string, resumeKey []string, batchSize int64,
+) (string, error) {
+	keyExprs := make(tree.Exprs, len(keyColumns))
+	orderBy := make(tree.OrderBy, len(keyColumns))
+	returning := make(tree.ReturningExprs, len(keyColumns))
+	for i, col := range keyColumns {
+		keyExprs[i] = tree.NewUnresolvedName(col)
+		orderBy[i] = &tree.Order{OrderType: tree.OrderByColumn, Expr: keyExprs[i]}
+		returning[i] = tree.SelectExpr{Expr: keyExprs[i]}
+	}
+	limit := &tree.Limit{Count: tree.NewDInt(tree.DInt(batchSize))}
+
+	var where *tree.Where
+	switch t := stmt.(type) {
+	case *tree.Delete:
+		where = t.Where
+	case *tree.Update:
+		where = t.Where
+	default:
+		return "", errors.AssertionFailedf("unexpected batched statement %T", stmt)
+	}
+	if len(resumeKey) > 0 {
+		resumeExprs := make(tree.Exprs, len(resumeKey))
+		for i := range resumeKey {
+			expr, err := parser.ParseExpr(resumeKey[i])
+			if err != nil {
+				return "", err
+			}
+			resumeExprs[i] = expr
+		}
+		var cond tree.Expr = &tree.ComparisonExpr{
+			Operator: tree.GT,
+			Left:     &tree.Tuple{Exprs: keyExprs},
+			Right:    &tree.Tuple{Exprs: resumeExprs},
+		}
+		if len(keyExprs) == 1 {
+			cond = &tree.ComparisonExpr{Operator: tree.GT, Left: keyExprs[0], Right: resumeExprs[0]}
+		}
+		if where != nil {
+			cond = &tree.AndExpr{Left: &tree.ParenExpr{Expr: where.Expr}, Right: cond}
+		}
+		where = tree.NewWhere(tree.AstWhere, cond)
+	}
+
+	switch t := stmt.(type) {
+	case *tree.Delete:
+		batch := *t
+		batch.Where, batch.OrderBy, batch.Limit, batch.Returning = where, orderBy, limit, &returning
+		return tree.AsString(&batch), nil
+	case *tree.Update:
+		batch := *t
+		batch.Where, batch.OrderBy, batch.Limit, batch.Returning = where, orderBy, limit, &returning
+		return tree.AsString(&batch), nil
+	}
+	return "", errors.AssertionFailedf("unexpected batched statement %T", stmt)
+}
+
+// maxBatchedDMLKey returns the largest of the primary keys returned by a
+// batch, with each column formatted as a parsable SQL expression.
+func maxBatchedDMLKey(evalCtx *tree.EvalContext, rows []tree.Datums) []string {
+	max := rows[0]
+	for _, row := range rows[1:] {
+		for i := range row {
+			if c := row[i].Compare(evalCtx, max[i]); c != 0 {
+				if c > 0 {
+					max = row
+				}
+				break
+			}
+		}
+	}
+	key := make([]string, len(max))
+	for i := range max {
+		key[i] = tree.AsStringWithFlags(max[i], tree.FmtParsable)
+	}
+	return key
+}
+
+// batchedDMLResumer implements the jobs.Resumer interface for BatchedDML
+// jobs. A new instance is created for each job.
+type batchedDMLResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &batchedDMLResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+//
+// Each batch runs in its own transaction, which also records the primary key
+// of the last affected row in the job progress, so that a resumed job picks
+// up where the last committed batch left off. When a batch has to be retried
+// because of contention, the batch size is halved and an exponentially
+// growing delay is added before the next batch.
+func (r *batchedDMLResumer) Resume(
+	ctx context.Context, execCtx interface{}, _ chan<- tree.Datums,
+) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	evalCtx := &p.ExtendedEvalContext().EvalContext
+	details := r.job.Details().(jobspb.BatchedDMLDetails)
+	progress := *r.job.Progress().GetBatchedDML()
+
+	stmt, err := parser.ParseOne(details.Statement)
+	if err != nil {
+		return err
+	}
+	override := sessiondata.InternalExecutorOverride{
+		User:     r.job.Payload().UsernameProto.Decode(),
+		Database: details.Database,
+	}
+
+	batchSize := details.BatchSize
+	var backoff time.Duration
+	for {
+		query, err := makeBatchedDMLQuery(stmt.AST, details.KeyColumns, progress.ResumeKey, batchSize)
+		if err != nil {
+			return err
+		}
+
+		var affected int
+		var next jobspb.BatchedDMLProgress
+		attempts := 0
+		if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+			attempts++
+			rows, err := execCfg.InternalExecutor.QueryEx(ctx, "batched-dml", txn, override, query)
+			if err != nil {
+				return err
+			}
+			affected = len(rows)
+			next = progress
+			next.RowsAffected += int64(affected)
+			next.Batches++
+			if affected > 0 {
+				next.ResumeKey = maxBatchedDMLKey(evalCtx, rows)
+			}
+			return r.job.WithTxn(txn).RunningStatus(ctx,
+				func(_ context.Context, details jobspb.Details) (jobs.RunningStatus, error) {
+					*details.(*jobspb.Progress_BatchedDML).BatchedDML = next
+					return jobs.RunningStatus(fmt.Sprintf(
+						runningStatusBatchedDML, next.RowsAffected, next.Batches,
+					)), nil
+				})
+		}); err != nil {
+			return err
+		}
+		progress = next
+		if int64(affected) < batchSize {
+			return nil
+		}
+
+		if attempts > 1 {
+			if batchSize > 1 {
+				batchSize /= 2
+			}
+			if backoff *= 2; backoff < batchedDMLMinBackoff {
+				backoff = batchedDMLMinBackoff
+			} else if backoff > batchedDMLMaxBackoff {
+				backoff = batchedDMLMaxBackoff
+			}
+		} else {
+			// Without contention, grow back towards the requested batch size.
+			if batchSize *= 2; batchSize > details.BatchSize {
+				batchSize = details.BatchSize
+			}
+			backoff = 0
+		}
+
+		if wait := details.Pacing + backoff; wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. The batches that
+// already committed are not rolled back.
+func (r *batchedDMLResumer) OnFailOrCancel(context.Context, interface{}) error { return nil }
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeBatchedDML, func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return &batchedDMLResumer{job: job}
+	})
+}
@@ -342,6 +342,11 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 
 	target.AddDescriptor(keys.SystemDatabaseID, systemschema.ScheduledJobsTable)
 	target.AddDescriptor(keys.SystemDatabaseID, systemschema.SqllivenessTable)
+
+	// Tables introduced in 21.1.
+
+	target.AddDescriptor(keys.SystemDatabaseID, systemschema.DatabaseRoleSettingsTable)
+	target.AddDescriptor(keys.SystemDatabaseID, systemschema.JobInfoTable)
 }
 
 // addSplitIDs adds a split point for each of the PseudoTableIDs to the supplied
@@ -76,6 +76,23 @@ const (
 	CrdbInternalZonesTableID
 	CrdbInternalInvalidDescriptorsTableID
 	CrdbInternalClusterDatabasePrivilegesTableID
+	CrdbInternalPreparedStatementsTableID
+	CrdbInternalLingeringIntentsTableID
+	CrdbInternalKVRaftStatusTableID
+	CrdbInternalKVRaftProgressTableID
+	CrdbInternalKVFollowerReadStatusTableID
+	CrdbInternalKVConsistencyChecksTableID
+	CrdbInternalKVStoreDiskStatusTableID
+	CrdbInternalNodeMemoryMonitorsTableID
+	CrdbInternalCompactionsTableID
+	CrdbInternalTableMVCCStatsTableID
+	CrdbInternalObjectDependenciesTableID
+	CrdbInternalClusterRoleMembershipsTableID
+	CrdbInternalTableDiskUsageTableID
+	CrdbInternalClusterUpgradeStatusTableID
+	CrdbInternalFlowsTableID
+	CrdbInternalWebSessionsTableID
+	CrdbInternalNodeDiagnosticsReportTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
@@ -151,6 +168,8 @@ const (
 	PgCatalogUserMappingTableID
 	PgCatalogTablesTableID
 	PgCatalogTablespaceTableID
+	PgCatalogTimezoneAbbrevsTableID
+	PgCatalogTimezoneNamesTableID
 	PgCatalogTriggerTableID
 	PgCatalogTypeTableID
 	PgCatalogViewsTableID
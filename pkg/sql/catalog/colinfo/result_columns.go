@@ -204,6 +204,20 @@ var ShowLastQueryStatisticsColumns = ResultColumns{
 	{Name: "service_latency", Typ: types.Interval},
 }
 
+// ShowTransactionDetailsColumns are the columns of a
+// SHOW TRANSACTION DETAILS statement.
+var ShowTransactionDetailsColumns = ResultColumns{
+	{Name: "txn_id", Typ: types.Uuid},
+	{Name: "priority", Typ: types.String},
+	{Name: "isolation", Typ: types.String},
+	{Name: "read_timestamp", Typ: types.Decimal},
+	{Name: "num_retries", Typ: types.Int},
+	{Name: "savepoints", Typ: types.StringArray},
+	// tracked_write_bytes is the size of the keys written or locked by the
+	// transaction, see kv.Txn.TrackedWriteBytes. Values are not counted.
+	{Name: "tracked_write_bytes", Typ: types.Int},
+}
+
 // ShowFingerprintsColumns are the result columns of a
 // SHOW EXPERIMENTAL_FINGERPRINTS statement.
 var ShowFingerprintsColumns = ResultColumns{
@@ -240,6 +254,16 @@ var AlterTableScatterColumns = ResultColumns{
 	{Name: "pretty", Typ: types.String},
 }
 
+// VerifyConsistencyColumns are the result columns of an
+// ALTER TABLE/RANGE .. EXPERIMENTAL VERIFY CONSISTENCY statement.
+var VerifyConsistencyColumns = ResultColumns{
+	{Name: "range_id", Typ: types.Int},
+	{Name: "start_key", Typ: types.Bytes},
+	{Name: "start_pretty", Typ: types.String},
+	{Name: "status", Typ: types.String},
+	{Name: "detail", Typ: types.String},
+}
+
 // ScrubColumns are the result columns of a SCRUB statement.
 var ScrubColumns = ResultColumns{
 	{Name: "job_uuid", Typ: types.Uuid},
@@ -35,11 +35,21 @@ func (desc *ColumnDescriptor) HasDefault() bool {
 	return desc.DefaultExpr != nil
 }
 
+// HasOnUpdate returns true if the column has an ON UPDATE expression.
+func (desc *ColumnDescriptor) HasOnUpdate() bool {
+	return desc.OnUpdateExpr != nil
+}
+
 // IsComputed returns true if this is a computed column.
 func (desc *ColumnDescriptor) IsComputed() bool {
 	return desc.ComputeExpr != nil
 }
 
+// IsVirtual returns true if this is a virtual computed column.
+func (desc *ColumnDescriptor) IsVirtual() bool {
+	return desc.Virtual
+}
+
 // ColName returns the name of the column as a tree.Name.
 func (desc *ColumnDescriptor) ColName() tree.Name {
 	return tree.Name(desc.Name)
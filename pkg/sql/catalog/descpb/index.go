@@ -170,6 +170,14 @@ func (desc *IndexDescriptor) IsValidReferencedIndex(referencedColIDs ColumnIDs)
 	return desc.Unique && !desc.IsPartial() && ColumnIDs(desc.ColumnIDs).Equals(referencedColIDs)
 }
 
+// IsValidPartialReferencedIndex returns whether the index is a partial unique
+// index that can serve as a referenced index for a foreign key constraint with
+// the provided set of referencedColIDs. Such a foreign key only references the
+// rows that satisfy the predicate of the index.
+func (desc *IndexDescriptor) IsValidPartialReferencedIndex(referencedColIDs ColumnIDs) bool {
+	return desc.Unique && desc.IsPartial() && ColumnIDs(desc.ColumnIDs).Equals(referencedColIDs)
+}
+
 // HasOldStoredColumns returns whether the index has stored columns in the old
 // format (data encoded the same way as if they were in an implicit column).
 func (desc *IndexDescriptor) HasOldStoredColumns() bool {
@@ -100,6 +100,14 @@ func FormatColumnForDisplay(
 		}
 		f.WriteString(defExpr)
 	}
+	if desc.HasOnUpdate() {
+		f.WriteString(" ON UPDATE ")
+		onUpdateExpr, err := FormatExprForDisplay(ctx, tbl, *desc.OnUpdateExpr, semaCtx, tree.FmtParsable)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(onUpdateExpr)
+	}
 	if desc.IsComputed() {
 		f.WriteString(" AS (")
 		compExpr, err := FormatExprForDisplay(ctx, tbl, *desc.ComputeExpr, semaCtx, tree.FmtParsable)
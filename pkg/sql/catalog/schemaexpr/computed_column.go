@@ -51,6 +51,7 @@ func MakeComputedColumnValidator(
 // A computed column expression is valid if all of the following are true:
 //
 //   - It does not have a default value.
+//   - It does not have an ON UPDATE expression.
 //   - It does not reference other computed columns.
 //
 // It additionally updates the target computed column with the serialized
@@ -63,6 +64,12 @@ func (v *ComputedColumnValidator) Validate(d *tree.ColumnTableDef) error {
 			"computed columns cannot have default values",
 		)
 	}
+	if d.HasOnUpdateExpr() {
+		return pgerror.New(
+			pgcode.InvalidTableDefinition,
+			"computed columns cannot have ON UPDATE expressions",
+		)
+	}
 
 	var depColIDs catalog.TableColSet
 	// First, check that no column in the expression is a computed column.
@@ -345,6 +345,30 @@ CREATE TABLE system.sqlliveness (
     expiration       DECIMAL NOT NULL,
   	FAMILY fam0_session_id_expiration (session_id, expiration)
 )`
+
+	// DatabaseRoleSettingsTableSchema stores the default values of session
+	// variables for a role in a database. A database_id of 0 applies to all
+	// databases, and an empty role_name applies to all roles.
+	DatabaseRoleSettingsTableSchema = `
+CREATE TABLE system.database_role_settings (
+	database_id OID NOT NULL,
+	role_name STRING NOT NULL,
+	settings STRING[] NOT NULL,
+	PRIMARY KEY (database_id, role_name),
+	FAMILY "primary" (database_id, role_name, settings)
+)`
+
+	// JobInfoTableSchema stores the information of jobs that is updated
+	// independently of the rest of their state, one row per job and key, so that
+	// frequent updates don't rewrite the row of the job in system.jobs.
+	JobInfoTableSchema = `
+CREATE TABLE system.job_info (
+	job_id INT8 NOT NULL,
+	info_key STRING NOT NULL,
+	value BYTES NOT NULL,
+	PRIMARY KEY (job_id, info_key),
+	FAMILY "primary" (job_id, info_key, value)
+)`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1690,6 +1714,83 @@ var (
 		FormatVersion:  descpb.InterleavedFormatVersion,
 		NextMutationID: 1,
 	})
+
+	// DatabaseRoleSettingsTable is the descriptor for the database_role_settings
+	// table.
+	DatabaseRoleSettingsTable = tabledesc.NewImmutable(descpb.TableDescriptor{
+		Name:                    "database_role_settings",
+		ID:                      keys.DatabaseRoleSettingsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "database_id", ID: 1, Type: types.Oid},
+			{Name: "role_name", ID: 2, Type: types.String},
+			{Name: "settings", ID: 3, Type: types.StringArray},
+		},
+		NextColumnID: 4,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ColumnNames:     []string{"database_id", "role_name", "settings"},
+				ColumnIDs:       []descpb.ColumnID{1, 2, 3},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"database_id", "role_name"},
+			ColumnDirections: []descpb.IndexDescriptor_Direction{descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC},
+			ColumnIDs:        []descpb.ColumnID{1, 2},
+			Version:          descpb.EmptyArraysInInvertedIndexesVersion,
+		},
+		NextIndexID: 2,
+		Privileges: descpb.NewCustomSuperuserPrivilegeDescriptor(
+			descpb.SystemAllowedPrivileges[keys.DatabaseRoleSettingsTableID], security.NodeUserName()),
+		FormatVersion:  descpb.InterleavedFormatVersion,
+		NextMutationID: 1,
+	})
+
+	// JobInfoTable is the descriptor for the job_info table.
+	JobInfoTable = tabledesc.NewImmutable(descpb.TableDescriptor{
+		Name:                    "job_info",
+		ID:                      keys.JobInfoTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "job_id", ID: 1, Type: types.Int},
+			{Name: "info_key", ID: 2, Type: types.String},
+			{Name: "value", ID: 3, Type: types.Bytes},
+		},
+		NextColumnID: 4,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ColumnNames:     []string{"job_id", "info_key", "value"},
+				ColumnIDs:       []descpb.ColumnID{1, 2, 3},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:             "primary",
+			ID:               1,
+			Unique:           true,
+			ColumnNames:      []string{"job_id", "info_key"},
+			ColumnDirections: []descpb.IndexDescriptor_Direction{descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC},
+			ColumnIDs:        []descpb.ColumnID{1, 2},
+			Version:          descpb.EmptyArraysInInvertedIndexesVersion,
+		},
+		NextIndexID: 2,
+		Privileges: descpb.NewCustomSuperuserPrivilegeDescriptor(
+			descpb.SystemAllowedPrivileges[keys.JobInfoTableID], security.NodeUserName()),
+		FormatVersion:  descpb.InterleavedFormatVersion,
+		NextMutationID: 1,
+	})
 )
 
 // newCommentPrivilegeDescriptor returns a privilege descriptor for comment table
@@ -945,6 +945,11 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 				return err
 			}
 		}
+		if c.HasOnUpdate() {
+			if err := f(c.OnUpdateExpr); err != nil {
+				return err
+			}
+		}
 		if c.IsComputed() {
 			if err := f(c.ComputeExpr); err != nil {
 				return err
@@ -3133,6 +3138,37 @@ func (desc *Mutable) RenameIndexDescriptor(index *descpb.IndexDescriptor, name s
 	return fmt.Errorf("index with id = %d does not exist", id)
 }
 
+// FKAutoIndexName returns the name of the index that is created
+// automatically on the given table to support the foreign key constraint
+// with the given name.
+func FKAutoIndexName(tableName, constraintName string) string {
+	return fmt.Sprintf("%s_auto_index_%s", tableName, constraintName)
+}
+
+// renameFKAutoIndex renames the index that was automatically created to
+// support the given foreign key after the key was renamed from oldName to
+// newName. Indexes that no longer have their generated name, indexes that
+// views depend on, and indexes whose new name is already taken are left
+// untouched.
+func (desc *Mutable) renameFKAutoIndex(
+	fk *descpb.ForeignKeyConstraint, oldName, newName string,
+) error {
+	idx, dropped, err := desc.FindIndexByName(FKAutoIndexName(desc.Name, oldName))
+	if err != nil || dropped || !idx.IsValidOriginIndex(fk.OriginColumnIDs) {
+		return nil //nolint:returnerrcheck
+	}
+	for _, tableRef := range desc.DependedOnBy {
+		if tableRef.IndexID == idx.ID {
+			return nil
+		}
+	}
+	newIndexName := FKAutoIndexName(desc.Name, newName)
+	if desc.ValidateIndexNameIsUnique(newIndexName) != nil {
+		return nil
+	}
+	return desc.RenameIndexDescriptor(idx, newIndexName)
+}
+
 // DropConstraint drops a constraint, either by removing it from the table
 // descriptor or by queuing a mutation for a schema change.
 func (desc *Mutable) DropConstraint(
@@ -3321,7 +3357,9 @@ func (desc *Mutable) RenameConstraint(
 			return err
 		}
 		fk.Name = newName
-		return nil
+		// Keep the name of the index that was created automatically to support
+		// the foreign key in sync with the name of the key.
+		return desc.renameFKAutoIndex(fk, oldName, newName)
 
 	case descpb.ConstraintTypeCheck:
 		if detail.CheckConstraint.Validity == descpb.ConstraintValidity_Validating {
@@ -3931,8 +3969,12 @@ func (desc *wrapper) MakeFirstMutationPublic(includeConstraints bool) (*Mutable,
 }
 
 // ColumnNeedsBackfill returns true if adding the given column requires a
-// backfill (dropping a column always requires a backfill).
+// backfill (dropping a column always requires a backfill). Virtual columns
+// are not stored, so they never need to be backfilled.
 func ColumnNeedsBackfill(desc *descpb.ColumnDescriptor) bool {
+	if desc.Virtual {
+		return false
+	}
 	if desc.HasNullDefault() {
 		return false
 	}
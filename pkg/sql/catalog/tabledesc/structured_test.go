@@ -1828,6 +1828,10 @@ func TestColumnNeedsBackfill(t *testing.T) {
 	defaultNotNull := &descpb.ColumnDescriptor{
 		Name: "four", ID: 4, Type: types.Int, DefaultExpr: &four, Nullable: true, ComputeExpr: nil,
 	}
+	// Create a virtual computed column, which isn't stored.
+	virtual := &descpb.ColumnDescriptor{
+		Name: "virt", ID: 5, Type: types.Int, DefaultExpr: nil, Nullable: true, ComputeExpr: &four, Virtual: true,
+	}
 	// Verify that a backfill doesn't occur according to the ColumnNeedsBackfill
 	// function for the default NULL values, and that it does occur for an INT
 	// default value.
@@ -1843,6 +1847,10 @@ func TestColumnNeedsBackfill(t *testing.T) {
 		t.Fatal("Expected explicit SET DEFAULT NULL to require a backfill," +
 			" ColumnNeedsBackfill states that it does not.")
 	}
+	if ColumnNeedsBackfill(virtual) != false {
+		t.Fatal("Expected a virtual computed column to not require a backfill," +
+			" ColumnNeedsBackfill states that it does.")
+	}
 }
 
 func TestDefaultExprNil(t *testing.T) {
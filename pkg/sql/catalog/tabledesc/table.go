@@ -102,6 +102,20 @@ func MakeColumnDefDescs(
 		}
 	}
 
+	if d.HasOnUpdateExpr() {
+		// Verify the ON UPDATE expression type is compatible with the column
+		// type and does not contain invalid functions.
+		onUpdateExpr, err := schemaexpr.SanitizeVarFreeExpr(
+			ctx, d.OnUpdateExpr.Expr, resType, "ON UPDATE", semaCtx, tree.VolatilityVolatile,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		d.OnUpdateExpr.Expr = onUpdateExpr
+		s := tree.Serialize(d.OnUpdateExpr.Expr)
+		col.OnUpdateExpr = &s
+	}
+
 	if d.IsComputed() {
 		s := tree.Serialize(d.Computed.Expr)
 		col.ComputeExpr = &s
@@ -333,7 +347,8 @@ func (desc *wrapper) collectConstraintInfo(
 }
 
 // FindFKReferencedIndex finds the first index in the supplied referencedTable
-// that can satisfy a foreign key of the supplied column ids.
+// that can satisfy a foreign key of the supplied column ids. A partial unique
+// index is only returned if no other unique index matches the columns.
 func FindFKReferencedIndex(
 	referencedTable catalog.TableDescriptor, referencedColIDs descpb.ColumnIDs,
 ) (*descpb.IndexDescriptor, error) {
@@ -351,6 +366,12 @@ func FindFKReferencedIndex(
 			return idx, nil
 		}
 	}
+	for i := range indexes {
+		idx := &indexes[i]
+		if idx.IsValidPartialReferencedIndex(referencedColIDs) {
+			return idx, nil
+		}
+	}
 	return nil, pgerror.Newf(
 		pgcode.ForeignKeyViolation,
 		"there is no unique constraint matching given keys for referenced table %s",
@@ -199,6 +199,17 @@ func nonMatchingRowQuery(
 		on[i] = fmt.Sprintf("%s = %s", qualifiedSrcCols[i], targetCols[i])
 	}
 
+	// If the foreign key references a partial unique index, only the rows that
+	// satisfy the predicate of the index can be referenced.
+	targetWhere := ""
+	referencedIdx, err := tabledesc.FindFKReferencedIndex(targetTbl, fk.ReferencedColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	if referencedIdx.IsPartial() {
+		targetWhere = fmt.Sprintf(" WHERE %s", referencedIdx.Predicate)
+	}
+
 	limit := ""
 	if limitResults {
 		limit = " LIMIT 1"
@@ -207,7 +218,7 @@ func nonMatchingRowQuery(
 		`SELECT %[1]s FROM 
 		  (SELECT %[2]s FROM [%[3]d AS src]@{IGNORE_FOREIGN_KEYS} WHERE %[4]s) AS s
 			LEFT OUTER JOIN
-			(SELECT * FROM [%[5]d AS target]) AS t
+			(SELECT * FROM [%[5]d AS target]%[9]s) AS t
 			ON %[6]s
 		 WHERE %[7]s IS NULL %[8]s`,
 		strings.Join(qualifiedSrcCols, ", "), // 1
@@ -219,6 +230,7 @@ func nonMatchingRowQuery(
 		// Sufficient to check the first column to see whether there was no matching row
 		targetCols[0], // 7
 		limit,         // 8
+		targetWhere,   // 9
 	), originColNames, nil
 }
 
@@ -0,0 +1,230 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/rpc"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+const runningStatusCompaction = "compacted %d of %d stores"
+
+// compactNode runs an `ALTER TABLE/INDEX ... EXPERIMENTAL COMPACT` statement.
+// The compaction is executed by a Compaction job, and the node waits for the
+// job to finish.
+type compactNode struct {
+	record jobs.Record
+}
+
+// Compact plans a manual compaction of the key span of a table or index.
+// (`ALTER TABLE/INDEX ... EXPERIMENTAL COMPACT` statement)
+// Privileges: admin role.
+func (p *planner) Compact(ctx context.Context, n *tree.Compact) (planNode, error) {
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, errorutil.UnsupportedWithMultiTenancy(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+	}
+	if !p.ExtendedEvalContext().TxnImplicit {
+		return nil, pgerror.Newf(pgcode.InvalidTransactionState,
+			"%s cannot be used inside a transaction", n.StatementTag())
+	}
+	if err := p.RequireAdminRole(ctx, "compact"); err != nil {
+		return nil, err
+	}
+
+	tableDesc, index, err := p.getTableAndIndex(ctx, &n.TableOrIndex, privilege.SELECT)
+	if err != nil {
+		return nil, err
+	}
+	details := jobspb.CompactionDetails{
+		TableID: tableDesc.GetID(),
+		Span:    tableDesc.TableSpan(p.ExecCfg().Codec),
+	}
+	if n.TableOrIndex.Index != "" {
+		details.IndexID = index.ID
+		details.Span = tableDesc.IndexSpan(p.ExecCfg().Codec, index.ID)
+	}
+
+	return &compactNode{
+		record: jobs.Record{
+			Description:   tree.AsStringWithFQNames(n, p.EvalContext().Annotations),
+			Username:      p.User(),
+			DescriptorIDs: []descpb.ID{tableDesc.GetID()},
+			Details:       details,
+			Progress:      jobspb.CompactionProgress{},
+		},
+	}, nil
+}
+
+func (n *compactNode) startExec(params runParams) error {
+	registry := params.p.ExecCfg().JobRegistry
+	_, errCh, err := registry.CreateAndStartJob(params.ctx, nil /* resultsCh */, n.record)
+	if err != nil {
+		return err
+	}
+	return <-errCh
+}
+
+func (*compactNode) Next(runParams) (bool, error) { return false, nil }
+func (*compactNode) Values() tree.Datums          { return nil }
+func (*compactNode) Close(context.Context)        {}
+
+// compactEngineSpan asks the given store to compact the given span of its
+// storage engine, and waits for the compaction to finish.
+func compactEngineSpan(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	nodeID roachpb.NodeID,
+	storeID roachpb.StoreID,
+	span roachpb.Span,
+) error {
+	conn, err := execCfg.DistSender.NodeDialer().Dial(ctx, nodeID, rpc.DefaultClass)
+	if err != nil {
+		return errors.Wrapf(err, "could not dial node ID %d", nodeID)
+	}
+	client := kvserver.NewPerStoreClient(conn)
+	req := &kvserver.CompactEngineSpanRequest{
+		StoreRequestHeader: kvserver.StoreRequestHeader{
+			NodeID:  nodeID,
+			StoreID: storeID,
+		},
+		Span: span,
+	}
+	_, err = client.CompactEngineSpan(ctx, req)
+	return err
+}
+
+// compactionStores returns the stores holding a replica of a range
+// overlapping span, ordered by store ID.
+func compactionStores(
+	ctx context.Context, db *kv.DB, span roachpb.Span,
+) ([]roachpb.ReplicationTarget, error) {
+	var targets []roachpb.ReplicationTarget
+	if err := db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		targets = targets[:0]
+		ranges, err := ScanMetaKVs(ctx, txn, span)
+		if err != nil {
+			return err
+		}
+		seen := make(map[roachpb.StoreID]struct{})
+		var desc roachpb.RangeDescriptor
+		for _, r := range ranges {
+			if err := r.ValueProto(&desc); err != nil {
+				return err
+			}
+			for _, replica := range desc.Replicas().All() {
+				if _, ok := seen[replica.StoreID]; ok {
+					continue
+				}
+				seen[replica.StoreID] = struct{}{}
+				targets = append(targets, roachpb.ReplicationTarget{
+					NodeID:  replica.NodeID,
+					StoreID: replica.StoreID,
+				})
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].StoreID < targets[j].StoreID
+	})
+	return targets, nil
+}
+
+// compactionResumer implements the jobs.Resumer interface for Compaction
+// jobs. A new instance is created for each job.
+type compactionResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &compactionResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+//
+// The stores holding replicas of the span are compacted one at a time in
+// increasing store ID order, and the ID of each compacted store is recorded
+// in the job progress, so that a resumed job skips the stores that were
+// already compacted.
+func (r *compactionResumer) Resume(
+	ctx context.Context, execCtx interface{}, _ chan<- tree.Datums,
+) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.CompactionDetails)
+	progress := *r.job.Progress().GetCompaction()
+
+	targets, err := compactionStores(ctx, execCfg.DB, details.Span)
+	if err != nil {
+		return err
+	}
+	var remaining []roachpb.ReplicationTarget
+	for _, t := range targets {
+		if t.StoreID > progress.LastStoreID {
+			remaining = append(remaining, t)
+		}
+	}
+	progress.StoresTotal = progress.StoresCompacted + int64(len(remaining))
+
+	for _, t := range remaining {
+		log.Infof(ctx, "compacting %s on n%d,s%d", details.Span, t.NodeID, t.StoreID)
+		if err := compactEngineSpan(ctx, execCfg, t.NodeID, t.StoreID, details.Span); err != nil {
+			return errors.Wrapf(err, "compacting store s%d", t.StoreID)
+		}
+		progress.StoresCompacted++
+		progress.LastStoreID = t.StoreID
+		if err := r.job.FractionProgressed(ctx,
+			func(ctx context.Context, d jobspb.ProgressDetails) float32 {
+				*d.(*jobspb.Progress_Compaction).Compaction = progress
+				return float32(progress.StoresCompacted) / float32(progress.StoresTotal)
+			},
+		); err != nil {
+			return err
+		}
+		if err := r.job.RunningStatus(ctx,
+			func(context.Context, jobspb.Details) (jobs.RunningStatus, error) {
+				return jobs.RunningStatus(fmt.Sprintf(
+					runningStatusCompaction, progress.StoresCompacted, progress.StoresTotal,
+				)), nil
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface. There is nothing to
+// undo for the stores that were already compacted.
+func (r *compactionResumer) OnFailOrCancel(context.Context, interface{}) error { return nil }
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeCompaction, func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+		return &compactionResumer{job: job}
+	})
+}
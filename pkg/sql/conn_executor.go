@@ -489,6 +489,7 @@ func (s *Server) newSessionData(args SessionArgs) *sessiondata.SessionData {
 		},
 		LocalOnlySessionData: sessiondata.LocalOnlySessionData{
 			RemoteAddr:        args.RemoteAddr,
+			ClusterName:       args.ClusterName,
 			ResultsBufferSize: args.ConnResultsBufferSize,
 		},
 	}
@@ -628,10 +629,12 @@ func (s *Server) newConnExecutor(
 		portals:   make(map[string]PreparedPortal),
 	}
 	ex.extraTxnState.prepStmtsNamespaceMemAcc = ex.sessionMon.MakeBoundAccount()
+	ex.extraTxnState.resultsBufferMemAcc = ex.sessionMon.MakeBoundAccount()
 	ex.extraTxnState.descCollection = descs.MakeCollection(
 		s.cfg.LeaseManager, s.cfg.Settings, sd, s.cfg.HydratedTables)
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangeJobsCache = make(map[descpb.ID]*jobs.Job)
+	ex.extraTxnState.tablesWritten = make(map[descpb.ID]struct{})
 	ex.mu.ActiveQueries = make(map[ClusterWideID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -857,6 +860,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, prepStmtNamespace{}, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
+		ex.extraTxnState.resultsBufferMemAcc.Close(ctx)
 	}
 
 	if ex.sessionTracing.Enabled() {
@@ -954,6 +958,11 @@ type connExecutor struct {
 		// queued up for the given ID.
 		schemaChangeJobsCache map[descpb.ID]*jobs.Job
 
+		// tablesWritten contains the IDs of the tables written by the
+		// transaction. The results read from these tables are removed from the
+		// result cache when the transaction commits.
+		tablesWritten map[descpb.ID]struct{}
+
 		// autoRetryCounter keeps track of the which iteration of a transaction
 		// auto-retry we're currently in. It's 0 whenever the transaction state is not
 		// stateOpen.
@@ -1003,6 +1012,19 @@ type connExecutor struct {
 		// connExecutor's closure.
 		prepStmtsNamespaceMemAcc mon.BoundAccount
 
+		// resultsBufferMemAcc tracks the memory used to buffer the results of
+		// implicit transactions beyond the regular results buffer, so that
+		// they can be retried automatically. It is cleared when the transaction
+		// finishes or restarts, and should be closed upon connExecutor's
+		// closure. See RestrictedCommandResult.ExtendBuffering.
+		resultsBufferMemAcc mon.BoundAccount
+
+		// resultsStreamingDisabled is set when an implicit transaction is
+		// restarted because its results overflowed the results buffer. All the
+		// results of the following attempts are buffered, for as long as the
+		// session's memory budget allows.
+		resultsStreamingDisabled bool
+
 		// onTxnFinish (if non-nil) will be called when txn is finished (either
 		// committed or aborted). It is set when txn is started but can remain
 		// unset when txn is executed within another higher-level txn.
@@ -1224,6 +1246,10 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 		delete(ex.extraTxnState.schemaChangeJobsCache, k)
 	}
 
+	for k := range ex.extraTxnState.tablesWritten {
+		delete(ex.extraTxnState.tablesWritten, k)
+	}
+
 	ex.extraTxnState.descCollection.ReleaseAll(ctx)
 
 	// Close all portals.
@@ -1232,9 +1258,12 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 		delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 	}
 
+	ex.extraTxnState.resultsBufferMemAcc.Clear(ctx)
+
 	switch ev {
 	case txnCommit, txnRollback:
 		ex.extraTxnState.savepoints.clear()
+		ex.extraTxnState.resultsStreamingDisabled = false
 		// After txn is finished, we need to call onTxnFinish (if it's non-nil).
 		if ex.extraTxnState.onTxnFinish != nil {
 			ex.extraTxnState.onTxnFinish(ev)
@@ -1966,6 +1995,46 @@ func (ex *connExecutor) getRewindTxnCapability() (rewindCapability, bool) {
 	}, true
 }
 
+// canRewindTxn returns whether rewinding to the position previously set
+// through setTxnRewindPos() is currently possible, i.e. whether none of the
+// results produced since then have been delivered to the client.
+func (ex *connExecutor) canRewindTxn() bool {
+	cl := ex.clientComm.LockCommunication()
+	defer cl.Close()
+	return cl.ClientPos() < ex.extraTxnState.txnRewindPos
+}
+
+// restartWithStreamingDisabled returns the event restarting the current
+// implicit transaction after its results overflowed the results buffer, such
+// that all the results of its next attempts are buffered. A nil event is
+// returned if the transaction can no longer be rewound.
+func (ex *connExecutor) restartWithStreamingDisabled(
+	ctx context.Context, stmt tree.Statement,
+) (fsm.Event, fsm.EventPayload) {
+	rc, canAutoRetry := ex.getRewindTxnCapability()
+	if !canAutoRetry {
+		return nil, nil
+	}
+	ex.extraTxnState.resultsStreamingDisabled = true
+	txn := ex.state.mu.txn
+	txn.ManualRestart(ctx, ex.server.cfg.Clock.Now())
+	ev := eventRetriableErr{
+		IsCommit:     fsm.FromBool(isCommit(stmt)),
+		CanAutoRetry: fsm.True,
+	}
+	payload := eventRetriableErrPayload{
+		err: roachpb.NewTransactionRetryWithProtoRefreshError(
+			ErrImplicitTxnResultsOverflow.Error(),
+			txn.ID(),
+			// No updated transaction required; we've already manually updated our
+			// client.Txn.
+			roachpb.Transaction{},
+		),
+		rewCap: rc,
+	}
+	return ev, payload
+}
+
 // isCommit returns true if stmt is a "COMMIT" statement.
 func isCommit(stmt tree.Statement) bool {
 	_, ok := stmt.(*tree.CommitTransaction)
@@ -2135,6 +2204,7 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		TxnModesSetter:       ex,
 		Jobs:                 &ex.extraTxnState.jobs,
 		SchemaChangeJobCache: ex.extraTxnState.schemaChangeJobsCache,
+		TablesWritten:        ex.extraTxnState.tablesWritten,
 		schemaAccessors:      scInterface,
 		sqlStatsCollector:    ex.statsCollector,
 	}
@@ -2161,6 +2231,7 @@ func (ex *connExecutor) resetEvalCtx(evalCtx *extendedEvalContext, txn *kv.Txn,
 	evalCtx.Mon = ex.state.mon
 	evalCtx.PrepareOnly = false
 	evalCtx.SkipNormalize = false
+	evalCtx.StmtDeadline = time.Time{}
 }
 
 // getTransactionState retrieves a text representation of the given state.
@@ -2300,6 +2371,7 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			}
 		}
 		ex.notifyStatsRefresherOfNewTables(ex.Ctx())
+		ex.invalidateCachedResults()
 
 		if err := ex.server.cfg.JobRegistry.Run(
 			ex.ctxHolder.connCtx,
@@ -2359,6 +2431,28 @@ func (ex *connExecutor) cancelQuery(queryID ClusterWideID) bool {
 	return false
 }
 
+// timeoutQuery cancels the query with the given ID because it exceeded
+// statement_timeout or transaction_timeout, timeoutErr being the error it
+// fails with. Unlike cancelQuery, it records the timeout on the query so that
+// its statistics tell it apart from a query canceled by a client.
+func (ex *connExecutor) timeoutQuery(queryID ClusterWideID, timeoutErr error) {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	if queryMeta, exists := ex.mu.ActiveQueries[queryID]; exists {
+		queryMeta.timeoutErr = timeoutErr
+		queryMeta.cancel()
+	}
+}
+
+// queryTimedOut returns whether the query with the given ID was canceled by
+// timeoutQuery.
+func (ex *connExecutor) queryTimedOut(queryID ClusterWideID) bool {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	queryMeta, exists := ex.mu.ActiveQueries[queryID]
+	return exists && queryMeta.timeoutErr != nil
+}
+
 // cancelSession is part of the registrySession interface.
 func (ex *connExecutor) cancelSession() {
 	if ex.onCancelSession == nil {
@@ -2373,6 +2467,11 @@ func (ex *connExecutor) user() security.SQLUsername {
 	return ex.sessionData.User()
 }
 
+// memoryMonitor is part of the registrySession interface.
+func (ex *connExecutor) memoryMonitor() *mon.BytesMonitor {
+	return ex.mon
+}
+
 // serialize is part of the registrySession interface.
 func (ex *connExecutor) serialize() serverpb.Session {
 	ex.mu.RLock()
@@ -2463,6 +2562,7 @@ func (ex *connExecutor) serialize() serverpb.Session {
 		MaxAllocBytes:   ex.mon.MaximumBytes(),
 
 		LastActiveQueryAnon: lastActiveQueryAnon,
+		ClusterName:         ex.sessionData.ClusterName,
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/fsm"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
@@ -269,6 +270,9 @@ func (ex *connExecutor) execStmtInOpenState(
 
 	var timeoutTicker *time.Timer
 	queryTimedOut := false
+	// timeoutErr is the error the statement fails with if it times out, see
+	// stmtDeadline.
+	var timeoutErr error
 	doneAfterFunc := make(chan struct{}, 1)
 
 	// Canceling a query cancels its transaction's context so we take a reference
@@ -294,7 +298,8 @@ func (ex *connExecutor) execStmtInOpenState(
 		// sorts of errors on the result. Rather than trying to impose discipline
 		// in that jungle, we just overwrite them all here with an error that's
 		// nicer to look at for the client.
-		if res != nil && ctx.Err() != nil && res.Err() != nil {
+		queryCanceled := res != nil && ctx.Err() != nil && res.Err() != nil
+		if queryCanceled {
 			// Even in the cases where the error is a retryable error, we want to
 			// intercept the event and payload returned here to ensure that the query
 			// is not retried.
@@ -321,8 +326,14 @@ func (ex *connExecutor) execStmtInOpenState(
 			retEv = eventNonRetriableErr{
 				IsCommit: fsm.FromBool(isCommit(ast)),
 			}
-			res.SetError(sqlerrors.QueryTimeoutError)
-			retPayload = eventNonRetriableErrPayload{err: sqlerrors.QueryTimeoutError}
+			res.SetError(timeoutErr)
+			retPayload = eventNonRetriableErrPayload{err: timeoutErr}
+		}
+
+		if queryTimedOut {
+			ex.logQueryCanceled(ctx, ast, timeoutErr)
+		} else if queryCanceled {
+			ex.logQueryCanceled(ctx, ast, nil /* timeoutErr */)
 		}
 	}
 	// Generally we want to unregister after the auto-commit below. However, in
@@ -394,19 +405,19 @@ func (ex *connExecutor) execStmtInOpenState(
 		p.extendedEvalCtx.Context = ctx
 	}
 
-	// We exempt `SET` statements from the statement timeout, particularly so as
-	// not to block the `SET statement_timeout` command itself.
-	if ex.sessionData.StmtTimeout > 0 && ast.StatementTag() != "SET" {
-		timerDuration := ex.sessionData.StmtTimeout - timeutil.Since(ex.phaseTimes[sessionQueryReceived])
+	var deadline time.Time
+	if deadline, timeoutErr = ex.stmtDeadline(ast); timeoutErr != nil {
+		timerDuration := timeutil.Until(deadline)
 		// There's no need to proceed with execution if the timer has already expired.
 		if timerDuration < 0 {
 			queryTimedOut = true
-			return makeErrEvent(sqlerrors.QueryTimeoutError)
+			return makeErrEvent(timeoutErr)
 		}
+		p.extendedEvalCtx.StmtDeadline = deadline
 		timeoutTicker = time.AfterFunc(
 			timerDuration,
 			func() {
-				ex.cancelQuery(queryID)
+				ex.timeoutQuery(queryID, timeoutErr)
 				queryTimedOut = true
 				doneAfterFunc <- struct{}{}
 			})
@@ -653,6 +664,13 @@ func (ex *connExecutor) execStmtInOpenState(
 	}
 
 	if err := res.Err(); err != nil {
+		if errors.Is(err, ErrImplicitTxnResultsOverflow) {
+			// None of the results of the transaction were flushed to the
+			// client, so restart it with streaming disabled.
+			if ev, payload := ex.restartWithStreamingDisabled(ctx, ast); ev != nil {
+				return ev, payload, nil
+			}
+		}
 		return makeErrEvent(err)
 	}
 
@@ -684,6 +702,56 @@ func (ex *connExecutor) execStmtInOpenState(
 	return nil, nil, nil
 }
 
+// stmtDeadline returns the time at which the execution of the given statement
+// times out, because of statement_timeout or transaction_timeout, whichever
+// expires first, along with the error the statement fails with when it does.
+// The returned error is nil if the statement doesn't time out.
+//
+// We exempt `SET` statements from both timeouts, particularly so as not to
+// block the `SET statement_timeout` command itself, and ROLLBACK from
+// transaction_timeout so that a transaction that timed out can be closed.
+func (ex *connExecutor) stmtDeadline(ast tree.Statement) (time.Time, error) {
+	if ast.StatementTag() == "SET" {
+		return time.Time{}, nil
+	}
+	var deadline time.Time
+	var timeoutErr error
+	if ex.sessionData.StmtTimeout > 0 {
+		deadline = ex.phaseTimes[sessionQueryReceived].Add(ex.sessionData.StmtTimeout)
+		timeoutErr = sqlerrors.QueryTimeoutError
+	}
+	_, isRollback := ast.(*tree.RollbackTransaction)
+	if ex.sessionData.TransactionTimeout > 0 && !isRollback {
+		txnDeadline := ex.state.mu.txnStart.Add(ex.sessionData.TransactionTimeout)
+		if timeoutErr == nil || txnDeadline.Before(deadline) {
+			deadline = txnDeadline
+			timeoutErr = sqlerrors.TxnTimeoutError
+		}
+	}
+	return deadline, timeoutErr
+}
+
+// logQueryCanceled records a query_canceled event for a statement whose
+// execution was interrupted. timeoutErr is the error the statement timed out
+// with, or nil if it was canceled by a client.
+func (ex *connExecutor) logQueryCanceled(ctx context.Context, ast tree.Statement, timeoutErr error) {
+	if ex.executorType == executorTypeInternal {
+		return
+	}
+	ev := &eventpb.QueryCanceled{Reason: "canceled"}
+	switch timeoutErr {
+	case sqlerrors.QueryTimeoutError:
+		ev.Reason = "statement_timeout"
+		ev.Timeout = ex.sessionData.StmtTimeout.String()
+	case sqlerrors.TxnTimeoutError:
+		ev.Reason = "transaction_timeout"
+		ev.Timeout = ex.sessionData.TransactionTimeout.String()
+	}
+	ev.Statement = ast.String()
+	ev.User = ex.sessionData.User().Normalized()
+	log.StructuredEvent(ctx, ev)
+}
+
 func (ex *connExecutor) checkDescriptorTwoVersionInvariant(ctx context.Context) error {
 	var inRetryBackoff func()
 	if knobs := ex.server.cfg.SchemaChangerTestingKnobs; knobs != nil {
@@ -804,6 +872,13 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	// directly. Configure this here.
 	if planner.curPlan.avoidBuffering {
 		res.DisableBuffering()
+	} else if planner.autoCommit && ex.canRewindTxn() {
+		// Buffer the results of implicit transactions further, so that we can
+		// still retry them automatically after they produced more results than
+		// fit in the regular buffer.
+		res.ExtendBuffering(
+			&ex.extraTxnState.resultsBufferMemAcc, ex.extraTxnState.resultsStreamingDisabled,
+		)
 	}
 
 	defer func() {
@@ -835,6 +910,32 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		return nil
 	}
 
+	// If the result of the statement can be cached, serve it from the result
+	// cache if possible. Otherwise, record the result as it is produced so that
+	// it can be added to the cache.
+	resultCacheKey, useResultCache := ex.resultCacheKey(planner)
+	var resultRecorder *resultCacheRecorder
+	if useResultCache {
+		if cached, ok := ex.findCachedResult(ctx, planner, resultCacheKey); ok {
+			planner.curPlan.flags.Set(planFlagExecDone)
+			for _, row := range cached.Rows {
+				if err := res.AddRow(ctx, row); err != nil {
+					return err
+				}
+			}
+			ex.recordStatementSummary(
+				ctx, planner,
+				ex.extraTxnState.autoRetryCounter, res.RowsAffected(), res.Err(), topLevelQueryStats{},
+			)
+			return nil
+		}
+		resultRecorder = &resultCacheRecorder{
+			RestrictedCommandResult: res,
+			maxSize:                 ex.server.cfg.ResultCache.MaxEntrySize(),
+		}
+		res = resultRecorder
+	}
+
 	ex.sessionTracing.TracePlanCheckStart(ctx)
 	distributePlan := getPlanDistribution(
 		ctx, planner, planner.execCfg.NodeID, ex.sessionData.DistSQLMode, planner.curPlan.main,
@@ -888,6 +989,9 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 	)
 	ex.sessionTracing.TraceExecEnd(ctx, res.Err(), res.RowsAffected())
 	ex.statsCollector.phaseTimes[plannerEndExecStmt] = timeutil.Now()
+	if resultRecorder != nil && err == nil {
+		ex.addCachedResult(planner, resultCacheKey, resultRecorder)
+	}
 
 	// Record the statement summary. This also closes the plan if the
 	// plan has not been closed earlier.
@@ -1218,6 +1322,8 @@ func (ex *connExecutor) runObserverStatement(
 		return ex.runShowTransactionState(ctx, res)
 	case *tree.ShowSavepointStatus:
 		return ex.runShowSavepointState(ctx, res)
+	case *tree.ShowTransactionDetails:
+		return ex.runShowTransactionDetails(ctx, res)
 	case *tree.ShowSyntax:
 		return ex.runShowSyntax(ctx, sqlStmt.Statement, res)
 	case *tree.SetTracing:
@@ -1262,6 +1368,39 @@ func (ex *connExecutor) runShowTransactionState(
 	return res.AddRow(ctx, tree.Datums{tree.NewDString(state)})
 }
 
+// runShowTransactionDetails executes a SHOW TRANSACTION DETAILS statement. No
+// row is returned when there is no open transaction.
+//
+// If an error is returned, the connection needs to stop processing queries.
+func (ex *connExecutor) runShowTransactionDetails(
+	ctx context.Context, res RestrictedCommandResult,
+) error {
+	res.SetColumns(ctx, colinfo.ShowTransactionDetailsColumns)
+
+	ex.state.mu.RLock()
+	txn := ex.state.mu.txn
+	ex.state.mu.RUnlock()
+	if _, noTxn := ex.machine.CurState().(stateNoTxn); noTxn || txn == nil {
+		return nil
+	}
+
+	savepoints := tree.NewDArray(types.String)
+	for _, entry := range ex.extraTxnState.savepoints {
+		if err := savepoints.Append(tree.NewDString(string(entry.name))); err != nil {
+			return err
+		}
+	}
+	return res.AddRow(ctx, tree.Datums{
+		tree.NewDUuid(tree.DUuid{UUID: txn.ID()}),
+		tree.NewDString(txn.UserPriority().String()),
+		tree.NewDString("serializable"),
+		tree.TimestampToDecimalDatum(txn.ReadTimestamp()),
+		tree.NewDInt(tree.DInt(ex.extraTxnState.autoRetryCounter)),
+		savepoints,
+		tree.NewDInt(tree.DInt(txn.TrackedWriteBytes())),
+	})
+}
+
 func (ex *connExecutor) runShowLastQueryStatistics(
 	ctx context.Context, res RestrictedCommandResult,
 ) error {
@@ -124,6 +124,7 @@ func (ex *connExecutor) addPreparedStmt(
 	}
 
 	if err := prepared.memAcc.Grow(ctx, int64(len(name))); err != nil {
+		prepared.memAcc.Close(ctx)
 		return nil, err
 	}
 	ex.extraTxnState.prepStmtsNamespace.prepStmts[name] = prepared
@@ -142,7 +143,7 @@ func (ex *connExecutor) prepare(
 	stmt Statement,
 	placeholderHints tree.PlaceholderTypes,
 	origin PreparedStatementOrigin,
-) (*PreparedStatement, error) {
+) (_ *PreparedStatement, retErr error) {
 	if placeholderHints == nil {
 		placeholderHints = make(tree.PlaceholderTypes, stmt.NumPlaceholders)
 	}
@@ -159,9 +160,14 @@ func (ex *connExecutor) prepare(
 		createdAt: timeutil.Now(),
 		origin:    origin,
 	}
-	// NB: if we start caching the plan, we'll want to keep around the memory
-	// account used for the plan, rather than clearing it.
-	defer prepared.memAcc.Clear(ctx)
+	// The memory account is kept for as long as the prepared statement is in
+	// use, so that the memo it holds is accounted for against the session
+	// monitor. It is only released here if the statement fails to prepare.
+	defer func() {
+		if retErr != nil {
+			prepared.memAcc.Close(ctx)
+		}
+	}()
 
 	if stmt.AST == nil {
 		return prepared, nil
@@ -925,6 +925,55 @@ func TestShowLastQueryStatistics(t *testing.T) {
 	}
 }
 
+func TestShowTransactionDetails(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlConn, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(sqlConn)
+	sqlDB.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v STRING)")
+
+	// No row is returned outside of a transaction.
+	require.Empty(t, sqlDB.QueryStr(t, "SHOW TRANSACTION DETAILS"))
+
+	tx, err := sqlConn.Begin()
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	for _, stmt := range []string{
+		"SET TRANSACTION PRIORITY HIGH",
+		"SAVEPOINT a",
+		"SAVEPOINT b",
+		"INSERT INTO t VALUES (1, 'foo'), (2, 'bar')",
+	} {
+		_, err := tx.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	var txnID, priority, isolation, readTS, savepoints string
+	var numRetries, trackedWriteBytes int
+	require.NoError(t, tx.QueryRow("SHOW TRANSACTION DETAILS").Scan(
+		&txnID, &priority, &isolation, &readTS, &numRetries, &savepoints, &trackedWriteBytes,
+	))
+	require.NotEmpty(t, txnID)
+	require.Equal(t, "high", priority)
+	require.Equal(t, "serializable", isolation)
+	require.NotEmpty(t, readTS)
+	require.Equal(t, 0, numRetries)
+	require.Equal(t, "{a,b}", savepoints)
+	require.Greater(t, trackedWriteBytes, 0)
+
+	// The transaction ID matches the one reported by crdb_internal.
+	var otherTxnID string
+	require.NoError(t, tx.QueryRow(
+		"SELECT id::STRING FROM crdb_internal.node_transactions WHERE session_id = "+
+			"(SELECT session_id FROM [SHOW session_id])",
+	).Scan(&otherTxnID))
+	require.Equal(t, otherTxnID, txnID)
+}
+
 // dynamicRequestFilter exposes a filter method which is a
 // kvserverbase.ReplicaRequestFilter but can be set dynamically.
 type dynamicRequestFilter struct {
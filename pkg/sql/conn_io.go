@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/ring"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
@@ -728,6 +729,20 @@ type RestrictedCommandResult interface {
 	// to this CommandResult, will be flushed immediately to the client.
 	// This is currently used for sinkless changefeeds.
 	DisableBuffering()
+
+	// ExtendBuffering can be called during the execution of a statement in an
+	// implicit transaction, before any of the transaction's results have been
+	// flushed, to let its results accumulate further than usual before they
+	// are flushed to the client. As long as no results have been flushed, the
+	// transaction can be retried automatically if it needs to restart.
+	//
+	// The results buffered beyond the usual size are accounted for in acc.
+	// Once they exceed the size up to which the results of implicit
+	// transactions are buffered, AddRow returns ErrImplicitTxnResultsOverflow
+	// so that the transaction can be restarted with streaming disabled. If
+	// streamingDisabled is set, the results are instead buffered for as long
+	// as acc can grow, and only flushed once it can't.
+	ExtendBuffering(acc *mon.BoundAccount, streamingDisabled bool)
 }
 
 // DescribeResult represents the result of a Describe command (for either
@@ -915,6 +930,11 @@ func (r *bufferedCommandResult) DisableBuffering() {
 	panic("cannot disable buffering here")
 }
 
+// ExtendBuffering is part of the RestrictedCommandResult interface.
+func (r *bufferedCommandResult) ExtendBuffering(*mon.BoundAccount, bool) {
+	// All the results are buffered anyway.
+}
+
 // SetError is part of the RestrictedCommandResult interface.
 func (r *bufferedCommandResult) SetError(err error) {
 	r.err = err
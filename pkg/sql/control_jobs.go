@@ -40,26 +40,56 @@ func (n *controlJobsNode) FastPathResults() (int, bool) {
 	return n.numRows, true
 }
 
-func (n *controlJobsNode) startExec(params runParams) error {
-	userIsAdmin, err := params.p.HasAdminRole(params.ctx)
+// checkCanControlJobs checks that the current user can control jobs, and
+// returns whether the user is an admin.
+func (p *planner) checkCanControlJobs(ctx context.Context) (userIsAdmin bool, _ error) {
+	userIsAdmin, err := p.HasAdminRole(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// users can pause/resume/cancel jobs owned by non-admin users
 	// if they have CONTROLJOBS privilege.
 	if !userIsAdmin {
-		hasControlJob, err := params.p.HasRoleOption(params.ctx, roleoption.CONTROLJOB)
+		hasControlJob, err := p.HasRoleOption(ctx, roleoption.CONTROLJOB)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if !hasControlJob {
-			return pgerror.Newf(pgcode.InsufficientPrivilege,
+			return false, pgerror.Newf(pgcode.InsufficientPrivilege,
 				"user %s does not have %s privilege",
-				params.p.User(), roleoption.CONTROLJOB)
+				p.User(), roleoption.CONTROLJOB)
 		}
 	}
+	return userIsAdmin, nil
+}
+
+// checkCanControlJob checks that the current user, who passed
+// checkCanControlJobs, can control the given job.
+func (p *planner) checkCanControlJob(ctx context.Context, job *jobs.Job, userIsAdmin bool) error {
+	if userIsAdmin {
+		return nil
+	}
+	owner := job.Payload().UsernameProto.Decode()
+	ok, err := p.UserHasAdminRole(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	// Owner is an admin but user executing the statement is not.
+	if ok {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only admins can control jobs owned by other admins")
+	}
+	return nil
+}
+
+func (n *controlJobsNode) startExec(params runParams) error {
+	userIsAdmin, err := params.p.checkCanControlJobs(params.ctx)
+	if err != nil {
+		return err
+	}
 
 	reg := params.p.ExecCfg().JobRegistry
 	for {
@@ -87,19 +117,8 @@ func (n *controlJobsNode) startExec(params runParams) error {
 		}
 
 		if job != nil {
-			owner := job.Payload().UsernameProto.Decode()
-
-			if !userIsAdmin {
-				ok, err := params.p.UserHasAdminRole(params.ctx, owner)
-				if err != nil {
-					return err
-				}
-
-				// Owner is an admin but user executing the statement is not.
-				if ok {
-					return pgerror.Newf(pgcode.InsufficientPrivilege,
-						"only admins can control jobs owned by other admins")
-				}
+			if err := params.p.checkCanControlJob(params.ctx, job, userIsAdmin); err != nil {
+				return err
 			}
 		}
 
@@ -61,14 +61,15 @@ func loadSchedule(params runParams, scheduleID tree.Datum) (*jobs.ScheduledJob,
 	env := jobSchedulerEnv(params)
 	schedule := jobs.NewScheduledJob(env)
 
-	// Load schedule expression.  This is needed for resume command, but we
-	// also use this query to check for the schedule existence.
+	// Load schedule expression and next run.  These are needed for resume and
+	// alter commands, but we also use this query to check for the schedule
+	// existence.
 	datums, cols, err := params.ExecCfg().InternalExecutor.QueryWithCols(
 		params.ctx,
 		"load-schedule",
 		params.EvalContext().Txn, sessiondata.InternalExecutorOverride{User: security.RootUserName()},
 		fmt.Sprintf(
-			"SELECT schedule_id, schedule_expr FROM %s WHERE schedule_id = $1",
+			"SELECT schedule_id, schedule_expr, next_run FROM %s WHERE schedule_id = $1",
 			env.ScheduledJobsTableName(),
 		),
 		scheduleID)
@@ -13,7 +13,10 @@ package sql
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"net"
 	"net/url"
 	"sort"
@@ -28,11 +31,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/diagnosticspb"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -51,15 +56,20 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/protoreflect"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -87,12 +97,16 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalBuiltinFunctionsTableID:          crdbInternalBuiltinFunctionsTable,
 		catconstants.CrdbInternalClusterQueriesTableID:            crdbInternalClusterQueriesTable,
 		catconstants.CrdbInternalClusterTransactionsTableID:       crdbInternalClusterTxnsTable,
+		catconstants.CrdbInternalClusterUpgradeStatusTableID:      crdbInternalClusterUpgradeStatusTable,
 		catconstants.CrdbInternalClusterSessionsTableID:           crdbInternalClusterSessionsTable,
+		catconstants.CrdbInternalClusterRoleMembershipsTableID:    crdbInternalClusterRoleMembershipsTable,
 		catconstants.CrdbInternalClusterSettingsTableID:           crdbInternalClusterSettingsTable,
 		catconstants.CrdbInternalCreateStmtsTableID:               crdbInternalCreateStmtsTable,
 		catconstants.CrdbInternalCreateTypeStmtsTableID:           crdbInternalCreateTypeStmtsTable,
+		catconstants.CrdbInternalCompactionsTableID:               crdbInternalCompactionsTable,
 		catconstants.CrdbInternalDatabasesTableID:                 crdbInternalDatabasesTable,
 		catconstants.CrdbInternalFeatureUsageID:                   crdbInternalFeatureUsage,
+		catconstants.CrdbInternalFlowsTableID:                     crdbInternalFlowsTable,
 		catconstants.CrdbInternalForwardDependenciesTableID:       crdbInternalForwardDependenciesTable,
 		catconstants.CrdbInternalGossipNodesTableID:               crdbInternalGossipNodesTable,
 		catconstants.CrdbInternalGossipAlertsTableID:              crdbInternalGossipAlertsTable,
@@ -102,11 +116,20 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalJobsTableID:                      crdbInternalJobsTable,
 		catconstants.CrdbInternalKVNodeStatusTableID:              crdbInternalKVNodeStatusTable,
 		catconstants.CrdbInternalKVStoreStatusTableID:             crdbInternalKVStoreStatusTable,
+		catconstants.CrdbInternalKVStoreDiskStatusTableID:         crdbInternalKVStoreDiskStatusTable,
+		catconstants.CrdbInternalKVRaftStatusTableID:              crdbInternalKVRaftStatusTable,
+		catconstants.CrdbInternalKVRaftProgressTableID:            crdbInternalKVRaftProgressTable,
+		catconstants.CrdbInternalKVFollowerReadStatusTableID:      crdbInternalKVFollowerReadStatusTable,
+		catconstants.CrdbInternalKVConsistencyChecksTableID:       crdbInternalKVConsistencyChecksTable,
 		catconstants.CrdbInternalLeasesTableID:                    crdbInternalLeasesTable,
 		catconstants.CrdbInternalLocalQueriesTableID:              crdbInternalLocalQueriesTable,
 		catconstants.CrdbInternalLocalTransactionsTableID:         crdbInternalLocalTxnsTable,
 		catconstants.CrdbInternalLocalSessionsTableID:             crdbInternalLocalSessionsTable,
+		catconstants.CrdbInternalLingeringIntentsTableID:          crdbInternalLingeringIntentsTable,
 		catconstants.CrdbInternalLocalMetricsTableID:              crdbInternalLocalMetricsTable,
+		catconstants.CrdbInternalNodeDiagnosticsReportTableID:     crdbInternalNodeDiagnosticsReportTable,
+		catconstants.CrdbInternalNodeMemoryMonitorsTableID:        crdbInternalNodeMemoryMonitorsTable,
+		catconstants.CrdbInternalObjectDependenciesTableID:        crdbInternalObjectDependenciesTable,
 		catconstants.CrdbInternalPartitionsTableID:                crdbInternalPartitionsTable,
 		catconstants.CrdbInternalPredefinedCommentsTableID:        crdbInternalPredefinedCommentsTable,
 		catconstants.CrdbInternalRangesNoLeasesTableID:            crdbInternalRangesNoLeasesTable,
@@ -119,12 +142,16 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalTableColumnsTableID:              crdbInternalTableColumnsTable,
 		catconstants.CrdbInternalTableIndexesTableID:              crdbInternalTableIndexesTable,
 		catconstants.CrdbInternalTablesTableLastStatsID:           crdbInternalTablesTableLastStats,
+		catconstants.CrdbInternalTableDiskUsageTableID:            crdbInternalTableDiskUsageTable,
+		catconstants.CrdbInternalTableMVCCStatsTableID:            crdbInternalTableMVCCStatsTable,
 		catconstants.CrdbInternalTablesTableID:                    crdbInternalTablesTable,
 		catconstants.CrdbInternalTransactionStatsTableID:          crdbInternalTransactionStatisticsTable,
 		catconstants.CrdbInternalTxnStatsTableID:                  crdbInternalTxnStatsTable,
+		catconstants.CrdbInternalWebSessionsTableID:               crdbInternalWebSessionsTable,
 		catconstants.CrdbInternalZonesTableID:                     crdbInternalZonesTable,
 		catconstants.CrdbInternalInvalidDescriptorsTableID:        crdbInternalInvalidDescriptorsTable,
 		catconstants.CrdbInternalClusterDatabasePrivilegesTableID: crdbInternalClusterDatabasePrivilegesTable,
+		catconstants.CrdbInternalPreparedStatementsTableID:        crdbInternalPreparedStatementsTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -163,7 +190,7 @@ CREATE TABLE crdb_internal.node_build_info (
 }
 
 var crdbInternalRuntimeInfoTable = virtualSchemaTable{
-	comment: `server parameters, useful to construct connection URLs (RAM, local node only)`,
+	comment: `server parameters, useful to construct connection URLs, and process configuration (RAM, local node only)`,
 	schema: `
 CREATE TABLE crdb_internal.node_runtime_info (
   node_id   INT NOT NULL,
@@ -217,10 +244,88 @@ CREATE TABLE crdb_internal.node_runtime_info (
 				}
 			}
 		}
+
+		for _, row := range processRuntimeInfo(p.ExecCfg().GetMemoryInfo) {
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDString(row[0]),
+				tree.NewDString(row[1]),
+				tree.NewDString(row[2]),
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 }
 
+// crdbInternalNodeMemoryMonitorsTable exposes the tree of SQL memory monitors
+// of the local node, so that memory usage and "memory budget exceeded" errors
+// can be attributed to the layer and the session that consumes the memory.
+var crdbInternalNodeMemoryMonitorsTable = virtualSchemaTable{
+	comment: `SQL memory monitors and their current and peak allocations (RAM, local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_memory_monitors (
+  node_id     INT NOT NULL,
+  level       INT NOT NULL,
+  name        STRING NOT NULL,
+  id          INT NOT NULL,
+  parent_id   INT,
+  session_id  STRING,
+  used        INT NOT NULL,
+  peak        INT NOT NULL,
+  pool_budget INT NOT NULL,
+  reserved    INT NOT NULL,
+  limit_bytes INT
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_memory_monitors"); err != nil {
+			return err
+		}
+		root := p.ExecCfg().RootMemoryMonitor
+		if root == nil {
+			return nil
+		}
+		nodeID, _ := p.ExecCfg().NodeID.OptionalNodeID() // zero if not available
+		sessionsByMonitor := p.ExecCfg().SessionRegistry.sessionsByMemoryMonitor()
+		// The session that each visited monitor belongs to, if any. Monitors
+		// are visited after their pool, so they inherit its session.
+		monitorSessions := make(map[int64]tree.Datum)
+		return root.TraverseTree(func(s mon.MonitorState) error {
+			sessionID := tree.DNull
+			if id, ok := sessionsByMonitor[s.ID]; ok {
+				sessionID = tree.NewDString(id.String())
+			} else if parentSession, ok := monitorSessions[s.ParentID]; ok {
+				sessionID = parentSession
+			}
+			if sessionID != tree.DNull {
+				monitorSessions[s.ID] = sessionID
+			}
+			parentID := tree.DNull
+			if s.ParentID != 0 {
+				parentID = tree.NewDInt(tree.DInt(s.ParentID))
+			}
+			limit := tree.DNull
+			if s.Limit != math.MaxInt64 {
+				limit = tree.NewDInt(tree.DInt(s.Limit))
+			}
+			return addRow(
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDInt(tree.DInt(s.Level)),
+				tree.NewDString(s.Name),
+				tree.NewDInt(tree.DInt(s.ID)),
+				parentID,
+				sessionID,
+				tree.NewDInt(tree.DInt(s.Used)),
+				tree.NewDInt(tree.DInt(s.Peak)),
+				tree.NewDInt(tree.DInt(s.PoolBudget)),
+				tree.NewDInt(tree.DInt(s.Reserved)),
+				limit,
+			)
+		})
+	},
+}
+
 var crdbInternalDatabasesTable = virtualSchemaTable{
 	comment: `databases accessible by the current user (KV scan)`,
 	schema: `
@@ -288,15 +393,20 @@ CREATE TABLE crdb_internal.tables (
   audit_mode               STRING NOT NULL,
   schema_name              STRING NOT NULL,
   parent_schema_id         INT NOT NULL,
-  locality                 TEXT
+  locality                 TEXT,
+  estimated_row_count      INT
 )`,
 	generator: func(ctx context.Context, p *planner, dbDesc *dbdesc.Immutable) (virtualTableGenerator, cleanupFunc, error) {
-		row := make(tree.Datums, 14)
+		row := make(tree.Datums, 16)
 		worker := func(pusher rowPusher) error {
 			descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
 			if err != nil {
 				return err
 			}
+			rowCounts, err := getTableRowCounts(ctx, p)
+			if err != nil {
+				return err
+			}
 			dbNames := make(map[descpb.ID]string)
 			scNames := make(map[descpb.ID]string)
 			scNames[keys.PublicSchemaID] = sessiondata.PublicSchemaName
@@ -339,6 +449,14 @@ CREATE TABLE crdb_internal.tables (
 					}
 					locality = tree.NewDString(f.String())
 				}
+				// Virtual tables don't have statistics.
+				rowCount := tree.DNull
+				if !table.IsVirtualTable() {
+					rowCount = tree.NewDInt(0)
+					if cnt, ok := rowCounts[tree.DInt(table.GetID())]; ok {
+						rowCount = cnt
+					}
+				}
 				row = row[:0]
 				row = append(row,
 					tree.NewDInt(tree.DInt(int64(table.GetID()))),
@@ -357,6 +475,7 @@ CREATE TABLE crdb_internal.tables (
 					tree.NewDString(scName),
 					tree.NewDInt(tree.DInt(int64(table.GetParentSchemaID()))),
 					locality,
+					rowCount,
 				)
 				return pusher.pushRow(row...)
 			}
@@ -414,30 +533,11 @@ CREATE TABLE crdb_internal.table_row_statistics (
   estimated_row_count        INT
 )`,
 	populate: func(ctx context.Context, p *planner, db *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
-		// Collect the latests statistics for all tables.
-		query := `
-           SELECT s."tableID", max(s."rowCount")
-             FROM system.table_statistics AS s
-             JOIN (
-                    SELECT "tableID", max("createdAt") AS last_dt
-                      FROM system.table_statistics
-                     GROUP BY "tableID"
-                  ) AS l ON l."tableID" = s."tableID" AND l.last_dt = s."createdAt"
-            GROUP BY s."tableID"`
-		statRows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
-			ctx, "crdb-internal-statistics-table", p.txn,
-			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
-			query)
+		statMap, err := getTableRowCounts(ctx, p)
 		if err != nil {
 			return err
 		}
 
-		// Convert statistics into map: tableID -> rowCount.
-		statMap := make(map[tree.DInt]tree.Datum)
-		for _, r := range statRows {
-			statMap[tree.MustBeDInt(r[0])] = r[1]
-		}
-
 		// Walk over all available tables and show row count for each of them
 		// using collected statistics.
 		return forEachTableDescAll(ctx, p, db, virtualMany,
@@ -461,6 +561,179 @@ CREATE TABLE crdb_internal.table_row_statistics (
 	},
 }
 
+// getTableRowCounts returns the row count of the latest statistics collected
+// for each table, keyed by table ID.
+func getTableRowCounts(ctx context.Context, p *planner) (map[tree.DInt]tree.Datum, error) {
+	// Collect the latests statistics for all tables.
+	query := `
+           SELECT s."tableID", max(s."rowCount")
+             FROM system.table_statistics AS s
+             JOIN (
+                    SELECT "tableID", max("createdAt") AS last_dt
+                      FROM system.table_statistics
+                     GROUP BY "tableID"
+                  ) AS l ON l."tableID" = s."tableID" AND l.last_dt = s."createdAt"
+            GROUP BY s."tableID"`
+	statRows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+		ctx, "crdb-internal-statistics-table", p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert statistics into map: tableID -> rowCount.
+	statMap := make(map[tree.DInt]tree.Datum, len(statRows))
+	for _, r := range statRows {
+		statMap[tree.MustBeDInt(r[0])] = r[1]
+	}
+	return statMap, nil
+}
+
+// crdbInternalTableDiskUsageTable reports the approximate disk usage of each
+// table. It is kept separate from crdb_internal.tables because it reads the
+// statistics of every range, which SHOW TABLES only needs WITH DETAILS.
+var crdbInternalTableDiskUsageTable = virtualSchemaTable{
+	comment: `approximate disk usage of the tables accessible by current user (KV scan; expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.table_disk_usage (
+  table_id               INT NOT NULL,
+  approximate_disk_bytes INT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		// Range metadata is only available to the system tenant.
+		if !p.ExecCfg().Codec.ForSystemTenant() {
+			return nil
+		}
+		diskBytes, err := getTableDiskBytes(ctx, p)
+		if err != nil {
+			return err
+		}
+		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		for _, desc := range descs {
+			table, ok := desc.(catalog.TableDescriptor)
+			if !ok || table.IsVirtualTable() || p.CheckAnyPrivilege(ctx, table) != nil {
+				continue
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(table.GetID())),
+				tree.NewDInt(tree.DInt(diskBytes[table.GetID()])),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// getTableDiskBytes returns the approximate number of bytes stored for each
+// table, keyed by table ID. The size of a range is the total size of its keys
+// and values according to its MVCC statistics, and is attributed to the table
+// containing the start of the range. Range metadata is only available to the
+// system tenant.
+func getTableDiskBytes(ctx context.Context, p *planner) (map[descpb.ID]int64, error) {
+	tableIDs, rangeStarts, err := getTableRangeStarts(ctx, p.txn, roachpb.Span{
+		Key:    keys.TableDataMin,
+		EndKey: keys.TableDataMax,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var startKeys []roachpb.Key
+	for _, id := range tableIDs {
+		startKeys = append(startKeys, rangeStarts[id]...)
+	}
+	stats, err := getRangeStats(ctx, p.txn, startKeys)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[descpb.ID]int64, len(tableIDs))
+	for _, id := range tableIDs {
+		for i := range rangeStarts[id] {
+			sizes[id] += stats[i].Total()
+		}
+		stats = stats[len(rangeStarts[id]):]
+	}
+	return sizes, nil
+}
+
+// getTableRangeStarts scans the range descriptors of the ranges overlapping
+// span, and returns the start keys of the ranges grouped by the table
+// containing them, along with the IDs of these tables in key order. The start
+// key of the first range is clamped to span.Key. Range metadata is only
+// available to the system tenant.
+func getTableRangeStarts(
+	ctx context.Context, txn *kv.Txn, span roachpb.Span,
+) (tableIDs []descpb.ID, rangeStarts map[descpb.ID][]roachpb.Key, _ error) {
+	ranges, err := ScanMetaKVs(ctx, txn, span)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeStarts = make(map[descpb.ID][]roachpb.Key)
+	var desc roachpb.RangeDescriptor
+	for _, r := range ranges {
+		if err := r.ValueProto(&desc); err != nil {
+			return nil, nil, err
+		}
+		startKey := desc.StartKey.AsRawKey()
+		if startKey.Compare(span.Key) < 0 {
+			startKey = span.Key
+		}
+		_, tableID, err := keys.SystemSQLCodec.DecodeTablePrefix(startKey)
+		if err != nil {
+			// The range starts past the table data, e.g. in a tenant keyspace.
+			continue
+		}
+		id := descpb.ID(tableID)
+		if _, ok := rangeStarts[id]; !ok {
+			tableIDs = append(tableIDs, id)
+		}
+		rangeStarts[id] = append(rangeStarts[id], startKey)
+	}
+	return tableIDs, rangeStarts, nil
+}
+
+// sampleRangeStarts returns at most maxSampled evenly spaced keys of
+// rangeStarts.
+func sampleRangeStarts(rangeStarts []roachpb.Key, maxSampled int) []roachpb.Key {
+	n := len(rangeStarts)
+	if n <= maxSampled {
+		return rangeStarts
+	}
+	sampled := make([]roachpb.Key, maxSampled)
+	for i := range sampled {
+		sampled[i] = rangeStarts[i*n/maxSampled]
+	}
+	return sampled
+}
+
+// getRangeStats returns the MVCC statistics of the ranges containing the
+// given keys, in the same order, reading them in a single batch.
+func getRangeStats(
+	ctx context.Context, txn *kv.Txn, rangeKeys []roachpb.Key,
+) ([]enginepb.MVCCStats, error) {
+	if len(rangeKeys) == 0 {
+		return nil, nil
+	}
+	var b kv.Batch
+	for _, key := range rangeKeys {
+		b.AddRawRequest(&roachpb.RangeStatsRequest{
+			RequestHeader: roachpb.RequestHeader{Key: key},
+		})
+	}
+	if err := txn.Run(ctx, &b); err != nil {
+		return nil, err
+	}
+	stats := make([]enginepb.MVCCStats, len(rangeKeys))
+	for i, resp := range b.RawResponse().Responses {
+		stats[i] = resp.GetInner().(*roachpb.RangeStatsResponse).MVCCStats
+	}
+	return stats, nil
+}
+
 // TODO(tbg): prefix with kv_.
 var crdbInternalSchemaChangesTable = virtualSchemaTable{
 	comment: `ongoing schema changes, across all descriptors accessible by current user (KV scan; expensive!)`,
@@ -588,7 +861,11 @@ CREATE TABLE crdb_internal.jobs (
 	fraction_completed 		FLOAT,
 	high_water_timestamp	DECIMAL,
 	error              		STRING,
-	coordinator_id     		INT
+	coordinator_id     		INT,
+	conversion_report  		JSONB,
+	details            		JSONB,
+	num_runs           		INT,
+	last_run           		TIMESTAMP
 )`,
 	comment: `decoded job metadata from system.jobs (KV scan)`,
 	generator: func(ctx context.Context, p *planner, _ *dbdesc.Immutable) (virtualTableGenerator, cleanupFunc, error) {
@@ -605,7 +882,8 @@ CREATE TABLE crdb_internal.jobs (
 
 		// Beware: we're querying system.jobs as root; we need to be careful to filter
 		// out results that the current user is not able to see.
-		query := `SELECT id, status, created, payload, progress FROM system.jobs`
+		query := `SELECT id, status, created, payload, ` +
+			jobs.ProgressColumn(ctx, p.ExecCfg().Settings) + ` FROM system.jobs`
 		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
 			ctx, "crdb-internal-jobs-table", p.txn,
 			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
@@ -635,7 +913,7 @@ CREATE TABLE crdb_internal.jobs (
 		}
 
 		// We'll reuse this container on each loop.
-		container := make(tree.Datums, 0, 16)
+		container := make(tree.Datums, 0, 20)
 		return func() (datums tree.Datums, e error) {
 			// Loop while we need to skip a row.
 			for {
@@ -647,8 +925,9 @@ CREATE TABLE crdb_internal.jobs (
 				id, status, created, payloadBytes, progressBytes := r[0], r[1], r[2], r[3], r[4]
 
 				var jobType, description, statement, username, descriptorIDs, started, runningStatus,
-					finished, modified, fractionCompleted, highWaterTimestamp, errorStr, leaseNode = tree.DNull,
-					tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull,
+					finished, modified, fractionCompleted, highWaterTimestamp, errorStr, leaseNode,
+					conversionReport, details, numRuns, lastRun = tree.DNull, tree.DNull, tree.DNull, tree.DNull,
+					tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull,
 					tree.DNull, tree.DNull, tree.DNull, tree.DNull, tree.DNull
 
 				// Extract data from the payload.
@@ -702,6 +981,26 @@ CREATE TABLE crdb_internal.jobs (
 						leaseNode = tree.NewDInt(tree.DInt(payload.Lease.NodeID))
 					}
 					errorStr = tree.NewDString(payload.Error)
+					if details := payload.GetImport(); details != nil && len(details.ConversionReport) > 0 {
+						notes := json.NewArrayBuilder(len(details.ConversionReport))
+						for _, n := range details.ConversionReport {
+							note := json.NewObjectBuilder(3)
+							note.Add("object", json.FromString(n.Object))
+							note.Add("clause", json.FromString(n.Clause))
+							note.Add("action", json.FromString(n.Action))
+							notes.Add(note.Build())
+						}
+						conversionReport = tree.NewDJSON(notes.Build())
+					}
+					details, err = jobDetailsToJSON(payload)
+					if err != nil {
+						return nil, err
+					}
+					numRuns = tree.NewDInt(tree.DInt(payload.NumRuns))
+					lastRun, err = tsOrNull(payload.LastRunMicros)
+					if err != nil {
+						return nil, err
+					}
 				}
 
 				// Extract data from the progress field.
@@ -757,6 +1056,10 @@ CREATE TABLE crdb_internal.jobs (
 					highWaterTimestamp,
 					errorStr,
 					leaseNode,
+					conversionReport,
+					details,
+					numRuns,
+					lastRun,
 				)
 				return container, nil
 			}
@@ -764,6 +1067,185 @@ CREATE TABLE crdb_internal.jobs (
 	},
 }
 
+// jobDetailsToJSON returns the JSONB representation of the type-specific
+// details of a job, or NULL if the job has none. As in the description of the
+// job, the credentials in URIs are redacted and encryption keys are omitted.
+func jobDetailsToJSON(payload *jobspb.Payload) (tree.Datum, error) {
+	var msg protoutil.Message
+	switch d := payload.UnwrapDetails().(type) {
+	case nil:
+		return tree.DNull, nil
+	case jobspb.BackupDetails:
+		d.URI = redactJobURI(d.URI)
+		d.CollectionURI = redactJobURI(d.CollectionURI)
+		d.URIsByLocalityKV = redactJobURIMap(d.URIsByLocalityKV)
+		d.EncryptionOptions = nil
+		msg = &d
+	case jobspb.RestoreDetails:
+		d.URIs = redactJobURIs(d.URIs)
+		localityInfo := make([]jobspb.RestoreDetails_BackupLocalityInfo, len(d.BackupLocalityInfo))
+		for i := range d.BackupLocalityInfo {
+			localityInfo[i].URIsByOriginalLocalityKV = redactJobURIMap(
+				d.BackupLocalityInfo[i].URIsByOriginalLocalityKV,
+			)
+		}
+		d.BackupLocalityInfo = localityInfo
+		d.Encryption = nil
+		msg = &d
+	case jobspb.ImportDetails:
+		d.URIs = redactJobURIs(d.URIs)
+		msg = &d
+	case jobspb.ChangefeedDetails:
+		d.SinkURI = redactJobURI(d.SinkURI)
+		msg = &d
+	case jobspb.SchemaChangeDetails:
+		msg = &d
+	case jobspb.CreateStatsDetails:
+		msg = &d
+	case jobspb.SchemaChangeGCDetails:
+		msg = &d
+	case jobspb.TypeSchemaChangeDetails:
+		msg = &d
+	case jobspb.BatchedDMLDetails:
+		msg = &d
+	case jobspb.CompactionDetails:
+		msg = &d
+	default:
+		return nil, errors.AssertionFailedf("unknown job details type %T", d)
+	}
+	j, err := protoreflect.MessageToJSON(msg, false /* emitDefaults */)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDJSON(j), nil
+}
+
+// redactJobURI redacts the password and the values of the query parameters of
+// a URI stored in job details, which hold the credentials of the external
+// storage it designates.
+func redactJobURI(uri string) string {
+	if uri == "" {
+		return uri
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "redacted"
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "redacted")
+		}
+	}
+	params := u.Query()
+	for param := range params {
+		params.Set(param, "redacted")
+	}
+	u.RawQuery = params.Encode()
+	return u.String()
+}
+
+func redactJobURIs(uris []string) []string {
+	if uris == nil {
+		return nil
+	}
+	res := make([]string, len(uris))
+	for i, uri := range uris {
+		res[i] = redactJobURI(uri)
+	}
+	return res
+}
+
+func redactJobURIMap(uris map[string]string) map[string]string {
+	if uris == nil {
+		return nil
+	}
+	res := make(map[string]string, len(uris))
+	for k, uri := range uris {
+		res[k] = redactJobURI(uri)
+	}
+	return res
+}
+
+// crdbInternalCompactionsTable exposes the compaction jobs started by `ALTER
+// TABLE/INDEX ... EXPERIMENTAL COMPACT` that have not finished yet.
+var crdbInternalCompactionsTable = virtualSchemaTable{
+	comment: `ongoing manual compactions of table and index spans (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.compactions (
+  job_id             INT NOT NULL,
+  status             STRING NOT NULL,
+  description        STRING NOT NULL,
+  table_id           INT NOT NULL,
+  index_id           INT,
+  start_key          BYTES NOT NULL,
+  end_key            BYTES NOT NULL,
+  stores_compacted   INT NOT NULL,
+  stores_total       INT NOT NULL,
+  fraction_completed FLOAT NOT NULL,
+  created            TIMESTAMP NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.compactions"); err != nil {
+			return err
+		}
+		query := fmt.Sprintf(
+			`SELECT id, status, created, payload, %s FROM system.jobs WHERE status NOT IN ('%s', '%s', '%s')`,
+			jobs.ProgressColumn(ctx, p.ExecCfg().Settings),
+			jobs.StatusSucceeded, jobs.StatusFailed, jobs.StatusCanceled,
+		)
+		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+			ctx, "crdb-internal-compactions-table", p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			query)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			id, status, created, payloadBytes, progressBytes := r[0], r[1], r[2], r[3], r[4]
+			payload, err := jobs.UnmarshalPayload(payloadBytes)
+			if err != nil {
+				return err
+			}
+			details := payload.GetCompaction()
+			if details == nil {
+				continue
+			}
+			var progress jobspb.CompactionProgress
+			var fractionCompleted float32
+			if progressBytes != tree.DNull {
+				jobProgress, err := jobs.UnmarshalProgress(progressBytes)
+				if err != nil {
+					return err
+				}
+				if c := jobProgress.GetCompaction(); c != nil {
+					progress = *c
+				}
+				fractionCompleted = jobProgress.GetFractionCompleted()
+			}
+			indexID := tree.DNull
+			if details.IndexID != 0 {
+				indexID = tree.NewDInt(tree.DInt(details.IndexID))
+			}
+			if err := addRow(
+				id,
+				status,
+				tree.NewDString(payload.Description),
+				tree.NewDInt(tree.DInt(details.TableID)),
+				indexID,
+				tree.NewDBytes(tree.DBytes(details.Span.Key)),
+				tree.NewDBytes(tree.DBytes(details.Span.EndKey)),
+				tree.NewDInt(tree.DInt(progress.StoresCompacted)),
+				tree.NewDInt(tree.DInt(progress.StoresTotal)),
+				tree.NewDFloat(tree.DFloat(fractionCompleted)),
+				created,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 type stmtList []stmtKey
 
 func (s stmtList) Len() int {
@@ -820,7 +1302,9 @@ CREATE TABLE crdb_internal.node_statement_statistics (
   bytes_read_var      FLOAT NOT NULL,
   rows_read_avg       FLOAT NOT NULL,
   rows_read_var       FLOAT NOT NULL,
-  implicit_txn        BOOL NOT NULL
+  implicit_txn        BOOL NOT NULL,
+  timed_out_count     INT NOT NULL,
+  canceled_count      INT NOT NULL
 )`,
 	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
 		hasViewActivity, err := p.HasRoleOption(ctx, roleoption.VIEWACTIVITY)
@@ -914,6 +1398,8 @@ CREATE TABLE crdb_internal.node_statement_statistics (
 					tree.NewDFloat(tree.DFloat(s.mu.data.RowsRead.Mean)),
 					tree.NewDFloat(tree.DFloat(s.mu.data.RowsRead.GetVariance(s.mu.data.Count))),
 					tree.MakeDBool(tree.DBool(stmtKey.implicitTxn)),
+					tree.NewDInt(tree.DInt(s.mu.data.TimedOutCount)),
+					tree.NewDInt(tree.DInt(s.mu.data.CanceledCount)),
 				)
 				s.mu.Unlock()
 				if err != nil {
@@ -1207,6 +1693,44 @@ CREATE TABLE crdb_internal.session_variables (
 	},
 }
 
+// crdbInternalPreparedStatementsTable exposes the prepared statements of the
+// current session, along with the memory they use.
+var crdbInternalPreparedStatementsTable = virtualSchemaTable{
+	comment: `prepared statements of the current session (RAM)`,
+	schema: `
+CREATE TABLE crdb_internal.prepared_statements (
+  name            STRING NOT NULL,
+  statement       STRING NOT NULL,
+  parameter_types STRING[] NOT NULL,
+  created         TIMESTAMPTZ NOT NULL,
+  memory_bytes    INT NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		for name, stmt := range p.preparedStatements.List() {
+			paramTypes := tree.NewDArray(types.String)
+			for _, typ := range stmt.PrepareMetadata.PlaceholderTypesInfo.Types {
+				if err := paramTypes.Append(tree.NewDString(typ.SQLString())); err != nil {
+					return err
+				}
+			}
+			created, err := tree.MakeDTimestampTZ(stmt.createdAt, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDString(name),
+				tree.NewDString(stmt.SQL),
+				paramTypes,
+				created,
+				tree.NewDInt(tree.DInt(stmt.memAcc.Used())),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 const txnsSchemaPattern = `
 CREATE TABLE crdb_internal.%s (
   id UUID,                 -- the unique ID of the transaction
@@ -1217,7 +1741,11 @@ CREATE TABLE crdb_internal.%s (
   application_name STRING, -- the name of the application as per SET application_name
   num_stmts INT,           -- the number of statements executed so far
   num_retries INT,         -- the number of times the transaction was restarted
-  num_auto_retries INT     -- the number of times the transaction was automatically restarted
+  num_auto_retries INT,    -- the number of times the transaction was automatically restarted
+  user_name STRING,        -- the user running the transaction
+  isolation STRING,        -- the isolation level of the transaction
+  priority STRING,         -- the priority of the transaction
+  idle BOOL                -- whether no statement is currently executing in the transaction
 )`
 
 var crdbInternalLocalTxnsTable = virtualSchemaTable{
@@ -1239,6 +1767,150 @@ var crdbInternalLocalTxnsTable = virtualSchemaTable{
 	},
 }
 
+// crdbInternalFlowsTable exposes the flows that other nodes set up on this
+// node on behalf of distributed queries, together with the statement they
+// belong to. Flows running on the gateway of their query aren't included.
+var crdbInternalFlowsTable = virtualSchemaTable{
+	comment: "running and queued remote DistSQL flows (RAM; local node only)",
+	schema: `
+CREATE TABLE crdb_internal.flows (
+  flow_id         UUID NOT NULL,      -- the ID of the flow
+  node_id         INT NOT NULL,       -- the node running the flow
+  gateway_node_id INT NOT NULL,       -- the node that planned the flow
+  stmt            STRING,             -- the anonymized statement the flow belongs to
+  since           TIMESTAMP NOT NULL, -- when the flow started running, or was queued
+  status          STRING NOT NULL     -- 'running' or 'queued'
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.flows"); err != nil {
+			return err
+		}
+		nodeID, _ := p.execCfg.NodeID.OptionalNodeID() // zero if not available
+		flows := p.execCfg.DistSQLSrv.Flows()
+		sort.Slice(flows, func(i, j int) bool {
+			return flows[i].Timestamp.Before(flows[j].Timestamp)
+		})
+		for _, f := range flows {
+			stmt := tree.DNull
+			if f.StatementSQL != "" {
+				stmt = tree.NewDString(f.StatementSQL)
+			}
+			since, err := tree.MakeDTimestamp(f.Timestamp, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			status := "running"
+			if f.Queued {
+				status = "queued"
+			}
+			if err := addRow(
+				tree.NewDUuid(tree.DUuid{UUID: f.FlowID.UUID}),
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.NewDInt(tree.DInt(f.Gateway)),
+				stmt,
+				since,
+				tree.NewDString(status),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalWebSessionsTable exposes the sessions used to authenticate to
+// the DB Console and the HTTP APIs, without their secrets. Admin users can see
+// the sessions of all users, and other users only their own.
+var crdbInternalWebSessionsTable = virtualSchemaTable{
+	comment: "DB Console and HTTP API login sessions visible by the current user",
+	schema: `
+CREATE TABLE crdb_internal.web_sessions (
+  id             INT NOT NULL,
+  username       STRING NOT NULL,
+  created        TIMESTAMP NOT NULL,
+  expires        TIMESTAMP NOT NULL,
+  revoked        TIMESTAMP,
+  last_used      TIMESTAMP NOT NULL,
+  client_address STRING              -- the IP address the user logged in from, if known
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		isAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return err
+		}
+		query := `
+SELECT id, username, "createdAt", "expiresAt", "revokedAt", "lastUsedAt", "auditInfo"
+  FROM system.web_sessions`
+		var args []interface{}
+		if !isAdmin {
+			query += ` WHERE username = $1`
+			args = append(args, p.User().Normalized())
+		}
+		query += ` ORDER BY "createdAt", id`
+		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+			ctx, "crdb-internal-web-sessions-table", p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			query, args...)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if err := addRow(r...); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalNodeDiagnosticsReportTable exposes the anonymized diagnostics
+// report that the local node would send next if diagnostics reporting is
+// enabled, along with the URL it would be sent to. Reading it does not reset
+// the reported counters.
+var crdbInternalNodeDiagnosticsReportTable = virtualSchemaTable{
+	comment: "diagnostics report the local node would send (RPC)",
+	schema: `
+CREATE TABLE crdb_internal.node_diagnostics_report (
+  node_id       INT NOT NULL,
+  reporting_url STRING,       -- NULL if the reporting URL is disabled
+  report        JSONB NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_diagnostics_report"); err != nil {
+			return err
+		}
+		ss, err := p.ExecCfg().NodesStatusServer.OptionalNodesStatusServer(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+		if err != nil {
+			return err
+		}
+		report, err := ss.Diagnostics(ctx, &serverpb.DiagnosticsRequest{NodeId: "local"})
+		if err != nil {
+			return err
+		}
+		reportJSON, err := protoreflect.MessageToJSON(report, false /* emitDefaults */)
+		if err != nil {
+			return err
+		}
+		// The query parameters of the reporting URL are sent along with the
+		// report, so they are shown as well.
+		clusterInfo := diagnosticspb.ClusterInfo{
+			ClusterID:  p.ExecCfg().ClusterID(),
+			IsInsecure: p.ExecCfg().RPCContext.Config.Insecure,
+			IsInternal: ClusterIsInternal(&p.ExecCfg().Settings.SV),
+		}
+		reportingURL := tree.DNull
+		if u := diagnosticspb.BuildReportingURL(&clusterInfo, &report.Node, nil /* knobs */); u != nil {
+			reportingURL = tree.NewDString(u.String())
+		}
+		return addRow(
+			tree.NewDInt(tree.DInt(report.Node.NodeID)),
+			reportingURL,
+			tree.NewDJSON(reportJSON),
+		)
+	},
+}
+
 var crdbInternalClusterTxnsTable = virtualSchemaTable{
 	comment: "running user transactions visible by the current user (cluster RPC; expensive!)",
 	schema:  fmt.Sprintf(txnsSchemaPattern, "cluster_transactions"),
@@ -1278,6 +1950,10 @@ func populateTransactionsTable(
 				tree.NewDInt(tree.DInt(txn.NumStatementsExecuted)),
 				tree.NewDInt(tree.DInt(txn.NumRetries)),
 				tree.NewDInt(tree.DInt(txn.NumAutoRetries)),
+				tree.NewDString(session.Username),
+				tree.NewDString(tree.SerializableIsolation.String()),
+				tree.NewDString(txn.Priority),
+				tree.MakeDBool(len(session.ActiveQueries) == 0),
 			); err != nil {
 				return err
 			}
@@ -1298,6 +1974,10 @@ func populateTransactionsTable(
 				tree.DNull,                             // NumStatementsExecuted
 				tree.DNull,                             // NumRetries
 				tree.DNull,                             // NumAutoRetries
+				tree.DNull,                             // user name
+				tree.DNull,                             // isolation
+				tree.DNull,                             // priority
+				tree.DNull,                             // idle
 			); err != nil {
 				return err
 			}
@@ -1318,7 +1998,9 @@ CREATE TABLE crdb_internal.%s (
   client_address   STRING,         -- the address of the client that issued the query
   application_name STRING,         -- the name of the application as per SET application_name
   distributed      BOOL,           -- whether the query is running distributed
-  phase            STRING          -- the current execution phase
+  phase            STRING,         -- the current execution phase
+  INDEX(user_name),
+  INDEX(application_name)
 )`
 
 func (p *planner) makeSessionsRequest(ctx context.Context) (serverpb.ListSessionsRequest, error) {
@@ -1368,45 +2050,134 @@ func getSessionID(session serverpb.Session) tree.Datum {
 
 // crdbInternalLocalQueriesTable exposes the list of running queries
 // on the current node. The results are dependent on the current user.
-var crdbInternalLocalQueriesTable = virtualSchemaTable{
-	comment: "running queries visible by current user (RAM; local node only)",
-	schema:  fmt.Sprintf(queriesSchemaPattern, "node_queries"),
-	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
-		req, err := p.makeSessionsRequest(ctx)
-		if err != nil {
-			return err
-		}
-		response, err := p.extendedEvalCtx.SQLStatusServer.ListLocalSessions(ctx, &req)
-		if err != nil {
-			return err
-		}
-		return populateQueriesTable(ctx, addRow, response)
+var crdbInternalLocalQueriesTable = makeQueriesTable(
+	"running queries visible by current user (RAM; local node only)",
+	"node_queries",
+	func(
+		ctx context.Context, p *planner, req *serverpb.ListSessionsRequest,
+	) (*serverpb.ListSessionsResponse, error) {
+		return p.extendedEvalCtx.SQLStatusServer.ListLocalSessions(ctx, req)
 	},
-}
+)
 
 // crdbInternalClusterQueriesTable exposes the list of running queries
 // on the entire cluster. The result is dependent on the current user.
-var crdbInternalClusterQueriesTable = virtualSchemaTable{
-	comment: "running queries visible by current user (cluster RPC; expensive!)",
-	schema:  fmt.Sprintf(queriesSchemaPattern, "cluster_queries"),
-	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+var crdbInternalClusterQueriesTable = makeQueriesTable(
+	"running queries visible by current user (cluster RPC; expensive!)",
+	"cluster_queries",
+	func(
+		ctx context.Context, p *planner, req *serverpb.ListSessionsRequest,
+	) (*serverpb.ListSessionsResponse, error) {
+		return p.extendedEvalCtx.SQLStatusServer.ListSessions(ctx, req)
+	},
+)
+
+// sessionsFilter restricts the sessions whose queries are listed in the
+// node_queries and cluster_queries tables. A nil field doesn't filter
+// anything.
+type sessionsFilter struct {
+	username        *string
+	applicationName *string
+}
+
+func (f sessionsFilter) matches(session *serverpb.Session) bool {
+	return (f.username == nil || *f.username == session.Username) &&
+		(f.applicationName == nil || *f.applicationName == session.ApplicationName)
+}
+
+// makeQueriesTable constructs a virtual table listing the queries of the
+// sessions returned by listSessions. Equality filters on user_name and
+// application_name are served by virtual indexes, which push the filter down
+// to the ListSessions request so that the nodes only return the matching
+// sessions.
+func makeQueriesTable(
+	comment string,
+	name string,
+	listSessions func(
+		ctx context.Context, p *planner, req *serverpb.ListSessionsRequest,
+	) (*serverpb.ListSessionsResponse, error),
+) virtualSchemaTable {
+	populate := func(
+		ctx context.Context, p *planner, addRow func(...tree.Datum) error, filter sessionsFilter,
+	) error {
 		req, err := p.makeSessionsRequest(ctx)
 		if err != nil {
 			return err
 		}
-		response, err := p.extendedEvalCtx.SQLStatusServer.ListSessions(ctx, &req)
+		if filter.username != nil {
+			// Users that may only see their own sessions see nothing when asking
+			// for the sessions of another user.
+			if req.Username != "" && req.Username != *filter.username {
+				return nil
+			}
+			req.Username = *filter.username
+		}
+		if filter.applicationName != nil {
+			// The empty application name is not pushed down, since it means "any
+			// application" in the request. The sessions are filtered again by
+			// populateQueriesTable regardless.
+			req.ApplicationName = *filter.applicationName
+		}
+		response, err := listSessions(ctx, p, &req)
 		if err != nil {
 			return err
 		}
-		return populateQueriesTable(ctx, addRow, response)
-	},
+		return populateQueriesTable(ctx, addRow, response, filter)
+	}
+	return virtualSchemaTable{
+		comment: comment,
+		schema:  fmt.Sprintf(queriesSchemaPattern, name),
+		populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+			return populate(ctx, p, addRow, sessionsFilter{})
+		},
+		indexes: []virtualIndex{
+			{
+				populate: func(ctx context.Context, constraint tree.Datum, p *planner, _ *dbdesc.Immutable,
+					addRow func(...tree.Datum) error) (bool, error) {
+					d, ok := tree.UnwrapDatum(p.EvalContext(), constraint).(*tree.DString)
+					if !ok {
+						return false, nil
+					}
+					// Session usernames are always normalized and non-empty. Besides, the
+					// empty username would mean "all users" in the request.
+					username, err := security.MakeSQLUsernameFromPreNormalizedStringChecked(string(*d))
+					if err != nil || username.Undefined() {
+						//nolint:returnerrcheck
+						return false, nil
+					}
+					usernameStr := username.Normalized()
+					return true, populate(ctx, p, addRow, sessionsFilter{username: &usernameStr})
+				},
+			},
+			{
+				populate: func(ctx context.Context, constraint tree.Datum, p *planner, _ *dbdesc.Immutable,
+					addRow func(...tree.Datum) error) (bool, error) {
+					d, ok := tree.UnwrapDatum(p.EvalContext(), constraint).(*tree.DString)
+					if !ok {
+						return false, nil
+					}
+					applicationName := string(*d)
+					return true, populate(ctx, p, addRow, sessionsFilter{applicationName: &applicationName})
+				},
+			},
+		},
+	}
 }
 
 func populateQueriesTable(
-	ctx context.Context, addRow func(...tree.Datum) error, response *serverpb.ListSessionsResponse,
+	ctx context.Context,
+	addRow func(...tree.Datum) error,
+	response *serverpb.ListSessionsResponse,
+	filter sessionsFilter,
 ) error {
-	for _, session := range response.Sessions {
-		sessionID := getSessionID(session)
+	for i := range response.Sessions {
+		session := &response.Sessions[i]
+		// Nodes running older versions ignore the application name in the
+		// request, so the sessions are filtered here too.
+		if !filter.matches(session) {
+			continue
+		}
+		sessionID := getSessionID(*session)
 		for _, query := range session.ActiveQueries {
 			isDistributedDatum := tree.DNull
 			phase := strings.ToLower(query.Phase.String())
@@ -1455,7 +2226,9 @@ func populateQueriesTable(
 
 	for _, rpcErr := range response.Errors {
 		log.Warningf(ctx, "%v", rpcErr.Message)
-		if rpcErr.NodeID != 0 {
+		// The error rows can't satisfy a filter on the user or application
+		// name, so they are only reported when listing all sessions.
+		if rpcErr.NodeID != 0 && filter == (sessionsFilter{}) {
 			// Add a row with this node ID, the error for query, and
 			// nulls for all other columns.
 			if err := addRow(
@@ -1491,7 +2264,8 @@ CREATE TABLE crdb_internal.%s (
   oldest_query_start TIMESTAMP,      -- the time when the oldest query in the session was started
   kv_txn             STRING,         -- the ID of the current KV transaction
   alloc_bytes        INT,            -- the number of bytes allocated by the session
-  max_alloc_bytes    INT             -- the high water mark of bytes allocated by the session
+  max_alloc_bytes    INT,            -- the high water mark of bytes allocated by the session
+  cluster_name       STRING          -- the cluster name specified by the client in the options parameter
 )
 `
 
@@ -1584,6 +2358,7 @@ func populateSessionsTable(
 			kvTxnIDDatum,
 			tree.NewDInt(tree.DInt(session.AllocBytes)),
 			tree.NewDInt(tree.DInt(session.MaxAllocBytes)),
+			tree.NewDString(session.ClusterName),
 		); err != nil {
 			return err
 		}
@@ -1607,6 +2382,7 @@ func populateSessionsTable(
 				tree.DNull,                             // kv_txn
 				tree.DNull,                             // alloc_bytes
 				tree.DNull,                             // max_alloc_bytes
+				tree.DNull,                             // cluster_name
 			); err != nil {
 				return err
 			}
@@ -1752,7 +2528,11 @@ var typeTable = tree.NewDString("table")
 var typeSequence = tree.NewDString("sequence")
 
 // crdbInternalCreateStmtsTable exposes the CREATE TABLE/CREATE VIEW
-// statements.
+// statements. The create_statement_fingerprint column is the hex-encoded
+// SHA-256 hash of create_statement, which lets schemas be compared across
+// clusters without transferring the statements themselves. The
+// original_create_statement column is the CREATE VIEW statement of a view as
+// it was typed by the user, if it is known.
 //
 // TODO(tbg): prefix with kv_.
 var crdbInternalCreateStmtsTable = makeAllRelationsVirtualTableWithDescriptorIDIndex(
@@ -1771,6 +2551,8 @@ CREATE TABLE crdb_internal.create_statements (
   alter_statements              STRING[] NOT NULL,
   validate_statements           STRING[] NOT NULL,
   has_partitions                BOOL NOT NULL,
+  create_statement_fingerprint  STRING NOT NULL,
+  original_create_statement     STRING,
   INDEX(descriptor_id)
 )
 `, virtualOnce, false, /* includesIndexEntries */
@@ -1786,6 +2568,7 @@ CREATE TABLE crdb_internal.create_statements (
 
 		var descType tree.Datum
 		var stmt, createNofk string
+		originalStmt := tree.DNull
 		alterStmts := tree.NewDArray(types.String)
 		validateStmts := tree.NewDArray(types.String)
 		namePrefix := tree.ObjectNamePrefix{SchemaName: tree.Name(scName), ExplicitSchema: true}
@@ -1794,9 +2577,28 @@ CREATE TABLE crdb_internal.create_statements (
 		if table.IsView() {
 			descType = typeView
 			stmt, err = ShowCreateView(ctx, &name, table)
+			if s := table.TableDesc().OriginalViewStatement; s != "" {
+				originalStmt = tree.NewDString(s)
+			}
 		} else if table.IsSequence() {
 			descType = typeSequence
-			stmt, err = ShowCreateSequence(ctx, &name, table)
+			// The table owning the sequence is usually created after it, so the
+			// owner is set by an ALTER statement when replaying the statements.
+			var owner *tree.ColumnItem
+			owner, err = showSequenceOwner(table, lookup, contextName)
+			if err != nil {
+				return err
+			}
+			createNofk, err = ShowCreateSequence(ctx, &name, table, nil /* owner */)
+			if err != nil {
+				return err
+			}
+			if owner != nil {
+				if err := alterStmts.Append(tree.NewDString(showAlterSequenceOwner(&name, owner))); err != nil {
+					return err
+				}
+			}
+			stmt, err = ShowCreateSequence(ctx, &name, table, owner)
 		} else {
 			descType = typeTable
 			displayOptions := ShowCreateDisplayOptions{
@@ -1842,9 +2644,18 @@ CREATE TABLE crdb_internal.create_statements (
 			alterStmts,
 			validateStmts,
 			tree.MakeDBool(tree.DBool(hasPartitions)),
+			tree.NewDString(fingerprintCreateStatement(stmt)),
+			originalStmt,
 		)
 	})
 
+// fingerprintCreateStatement returns the hex-encoded SHA-256 hash of a CREATE
+// statement.
+func fingerprintCreateStatement(stmt string) string {
+	sum := sha256.Sum256([]byte(stmt))
+	return hex.EncodeToString(sum[:])
+}
+
 func showAlterStatementWithInterleave(
 	ctx context.Context,
 	tn *tree.TableName,
@@ -2312,6 +3123,144 @@ CREATE TABLE crdb_internal.backward_dependencies (
 	},
 }
 
+// crdbInternalObjectDependenciesTable exposes the dependencies between
+// tables, views, sequences and types across all databases, so that the full
+// dependency graph of an object can be computed with a recursive query.
+var crdbInternalObjectDependenciesTable = virtualSchemaTable{
+	comment: "inter-object dependencies across all databases accessible by current user (KV scan)",
+	schema: `
+CREATE TABLE crdb_internal.object_dependencies (
+  object_id          INT NOT NULL,
+  object_database    STRING NOT NULL,
+  object_name        STRING NOT NULL,
+  object_type        STRING NOT NULL,
+  column_id          INT,
+  dependson_id       INT NOT NULL,
+  dependson_database STRING,
+  dependson_name     STRING,
+  dependson_type     STRING,
+  dependency_type    STRING NOT NULL
+)
+`,
+	populate: func(
+		ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error,
+	) error {
+		fkDep := tree.NewDString("fk")
+		viewDep := tree.NewDString("view")
+		sequenceDep := tree.NewDString("sequence")
+		sequenceOwnerDep := tree.NewDString("sequence owner")
+		typeDep := tree.NewDString("type")
+		return forEachTableDescWithTableLookup(ctx, p, nil /* dbContext */, hideVirtual, func(
+			db *dbdesc.Immutable, _ string, table catalog.TableDescriptor, tableLookup tableLookupFn,
+		) error {
+			objectID := tree.NewDInt(tree.DInt(table.GetID()))
+			objectDB := tree.NewDString(db.GetName())
+			objectName := tree.NewDString(table.GetName())
+			objectType := tree.NewDString(objectDependencyType(table))
+
+			// describe returns the database, name and type of the object with
+			// the given ID, or NULLs if the object is not visible.
+			describe := func(id descpb.ID) (tree.Datum, tree.Datum, tree.Datum) {
+				if tbl, ok := tableLookup.tbDescs[id]; ok {
+					return tree.NewDString(tableLookup.getParentName(tbl)),
+						tree.NewDString(tbl.GetName()),
+						tree.NewDString(objectDependencyType(tbl))
+				}
+				if typ, ok := tableLookup.typDescs[id]; ok {
+					dbName := tree.DNull
+					if name, ok := tableLookup.dbNames[typ.ParentID]; ok {
+						dbName = tree.NewDString(name)
+					}
+					return dbName, tree.NewDString(typ.GetName()), typeDep
+				}
+				return tree.DNull, tree.DNull, tree.DNull
+			}
+			addDep := func(colID tree.Datum, id descpb.ID, depType tree.Datum) error {
+				depDB, depName, depObjType := describe(id)
+				return addRow(
+					objectID, objectDB, objectName, objectType,
+					colID,
+					tree.NewDInt(tree.DInt(id)), depDB, depName, depObjType,
+					depType,
+				)
+			}
+
+			// Record the foreign key dependencies.
+			if err := table.ForeachOutboundFK(func(fk *descpb.ForeignKeyConstraint) error {
+				return addDep(tree.DNull, fk.ReferencedTableID, fkDep)
+			}); err != nil {
+				return err
+			}
+
+			// Record the view dependencies.
+			for _, id := range table.GetDependsOn() {
+				if err := addDep(tree.DNull, id, viewDep); err != nil {
+					return err
+				}
+			}
+
+			// Record the ownership of a sequence by a table column. The sequence
+			// is dropped along with its owner.
+			if table.IsSequence() {
+				owner := table.GetSequenceOpts().SequenceOwner
+				if owner.OwnerTableID != descpb.InvalidID {
+					if err := addDep(tree.DNull, owner.OwnerTableID, sequenceOwnerDep); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Record the sequences used by the columns.
+			if err := table.ForeachPublicColumn(func(col *descpb.ColumnDescriptor) error {
+				for _, sequenceID := range col.UsesSequenceIds {
+					if err := addDep(tree.NewDInt(tree.DInt(col.ID)), sequenceID, sequenceDep); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			// Record the user defined types referenced by the table. Array types
+			// are implicitly created along with their element type, so only the
+			// latter is reported.
+			typeIDs, err := table.GetAllReferencedTypeIDs(func(id descpb.ID) (catalog.TypeDescriptor, error) {
+				typ, ok := tableLookup.typDescs[id]
+				if !ok {
+					return nil, errors.AssertionFailedf("type descriptor %d not found", id)
+				}
+				return typ, nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, id := range typeIDs {
+				if typ := tableLookup.typDescs[id]; typ.Kind == descpb.TypeDescriptor_ALIAS {
+					continue
+				}
+				if err := addDep(tree.DNull, id, typeDep); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+// objectDependencyType returns the type of the object represented by the
+// given table descriptor: "table", "view" or "sequence".
+func objectDependencyType(table catalog.TableDescriptor) string {
+	switch {
+	case table.IsView():
+		return "view"
+	case table.IsSequence():
+		return "sequence"
+	default:
+		return "table"
+	}
+}
+
 // crdbInternalFeatureUsage exposes the telemetry counters.
 var crdbInternalFeatureUsage = virtualSchemaTable{
 	comment: "telemetry counters (RAM; local node only)",
@@ -2620,6 +3569,328 @@ CREATE TABLE crdb_internal.ranges_no_leases (
 	},
 }
 
+// lingeringIntentsMinAge is the minimum age of the intents reported by
+// crdb_internal.lingering_intents.
+var lingeringIntentsMinAge = settings.RegisterDurationSetting(
+	"sql.crdb_internal.lingering_intents.min_age",
+	"minimum age of the intents reported by crdb_internal.lingering_intents",
+	5*time.Minute,
+	settings.NonNegativeDuration,
+)
+
+const (
+	// lingeringIntentsScanLimit bounds the number of keys scanned in each range
+	// when sampling it for intents.
+	lingeringIntentsScanLimit = 10000
+	// lingeringIntentsMaxPerRange bounds the number of intents reported for
+	// each range.
+	lingeringIntentsMaxPerRange = 100
+)
+
+// crdbInternalLingeringIntentsTable samples the ranges in the system for
+// intents that are older than sql.crdb_internal.lingering_intents.min_age,
+// along with the metadata of the transactions that wrote them.
+//
+// The transaction owning an intent is looked up with a QueryIntent request on
+// behalf of a transaction that can't own it, which reports the intent found on
+// the key, and a QueryTxn request for the owner's record. Neither request
+// pushes the owner, so looking up an intent never aborts its transaction or
+// resolves the intent. Intents that are gone by the time they are looked up
+// are reported with the resolved column set and no transaction metadata. Note
+// that, like any inconsistent read, the scan that finds the intents hands them
+// to the intent resolver, which cleans up those of finalized or expired
+// transactions.
+var crdbInternalLingeringIntentsTable = virtualSchemaTable{
+	comment: `sample of old intents and their transactions (KV scan; expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.lingering_intents (
+  range_id           INT NOT NULL,
+  key                BYTES NOT NULL,
+  pretty_key         STRING NOT NULL,
+  intent_timestamp   TIMESTAMP NOT NULL,
+  age                INTERVAL NOT NULL,
+  resolved           BOOL NOT NULL,
+  txn_id             UUID,
+  txn_key            STRING,
+  txn_status         STRING,
+  txn_epoch          INT,
+  txn_priority       INT,
+  txn_last_heartbeat TIMESTAMP
+)
+`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.lingering_intents"); err != nil {
+			return err
+		}
+		if !p.ExecCfg().Codec.ForSystemTenant() {
+			return errorutil.UnsupportedWithMultiTenancy(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+		}
+		ranges, err := ScanMetaKVs(ctx, p.txn, roachpb.Span{
+			Key:    keys.MinKey,
+			EndKey: keys.MaxKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		sender := p.ExecCfg().DB.NonTransactionalSender()
+		now := p.ExecCfg().Clock.Now()
+		minAge := lingeringIntentsMinAge.Get(&p.ExecCfg().Settings.SV)
+		var desc roachpb.RangeDescriptor
+		for _, r := range ranges {
+			if err := r.ValueProto(&desc); err != nil {
+				return err
+			}
+			span := desc.RSpan().AsRawSpanWithNoLocals()
+			if span.Key.Compare(keys.LocalMax) < 0 {
+				span.Key = keys.LocalMax
+			}
+
+			// Skip ranges whose stats report no intents at all.
+			statsResp, pErr := kv.SendWrapped(ctx, sender, &roachpb.RangeStatsRequest{
+				RequestHeader: roachpb.RequestHeader{Key: span.Key},
+			})
+			if pErr != nil {
+				return pErr.GoError()
+			}
+			if statsResp.(*roachpb.RangeStatsResponse).MVCCStats.IntentCount == 0 {
+				continue
+			}
+
+			scanResp, pErr := kv.SendWrappedWith(ctx, sender, roachpb.Header{
+				ReadConsistency:    roachpb.READ_UNCOMMITTED,
+				MaxSpanRequestKeys: lingeringIntentsScanLimit,
+			}, &roachpb.ScanRequest{
+				RequestHeader: roachpb.RequestHeader{Key: span.Key, EndKey: span.EndKey},
+			})
+			if pErr != nil {
+				return pErr.GoError()
+			}
+
+			reported := 0
+			for _, intentKV := range scanResp.(*roachpb.ScanResponse).IntentRows {
+				if reported >= lingeringIntentsMaxPerRange {
+					break
+				}
+				age := now.GoTime().Sub(intentKV.Value.Timestamp.GoTime())
+				if age < minAge {
+					continue
+				}
+				reported++
+				if err := p.addLingeringIntentRow(
+					ctx, sender, desc.RangeID, intentKV.Key, intentKV.Value.Timestamp, age, addRow,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// addLingeringIntentRow looks up the transaction owning the intent on the
+// given key and adds a row describing both to crdb_internal.lingering_intents.
+func (p *planner) addLingeringIntentRow(
+	ctx context.Context,
+	sender kv.Sender,
+	rangeID roachpb.RangeID,
+	key roachpb.Key,
+	intentTS hlc.Timestamp,
+	age time.Duration,
+	addRow func(...tree.Datum) error,
+) error {
+	row := tree.Datums{
+		tree.NewDInt(tree.DInt(rangeID)),
+		tree.NewDBytes(tree.DBytes(key)),
+		tree.NewDString(keys.PrettyPrint(nil /* valDirs */, key)),
+		tree.TimestampToInexactDTimestamp(intentTS),
+		tree.NewDInterval(duration.MakeDuration(age.Nanoseconds(), 0, 0), types.DefaultIntervalTypeMetadata),
+		tree.DBoolFalse,
+		tree.DNull,
+		tree.DNull,
+		tree.DNull,
+		tree.DNull,
+		tree.DNull,
+		tree.DNull,
+	}
+
+	// The zero transaction never owns the intent, so the request fails with an
+	// IntentMissingError carrying the intent that is actually on the key, if
+	// any.
+	_, pErr := kv.SendWrapped(ctx, sender, &roachpb.QueryIntentRequest{
+		RequestHeader:  roachpb.RequestHeader{Key: key},
+		ErrorIfMissing: true,
+	})
+	if pErr == nil {
+		return errors.AssertionFailedf("intent on key %s unexpectedly owned by the zero transaction", key)
+	}
+	imErr, ok := pErr.GetDetail().(*roachpb.IntentMissingError)
+	if !ok {
+		return pErr.GoError()
+	}
+	if imErr.WrongIntent == nil {
+		// The intent was resolved since the key was scanned.
+		row[5] = tree.DBoolTrue
+		return addRow(row...)
+	}
+	meta := imErr.WrongIntent.Txn
+	row[6] = tree.NewDUuid(tree.DUuid{UUID: meta.ID})
+	row[7] = tree.NewDString(keys.PrettyPrint(nil /* valDirs */, meta.Key))
+	row[9] = tree.NewDInt(tree.DInt(meta.Epoch))
+	row[10] = tree.NewDInt(tree.DInt(meta.Priority))
+
+	resp, pErr := kv.SendWrapped(ctx, sender, &roachpb.QueryTxnRequest{
+		RequestHeader: roachpb.RequestHeader{Key: meta.Key},
+		Txn:           meta,
+	})
+	if pErr != nil {
+		return pErr.GoError()
+	}
+	queriedTxn := resp.(*roachpb.QueryTxnResponse).QueriedTxn
+	row[8] = tree.NewDString(queriedTxn.Status.String())
+	if !queriedTxn.LastHeartbeat.IsEmpty() {
+		row[11] = tree.TimestampToInexactDTimestamp(queriedTxn.LastHeartbeat)
+	}
+	return addRow(row...)
+}
+
+// tableMVCCStatsMaxSampledRanges bounds the number of ranges of each table
+// whose MVCC statistics are read by crdb_internal.table_mvcc_stats.
+var tableMVCCStatsMaxSampledRanges = settings.RegisterIntSetting(
+	"sql.crdb_internal.table_mvcc_stats.max_sampled_ranges",
+	"maximum number of ranges of each table whose MVCC statistics are read by crdb_internal.table_mvcc_stats",
+	32,
+	settings.PositiveInt,
+)
+
+// crdbInternalTableMVCCStatsTable reports the live and garbage (non-live)
+// bytes of each table, so that tables that would benefit from a shorter GC
+// TTL or a manual compaction can be found.
+//
+// Each range is attributed to the table containing its start key. For tables
+// with more ranges than sql.crdb_internal.table_mvcc_stats.max_sampled_ranges,
+// the statistics of evenly spaced ranges are read and extrapolated to the
+// whole table.
+var crdbInternalTableMVCCStatsTable = virtualSchemaTable{
+	comment: `sampled live and garbage MVCC statistics per table (KV scan; expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.table_mvcc_stats (
+  table_id            INT NOT NULL,
+  database_name       STRING,
+  table_name          STRING,
+  range_count         INT NOT NULL,
+  sampled_range_count INT NOT NULL,
+  live_bytes          INT NOT NULL,
+  total_bytes         INT NOT NULL,
+  garbage_bytes       INT NOT NULL,
+  garbage_ratio       FLOAT,
+  live_count          INT NOT NULL,
+  key_count           INT NOT NULL,
+  tombstone_count     INT NOT NULL,
+  avg_garbage_age     INTERVAL,
+  contains_estimates  BOOL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.table_mvcc_stats"); err != nil {
+			return err
+		}
+		if !p.ExecCfg().Codec.ForSystemTenant() {
+			return errorutil.UnsupportedWithMultiTenancy(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+		}
+		tableIDs, rangeStarts, err := getTableRangeStarts(ctx, p.txn, roachpb.Span{
+			Key:    keys.TableDataMin,
+			EndKey: keys.TableDataMax,
+		})
+		if err != nil {
+			return err
+		}
+
+		maxSampled := int(tableMVCCStatsMaxSampledRanges.Get(&p.ExecCfg().Settings.SV))
+		sampled := make(map[descpb.ID]int, len(tableIDs))
+		var sampledKeys []roachpb.Key
+		for _, id := range tableIDs {
+			starts := sampleRangeStarts(rangeStarts[id], maxSampled)
+			sampledKeys = append(sampledKeys, starts...)
+			sampled[id] = len(starts)
+		}
+		rangeStats, err := getRangeStats(ctx, p.txn, sampledKeys)
+		if err != nil {
+			return err
+		}
+
+		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		dbNames := make(map[descpb.ID]string)
+		tables := make(map[descpb.ID]catalog.TableDescriptor)
+		for _, catDesc := range descs {
+			switch d := catDesc.(type) {
+			case *dbdesc.Immutable:
+				dbNames[d.GetID()] = d.GetName()
+			case catalog.TableDescriptor:
+				tables[d.GetID()] = d
+			}
+		}
+
+		nowNanos := p.ExecCfg().Clock.PhysicalNow()
+		for _, id := range tableIDs {
+			var stats enginepb.MVCCStats
+			for _, rs := range rangeStats[:sampled[id]] {
+				stats.Add(rs)
+			}
+			rangeStats = rangeStats[sampled[id]:]
+			stats.AgeTo(nowNanos)
+
+			// Extrapolate the statistics of the sampled ranges to the whole
+			// table. The ratios are not affected.
+			scale := float64(len(rangeStarts[id])) / float64(sampled[id])
+			estimate := func(v int64) tree.Datum {
+				return tree.NewDInt(tree.DInt(math.Round(float64(v) * scale)))
+			}
+			garbageBytes := stats.GCBytes()
+			garbageRatio := tree.DNull
+			if total := stats.Total(); total > 0 {
+				garbageRatio = tree.NewDFloat(tree.DFloat(float64(garbageBytes) / float64(total)))
+			}
+			avgGarbageAge := tree.DNull
+			if garbageBytes > 0 {
+				age := time.Duration(stats.GCBytesAge/garbageBytes) * time.Second
+				avgGarbageAge = tree.NewDInterval(
+					duration.MakeDuration(age.Nanoseconds(), 0, 0), types.DefaultIntervalTypeMetadata,
+				)
+			}
+			dbName, tableName := tree.DNull, tree.DNull
+			if table, ok := tables[id]; ok {
+				tableName = tree.NewDString(table.GetName())
+				if name, ok := dbNames[table.GetParentID()]; ok {
+					dbName = tree.NewDString(name)
+				}
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(id)),
+				dbName,
+				tableName,
+				tree.NewDInt(tree.DInt(len(rangeStarts[id]))),
+				tree.NewDInt(tree.DInt(sampled[id])),
+				estimate(stats.LiveBytes),
+				estimate(stats.Total()),
+				estimate(garbageBytes),
+				garbageRatio,
+				estimate(stats.LiveCount),
+				estimate(stats.KeyCount),
+				// Keys whose most recent version is a deletion tombstone.
+				estimate(stats.KeyCount-stats.LiveCount),
+				avgGarbageAge,
+				tree.MakeDBool(tree.DBool(stats.ContainsEstimates != 0)),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // NamespaceKey represents a key from the namespace table.
 type NamespaceKey struct {
 	ParentID descpb.ID
@@ -3126,6 +4397,222 @@ CREATE TABLE crdb_internal.gossip_liveness (
 	},
 }
 
+// crdbInternalClusterUpgradeStatusTable exposes the progress of the upgrade of
+// the cluster version, so that upgrades which don't finalize can be diagnosed.
+// It mirrors the checks the server runs before finalizing an upgrade
+// automatically.
+var crdbInternalClusterUpgradeStatusTable = virtualSchemaTable{
+	comment: "cluster version, node binary versions and pending upgrade steps (RAM; local node only)",
+	schema: `
+CREATE TABLE crdb_internal.cluster_upgrade_status (
+  component STRING NOT NULL,
+  name      STRING NOT NULL,
+  version   STRING,
+  status    STRING NOT NULL,
+  details   STRING
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.cluster_upgrade_status"); err != nil {
+			return err
+		}
+
+		g, err := p.ExecCfg().Gossip.OptionalErr(47899)
+		if err != nil {
+			return err
+		}
+
+		descriptors, err := getAllNodeDescriptors(p)
+		if err != nil {
+			return err
+		}
+		sort.Slice(descriptors, func(i, j int) bool {
+			return descriptors[i].NodeID < descriptors[j].NodeID
+		})
+
+		membership := make(map[roachpb.NodeID]livenesspb.MembershipStatus)
+		if err := g.IterateInfos(gossip.KeyNodeLivenessPrefix, func(key string, i gossip.Info) error {
+			bytes, err := i.Value.GetBytes()
+			if err != nil {
+				return errors.NewAssertionErrorWithWrappedErrf(err,
+					"failed to extract bytes for key %q", key)
+			}
+
+			var l livenesspb.Liveness
+			if err := protoutil.Unmarshal(bytes, &l); err != nil {
+				return errors.NewAssertionErrorWithWrappedErrf(err,
+					"failed to parse value for key %q", key)
+			}
+			membership[l.NodeID] = l.Membership
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// The cluster version can be upgraded to the lowest binary version of
+		// the nodes, and is only finalized automatically once all the nodes that
+		// aren't decommissioned are running and on the same binary version.
+		var target, newest roachpb.Version
+		var blockers []string
+		nodeStatuses := make([]string, len(descriptors))
+		for i := range descriptors {
+			d := &descriptors[i]
+			_, err := g.GetInfo(gossip.MakeGossipClientsKey(d.NodeID))
+			status := "live"
+			switch m := membership[d.NodeID]; {
+			case m.Decommissioned():
+				status = "decommissioned"
+			case err != nil:
+				status = "unavailable"
+			case m.Decommissioning():
+				status = "decommissioning"
+			}
+			nodeStatuses[i] = status
+			if status == "decommissioned" {
+				continue
+			}
+			if status == "unavailable" {
+				blockers = append(blockers, fmt.Sprintf("node %d is unavailable", d.NodeID))
+			}
+			if target == (roachpb.Version{}) || d.ServerVersion.Less(target) {
+				target = d.ServerVersion
+			}
+			if newest.Less(d.ServerVersion) {
+				newest = d.ServerVersion
+			}
+		}
+		if target != newest {
+			blockers = append(blockers, fmt.Sprintf(
+				"not all nodes are running the same binary version (saw %s and %s)", target, newest))
+		}
+
+		// While cluster.preserve_downgrade_option is set, the cluster version
+		// can't be upgraded, automatically or manually, so that the binaries
+		// can be rolled back. The feature gates of the pending upgrade steps stay
+		// disabled until it is reset.
+		st := p.ExecCfg().Settings
+		active := st.Version.ActiveVersion(ctx)
+		var preserved string
+		if setting, ok := settings.Lookup(
+			"cluster.preserve_downgrade_option", settings.LookupForLocalAccess,
+		); ok {
+			preserved = setting.String(&st.SV)
+		}
+		if preserved != "" {
+			blocker := fmt.Sprintf("cluster.preserve_downgrade_option is set to %s", preserved)
+			row, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryRowEx(
+				ctx, "crdb-internal-preserve-downgrade-option", p.txn,
+				sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+				`SELECT "lastUpdated" FROM system.settings WHERE name = 'cluster.preserve_downgrade_option'`,
+			)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				since := tree.MustBeDTimestamp(row[0]).Time
+				blocker += fmt.Sprintf(" since %s", since.Format(time.RFC3339))
+			}
+			blockers = append(blockers, blocker)
+		}
+
+		// Upgrades step through every version between the cluster version and
+		// the target, each preceded by a fence version. The versions of the
+		// release the binary can be upgraded from are used to report progress.
+		steps := clusterversion.ListBetween(
+			clusterversion.ClusterVersion{Version: st.Version.BinaryMinSupportedVersion()},
+			clusterversion.ClusterVersion{Version: target},
+		)
+		var pending []clusterversion.ClusterVersion
+		for _, step := range steps {
+			if !active.IsActiveVersion(step.Version) {
+				pending = append(pending, step)
+			}
+		}
+		// Fence versions have odd internal versions.
+		upgrading := active.Internal%2 != 0
+
+		clusterStatus, clusterDetails := "finalized", tree.DNull
+		switch {
+		case upgrading:
+			clusterStatus = "upgrading"
+		case active.Less(target):
+			clusterStatus = "upgrade pending"
+		}
+		if clusterStatus != "finalized" && len(steps) > 0 {
+			clusterDetails = tree.NewDString(fmt.Sprintf("%d of %d upgrade steps to %s complete",
+				len(steps)-len(pending), len(steps), target))
+		}
+		if err := addRow(
+			tree.NewDString("cluster"),
+			tree.NewDString("version"),
+			tree.NewDString(active.String()),
+			tree.NewDString(clusterStatus),
+			clusterDetails,
+		); err != nil {
+			return err
+		}
+
+		finalizationStatus, finalizationDetails := "ready", ""
+		switch {
+		case clusterStatus == "finalized":
+			finalizationStatus, finalizationDetails = "not needed", "cluster version is up to date"
+		case upgrading:
+			finalizationStatus, finalizationDetails = "running", fmt.Sprintf("upgrading to %s", target)
+		case len(blockers) > 0:
+			finalizationStatus, finalizationDetails = "blocked", strings.Join(blockers, "; ")
+		default:
+			finalizationDetails = fmt.Sprintf("cluster version will be upgraded to %s", target)
+		}
+		if err := addRow(
+			tree.NewDString("cluster"),
+			tree.NewDString("auto-finalization"),
+			tree.DNull,
+			tree.NewDString(finalizationStatus),
+			tree.NewDString(finalizationDetails),
+		); err != nil {
+			return err
+		}
+
+		for i := range descriptors {
+			d := &descriptors[i]
+			if err := addRow(
+				tree.NewDString("node"),
+				tree.NewDString(fmt.Sprintf("n%d", d.NodeID)),
+				tree.NewDString(d.ServerVersion.String()),
+				tree.NewDString(nodeStatuses[i]),
+				tree.NewDString(d.BuildTag),
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, step := range pending {
+			name := step.String()
+			if key, ok := clusterversion.KeyFor(step.Version); ok {
+				name = key.String()
+			}
+			// The fence version immediately precedes the version it fences.
+			status, details, fence := "pending", tree.DNull, step.Version
+			fence.Internal--
+			if active.Version == fence {
+				status = "running"
+			} else if preserved != "" {
+				status = "blocked"
+				details = tree.NewDString(fmt.Sprintf("cluster.preserve_downgrade_option is set to %s", preserved))
+			}
+			if err := addRow(
+				tree.NewDString("migration"),
+				tree.NewDString(name),
+				tree.NewDString(step.String()),
+				tree.NewDString(status),
+				details,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalGossipAlertsTable exposes current health alerts in the cluster.
 var crdbInternalGossipAlertsTable = virtualSchemaTable{
 	comment: "locally known gossiped health alerts (RAM; local node only)",
@@ -3667,6 +5154,357 @@ CREATE TABLE crdb_internal.kv_store_status (
 	},
 }
 
+// crdbInternalKVStoreDiskStatusTable exposes the disk space of the cluster
+// stores, the size of their emergency ballast files and whether they reject
+// writes because their disk is nearly full.
+var crdbInternalKVStoreDiskStatusTable = virtualSchemaTable{
+	comment: "store disk space, ballast and read-only status (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_store_disk_status (
+  node_id       INT NOT NULL,
+  store_id      INT NOT NULL,
+  capacity      INT NOT NULL,
+  available     INT NOT NULL,
+  used          INT NOT NULL,
+  ballast_bytes INT NOT NULL,
+  read_only     BOOL NOT NULL
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_store_disk_status"); err != nil {
+			return err
+		}
+		ss, err := p.ExecCfg().NodesStatusServer.OptionalNodesStatusServer(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+		if err != nil {
+			return err
+		}
+		response, err := ss.Nodes(ctx, &serverpb.NodesRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, n := range response.Nodes {
+			for _, s := range n.StoreStatuses {
+				if err := addRow(
+					tree.NewDInt(tree.DInt(s.Desc.Node.NodeID)),
+					tree.NewDInt(tree.DInt(s.Desc.StoreID)),
+					tree.NewDInt(tree.DInt(s.Desc.Capacity.Capacity)),
+					tree.NewDInt(tree.DInt(s.Desc.Capacity.Available)),
+					tree.NewDInt(tree.DInt(s.Desc.Capacity.Used)),
+					tree.NewDInt(tree.DInt(s.Metrics["capacity.ballast"])),
+					tree.MakeDBool(s.Metrics["capacity.read_only"] != 0),
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// forEachLiveReplicaInfo calls fn with the status of every replica on the
+// live nodes of the cluster.
+func forEachLiveReplicaInfo(
+	ctx context.Context, p *planner, fn func(serverpb.RangeInfo) error,
+) error {
+	ss, err := p.ExecCfg().NodesStatusServer.OptionalNodesStatusServer(
+		errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+	if err != nil {
+		return err
+	}
+	nodes, err := ss.Nodes(ctx, &serverpb.NodesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes.Nodes {
+		nodeID := n.Desc.NodeID
+		// Dead and decommissioned nodes cannot report the status of their
+		// replicas.
+		if nodes.LivenessByNodeID[nodeID] != livenesspb.NodeLivenessStatus_LIVE {
+			continue
+		}
+		ranges, err := ss.Ranges(ctx, &serverpb.RangesRequest{NodeId: nodeID.String()})
+		if err != nil {
+			return errors.Wrapf(err, "fetching ranges of n%d", nodeID)
+		}
+		for _, r := range ranges.Ranges {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var crdbInternalKVRaftStatusTable = virtualSchemaTable{
+	comment: "raft status of every replica (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_raft_status (
+  node_id               INT NOT NULL,
+  store_id              INT NOT NULL,
+  range_id              INT NOT NULL,
+  replica_id            INT NOT NULL,
+  raft_state            STRING NOT NULL,
+  leader_id             INT NOT NULL,
+  term                  INT NOT NULL,
+  commit_index          INT NOT NULL,
+  applied_index         INT NOT NULL,
+  last_index            INT NOT NULL,
+  truncated_index       INT NOT NULL,
+  truncated_term        INT NOT NULL,
+  raft_log_size         INT NOT NULL,
+  raft_log_size_trusted BOOL NOT NULL,
+  quiescent             BOOL NOT NULL
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_raft_status"); err != nil {
+			return err
+		}
+		return forEachLiveReplicaInfo(ctx, p, func(r serverpb.RangeInfo) error {
+			var truncatedState roachpb.RaftTruncatedState
+			if r.State.TruncatedState != nil {
+				truncatedState = *r.State.TruncatedState
+			}
+			return addRow(
+				tree.NewDInt(tree.DInt(r.SourceNodeID)),
+				tree.NewDInt(tree.DInt(r.SourceStoreID)),
+				tree.NewDInt(tree.DInt(r.State.Desc.RangeID)),
+				tree.NewDInt(tree.DInt(r.RaftState.ReplicaID)),
+				tree.NewDString(r.RaftState.State),
+				tree.NewDInt(tree.DInt(r.RaftState.Lead)),
+				tree.NewDInt(tree.DInt(r.RaftState.HardState.Term)),
+				tree.NewDInt(tree.DInt(r.RaftState.HardState.Commit)),
+				tree.NewDInt(tree.DInt(r.RaftState.Applied)),
+				tree.NewDInt(tree.DInt(r.State.LastIndex)),
+				tree.NewDInt(tree.DInt(truncatedState.Index)),
+				tree.NewDInt(tree.DInt(truncatedState.Term)),
+				tree.NewDInt(tree.DInt(r.State.RaftLogSize)),
+				tree.MakeDBool(tree.DBool(r.State.RaftLogSizeTrusted)),
+				tree.MakeDBool(tree.DBool(r.Quiescent)),
+			)
+		})
+	},
+}
+
+var crdbInternalKVRaftProgressTable = virtualSchemaTable{
+	comment: "raft progress of the followers of every raft leader (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_raft_progress (
+  node_id          INT NOT NULL,
+  store_id         INT NOT NULL,
+  range_id         INT NOT NULL,
+  leader_id        INT NOT NULL,
+  follower_id      INT NOT NULL,
+  state            STRING NOT NULL,
+  match_index      INT NOT NULL,
+  next_index       INT NOT NULL,
+  lag              INT NOT NULL,
+  paused           BOOL NOT NULL,
+  pending_snapshot INT NOT NULL
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_raft_progress"); err != nil {
+			return err
+		}
+		return forEachLiveReplicaInfo(ctx, p, func(r serverpb.RangeInfo) error {
+			// Only the leader tracks the progress of its followers.
+			followerIDs := make([]uint64, 0, len(r.RaftState.Progress))
+			for id := range r.RaftState.Progress {
+				followerIDs = append(followerIDs, id)
+			}
+			sort.Slice(followerIDs, func(i, j int) bool { return followerIDs[i] < followerIDs[j] })
+			for _, id := range followerIDs {
+				progress := r.RaftState.Progress[id]
+				var lag uint64
+				if r.State.LastIndex > progress.Match {
+					lag = r.State.LastIndex - progress.Match
+				}
+				if err := addRow(
+					tree.NewDInt(tree.DInt(r.SourceNodeID)),
+					tree.NewDInt(tree.DInt(r.SourceStoreID)),
+					tree.NewDInt(tree.DInt(r.State.Desc.RangeID)),
+					tree.NewDInt(tree.DInt(r.RaftState.ReplicaID)),
+					tree.NewDInt(tree.DInt(id)),
+					tree.NewDString(progress.State),
+					tree.NewDInt(tree.DInt(progress.Match)),
+					tree.NewDInt(tree.DInt(progress.Next)),
+					tree.NewDInt(tree.DInt(lag)),
+					tree.MakeDBool(tree.DBool(progress.Paused)),
+					tree.NewDInt(tree.DInt(progress.PendingSnapshot)),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var crdbInternalKVFollowerReadStatusTable = virtualSchemaTable{
+	comment: "closed timestamp and follower read health of every range (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_follower_read_status (
+  range_id                INT NOT NULL,
+  leaseholder_node_id     INT,
+  closed_timestamp        TIMESTAMP NOT NULL,
+  closed_timestamp_age    INTERVAL NOT NULL,
+  follower_reads_servable BOOL,
+  lagging_node_id         INT,
+  lagging_store_id        INT
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_follower_read_status"); err != nil {
+			return err
+		}
+
+		// The closed timestamp of a range is the one of its replica that lags
+		// the most, since follower reads may be routed to any of them.
+		laggingReplicas := make(map[roachpb.RangeID]serverpb.RangeInfo)
+		if err := forEachLiveReplicaInfo(ctx, p, func(r serverpb.RangeInfo) error {
+			rangeID := r.State.Desc.RangeID
+			if lagging, ok := laggingReplicas[rangeID]; !ok ||
+				r.State.ActiveClosedTimestamp.Less(lagging.State.ActiveClosedTimestamp) {
+				laggingReplicas[rangeID] = r
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		rangeIDs := make([]roachpb.RangeID, 0, len(laggingReplicas))
+		for rangeID := range laggingReplicas {
+			rangeIDs = append(rangeIDs, rangeID)
+		}
+		sort.Slice(rangeIDs, func(i, j int) bool { return rangeIDs[i] < rangeIDs[j] })
+
+		// Follower reads are served at follower_read_timestamp(), so a range
+		// can serve them if its closed timestamp is no older than the offset
+		// used by that function. Without the offset (in non-CCL builds or
+		// without an enterprise license) follower reads are disabled.
+		var followerReadOffset time.Duration
+		followerReadsEnabled := false
+		if builtins.EvalFollowerReadOffset != nil {
+			offset, err := builtins.EvalFollowerReadOffset(p.ExecCfg().ClusterID(), p.ExecCfg().Settings)
+			if err == nil {
+				followerReadOffset, followerReadsEnabled = offset, true
+			}
+		}
+
+		now := p.ExecCfg().Clock.Now()
+		for _, rangeID := range rangeIDs {
+			r := laggingReplicas[rangeID]
+			closedTS := r.State.ActiveClosedTimestamp
+			age := now.GoTime().Sub(closedTS.GoTime())
+
+			leaseholderNodeID := tree.DNull
+			if r.State.Lease != nil && !r.State.Lease.Empty() {
+				leaseholderNodeID = tree.NewDInt(tree.DInt(r.State.Lease.Replica.NodeID))
+			}
+			servable := tree.DNull
+			laggingNodeID, laggingStoreID := tree.DNull, tree.DNull
+			if followerReadsEnabled {
+				ok := age <= -followerReadOffset
+				servable = tree.MakeDBool(tree.DBool(ok))
+				if !ok {
+					laggingNodeID = tree.NewDInt(tree.DInt(r.SourceNodeID))
+					laggingStoreID = tree.NewDInt(tree.DInt(r.SourceStoreID))
+				}
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(rangeID)),
+				leaseholderNodeID,
+				tree.TimestampToInexactDTimestamp(closedTS),
+				tree.NewDInterval(duration.MakeDuration(age.Nanoseconds(), 0, 0), types.DefaultIntervalTypeMetadata),
+				servable,
+				laggingNodeID,
+				laggingStoreID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalKVConsistencyChecksTable = virtualSchemaTable{
+	comment: "last consistency checks of every range (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_consistency_checks (
+  range_id           INT NOT NULL,
+  start_pretty       STRING NOT NULL,
+  end_pretty         STRING NOT NULL,
+  last_queue_check   TIMESTAMP,
+  last_check         TIMESTAMP,
+  last_check_node_id INT,
+  last_check_status  STRING,
+  last_check_detail  STRING
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_consistency_checks"); err != nil {
+			return err
+		}
+
+		// Checks are coordinated by the leaseholder, which may have moved since,
+		// so report the most recent check known to any replica of the range.
+		// The queue's last processed time is replicated, but replicas may lag
+		// behind, so the most recent one is reported as well.
+		lastChecks := make(map[roachpb.RangeID]serverpb.RangeInfo)
+		lastQueueChecks := make(map[roachpb.RangeID]hlc.Timestamp)
+		if err := forEachLiveReplicaInfo(ctx, p, func(r serverpb.RangeInfo) error {
+			rangeID := r.State.Desc.RangeID
+			if last, ok := lastChecks[rangeID]; !ok ||
+				last.State.LastConsistencyCheckTime.Less(r.State.LastConsistencyCheckTime) {
+				lastChecks[rangeID] = r
+			}
+			lastQueueCheck := lastQueueChecks[rangeID]
+			lastQueueCheck.Forward(r.State.ConsistencyQueueLastProcessed)
+			lastQueueChecks[rangeID] = lastQueueCheck
+			return nil
+		}); err != nil {
+			return err
+		}
+		rangeIDs := make([]roachpb.RangeID, 0, len(lastChecks))
+		for rangeID := range lastChecks {
+			rangeIDs = append(rangeIDs, rangeID)
+		}
+		sort.Slice(rangeIDs, func(i, j int) bool { return rangeIDs[i] < rangeIDs[j] })
+
+		timestampOrNull := func(ts hlc.Timestamp) tree.Datum {
+			if ts.IsEmpty() {
+				return tree.DNull
+			}
+			return tree.TimestampToInexactDTimestamp(ts)
+		}
+		for _, rangeID := range rangeIDs {
+			r := lastChecks[rangeID]
+			desc := r.State.Desc
+			lastCheckNodeID, lastCheckStatus, lastCheckDetail := tree.DNull, tree.DNull, tree.DNull
+			if !r.State.LastConsistencyCheckTime.IsEmpty() {
+				lastCheckNodeID = tree.NewDInt(tree.DInt(r.SourceNodeID))
+				lastCheckStatus = tree.NewDString(r.State.LastConsistencyCheckStatus)
+				lastCheckDetail = tree.NewDString(r.State.LastConsistencyCheckDetail)
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(rangeID)),
+				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, desc.StartKey.AsRawKey())),
+				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, desc.EndKey.AsRawKey())),
+				timestampOrNull(lastQueueChecks[rangeID]),
+				timestampOrNull(r.State.LastConsistencyCheckTime),
+				lastCheckNodeID,
+				lastCheckStatus,
+				lastCheckDetail,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 // crdbInternalPredefinedComments exposes the predefined
 // comments for virtual tables. This is used by SHOW TABLES WITH COMMENT
 // as fall-back when system.comments is silent.
@@ -3792,3 +5630,87 @@ CREATE TABLE crdb_internal.cluster_database_privileges (
 			})
 	},
 }
+
+// crdbInternalClusterRoleMembershipsTable exposes the direct and indirect role
+// memberships of every user and role, for auditing clusters with nested roles.
+var crdbInternalClusterRoleMembershipsTable = virtualSchemaTable{
+	comment: `role memberships, including those inherited through other roles`,
+	schema: `
+CREATE TABLE crdb_internal.cluster_role_memberships (
+	role_name  STRING NOT NULL,
+	member     STRING NOT NULL,
+	is_admin   BOOL NOT NULL,
+	is_direct  BOOL NOT NULL,
+	path       STRING[] NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		type membership struct {
+			role    security.SQLUsername
+			isAdmin bool
+		}
+		memberOf := make(map[security.SQLUsername][]membership)
+		var members []security.SQLUsername
+		if err := forEachRoleMembership(ctx, p,
+			func(role, member security.SQLUsername, isAdmin bool) error {
+				if _, ok := memberOf[member]; !ok {
+					members = append(members, member)
+				}
+				memberOf[member] = append(memberOf[member], membership{role: role, isAdmin: isAdmin})
+				return nil
+			}); err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			// Walk the membership graph breadth-first, so that each role is
+			// reported along one of the shortest paths leading to it. parent maps
+			// each reached role to the role it was reached through.
+			parent := map[security.SQLUsername]security.SQLUsername{}
+			reached := []security.SQLUsername{member}
+			for i := 0; i < len(reached); i++ {
+				for _, m := range memberOf[reached[i]] {
+					if _, ok := parent[m.role]; ok || m.role == member {
+						continue
+					}
+					parent[m.role] = reached[i]
+					reached = append(reached, m.role)
+				}
+			}
+
+			// The member holds the admin option on a role if it, or any role it
+			// belongs to, was granted that role WITH ADMIN OPTION.
+			isAdmin := map[security.SQLUsername]bool{}
+			for _, r := range reached {
+				for _, m := range memberOf[r] {
+					if m.isAdmin {
+						isAdmin[m.role] = true
+					}
+				}
+			}
+
+			memberName := tree.NewDString(member.Normalized())
+			for _, role := range reached[1:] {
+				path := tree.NewDArray(types.String)
+				for r := role; r != member; r = parent[r] {
+					if err := path.Append(tree.NewDString(r.Normalized())); err != nil {
+						return err
+					}
+				}
+				// The path was built from the role back to the member.
+				for i, j := 0, len(path.Array)-1; i < j; i, j = i+1, j-1 {
+					path.Array[i], path.Array[j] = path.Array[j], path.Array[i]
+				}
+				if err := addRow(
+					tree.NewDString(role.Normalized()),        // role_name
+					memberName,                                // member
+					tree.MakeDBool(tree.DBool(isAdmin[role])), // is_admin
+					tree.MakeDBool(parent[role] == member),    // is_direct
+					path,                                      // path
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
@@ -504,3 +504,42 @@ DELETE FROM system.descriptor WHERE id=54;
 
 	require.False(t, rows.Next())
 }
+
+// TestLingeringIntents verifies that crdb_internal.lingering_intents reports
+// the intents of an open transaction along with the transaction's metadata,
+// without disturbing the transaction.
+func TestLingeringIntents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	runner := sqlutils.MakeSQLRunner(sqlDB)
+	runner.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	runner.Exec(t, `SET CLUSTER SETTING sql.crdb_internal.lingering_intents.min_age = '0s'`)
+	var tableID int
+	runner.QueryRow(t, `SELECT 't'::REGCLASS::OID`).Scan(&tableID)
+
+	tx, err := sqlDB.BeginTx(ctx, nil /* opts */)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	_, err = tx.Exec(`SET application_name = 'lingering'; INSERT INTO t VALUES (1), (2)`)
+	require.NoError(t, err)
+	var txnID string
+	require.NoError(t, tx.QueryRow(
+		`SELECT id FROM crdb_internal.node_transactions WHERE application_name = 'lingering'`,
+	).Scan(&txnID))
+
+	runner.CheckQueryResults(t, fmt.Sprintf(`
+SELECT pretty_key, resolved, txn_status
+FROM crdb_internal.lingering_intents
+WHERE txn_id = '%s'
+ORDER BY key`, txnID), [][]string{
+		{fmt.Sprintf("/Table/%d/1/1/0", tableID), "false", "PENDING"},
+		{fmt.Sprintf("/Table/%d/1/2/0", tableID), "false", "PENDING"},
+	})
+
+	// Looking up the intents didn't abort their transaction.
+	require.NoError(t, tx.Commit())
+}
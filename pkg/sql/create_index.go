@@ -371,6 +371,7 @@ func maybeCreateAndAddShardCol(
 
 func (n *createIndexNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("index"))
+	firstNewColumnID, firstNewIndexID := n.tableDesc.NextColumnID, n.tableDesc.NextIndexID
 	_, dropped, err := n.tableDesc.FindIndexByName(string(n.n.Name))
 	if err == nil {
 		if dropped {
@@ -443,6 +444,11 @@ func (n *createIndexNode) startExec(params runParams) error {
 	if err := n.tableDesc.AllocateIDs(params.ctx); err != nil {
 		return err
 	}
+	if err := checkTableSchemaLimits(
+		&params.ExecCfg().Settings.SV, n.tableDesc, firstNewColumnID, firstNewIndexID,
+	); err != nil {
+		return err
+	}
 	// The index name may have changed as a result of
 	// AllocateIDs(). Retrieve it for the event log below.
 	index := n.tableDesc.Mutations[mutationIdx].GetIndex()
@@ -40,6 +40,9 @@ var userTableName = tree.NewTableName("system", "users")
 // RoleOptionsTableName represents system.role_options.
 var RoleOptionsTableName = tree.NewTableName("system", "role_options")
 
+// DatabaseRoleSettingsTableName represents system.database_role_settings.
+var DatabaseRoleSettingsTableName = tree.NewTableName("system", "database_role_settings")
+
 // CreateRole represents a CREATE ROLE statement.
 // Privileges: INSERT on system.users.
 //   notes: postgres allows the creation of users with an empty password. We do
@@ -322,7 +325,13 @@ func (p *planner) checkPasswordAndGetHash(
 		}
 	}
 
-	hashedPassword, err = security.HashPassword(password)
+	method := security.PasswordHashMethod(security.PasswordHashMethodSetting.Get(&st.SV))
+	if method == security.HashSCRAMSHA256 && !st.Version.IsActive(ctx, clusterversion.SCRAMAuthentication) {
+		// Nodes running a previous version cannot verify SCRAM verifiers, so
+		// the passwords are hashed with bcrypt until the upgrade is finalized.
+		method = security.HashBCrypt
+	}
+	hashedPassword, err = security.HashPasswordWithMethod(password, method)
 	if err != nil {
 		return hashedPassword, err
 	}
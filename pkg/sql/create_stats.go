@@ -144,9 +144,17 @@ func (n *createStatsNode) startJob(ctx context.Context, resultsCh chan<- tree.Da
 		if errors.Is(err, stats.ConcurrentCreateStatsError) {
 			// Delete the job so users don't see it and get confused by the error.
 			const stmt = `DELETE FROM system.jobs WHERE id = $1`
-			if _ /* cols */, delErr := n.p.ExecCfg().InternalExecutor.Exec(
-				ctx, "delete-job", nil /* txn */, stmt, *job.ID(),
-			); delErr != nil {
+			ie := n.p.ExecCfg().InternalExecutor
+			if delErr := n.p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+				if _ /* cols */, err := ie.Exec(ctx, "delete-job", txn, stmt, *job.ID()); err != nil {
+					return err
+				}
+				ids := tree.NewDArray(types.Int)
+				if err := ids.Append(tree.NewDInt(tree.DInt(*job.ID()))); err != nil {
+					return err
+				}
+				return jobs.DeleteJobInfo(ctx, ie, n.p.ExecCfg().Settings, txn, ids)
+			}); delErr != nil {
 				log.Warningf(ctx, "failed to delete job: %v", delErr)
 			}
 		}
@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -206,6 +207,13 @@ func getTableCreateParams(
 	} else if err != nil {
 		return nil, 0, err
 	}
+
+	if err := checkNameLength(&params.ExecCfg().Settings.SV, "relation", tableName.Table()); err != nil {
+		return nil, 0, err
+	}
+	if err := params.p.checkTableCountLimit(params.ctx, tableName.Table()); err != nil {
+		return nil, 0, err
+	}
 	return tKey, schemaID, nil
 }
 
@@ -274,6 +282,11 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	privs := CreateInheritedPrivilegesFromDBDesc(n.dbDesc, params.SessionData().User())
 
+	// The LIKE table definitions are replaced by the definitions they expand
+	// to while the descriptor is created, so the ones which copy comments,
+	// which can only be written once the table exists, are collected first.
+	likeDefsWithComments := likeTableDefsIncludingComments(n.n.Defs)
+
 	var asCols colinfo.ResultColumns
 	var desc *tabledesc.Mutable
 	var affected map[descpb.ID]*tabledesc.Mutable
@@ -363,6 +376,12 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	for _, d := range likeDefsWithComments {
+		if err := params.p.copyLikeTableComments(params.ctx, desc, d); err != nil {
+			return err
+		}
+	}
+
 	// TODO(otan): for MR databases with no locality set, set a default locality
 	// and add a notice.
 	if desc.LocalityConfig != nil {
@@ -385,6 +404,15 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	if err := checkTableSchemaLimits(
+		&params.ExecCfg().Settings.SV,
+		desc,
+		0, /* firstNewColumnID */
+		0, /* firstNewIndexID */
+	); err != nil {
+		return err
+	}
+
 	dg := catalogkv.NewOneLevelUncachedDescGetter(params.p.txn, params.ExecCfg().Codec)
 	if err := desc.Validate(params.ctx, dg); err != nil {
 		return err
@@ -421,7 +449,8 @@ func (n *createTableNode) startExec(params runParams) error {
 				params.ExecCfg().Codec,
 				desc.ImmutableCopy().(*tabledesc.Immutable),
 				desc.Columns,
-				params.p.alloc)
+				params.p.alloc,
+				&params.ExecCfg().Settings.SV)
 			if err != nil {
 				return err
 			}
@@ -908,10 +937,22 @@ func ResolveFK(
 	}
 
 	// Ensure that there is an index on the referenced side to use.
-	_, err = tabledesc.FindFKReferencedIndex(target, targetColIDs)
+	referencedIdx, err := tabledesc.FindFKReferencedIndex(target, targetColIDs)
 	if err != nil {
 		return err
 	}
+	// A foreign key referencing a partial unique index only references the rows
+	// that satisfy the predicate of the index. The referenced columns are not
+	// unique among the other rows, so a cascading action for a change to one of
+	// them could affect rows referencing a different row.
+	hasAction := func(a tree.ReferenceAction) bool {
+		return a != tree.NoAction && a != tree.Restrict
+	}
+	if referencedIdx.IsPartial() && (hasAction(d.Actions.Delete) || hasAction(d.Actions.Update)) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"foreign key referencing partial unique index %q cannot have a cascading action",
+			referencedIdx.Name)
+	}
 
 	var validity descpb.ConstraintValidity
 	if ts != NewTable {
@@ -954,7 +995,7 @@ func addIndexForFK(
 	ts TableState,
 ) (descpb.IndexID, error) {
 	autoIndexName := tabledesc.GenerateUniqueConstraintName(
-		fmt.Sprintf("%s_auto_index_%s", tbl.Name, constraintName),
+		tabledesc.FKAutoIndexName(tbl.Name, constraintName),
 		func(name string) bool {
 			return tbl.ValidateIndexNameIsUnique(name) != nil
 		},
@@ -1411,6 +1452,11 @@ func NewTableDesc(
 					return nil, pgerror.Newf(pgcode.Syntax, "virtual columns cannot have family specifications")
 				}
 			}
+			if d.HasOnUpdateExpr() && !evalCtx.Settings.Version.IsActive(ctx, clusterversion.OnUpdateExpressions) {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"version %v must be finalized to use ON UPDATE",
+					clusterversion.OnUpdateExpressions)
+			}
 
 			col, idx, expr, err := tabledesc.MakeColumnDefDescs(ctx, d, semaCtx, evalCtx)
 			if err != nil {
@@ -1581,6 +1627,10 @@ func NewTableDesc(
 			}
 		case *tree.UniqueConstraintTableDef:
 			if d.WithoutIndex {
+				if d.Sharded != nil {
+					return nil, pgerror.New(pgcode.FeatureNotSupported,
+						"unique constraints without an index cannot be hash sharded")
+				}
 				// We will add the unique constraint below.
 				break
 			}
@@ -1594,6 +1644,9 @@ func NewTableDesc(
 				if n.Interleave != nil && d.PrimaryKey {
 					return nil, pgerror.New(pgcode.FeatureNotSupported, "interleaved indexes cannot also be hash sharded")
 				}
+				if d.PartitionBy != nil {
+					return nil, pgerror.New(pgcode.FeatureNotSupported, "sharded indexes don't support partitioning")
+				}
 				if err := setupShardedIndexForNewTable(&d.IndexTableDef, &idx); err != nil {
 					return nil, err
 				}
@@ -1728,8 +1781,17 @@ func NewTableDesc(
 	// counter to map ColumnDefs to columns.
 	colIdx := 0
 	for i := range n.Defs {
-		if _, ok := n.Defs[i].(*tree.ColumnTableDef); ok {
-			if expr := columnDefaultExprs[i]; expr != nil {
+		if d, ok := n.Defs[i].(*tree.ColumnTableDef); ok {
+			exprs := []tree.TypedExpr{columnDefaultExprs[i]}
+			if d.HasOnUpdateExpr() {
+				// MakeColumnDefDescs replaced the ON UPDATE expression with its
+				// type-checked form.
+				exprs = append(exprs, d.OnUpdateExpr.Expr.(tree.TypedExpr))
+			}
+			for _, expr := range exprs {
+				if expr == nil {
+					continue
+				}
 				changedSeqDescs, err := maybeAddSequenceDependencies(ctx, vt, &desc, &desc.Columns[colIdx], expr, affected)
 				if err != nil {
 					return nil, err
@@ -2051,15 +2113,10 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		if err != nil {
 			return nil, err
 		}
-		opts := tree.LikeTableOpt(0)
-		// Process ons / offs.
-		for _, opt := range d.Options {
-			if opt.Excluded {
-				opts &^= opt.Opt
-			} else {
-				opts |= opt.Opt
-			}
+		if err := params.p.CheckPrivilege(params.ctx, td, privilege.SELECT); err != nil {
+			return nil, err
 		}
+		opts := likeTableOpts(d)
 
 		defs := make(tree.TableDefs, 0)
 		// Add all columns. Columns are always added.
@@ -2182,6 +2239,107 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 	return newDefs, nil
 }
 
+// likeTableOpts returns the options of a LIKE table definition, with the
+// later INCLUDING / EXCLUDING clauses taking precedence.
+func likeTableOpts(d *tree.LikeTableDef) tree.LikeTableOpt {
+	opts := tree.LikeTableOpt(0)
+	// Process ons / offs.
+	for _, opt := range d.Options {
+		if opt.Excluded {
+			opts &^= opt.Opt
+		} else {
+			opts |= opt.Opt
+		}
+	}
+	return opts
+}
+
+// likeTableDefsIncludingComments returns the LIKE table definitions among the
+// given ones that copy the comments of their source table.
+func likeTableDefsIncludingComments(defs tree.TableDefs) []*tree.LikeTableDef {
+	var res []*tree.LikeTableDef
+	for _, def := range defs {
+		if d, ok := def.(*tree.LikeTableDef); ok && likeTableOpts(d).Has(tree.LikeTableOptComments) {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// copyLikeTableComments copies the comments on the columns of the source
+// table of a LIKE ... INCLUDING COMMENTS table definition to the columns of
+// the same name of the new table. The comments on the indexes are copied the
+// same way if the indexes were copied too.
+func (p *planner) copyLikeTableComments(
+	ctx context.Context, desc *tabledesc.Mutable, d *tree.LikeTableDef,
+) error {
+	src, err := p.ResolveMutableTableDescriptor(ctx, &d.Name, true, tree.ResolveRequireTableDesc)
+	if err != nil {
+		return err
+	}
+	ie := p.ExtendedEvalContext().ExecCfg.InternalExecutor
+	rows, err := ie.QueryEx(
+		ctx,
+		"select-like-table-comments",
+		p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		"SELECT type, sub_id, comment FROM system.comments WHERE object_id = $1 AND type IN ($2, $3)",
+		src.GetID(),
+		keys.ColumnCommentType,
+		keys.IndexCommentType,
+	)
+	if err != nil {
+		return err
+	}
+	copyIndexes := likeTableOpts(d).Has(tree.LikeTableOptIndexes)
+	for _, row := range rows {
+		commentType := int(tree.MustBeDInt(row[0]))
+		subID := tree.MustBeDInt(row[1])
+		var newSubID tree.DInt
+		switch commentType {
+		case keys.ColumnCommentType:
+			srcCol, err := src.FindColumnByID(descpb.ColumnID(subID))
+			if err != nil {
+				return err
+			}
+			col, _, err := desc.FindColumnByName(tree.Name(srcCol.Name))
+			if err != nil {
+				// Hidden columns are not copied.
+				continue
+			}
+			newSubID = tree.DInt(col.ID)
+		case keys.IndexCommentType:
+			if !copyIndexes {
+				continue
+			}
+			srcIdx, err := src.FindIndexByID(descpb.IndexID(subID))
+			if err != nil {
+				return err
+			}
+			idx, _, err := desc.FindIndexByName(srcIdx.Name)
+			if err != nil {
+				// Default primary indexes are not copied.
+				continue
+			}
+			newSubID = tree.DInt(idx.ID)
+		}
+		if _, err := ie.ExecEx(
+			ctx,
+			"set-like-table-comment",
+			p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			"UPSERT INTO system.comments VALUES ($1, $2, $3, $4)",
+			commentType,
+			desc.GetID(),
+			newSubID,
+			row[2],
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
 // based on all the `colNames`.
 func makeShardColumnDesc(colNames []string, buckets int) (*descpb.ColumnDescriptor, error) {
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -178,6 +179,7 @@ func (n *createViewNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		desc.OriginalViewStatement = params.p.originalViewStatement()
 
 		if n.materialized {
 			// Ensure all nodes are the correct version.
@@ -308,6 +310,16 @@ func makeViewTableDesc(
 	return desc, nil
 }
 
+// originalViewStatement returns the text of the CREATE VIEW statement being
+// executed, as it was typed by the user. It returns an empty string if the
+// view is being created by some other statement.
+func (p *planner) originalViewStatement() string {
+	if _, ok := p.stmt.AST.(*tree.CreateView); !ok {
+		return ""
+	}
+	return strings.TrimSpace(p.stmt.SQL)
+}
+
 // replaceViewDesc modifies and returns the input view descriptor changed
 // to hold the new view represented by n. Note that back references from
 // tables that the new view depends on still need to be added. This function
@@ -321,6 +333,7 @@ func (p *planner) replaceViewDesc(
 ) (*tabledesc.Mutable, error) {
 	// Set the query to the new query.
 	toReplace.ViewQuery = n.viewQuery
+	toReplace.OriginalViewStatement = p.originalViewStatement()
 	// Reset the columns to add the new result columns onto.
 	toReplace.Columns = make([]descpb.ColumnDescriptor, 0, len(n.columns))
 	toReplace.NextColumnID = 0
@@ -40,6 +40,9 @@ func TryDelegate(
 	case *tree.ShowClusterSettingList:
 		return d.delegateShowClusterSettingList(t)
 
+	case *tree.ShowClusterUpgradeStatus:
+		return d.delegateShowClusterUpgradeStatus()
+
 	case *tree.ShowDatabases:
 		return d.delegateShowDatabases(t)
 
@@ -52,6 +55,9 @@ func TryDelegate(
 	case *tree.ShowCreate:
 		return d.delegateShowCreate(t)
 
+	case *tree.ShowCreateAllTables:
+		return d.delegateShowCreateAllTables()
+
 	case *tree.ShowDatabaseIndexes:
 		return d.delegateShowDatabaseIndexes(t)
 
@@ -61,6 +67,9 @@ func TryDelegate(
 	case *tree.ShowColumns:
 		return d.delegateShowColumns(t)
 
+	case *tree.ShowCompactions:
+		return d.delegateShowCompactions()
+
 	case *tree.ShowConstraints:
 		return d.delegateShowConstraints(t)
 
@@ -118,6 +127,9 @@ func TryDelegate(
 	case *tree.ShowVar:
 		return d.delegateShowVar(t)
 
+	case *tree.ShowWebSessions:
+		return d.delegateShowWebSessions(t)
+
 	case *tree.ShowZoneConfig:
 		return d.delegateShowZoneConfig(t)
 
@@ -139,6 +151,9 @@ func TryDelegate(
 	case *tree.ShowSavepointStatus:
 		return nil, unimplemented.NewWithIssue(47333, "cannot use SHOW SAVEPOINT STATUS as a statement source")
 
+	case *tree.ShowTransactionDetails:
+		return nil, unimplemented.NewWithIssue(47333, "cannot use SHOW TRANSACTION DETAILS as a statement source")
+
 	default:
 		return nil, nil
 	}
@@ -0,0 +1,25 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package delegate
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+// delegateShowClusterUpgradeStatus implements SHOW CLUSTER UPGRADE STATUS,
+// which returns the rows of crdb_internal.cluster_upgrade_status.
+func (d *delegator) delegateShowClusterUpgradeStatus() (tree.Statement, error) {
+	sqltelemetry.IncrementShowCounter(sqltelemetry.ClusterUpgradeStatus)
+	return parse(`
+SELECT component, name, version, status, details
+  FROM crdb_internal.cluster_upgrade_status`)
+}
@@ -0,0 +1,20 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package delegate
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+func (d *delegator) delegateShowCompactions() (tree.Statement, error) {
+	return parse(`
+SELECT job_id, status, description, stores_compacted, stores_total, fraction_completed, created
+  FROM crdb_internal.compactions
+ ORDER BY created`)
+}
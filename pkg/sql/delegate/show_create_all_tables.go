@@ -0,0 +1,41 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package delegate
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+// delegateShowCreateAllTables implements SHOW CREATE ALL TABLES, which returns
+// the statements needed to recreate the tables, views and sequences of the
+// current database, in the order of crdb_internal.show_create_all: every
+// descriptor is created after the descriptors it depends on, and foreign keys
+// are added and validated once all of them exist, so that the output can be
+// replayed as is.
+// Privileges: None.
+func (d *delegator) delegateShowCreateAllTables() (tree.Statement, error) {
+	sqltelemetry.IncrementShowCounter(sqltelemetry.CreateAllTables)
+
+	dbName, err := d.getSpecifiedOrCurrentDatabase("" /* specifiedDB */)
+	if err != nil {
+		return nil, err
+	}
+
+	const showCreateAllTablesQuery = `
+SELECT statement || ';' AS create_statement
+  FROM crdb_internal.show_create_all(%s) WITH ORDINALITY
+ ORDER BY ordinality`
+	return parse(fmt.Sprintf(showCreateAllTablesQuery, lex.EscapeSQLString(string(dbName))))
+}
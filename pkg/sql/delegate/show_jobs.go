@@ -30,12 +30,15 @@ SHOW JOBS SELECT id FROM system.jobs WHERE created_by_type='%s' and created_by_i
 
 	sqltelemetry.IncrementShowCounter(sqltelemetry.Jobs)
 
-	const (
-		selectClause = `SELECT job_id, job_type, description, statement, user_name, status,
+	columns := `job_id, job_type, description, statement, user_name, status,
 				       running_status, created, started, finished, modified,
-				       fraction_completed, error, coordinator_id
-				FROM crdb_internal.jobs`
-	)
+				       fraction_completed, error, coordinator_id, num_runs, last_run`
+	if n.Details {
+		// The details of a job consist of the report of the schema clauses that
+		// an IMPORT rewrote or dropped, and of its type-specific details.
+		columns += `, conversion_report, details`
+	}
+	selectClause := fmt.Sprintf(`SELECT %s FROM crdb_internal.jobs`, columns)
 	var typePredicate, whereClause, orderbyClause string
 	if n.Jobs == nil {
 		// Display all [only automatic] jobs without selecting specific jobs.
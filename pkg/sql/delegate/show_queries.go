@@ -11,7 +11,10 @@
 package delegate
 
 import (
+	"strings"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 )
@@ -23,9 +26,22 @@ func (d *delegator) delegateShowQueries(n *tree.ShowQueries) (tree.Statement, er
 	if n.Cluster {
 		table = `cluster_queries`
 	}
-	var filter string
+	var conds []string
 	if !n.All {
-		filter = " WHERE application_name NOT LIKE '" + catconstants.InternalAppNamePrefix + "%'"
+		conds = append(conds, "application_name NOT LIKE '"+catconstants.InternalAppNamePrefix+"%'")
+	}
+	// The equality filters below are served by the virtual indexes on
+	// user_name and application_name, which push them down to the
+	// ListSessions requests sent to the nodes.
+	if !n.User.Undefined() {
+		conds = append(conds, "user_name = "+lex.EscapeSQLString(n.User.Normalized()))
+	}
+	if n.Application != nil {
+		conds = append(conds, "application_name = "+lex.EscapeSQLString(*n.Application))
+	}
+	var filter string
+	if len(conds) > 0 {
+		filter = " WHERE " + strings.Join(conds, " AND ")
 	}
 	return parse(query + table + filter)
 }
@@ -26,11 +26,26 @@ func (d *delegator) delegateShowSchemas(n *tree.ShowSchemas) (tree.Statement, er
 	if err != nil {
 		return nil, err
 	}
+	columns := `nspname AS schema_name, rolname AS owner`
+	var privilegesJoin string
+	if n.WithPrivileges {
+		// The privileges of each schema are listed as "grantee=privilege" pairs,
+		// mirroring the rows returned by SHOW GRANTS ON SCHEMA.
+		columns += `, p.privileges`
+		privilegesJoin = `
+      LEFT JOIN (
+        SELECT table_schema,
+               array_agg(grantee || '=' || privilege_type ORDER BY grantee, privilege_type) AS privileges
+        FROM %[1]s.information_schema.schema_privileges
+        WHERE table_catalog = %[2]s
+        GROUP BY table_schema
+      ) p ON (p.table_schema = i.schema_name)`
+	}
 	getSchemasQuery := fmt.Sprintf(`
-      SELECT nspname AS schema_name, rolname AS owner
+      SELECT `+columns+`
       FROM %[1]s.information_schema.schemata i
       INNER JOIN pg_catalog.pg_namespace n ON (n.nspname = i.schema_name)
-      LEFT JOIN pg_catalog.pg_roles r ON (n.nspowner = r.oid)
+      LEFT JOIN pg_catalog.pg_roles r ON (n.nspowner = r.oid)`+privilegesJoin+`
 			WHERE catalog_name = %[2]s
 			ORDER BY schema_name`,
 		name.String(), // note: (tree.Name).String() != string(name)
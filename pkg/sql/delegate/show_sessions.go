@@ -16,7 +16,8 @@ import (
 )
 
 func (d *delegator) delegateShowSessions(n *tree.ShowSessions) (tree.Statement, error) {
-	const query = `SELECT node_id, session_id, user_name, client_address, application_name, active_queries, last_active_query, session_start, oldest_query_start FROM crdb_internal.`
+	// A session has at most one open transaction, whose ID is kv_txn.
+	const query = `SELECT node_id, session_id, user_name, client_address, application_name, active_queries, last_active_query, session_start, oldest_query_start, cluster_name, alloc_bytes, max_alloc_bytes, IF(kv_txn IS NULL, 0, 1) AS num_open_txns FROM crdb_internal.`
 	table := `node_sessions`
 	if n.Cluster {
 		table = `cluster_sessions`
@@ -22,7 +22,14 @@ import (
 func (d *delegator) delegateShowCreate(n *tree.ShowCreate) (tree.Statement, error) {
 	sqltelemetry.IncrementShowCounter(sqltelemetry.Create)
 
-	const showCreateQuery = `
+	// WITH ORIGINAL falls back to the regular CREATE statement for tables,
+	// sequences and views created before original statements were recorded.
+	createStmt := "create_statement"
+	if n.Original {
+		createStmt = "COALESCE(original_create_statement, create_statement)"
+	}
+
+	showCreateQuery := `
 WITH zone_configs AS (
     SELECT string_agg(raw_config_sql, e';\n') FROM crdb_internal.zones
     WHERE database_name = %[1]s
@@ -32,7 +39,7 @@ WITH zone_configs AS (
 )
 SELECT
     %[3]s AS table_name,
-    concat(create_statement,
+    concat(` + createStmt + `,
         CASE
         WHEN NOT has_partitions
             THEN NULL
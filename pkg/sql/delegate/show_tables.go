@@ -64,12 +64,14 @@ SELECT ns.nspname AS schema_name,
        s.estimated_row_count AS estimated_row_count,
        ct.locality AS locality
        %[3]s
+       %[5]s
 FROM %[1]s.pg_catalog.pg_class AS pc
 LEFT JOIN %[1]s.pg_catalog.pg_roles AS rl on (pc.relowner = rl.oid)
 JOIN %[1]s.pg_catalog.pg_namespace AS ns ON (ns.oid = pc.relnamespace)
 %[4]s
 LEFT JOIN crdb_internal.table_row_statistics AS s ON (s.table_id = pc.oid::INT8)
 LEFT JOIN crdb_internal.tables AS ct ON (pc.oid::int8 = ct.table_id)
+%[6]s
 WHERE pc.relkind IN ('r', 'v', 'S', 'm') %[2]s
 ORDER BY schema_name, table_name
 `
@@ -82,12 +84,19 @@ ORDER BY schema_name, table_name
 		)
 		comment = `, COALESCE(pd.description, '') AS comment`
 	}
+	var details, detailsJoin string
+	if n.WithDetails {
+		details = `, du.approximate_disk_bytes AS approximate_disk_bytes`
+		detailsJoin = `LEFT JOIN crdb_internal.table_disk_usage AS du ON (du.table_id = pc.oid::INT8)`
+	}
 	query := fmt.Sprintf(
 		getTablesQuery,
 		&name.CatalogName,
 		schemaClause,
 		comment,
 		descJoin,
+		details,
+		detailsJoin,
 	)
 	return parse(query)
 }
@@ -16,7 +16,8 @@ import (
 )
 
 func (d *delegator) delegateShowTransactions(n *tree.ShowTransactions) (tree.Statement, error) {
-	const query = `SELECT node_id, id AS txn_id, application_name, num_stmts, num_retries, num_auto_retries FROM `
+	const query = `SELECT node_id, id AS txn_id, application_name, user_name, now()::TIMESTAMP - start AS age, ` +
+		`num_stmts, num_retries, num_auto_retries, isolation, idle FROM `
 	table := `"".crdb_internal.node_transactions`
 	if n.Cluster {
 		table = `"".crdb_internal.cluster_transactions`
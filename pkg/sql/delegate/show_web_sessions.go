@@ -0,0 +1,31 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package delegate
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+// delegateShowWebSessions implements SHOW WEB SESSIONS, which returns the rows
+// of crdb_internal.web_sessions. Unless ALL is specified, expired and revoked
+// sessions are filtered out.
+func (d *delegator) delegateShowWebSessions(n *tree.ShowWebSessions) (tree.Statement, error) {
+	sqltelemetry.IncrementShowCounter(sqltelemetry.WebSessions)
+	const query = `
+SELECT id, username, created, expires, revoked, last_used, client_address
+  FROM crdb_internal.web_sessions`
+	var filter string
+	if !n.All {
+		filter = ` WHERE revoked IS NULL AND expires > now()`
+	}
+	return parse(query + filter)
+}
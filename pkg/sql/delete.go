@@ -72,6 +72,7 @@ func (d *deleteNode) startExec(params runParams) error {
 			params.EvalContext().Mon.MakeBoundAccount(),
 			colinfo.ColTypeInfoFromResCols(d.columns))
 	}
+	params.p.recordTableWrite(d.run.td.tableDesc().GetID())
 	return d.run.td.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
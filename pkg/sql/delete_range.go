@@ -99,12 +99,14 @@ func (d *deleteRangeNode) startExec(params runParams) error {
 		Index: d.desc.GetPrimaryIndex(),
 		Spans: d.spans,
 	}
+	params.p.recordTableWrite(d.desc.GetID())
 	for i, interleaved := range d.interleavedDesc {
 		allTables[i+1] = row.FetcherTableArgs{
 			Desc:  interleaved,
 			Index: interleaved.GetPrimaryIndex(),
 			Spans: d.spans,
 		}
+		params.p.recordTableWrite(interleaved.GetID())
 	}
 	if err := d.fetcher.Init(
 		params.ctx,
@@ -82,6 +82,13 @@ func (p *planner) createDatabase(
 		return nil, false, err
 	}
 
+	if err := checkNameLength(&p.ExecCfg().Settings.SV, "database", dbName); err != nil {
+		return nil, false, err
+	}
+	if err := p.checkDatabaseCountLimit(ctx, dbName); err != nil {
+		return nil, false, err
+	}
+
 	id, err := catalogkv.GenerateUniqueDescID(ctx, p.ExecCfg().DB, p.ExecCfg().Codec)
 	if err != nil {
 		return nil, false, err
@@ -13,6 +13,7 @@ package distsql
 import (
 	"context"
 	"io"
+	"math"
 	"sync"
 	"time"
 
@@ -124,6 +125,12 @@ func (ds *ServerImpl) Start() {
 	ds.flowScheduler.Start()
 }
 
+// Flows returns the remote flows that are currently running or queued on this
+// node.
+func (ds *ServerImpl) Flows() []flowinfra.FlowInfo {
+	return ds.flowScheduler.Serialize()
+}
+
 // Drain changes the node's draining state through gossip and drains the
 // server's flowRegistry. See flowRegistry.Drain for more details.
 func (ds *ServerImpl) Drain(
@@ -222,16 +229,29 @@ func (ds *ServerImpl) setupFlow(
 		)
 	}
 
-	// The monitor opened here is closed in Flow.Cleanup().
-	monitor := mon.NewMonitor(
+	// The monitor opened here is closed in Flow.Cleanup(). It is limited by the
+	// max_query_memory session variable, if set. The operators that can spill
+	// to disk do so when they reach that limit, like when they reach their own;
+	// the other ones fail the query with the breakdown of its memory usage.
+	limit := int64(math.MaxInt64)
+	if maxQueryMemory := req.EvalContext.SessionData.MaxQueryMemory; maxQueryMemory > 0 {
+		limit = maxQueryMemory
+	}
+	monitor := mon.NewMonitorWithLimit(
 		"flow",
 		mon.MemoryResource,
+		limit,
 		ds.Metrics.CurBytesCount,
 		ds.Metrics.MaxBytesHist,
 		-1, /* use default block size */
 		noteworthyMemoryUsageBytes,
 		ds.Settings,
 	)
+	if limit != math.MaxInt64 {
+		monitor.EnableLimitBreakdown(
+			"The query exceeded max_query_memory on this node. Consider increasing " +
+				"max_query_memory or reducing the number of rows the query processes.")
+	}
 	monitor.Start(ctx, parentMonitor, mon.BoundAccount{})
 
 	makeLeaf := func(req *execinfrapb.SetupFlowRequest) (*kv.Txn, error) {
@@ -307,6 +327,9 @@ func (ds *ServerImpl) setupFlow(
 
 	// Create the FlowCtx for the flow.
 	flowCtx := ds.NewFlowContext(ctx, req.Flow.FlowID, evalCtx, req.TraceKV, localState)
+	if req.TimeoutNanos != 0 {
+		flowCtx.Deadline = timeutil.Now().Add(time.Duration(req.TimeoutNanos))
+	}
 
 	// req always contains the desired vectorize mode, regardless of whether we
 	// have non-nil localState.EvalContext. We don't want to update EvalContext
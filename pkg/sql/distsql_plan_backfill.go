@@ -27,13 +27,15 @@ func initBackfillerSpec(
 	desc descpb.TableDescriptor,
 	duration time.Duration,
 	chunkSize int64,
+	maxRowsPerSecond int64,
 	readAsOf hlc.Timestamp,
 ) (execinfrapb.BackfillerSpec, error) {
 	ret := execinfrapb.BackfillerSpec{
-		Table:     desc,
-		Duration:  duration,
-		ChunkSize: chunkSize,
-		ReadAsOf:  readAsOf,
+		Table:            desc,
+		Duration:         duration,
+		ChunkSize:        chunkSize,
+		MaxRowsPerSecond: maxRowsPerSecond,
+		ReadAsOf:         readAsOf,
 	}
 	switch backfillType {
 	case indexBackfill:
@@ -55,10 +57,13 @@ func (dsp *DistSQLPlanner) createBackfiller(
 	desc descpb.TableDescriptor,
 	duration time.Duration,
 	chunkSize int64,
+	maxRowsPerSecond int64,
 	spans []roachpb.Span,
 	readAsOf hlc.Timestamp,
 ) (*PhysicalPlan, error) {
-	spec, err := initBackfillerSpec(backfillType, desc, duration, chunkSize, readAsOf)
+	spec, err := initBackfillerSpec(
+		backfillType, desc, duration, chunkSize, maxRowsPerSecond, readAsOf,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -12,6 +12,7 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"sync/atomic"
 	"time"
@@ -28,9 +29,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/logtags"
+	pbtypes "github.com/gogo/protobuf/types"
 )
 
 // RowResultWriter is a thin wrapper around a RowContainer.
@@ -115,6 +119,7 @@ func makeImportReaderSpecs(
 
 	// For each input file, assign it to a node.
 	inputSpecs := make([]*execinfrapb.ReadImportDataSpec, 0, len(nodes))
+	details := job.Details().(jobspb.ImportDetails)
 	progress := job.Progress()
 	importProgress := progress.GetImport()
 	for i, input := range from {
@@ -128,10 +133,12 @@ func makeImportReaderSpecs(
 					JobID: *job.ID(),
 					Slot:  int32(i),
 				},
-				WalltimeNanos: walltime,
-				Uri:           make(map[int32]string),
-				ResumePos:     make(map[int32]int64),
-				UserProto:     user.EncodeProto(),
+				WalltimeNanos:   walltime,
+				Uri:             make(map[int32]string),
+				ResumePos:       make(map[int32]int64),
+				UserProto:       user.EncodeProto(),
+				DetectConflicts: details.DetectConflicts,
+				SkipDuplicates:  details.SkipDuplicates,
 			}
 			inputSpecs = append(inputSpecs, spec)
 		}
@@ -150,27 +157,41 @@ func makeImportReaderSpecs(
 	return inputSpecs
 }
 
+// presplitTableBoundaries splits and scatters the index spans of the tables
+// being imported. reportProgress is called before the first span and after
+// each span has been split and scattered, with the number of spans processed
+// so far and the total number of spans.
 func presplitTableBoundaries(
 	ctx context.Context,
 	cfg *ExecutorConfig,
 	tables map[string]*execinfrapb.ReadImportDataSpec_ImportTable,
+	reportProgress func(done, total int) error,
 ) error {
-	expirationTime := cfg.DB.Clock().Now().Add(time.Hour.Nanoseconds(), 0)
+	var spans []roachpb.Span
 	for _, tbl := range tables {
 		// TODO(ajwerner): Consider passing in the wrapped descriptors.
 		tblDesc := tabledesc.MakeImmutable(*tbl.Desc)
-		for _, span := range tblDesc.AllIndexSpans(cfg.Codec) {
-			if err := cfg.DB.AdminSplit(ctx, span.Key, expirationTime); err != nil {
-				return err
-			}
+		spans = append(spans, tblDesc.AllIndexSpans(cfg.Codec)...)
+	}
 
-			log.VEventf(ctx, 1, "scattering index range %s", span.Key)
-			scatterReq := &roachpb.AdminScatterRequest{
-				RequestHeader: roachpb.RequestHeaderFromSpan(span),
-			}
-			if _, pErr := kv.SendWrapped(ctx, cfg.DB.NonTransactionalSender(), scatterReq); pErr != nil {
-				log.Errorf(ctx, "failed to scatter span %s: %s", span.Key, pErr)
-			}
+	if err := reportProgress(0, len(spans)); err != nil {
+		return err
+	}
+	expirationTime := cfg.DB.Clock().Now().Add(time.Hour.Nanoseconds(), 0)
+	for i, span := range spans {
+		if err := cfg.DB.AdminSplit(ctx, span.Key, expirationTime); err != nil {
+			return err
+		}
+
+		log.VEventf(ctx, 1, "scattering index range %s", span.Key)
+		scatterReq := &roachpb.AdminScatterRequest{
+			RequestHeader: roachpb.RequestHeaderFromSpan(span),
+		}
+		if _, pErr := kv.SendWrapped(ctx, cfg.DB.NonTransactionalSender(), scatterReq); pErr != nil {
+			log.Errorf(ctx, "failed to scatter span %s: %s", span.Key, pErr)
+		}
+		if err := reportProgress(i+1, len(spans)); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -242,6 +263,10 @@ func DistIngest(
 
 	rowProgress := make([]int64, len(from))
 	fractionProgress := make([]uint32, len(from))
+	// ingestedBytes counts the bytes reported as ingested by the processors
+	// since the last running status update.
+	var ingestedBytes int64
+	lastRateUpdate := timeutil.Now()
 
 	updateJobProgress := func() error {
 		return job.FractionProgressed(ctx,
@@ -261,6 +286,15 @@ func DistIngest(
 		)
 	}
 
+	updateRunningStatus := func() error {
+		elapsed := timeutil.Since(lastRateUpdate)
+		lastRateUpdate = timeutil.Now()
+		rate := int64(float64(atomic.SwapInt64(&ingestedBytes, 0)) / elapsed.Seconds())
+		return job.RunningStatus(ctx, func(_ context.Context, _ jobspb.Details) (jobs.RunningStatus, error) {
+			return jobs.RunningStatus(fmt.Sprintf("importing data at %s/s", humanizeutil.IBytes(rate))), nil
+		})
+	}
+
 	metaFn := func(_ context.Context, meta *execinfrapb.ProducerMetadata) error {
 		if meta.BulkProcessorProgress != nil {
 			for i, v := range meta.BulkProcessorProgress.ResumePos {
@@ -269,6 +303,13 @@ func DistIngest(
 			for i, v := range meta.BulkProcessorProgress.CompletedFraction {
 				atomic.StoreUint32(&fractionProgress[i], math.Float32bits(v))
 			}
+			var ingested roachpb.BulkOpSummary
+			if meta.BulkProcessorProgress.ProgressDetails.TypeUrl != "" {
+				if err := pbtypes.UnmarshalAny(&meta.BulkProcessorProgress.ProgressDetails, &ingested); err != nil {
+					log.Warningf(ctx, "unable to unmarshal import progress details: %+v", err)
+				}
+			}
+			atomic.AddInt64(&ingestedBytes, ingested.DataSize)
 
 			if alwaysFlushProgress {
 				return updateJobProgress()
@@ -288,7 +329,21 @@ func DistIngest(
 	})
 
 	if evalCtx.Codec.ForSystemTenant() {
-		if err := presplitTableBoundaries(ctx, execCtx.ExecCfg(), tables); err != nil {
+		// Pre-splitting and scattering can take minutes on large clusters, during
+		// which no data is ingested. Surface it in the running status so that the
+		// job does not look stuck at 0%.
+		lastPresplitUpdate := timeutil.Now()
+		reportPresplit := func(done, total int) error {
+			if done > 0 && done < total && timeutil.Since(lastPresplitUpdate) < time.Second {
+				return nil
+			}
+			lastPresplitUpdate = timeutil.Now()
+			return job.RunningStatus(ctx, func(_ context.Context, _ jobspb.Details) (jobs.RunningStatus, error) {
+				return jobs.RunningStatus(fmt.Sprintf(
+					"pre-splitting and scattering table spans: %d of %d done", done, total)), nil
+			})
+		}
+		if err := presplitTableBoundaries(ctx, execCtx.ExecCfg(), tables, reportPresplit); err != nil {
 			return roachpb.BulkOpSummary{}, err
 		}
 	}
@@ -320,6 +375,9 @@ func DistIngest(
 				if err := updateJobProgress(); err != nil {
 					return err
 				}
+				if err := updateRunningStatus(); err != nil {
+					return err
+				}
 			}
 		}
 	})
@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -187,6 +188,21 @@ func (dsp *DistSQLPlanner) setupFlows(
 		}
 		req := setupReq
 		req.Flow = *flowSpec
+		if !evalCtx.StmtDeadline.IsZero() {
+			// Remote flows cancel themselves once the statement times out, so that
+			// they don't outlive it when the cancellation of the gateway flow
+			// doesn't reach them (or reaches them late). The gateway flow doesn't
+			// need this, since the timeout cancels the query on this node. The
+			// remaining time is sent, rather than the deadline, as the clocks of
+			// the nodes may be skewed.
+			timeout := timeutil.Until(evalCtx.StmtDeadline)
+			if timeout <= 0 {
+				// The statement already timed out; the remote flow is canceled as
+				// soon as it is set up.
+				timeout = 1
+			}
+			req.TimeoutNanos = timeout.Nanoseconds()
+		}
 		runReq := runnerRequest{
 			ctx:        ctx,
 			nodeDialer: dsp.nodeDialer,
@@ -300,6 +316,16 @@ func (dsp *DistSQLPlanner) Run(
 		recv.SetError(errors.Errorf("expected to find gateway flow"))
 		return func() {}
 	}
+	if planCtx.planner != nil && planCtx.planner.stmt.AST != nil {
+		// Annotate the remote flows with the statement they belong to, so that
+		// the nodes running them can attribute them to it (see
+		// crdb_internal.flows).
+		for nodeID, flow := range flows {
+			if nodeID != dsp.gatewayNodeID {
+				flow.StatementSQL = planCtx.planner.stmt.AnonymizedStr
+			}
+		}
+	}
 
 	if planCtx.saveFlows != nil {
 		if err := planCtx.saveFlows(flows); err != nil {
@@ -733,8 +759,11 @@ func (r *DistSQLReceiver) Push(
 			// of some accepted technical debt (see comments on
 			// sql/pgwire.limitedCommandResult.moreResultsNeeded).
 			// Instead of changing the signature of AddRow, we have
-			// a sentinel error that is handled specially here.
-			if !errors.Is(commErr, ErrLimitedResultNotSupported) {
+			// a sentinel error that is handled specially here. The same
+			// goes for results overflowing the buffer of an implicit
+			// transaction, which restart the transaction instead.
+			if !errors.Is(commErr, ErrLimitedResultNotSupported) &&
+				!errors.Is(commErr, ErrImplicitTxnResultsOverflow) {
 				r.commErr = commErr
 			}
 		}
@@ -753,6 +782,10 @@ var (
 	// ErrLimitedResultClosed is a sentinel error produced by pgwire
 	// indicating the portal should be closed without error.
 	ErrLimitedResultClosed = errors.New("row count limit closed")
+	// ErrImplicitTxnResultsOverflow is a sentinel error produced by pgwire
+	// indicating that the results of an implicit transaction no longer fit in
+	// the results buffer. See RestrictedCommandResult.ExtendBuffering.
+	ErrImplicitTxnResultsOverflow = errors.New("implicit transaction results overflowed the results buffer")
 )
 
 // ProducerDone is part of the RowReceiver interface.
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -69,6 +70,9 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 	}
 	if !found {
 		// IfExists was specified and database was not found.
+		if n.DryRun {
+			return p.newDropDryRunNode(ctx, nil /* objects */)
+		}
 		return newZeroNode(nil /* columns */), nil
 	}
 
@@ -117,6 +121,14 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
+	if n.DryRun {
+		objects, err := p.dropDryRunDatabaseObjects(ctx, dbDesc, d)
+		if err != nil {
+			return nil, err
+		}
+		return p.newDropDryRunNode(ctx, objects)
+	}
+
 	return &dropDatabaseNode{
 		n:      n,
 		dbDesc: dbDesc,
@@ -195,6 +207,10 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := p.removeDbRoleSettings(ctx, n.dbDesc.GetID()); err != nil {
+		return err
+	}
+
 	// Log Drop Database event. This is an auditable log event and is recorded
 	// in the same transaction as the table descriptor update.
 	return p.logEvent(ctx,
@@ -319,3 +335,20 @@ func (p *planner) removeDbComment(ctx context.Context, dbID descpb.ID) error {
 
 	return err
 }
+
+// removeDbRoleSettings removes the session variable defaults configured with
+// ALTER ROLE ... IN DATABASE for the database.
+func (p *planner) removeDbRoleSettings(ctx context.Context, dbID descpb.ID) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettings) {
+		return nil
+	}
+	_, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.ExecEx(
+		ctx,
+		"delete-db-role-settings",
+		p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		"DELETE FROM system.database_role_settings WHERE database_id=$1",
+		tree.NewDOid(tree.DInt(dbID)))
+
+	return err
+}
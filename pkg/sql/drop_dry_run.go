@@ -0,0 +1,147 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// dropDryRunColumns are the result columns of a DROP ... DRY RUN statement.
+var dropDryRunColumns = colinfo.ResultColumns{
+	{Name: "object_type", Typ: types.String},
+	{Name: "object_name", Typ: types.String},
+}
+
+// dropDryRunObject is an object that would be dropped or modified by a DROP
+// statement.
+type dropDryRunObject struct {
+	typ  string
+	name string
+}
+
+// newDropDryRunNode returns a planNode listing the given objects, ordered by
+// type and name.
+func (p *planner) newDropDryRunNode(
+	ctx context.Context, objects []dropDryRunObject,
+) (planNode, error) {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].typ != objects[j].typ {
+			return objects[i].typ < objects[j].typ
+		}
+		return objects[i].name < objects[j].name
+	})
+	v := p.newContainerValuesNode(dropDryRunColumns, len(objects))
+	for _, o := range objects {
+		row := tree.Datums{tree.NewDString(o.typ), tree.NewDString(o.name)}
+		if _, err := v.rows.AddRow(ctx, row); err != nil {
+			v.Close(ctx)
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// dropDryRunTableObjects returns the tables, views and sequences that would
+// be dropped, along with the foreign key constraints that would be removed
+// from the tables that are not dropped.
+func (p *planner) dropDryRunTableObjects(
+	ctx context.Context, tables []*tabledesc.Mutable,
+) ([]dropDryRunObject, error) {
+	dropped := make(map[descpb.ID]struct{}, len(tables))
+	for _, desc := range tables {
+		dropped[desc.ID] = struct{}{}
+	}
+	objects := make([]dropDryRunObject, 0, len(tables))
+	for _, desc := range tables {
+		tn, err := p.getQualifiedTableName(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, dropDryRunObject{
+			typ:  objectDependencyType(desc),
+			name: tn.FQString(),
+		})
+		for i := range desc.InboundFKs {
+			ref := &desc.InboundFKs[i]
+			if _, ok := dropped[ref.OriginTableID]; ok {
+				continue
+			}
+			originDesc, err := p.LookupTableByID(ctx, ref.OriginTableID)
+			if err != nil {
+				return nil, err
+			}
+			originName, err := p.getQualifiedTableName(ctx, originDesc)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, dropDryRunObject{
+				typ:  "foreign key constraint",
+				name: fmt.Sprintf("%s on %s", ref.Name, originName.FQString()),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// dropDryRunDatabaseObjects returns the database, schemas, tables, views,
+// sequences and types that would be dropped by a DROP DATABASE statement,
+// along with the foreign key constraints that would be removed from tables
+// in other databases.
+func (p *planner) dropDryRunDatabaseObjects(
+	ctx context.Context, dbDesc *dbdesc.Mutable, d *dropCascadeState,
+) ([]dropDryRunObject, error) {
+	objects, err := p.dropDryRunTableObjects(ctx, d.allTableObjectsToDelete)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, dropDryRunObject{
+		typ:  "database",
+		name: tree.NameString(dbDesc.GetName()),
+	})
+	for _, sc := range d.schemasToDelete {
+		if sc.schema.Kind != catalog.SchemaUserDefined {
+			continue
+		}
+		objects = append(objects, dropDryRunObject{
+			typ:  "schema",
+			name: tree.NameString(dbDesc.GetName()) + "." + tree.NameString(sc.schema.Name),
+		})
+	}
+	for _, typ := range d.typesToDelete {
+		// Array types are dropped along with the types they alias.
+		if typ.Kind == descpb.TypeDescriptor_ALIAS {
+			continue
+		}
+		schemaName, err := resolver.ResolveSchemaNameByID(
+			ctx, p.txn, p.ExecCfg().Codec, typ.ParentID, typ.ParentSchemaID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tn := tree.MakeNewQualifiedTypeName(dbDesc.GetName(), schemaName, typ.GetName())
+		objects = append(objects, dropDryRunObject{
+			typ:  "type",
+			name: tn.FQString(),
+		})
+	}
+	return objects, nil
+}
@@ -388,11 +388,21 @@ func (p *planner) dropIndexByName(
 		tableDesc.InboundFKs[sliceIdx] = tableDesc.InboundFKs[i]
 		sliceIdx++
 		fk := &tableDesc.InboundFKs[i]
-		canReplace := func(idx *descpb.IndexDescriptor) bool {
-			return idx.IsValidReferencedIndex(fk.ReferencedColumnIDs)
+		// A foreign key referencing a partial unique index only references the
+		// rows that satisfy its predicate, so the index can only be replaced by an
+		// index that is not partial or that has the same predicate.
+		canReplace := func(other *descpb.IndexDescriptor) bool {
+			return other.IsValidReferencedIndex(fk.ReferencedColumnIDs) ||
+				(idx.IsPartial() && other.IsValidPartialReferencedIndex(fk.ReferencedColumnIDs) &&
+					other.Predicate == idx.Predicate)
+		}
+		isReferencedIndex := idx.IsValidReferencedIndex(fk.ReferencedColumnIDs)
+		if idx.IsValidPartialReferencedIndex(fk.ReferencedColumnIDs) {
+			referencedIdx, err := tabledesc.FindFKReferencedIndex(tableDesc, fk.ReferencedColumnIDs)
+			isReferencedIndex = err == nil && referencedIdx.ID == idx.ID
 		}
 		// The index being deleted could potentially be the referenced index for this fk.
-		if idx.IsValidReferencedIndex(fk.ReferencedColumnIDs) &&
+		if isReferencedIndex &&
 			// If we haven't found a replacement candidate for this foreign key, then
 			// we need a cascade to delete this index.
 			!indexHasReplacementCandidate(canReplace) {
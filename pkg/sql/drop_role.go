@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -326,6 +327,23 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+
+		// Remove the session variable defaults configured for the role.
+		if params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.DatabaseRoleSettings) {
+			_, err = params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
+				params.ctx,
+				opName,
+				params.p.txn,
+				fmt.Sprintf(
+					`DELETE FROM %s WHERE role_name=$1`,
+					DatabaseRoleSettingsTableName,
+				),
+				normalizedUsername,
+			)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if numRoleMembershipsDeleted > 0 {
@@ -105,6 +105,22 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 
 	}
 
+	if n.DryRun {
+		toDel := make([]toDelete, 0, len(td))
+		for _, t := range td {
+			toDel = append(toDel, t)
+		}
+		allObjectsToDelete, _, err := p.accumulateAllObjectsToDelete(ctx, toDel)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := p.dropDryRunTableObjects(ctx, allObjectsToDelete)
+		if err != nil {
+			return nil, err
+		}
+		return p.newDropDryRunNode(ctx, objects)
+	}
+
 	if len(td) == 0 {
 		return newZeroNode(nil /* columns */), nil
 	}
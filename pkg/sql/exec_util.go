@@ -59,6 +59,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -66,12 +67,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
@@ -714,7 +717,10 @@ type ExecutorConfig struct {
 	NodesStatusServer serverpb.OptionalNodesStatusServer
 	// SQLStatusServer gives access to a subset of the Status service and is
 	// available when not running as a system tenant.
-	SQLStatusServer   serverpb.SQLStatusServer
+	SQLStatusServer serverpb.SQLStatusServer
+	// TimeSeriesServer gives access to the internal time series database and
+	// is only available when running as a system tenant.
+	TimeSeriesServer  tspb.TimeSeriesServer
 	MetricsRecorder   nodeStatusGenerator
 	SessionRegistry   *SessionRegistry
 	SQLLivenessReader sqlliveness.Reader
@@ -725,6 +731,8 @@ type ExecutorConfig struct {
 	StatsRefresher    *stats.Refresher
 	InternalExecutor  *InternalExecutor
 	QueryCache        *querycache.C
+	ResultCache       *resultcache.C
+	TableCountCache   *TableCountCache
 
 	SchemaChangerMetrics *SchemaChangerMetrics
 	FeatureFlagMetrics   *featureflag.DenialMetrics
@@ -766,6 +774,15 @@ type ExecutorConfig struct {
 	// version` but before executing it. It can carry out arbitrary migrations
 	// that allow us to eventually remove legacy code.
 	VersionUpgradeHook func(ctx context.Context, from, to clusterversion.ClusterVersion) error
+
+	// GetMemoryInfo returns the total memory of the system and the memory
+	// limit of the cgroup of the process, or zero if there is none. The
+	// percentage-based memory flags are computed against the latter if set.
+	GetMemoryInfo func() (systemTotal, cgroupLimit int64, _ error)
+
+	// RootMemoryMonitor is the root of the SQL memory monitors of this
+	// server, from which the session and bulk operation monitors draw.
+	RootMemoryMonitor *mon.BytesMonitor
 }
 
 // Organization returns the value of cluster.organization.
@@ -1251,6 +1268,11 @@ type queryMeta struct {
 	// Cancellation function for the context associated with this query's transaction.
 	ctxCancel context.CancelFunc
 
+	// timeoutErr is set when the query is canceled because it exceeded
+	// statement_timeout or transaction_timeout. It tells these cancellations
+	// apart from the ones requested by clients.
+	timeoutErr error
+
 	// If set, this query will not be reported as part of SHOW QUERIES. This is
 	// set based on the statement implementing tree.HiddenFromShowQueries.
 	hidden bool
@@ -1286,6 +1308,13 @@ type SessionArgs struct {
 	// client.
 	RemoteAddr            net.Addr
 	ConnResultsBufferSize int64
+	// ConnImplicitTxnResultsBufferSize is the size up to which the results of
+	// statements executed in implicit transactions are buffered, if larger than
+	// ConnResultsBufferSize. See RestrictedCommandResult.ExtendBuffering.
+	ConnImplicitTxnResultsBufferSize int64
+	// ClusterName is the cluster name specified by the client through the
+	// options connection parameter, if any.
+	ClusterName string
 }
 
 // SessionRegistry stores a set of all sessions on this node.
@@ -1320,6 +1349,8 @@ type registrySession interface {
 	// serialize serializes a Session into a serverpb.Session
 	// that can be served over RPC.
 	serialize() serverpb.Session
+	// memoryMonitor returns the root memory monitor of the session.
+	memoryMonitor() *mon.BytesMonitor
 }
 
 // CancelQuery looks up the associated query in the session registry and cancels
@@ -1367,6 +1398,19 @@ func (r *SessionRegistry) CancelSession(
 	}, nil
 }
 
+// sessionsByMemoryMonitor returns the IDs of the sessions in the registry,
+// keyed by the ID of their root memory monitor.
+func (r *SessionRegistry) sessionsByMemoryMonitor() map[int64]ClusterWideID {
+	r.Lock()
+	defer r.Unlock()
+
+	res := make(map[int64]ClusterWideID, len(r.sessions))
+	for id, s := range r.sessions {
+		res[s.memoryMonitor().ID()] = id
+	}
+	return res
+}
+
 // SerializeAll returns a slice of all sessions in the registry, converted to serverpb.Sessions.
 func (r *SessionRegistry) SerializeAll() []serverpb.Session {
 	r.Lock()
@@ -2160,10 +2204,26 @@ func (m *sessionDataMutator) SetStmtTimeout(timeout time.Duration) {
 	m.data.StmtTimeout = timeout
 }
 
+func (m *sessionDataMutator) SetTransactionTimeout(timeout time.Duration) {
+	m.data.TransactionTimeout = timeout
+}
+
 func (m *sessionDataMutator) SetIdleInSessionTimeout(timeout time.Duration) {
 	m.data.IdleInSessionTimeout = timeout
 }
 
+func (m *sessionDataMutator) SetMaxQueryMemory(val int64) {
+	m.data.MaxQueryMemory = val
+}
+
+func (m *sessionDataMutator) SetJoinReaderMinBatchSize(val int64) {
+	m.data.JoinReaderMinBatchSize = val
+}
+
+func (m *sessionDataMutator) SetJoinReaderMaxBatchSize(val int64) {
+	m.data.JoinReaderMaxBatchSize = val
+}
+
 func (m *sessionDataMutator) SetIdleInTransactionSessionTimeout(timeout time.Duration) {
 	m.data.IdleInTransactionSessionTimeout = timeout
 }
@@ -2188,6 +2248,10 @@ func (m *sessionDataMutator) SetDisallowFullTableScans(val bool) {
 	m.data.DisallowFullTableScans = val
 }
 
+func (m *sessionDataMutator) SetResultCacheEnabled(val bool) {
+	m.data.ResultCacheEnabled = val
+}
+
 func (m *sessionDataMutator) SetAlterColumnTypeGeneral(val bool) {
 	m.data.AlterColumnTypeGeneralEnabled = val
 }
@@ -2258,13 +2322,14 @@ func (s *sqlStatsCollector) recordStatement(
 	automaticRetryCount int,
 	numRows int,
 	err error,
+	timedOut, canceled bool,
 	parseLat, planLat, runLat, svcLat, ovhLat float64,
 	stats topLevelQueryStats,
 ) roachpb.StmtID {
 	return s.appStats.recordStatement(
 		stmt, samplePlanDescription, distSQLUsed, vectorized, implicitTxn,
-		automaticRetryCount, numRows, err, parseLat, planLat, runLat, svcLat,
-		ovhLat, stats,
+		automaticRetryCount, numRows, err, timedOut, canceled, parseLat, planLat,
+		runLat, svcLat, ovhLat, stats,
 	)
 }
 
@@ -13,6 +13,9 @@
 package execinfra
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -62,6 +65,14 @@ type FlowCtx struct {
 	// Local is true if this flow is being run as part of a local-only query.
 	Local bool
 
+	// Deadline, if set, is the time at which the statement the flow belongs to
+	// times out. The context of the flow is canceled at that time.
+	Deadline time.Time
+
+	// timedOut is set, atomically, once the flow is canceled because Deadline
+	// passed. See MarkTimedOut and TimedOut.
+	timedOut int32
+
 	// TypeResolverFactory is used to construct transaction bound TypeResolvers
 	// to resolve type references during flow setup. It is not safe for concurrent
 	// use and is intended to be used only during flow setup and initialization.
@@ -109,3 +120,14 @@ func (ctx *FlowCtx) ProcessorComponentID(procID int32) execinfrapb.ComponentID {
 func (ctx *FlowCtx) StreamComponentID(streamID execinfrapb.StreamID) execinfrapb.ComponentID {
 	return execinfrapb.StreamComponentID(ctx.ID, streamID)
 }
+
+// MarkTimedOut records that the flow is being canceled because Deadline
+// passed, as opposed to because of its gateway or of an error.
+func (ctx *FlowCtx) MarkTimedOut() {
+	atomic.StoreInt32(&ctx.timedOut, 1)
+}
+
+// TimedOut returns whether the flow was canceled because Deadline passed.
+func (ctx *FlowCtx) TimedOut() bool {
+	return atomic.LoadInt32(&ctx.timedOut) == 1
+}
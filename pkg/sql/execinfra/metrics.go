@@ -25,6 +25,7 @@ type DistSQLMetrics struct {
 	FlowsActive           *metric.Gauge
 	FlowsTotal            *metric.Counter
 	FlowsQueued           *metric.Gauge
+	FlowsRejected         *metric.Counter
 	QueueWaitHist         *metric.Histogram
 	MaxBytesHist          *metric.Histogram
 	CurBytesCount         *metric.Gauge
@@ -75,6 +76,12 @@ var (
 		Measurement: "Flows",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaFlowsRejected = metric.Metadata{
+		Name:        "sql.distsql.flows.rejected",
+		Help:        "Number of distributed SQL flows rejected because the queue was full",
+		Measurement: "Flows",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaQueueWaitHist = metric.Metadata{
 		Name:        "sql.distsql.flows.queue_wait",
 		Help:        "Duration of time flows spend waiting in the queue",
@@ -126,6 +133,7 @@ func MakeDistSQLMetrics(histogramWindow time.Duration) DistSQLMetrics {
 		FlowsActive:           metric.NewGauge(metaFlowsActive),
 		FlowsTotal:            metric.NewCounter(metaFlowsTotal),
 		FlowsQueued:           metric.NewGauge(metaFlowsQueued),
+		FlowsRejected:         metric.NewCounter(metaFlowsRejected),
 		QueueWaitHist:         metric.NewLatency(metaQueueWaitHist, histogramWindow),
 		MaxBytesHist:          metric.NewHistogram(metaMemMaxBytes, histogramWindow, log10int64times1000, 3),
 		CurBytesCount:         metric.NewGauge(metaMemCurBytes),
@@ -233,6 +233,12 @@ func (s *ComponentStats) Union(other *ComponentStats) *ComponentStats {
 	if !result.KV.BytesRead.HasValue() {
 		result.KV.BytesRead = other.KV.BytesRead
 	}
+	if !result.KV.LookupBatches.HasValue() {
+		result.KV.LookupBatches = other.KV.LookupBatches
+	}
+	if !result.KV.LookupBatchBytes.HasValue() {
+		result.KV.LookupBatchBytes = other.KV.LookupBatchBytes
+	}
 
 	// Exec stats.
 	if !result.Exec.ExecTime.HasValue() {
@@ -317,6 +323,8 @@ func (s *ComponentStats) MakeDeterministic() {
 		// BytesRead is overridden to a useful value for tests.
 		s.KV.BytesRead.Set(8 * s.KV.TuplesRead.Value())
 	}
+	// LookupBatchBytes depends on the encoding of the input rows.
+	resetUint(&s.KV.LookupBatchBytes)
 
 	// Exec.
 	timeVal(&s.Exec.ExecTime)
@@ -556,7 +556,8 @@ type diagramEdge struct {
 	DestInput    int      `json:"destInput"`
 	Stats        []string `json:"stats,omitempty"`
 
-	streamID StreamID
+	streamID   StreamID
+	streamType StreamEndpointSpec_Type
 }
 
 // FlowDiagram is a plan diagram that can be made into a URL.
@@ -565,6 +566,16 @@ type FlowDiagram interface {
 	// diagram.
 	ToURL() (string, url.URL, error)
 
+	// ToDOT generates a representation of the flow diagram as a single graph in
+	// the DOT language of Graphviz.
+	ToDOT() string
+
+	// ToJSON generates a self-contained JSON description of the flow diagram:
+	// the processors and the nodes they are placed on, and the streams between
+	// them. Unlike the data encoded in the URL, it identifies processors and
+	// streams by their IDs and doesn't need the diagram viewer to be read.
+	ToJSON() (string, error)
+
 	// AddSpans adds stats extracted from the input spans to the diagram.
 	AddSpans([]tracingpb.RecordedSpan)
 }
@@ -575,8 +586,9 @@ type diagramData struct {
 	Processors []diagramProcessor `json:"processors"`
 	Edges      []diagramEdge      `json:"edges"`
 
-	flags  DiagramFlags
-	flowID FlowID
+	flags   DiagramFlags
+	flowID  FlowID
+	nodeIDs []roachpb.NodeID
 }
 
 var _ FlowDiagram = &diagramData{}
@@ -590,6 +602,156 @@ func (d diagramData) ToURL() (string, url.URL, error) {
 	return encodeJSONToURL(buf)
 }
 
+// dotEscaper escapes strings used as quoted identifiers in the DOT language.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ToDOT implements the FlowDiagram interface. Every processor is a node of the
+// graph, grouped in a cluster per SQL node, and every stream is an edge.
+func (d diagramData) ToDOT() string {
+	var buf bytes.Buffer
+	buf.WriteString("digraph distsql {\n")
+	buf.WriteString("  node [shape=box];\n")
+	if d.SQL != "" {
+		fmt.Fprintf(&buf, "  label=\"%s\";\n", dotEscaper.Replace(d.SQL))
+		buf.WriteString("  labelloc=t;\n")
+	}
+	cellStr := func(c diagramCell) string {
+		return strings.Join(append([]string{c.Title}, c.Details...), " ")
+	}
+	for n, nodeName := range d.NodeNames {
+		fmt.Fprintf(&buf, "  subgraph cluster_%d {\n", n)
+		fmt.Fprintf(&buf, "    label=\"Node %s\";\n", dotEscaper.Replace(nodeName))
+		for i := range d.Processors {
+			p := &d.Processors[i]
+			if p.NodeIdx != n {
+				continue
+			}
+			var lines []string
+			for j := range p.Inputs {
+				lines = append(lines, fmt.Sprintf("input %d: %s", j+1, cellStr(p.Inputs[j])))
+			}
+			lines = append(lines, p.Core.Title)
+			lines = append(lines, p.Core.Details...)
+			for j := range p.Outputs {
+				lines = append(lines, fmt.Sprintf("output %d: %s", j+1, cellStr(p.Outputs[j])))
+			}
+			fmt.Fprintf(&buf, "    p%d [label=\"%s\"];\n", i, dotEscaper.Replace(strings.Join(lines, "\n")))
+		}
+		buf.WriteString("  }\n")
+	}
+	for _, e := range d.Edges {
+		var attrs []string
+		if e.SourceOutput > 0 {
+			attrs = append(attrs, fmt.Sprintf("taillabel=\"%d\"", e.SourceOutput))
+		}
+		if e.DestInput > 0 {
+			attrs = append(attrs, fmt.Sprintf("headlabel=\"%d\"", e.DestInput))
+		}
+		if len(e.Stats) > 0 {
+			attrs = append(attrs, fmt.Sprintf("label=\"%s\"", dotEscaper.Replace(strings.Join(e.Stats, "\n"))))
+		}
+		fmt.Fprintf(&buf, "  p%d -> p%d", e.SourceProc, e.DestProc)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&buf, " [%s]", strings.Join(attrs, ", "))
+		}
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// inlineDiagram is the JSON document produced by ToJSON.
+type inlineDiagram struct {
+	SQL        string            `json:"sql"`
+	Nodes      []inlineNode      `json:"nodes"`
+	Processors []inlineProcessor `json:"processors"`
+	Streams    []inlineStream    `json:"streams"`
+}
+
+type inlineNode struct {
+	NodeID roachpb.NodeID `json:"nodeID"`
+	// Processors contains the IDs of the processors placed on the node.
+	Processors []int32 `json:"processors"`
+}
+
+type inlineProcessor struct {
+	ProcessorID int32          `json:"processorID"`
+	NodeID      roachpb.NodeID `json:"nodeID"`
+	StageID     int32          `json:"stage"`
+	Inputs      []diagramCell  `json:"inputs"`
+	Core        diagramCell    `json:"core"`
+	Outputs     []diagramCell  `json:"outputs"`
+}
+
+type inlineStream struct {
+	// Type is one of "local", "remote" and "sync_response". Streams of the
+	// latter type carry the results back to the client; they have neither a
+	// stream ID nor a destination processor.
+	Type            string    `json:"type"`
+	StreamID        *StreamID `json:"streamID,omitempty"`
+	SourceProcessor int32     `json:"sourceProcessor"`
+	SourceOutput    int       `json:"sourceOutput"`
+	DestProcessor   *int32    `json:"destProcessor,omitempty"`
+	DestInput       int       `json:"destInput"`
+	Stats           []string  `json:"stats,omitempty"`
+}
+
+// ToJSON implements the FlowDiagram interface.
+func (d diagramData) ToJSON() (string, error) {
+	doc := inlineDiagram{
+		SQL:        d.SQL,
+		Nodes:      make([]inlineNode, len(d.nodeIDs)),
+		Processors: []inlineProcessor{},
+		Streams:    make([]inlineStream, 0, len(d.Edges)),
+	}
+	for n, nodeID := range d.nodeIDs {
+		doc.Nodes[n] = inlineNode{NodeID: nodeID, Processors: []int32{}}
+	}
+	for i := range d.Processors {
+		p := &d.Processors[i]
+		if p.processorID < 0 {
+			// Skip the Response pseudo-processor; the streams leading to it are
+			// described as sync_response streams.
+			continue
+		}
+		doc.Nodes[p.NodeIdx].Processors = append(doc.Nodes[p.NodeIdx].Processors, p.processorID)
+		doc.Processors = append(doc.Processors, inlineProcessor{
+			ProcessorID: p.processorID,
+			NodeID:      d.nodeIDs[p.NodeIdx],
+			StageID:     p.StageID,
+			Inputs:      p.Inputs,
+			Core:        p.Core,
+			Outputs:     p.Outputs,
+		})
+	}
+	for _, e := range d.Edges {
+		stream := inlineStream{
+			SourceProcessor: d.Processors[e.SourceProc].processorID,
+			SourceOutput:    e.SourceOutput,
+			DestInput:       e.DestInput,
+			Stats:           e.Stats,
+		}
+		if e.streamType == StreamEndpointSpec_SYNC_RESPONSE {
+			stream.Type = "sync_response"
+		} else {
+			if e.streamType == StreamEndpointSpec_REMOTE {
+				stream.Type = "remote"
+			} else {
+				stream.Type = "local"
+			}
+			streamID := e.streamID
+			destProcessor := d.Processors[e.DestProc].processorID
+			stream.StreamID, stream.DestProcessor = &streamID, &destProcessor
+		}
+		doc.Streams = append(doc.Streams, stream)
+	}
+	res, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
 // AddSpans implements the FlowDiagram interface.
 func (d *diagramData) AddSpans(spans []tracingpb.RecordedSpan) {
 	statsMap := ExtractStatsFromSpans(spans, d.flags.MakeDeterministic)
@@ -609,12 +771,16 @@ func (d *diagramData) AddSpans(spans []tracingpb.RecordedSpan) {
 }
 
 func generateDiagramData(
-	sql string, flows []FlowSpec, nodeNames []string, flags DiagramFlags,
+	sql string, flows []FlowSpec, nodeIDs []roachpb.NodeID, flags DiagramFlags,
 ) (FlowDiagram, error) {
 	d := &diagramData{
 		SQL:       sql,
-		NodeNames: nodeNames,
+		NodeNames: make([]string, len(nodeIDs)),
 		flags:     flags,
+		nodeIDs:   nodeIDs,
+	}
+	for i, n := range nodeIDs {
+		d.NodeNames[i] = n.String()
 	}
 	if len(flows) > 0 {
 		d.flowID = flows[0].FlowID
@@ -717,6 +883,7 @@ func generateDiagramData(
 						SourceProc:   pIdx,
 						SourceOutput: srcOutput,
 						streamID:     o.StreamID,
+						streamType:   o.Type,
 					}
 					if o.Type == StreamEndpointSpec_SYNC_RESPONSE {
 						edge.DestProc = len(d.Processors) - 1
@@ -753,14 +920,14 @@ func GeneratePlanDiagram(
 	sort.Ints(nodeIDs)
 
 	flowSlice := make([]FlowSpec, len(nodeIDs))
-	nodeNames := make([]string, len(nodeIDs))
+	flowNodeIDs := make([]roachpb.NodeID, len(nodeIDs))
 	for i, nVal := range nodeIDs {
 		n := roachpb.NodeID(nVal)
 		flowSlice[i] = *flows[n]
-		nodeNames[i] = n.String()
+		flowNodeIDs[i] = n
 	}
 
-	return generateDiagramData(sql, flowSlice, nodeNames, flags)
+	return generateDiagramData(sql, flowSlice, flowNodeIDs, flags)
 }
 
 // GeneratePlanDiagramURL generates the json data for a flow diagram and a
@@ -65,7 +65,7 @@ func TestPlanDiagramIndexJoin(t *testing.T) {
 			Output: []OutputRouterSpec{{
 				Type: OutputRouterSpec_PASS_THROUGH,
 				Streams: []StreamEndpointSpec{
-					{StreamID: 0},
+					{Type: StreamEndpointSpec_REMOTE, StreamID: 0},
 				},
 			}},
 			StageID:     1,
@@ -83,7 +83,7 @@ func TestPlanDiagramIndexJoin(t *testing.T) {
 			Output: []OutputRouterSpec{{
 				Type: OutputRouterSpec_PASS_THROUGH,
 				Streams: []StreamEndpointSpec{
-					{StreamID: 1},
+					{Type: StreamEndpointSpec_REMOTE, StreamID: 1},
 				},
 			}},
 			StageID:     1,
@@ -138,7 +138,11 @@ func TestPlanDiagramIndexJoin(t *testing.T) {
 	flags := DiagramFlags{
 		ShowInputTypes: true,
 	}
-	json, url, err := GeneratePlanDiagramURL("SOME SQL HERE", flows, flags)
+	diagram, err := GeneratePlanDiagram("SOME SQL HERE", flows, flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json, url, err := diagram.ToURL()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -169,6 +173,55 @@ func TestPlanDiagramIndexJoin(t *testing.T) {
 	if url.String() != expectedURL {
 		t.Errorf("expected `%s` got `%s`", expectedURL, url.String())
 	}
+
+	expectedDOT := `digraph distsql {
+  node [shape=box];
+  label="SOME SQL HERE";
+  labelloc=t;
+  subgraph cluster_0 {
+    label="Node 1";
+    p0 [label="TableReader/0\nTable@SomeIndex\nOut: @1,@2"];
+  }
+  subgraph cluster_1 {
+    label="Node 2";
+    p1 [label="TableReader/1\nTable@SomeIndex\nOut: @1,@2"];
+  }
+  subgraph cluster_2 {
+    label="Node 3";
+    p2 [label="TableReader/2\nTable@SomeIndex\nOut: @1,@2"];
+    p3 [label="input 1: ordered @2+\nJoinReader/3\nTable@primary\nOut: @3"];
+    p4 [label="Response"];
+  }
+  p0 -> p3 [headlabel="1"];
+  p1 -> p3 [headlabel="1"];
+  p2 -> p3 [headlabel="1"];
+  p3 -> p4;
+}`
+	if dot := diagram.ToDOT(); dot != expectedDOT {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedDOT, dot)
+	}
+
+	inlineJSON, err := diagram.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedInline := `{"sql":"SOME SQL HERE",` +
+		`"nodes":[{"nodeID":1,"processors":[0]},{"nodeID":2,"processors":[1]},{"nodeID":3,"processors":[2,3]}],` +
+		`"processors":[` +
+		`{"processorID":0,"nodeID":1,"stage":1,"inputs":[],"core":{"title":"TableReader/0","details":["Table@SomeIndex","Out: @1,@2"]},"outputs":[]},` +
+		`{"processorID":1,"nodeID":2,"stage":1,"inputs":[],"core":{"title":"TableReader/1","details":["Table@SomeIndex","Out: @1,@2"]},"outputs":[]},` +
+		`{"processorID":2,"nodeID":3,"stage":1,"inputs":[],"core":{"title":"TableReader/2","details":["Table@SomeIndex","Out: @1,@2"]},"outputs":[]},` +
+		`{"processorID":3,"nodeID":3,"stage":2,"inputs":[{"title":"ordered","details":["@2+"]}],"core":{"title":"JoinReader/3","details":["Table@primary","Out: @3"]},"outputs":[]}` +
+		`],` +
+		`"streams":[` +
+		`{"type":"remote","streamID":0,"sourceProcessor":0,"sourceOutput":0,"destProcessor":3,"destInput":1},` +
+		`{"type":"remote","streamID":1,"sourceProcessor":1,"sourceOutput":0,"destProcessor":3,"destInput":1},` +
+		`{"type":"local","streamID":2,"sourceProcessor":2,"sourceOutput":0,"destProcessor":3,"destInput":1},` +
+		`{"type":"sync_response","sourceProcessor":3,"sourceOutput":0,"destInput":0}` +
+		`]}`
+	if inlineJSON != expectedInline {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedInline, inlineJSON)
+	}
 }
 
 func TestPlanDiagramJoin(t *testing.T) {
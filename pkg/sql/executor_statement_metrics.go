@@ -195,11 +195,19 @@ func (ex *connExecutor) recordStatementSummary(
 		m.SQLServiceLatency.RecordValue(svcLatRaw.Nanoseconds())
 	}
 
+	// Tell apart the statements that timed out from the ones canceled by a
+	// client; both fail with their context canceled.
+	var timedOut, canceled bool
+	if err != nil && ctx.Err() != nil {
+		timedOut = ex.queryTimedOut(stmt.QueryID)
+		canceled = !timedOut
+	}
+
 	stmtID := ex.statsCollector.recordStatement(
 		stmt, planner.instrumentation.PlanForStats(ctx),
 		flags.IsDistributed(), flags.IsSet(planFlagVectorized),
 		flags.IsSet(planFlagImplicitTxn), automaticRetryCount, rowsAffected, err,
-		parseLat, planLat, runLat, svcLat, execOverhead, stats,
+		timedOut, canceled, parseLat, planLat, runLat, svcLat, execOverhead, stats,
 	)
 
 	// Do some transaction level accounting for the transaction this statement is
@@ -0,0 +1,498 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachange"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
+)
+
+// explainDDLTable describes the work the schema changer performs on a table
+// to execute a schema change statement.
+type explainDDLTable struct {
+	table catalog.TableDescriptor
+
+	// changes are the descriptor changes that are made in the statement's
+	// transaction and take effect once the new descriptor version is leased.
+	changes []string
+	// added and dropped are the columns, indexes and constraints that go
+	// through the DELETE_ONLY and DELETE_AND_WRITE_ONLY states before being
+	// made public or removed.
+	added, dropped []string
+	// columnBackfill are the column additions and removals that are
+	// performed by rewriting the primary index.
+	columnBackfill []string
+	// indexBackfills are the indexes built from the primary index.
+	indexBackfills []string
+	// validations are the checks run against the existing data.
+	validations []string
+	// gc are the indexes whose data is deleted once the GC TTL has elapsed.
+	gc []string
+}
+
+// ExplainDDL plans an EXPLAIN (DDL) statement, which lists the stages,
+// backfills and validations the schema changer executes for a schema change
+// statement, along with an estimate of the amount of data it processes. The
+// schema change itself is not executed.
+// Privileges: same as the explained statement for the resolved objects.
+func (p *planner) ExplainDDL(ctx context.Context, n *tree.Explain) (planNode, error) {
+	if n.Statement.StatementType() != tree.DDL {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"EXPLAIN (DDL) can only be used with schema change statements, not %s",
+			n.Statement.StatementTag())
+	}
+	// Format the statement before the names it contains are qualified by
+	// name resolution.
+	stmt := tree.AsString(n.Statement)
+
+	var tables []*explainDDLTable
+	switch t := n.Statement.(type) {
+	case *tree.AlterTable:
+		table, err := p.ResolveUncachedTableDescriptorEx(
+			ctx, t.Table, true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.CheckPrivilege(ctx, table, privilege.CREATE); err != nil {
+			return nil, err
+		}
+		e := &explainDDLTable{table: table}
+		if err := p.explainAlterTable(ctx, e, t); err != nil {
+			return nil, err
+		}
+		tables = append(tables, e)
+
+	case *tree.CreateIndex:
+		table, err := p.ResolveUncachedTableDescriptor(
+			ctx, &t.Table, true /* required */, tree.ResolveRequireTableOrViewDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.CheckPrivilege(ctx, table, privilege.CREATE); err != nil {
+			return nil, err
+		}
+		e := &explainDDLTable{table: table}
+		e.addIndex(explainDDLIndexName(table, t.Name, t.Columns, t.Unique), t.Unique)
+		tables = append(tables, e)
+
+	case *tree.DropIndex:
+		byID := make(map[descpb.ID]*explainDDLTable)
+		for _, index := range t.IndexList {
+			table, idx, err := p.getTableAndIndex(ctx, index, privilege.CREATE)
+			if err != nil {
+				return nil, err
+			}
+			e, ok := byID[table.GetID()]
+			if !ok {
+				e = &explainDDLTable{table: table}
+				byID[table.GetID()] = e
+				tables = append(tables, e)
+			}
+			e.dropIndex(idx)
+		}
+	}
+
+	tp := treeprinter.NewWithStyle(treeprinter.BulletStyle)
+	root := tp.Child("schema change")
+	root.AddLine(fmt.Sprintf("statement: %s", stmt))
+	if len(tables) == 0 {
+		root.AddLine("stages are only detailed for ALTER TABLE, CREATE INDEX and DROP INDEX")
+	}
+	for _, e := range tables {
+		if err := p.explainDDLFormatTable(ctx, root, e); err != nil {
+			return nil, err
+		}
+	}
+
+	rows := tp.FormattedRows()
+	v := p.newContainerValuesNode(colinfo.ExplainPlanColumns, len(rows))
+	for _, row := range rows {
+		if _, err := v.rows.AddRow(ctx, tree.Datums{tree.NewDString(row)}); err != nil {
+			v.Close(ctx)
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// explainAlterTable records the work performed by each of the commands of an
+// ALTER TABLE statement.
+func (p *planner) explainAlterTable(
+	ctx context.Context, e *explainDDLTable, n *tree.AlterTable,
+) error {
+	for _, cmd := range n.Cmds {
+		switch t := cmd.(type) {
+		case *tree.AlterTableAddColumn:
+			d := t.ColumnDef
+			col := fmt.Sprintf("column %s", &d.Name)
+			e.added = append(e.added, col)
+			hasNullDefault := d.HasDefaultExpr() && d.DefaultExpr.Expr == tree.DNull
+			if (d.HasDefaultExpr() && !hasNullDefault) || d.Nullable.Nullability == tree.NotNull ||
+				(d.IsComputed() && !d.IsVirtual()) {
+				e.columnBackfill = append(e.columnBackfill, "add "+col)
+			}
+			if d.Unique.IsUnique && !d.Unique.WithoutIndex {
+				elems := tree.IndexElemList{{Column: d.Name}}
+				e.addIndex(explainDDLIndexName(e.table, d.Unique.ConstraintName, elems, true /* unique */), true /* unique */)
+			} else if d.Unique.IsUnique {
+				e.addConstraint("unique constraint", d.Unique.ConstraintName, col)
+			}
+			for _, check := range d.CheckExprs {
+				e.addConstraint("check constraint", check.ConstraintName, col)
+			}
+			if d.HasFKConstraint() {
+				e.addConstraint("foreign key constraint", d.References.ConstraintName, col)
+			}
+
+		case *tree.AlterTableAddConstraint:
+			validate := t.ValidationBehavior == tree.ValidationDefault
+			switch c := t.ConstraintDef.(type) {
+			case *tree.UniqueConstraintTableDef:
+				switch {
+				case c.PrimaryKey:
+					e.alterPrimaryKey(c.Name)
+				case c.WithoutIndex:
+					e.addConstraint("unique constraint", c.Name, "")
+				default:
+					e.addIndex(explainDDLIndexName(e.table, c.Name, c.Columns, true /* unique */), true /* unique */)
+				}
+			case *tree.CheckConstraintTableDef:
+				if validate {
+					e.addConstraint("check constraint", c.Name, "")
+				} else {
+					e.changes = append(e.changes, fmt.Sprintf("add unvalidated check constraint %s", &c.Name))
+				}
+			case *tree.ForeignKeyConstraintTableDef:
+				if validate {
+					e.addConstraint("foreign key constraint", c.Name, "")
+				} else {
+					e.changes = append(e.changes, fmt.Sprintf("add unvalidated foreign key constraint %s", &c.Name))
+				}
+			}
+
+		case *tree.AlterTableDropColumn:
+			col, _, err := e.table.FindColumnByName(t.Column)
+			if err != nil {
+				if t.IfExists {
+					continue
+				}
+				return err
+			}
+			e.dropped = append(e.dropped, fmt.Sprintf("column %s", &t.Column))
+			e.columnBackfill = append(e.columnBackfill, fmt.Sprintf("drop column %s", &t.Column))
+			indexes := e.table.GetPublicNonPrimaryIndexes()
+			for i := range indexes {
+				idx := &indexes[i]
+				if idx.ContainsColumnID(col.ID) {
+					e.dropIndex(idx)
+				}
+			}
+
+		case *tree.AlterTableDropConstraint:
+			e.dropped = append(e.dropped, fmt.Sprintf("constraint %s", &t.Constraint))
+
+		case *tree.AlterTableValidateConstraint:
+			e.validations = append(e.validations, fmt.Sprintf("constraint %s", &t.Constraint))
+
+		case *tree.AlterTableSetNotNull:
+			e.addConstraint("NOT NULL constraint", "", fmt.Sprintf("column %s", &t.Column))
+
+		case *tree.AlterTableAlterColumnType:
+			if err := p.explainAlterColumnType(ctx, e, t); err != nil {
+				return err
+			}
+
+		case *tree.AlterTableAlterPrimaryKey:
+			e.alterPrimaryKey(t.Name)
+
+		default:
+			e.changes = append(e.changes, strings.TrimSpace(tree.AsString(cmd)))
+		}
+	}
+	return nil
+}
+
+// explainAlterColumnType records the work performed by an ALTER COLUMN TYPE
+// command, which depends on whether the existing data has to be rewritten.
+func (p *planner) explainAlterColumnType(
+	ctx context.Context, e *explainDDLTable, t *tree.AlterTableAlterColumnType,
+) error {
+	col, _, err := e.table.FindColumnByName(t.Column)
+	if err != nil {
+		return err
+	}
+	typ, err := tree.ResolveType(ctx, t.ToType, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return err
+	}
+	kind := schemachange.ColumnConversionGeneral
+	if t.Using == nil {
+		kind, err = schemachange.ClassifyConversion(ctx, col.Type, typ)
+		if err != nil {
+			return err
+		}
+	}
+	colName := fmt.Sprintf("column %s", &t.Column)
+	switch kind {
+	case schemachange.ColumnConversionDangerous, schemachange.ColumnConversionImpossible:
+		return pgerror.Newf(pgcode.CannotCoerce,
+			"the requested type conversion (%s -> %s) requires an explicit USING expression",
+			col.Type.SQLString(), typ.SQLString())
+	case schemachange.ColumnConversionTrivial:
+		e.changes = append(e.changes, fmt.Sprintf("change type of %s to %s", colName, typ.SQLString()))
+	default:
+		// The new values are computed into a new column, which replaces the
+		// original column along with the indexes containing it. Conversions
+		// that only need to validate the existing values are performed the
+		// same way.
+		newCol := fmt.Sprintf("%s (%s)", colName, typ.SQLString())
+		e.added = append(e.added, newCol)
+		e.dropped = append(e.dropped, fmt.Sprintf("%s (%s)", colName, col.Type.SQLString()))
+		e.columnBackfill = append(e.columnBackfill, "add "+newCol)
+		indexes := e.table.GetPublicNonPrimaryIndexes()
+		for i := range indexes {
+			idx := &indexes[i]
+			if idx.ContainsColumnID(col.ID) {
+				e.addIndex(fmt.Sprintf("%s (rebuilt)", tree.NameString(idx.Name)), idx.Unique)
+				e.dropIndex(idx)
+			}
+		}
+	}
+	return nil
+}
+
+// addIndex records the addition of an index, which is backfilled from the
+// primary index.
+func (e *explainDDLTable) addIndex(name string, unique bool) {
+	index := fmt.Sprintf("index %s", name)
+	e.added = append(e.added, index)
+	e.indexBackfills = append(e.indexBackfills, index)
+	if unique {
+		e.validations = append(e.validations, fmt.Sprintf("uniqueness of %s", index))
+	}
+	e.validations = append(e.validations, fmt.Sprintf("row count of %s", index))
+}
+
+// dropIndex records the removal of an index. The data of interleaved indexes
+// is deleted by the schema change job, the data of other indexes is deleted
+// by the GC job.
+func (e *explainDDLTable) dropIndex(idx *descpb.IndexDescriptor) {
+	index := fmt.Sprintf("index %s", tree.NameString(idx.Name))
+	e.dropped = append(e.dropped, index)
+	if idx.IsInterleaved() {
+		e.indexBackfills = append(e.indexBackfills, fmt.Sprintf("delete entries of %s", index))
+	} else {
+		e.gc = append(e.gc, index)
+	}
+}
+
+// addConstraint records the addition of a constraint that is validated
+// against the existing rows.
+func (e *explainDDLTable) addConstraint(kind string, name tree.Name, on string) {
+	constraint := kind
+	if name != "" {
+		constraint = fmt.Sprintf("%s %s", kind, &name)
+	}
+	if on != "" {
+		constraint = fmt.Sprintf("%s on %s", constraint, on)
+	}
+	e.added = append(e.added, constraint)
+	e.validations = append(e.validations, constraint)
+}
+
+// alterPrimaryKey records the replacement of the primary index, which
+// requires the secondary indexes to be rebuilt.
+func (e *explainDDLTable) alterPrimaryKey(name tree.Name) {
+	if name == "" {
+		name = tree.Name(e.table.GetPrimaryIndex().Name)
+	}
+	e.addIndex(fmt.Sprintf("%s (new primary key)", &name), true /* unique */)
+	indexes := e.table.GetPublicNonPrimaryIndexes()
+	for i := range indexes {
+		idx := &indexes[i]
+		e.addIndex(fmt.Sprintf("%s (rebuilt)", tree.NameString(idx.Name)), idx.Unique)
+		e.dropIndex(idx)
+	}
+	e.dropIndex(e.table.GetPrimaryIndex())
+}
+
+// explainDDLFormatTable adds the stages of the schema change of a table to
+// the EXPLAIN (DDL) output.
+func (p *planner) explainDDLFormatTable(
+	ctx context.Context, root treeprinter.Node, e *explainDDLTable,
+) error {
+	tn, err := p.getQualifiedTableName(ctx, e.table)
+	if err != nil {
+		return err
+	}
+	node := root.Childf("table %s", tn.FQString())
+	rows, err := p.explainDDLEstimatedRows(ctx, e.table)
+	if err != nil {
+		return err
+	}
+	node.AddLine(fmt.Sprintf("estimated rows: %s", rows))
+	size, err := p.explainDDLEstimatedSize(ctx, e.table)
+	if err != nil {
+		return err
+	}
+	node.AddLine(fmt.Sprintf("estimated size: %s", size))
+
+	stage := 0
+	addStage := func(name string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		stage++
+		s := node.Childf("stage %d: %s", stage, name)
+		for _, l := range lines {
+			s.AddLine(l)
+		}
+	}
+	withState := func(elems []string, state string) []string {
+		res := make([]string, len(elems))
+		for i, elem := range elems {
+			res[i] = fmt.Sprintf("%s: %s", elem, state)
+		}
+		return res
+	}
+
+	// The added elements start in the DELETE_ONLY state and the dropped
+	// elements move to the DELETE_AND_WRITE_ONLY state in the statement's
+	// transaction; the schema change job then moves them through the
+	// remaining states, one descriptor version at a time.
+	var txnLines []string
+	txnLines = append(txnLines, e.changes...)
+	txnLines = append(txnLines, withState(e.added, "DELETE_ONLY")...)
+	txnLines = append(txnLines, withState(e.dropped, "DELETE_AND_WRITE_ONLY")...)
+	addStage("statement transaction", txnLines)
+
+	var writeOnlyLines []string
+	writeOnlyLines = append(writeOnlyLines, withState(e.added, "DELETE_AND_WRITE_ONLY")...)
+	writeOnlyLines = append(writeOnlyLines, withState(e.dropped, "DELETE_ONLY")...)
+	addStage("schema change job: next descriptor version", writeOnlyLines)
+
+	if len(e.columnBackfill) > 0 {
+		addStage(
+			fmt.Sprintf("column backfill of primary index %s (rewrites every row)",
+				tree.NameString(e.table.GetPrimaryIndex().Name)),
+			e.columnBackfill,
+		)
+	}
+	addStage("index backfill (reads every row of the primary index)", e.indexBackfills)
+	addStage("validation (reads every row)", e.validations)
+
+	var publicLines []string
+	publicLines = append(publicLines, withState(e.added, "PUBLIC")...)
+	publicLines = append(publicLines, withState(e.dropped, "ABSENT")...)
+	addStage("schema change job: final descriptor version", publicLines)
+
+	addStage("GC job (after the GC TTL)", e.gc)
+	if stage == 0 {
+		node.AddLine("no schema change stages")
+	}
+	return nil
+}
+
+// explainDDLEstimatedRows returns the row count of the given table from its
+// most recent statistics.
+func (p *planner) explainDDLEstimatedRows(
+	ctx context.Context, table catalog.TableDescriptor,
+) (string, error) {
+	stats, err := p.ExecCfg().TableStatsCache.GetTableStats(ctx, table.GetID())
+	if err != nil {
+		return "", err
+	}
+	if len(stats) == 0 {
+		return "unknown (no table statistics)", nil
+	}
+	return strconv.FormatUint(stats[0].RowCount, 10), nil
+}
+
+// explainDDLEstimatedSize returns the live bytes of the ranges of the given
+// table. For tables with more ranges than
+// sql.crdb_internal.table_mvcc_stats.max_sampled_ranges, the statistics of
+// evenly spaced ranges are read and extrapolated to the whole table.
+func (p *planner) explainDDLEstimatedSize(
+	ctx context.Context, table catalog.TableDescriptor,
+) (string, error) {
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return "unknown", nil
+	}
+	prefix := p.ExecCfg().Codec.TablePrefix(uint32(table.GetID()))
+	_, rangeStarts, err := getTableRangeStarts(ctx, p.txn, roachpb.Span{
+		Key: prefix, EndKey: prefix.PrefixEnd(),
+	})
+	if err != nil {
+		return "", err
+	}
+	starts := rangeStarts[table.GetID()]
+	if len(starts) == 0 {
+		return "unknown", nil
+	}
+	maxSampled := int(tableMVCCStatsMaxSampledRanges.Get(&p.ExecCfg().Settings.SV))
+	sampled := sampleRangeStarts(starts, maxSampled)
+	rangeStats, err := getRangeStats(ctx, p.txn, sampled)
+	if err != nil {
+		return "", err
+	}
+	var stats enginepb.MVCCStats
+	for _, rs := range rangeStats {
+		stats.Add(rs)
+	}
+	liveBytes := stats.LiveBytes * int64(len(starts)) / int64(len(sampled))
+	return fmt.Sprintf("%s in %d ranges", humanizeutil.IBytes(liveBytes), len(starts)), nil
+}
+
+// explainDDLIndexName returns the name of an index added by a schema change,
+// generating it the same way as the schema changer if it is not specified.
+func explainDDLIndexName(
+	table catalog.TableDescriptor, name tree.Name, columns tree.IndexElemList, unique bool,
+) string {
+	if name != "" {
+		return name.String()
+	}
+	segments := make([]string, 0, len(columns)+2)
+	segments = append(segments, table.GetName())
+	for i := range columns {
+		segments = append(segments, string(columns[i].Column))
+	}
+	if unique {
+		segments = append(segments, "key")
+	} else {
+		segments = append(segments, "idx")
+	}
+	baseName := strings.Join(segments, "_")
+	generated := baseName
+	for i := 1; ; i++ {
+		if _, _, err := table.FindIndexByName(generated); err != nil {
+			break
+		}
+		generated = fmt.Sprintf("%s%d", baseName, i)
+	}
+	return tree.NameString(generated)
+}
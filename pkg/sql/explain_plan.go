@@ -30,7 +30,8 @@ import (
 )
 
 // explainPlanNode implements EXPLAIN (PLAN) and EXPLAIN (DISTSQL); it produces
-// the output of EXPLAIN given an explain.Plan.
+// the output of EXPLAIN given an explain.Plan, in the format requested by the
+// FORMAT option.
 type explainPlanNode struct {
 	optColumnsSlot
 
@@ -65,6 +66,7 @@ func (e *explainPlanNode) startExec(params runParams) error {
 		planCtx.planner.curPlan.subqueryPlans = outerSubqueries
 	}()
 	physicalPlan, err := newPhysPlanForExplainPurposes(planCtx, distSQLPlanner, plan.main)
+	var diagram execinfrapb.FlowDiagram
 	var diagramURL url.URL
 	var diagramJSON string
 	if err != nil {
@@ -106,7 +108,7 @@ func (e *explainPlanNode) startExec(params runParams) error {
 			flags := execinfrapb.DiagramFlags{
 				ShowInputTypes: e.options.Flags[tree.ExplainFlagTypes],
 			}
-			diagram, err := execinfrapb.GeneratePlanDiagram(params.p.stmt.String(), flows, flags)
+			diagram, err = execinfrapb.GeneratePlanDiagram(params.p.stmt.String(), flows, flags)
 			if err != nil {
 				return err
 			}
@@ -119,16 +121,38 @@ func (e *explainPlanNode) startExec(params runParams) error {
 	}
 
 	var rows []string
-	if e.options.Flags[tree.ExplainFlagJSON] {
+	switch {
+	case e.options.Mode == tree.ExplainDistSQL && e.options.Flags[tree.ExplainFlagJSON]:
 		// For the JSON flag, we only want to emit the diagram JSON.
 		rows = []string{diagramJSON}
-	} else {
+	case e.options.Mode == tree.ExplainDistSQL && e.options.Format == tree.ExplainFormatJSON:
+		// Unlike the JSON flag, FORMAT JSON emits the diagram inline, without
+		// the layout data used by the diagram viewer.
+		inlineJSON, err := diagram.ToJSON()
+		if err != nil {
+			return err
+		}
+		rows = []string{inlineJSON}
+	case e.options.Mode == tree.ExplainDistSQL && e.options.Format == tree.ExplainFormatDOT:
+		rows = []string{diagram.ToDOT()}
+	default:
 		if err := emitExplain(ob, params.EvalContext(), params.p.ExecCfg().Codec, e.plan); err != nil {
 			return err
 		}
-		rows = ob.BuildStringRows()
-		if e.options.Mode == tree.ExplainDistSQL {
-			rows = append(rows, "", fmt.Sprintf("Diagram: %s", diagramURL.String()))
+		switch e.options.Format {
+		case tree.ExplainFormatJSON:
+			planJSON, err := ob.BuildJSON()
+			if err != nil {
+				return err
+			}
+			rows = []string{planJSON}
+		case tree.ExplainFormatDOT:
+			rows = []string{ob.BuildDOT()}
+		default:
+			rows = ob.BuildStringRows()
+			if e.options.Mode == tree.ExplainDistSQL {
+				rows = append(rows, "", fmt.Sprintf("Diagram: %s", diagramURL.String()))
+			}
 		}
 	}
 	v := params.p.newContainerValuesNode(colinfo.ExplainPlanColumns, 0)
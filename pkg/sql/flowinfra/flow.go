@@ -13,6 +13,7 @@ package flowinfra
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
@@ -20,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -110,6 +112,10 @@ type Flow interface {
 	// GetID returns the flow ID.
 	GetID() execinfrapb.FlowID
 
+	// GetSpec returns the spec that the flow was set up with. Can only be
+	// called after Setup().
+	GetSpec() *execinfrapb.FlowSpec
+
 	// Cleanup should be called when the flow completes (after all processors and
 	// mailboxes exited).
 	Cleanup(context.Context)
@@ -167,7 +173,8 @@ type FlowBase struct {
 	ctxCancel context.CancelFunc
 	ctxDone   <-chan struct{}
 
-	// spec is the request that produced this flow. Only used for debugging.
+	// spec is the request that produced this flow. Only used for debugging and
+	// introspection.
 	spec *execinfrapb.FlowSpec
 }
 
@@ -176,6 +183,23 @@ func (f *FlowBase) Setup(
 	ctx context.Context, spec *execinfrapb.FlowSpec, _ FuseOpt,
 ) (context.Context, error) {
 	ctx, f.ctxCancel = contextutil.WithCancel(ctx)
+	if !f.Deadline.IsZero() {
+		// Remote flows of a statement that times out are canceled here rather
+		// than waiting for the cancellation of the gateway flow to propagate (it
+		// might never reach flows that haven't connected their streams yet).
+		// The timeout is recorded before the flow is canceled, so that the
+		// errors caused by the cancellation can be told apart from the ones
+		// caused by the gateway canceling the flow.
+		cancel := f.ctxCancel
+		timer := time.AfterFunc(timeutil.Until(f.Deadline), func() {
+			f.MarkTimedOut()
+			cancel()
+		})
+		f.ctxCancel = func() {
+			timer.Stop()
+			cancel()
+		}
+	}
 	f.ctxDone = ctx.Done()
 	f.spec = spec
 	return ctx, nil
@@ -235,6 +259,11 @@ func (f *FlowBase) GetID() execinfrapb.FlowID {
 	return f.ID
 }
 
+// GetSpec is part of the Flow interface.
+func (f *FlowBase) GetSpec() *execinfrapb.FlowSpec {
+	return f.spec
+}
+
 // CheckInboundStreamID takes a stream ID and returns an error if an inbound
 // stream already exists with that ID in the inbound streams map, creating the
 // inbound streams map if it is nil.
@@ -16,9 +16,13 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -33,6 +37,15 @@ var settingMaxRunningFlows = settings.RegisterIntSetting(
 	500,
 ).WithPublic()
 
+var settingMaxQueuedFlows = settings.RegisterIntSetting(
+	"sql.distsql.max_queued_flows",
+	"maximum number of flows that can be queued on a node waiting for one of the "+
+		"running flows to finish; flows scheduled while the queue is full are rejected "+
+		"(0 means that the queue is unbounded)",
+	1000,
+	settings.NonNegativeInt,
+).WithPublic()
+
 // FlowScheduler manages running flows and decides when to queue and when to
 // start flows. The main interface it presents is ScheduleFlows, which passes a
 // flow to be run.
@@ -45,11 +58,15 @@ type FlowScheduler struct {
 	mu struct {
 		syncutil.Mutex
 		queue *list.List
+		// runningFlows keeps track of all flows that are currently running via
+		// this FlowScheduler, keyed by flow ID.
+		runningFlows map[execinfrapb.FlowID]FlowInfo
 	}
 
 	atomics struct {
 		numRunning      int32
 		maxRunningFlows int32
+		maxQueuedFlows  int32
 	}
 }
 
@@ -62,6 +79,30 @@ type flowWithCtx struct {
 	enqueueTime time.Time
 }
 
+// FlowInfo describes a flow that is running or queued on a FlowScheduler.
+type FlowInfo struct {
+	FlowID execinfrapb.FlowID
+	// Gateway is the node that planned the flow.
+	Gateway roachpb.NodeID
+	// StatementSQL is the anonymized statement that the flow belongs to. It is
+	// empty for flows that weren't planned on behalf of a SQL statement.
+	StatementSQL string
+	// Timestamp is the time at which the flow started running or, if the flow
+	// is queued, the time at which it was enqueued.
+	Timestamp time.Time
+	// Queued is set if the flow is waiting for its turn to run.
+	Queued bool
+}
+
+func makeFlowInfo(f Flow, timestamp time.Time, queued bool) FlowInfo {
+	info := FlowInfo{FlowID: f.GetID(), Timestamp: timestamp, Queued: queued}
+	if spec := f.GetSpec(); spec != nil {
+		info.Gateway = spec.Gateway
+		info.StatementSQL = spec.StatementSQL
+	}
+	return info
+}
+
 // NewFlowScheduler creates a new FlowScheduler.
 func NewFlowScheduler(
 	ambient log.AmbientContext,
@@ -76,10 +117,15 @@ func NewFlowScheduler(
 		metrics:        metrics,
 	}
 	fs.mu.queue = list.New()
+	fs.mu.runningFlows = make(map[execinfrapb.FlowID]FlowInfo)
 	fs.atomics.maxRunningFlows = int32(settingMaxRunningFlows.Get(&settings.SV))
 	settingMaxRunningFlows.SetOnChange(&settings.SV, func() {
 		atomic.StoreInt32(&fs.atomics.maxRunningFlows, int32(settingMaxRunningFlows.Get(&settings.SV)))
 	})
+	fs.atomics.maxQueuedFlows = int32(settingMaxQueuedFlows.Get(&settings.SV))
+	settingMaxQueuedFlows.SetOnChange(&settings.SV, func() {
+		atomic.StoreInt32(&fs.atomics.maxQueuedFlows, int32(settingMaxQueuedFlows.Get(&settings.SV)))
+	})
 	return fs
 }
 
@@ -100,13 +146,30 @@ func (fs *FlowScheduler) canRunFlow(_ Flow) bool {
 }
 
 // runFlowNow starts the given flow; does not wait for the flow to complete. The
-// caller is responsible for incrementing numRunning.
-func (fs *FlowScheduler) runFlowNow(ctx context.Context, f Flow) error {
+// caller is responsible for incrementing numRunning. locked indicates whether
+// fs.mu is already being held.
+func (fs *FlowScheduler) runFlowNow(ctx context.Context, f Flow, locked bool) error {
 	log.VEventf(
 		ctx, 1, "flow scheduler running flow %s, currently running %d", f.GetID(), atomic.LoadInt32(&fs.atomics.numRunning)-1,
 	)
 	fs.metrics.FlowStart()
+	// The flow is registered before it is started so that the main loop, which
+	// unregisters it once it's done, can't observe it finishing first.
+	if !locked {
+		fs.mu.Lock()
+	}
+	fs.mu.runningFlows[f.GetID()] = makeFlowInfo(f, timeutil.Now(), false /* queued */)
+	if !locked {
+		fs.mu.Unlock()
+	}
 	if err := f.Start(ctx, func() { fs.flowDoneCh <- f }); err != nil {
+		if !locked {
+			fs.mu.Lock()
+		}
+		delete(fs.mu.runningFlows, f.GetID())
+		if !locked {
+			fs.mu.Unlock()
+		}
 		return err
 	}
 	// TODO(radu): we could replace the WaitGroup with a structure that keeps a
@@ -122,15 +185,25 @@ func (fs *FlowScheduler) runFlowNow(ctx context.Context, f Flow) error {
 // the given flow.
 //
 // If the flow can start immediately, errors encountered when starting the flow
-// are returned. If the flow is enqueued, these error will be later ignored.
+// are returned. If the flow is enqueued, these error will be later ignored. If
+// the queue is full, the flow is cleaned up and an error is returned.
 func (fs *FlowScheduler) ScheduleFlow(ctx context.Context, f Flow) error {
 	return fs.stopper.RunTaskWithErr(
 		ctx, "flowinfra.FlowScheduler: scheduling flow", func(ctx context.Context) error {
 			if fs.canRunFlow(f) {
-				return fs.runFlowNow(ctx, f)
+				return fs.runFlowNow(ctx, f, false /* locked */)
 			}
 			fs.mu.Lock()
 			defer fs.mu.Unlock()
+			maxQueuedFlows := int(atomic.LoadInt32(&fs.atomics.maxQueuedFlows))
+			if maxQueuedFlows > 0 && fs.mu.queue.Len() >= maxQueuedFlows {
+				log.VEventf(ctx, 1, "flow scheduler rejecting flow %s, queue is full", f.GetID())
+				fs.metrics.FlowsRejected.Inc(1)
+				f.Cleanup(ctx)
+				return pgerror.Newf(pgcode.InsufficientResources,
+					"too many flows queued on this node (%d); see sql.distsql.max_queued_flows",
+					maxQueuedFlows)
+			}
 			log.VEventf(ctx, 1, "flow scheduler enqueuing flow %s to be run later", f.GetID())
 			fs.metrics.FlowsQueued.Inc(1)
 			fs.mu.queue.PushBack(&flowWithCtx{
@@ -158,8 +231,9 @@ func (fs *FlowScheduler) Start() {
 			}
 			fs.mu.Unlock()
 			select {
-			case <-fs.flowDoneCh:
+			case f := <-fs.flowDoneCh:
 				fs.mu.Lock()
+				delete(fs.mu.runningFlows, f.GetID())
 				// Decrement numRunning lazily (i.e. only if there is no new flow to
 				// run).
 				decrementNumRunning := stopped
@@ -177,7 +251,7 @@ func (fs *FlowScheduler) Start() {
 						// Note: we use the flow's context instead of the worker
 						// context, to ensure that logging etc is relative to the
 						// specific flow.
-						if err := fs.runFlowNow(n.ctx, n.flow); err != nil {
+						if err := fs.runFlowNow(n.ctx, n.flow, true /* locked */); err != nil {
 							log.Errorf(n.ctx, "error starting queued flow: %s", err)
 						}
 					} else {
@@ -195,3 +269,19 @@ func (fs *FlowScheduler) Start() {
 		}
 	})
 }
+
+// Serialize returns the flows that are currently running or queued on this
+// node, running flows first.
+func (fs *FlowScheduler) Serialize() []FlowInfo {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	infos := make([]FlowInfo, 0, len(fs.mu.runningFlows)+fs.mu.queue.Len())
+	for _, info := range fs.mu.runningFlows {
+		infos = append(infos, info)
+	}
+	for e := fs.mu.queue.Front(); e != nil; e = e.Next() {
+		f := e.Value.(*flowWithCtx)
+		infos = append(infos, makeFlowInfo(f.flow, f.enqueueTime, true /* queued */))
+	}
+	return infos
+}
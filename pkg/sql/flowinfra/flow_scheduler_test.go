@@ -30,6 +30,7 @@ import (
 )
 
 type mockFlow struct {
+	spec execinfrapb.FlowSpec
 	// runCh is a chan that is closed when either Run or Start is called.
 	runCh chan struct{}
 	// doneCh is a chan that the flow blocks on when run through Start and Wait
@@ -43,7 +44,11 @@ type mockFlow struct {
 var _ Flow = &mockFlow{}
 
 func newMockFlow() *mockFlow {
-	return &mockFlow{runCh: make(chan struct{}), doneCh: make(chan struct{})}
+	return &mockFlow{
+		spec:   execinfrapb.FlowSpec{FlowID: execinfrapb.FlowID{UUID: uuid.MakeV4()}},
+		runCh:  make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
 }
 
 func (m *mockFlow) Setup(
@@ -91,7 +96,11 @@ func (m *mockFlow) AddStartable(_ Startable) {
 }
 
 func (m *mockFlow) GetID() execinfrapb.FlowID {
-	return execinfrapb.FlowID{UUID: uuid.Nil}
+	return m.spec.FlowID
+}
+
+func (m *mockFlow) GetSpec() *execinfrapb.FlowSpec {
+	return &m.spec
 }
 
 func (m *mockFlow) Cleanup(_ context.Context) {}
@@ -118,25 +127,102 @@ func TestFlowScheduler(t *testing.T) {
 		return int(atomic.LoadInt32(&scheduler.atomics.numRunning))
 	}
 
+	// checkFlows verifies the flows reported by the scheduler, given as a
+	// mapping from flow ID to whether the flow is queued.
+	checkFlows := func(expected map[execinfrapb.FlowID]bool) error {
+		infos := scheduler.Serialize()
+		if len(infos) != len(expected) {
+			return errors.Errorf("expected %d flows, found %d", len(expected), len(infos))
+		}
+		for _, info := range infos {
+			queued, ok := expected[info.FlowID]
+			if !ok {
+				return errors.Errorf("unexpected flow %s", info.FlowID)
+			}
+			if info.Queued != queued {
+				return errors.Errorf("expected flow %s to have queued=%t", info.FlowID, queued)
+			}
+		}
+		return nil
+	}
+
 	flow1 := newMockFlow()
+	flow1.spec.Gateway = 2
+	flow1.spec.StatementSQL = "SELECT _ FROM t"
 	require.NoError(t, scheduler.ScheduleFlow(ctx, flow1))
 	require.Equal(t, 1, getNumRunning())
+	infos := scheduler.Serialize()
+	require.Len(t, infos, 1)
+	require.Equal(t, flow1.GetID(), infos[0].FlowID)
+	require.Equal(t, flow1.spec.Gateway, infos[0].Gateway)
+	require.Equal(t, flow1.spec.StatementSQL, infos[0].StatementSQL)
+	require.False(t, infos[0].Queued)
 
 	flow2 := newMockFlow()
 	require.NoError(t, scheduler.ScheduleFlow(ctx, flow2))
 	// numRunning should still be 1 because a maximum of 1 flow can run at a time
 	// and flow1 has not finished yet.
 	require.Equal(t, 1, getNumRunning())
+	require.NoError(t, checkFlows(map[execinfrapb.FlowID]bool{
+		flow1.GetID(): false,
+		flow2.GetID(): true,
+	}))
 
 	close(flow1.doneCh)
 	// Now that flow1 has finished, flow2 should be run.
 	<-flow2.runCh
 	require.Equal(t, 1, getNumRunning())
+	testutils.SucceedsSoon(t, func() error {
+		return checkFlows(map[execinfrapb.FlowID]bool{flow2.GetID(): false})
+	})
 	close(flow2.doneCh)
 	testutils.SucceedsSoon(t, func() error {
 		if getNumRunning() != 0 {
 			return errors.New("expected numRunning to fall back to 0")
 		}
+		return checkFlows(nil)
+	})
+}
+
+func TestFlowSchedulerRejectsFlowsWhenQueueIsFull(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var (
+		ctx      = context.Background()
+		stopper  = stop.NewStopper()
+		settings = cluster.MakeTestingClusterSettings()
+		metrics  = execinfra.MakeDistSQLMetrics(base.DefaultHistogramWindowInterval())
+	)
+	defer stopper.Stop(ctx)
+
+	scheduler := NewFlowScheduler(log.AmbientContext{}, stopper, settings, &metrics)
+	scheduler.Start()
+	scheduler.atomics.maxRunningFlows = 1
+	settingMaxQueuedFlows.Override(&settings.SV, 1)
+
+	flow1, flow2, flow3 := newMockFlow(), newMockFlow(), newMockFlow()
+	require.NoError(t, scheduler.ScheduleFlow(ctx, flow1))
+	require.NoError(t, scheduler.ScheduleFlow(ctx, flow2))
+	// flow1 is running and flow2 is queued, so there is no room for flow3.
+	err := scheduler.ScheduleFlow(ctx, flow3)
+	require.True(t, testutils.IsError(err, "too many flows queued on this node"), err)
+	require.Equal(t, int64(1), metrics.FlowsRejected.Count())
+	require.Equal(t, int64(1), metrics.FlowsQueued.Value())
+
+	// Lifting the limit lets flows queue up again.
+	settingMaxQueuedFlows.Override(&settings.SV, 0)
+	flow4 := newMockFlow()
+	require.NoError(t, scheduler.ScheduleFlow(ctx, flow4))
+	require.Equal(t, int64(2), metrics.FlowsQueued.Value())
+
+	for _, f := range []*mockFlow{flow1, flow2, flow4} {
+		<-f.runCh
+		close(f.doneCh)
+	}
+	testutils.SucceedsSoon(t, func() error {
+		if n := atomic.LoadInt32(&scheduler.atomics.numRunning); n != 0 {
+			return errors.Errorf("expected numRunning to fall back to 0, found %d", n)
+		}
 		return nil
 	})
 }
@@ -14,17 +14,23 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
 )
 
 // BenchmarkFlowSetup sets up a flow for a scan that is dominated by the setup
@@ -82,3 +88,40 @@ func BenchmarkFlowSetup(b *testing.B) {
 		}
 	}
 }
+
+// TestFlowDeadline verifies that a flow with a deadline is canceled once it
+// passes, and that the cancellation is recorded as a timeout.
+func TestFlowDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		name     string
+		deadline time.Time
+		timedOut bool
+	}{
+		{name: "no deadline"},
+		{name: "future deadline", deadline: timeutil.Now().Add(time.Hour)},
+		{name: "past deadline", deadline: timeutil.Now().Add(-time.Second), timedOut: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := flowinfra.NewFlowBase(
+				execinfra.FlowCtx{Deadline: tc.deadline}, nil, /* flowReg */
+				nil /* syncFlowConsumer */, nil, /* localProcessors */
+			)
+			ctx, err := f.Setup(context.Background(), &execinfrapb.FlowSpec{}, flowinfra.FuseNormally)
+			require.NoError(t, err)
+			if tc.timedOut {
+				<-ctx.Done()
+			} else {
+				require.NoError(t, ctx.Err())
+			}
+			require.Equal(t, tc.timedOut, f.GetFlowCtx().TimedOut())
+
+			// Canceling the flow doesn't count as a timeout.
+			f.GetCancelFlowFn()()
+			<-ctx.Done()
+			require.Equal(t, tc.timedOut, f.GetFlowCtx().TimedOut())
+		})
+	}
+}
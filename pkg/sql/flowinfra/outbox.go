@@ -20,6 +20,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
@@ -127,6 +129,16 @@ func (m *Outbox) addRow(
 	mustFlush := false
 	var encodingErr error
 	if meta != nil {
+		if meta.Err != nil && m.flowCtx.TimedOut() {
+			// The error was most likely caused by the cancellation of the flow at
+			// the deadline of its statement, so it is reported as a timeout rather
+			// than as whatever error the cancellation happened to surface as.
+			timeoutMeta := *meta
+			timeoutMeta.Err = pgerror.Wrap(
+				meta.Err, pgcode.QueryCanceled, "query execution canceled due to timeout",
+			)
+			meta = &timeoutMeta
+		}
 		m.encoder.AddMetadata(ctx, *meta)
 		// If we hit an error, let's forward it ASAP. The consumer will probably
 		// close.
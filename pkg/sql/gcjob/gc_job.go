@@ -22,7 +22,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
-	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -110,7 +109,8 @@ func (r schemaChangeGCResumer) Resume(
 
 	tableDropTimes, indexDropTimes := getDropTimes(details)
 
-	timer := timeutil.NewTimer()
+	timeSource := getTimeSource(execCfg)
+	timer := timeSource.NewTimer()
 	defer timer.Stop()
 	timer.Reset(0)
 	gossipUpdateC, cleanup := execCfg.GCJobNotifier.AddNotifyee(ctx)
@@ -121,8 +121,8 @@ func (r schemaChangeGCResumer) Resume(
 			if log.V(2) {
 				log.Info(ctx, "received a new system config")
 			}
-		case <-timer.C:
-			timer.Read = true
+		case <-timer.Ch():
+			timer.MarkRead()
 			if log.V(2) {
 				log.Info(ctx, "SchemaChangeGC timer triggered")
 			}
@@ -133,7 +133,7 @@ func (r schemaChangeGCResumer) Resume(
 		// Refresh the status of all tables in case any GC TTLs have changed.
 		remainingTables := getAllTablesWaitingForGC(details, progress)
 		expired, earliestDeadline := refreshTables(ctx, execCfg, remainingTables, tableDropTimes, indexDropTimes, r.jobID, progress)
-		timerDuration := time.Until(earliestDeadline)
+		timerDuration := earliestDeadline.Sub(timeSource.Now())
 
 		if expired {
 			// Some elements have been marked as DELETING so save the progress.
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -197,3 +198,12 @@ func getDropTimes(
 	}
 	return tableDropTimes, indexDropTimes
 }
+
+// getTimeSource returns the TimeSource used to determine whether the GC TTL of
+// the dropped elements has expired.
+func getTimeSource(execCfg *sql.ExecutorConfig) timeutil.TimeSource {
+	if knobs := execCfg.GCJobTestingKnobs; knobs != nil && knobs.TimeSource != nil {
+		return knobs.TimeSource
+	}
+	return timeutil.DefaultTimeSource{}
+}
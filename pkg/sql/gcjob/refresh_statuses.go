@@ -107,7 +107,7 @@ func updateStatusForGCElements(
 		// Update the status of the table if the table was dropped.
 		if table.Dropped() {
 			deadline := updateTableStatus(ctx, execCfg, int64(tableTTL), protectedtsCache, table, tableDropTimes, progress)
-			if timeutil.Until(deadline) < 0 {
+			if deadline.Sub(getTimeSource(execCfg).Now()) < 0 {
 				expired = true
 			} else if deadline.Before(earliestDeadline) {
 				earliestDeadline = deadline
@@ -164,7 +164,7 @@ func updateTableStatus(
 			return deadline
 		}
 
-		lifetime := timeutil.Until(deadline)
+		lifetime := deadline.Sub(getTimeSource(execCfg).Now())
 		if lifetime < 0 {
 			if log.V(2) {
 				log.Infof(ctx, "detected expired table %d", t.ID)
@@ -213,7 +213,7 @@ func updateIndexesStatus(
 			log.Infof(ctx, "a timestamp protection delayed GC of index %d from table %d", idxProgress.IndexID, table.ID)
 			continue
 		}
-		lifetime := time.Until(deadline)
+		lifetime := deadline.Sub(getTimeSource(execCfg).Now())
 		if lifetime > 0 {
 			if log.V(2) {
 				log.Infof(ctx, "index %d from table %d still has %+v until GC", idxProgress.IndexID, table.ID, lifetime)
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -308,3 +309,57 @@ SELECT job_id, status
 		return nil
 	})
 }
+
+// TestSchemaChangeGCJobTimeSource verifies that the GC TTL of a dropped table
+// is evaluated with the TimeSource testing knob, so that the GC can be
+// triggered without waiting for the TTL to expire.
+func TestSchemaChangeGCJobTimeSource(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	defer jobs.TestingSetAdoptAndCancelIntervals(100*time.Millisecond, 100*time.Millisecond)()
+
+	timeSource := timeutil.NewManualTime(timeutil.Now())
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			GCJob: &sql.GCJobTestingKnobs{TimeSource: timeSource},
+		},
+	})
+	ctx := context.Background()
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, "SET CLUSTER SETTING kv.range_merge.queue_enabled = false")
+	sqlDB.Exec(t, "CREATE DATABASE db")
+	sqlDB.Exec(t, "CREATE TABLE db.foo (k INT PRIMARY KEY)")
+	sqlDB.Exec(t, "ALTER TABLE db.foo CONFIGURE ZONE USING gc.ttlseconds = 60 * 60")
+	sqlDB.Exec(t, "DROP TABLE db.foo")
+
+	var jobID int64
+	sqlDB.QueryRow(t, `
+SELECT job_id
+  FROM crdb_internal.jobs
+ WHERE description LIKE 'GC for DROP TABLE db.public.foo';
+`).Scan(&jobID)
+	jobStatus := func() jobs.Status {
+		var status jobs.Status
+		sqlDB.QueryRow(t, "SELECT status FROM [SHOW JOB $1]", jobID).Scan(&status)
+		return status
+	}
+
+	// The job waits on a timer of the time source until the TTL expires.
+	testutils.SucceedsSoon(t, func() error {
+		if len(timeSource.Timers()) == 0 {
+			return errors.New("GC job is not waiting for the TTL to expire")
+		}
+		return nil
+	})
+	require.Equal(t, jobs.StatusRunning, jobStatus())
+
+	timeSource.Advance(2 * time.Hour)
+	testutils.SucceedsSoon(t, func() error {
+		if status := jobStatus(); status != jobs.StatusSucceeded {
+			return errors.Errorf("job status %v != %v", status, jobs.StatusSucceeded)
+		}
+		return nil
+	})
+}
@@ -187,6 +187,7 @@ func (n *insertNode) startExec(params runParams) error {
 
 	n.run.initRowContainer(params, n.columns)
 
+	params.p.recordTableWrite(n.run.ti.tableDesc().GetID())
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
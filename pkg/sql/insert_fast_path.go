@@ -249,6 +249,7 @@ func (n *insertFastPathNode) startExec(params runParams) error {
 		}
 	}
 
+	params.p.recordTableWrite(n.run.ti.tableDesc().GetID())
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -431,9 +431,9 @@ func (m execNodeTraceMetadata) annotateExplain(
 	var walk func(n *explain.Node)
 	walk = func(n *explain.Node) {
 		wrapped := n.WrappedNode()
+		var nodeStats exec.ExecutionStats
+		haveStats := false
 		if components, ok := m[wrapped]; ok {
-			var nodeStats exec.ExecutionStats
-
 			incomplete := false
 			for i := range components {
 				stats := statsMap[components[i]]
@@ -444,14 +444,28 @@ func (m execNodeTraceMetadata) annotateExplain(
 				nodeStats.RowCount.MaybeAdd(stats.Output.NumTuples)
 				nodeStats.KVBytesRead.MaybeAdd(stats.KV.BytesRead)
 				nodeStats.KVRowsRead.MaybeAdd(stats.KV.TuplesRead)
+				nodeStats.LookupBatches.MaybeAdd(stats.KV.LookupBatches)
+				nodeStats.LookupBatchBytes.MaybeAdd(stats.KV.LookupBatchBytes)
 			}
 			// If we didn't get statistics for all processors, we don't show the
 			// incomplete results. In the future, we may consider an incomplete flag
 			// if we want to show them with a warning.
-			if !incomplete {
-				n.Annotate(exec.ExecutionStatsID, &nodeStats)
+			if incomplete {
+				nodeStats = exec.ExecutionStats{}
+			} else {
+				haveStats = true
 			}
 		}
+		// Apply joins keep track of the executions of their right side
+		// themselves, since each execution is planned and run separately.
+		if a, ok := wrapped.(*applyJoinNode); ok {
+			nodeStats.ApplyJoinRightSideExecutions.Set(a.stats.rightSideExecutions)
+			nodeStats.ApplyJoinRightRows.Set(a.stats.rightRows)
+			haveStats = true
+		}
+		if haveStats {
+			n.Annotate(exec.ExecutionStatsID, &nodeStats)
+		}
 
 		for i := 0; i < n.ChildCount(); i++ {
 			walk(n.Child(i))
@@ -150,6 +150,23 @@ import (
 //  - statement notice <regexp>
 //    Like "statement ok" but expects a notice that matches the given regexp.
 //
+//  - statement event <regexp>
+//    Like "statement ok" but expects the statement to log an event to
+//    system.eventlog which matches the given regexp. Events are matched as
+//    lines of the form "<eventType>: <info>". For example:
+//      statement event create_table: .*"TableName":"test.public.kv"
+//      CREATE TABLE kv (k INT PRIMARY KEY, v INT)
+//
+//  - statement job <regexp>
+//    Like "statement ok" but expects the statement to create a job which
+//    matches the given regexp. Jobs are matched as lines of the form
+//    "<job_type>: <description>". For example:
+//      statement job SCHEMA CHANGE: ALTER TABLE .* ADD COLUMN
+//      ALTER TABLE kv ADD COLUMN w INT
+//
+//    Events and jobs are looked up after the statement returns, so the
+//    statement must not run inside an explicit transaction.
+//
 //  - statement count N
 //    Like "statement ok" but expect a final RowsAffected count of N.
 //    example:
@@ -364,6 +381,8 @@ import (
 var (
 	resultsRE = regexp.MustCompile(`^(\d+)\s+values?\s+hashing\s+to\s+([0-9A-Fa-f]+)$`)
 	noticeRE  = regexp.MustCompile(`^statement\s+notice\s+(.*)$`)
+	eventRE   = regexp.MustCompile(`^statement\s+event\s+(.*)$`)
+	jobRE     = regexp.MustCompile(`^statement\s+job\s+(.*)$`)
 	errorRE   = regexp.MustCompile(`^(?:statement|query)\s+error\s+(?:pgcode\s+([[:alnum:]]+)\s+)?(.*)$`)
 	varRE     = regexp.MustCompile(`\$[a-zA-Z][a-zA-Z_0-9]*`)
 
@@ -754,6 +773,10 @@ type logicStatement struct {
 	sql string
 	// expected notice, if any.
 	expectNotice string
+	// expected event logged to system.eventlog, if any.
+	expectEvent string
+	// expected job created by the statement, if any.
+	expectJob string
 	// expected error, if any. "" indicates the statement should
 	// succeed.
 	expectErr string
@@ -1860,9 +1883,13 @@ func (t *logicTest) processSubtest(
 				pos:         fmt.Sprintf("\n%s:%d", path, s.line+subtest.lineLineIndexIntoFile),
 				expectCount: -1,
 			}
-			// Parse "statement (notice|error) <regexp>"
+			// Parse "statement (notice|event|job|error) <regexp>"
 			if m := noticeRE.FindStringSubmatch(s.Text()); m != nil {
 				stmt.expectNotice = m[1]
+			} else if m := eventRE.FindStringSubmatch(s.Text()); m != nil {
+				stmt.expectEvent = m[1]
+			} else if m := jobRE.FindStringSubmatch(s.Text()); m != nil {
+				stmt.expectJob = m[1]
 			} else if m := errorRE.FindStringSubmatch(s.Text()); m != nil {
 				stmt.expectErrCode = m[1]
 				stmt.expectErr = m[2]
@@ -2444,6 +2471,14 @@ func (t *logicTest) execStatement(stmt logicStatement) (bool, error) {
 			t.outf("rewrote:\n%s\n", execSQL)
 		}
 	}
+	// Side effects are looked up by timestamp, using the root connection as
+	// the current user may not be allowed to read them.
+	var since string
+	if stmt.expectEvent != "" || stmt.expectJob != "" {
+		if err := t.clients[security.RootUser].QueryRow(`SELECT now()::STRING`).Scan(&since); err != nil {
+			return false, err
+		}
+	}
 	res, err := t.db.Exec(execSQL)
 	if err == nil {
 		sqlutils.VerifyStatementPrettyRoundtrip(t.t(), stmt.sql)
@@ -2467,12 +2502,53 @@ func (t *logicTest) execStatement(stmt logicStatement) (bool, error) {
 	// - error on expected error is worth going further, even
 	//   if the obtained error does not match the expected error.
 	cont, err := t.verifyError("", stmt.pos, stmt.expectNotice, stmt.expectErr, stmt.expectErrCode, err)
+	if err == nil && stmt.expectEvent != "" {
+		err = t.verifySideEffect(
+			execSQL, stmt.pos, "event", stmt.expectEvent, since,
+			`SELECT "eventType" || ': ' || info FROM system.eventlog
+WHERE timestamp > $1::TIMESTAMPTZ ORDER BY timestamp`,
+		)
+	}
+	if err == nil && stmt.expectJob != "" {
+		err = t.verifySideEffect(
+			execSQL, stmt.pos, "job", stmt.expectJob, since,
+			`SELECT job_type || ': ' || description FROM crdb_internal.jobs
+WHERE created > $1::TIMESTAMPTZ ORDER BY created`,
+		)
+	}
 	if err != nil {
 		t.finishOne("OK")
 	}
 	return cont, err
 }
 
+// verifySideEffect checks that one of the lines returned by query, which is
+// passed the time at which the statement started, matches the expected
+// pattern. kind is used in the error message.
+func (t *logicTest) verifySideEffect(sql, pos, kind, expected, since, query string) error {
+	rows, err := t.clients[security.RootUser].Query(query, since)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var found []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return err
+		}
+		found = append(found, line)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	foundLines := strings.Join(found, "\n")
+	if match, _ := regexp.MatchString("(?m)"+expected, foundLines); !match {
+		return errors.Errorf("%s: %s\nexpected %s pattern:\n%s\n\ngot:\n%s", pos, sql, kind, expected, foundLines)
+	}
+	return nil
+}
+
 func (t *logicTest) hashResults(results []string) (string, error) {
 	// Hash the values using MD5. This hashing precisely matches the hashing in
 	// sqllogictest.c.
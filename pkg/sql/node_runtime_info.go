@@ -0,0 +1,143 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/cgroups"
+	"github.com/cockroachdb/cockroach/pkg/util/envutil"
+)
+
+// processRuntimeInfo returns the (component, field, value) rows describing the
+// configuration of the running process: its command-line arguments with
+// sensitive values redacted, the environment variables that affect it, the
+// CPU and memory limits of its cgroup, the memory that the percentage-based
+// memory flags are computed against, and GOMAXPROCS.
+func processRuntimeInfo(
+	getMemoryInfo func() (systemTotal, cgroupLimit int64, _ error),
+) [][3]string {
+	rows := [][3]string{
+		{"Process", "Args", strings.Join(redactProcessArgs(os.Args), " ")},
+		{"Process", "GOMAXPROCS", strconv.Itoa(runtime.GOMAXPROCS(0))},
+		{"Process", "NumCPU", strconv.Itoa(runtime.NumCPU())},
+	}
+
+	env := envutil.GetEnvVarsUsed()
+	sort.Strings(env)
+	for _, kv := range env {
+		k, v := kv, ""
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			k, v = kv[:i], kv[i+1:]
+		}
+		rows = append(rows, [3]string{"Env", k, v})
+	}
+
+	memLimit := "unknown"
+	if limit, warnings, err := cgroups.GetMemoryLimit(); err != nil {
+		memLimit = fmt.Sprintf("unknown: %v", err)
+	} else if limit > 0 {
+		memLimit = strconv.FormatInt(limit, 10)
+	} else if warnings != "" {
+		memLimit = fmt.Sprintf("unknown: %s", warnings)
+	}
+	rows = append(rows, [3]string{"Cgroup", "MemoryLimit", memLimit})
+
+	var cpuQuota, cpuPeriod, cpuLimit string
+	if cpu, err := cgroups.GetCgroupCPU(); err != nil {
+		cpuQuota = fmt.Sprintf("unknown: %v", err)
+		cpuPeriod, cpuLimit = cpuQuota, cpuQuota
+	} else {
+		cpuQuota = strconv.FormatInt(cpu.Quota, 10)
+		cpuPeriod = strconv.FormatInt(cpu.Period, 10)
+		cpuLimit = strconv.FormatFloat(cpu.CPUShares(), 'f', 2, 64)
+	}
+	rows = append(rows,
+		[3]string{"Cgroup", "CPUQuota", cpuQuota},
+		[3]string{"Cgroup", "CPUPeriod", cpuPeriod},
+		[3]string{"Cgroup", "CPULimit", cpuLimit},
+	)
+
+	if getMemoryInfo != nil {
+		systemTotal, available := "unknown", "unknown"
+		if total, limit, err := getMemoryInfo(); err != nil {
+			systemTotal = fmt.Sprintf("unknown: %v", err)
+			available = systemTotal
+		} else {
+			systemTotal = strconv.FormatInt(total, 10)
+			if limit > 0 {
+				total = limit
+			}
+			available = strconv.FormatInt(total, 10)
+		}
+		rows = append(rows,
+			[3]string{"Memory", "SystemTotal", systemTotal},
+			[3]string{"Memory", "Available", available},
+		)
+	}
+	return rows
+}
+
+// redactProcessArgs returns a copy of the given command-line arguments in
+// which the values of flags that may hold secrets, and the passwords of URLs,
+// are redacted.
+func redactProcessArgs(args []string) []string {
+	const redacted = "<redacted>"
+	isSensitiveFlag := func(name string) bool {
+		name = strings.ToLower(strings.TrimLeft(name, "-"))
+		for _, s := range []string{"password", "secret", "token", "key"} {
+			if strings.Contains(name, s) {
+				return true
+			}
+		}
+		return false
+	}
+	res := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			arg = redacted
+			redactNext = false
+		case strings.HasPrefix(arg, "-"):
+			if eq := strings.IndexByte(arg, '='); eq >= 0 {
+				if isSensitiveFlag(arg[:eq]) {
+					arg = arg[:eq+1] + redacted
+				} else {
+					arg = arg[:eq+1] + redactURLPassword(arg[eq+1:])
+				}
+			} else if isSensitiveFlag(arg) {
+				// The value is the next argument.
+				redactNext = true
+			}
+		default:
+			arg = redactURLPassword(arg)
+		}
+		res[i] = arg
+	}
+	return res
+}
+
+// redactURLPassword redacts the password of s if it is a URL.
+func redactURLPassword(s string) string {
+	if u, err := url.Parse(s); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+	}
+	return s
+}
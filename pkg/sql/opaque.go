@@ -61,6 +61,10 @@ func buildOpaque(
 		plan, err = p.AlterDatabaseSurvivalGoal(ctx, n)
 	case *tree.AlterIndex:
 		plan, err = p.AlterIndex(ctx, n)
+	case *tree.AlterJob:
+		plan, err = p.AlterJob(ctx, n)
+	case *tree.AlterSchedule:
+		plan, err = p.AlterSchedule(ctx, n)
 	case *tree.AlterSchema:
 		plan, err = p.AlterSchema(ctx, n)
 	case *tree.AlterTable:
@@ -73,6 +77,10 @@ func buildOpaque(
 		plan, err = p.AlterType(ctx, n)
 	case *tree.AlterRole:
 		plan, err = p.AlterRole(ctx, n)
+	case *tree.AlterRoleSet:
+		plan, err = p.AlterRoleSet(ctx, n)
+	case *tree.BatchedDML:
+		plan, err = p.BatchedDML(ctx, n)
 	case *tree.AlterSequence:
 		plan, err = p.AlterSequence(ctx, n)
 	case *tree.CommentOnColumn:
@@ -83,6 +91,8 @@ func buildOpaque(
 		plan, err = p.CommentOnIndex(ctx, n)
 	case *tree.CommentOnTable:
 		plan, err = p.CommentOnTable(ctx, n)
+	case *tree.Compact:
+		plan, err = p.Compact(ctx, n)
 	case *tree.CreateDatabase:
 		plan, err = p.CreateDatabase(ctx, n)
 	case *tree.CreateIndex:
@@ -101,6 +111,11 @@ func buildOpaque(
 		plan, err = p.Deallocate(ctx, n)
 	case *tree.Discard:
 		plan, err = p.Discard(ctx, n)
+	case *tree.Explain:
+		if n.Mode != tree.ExplainDDL {
+			return nil, errors.AssertionFailedf("unexpected opaque EXPLAIN mode %s", n.Mode)
+		}
+		plan, err = p.ExplainDDL(ctx, n)
 	case *tree.DropDatabase:
 		plan, err = p.DropDatabase(ctx, n)
 	case *tree.DropIndex:
@@ -141,6 +156,8 @@ func buildOpaque(
 		plan, err = p.Revoke(ctx, n)
 	case *tree.RevokeRole:
 		plan, err = p.RevokeRole(ctx, n)
+	case *tree.RevokeWebSessions:
+		plan, err = p.RevokeWebSessions(ctx, n)
 	case *tree.Scatter:
 		plan, err = p.Scatter(ctx, n)
 	case *tree.Scrub:
@@ -171,6 +188,8 @@ func buildOpaque(
 		plan, err = p.ShowFingerprints(ctx, n)
 	case *tree.Truncate:
 		plan, err = p.Truncate(ctx, n)
+	case *tree.VerifyConsistency:
+		plan, err = p.VerifyConsistency(ctx, n)
 	case tree.CCLOnlyStatement:
 		plan, err = p.maybePlanHook(ctx, stmt)
 		if plan == nil && err == nil {
@@ -202,6 +221,8 @@ func init() {
 		&tree.AlterDatabasePrimaryRegion{},
 		&tree.AlterDatabaseSurvivalGoal{},
 		&tree.AlterIndex{},
+		&tree.AlterJob{},
+		&tree.AlterSchedule{},
 		&tree.AlterSchema{},
 		&tree.AlterTable{},
 		&tree.AlterTableLocality{},
@@ -209,10 +230,13 @@ func init() {
 		&tree.AlterType{},
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
+		&tree.AlterRoleSet{},
+		&tree.BatchedDML{},
 		&tree.CommentOnColumn{},
 		&tree.CommentOnDatabase{},
 		&tree.CommentOnIndex{},
 		&tree.CommentOnTable{},
+		&tree.Compact{},
 		&tree.CreateDatabase{},
 		&tree.CreateExtension{},
 		&tree.CreateIndex{},
@@ -222,6 +246,8 @@ func init() {
 		&tree.CreateRole{},
 		&tree.Deallocate{},
 		&tree.Discard{},
+		// EXPLAIN is only planned as an opaque statement in the DDL mode.
+		&tree.Explain{},
 		&tree.DropDatabase{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
@@ -242,6 +268,7 @@ func init() {
 		&tree.ReparentDatabase{},
 		&tree.Revoke{},
 		&tree.RevokeRole{},
+		&tree.RevokeWebSessions{},
 		&tree.Scatter{},
 		&tree.Scrub{},
 		&tree.SetClusterSetting{},
@@ -257,6 +284,7 @@ func init() {
 		&tree.ShowZoneConfig{},
 		&tree.ShowFingerprints{},
 		&tree.Truncate{},
+		&tree.VerifyConsistency{},
 
 		// CCL statements (without Export which has an optimizer operator).
 		&tree.Backup{},
@@ -34,6 +34,7 @@ type Column struct {
 	virtualComputed             bool
 	defaultExpr                 string
 	computedExpr                string
+	onUpdateExpr                string
 	invertedSourceColumnOrdinal int
 }
 
@@ -125,6 +126,20 @@ func (c *Column) ComputedExprStr() string {
 	return c.computedExpr
 }
 
+// HasOnUpdate returns true if the column has an ON UPDATE expression.
+// OnUpdateExprStr will be set to the SQL expression string in that case.
+func (c *Column) HasOnUpdate() bool {
+	return c.onUpdateExpr != ""
+}
+
+// OnUpdateExprStr is set to the SQL expression string that describes the
+// column's ON UPDATE expression. It is used to provide the column's value when
+// the user updates a row without setting a value for the column. ON UPDATE
+// expressions cannot depend on other columns.
+func (c *Column) OnUpdateExprStr() string {
+	return c.onUpdateExpr
+}
+
 // IsVirtualComputed returns true if this is a virtual computed column.
 func (c *Column) IsVirtualComputed() bool {
 	return c.virtualComputed
@@ -181,6 +196,7 @@ func (c *Column) InitNonVirtual(
 	hidden bool,
 	defaultExpr *string,
 	computedExpr *string,
+	onUpdateExpr *string,
 ) {
 	if kind == VirtualInverted {
 		panic(errors.AssertionFailedf("incorrect init method"))
@@ -202,6 +218,11 @@ func (c *Column) InitNonVirtual(
 	} else {
 		c.computedExpr = ""
 	}
+	if onUpdateExpr != nil {
+		c.onUpdateExpr = *onUpdateExpr
+	} else {
+		c.onUpdateExpr = ""
+	}
 	c.invertedSourceColumnOrdinal = -1
 }
 
@@ -219,6 +240,7 @@ func (c *Column) InitVirtualInverted(
 	c.hidden = true
 	c.defaultExpr = ""
 	c.computedExpr = ""
+	c.onUpdateExpr = ""
 	c.invertedSourceColumnOrdinal = invertedSourceColumnOrdinal
 }
 
@@ -228,6 +250,7 @@ func (c *Column) InitVirtualComputed(
 	ordinal int,
 	stableID StableID,
 	name tree.Name,
+	kind ColumnKind,
 	datumType *types.T,
 	nullable bool,
 	hidden bool,
@@ -236,12 +259,13 @@ func (c *Column) InitVirtualComputed(
 	c.ordinal = ordinal
 	c.stableID = stableID
 	c.name = name
-	c.kind = Ordinary
+	c.kind = kind
 	c.datumType = datumType
 	c.nullable = nullable
 	c.hidden = hidden
 	c.defaultExpr = ""
 	c.computedExpr = computedExpr
+	c.onUpdateExpr = ""
 	c.virtualComputed = true
 	c.invertedSourceColumnOrdinal = -1
 }
@@ -315,6 +315,9 @@ func formatColumn(col *Column, buf *bytes.Buffer) {
 	if col.HasDefault() {
 		fmt.Fprintf(buf, " default (%s)", col.DefaultExprStr())
 	}
+	if col.HasOnUpdate() {
+		fmt.Fprintf(buf, " on update (%s)", col.OnUpdateExprStr())
+	}
 	if col.IsHidden() {
 		fmt.Fprintf(buf, " [hidden]")
 	}
@@ -679,6 +679,12 @@ func (b *Builder) applySimpleProject(
 }
 
 func (b *Builder) buildProject(prj *memo.ProjectExpr) (execPlan, error) {
+	for i := range prj.Projections {
+		if prj.Projections[i].ScalarProps().HasCorrelatedSubquery {
+			return b.buildProjectApply(prj)
+		}
+	}
+
 	md := b.mem.Metadata()
 	input, err := b.buildRelational(prj.Input)
 	if err != nil {
@@ -790,26 +796,7 @@ func (b *Builder) buildApplyJoin(join memo.RelExpr) (execPlan, error) {
 			return f.CopyAndReplaceDefault(e, replaceFn)
 		}
 		f.CopyAndReplace(rightExpr, &rightRequiredProps, replaceFn)
-
-		newRightSide, err := o.Optimize()
-		if err != nil {
-			return nil, err
-		}
-
-		eb := New(ef, f.Memo(), b.catalog, newRightSide, b.evalCtx, false /* allowAutoCommit */)
-		eb.disableTelemetry = true
-		plan, err := eb.Build()
-		if err != nil {
-			if errors.IsAssertionFailure(err) {
-				// Enhance the error with the EXPLAIN (OPT, VERBOSE) of the inner
-				// expression.
-				fmtFlags := memo.ExprFmtHideQualifications | memo.ExprFmtHideScalars | memo.ExprFmtHideTypes
-				explainOpt := o.FormatExpr(newRightSide, fmtFlags)
-				err = errors.WithDetailf(err, "newRightSide:\n%s", explainOpt)
-			}
-			return nil, err
-		}
-		return plan, nil
+		return b.buildApplyRightSide(ef, &o)
 	}
 
 	// The right plan will always produce the columns in the presentation, in
@@ -854,6 +841,123 @@ func (b *Builder) buildApplyJoin(join memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+// buildApplyRightSide optimizes the right side of an apply join, which has
+// already been copied into the memo of the given optimizer with all outer
+// columns replaced by constants, and builds it against the given factory.
+func (b *Builder) buildApplyRightSide(ef exec.Factory, o *xform.Optimizer) (exec.Plan, error) {
+	newRightSide, err := o.Optimize()
+	if err != nil {
+		return nil, err
+	}
+
+	eb := New(ef, o.Factory().Memo(), b.catalog, newRightSide, b.evalCtx, false /* allowAutoCommit */)
+	eb.disableTelemetry = true
+	plan, err := eb.Build()
+	if err != nil {
+		if errors.IsAssertionFailure(err) {
+			// Enhance the error with the EXPLAIN (OPT, VERBOSE) of the inner
+			// expression.
+			fmtFlags := memo.ExprFmtHideQualifications | memo.ExprFmtHideScalars | memo.ExprFmtHideTypes
+			explainOpt := o.FormatExpr(newRightSide, fmtFlags)
+			err = errors.WithDetailf(err, "newRightSide:\n%s", explainOpt)
+		}
+		return nil, err
+	}
+	return plan, nil
+}
+
+// buildProjectApply builds a Project whose projections contain correlated
+// subqueries that could not be hoisted or decorrelated by the optimizer (for
+// example, a subquery in a CASE branch that must only be evaluated when the
+// branch is taken). Rather than failing, the projections are evaluated once
+// per input row by an apply join: the right side is a single-row Project
+// which is re-planned for each input row with the outer columns replaced by
+// the values from that row. Once the outer columns are constant, the
+// optimizer can fold away untaken branches and the remaining subqueries are
+// uncorrelated, so they can be executed as usual.
+func (b *Builder) buildProjectApply(prj *memo.ProjectExpr) (execPlan, error) {
+	for i := range prj.Projections {
+		if len(memo.WithUses(&prj.Projections[i])) != 0 {
+			return execPlan{}, fmt.Errorf("references to WITH expressions from correlated subqueries are unsupported")
+		}
+	}
+
+	leftPlan, err := b.buildRelational(prj.Input)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	// The right side produces only the projected columns; the passthrough
+	// columns are taken from the left side.
+	var rightCols opt.ColSet
+	for i := range prj.Projections {
+		rightCols.Add(prj.Projections[i].Col)
+	}
+	rightRequiredProps := physical.Required{Presentation: b.makePresentation(rightCols)}
+
+	inputCols := prj.Input.Relational().OutputCols.ToList()
+	inputColOrds := make([]int, len(inputCols))
+	for i, col := range inputCols {
+		ord, ok := leftPlan.outputCols.Get(int(col))
+		if !ok {
+			return execPlan{}, fmt.Errorf("couldn't find binding column %d in left output columns", col)
+		}
+		inputColOrds[i] = ord
+	}
+
+	var o xform.Optimizer
+	planRightSideFn := func(ef exec.Factory, leftRow tree.Datums) (exec.Plan, error) {
+		o.Init(b.evalCtx, b.catalog)
+		f := o.Factory()
+
+		// Copy the Project into a new memo, replacing its input with a single
+		// row of constants and each reference to an input column with the
+		// corresponding constant.
+		var replaceFn norm.ReplaceFunc
+		replaceFn = func(e opt.Expr) opt.Expr {
+			if e == prj.Input {
+				elems := make(memo.ScalarListExpr, len(inputCols))
+				typs := make([]*types.T, len(inputCols))
+				for i, col := range inputCols {
+					typs[i] = f.Metadata().ColumnMeta(col).Type
+					elems[i] = f.ConstructConstVal(leftRow[inputColOrds[i]], typs[i])
+				}
+				return f.ConstructValues(
+					memo.ScalarListExpr{f.ConstructTuple(elems, types.MakeTuple(typs))},
+					&memo.ValuesPrivate{Cols: inputCols, ID: f.Metadata().NextUniqueID()},
+				)
+			}
+			if t, ok := e.(*memo.VariableExpr); ok {
+				for i, col := range inputCols {
+					if col == t.Col {
+						return f.ConstructConstVal(leftRow[inputColOrds[i]], t.Typ)
+					}
+				}
+			}
+			return f.CopyAndReplaceDefault(e, replaceFn)
+		}
+		f.CopyAndReplace(prj, &rightRequiredProps, replaceFn)
+		return b.buildApplyRightSide(ef, &o)
+	}
+
+	var rightOutputCols opt.ColMap
+	for i := range rightRequiredProps.Presentation {
+		rightOutputCols.Set(int(rightRequiredProps.Presentation[i].ID), i)
+	}
+	applyPlan := execPlan{outputCols: joinOutputMap(leftPlan.outputCols, rightOutputCols)}
+	applyPlan.root, err = b.factory.ConstructApplyJoin(
+		descpb.InnerJoin,
+		leftPlan.root,
+		b.presentationToResultColumns(rightRequiredProps.Presentation),
+		nil, /* onCond */
+		planRightSideFn,
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+	return b.applySimpleProject(applyPlan, prj.Relational().OutputCols, prj.ProvidedPhysical().Ordering)
+}
+
 // makePresentation creates a Presentation that contains the given columns, in
 // order of their IDs.
 func (b *Builder) makePresentation(cols opt.ColSet) physical.Presentation {
@@ -1788,7 +1892,7 @@ func (b *Builder) buildRecursiveCTE(rec *memo.RecursiveCTEExpr) (execPlan, error
 
 	label := fmt.Sprintf("working buffer (%s)", rec.Name)
 	var ep execPlan
-	ep.root, err = b.factory.ConstructRecursiveCTE(initial.root, fn, label)
+	ep.root, err = b.factory.ConstructRecursiveCTE(initial.root, fn, label, rec.Deduplicate)
 	if err != nil {
 		return execPlan{}, err
 	}
@@ -340,6 +340,19 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if s.KVBytesRead.HasValue() {
 			e.ob.AddField("KV bytes read", humanize.IBytes(s.KVBytesRead.Value()))
 		}
+		if s.LookupBatches.HasValue() {
+			n := s.LookupBatches.Value()
+			e.ob.AddField("lookup batches", humanizeutil.Count(n))
+			if bytes := s.LookupBatchBytes.Value(); n > 0 && bytes > 0 {
+				e.ob.AddField("avg lookup batch size", humanize.IBytes(bytes/n))
+			}
+		}
+		if s.ApplyJoinRightSideExecutions.HasValue() {
+			e.ob.AddField("right side executions", humanizeutil.Count(s.ApplyJoinRightSideExecutions.Value()))
+		}
+		if s.ApplyJoinRightRows.HasValue() {
+			e.ob.AddField("right side rows", humanizeutil.Count(s.ApplyJoinRightRows.Value()))
+		}
 	}
 
 	if stats, ok := n.annotations[exec.EstimatedStatsID]; ok {
@@ -447,6 +460,12 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			a.Aggregations, nil /* groupCols */, nil /* groupColOrdering */, true, /* isScalar */
 		)
 
+	case recursiveCTEOp:
+		a := n.args.(*recursiveCTEArgs)
+		if a.Deduplicate {
+			ob.Attr("deduplicate", "")
+		}
+
 	case distinctOp:
 		a := n.args.(*distinctArgs)
 		inputCols := a.Input.Columns()
@@ -688,7 +707,6 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		alterTableUnsplitOp,
 		alterTableUnsplitAllOp,
 		alterTableRelocateOp,
-		recursiveCTEOp,
 		controlJobsOp,
 		controlSchedulesOp,
 		cancelQueriesOp,
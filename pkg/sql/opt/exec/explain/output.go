@@ -12,7 +12,9 @@ package explain
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -303,6 +305,130 @@ func (ob *OutputBuilder) BuildProtoTree() *roachpb.ExplainTreePlanNode {
 	return sentinel.Children[0]
 }
 
+// jsonField is a field of the JSON representation of the plan.
+type jsonField struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// jsonNode is a node of the JSON representation of the plan.
+type jsonNode struct {
+	Name     string      `json:"name"`
+	Columns  string      `json:"columns,omitempty"`
+	Ordering string      `json:"ordering,omitempty"`
+	Fields   []jsonField `json:"fields,omitempty"`
+	Children []*jsonNode `json:"children,omitempty"`
+}
+
+// jsonPlan is the JSON representation of the plan.
+type jsonPlan struct {
+	// Fields contains the top-level fields, like "distribution".
+	Fields []jsonField `json:"fields,omitempty"`
+	Plan   *jsonNode   `json:"plan,omitempty"`
+}
+
+// BuildJSON creates a representation of the plan as a single JSON document, for
+// use by external tools. The document has the following structure:
+//
+//   {
+//     "fields": [{"key": "distribution", "value": "local"}, ...],
+//     "plan": {
+//       "name": "scan",
+//       "columns": "(a, b)",
+//       "ordering": "+a",
+//       "fields": [{"key": "table", "value": "t@primary"}, ...],
+//       "children": [...]
+//     }
+//   }
+//
+// The columns and the ordering of the nodes are only set with the Verbose flag.
+func (ob *OutputBuilder) BuildJSON() (string, error) {
+	var plan jsonPlan
+	// We reconstruct the hierarchy using the levels.
+	// stack keeps track of the current node on each level. We use a sentinel node
+	// for level 0.
+	sentinel := &jsonNode{}
+	stack := []*jsonNode{sentinel}
+	for _, entry := range ob.entries {
+		field := jsonField{Key: entry.field, Value: entry.fieldVal}
+		if entry.isNode() {
+			parent := stack[entry.level-1]
+			child := &jsonNode{Name: entry.node, Columns: entry.columns, Ordering: entry.ordering}
+			parent.Children = append(parent.Children, child)
+			stack = append(stack[:entry.level], child)
+		} else if len(stack) == 1 {
+			plan.Fields = append(plan.Fields, field)
+		} else {
+			node := stack[len(stack)-1]
+			node.Fields = append(node.Fields, field)
+		}
+	}
+	if len(sentinel.Children) > 0 {
+		plan.Plan = sentinel.Children[0]
+	}
+	b, err := json.Marshal(plan)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// dotEscaper escapes strings used as quoted identifiers in the DOT language.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// BuildDOT creates a representation of the plan as a single graph in the DOT
+// language of Graphviz. Every node of the plan is a node of the graph labeled
+// with its name and fields, with an edge to each of its children; the top-level
+// fields make up the label of the graph.
+func (ob *OutputBuilder) BuildDOT() string {
+	var buf bytes.Buffer
+	buf.WriteString("digraph plan {\n")
+	buf.WriteString("  node [shape=box];\n")
+
+	var topLevel []string
+	var lines []string
+	// stack keeps track of the ID of the current node on each level.
+	stack := []int{-1}
+	numNodes := 0
+	flushNode := func() {
+		if len(lines) > 0 {
+			fmt.Fprintf(&buf, "  n%d [label=\"%s\"];\n", stack[len(stack)-1], dotEscaper.Replace(strings.Join(lines, "\n")))
+			lines = lines[:0]
+		}
+	}
+	for _, entry := range ob.entries {
+		if !entry.isNode() {
+			if len(stack) == 1 {
+				topLevel = append(topLevel, entry.fieldStr())
+			} else {
+				lines = append(lines, entry.fieldStr())
+			}
+			continue
+		}
+		flushNode()
+		id := numNodes
+		numNodes++
+		if parent := stack[entry.level-1]; parent != -1 {
+			fmt.Fprintf(&buf, "  n%d -> n%d;\n", parent, id)
+		}
+		stack = append(stack[:entry.level], id)
+		lines = append(lines, entry.node)
+		if entry.columns != "" {
+			lines = append(lines, fmt.Sprintf("columns: %s", entry.columns))
+		}
+		if entry.ordering != "" {
+			lines = append(lines, fmt.Sprintf("ordering: %s", entry.ordering))
+		}
+	}
+	flushNode()
+	if len(topLevel) > 0 {
+		fmt.Fprintf(&buf, "  label=\"%s\";\n", dotEscaper.Replace(strings.Join(topLevel, "\n")))
+		buf.WriteString("  labelloc=t;\n")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
 // AddTopLevelField adds a top-level field. Cannot be called while inside a
 // node.
 func (ob *OutputBuilder) AddTopLevelField(key, value string) {
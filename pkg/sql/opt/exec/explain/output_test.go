@@ -12,6 +12,7 @@ package explain_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"text/tabwriter"
@@ -90,6 +91,20 @@ func TestOutputBuilder(t *testing.T) {
 			}
 			return string(treeYaml)
 
+		case "json":
+			res, err := ob.BuildJSON()
+			if err != nil {
+				panic(err)
+			}
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, []byte(res), "", "  "); err != nil {
+				panic(err)
+			}
+			return buf.String()
+
+		case "dot":
+			return ob.BuildDOT()
+
 		case "datums":
 			rows := ob.BuildExplainRows()
 
@@ -301,6 +301,19 @@ type ExecutionStats struct {
 
 	KVBytesRead optional.Uint
 	KVRowsRead  optional.Uint
+
+	// LookupBatches is the number of lookups performed by a lookup or index
+	// join, and LookupBatchBytes is the total size of the batches of input rows
+	// the lookups were performed for.
+	LookupBatches    optional.Uint
+	LookupBatchBytes optional.Uint
+
+	// ApplyJoinRightSideExecutions is the number of times the right side of an
+	// apply join was planned and executed (once per left row).
+	ApplyJoinRightSideExecutions optional.Uint
+	// ApplyJoinRightRows is the total number of rows produced by all the
+	// executions of the right side of an apply join.
+	ApplyJoinRightRows optional.Uint
 }
 
 // BuildPlanForExplainFn builds an execution plan against the given
@@ -644,6 +644,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			f.formatColList(e, tp, "initial columns:", t.InitialCols)
 			f.formatColList(e, tp, "recursive columns:", t.RecursiveCols)
 		}
+		if t.Deduplicate {
+			tp.Child("deduplicate")
+		}
 
 	default:
 		if opt.IsJoinOp(t) {
@@ -508,6 +508,7 @@ func (h *hasher) HashFKCascades(val FKCascades) {
 
 func (h *hasher) HashExplainOptions(val tree.ExplainOptions) {
 	h.HashUint64(uint64(val.Mode))
+	h.HashUint64(uint64(val.Format))
 	hash := h.hash
 	for i, val := range val.Flags {
 		if val {
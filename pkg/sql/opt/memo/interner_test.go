@@ -92,6 +92,9 @@ func TestInterner(t *testing.T) {
 	explain3.Flags[1] = true
 	explain3.Flags[2] = true
 	explain3.Flags[3] = true
+	explain4 := tree.ExplainOptions{Mode: tree.ExplainPlan, Format: tree.ExplainFormatJSON}
+	explain4.Flags[1] = true
+	explain4.Flags[2] = true
 
 	scanNode := &ScanExpr{}
 	andExpr := &AndExpr{}
@@ -408,6 +411,7 @@ func TestInterner(t *testing.T) {
 			{val1: explain1, val2: explain1, equal: true},
 			{val1: explain1, val2: explain2, equal: false},
 			{val1: explain2, val2: explain3, equal: false},
+			{val1: explain1, val2: explain4, equal: false},
 		}},
 
 		{hashFn: in.hasher.HashShowTraceType, eqFn: in.hasher.IsShowTraceTypeEqual, variations: []testVariation{
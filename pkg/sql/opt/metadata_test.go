@@ -195,6 +195,7 @@ func TestMetadataTables(t *testing.T) {
 			false, /* hidden */
 			nil,   /* defaultExpr */
 			nil,   /* computedExpr */
+			nil,   /* onUpdateExpr */
 		)
 		return c
 	}
@@ -692,6 +692,18 @@ func (c *CustomFuncs) AddColsToPartition(
 	return &cpy
 }
 
+// AppendZipSentinel returns a copy of the given zip with an extra scalar item
+// appended to it. Since a scalar item always produces exactly one row, a
+// ProjectSet with the returned zip outputs at least one row for each input row,
+// with generator columns padded with NULLs when the generators produce no rows.
+// See the TryDecorrelateLeftJoinProjectSet rule for more details.
+func (c *CustomFuncs) AppendZipSentinel(zip memo.ZipExpr) memo.ZipExpr {
+	sentinelColID := c.f.Metadata().AddColumn("sentinel", types.Bool)
+	newZip := make(memo.ZipExpr, len(zip), len(zip)+1)
+	copy(newZip, zip)
+	return append(newZip, c.f.ConstructZipItem(memo.TrueSingleton, opt.ColList{sentinelColID}))
+}
+
 // ConstructAnyCondition builds an expression that compares the given scalar
 // expression with the first (and only) column of the input rowset, using the
 // given comparison operator.
@@ -80,6 +80,20 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 				numColumns, util.Pluralize(int64(numColumns))))
 		}
 
+		if hasTypedColumns(ct) {
+			// The AS query is rewritten to cast its columns to the types specified
+			// for them, rather than casts being added to the plan, since the query
+			// may be run again from its string representation to populate the
+			// table asynchronously. The statement is copied so that the original
+			// AST is left untouched if it is built again.
+			castCT := *ct
+			castCT.AsSource = castCreateTableAsSource(ct)
+			ct = &castCT
+			b.pushWithFrame()
+			outScope = b.buildStmt(ct.AsSource, nil /* desiredTypes */, inScope)
+			b.popWithFrame(outScope)
+		}
+
 		input = outScope.expr
 		if !ct.AsHasUserSpecifiedPrimaryKey() {
 			// Synthesize rowid column, and append to end of column list.
@@ -111,3 +125,52 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 	)
 	return outScope
 }
+
+// hasTypedColumns returns true if a type is specified for any of the columns
+// of the given CREATE TABLE ... AS statement.
+func hasTypedColumns(ct *tree.CreateTable) bool {
+	for _, def := range ct.Defs {
+		if d, ok := def.(*tree.ColumnTableDef); ok && d.Type != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// castCreateTableAsSource returns the AS query of the given CREATE TABLE ...
+// AS statement wrapped in a query that casts the columns for which a type is
+// specified to that type, for example:
+//
+//   CREATE TABLE t (a, b DECIMAL) AS SELECT x, y FROM u
+//
+// populates t with the results of:
+//
+//   SELECT a, b::DECIMAL AS b FROM (SELECT x, y FROM u) AS ctas_source (a, b)
+//
+func castCreateTableAsSource(ct *tree.CreateTable) *tree.Select {
+	var names tree.NameList
+	var exprs tree.SelectExprs
+	for _, def := range ct.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			continue
+		}
+		names = append(names, d.Name)
+		var expr tree.Expr = tree.NewUnresolvedName(string(d.Name))
+		if d.Type != nil {
+			expr = &tree.CastExpr{Expr: expr, Type: d.Type, SyntaxMode: tree.CastShort}
+		}
+		exprs = append(exprs, tree.SelectExpr{Expr: expr, As: tree.UnrestrictedName(d.Name)})
+	}
+	return &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From: tree.From{
+				Tables: tree.TableExprs{&tree.AliasedTableExpr{
+					Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: ct.AsSource}},
+					As:   tree.AliasClause{Alias: "ctas_source", Cols: names},
+				}},
+			},
+		},
+	}
+}
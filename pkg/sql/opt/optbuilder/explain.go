@@ -20,6 +20,16 @@ import (
 )
 
 func (b *Builder) buildExplain(explain *tree.Explain, inScope *scope) (outScope *scope) {
+	if explain.Mode == tree.ExplainDDL {
+		// The explained schema change statement is not built; EXPLAIN (DDL) is
+		// an opaque statement which inspects it instead.
+		telemetry.Inc(sqltelemetry.ExplainDDLUseCounter)
+		if outScope = b.tryBuildOpaque(explain, inScope); outScope == nil {
+			panic(errors.AssertionFailedf("EXPLAIN (DDL) is not registered as an opaque statement"))
+		}
+		return outScope
+	}
+
 	b.pushWithFrame()
 
 	// We don't allow the statement under Explain to reference outer columns, so we
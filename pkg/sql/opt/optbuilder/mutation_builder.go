@@ -601,6 +601,50 @@ func (mb *mutationBuilder) addSynthesizedDefaultCols(
 	mb.outScope = pb.Finish()
 }
 
+// addSynthesizedOnUpdateCols is a helper method for addSynthesizedColsForUpdate
+// that scans the list of Ordinary table columns, looking for any that have an
+// ON UPDATE expression and are not explicitly updated by the input expression.
+// New columns are synthesized for those columns using the ON UPDATE
+// expression.
+//
+// NOTE: colIDs is updated with the column IDs of any synthesized columns which
+// are added to mb.outScope.
+func (mb *mutationBuilder) addSynthesizedOnUpdateCols(colIDs opt.OptionalColList) {
+	// We will construct a new Project operator that will contain the newly
+	// synthesized column(s).
+	pb := makeProjectionBuilder(mb.b, mb.outScope)
+
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		tabCol := mb.tab.Column(i)
+		if tabCol.Kind() != cat.Ordinary || !tabCol.HasOnUpdate() {
+			continue
+		}
+		// Skip columns that are already specified.
+		if colIDs[i] != 0 {
+			continue
+		}
+
+		expr, err := parser.ParseExpr(tabCol.OnUpdateExprStr())
+		if err != nil {
+			panic(err)
+		}
+
+		// Add synthesized column. It is important to use the real column name, as
+		// this column may later be referred to by a computed column.
+		tabColID := mb.tabID.ColumnID(i)
+		newCol, _ := pb.Add(tabCol.ColName(), expr, tabCol.DatumType())
+
+		// Remember id of newly synthesized column.
+		colIDs[i] = newCol
+
+		// Add corresponding target column.
+		mb.targetColList = append(mb.targetColList, tabColID)
+		mb.targetColSet.Add(tabColID)
+	}
+
+	mb.outScope = pb.Finish()
+}
+
 // addSynthesizedComputedCols is a helper method for addSynthesizedColsForInsert
 // and addSynthesizedColsForUpdate that scans the list of table columns, looking
 // for any that are computed and do not yet have values provided by the input
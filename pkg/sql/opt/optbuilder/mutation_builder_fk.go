@@ -17,9 +17,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/errors"
 )
 
@@ -336,22 +338,7 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 		// performance either: we would be incurring extra cost (more complicated
 		// expressions, scanning the input buffer twice) for a rare case.
 
-		oldRows, colsForOldRow, _ := mb.makeCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals)
-		newRows, colsForNewRow, _ := mb.makeCheckInputScan(checkInputScanNewVals, h.tabOrdinals)
-
-		// The rows that no longer exist are the ones that were "deleted" by virtue
-		// of being updated _from_, minus the ones that were "added" by virtue of
-		// being updated _to_.
-		deletedRows := mb.b.factory.ConstructExcept(
-			oldRows,
-			newRows,
-			&memo.SetPrivate{
-				LeftCols:  colsForOldRow,
-				RightCols: colsForNewRow,
-				OutCols:   colsForOldRow,
-			},
-		)
-
+		deletedRows, colsForOldRow := mb.buildFKCheckDeletedRowsForUpdate(h)
 		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(deletedRows, colsForOldRow))
 	}
 	telemetry.Inc(sqltelemetry.ForeignKeyChecksUseCounter)
@@ -439,26 +426,46 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 		// insertions (using a "canaryCol IS NOT NULL" condition). But the rows we
 		// would filter out have all-null fetched values anyway and will never match
 		// in the semi join.
-		oldRows, colsForOldRow, _ := mb.makeCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals)
-		newRows, colsForNewRow, _ := mb.makeCheckInputScan(checkInputScanNewVals, h.tabOrdinals)
-
-		// The rows that no longer exist are the ones that were "deleted" by virtue
-		// of being updated _from_, minus the ones that were "added" by virtue of
-		// being updated _to_.
-		deletedRows := mb.b.factory.ConstructExcept(
-			oldRows,
-			newRows,
-			&memo.SetPrivate{
-				LeftCols:  colsForOldRow,
-				RightCols: colsForNewRow,
-				OutCols:   colsForOldRow,
-			},
-		)
+		deletedRows, colsForOldRow := mb.buildFKCheckDeletedRowsForUpdate(h)
 		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(deletedRows, colsForOldRow))
 	}
 	telemetry.Inc(sqltelemetry.ForeignKeyChecksUseCounter)
 }
 
+// buildFKCheckDeletedRowsForUpdate builds the input to the deletion-side check
+// of an update or upsert for the inbound FK constraint of the given helper. It
+// returns the expression and its columns, which contain the "old" values of
+// the referenced FK columns.
+//
+// If the FK constraint references a partial unique index, the "new" values are
+// not removed from the "old" values: the updated row may no longer be in the
+// index even though its FK values did not change. Values which are still
+// referenceable after the update are instead filtered out by the deletion
+// check itself (see buildDeletionCheck).
+func (mb *mutationBuilder) buildFKCheckDeletedRowsForUpdate(
+	h *fkCheckHelper,
+) (deletedRows memo.RelExpr, colsForOldRow opt.ColList) {
+	oldRows, colsForOldRow, _ := mb.makeCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals)
+	if h.refPartialIndex != noPartialIndex {
+		return oldRows, colsForOldRow
+	}
+	newRows, colsForNewRow, _ := mb.makeCheckInputScan(checkInputScanNewVals, h.tabOrdinals)
+
+	// The rows that no longer exist are the ones that were "deleted" by virtue
+	// of being updated _from_, minus the ones that were "added" by virtue of
+	// being updated _to_.
+	deletedRows = mb.b.factory.ConstructExcept(
+		oldRows,
+		newRows,
+		&memo.SetPrivate{
+			LeftCols:  colsForOldRow,
+			RightCols: colsForNewRow,
+			OutCols:   colsForOldRow,
+		},
+	)
+	return deletedRows, colsForOldRow
+}
+
 // outboundFKColsUpdated returns true if any of the FK columns for an outbound
 // constraint are being updated (according to updateColIDs).
 func (mb *mutationBuilder) outboundFKColsUpdated(fkOrdinal int) bool {
@@ -472,7 +479,10 @@ func (mb *mutationBuilder) outboundFKColsUpdated(fkOrdinal int) bool {
 }
 
 // inboundFKColsUpdated returns true if any of the FK columns for an inbound
-// constraint are being updated (according to updateColIDs).
+// constraint are being updated (according to updateColIDs). If the constraint
+// references a partial unique index, the columns referenced by the predicate of
+// the index are considered FK columns as well, since updating them can remove a
+// row from the index.
 func (mb *mutationBuilder) inboundFKColsUpdated(fkOrdinal int) bool {
 	fk := mb.tab.InboundForeignKey(fkOrdinal)
 	for i, n := 0, fk.ColumnCount(); i < n; i++ {
@@ -480,9 +490,66 @@ func (mb *mutationBuilder) inboundFKColsUpdated(fkOrdinal int) bool {
 			return true
 		}
 	}
+	if idx := fkReferencedPartialIndex(fk, mb.tab); idx != noPartialIndex {
+		predCols := mb.partialIndexPredicateCols(idx)
+		for ord, colID := range mb.updateColIDs {
+			if colID != 0 && predCols.Contains(mb.tabID.ColumnID(ord)) {
+				return true
+			}
+		}
+	}
 	return false
 }
 
+// partialIndexPredicateCols returns the columns of the target table that are
+// referenced by the predicate of the given partial index.
+func (mb *mutationBuilder) partialIndexPredicateCols(idx cat.IndexOrdinal) opt.ColSet {
+	predScope := mb.b.allocScope()
+	predScope.appendOrdinaryColumnsFromTable(mb.md.TableMeta(mb.tabID), &mb.alias)
+	texpr := resolvePartialIndexPredicate(predScope, mb.parsePartialIndexPredicateExpr(idx))
+	scalar := mb.b.buildScalar(texpr, predScope, nil, nil, nil)
+	return mb.b.factory.ConstructFiltersItem(scalar).ScalarProps().OuterCols
+}
+
+// noPartialIndex is returned by fkReferencedPartialIndex when a FK constraint
+// does not reference a partial unique index.
+const noPartialIndex cat.IndexOrdinal = -1
+
+// fkReferencedPartialIndex returns the ordinal of the partial unique index of
+// the referenced table that the given FK constraint references, or
+// noPartialIndex if the constraint references a unique index that is not
+// partial. As in tabledesc.FindFKReferencedIndex, a partial unique index is
+// only referenced if no other unique index matches the referenced columns.
+//
+// A FK constraint referencing a partial unique index only references the rows
+// that satisfy the predicate of the index.
+func fkReferencedPartialIndex(fk cat.ForeignKeyConstraint, refTab cat.Table) cat.IndexOrdinal {
+	partialIndex := noPartialIndex
+	for i, n := 0, refTab.IndexCount(); i < n; i++ {
+		index := refTab.Index(i)
+		if !index.IsUnique() || index.LaxKeyColumnCount() != fk.ColumnCount() {
+			continue
+		}
+		matches := true
+		for j, m := 0, fk.ColumnCount(); j < m; j++ {
+			if index.Column(j).Ordinal() != fk.ReferencedColumnOrdinal(refTab, j) {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if _, isPartial := index.Predicate(); !isPartial {
+			return noPartialIndex
+		}
+		if partialIndex == noPartialIndex {
+			partialIndex = i
+		}
+	}
+	return partialIndex
+}
+
 // ensureWithID makes sure that withID is initialized (and thus that the input
 // to the mutation will be buffered).
 //
@@ -515,6 +582,11 @@ type fkCheckHelper struct {
 	// otherTabOrdinals are the table ordinals of the FK columns in the "other"
 	// table. They correspond 1-to-1 to the columns in the ForeignKeyConstraint.
 	otherTabOrdinals []int
+
+	// refPartialIndex is the ordinal of the partial unique index of the
+	// referenced table that the FK constraint references, or noPartialIndex.
+	// See fkReferencedPartialIndex.
+	refPartialIndex cat.IndexOrdinal
 }
 
 // initWithOutboundFK initializes the helper with an outbound FK constraint.
@@ -544,6 +616,7 @@ func (h *fkCheckHelper) initWithOutboundFK(mb *mutationBuilder, fkOrdinal int) b
 		h.tabOrdinals[i] = h.fk.OriginColumnOrdinal(mb.tab, i)
 		h.otherTabOrdinals[i] = h.fk.ReferencedColumnOrdinal(h.otherTab, i)
 	}
+	h.refPartialIndex = fkReferencedPartialIndex(h.fk, h.otherTab)
 
 	// Check if we are setting NULL values for the FK columns, like when this
 	// mutation is the result of a SET NULL cascade action.
@@ -593,6 +666,7 @@ func (h *fkCheckHelper) initWithInboundFK(mb *mutationBuilder, fkOrdinal int) (o
 		h.tabOrdinals[i] = h.fk.ReferencedColumnOrdinal(mb.tab, i)
 		h.otherTabOrdinals[i] = h.fk.OriginColumnOrdinal(h.otherTab, i)
 	}
+	h.refPartialIndex = fkReferencedPartialIndex(h.fk, mb.tab)
 
 	return true
 }
@@ -614,6 +688,10 @@ func resolveTable(ctx context.Context, catalog cat.Catalog, id cat.StableID) cat
 
 // buildOtherTableScan builds a Scan of the "other" table.
 func (h *fkCheckHelper) buildOtherTableScan() (outScope *scope, tabMeta *opt.TableMeta) {
+	if h.fkOutbound {
+		// The other table is the referenced table.
+		return h.buildReferencedTableScan(h.otherTab, h.otherTabOrdinals)
+	}
 	otherTabMeta := h.mb.b.addTable(h.otherTab, tree.NewUnqualifiedTableName(h.otherTab.Name()))
 	return h.mb.b.buildScan(
 		otherTabMeta,
@@ -624,6 +702,56 @@ func (h *fkCheckHelper) buildOtherTableScan() (outScope *scope, tabMeta *opt.Tab
 	), otherTabMeta
 }
 
+// buildReferencedTableScan builds a Scan of the referenced table, which outputs
+// the columns with the given ordinals first. If the FK constraint references a
+// partial unique index, the rows of the Scan are filtered by the predicate of
+// the index.
+func (h *fkCheckHelper) buildReferencedTableScan(
+	refTab cat.Table, refTabOrdinals []int,
+) (outScope *scope, tabMeta *opt.TableMeta) {
+	b := h.mb.b
+	refTabMeta := b.addTable(refTab, tree.NewUnqualifiedTableName(refTab.Name()))
+	if h.refPartialIndex == noPartialIndex {
+		return b.buildScan(
+			refTabMeta,
+			refTabOrdinals,
+			&tree.IndexFlags{IgnoreForeignKeys: true},
+			noRowLocking,
+			b.allocScope(),
+		), refTabMeta
+	}
+
+	// The predicate can reference any column of the table, so all columns are
+	// scanned. The columns that are not needed are pruned during normalization.
+	var fkOrds util.FastIntSet
+	ordinals := append([]int(nil), refTabOrdinals...)
+	for _, ord := range refTabOrdinals {
+		fkOrds.Add(ord)
+	}
+	for _, ord := range tableOrdinals(refTab, columnKinds{includeVirtualComputed: true}) {
+		if !fkOrds.Contains(ord) {
+			ordinals = append(ordinals, ord)
+		}
+	}
+	outScope = b.buildScan(
+		refTabMeta,
+		ordinals,
+		&tree.IndexFlags{IgnoreForeignKeys: true},
+		noRowLocking,
+		b.allocScope(),
+	)
+
+	pred, _ := refTab.Index(h.refPartialIndex).Predicate()
+	expr, err := parser.ParseExpr(pred)
+	if err != nil {
+		panic(err)
+	}
+	texpr := resolvePartialIndexPredicate(outScope, expr)
+	filter := b.factory.ConstructFiltersItem(b.buildScalar(texpr, outScope, nil, nil, nil))
+	outScope.expr = b.factory.ConstructSelect(outScope.expr, memo.FiltersExpr{filter})
+	return outScope, refTabMeta
+}
+
 func (h *fkCheckHelper) allocOrdinals(numCols int) {
 	buf := make([]int, numCols*2)
 	h.tabOrdinals = buf[:numCols]
@@ -771,6 +899,26 @@ func (h *fkCheckHelper) buildDeletionCheck(
 	}
 	semiJoin := f.ConstructSemiJoin(deletedRows, scanScope.expr, semiJoinFilters, &p)
 
+	if h.refPartialIndex != noPartialIndex {
+		// A FK constraint that references a partial unique index only references
+		// the rows in the index. After the mutation, the index can still contain
+		// a row with the values of a removed row, either because the removed row
+		// was not in the index or because another row took its place. Referencing
+		// rows are only orphaned if there is no such row, so build an anti join
+		// with the rows of the index.
+		refScope, _ := h.buildReferencedTableScan(h.mb.tab, h.tabOrdinals)
+		antiJoinFilters := make(memo.FiltersExpr, len(deleteCols))
+		for j := range deleteCols {
+			antiJoinFilters[j] = f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(deleteCols[j]),
+					f.ConstructVariable(refScope.cols[j].id),
+				),
+			)
+		}
+		semiJoin = f.ConstructAntiJoin(semiJoin, refScope.expr, antiJoinFilters, &p)
+	}
+
 	return f.ConstructFKChecksItem(semiJoin, &memo.FKChecksItemPrivate{
 		OriginTable:     origTabMeta.MetaID,
 		ReferencedTable: h.mb.tabID,
@@ -290,7 +290,8 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 
 // addSynthesizedColsForUpdate wraps an Update input expression with a Project
 // operator containing any computed columns that need to be updated. This
-// includes write-only mutation columns that are computed.
+// includes write-only mutation columns that are computed, as well as columns
+// with an ON UPDATE expression that are not explicitly updated.
 func (mb *mutationBuilder) addSynthesizedColsForUpdate() {
 	// Allow mutation columns to be referenced by other computed mutation
 	// columns (otherwise the scope will raise an error if a mutation column
@@ -306,6 +307,10 @@ func (mb *mutationBuilder) addSynthesizedColsForUpdate() {
 	// set by the backfiller.
 	mb.addSynthesizedDefaultCols(mb.updateColIDs, false /* includeOrdinary */)
 
+	// Add columns with an ON UPDATE expression that are not explicitly set by
+	// the statement.
+	mb.addSynthesizedOnUpdateCols(mb.updateColIDs)
+
 	// Possibly round DECIMAL-related columns containing update values. Do
 	// this before evaluating computed expressions, since those may depend on
 	// the inserted columns.
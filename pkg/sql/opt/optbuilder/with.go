@@ -11,12 +11,14 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
 )
 
@@ -35,6 +37,11 @@ func (b *Builder) buildCTE(
 	cte *tree.CTE, inScope *scope, isRecursive bool,
 ) (memo.RelExpr, physical.Presentation) {
 	if !isRecursive {
+		if cte.Cycle != nil {
+			panic(pgerror.Newf(
+				pgcode.Syntax, "WITH query %q is not recursive; CYCLE clause is not allowed", cte.Name.Alias,
+			))
+		}
 		cteScope := b.buildStmt(cte.Stmt, nil /* desiredTypes */, inScope)
 		cteScope.removeHiddenCols()
 		b.dropOrderingAndExtraCols(cteScope)
@@ -45,7 +52,7 @@ func (b *Builder) buildCTE(
 	//
 	//   WITH RECURSIVE name(cols) AS (
 	//     initial_query
-	//     UNION ALL
+	//     UNION [ALL]
 	//     recursive_query
 	//   )
 	//
@@ -57,6 +64,8 @@ func (b *Builder) buildCTE(
 	//       the working table for the recursive self-reference.
 	//     * emit all resulting rows, and save them as the next iteration's
 	//       working table.
+	// With UNION (instead of UNION ALL), rows that duplicate a row that was
+	// already emitted are discarded and are not added to the working table.
 	//
 	// Note however, that a non-recursive CTE can be used even when RECURSIVE is
 	// specified (particularly useful when there are multiple CTEs defined).
@@ -76,24 +85,22 @@ func (b *Builder) buildCTE(
 	cteScope.ctes = map[string]*cteSource{cte.Name.Alias.String(): cteSrc}
 
 	initial, recursive, isUnionAll, ok := b.splitRecursiveCTE(cte.Stmt)
-	// We don't currently support the UNION form (only UNION ALL).
-	if !ok || !isUnionAll {
+	if !ok {
+		if cte.Cycle != nil {
+			panic(pgerror.Newf(
+				pgcode.Syntax,
+				"with a CYCLE clause, recursive query %q must have the form non-recursive-term UNION [ALL] recursive-term",
+				cte.Name.Alias,
+			))
+		}
 		// Build this as a non-recursive CTE, but throw a proper error message if it
 		// does have a recursive reference.
 		cteSrc.onRef = func() {
-			if !ok {
-				panic(pgerror.Newf(
-					pgcode.Syntax,
-					"recursive query %q does not have the form non-recursive-term UNION ALL recursive-term",
-					cte.Name.Alias,
-				))
-			} else {
-				panic(unimplementedWithIssueDetailf(
-					46642, "",
-					"recursive query %q uses UNION which is not implemented (only UNION ALL is supported)",
-					cte.Name.Alias,
-				))
-			}
+			panic(pgerror.Newf(
+				pgcode.Syntax,
+				"recursive query %q does not have the form non-recursive-term UNION [ALL] recursive-term",
+				cte.Name.Alias,
+			))
 		}
 		return b.buildCTE(cte, cteScope, false /* recursive */)
 	}
@@ -115,6 +122,14 @@ func (b *Builder) buildCTE(
 	initialScope.removeHiddenCols()
 	b.dropOrderingAndExtraCols(initialScope)
 
+	cteSrc.cols = b.getCTECols(initialScope, cte.Name)
+	if cte.Cycle != nil {
+		// Add the cycle mark and path columns to the initial query and rewrite
+		// the recursive query to maintain them.
+		initialScope = b.buildCycleInitialCols(cte.Cycle, cteSrc, initialScope)
+		recursive = b.rewriteRecursiveForCycle(cte, recursive, cteSrc.cols)
+	}
+
 	// The properties of the binding are tricky: the recursive expression is
 	// invoked repeatedly and these must hold each time. We can't use the initial
 	// expression's properties directly, as those only hold the first time the
@@ -137,8 +152,6 @@ func (b *Builder) buildCTE(
 	})
 	b.factory.Metadata().AddWithBinding(withID, cteSrc.expr)
 
-	cteSrc.cols = b.getCTECols(initialScope, cte.Name)
-
 	outScope := inScope.push()
 
 	initialTypes := initialScope.makeColumnTypes()
@@ -167,8 +180,15 @@ func (b *Builder) buildCTE(
 	b.popWithFrame(recursiveScope)
 
 	if numRefs == 0 {
+		if cte.Cycle != nil {
+			panic(pgerror.Newf(
+				pgcode.Syntax,
+				"with a CYCLE clause, the recursive term of query %q must reference %q in its FROM clause",
+				cte.Name.Alias, cte.Name.Alias,
+			))
+		}
 		// Build this as a non-recursive CTE.
-		cteScope := b.buildSetOp(tree.UnionOp, false /* all */, inScope, initialScope, recursiveScope)
+		cteScope := b.buildSetOp(tree.UnionOp, isUnionAll, inScope, initialScope, recursiveScope)
 		return cteScope.expr, b.getCTECols(cteScope, cte.Name)
 	}
 
@@ -201,6 +221,7 @@ func (b *Builder) buildCTE(
 		InitialCols:   colsToColList(initialScope.cols),
 		RecursiveCols: colsToColList(recursiveScope.cols),
 		OutCols:       colsToColList(outScope.cols),
+		Deduplicate:   !isUnionAll,
 	}
 
 	expr := b.factory.ConstructRecursiveCTE(cteSrc.expr, initialScope.expr, recursiveScope.expr, &private)
@@ -240,7 +261,7 @@ func (b *Builder) getCTECols(cteScope *scope, name tree.AliasClause) physical.Pr
 }
 
 // splitRecursiveCTE splits a CTE statement of the form
-//   initial_query UNION ALL recursive_query
+//   initial_query UNION [ALL] recursive_query
 // into the initial and recursive parts. If the statement is not of this form,
 // returns ok=false.
 func (b *Builder) splitRecursiveCTE(
@@ -258,3 +279,231 @@ func (b *Builder) splitRecursiveCTE(
 	}
 	return union.Left, union.Right, union.All, true
 }
+
+// buildCycleInitialCols adds the mark and path columns of the given CYCLE
+// clause to the initial query of a recursive CTE. The mark column is set to the
+// mark default value and the path column is set to a single-element array
+// containing a tuple of the cycle columns. The new columns are also added to
+// the CTE columns in cteSrc.
+func (b *Builder) buildCycleInitialCols(
+	cycle *tree.CycleClause, cteSrc *cteSource, initialScope *scope,
+) *scope {
+	colNames := make(tree.NameList, len(cteSrc.cols))
+	for i := range cteSrc.cols {
+		colNames[i] = tree.Name(cteSrc.cols[i].Alias)
+	}
+	for _, name := range []tree.Name{cycle.MarkColumn, cycle.PathColumn} {
+		for i := range colNames {
+			if colNames[i] == name {
+				panic(pgerror.Newf(
+					pgcode.DuplicateColumn,
+					"cycle column %q already used in WITH query column list", name,
+				))
+			}
+		}
+	}
+	if cycle.MarkColumn == cycle.PathColumn {
+		panic(pgerror.Newf(
+			pgcode.DuplicateColumn, "cycle mark column name and cycle path column name are the same",
+		))
+	}
+
+	cycleCols := make(memo.ScalarListExpr, len(cycle.Columns))
+	tupleContents := make([]*types.T, len(cycle.Columns))
+	for i, name := range cycle.Columns {
+		ord := -1
+		for j := range colNames {
+			if colNames[j] == name {
+				ord = j
+				break
+			}
+		}
+		if ord == -1 {
+			panic(pgerror.Newf(
+				pgcode.UndefinedColumn, "cycle column %q not in WITH query column list", name,
+			))
+		}
+		col := &initialScope.cols[ord]
+		cycleCols[i] = b.factory.ConstructVariable(col.id)
+		tupleContents[i] = col.typ
+	}
+
+	markDefault := b.buildCycleMarkDefault(cycle)
+	tupleTyp := types.MakeTuple(tupleContents)
+	path := b.factory.ConstructArray(
+		memo.ScalarListExpr{b.factory.ConstructTuple(cycleCols, tupleTyp)},
+		types.MakeArray(tupleTyp),
+	)
+
+	outScope := initialScope.replace()
+	outScope.appendColumnsFromScope(initialScope)
+	markCol := b.synthesizeColumn(
+		outScope, string(cycle.MarkColumn), markDefault.DataType(), nil /* expr */, markDefault,
+	)
+	cteSrc.cols = append(cteSrc.cols, opt.AliasedColumn{Alias: markCol.name.String(), ID: markCol.id})
+	pathCol := b.synthesizeColumn(
+		outScope, string(cycle.PathColumn), path.DataType(), nil /* expr */, path,
+	)
+	cteSrc.cols = append(cteSrc.cols, opt.AliasedColumn{Alias: pathCol.name.String(), ID: pathCol.id})
+	b.constructProjectForScope(initialScope, outScope)
+	return outScope
+}
+
+// buildCycleMarkDefault type checks the mark value and mark default of the
+// given CYCLE clause and returns a scalar expression for the default.
+func (b *Builder) buildCycleMarkDefault(cycle *tree.CycleClause) opt.ScalarExpr {
+	markValue, markDefault := cycleMarkValues(cycle)
+	emptyScope := b.allocScope()
+	value := emptyScope.resolveType(markValue, types.Any)
+	def := emptyScope.resolveAndRequireType(markDefault, value.ResolvedType())
+	return b.buildScalar(def, emptyScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// cycleMarkValues returns the mark value and mark default of the given CYCLE
+// clause, which are TRUE and FALSE if they were not specified.
+func cycleMarkValues(cycle *tree.CycleClause) (markValue, markDefault tree.Expr) {
+	if cycle.MarkValue == nil {
+		return tree.DBoolTrue, tree.DBoolFalse
+	}
+	return cycle.MarkValue, cycle.MarkDefault
+}
+
+// cycleSubqueryAlias is the name of the subquery used by
+// rewriteRecursiveForCycle.
+const cycleSubqueryAlias = "cycle"
+
+// rewriteRecursiveForCycle rewrites the recursive query of a CTE with a CYCLE
+// clause so that it maintains the cycle mark and path columns, similarly to
+// Postgres. A recursive query of the form:
+//
+//   SELECT <exprs> FROM <from> WHERE <where>
+//
+// is rewritten to:
+//
+//   SELECT "cycle".<cols>,
+//          CASE WHEN ("cycle".<cycle cols>) = ANY("cycle".<path>)
+//            THEN <mark value> ELSE <mark default> END AS <mark>,
+//          array_append("cycle".<path>, ("cycle".<cycle cols>)) AS <path>
+//   FROM (
+//     SELECT <exprs>, <ref>.<path> FROM <from>
+//     WHERE <where> AND <ref>.<mark> <> <mark value>
+//   ) AS "cycle"(<cols>, <path>)
+//
+// where <ref> is the name of the reference to the working table. Rows for which
+// a cycle was detected are still returned, but are not processed further.
+func (b *Builder) rewriteRecursiveForCycle(
+	cte *tree.CTE, recursive *tree.Select, cols physical.Presentation,
+) *tree.Select {
+	cycle := cte.Cycle
+	sel, ok := recursive.Select.(*tree.SelectClause)
+	if !ok || recursive.With != nil || recursive.OrderBy != nil || recursive.Limit != nil {
+		panic(pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"with a CYCLE clause, the recursive term of query %q must be a simple SELECT", cte.Name.Alias,
+		))
+	}
+	ref, ok := findCTERefInFrom(sel.From.Tables, cte.Name.Alias)
+	if !ok {
+		panic(pgerror.Newf(
+			pgcode.Syntax,
+			"with a CYCLE clause, the recursive term of query %q must reference %q in its FROM clause",
+			cte.Name.Alias, cte.Name.Alias,
+		))
+	}
+	markValue, markDefault := cycleMarkValues(cycle)
+
+	// Build the inner query, which passes through the path of the working table
+	// row and filters out the rows for which a cycle was already detected.
+	inner := *sel
+	inner.Exprs = append(tree.SelectExprs(nil), sel.Exprs...)
+	inner.Exprs = append(inner.Exprs, tree.SelectExpr{
+		Expr: tree.NewUnresolvedName(string(ref), string(cycle.PathColumn)),
+	})
+	var filter tree.Expr = &tree.ComparisonExpr{
+		Operator: tree.NE,
+		Left:     tree.NewUnresolvedName(string(ref), string(cycle.MarkColumn)),
+		Right:    markValue,
+	}
+	if sel.Where != nil {
+		filter = &tree.AndExpr{Left: &tree.ParenExpr{Expr: sel.Where.Expr}, Right: filter}
+	}
+	inner.Where = tree.NewWhere(tree.AstWhere, filter)
+
+	// Build the outer query, which computes the new mark and path.
+	innerCols := make(tree.NameList, 0, len(cols)-1)
+	outerExprs := make(tree.SelectExprs, 0, len(cols))
+	for _, col := range cols[:len(cols)-2] {
+		innerCols = append(innerCols, tree.Name(col.Alias))
+		outerExprs = append(outerExprs, tree.SelectExpr{
+			Expr: tree.NewUnresolvedName(cycleSubqueryAlias, col.Alias),
+		})
+	}
+	innerCols = append(innerCols, cycle.PathColumn)
+	cycleTuple := &tree.Tuple{Exprs: make(tree.Exprs, len(cycle.Columns))}
+	for i, name := range cycle.Columns {
+		cycleTuple.Exprs[i] = tree.NewUnresolvedName(cycleSubqueryAlias, string(name))
+	}
+	path := tree.NewUnresolvedName(cycleSubqueryAlias, string(cycle.PathColumn))
+	outerExprs = append(outerExprs,
+		tree.SelectExpr{
+			Expr: &tree.CaseExpr{
+				Whens: []*tree.When{{
+					Cond: &tree.ComparisonExpr{
+						Operator:    tree.Any,
+						SubOperator: tree.EQ,
+						Left:        cycleTuple,
+						Right:       path,
+					},
+					Val: markValue,
+				}},
+				Else: markDefault,
+			},
+			As: tree.UnrestrictedName(cycle.MarkColumn),
+		},
+		tree.SelectExpr{
+			Expr: &tree.FuncExpr{
+				Func:  tree.WrapFunction("array_append"),
+				Exprs: tree.Exprs{path, cycleTuple},
+			},
+			As: tree.UnrestrictedName(cycle.PathColumn),
+		},
+	)
+
+	return &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: outerExprs,
+			From: tree.From{
+				Tables: tree.TableExprs{&tree.AliasedTableExpr{
+					Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: &tree.Select{Select: &inner}}},
+					As:   tree.AliasClause{Alias: cycleSubqueryAlias, Cols: innerCols},
+				}},
+			},
+		},
+	}
+}
+
+// findCTERefInFrom searches the given FROM clause tables for a reference to
+// the CTE with the given name, and returns the name through which the
+// reference can be used (the alias, if there is one).
+func findCTERefInFrom(tables tree.TableExprs, cteName tree.Name) (tree.Name, bool) {
+	for _, t := range tables {
+		switch t := t.(type) {
+		case *tree.AliasedTableExpr:
+			if tn, ok := t.Expr.(*tree.TableName); ok && !tn.ExplicitSchema && tn.ObjectName == cteName {
+				if t.As.Alias != "" {
+					return t.As.Alias, true
+				}
+				return cteName, true
+			}
+		case *tree.JoinTableExpr:
+			if name, ok := findCTERefInFrom(tree.TableExprs{t.Left, t.Right}, cteName); ok {
+				return name, true
+			}
+		case *tree.ParenTableExpr:
+			if name, ok := findCTERefInFrom(tree.TableExprs{t.Expr}, cteName); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -1478,6 +1478,7 @@ func (ot *OptTester) createTableAs(name tree.TableName, rel memo.RelExpr) (*test
 			false, /* hidden */
 			nil,   /* defaultExpr */
 			nil,   /* computedExpr */
+			nil,   /* onUpdateExpr */
 		)
 
 		// Make sure we have estimated stats for this column.
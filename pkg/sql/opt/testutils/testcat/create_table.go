@@ -122,6 +122,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 			true,               /* hidden */
 			&uniqueRowIDString, /* defaultExpr */
 			nil,                /* computedExpr */
+			nil,                /* onUpdateExpr */
 		)
 		tab.Columns = append(tab.Columns, rowid)
 	}
@@ -149,6 +150,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		true, /* hidden */
 		nil,  /* defaultExpr */
 		nil,  /* computedExpr */
+		nil,  /* onUpdateExpr */
 	)
 	tab.Columns = append(tab.Columns, mvcc)
 
@@ -278,6 +280,7 @@ func (tc *Catalog) createVirtualTable(stmt *tree.CreateTable) *Table {
 		true,  /* hidden */
 		nil,   /* defaultExpr */
 		nil,   /* computedExpr */
+		nil,   /* onUpdateExpr */
 	)
 
 	tab.Columns = []cat.Column{pk}
@@ -334,6 +337,7 @@ func (tc *Catalog) CreateTableAs(name tree.TableName, columns []cat.Column) *Tab
 		true,               /* hidden */
 		&uniqueRowIDString, /* defaultExpr */
 		nil,                /* computedExpr */
+		nil,                /* onUpdateExpr */
 	)
 
 	tab.Columns = append(tab.Columns, rowid)
@@ -399,20 +403,31 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 				return false
 			}
 		}
-		if _, isPartialIndex := idx.Predicate(); isPartialIndex {
-			return false
-		}
 		return true
 	}
 
-	// 1. Verify that the target table has a unique index.
+	isPartial := func(idx *Index) bool {
+		_, isPartialIndex := idx.Predicate()
+		return isPartialIndex
+	}
+
+	// 1. Verify that the target table has a unique index. A partial unique index
+	// is only used if there is no other matching index.
 	var targetIndex *Index
 	for _, idx := range targetTable.Indexes {
-		if matches(idx, toCols, true /* strict */) {
+		if matches(idx, toCols, true /* strict */) && !isPartial(idx) {
 			targetIndex = idx
 			break
 		}
 	}
+	if targetIndex == nil {
+		for _, idx := range targetTable.Indexes {
+			if matches(idx, toCols, true /* strict */) && isPartial(idx) && idx.IsUnique() {
+				targetIndex = idx
+				break
+			}
+		}
+	}
 	if targetIndex == nil {
 		panic(fmt.Errorf(
 			"there is no unique constraint matching given keys for referenced table %s",
@@ -424,7 +439,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		// 2. Search for an existing index in the source table; add it if necessary.
 		found := false
 		for _, idx := range tab.Indexes {
-			if matches(idx, fromCols, false /* strict */) {
+			if matches(idx, fromCols, false /* strict */) && !isPartial(idx) {
 				found = true
 				break
 			}
@@ -502,12 +517,17 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 		kind = cat.DeleteOnly
 	}
 
-	var defaultExpr, computedExpr *string
+	var defaultExpr, computedExpr, onUpdateExpr *string
 	if def.DefaultExpr.Expr != nil {
 		s := serializeTableDefExpr(def.DefaultExpr.Expr)
 		defaultExpr = &s
 	}
 
+	if def.OnUpdateExpr.Expr != nil {
+		s := serializeTableDefExpr(def.OnUpdateExpr.Expr)
+		onUpdateExpr = &s
+	}
+
 	if def.Computed.Expr != nil {
 		s := serializeTableDefExpr(def.Computed.Expr)
 		computedExpr = &s
@@ -519,6 +539,7 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 			ordinal,
 			cat.StableID(1+ordinal),
 			name,
+			cat.Ordinary,
 			typ,
 			nullable,
 			false, /* hidden */
@@ -535,6 +556,7 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 			false, /* hidden */
 			defaultExpr,
 			computedExpr,
+			onUpdateExpr,
 		)
 	}
 	tt.Columns = append(tt.Columns, col)
@@ -833,6 +855,7 @@ func columnForIndexElemExpr(tt *Table, expr tree.Expr) cat.Column {
 		len(tt.Columns),
 		cat.StableID(1+len(tt.Columns)),
 		name,
+		cat.Ordinary,
 		typ,
 		true, /* nullable */
 		true, /* hidden */
@@ -662,15 +662,14 @@ func newOptTable(
 				desc.Hidden,
 				desc.DefaultExpr,
 				desc.ComputeExpr,
+				desc.OnUpdateExpr,
 			)
 		} else {
-			if kind != cat.Ordinary {
-				return nil, errors.AssertionFailedf("virtual mutation column")
-			}
 			ot.columns[ordinal].InitVirtualComputed(
 				ordinal,
 				cat.StableID(desc.ID),
 				tree.Name(desc.Name),
+				kind,
 				desc.Type,
 				desc.Nullable,
 				desc.Hidden,
@@ -704,6 +703,7 @@ func newOptTable(
 				sysCol.Hidden,
 				sysCol.DefaultExpr,
 				sysCol.ComputeExpr,
+				sysCol.OnUpdateExpr,
 			)
 		}
 	}
@@ -1693,6 +1693,7 @@ func newOptVirtualTable(
 		true,  /* hidden */
 		nil,   /* defaultExpr */
 		nil,   /* computedExpr */
+		nil,   /* onUpdateExpr */
 	)
 	for i := range desc.Columns {
 		d := desc.Columns[i]
@@ -1706,6 +1707,7 @@ func newOptVirtualTable(
 			d.Hidden,
 			d.DefaultExpr,
 			d.ComputeExpr,
+			d.OnUpdateExpr,
 		)
 	}
 
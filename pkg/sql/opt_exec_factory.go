@@ -906,12 +906,13 @@ func (ef *execFactory) ConstructScanBuffer(ref exec.Node, label string) (exec.No
 
 // ConstructRecursiveCTE is part of the exec.Factory interface.
 func (ef *execFactory) ConstructRecursiveCTE(
-	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string,
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, deduplicate bool,
 ) (exec.Node, error) {
 	return &recursiveCTENode{
 		initial:        initial.(planNode),
 		genIterationFn: fn,
 		label:          label,
+		deduplicate:    deduplicate,
 	}, nil
 }
 
@@ -1163,6 +1164,7 @@ func (ef *execFactory) ConstructInsert(
 	// Create the table inserter, which does the bulk of the work.
 	ri, err := row.MakeInserter(
 		ctx, ef.planner.txn, ef.planner.ExecCfg().Codec, tabDesc, colDescs, ef.planner.alloc,
+		&ef.planner.ExecCfg().Settings.SV,
 	)
 	if err != nil {
 		return nil, err
@@ -1229,6 +1231,7 @@ func (ef *execFactory) ConstructInsertFastPath(
 	// Create the table inserter, which does the bulk of the work.
 	ri, err := row.MakeInserter(
 		ctx, ef.planner.txn, ef.planner.ExecCfg().Codec, tabDesc, colDescs, ef.planner.alloc,
+		&ef.planner.ExecCfg().Settings.SV,
 	)
 	if err != nil {
 		return nil, err
@@ -1325,6 +1328,7 @@ func (ef *execFactory) ConstructUpdate(
 		fetchColDescs,
 		row.UpdaterDefault,
 		ef.planner.alloc,
+		&ef.planner.ExecCfg().Settings.SV,
 	)
 	if err != nil {
 		return nil, err
@@ -1424,6 +1428,7 @@ func (ef *execFactory) ConstructUpsert(
 		tabDesc,
 		insertColDescs,
 		ef.planner.alloc,
+		&ef.planner.ExecCfg().Settings.SV,
 	)
 	if err != nil {
 		return nil, err
@@ -1439,6 +1444,7 @@ func (ef *execFactory) ConstructUpsert(
 		fetchColDescs,
 		row.UpdaterDefault,
 		ef.planner.alloc,
+		&ef.planner.ExecCfg().Settings.SV,
 	)
 	if err != nil {
 		return nil, err
@@ -78,6 +78,12 @@ func TestContextualHelp(t *testing.T) {
 
 		{`ALTER ROLE bleh ?? WITH NOCREATEROLE`, `ALTER ROLE`},
 
+		{`ALTER JOB ??`, `ALTER JOB`},
+		{`ALTER JOB 123 SET ??`, `ALTER JOB`},
+
+		{`ALTER SCHEDULE ??`, `ALTER SCHEDULE`},
+		{`ALTER SCHEDULE 123 SET ??`, `ALTER SCHEDULE`},
+
 		{`ALTER RANGE foo CONFIGURE ??`, `ALTER RANGE`},
 		{`ALTER RANGE ??`, `ALTER RANGE`},
 
@@ -258,6 +264,9 @@ func TestContextualHelp(t *testing.T) {
 		{`REVOKE ALL ON foo FROM ??`, `REVOKE`},
 		{`REVOKE ALL ON foo FROM bar ??`, `REVOKE`},
 
+		{`REVOKE WEB ??`, `REVOKE WEB SESSIONS`},
+		{`REVOKE WEB SESSIONS FOR ??`, `REVOKE WEB SESSIONS`},
+
 		{`SELECT * FROM ??`, `<SOURCE>`},
 		{`SELECT * FROM (??`, `<SOURCE>`}, // not <selectclause>! joins are allowed.
 		{`SELECT * FROM [SHOW ??`, `SHOW`},
@@ -286,15 +295,20 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SHOW QUERIES ??`, `SHOW QUERIES`},
 		{`SHOW LOCAL QUERIES ??`, `SHOW QUERIES`},
+		{`SHOW QUERIES FOR ??`, `SHOW QUERIES`},
 
 		{`SHOW TRACE ??`, `SHOW TRACE`},
 		{`SHOW TRACE FOR SESSION ??`, `SHOW TRACE`},
 		{`SHOW TRACE FOR ??`, `SHOW TRACE`},
 
 		{`SHOW JOB ??`, `SHOW JOBS`},
+		{`SHOW JOB WHEN COMPLETE ??`, `SHOW JOBS`},
 		{`SHOW JOBS ??`, `SHOW JOBS`},
+		{`SHOW JOBS WHEN COMPLETE ??`, `SHOW JOBS`},
 		{`SHOW AUTOMATIC JOBS ??`, `SHOW JOBS`},
 
+		{`SHOW COMPACTIONS ??`, `SHOW COMPACTIONS`},
+
 		{`SHOW SCHEDULE ??`, `SHOW SCHEDULES`},
 		{`SHOW SCHEDULES ??`, `SHOW SCHEDULES`},
 
@@ -303,6 +317,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW CLUSTER SETTING all ??`, `SHOW CLUSTER SETTING`},
 		{`SHOW ALL CLUSTER ??`, `SHOW CLUSTER SETTING`},
 
+		{`SHOW CLUSTER UPGRADE ??`, `SHOW CLUSTER UPGRADE STATUS`},
+
 		{`SHOW COLUMNS FROM ??`, `SHOW COLUMNS`},
 		{`SHOW COLUMNS FROM foo ??`, `SHOW COLUMNS`},
 
@@ -313,6 +329,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW CREATE TABLE blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE VIEW blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE SEQUENCE blah ??`, `SHOW CREATE`},
+		{`SHOW CREATE VIEW blah WITH ORIGINAL ??`, `SHOW CREATE`},
+		{`SHOW CREATE ALL TABLES ??`, `SHOW CREATE`},
 
 		{`SHOW DATABASES ??`, `SHOW DATABASES`},
 
@@ -349,6 +367,7 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SHOW TRANSACTION PRIORITY ??`, `SHOW TRANSACTION`},
 		{`SHOW TRANSACTION STATUS ??`, `SHOW TRANSACTION`},
+		{`SHOW TRANSACTION DETAILS ??`, `SHOW TRANSACTION`},
 		{`SHOW TRANSACTION ISOLATION ??`, `SHOW TRANSACTION`},
 		{`SHOW TRANSACTION ISOLATION LEVEL ??`, `SHOW TRANSACTION`},
 		{`SHOW SYNTAX ??`, `SHOW SYNTAX`},
@@ -361,6 +380,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SHOW USERS ??`, `SHOW USERS`},
 
+		{`SHOW WEB SESSIONS ??`, `SHOW WEB SESSIONS`},
+		{`SHOW ALL WEB SESSIONS ??`, `SHOW WEB SESSIONS`},
+
 		{`TRUNCATE foo ??`, `TRUNCATE`},
 		{`TRUNCATE foo, ??`, `TRUNCATE`},
 
@@ -80,11 +80,15 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 	*lval = l.tokens[l.lastPos]
 
 	switch lval.id {
-	case NOT, WITH, AS, GENERATED, NULLS:
+	case NOT, WITH, AS, GENERATED, NULLS, ON:
 		nextID := int32(0)
 		if l.lastPos+1 < len(l.tokens) {
 			nextID = l.tokens[l.lastPos+1].id
 		}
+		secondID := int32(0)
+		if l.lastPos+2 < len(l.tokens) {
+			secondID = l.tokens[l.lastPos+2].id
+		}
 
 		// If you update these cases, update lex.lookaheadKeywords.
 		switch lval.id {
@@ -114,6 +118,17 @@ func (l *lexer) Lex(lval *sqlSymType) int {
 			case FIRST, LAST:
 				lval.id = NULLS_LA
 			}
+		case ON:
+			// ON UPDATE and ON DELETE followed by a reference action belong to
+			// a foreign key reference, as opposed to a column's ON UPDATE
+			// expression.
+			switch nextID {
+			case UPDATE, DELETE:
+				switch secondID {
+				case NO, RESTRICT, CASCADE, SET:
+					lval.id = ON_LA
+				}
+			}
 		}
 	}
 
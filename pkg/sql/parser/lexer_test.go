@@ -27,6 +27,9 @@ func TestLexer(t *testing.T) {
 		{`NOT IN`, []int{NOT_LA, IN}},
 		{`NOT SIMILAR`, []int{NOT_LA, SIMILAR}},
 		{`AS OF SYSTEM TIME`, []int{AS_LA, OF, SYSTEM, TIME}},
+		{`ON UPDATE CASCADE`, []int{ON_LA, UPDATE, CASCADE}},
+		{`ON DELETE SET NULL`, []int{ON_LA, DELETE, SET, NULL}},
+		{`ON UPDATE now()`, []int{ON, UPDATE, IDENT, '(', ')'}},
 	}
 	for i, d := range testData {
 		s := makeScanner(d.sql)
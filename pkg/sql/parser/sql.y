@@ -209,6 +209,9 @@ func (u *sqlSymUnion) cte() *tree.CTE {
     }
     return nil
 }
+func (u *sqlSymUnion) cycleClause() *tree.CycleClause {
+    return u.val.(*tree.CycleClause)
+}
 func (u *sqlSymUnion) ctes() []*tree.CTE {
     return u.val.([]*tree.CTE)
 }
@@ -464,6 +467,9 @@ func (u *sqlSymUnion) rangePartitions() []tree.RangePartition {
 func (u *sqlSymUnion) setZoneConfig() *tree.SetZoneConfig {
     return u.val.(*tree.SetZoneConfig)
 }
+func (u *sqlSymUnion) showQueries() *tree.ShowQueries {
+    return u.val.(*tree.ShowQueries)
+}
 func (u *sqlSymUnion) tuples() []*tree.Tuple {
     return u.val.([]*tree.Tuple)
 }
@@ -488,12 +494,18 @@ func (u *sqlSymUnion) intervalTypeMetadata() types.IntervalTypeMetadata {
 func (u *sqlSymUnion) kvOption() tree.KVOption {
     return u.val.(tree.KVOption)
 }
+func (u *sqlSymUnion) setVar() *tree.SetVar {
+    return u.val.(*tree.SetVar)
+}
 func (u *sqlSymUnion) kvOptions() []tree.KVOption {
     if colType, ok := u.val.([]tree.KVOption); ok {
         return colType
     }
     return nil
 }
+func (u *sqlSymUnion) batchOptions() *tree.BatchOptions {
+    return u.val.(*tree.BatchOptions)
+}
 func (u *sqlSymUnion) backupOptions() *tree.BackupOptions {
   return u.val.(*tree.BackupOptions)
 }
@@ -503,6 +515,9 @@ func (u *sqlSymUnion) copyOptions() *tree.CopyOptions {
 func (u *sqlSymUnion) restoreOptions() *tree.RestoreOptions {
   return u.val.(*tree.RestoreOptions)
 }
+func (u *sqlSymUnion) showTablesOptions() tree.ShowTablesOptions {
+  return u.val.(tree.ShowTablesOptions)
+}
 func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
     return u.val.(tree.TransactionModes)
 }
@@ -595,26 +610,26 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ACCESS ACTION ADD ADMIN AFFINITY AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE APPLICATION ARRAY AS ASC
 %token <str> ASYMMETRIC AT ATTRIBUTE AUTHORIZATION AUTOMATIC
 
-%token <str> BACKUP BACKUPS BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
+%token <str> BACKUP BACKUPS BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
 %token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
-%token <str> COMMITTED COMPACT COMPLETE CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
-%token <str> CONFLICT CONNECTION CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
+%token <str> COMMITTED COMPACT COMPACTIONS COMPLETE CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
+%token <str> CONFLICT CONNECTION CONSISTENCY CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT DEFAULTS
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DESC DESTINATION DETACHED
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DESC DESTINATION DETACHED DETAILS
+%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP DRY
 
 %token <str> ELSE ENCODING ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
@@ -658,9 +673,9 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> NONE NORMAL NOT NOTHING NOTNULL NOVIEWACTIVITY NOWAIT NULL NULLIF NULLS NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR ON ONLY OPT OPTION OPTIONS OR
-%token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
+%token <str> ORDER ORDINALITY ORIGINAL OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACING
+%token <str> PACING PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PUBLIC PUBLICATION
@@ -671,11 +686,11 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> REGCLASS REGION REGIONAL REGIONS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE REINDEX
 %token <str> REMOVE_PATH RENAME REPEATABLE REPLACE
 %token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING RETRY REVISION_HISTORY REVOKE RIGHT
-%token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE RUNNING
+%token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE RUN RUNNING
 
 %token <str> SAVEPOINT SCATTER SCHEDULE SCHEDULES SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETS SETTING SETTINGS
-%token <str> SHARE SHOW SIMILAR SIMPLE SKIP SKIP_MISSING_FOREIGN_KEYS
+%token <str> SHARE SHOW SIMILAR SIMPLE SIZE SKIP SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 
 %token <str> START STATISTICS STATUS STDIN STRICT STRING STORAGE STORE STORED STORING SUBSTRING
@@ -688,11 +703,11 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> TRACING
 
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED UNSPLIT
-%token <str> UPDATE UPSERT UNTIL USE USER USERS USING UUID
+%token <str> UPDATE UPGRADE UPSERT UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIEWACTIVITY VIRTUAL
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY VIEW VARYING VIEWACTIVITY VIRTUAL
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WEB WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
 %token <str> YEAR
 
@@ -707,8 +722,10 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 // NOT, at least with respect to their left-hand subexpression. WITH_LA is
 // needed to make the grammar LALR(1). GENERATED_ALWAYS is needed to support
 // the Postgres syntax for computed columns along with our family related
-// extensions (CREATE FAMILY/CREATE FAMILY family_name).
-%token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS
+// extensions (CREATE FAMILY/CREATE FAMILY family_name). ON_LA is needed to
+// distinguish the ON UPDATE/ON DELETE reference actions of a foreign key from
+// the ON UPDATE expression of a column.
+%token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS ON_LA
 
 %union {
   id    int32
@@ -730,11 +747,14 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> alter_range_stmt
 %type <tree.Statement> alter_partition_stmt
 %type <tree.Statement> alter_role_stmt
+%type <tree.Statement> alter_job_stmt
+%type <tree.Statement> alter_schedule_stmt
 %type <tree.Statement> alter_type_stmt
 %type <tree.Statement> alter_schema_stmt
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
+%type <tree.Statement> alter_range_verify_consistency_stmt
 
 // ALTER TABLE
 %type <tree.Statement> alter_onetable_stmt
@@ -742,6 +762,8 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> alter_unsplit_stmt
 %type <tree.Statement> alter_rename_table_stmt
 %type <tree.Statement> alter_scatter_stmt
+%type <tree.Statement> alter_verify_consistency_stmt
+%type <tree.Statement> alter_compact_stmt
 %type <tree.Statement> alter_relocate_stmt
 %type <tree.Statement> alter_relocate_lease_stmt
 %type <tree.Statement> alter_zone_table_stmt
@@ -764,6 +786,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 // ALTER INDEX
 %type <tree.Statement> alter_oneindex_stmt
 %type <tree.Statement> alter_scatter_index_stmt
+%type <tree.Statement> alter_compact_index_stmt
 %type <tree.Statement> alter_split_index_stmt
 %type <tree.Statement> alter_unsplit_index_stmt
 %type <tree.Statement> alter_rename_index_stmt
@@ -820,6 +843,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> batched_dml_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -857,6 +881,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.StringOrPlaceholderOptList> string_or_placeholder_opt_list
 %type <[]tree.StringOrPlaceholderOptList> list_of_string_or_placeholder_opt_list
 %type <tree.Statement> revoke_stmt
+%type <tree.Statement> revoke_web_sessions_stmt
 %type <tree.Statement> refresh_stmt
 %type <*tree.Select> select_stmt
 %type <tree.Statement> abort_stmt
@@ -878,6 +903,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> show_constraints_stmt
 %type <tree.Statement> show_create_stmt
 %type <tree.Statement> show_csettings_stmt
+%type <tree.Statement> show_cluster_upgrade_status_stmt
 %type <tree.Statement> show_databases_stmt
 %type <tree.Statement> show_enums_stmt
 %type <tree.Statement> show_fingerprints_stmt
@@ -896,6 +922,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> show_schemas_stmt
 %type <tree.Statement> show_sequences_stmt
 %type <tree.Statement> show_session_stmt
+%type <tree.Statement> show_compactions_stmt
 %type <tree.Statement> show_sessions_stmt
 %type <tree.Statement> show_savepoint_stmt
 %type <tree.Statement> show_stats_stmt
@@ -907,10 +934,12 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> show_transactions_stmt
 %type <tree.Statement> show_types_stmt
 %type <tree.Statement> show_users_stmt
+%type <tree.Statement> show_web_sessions_stmt
 %type <tree.Statement> show_zone_stmt
 %type <tree.Statement> show_schedules_stmt
 
 %type <str> session_var
+%type <str> opt_in_database
 %type <*string> comment_text
 
 %type <tree.Statement> transaction_stmt
@@ -927,6 +956,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.KVOption> kv_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
+%type <*tree.BatchOptions> opt_batch_options_list batch_options_list batch_options
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.CopyOptions> opt_with_copy_options copy_options copy_options_list
 %type <str> import_format
@@ -971,6 +1001,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
 %type <tree.KVOption> role_option password_clause valid_until_clause
+%type <*tree.SetVar> set_or_reset_clause
 %type <tree.Operator> subquery_op
 %type <*tree.UnresolvedName> func_name func_name_no_crdb_extra
 %type <str> opt_class opt_collate
@@ -1042,6 +1073,9 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 
 %type <bool> all_or_distinct
 %type <bool> with_comment
+%type <bool> with_original
+%type <bool> with_privileges
+%type <tree.ShowTablesOptions> opt_show_tables_with show_tables_options show_tables_option
 %type <empty> join_outer
 %type <tree.JoinCond> join_qual
 %type <str> join_type
@@ -1096,7 +1130,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.ComparisonOperator> sub_type
 %type <tree.Expr> numeric_only
 %type <tree.AliasClause> alias_clause opt_alias_clause
-%type <bool> opt_ordinality opt_compact
+%type <bool> opt_ordinality opt_compact opt_dry_run
 %type <*tree.Order> sortby
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
@@ -1111,7 +1145,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.AsOfClause> as_of_clause opt_as_of_clause
 %type <tree.Expr> opt_changefeed_sink
 
-%type <str> explain_option_name
+%type <str> explain_option explain_option_name
 %type <[]string> explain_option_list opt_enum_val_list enum_val_list
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
@@ -1172,6 +1206,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <*tree.With> with_clause opt_with_clause
 %type <[]*tree.CTE> cte_list
 %type <*tree.CTE> common_table_expr
+%type <*tree.CycleClause> opt_cycle_clause
 %type <bool> materialize_clause
 
 %type <tree.Expr> within_group_clause
@@ -1197,6 +1232,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <str> relocate_kw
 
 %type <*tree.SetZoneConfig> set_zone_config
+%type <*tree.ShowQueries> opt_show_queries_filter
 
 %type <tree.Expr> opt_alter_column_using
 
@@ -1294,6 +1330,7 @@ stmt:
 | grant_stmt                // EXTEND WITH HELP: GRANT
 | prepare_stmt              // EXTEND WITH HELP: PREPARE
 | revoke_stmt               // EXTEND WITH HELP: REVOKE
+| revoke_web_sessions_stmt  // EXTEND WITH HELP: REVOKE WEB SESSIONS
 | savepoint_stmt            // EXTEND WITH HELP: SAVEPOINT
 | reassign_owned_by_stmt    // EXTEND WITH HELP: REASSIGN OWNED BY
 | drop_owned_by_stmt        // EXTEND WITH HELP: DROP OWNED BY
@@ -1311,10 +1348,12 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE, ALTER JOB, ALTER SCHEDULE
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
+| alter_job_stmt      // EXTEND WITH HELP: ALTER JOB
+| alter_schedule_stmt // EXTEND WITH HELP: ALTER SCHEDULE
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1350,6 +1389,8 @@ alter_ddl_stmt:
 //   ALTER TABLE ... UNSPLIT AT <selectclause>
 //   ALTER TABLE ... UNSPLIT ALL
 //   ALTER TABLE ... SCATTER [ FROM ( <exprs...> ) TO ( <exprs...> ) ]
+//   ALTER TABLE ... EXPERIMENTAL VERIFY CONSISTENCY
+//   ALTER TABLE ... EXPERIMENTAL COMPACT
 //   ALTER TABLE ... INJECT STATISTICS ...  (experimental)
 //   ALTER TABLE ... PARTITION BY RANGE ( <name...> ) ( <rangespec> )
 //   ALTER TABLE ... PARTITION BY LIST ( <name...> ) ( <listspec> )
@@ -1363,6 +1404,7 @@ alter_ddl_stmt:
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )]
 //   COLLATE <collationname>
+//   ON UPDATE <expr>
 //
 // Zone configurations:
 //   DISCARD
@@ -1378,6 +1420,8 @@ alter_table_stmt:
 | alter_split_stmt
 | alter_unsplit_stmt
 | alter_scatter_stmt
+| alter_verify_consistency_stmt
+| alter_compact_stmt
 | alter_zone_table_stmt
 | alter_rename_table_stmt
 | alter_table_set_schema_stmt
@@ -1522,6 +1566,7 @@ alter_database_primary_region_stmt:
 // %Category: DDL
 // %Text:
 // ALTER RANGE <zonename> <command>
+// ALTER RANGE <rangeid> EXPERIMENTAL VERIFY CONSISTENCY
 //
 // Commands:
 //   ALTER RANGE ... CONFIGURE ZONE <zoneconfig>
@@ -1535,6 +1580,7 @@ alter_database_primary_region_stmt:
 // %SeeAlso: ALTER TABLE
 alter_range_stmt:
   alter_zone_range_stmt
+| alter_range_verify_consistency_stmt
 | ALTER RANGE error // SHOW HELP: ALTER RANGE
 
 // %Help: ALTER INDEX - change the definition of an index
@@ -1548,6 +1594,7 @@ alter_range_stmt:
 //   ALTER INDEX ... UNSPLIT AT <selectclause>
 //   ALTER INDEX ... UNSPLIT ALL
 //   ALTER INDEX ... SCATTER [ FROM ( <exprs...> ) TO ( <exprs...> ) ]
+//   ALTER INDEX ... EXPERIMENTAL COMPACT
 //
 // Zone configurations:
 //   DISCARD
@@ -1563,6 +1610,7 @@ alter_index_stmt:
 | alter_split_index_stmt
 | alter_unsplit_index_stmt
 | alter_scatter_index_stmt
+| alter_compact_index_stmt
 | alter_rename_index_stmt
 | alter_zone_index_stmt
 // ALTER INDEX has its error help token here because the ALTER INDEX
@@ -1823,6 +1871,28 @@ alter_scatter_stmt:
     }
   }
 
+alter_verify_consistency_stmt:
+  ALTER TABLE table_name EXPERIMENTAL VERIFY CONSISTENCY
+  {
+    /* SKIP DOC */
+    $$.val = &tree.VerifyConsistency{Table: $3.unresolvedObjectName()}
+  }
+
+alter_compact_stmt:
+  ALTER TABLE table_name EXPERIMENTAL COMPACT
+  {
+    /* SKIP DOC */
+    name := $3.unresolvedObjectName().ToTableName()
+    $$.val = &tree.Compact{TableOrIndex: tree.TableIndexName{Table: name}}
+  }
+
+alter_range_verify_consistency_stmt:
+  ALTER RANGE iconst64 EXPERIMENTAL VERIFY CONSISTENCY
+  {
+    /* SKIP DOC */
+    $$.val = &tree.VerifyConsistency{RangeID: $3.int64()}
+  }
+
 alter_scatter_index_stmt:
   ALTER INDEX table_index_name SCATTER
   {
@@ -1833,6 +1903,13 @@ alter_scatter_index_stmt:
     $$.val = &tree.Scatter{TableOrIndex: $3.tableIndexName(), From: $7.exprs(), To: $11.exprs()}
   }
 
+alter_compact_index_stmt:
+  ALTER INDEX table_index_name EXPERIMENTAL COMPACT
+  {
+    /* SKIP DOC */
+    $$.val = &tree.Compact{TableOrIndex: $3.tableIndexName()}
+  }
+
 alter_table_cmds:
   alter_table_cmd
   {
@@ -2066,6 +2143,16 @@ opt_drop_behavior:
     $$.val = tree.DropDefault
   }
 
+opt_dry_run:
+  DRY RUN
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 opt_validate_behavior:
   NOT VALID
   {
@@ -3308,6 +3395,11 @@ opt_changefeed_sink:
 //               [ORDER BY <exprs...>]
 //               [LIMIT <expr>]
 //               [RETURNING <exprs...>]
+//               [WITH BATCH [<batch_option> [, ...]]]
+//
+// Batch options:
+//    SIZE <expr>     the maximum number of rows deleted per transaction
+//    PACING <expr>   the delay between consecutive batches (an interval)
 // %SeeAlso: WEBDOCS/delete.html
 delete_stmt:
   opt_with_clause DELETE FROM table_expr_opt_alias_idx opt_using_clause opt_where_clause opt_sort_clause opt_limit_clause returning_clause
@@ -3323,6 +3415,51 @@ delete_stmt:
   }
 | opt_with_clause DELETE error // SHOW HELP: DELETE
 
+// batched_dml_stmt is a DELETE or UPDATE statement that is executed by a job
+// as a sequence of bounded transactions instead of a single transaction.
+batched_dml_stmt:
+  delete_stmt WITH BATCH opt_batch_options_list
+  {
+    $$.val = &tree.BatchedDML{Statement: $1.stmt(), Options: *$4.batchOptions()}
+  }
+| update_stmt WITH BATCH opt_batch_options_list
+  {
+    $$.val = &tree.BatchedDML{Statement: $1.stmt(), Options: *$4.batchOptions()}
+  }
+
+opt_batch_options_list:
+  batch_options_list
+  {
+    $$.val = $1.batchOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.BatchOptions{}
+  }
+
+batch_options_list:
+  batch_options
+  {
+    $$.val = $1.batchOptions()
+  }
+| batch_options_list ',' batch_options
+  {
+    if err := $1.batchOptions().CombineWith($3.batchOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+// List of valid batch options.
+batch_options:
+  SIZE a_expr
+  {
+    $$.val = &tree.BatchOptions{Size: $2.expr()}
+  }
+| PACING a_expr
+  {
+    $$.val = &tree.BatchOptions{Pacing: $2.expr()}
+  }
+
 opt_using_clause:
   USING from_list { return unimplementedWithIssueDetail(sqllex, 40963, "delete using") }
 | /* EMPTY */ { }
@@ -3413,16 +3550,16 @@ drop_sequence_stmt:
 
 // %Help: DROP TABLE - remove a table
 // %Category: DDL
-// %Text: DROP TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT] [DRY RUN]
 // %SeeAlso: WEBDOCS/drop-table.html
 drop_table_stmt:
-  DROP TABLE table_name_list opt_drop_behavior
+  DROP TABLE table_name_list opt_drop_behavior opt_dry_run
   {
-    $$.val = &tree.DropTable{Names: $3.tableNames(), IfExists: false, DropBehavior: $4.dropBehavior()}
+    $$.val = &tree.DropTable{Names: $3.tableNames(), IfExists: false, DropBehavior: $4.dropBehavior(), DryRun: $5.bool()}
   }
-| DROP TABLE IF EXISTS table_name_list opt_drop_behavior
+| DROP TABLE IF EXISTS table_name_list opt_drop_behavior opt_dry_run
   {
-    $$.val = &tree.DropTable{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
+    $$.val = &tree.DropTable{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior(), DryRun: $7.bool()}
   }
 | DROP TABLE error // SHOW HELP: DROP TABLE
 
@@ -3453,23 +3590,25 @@ drop_index_stmt:
 
 // %Help: DROP DATABASE - remove a database
 // %Category: DDL
-// %Text: DROP DATABASE [IF EXISTS] <databasename> [CASCADE | RESTRICT]
+// %Text: DROP DATABASE [IF EXISTS] <databasename> [CASCADE | RESTRICT] [DRY RUN]
 // %SeeAlso: WEBDOCS/drop-database.html
 drop_database_stmt:
-  DROP DATABASE database_name opt_drop_behavior
+  DROP DATABASE database_name opt_drop_behavior opt_dry_run
   {
     $$.val = &tree.DropDatabase{
       Name: tree.Name($3),
       IfExists: false,
       DropBehavior: $4.dropBehavior(),
+      DryRun: $5.bool(),
     }
   }
-| DROP DATABASE IF EXISTS database_name opt_drop_behavior
+| DROP DATABASE IF EXISTS database_name opt_drop_behavior opt_dry_run
   {
     $$.val = &tree.DropDatabase{
       Name: tree.Name($5),
       IfExists: true,
       DropBehavior: $6.dropBehavior(),
+      DryRun: $7.bool(),
     }
   }
 | DROP DATABASE error // SHOW HELP: DROP DATABASE
@@ -3595,6 +3734,7 @@ analyze_target:
 // EXPLAIN <statement>
 // EXPLAIN ([PLAN ,] <planoptions...> ) <statement>
 // EXPLAIN (DISTSQL) <statement>
+// EXPLAIN (DDL) <schema change statement>
 // EXPLAIN ANALYZE [(DISTSQL)] <statement>
 // EXPLAIN ANALYZE (PLAN <planoptions...>) <statement>
 //
@@ -3603,7 +3743,7 @@ analyze_target:
 //     SHOW, EXPLAIN
 //
 // Plan options:
-//     TYPES, VERBOSE, OPT
+//     TYPES, VERBOSE, OPT, FORMAT { TEXT | JSON | DOT }
 //
 // %SeeAlso: WEBDOCS/explain.html
 explain_stmt:
@@ -3665,6 +3805,7 @@ explain_stmt:
 preparable_stmt:
   alter_stmt     // help texts in sub-rule
 | backup_stmt    // EXTEND WITH HELP: BACKUP
+| batched_dml_stmt // help texts in sub-rule
 | cancel_stmt    // help texts in sub-rule
 | create_stmt    // help texts in sub-rule
 | delete_stmt    // EXTEND WITH HELP: DELETE
@@ -3703,15 +3844,24 @@ row_source_extension_stmt:
 | upsert_stmt       // EXTEND WITH HELP: UPSERT
 
 explain_option_list:
-  explain_option_name
+  explain_option
   {
     $$.val = []string{$1}
   }
-| explain_option_list ',' explain_option_name
+| explain_option_list ',' explain_option
   {
     $$.val = append($1.strs(), $3)
   }
 
+// An explain option is either a single word, like VERBOSE, or a name followed
+// by a value, like FORMAT JSON.
+explain_option:
+  explain_option_name
+| explain_option_name non_reserved_word
+  {
+    $$ = $1 + " " + $2
+  }
+
 // %Help: PREPARE - prepare a statement for later execution
 // %Category: Misc
 // %Text: PREPARE <name> [ ( <types...> ) ] AS <query>
@@ -3896,6 +4046,26 @@ revoke_stmt:
   }
 | REVOKE error // SHOW HELP: REVOKE
 
+// %Help: REVOKE WEB SESSIONS - revoke DB Console login sessions
+// %Category: Priv
+// %Text:
+// REVOKE WEB SESSION <sessionid>
+// REVOKE WEB SESSIONS FOR USER <name>
+//
+// Revoked sessions can no longer be used to access the DB Console and the
+// HTTP APIs. Users without the admin role can only revoke their own sessions.
+// %SeeAlso: SHOW WEB SESSIONS
+revoke_web_sessions_stmt:
+  REVOKE WEB SESSION a_expr
+  {
+    $$.val = &tree.RevokeWebSessions{Session: $4.expr()}
+  }
+| REVOKE WEB SESSIONS FOR USER role_spec
+  {
+    $$.val = &tree.RevokeWebSessions{User: $6.user()}
+  }
+| REVOKE WEB error // SHOW HELP: REVOKE WEB SESSIONS
+
 // ALL can either be by itself, or with the optional PRIVILEGES keyword (which no-ops)
 privileges:
   ALL opt_privileges_clause
@@ -4345,18 +4515,20 @@ zone_value:
 // %Category: Group
 // %Text:
 // SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
-// SHOW CREATE, SHOW DATABASES, SHOW ENUMS, SHOW HISTOGRAM, SHOW INDEXES, SHOW
+// SHOW CLUSTER UPGRADE STATUS, SHOW CREATE, SHOW DATABASES, SHOW ENUMS, SHOW HISTOGRAM, SHOW INDEXES, SHOW
 // PARTITIONS, SHOW JOBS, SHOW QUERIES, SHOW RANGE, SHOW RANGES, SHOW REGIONS, SHOW SURVIVAL GOAL,
 // SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
 // SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE, SHOW TRANSACTION,
 // SHOW TRANSACTIONS, SHOW TYPES, SHOW USERS, SHOW LAST QUERY STATISTICS, SHOW SCHEDULES,
-// SHOW LOCALITY
+// SHOW LOCALITY, SHOW COMPACTIONS, SHOW WEB SESSIONS
 show_stmt:
   show_backup_stmt          // EXTEND WITH HELP: SHOW BACKUP
 | show_columns_stmt         // EXTEND WITH HELP: SHOW COLUMNS
+| show_compactions_stmt     // EXTEND WITH HELP: SHOW COMPACTIONS
 | show_constraints_stmt     // EXTEND WITH HELP: SHOW CONSTRAINTS
 | show_create_stmt          // EXTEND WITH HELP: SHOW CREATE
 | show_csettings_stmt       // EXTEND WITH HELP: SHOW CLUSTER SETTING
+| show_cluster_upgrade_status_stmt // EXTEND WITH HELP: SHOW CLUSTER UPGRADE STATUS
 | show_databases_stmt       // EXTEND WITH HELP: SHOW DATABASES
 | show_enums_stmt           // EXTEND WITH HELP: SHOW ENUMS
 | show_types_stmt           // EXTEND WITH HELP: SHOW TYPES
@@ -4386,6 +4558,7 @@ show_stmt:
 | show_transaction_stmt     // EXTEND WITH HELP: SHOW TRANSACTION
 | show_transactions_stmt    // EXTEND WITH HELP: SHOW TRANSACTIONS
 | show_users_stmt           // EXTEND WITH HELP: SHOW USERS
+| show_web_sessions_stmt    // EXTEND WITH HELP: SHOW WEB SESSIONS
 | show_zone_stmt
 | SHOW error                // SHOW HELP: SHOW
 | show_last_query_stats_stmt
@@ -4579,6 +4752,21 @@ show_csettings_stmt:
   }
 | SHOW PUBLIC CLUSTER error // SHOW HELP: SHOW CLUSTER SETTING
 
+// %Help: SHOW CLUSTER UPGRADE STATUS - display the progress of cluster upgrades
+// %Category: Cfg
+// %Text: SHOW CLUSTER UPGRADE STATUS
+//
+// Displays the cluster version, the binary version of each node, the upgrade
+// steps left to finalize the cluster version, and whether the automatic
+// finalization of the upgrade is blocked.
+// %SeeAlso: SHOW CLUSTER SETTING, WEBDOCS/upgrade-cockroach-version.html
+show_cluster_upgrade_status_stmt:
+  SHOW CLUSTER UPGRADE STATUS
+  {
+    $$.val = &tree.ShowClusterUpgradeStatus{}
+  }
+| SHOW CLUSTER UPGRADE error // SHOW HELP: SHOW CLUSTER UPGRADE STATUS
+
 // %Help: SHOW COLUMNS - list columns in relation
 // %Category: DDL
 // %Text: SHOW COLUMNS FROM <tablename>
@@ -4736,20 +4924,45 @@ show_constraints_stmt:
 
 // %Help: SHOW QUERIES - list running queries
 // %Category: Misc
-// %Text: SHOW [ALL] [CLUSTER | LOCAL] QUERIES
+// %Text: SHOW [ALL] [CLUSTER | LOCAL] QUERIES [FOR USER <user>] [FOR APPLICATION <name>]
 // %SeeAlso: CANCEL QUERIES
 show_queries_stmt:
-  SHOW opt_cluster QUERIES
+  SHOW opt_cluster QUERIES opt_show_queries_filter
   {
-    $$.val = &tree.ShowQueries{All: false, Cluster: $2.bool()}
+    s := $4.showQueries()
+    s.Cluster = $2.bool()
+    $$.val = s
   }
 | SHOW opt_cluster QUERIES error // SHOW HELP: SHOW QUERIES
-| SHOW ALL opt_cluster QUERIES
+| SHOW ALL opt_cluster QUERIES opt_show_queries_filter
   {
-    $$.val = &tree.ShowQueries{All: true, Cluster: $3.bool()}
+    s := $5.showQueries()
+    s.All = true
+    s.Cluster = $3.bool()
+    $$.val = s
   }
 | SHOW ALL opt_cluster QUERIES error // SHOW HELP: SHOW QUERIES
 
+opt_show_queries_filter:
+  FOR USER role_spec
+  {
+    $$.val = &tree.ShowQueries{User: $3.user()}
+  }
+| FOR APPLICATION SCONST
+  {
+    app := $3
+    $$.val = &tree.ShowQueries{Application: &app}
+  }
+| FOR USER role_spec FOR APPLICATION SCONST
+  {
+    app := $6
+    $$.val = &tree.ShowQueries{User: $3.user(), Application: &app}
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.ShowQueries{}
+  }
+
 opt_cluster:
   /* EMPTY */
   { $$.val = true }
@@ -4762,8 +4975,11 @@ opt_cluster:
 // %Category: Misc
 // %Text:
 // SHOW [AUTOMATIC] JOBS [select clause]
+// SHOW JOBS <select clause> WITH DETAILS
 // SHOW JOBS FOR SCHEDULES [select clause]
-// SHOW JOB <jobid>
+// SHOW JOBS WHEN COMPLETE <select clause>
+// SHOW JOB <jobid> [WITH DETAILS]
+// SHOW JOB WHEN COMPLETE <jobid>
 // %SeeAlso: CANCEL JOBS, PAUSE JOBS, RESUME JOBS
 show_jobs_stmt:
   SHOW AUTOMATIC JOBS
@@ -4780,6 +4996,10 @@ show_jobs_stmt:
   {
     $$.val = &tree.ShowJobs{Jobs: $3.slct()}
   }
+| SHOW JOBS select_stmt WITH DETAILS
+  {
+    $$.val = &tree.ShowJobs{Jobs: $3.slct(), Details: true}
+  }
 | SHOW JOBS WHEN COMPLETE select_stmt
   {
     $$.val = &tree.ShowJobs{Jobs: $5.slct(), Block: true}
@@ -4797,6 +5017,15 @@ show_jobs_stmt:
       },
     }
   }
+| SHOW JOB a_expr WITH DETAILS
+  {
+    $$.val = &tree.ShowJobs{
+      Jobs: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Details: true,
+    }
+  }
 | SHOW JOB WHEN COMPLETE a_expr
   {
     $$.val = &tree.ShowJobs{
@@ -4887,6 +5116,17 @@ opt_compact:
   COMPACT { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
+// %Help: SHOW COMPACTIONS - list ongoing manual compactions
+// %Category: Misc
+// %Text: SHOW COMPACTIONS
+// %SeeAlso: ALTER TABLE, SHOW JOBS
+show_compactions_stmt:
+  SHOW COMPACTIONS
+  {
+    $$.val = &tree.ShowCompactions{}
+  }
+| SHOW COMPACTIONS error // SHOW HELP: SHOW COMPACTIONS
+
 // %Help: SHOW SESSIONS - list open client sessions
 // %Category: Misc
 // %Text: SHOW [ALL] [CLUSTER | LOCAL] SESSIONS
@@ -4905,10 +5145,10 @@ show_sessions_stmt:
 
 // %Help: SHOW TABLES - list tables
 // %Category: DDL
-// %Text: SHOW TABLES [FROM <databasename> [ . <schemaname> ] ] [WITH COMMENT]
+// %Text: SHOW TABLES [FROM <databasename> [ . <schemaname> ] ] [WITH { COMMENT | DETAILS } [, ...]]
 // %SeeAlso: WEBDOCS/show-tables.html
 show_tables_stmt:
-  SHOW TABLES FROM name '.' name with_comment
+  SHOW TABLES FROM name '.' name opt_show_tables_with
   {
     $$.val = &tree.ShowTables{ObjectNamePrefix:tree.ObjectNamePrefix{
         CatalogName: tree.Name($4),
@@ -4916,9 +5156,9 @@ show_tables_stmt:
         SchemaName: tree.Name($6),
         ExplicitSchema: true,
     },
-    WithComment: $7.bool()}
+    ShowTablesOptions: $7.showTablesOptions()}
   }
-| SHOW TABLES FROM name with_comment
+| SHOW TABLES FROM name opt_show_tables_with
   {
     $$.val = &tree.ShowTables{ObjectNamePrefix:tree.ObjectNamePrefix{
         // Note: the schema name may be interpreted as database name,
@@ -4926,14 +5166,45 @@ show_tables_stmt:
         SchemaName: tree.Name($4),
         ExplicitSchema: true,
     },
-    WithComment: $5.bool()}
+    ShowTablesOptions: $5.showTablesOptions()}
   }
-| SHOW TABLES with_comment
+| SHOW TABLES opt_show_tables_with
   {
-    $$.val = &tree.ShowTables{WithComment: $3.bool()}
+    $$.val = &tree.ShowTables{ShowTablesOptions: $3.showTablesOptions()}
   }
 | SHOW TABLES error // SHOW HELP: SHOW TABLES
 
+opt_show_tables_with:
+  WITH show_tables_options
+  {
+    $$.val = $2.showTablesOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ShowTablesOptions{}
+  }
+
+show_tables_options:
+  show_tables_option
+| show_tables_options ',' show_tables_option
+  {
+    a, b := $1.showTablesOptions(), $3.showTablesOptions()
+    $$.val = tree.ShowTablesOptions{
+      WithComment: a.WithComment || b.WithComment,
+      WithDetails: a.WithDetails || b.WithDetails,
+    }
+  }
+
+show_tables_option:
+  COMMENT
+  {
+    $$.val = tree.ShowTablesOptions{WithComment: true}
+  }
+| DETAILS
+  {
+    $$.val = tree.ShowTablesOptions{WithDetails: true}
+  }
+
 // %Help: SHOW TRANSACTIONS - list open client transactions across the cluster
 // %Category: Misc
 // %Text: SHOW [ALL] [CLUSTER | LOCAL] TRANSACTIONS
@@ -4953,17 +5224,25 @@ with_comment:
   WITH COMMENT { $$.val = true }
 | /* EMPTY */  { $$.val = false }
 
+with_original:
+  WITH ORIGINAL { $$.val = true }
+| /* EMPTY */   { $$.val = false }
+
+with_privileges:
+  WITH PRIVILEGES { $$.val = true }
+| /* EMPTY */     { $$.val = false }
+
 // %Help: SHOW SCHEMAS - list schemas
 // %Category: DDL
-// %Text: SHOW SCHEMAS [FROM <databasename> ]
+// %Text: SHOW SCHEMAS [FROM <databasename> ] [WITH PRIVILEGES]
 show_schemas_stmt:
-  SHOW SCHEMAS FROM name
+  SHOW SCHEMAS FROM name with_privileges
   {
-    $$.val = &tree.ShowSchemas{Database: tree.Name($4)}
+    $$.val = &tree.ShowSchemas{Database: tree.Name($4), WithPrivileges: $5.bool()}
   }
-| SHOW SCHEMAS
+| SHOW SCHEMAS with_privileges
   {
-    $$.val = &tree.ShowSchemas{}
+    $$.val = &tree.ShowSchemas{WithPrivileges: $3.bool()}
   }
 | SHOW SCHEMAS error // SHOW HELP: SHOW SCHEMAS
 
@@ -5002,6 +5281,7 @@ show_last_query_stats_stmt:
 // %Help: SHOW SAVEPOINT - display current savepoint properties
 // %Category: Cfg
 // %Text: SHOW SAVEPOINT STATUS
+// %SeeAlso: SHOW TRANSACTION
 show_savepoint_stmt:
   SHOW SAVEPOINT STATUS
   {
@@ -5011,8 +5291,8 @@ show_savepoint_stmt:
 
 // %Help: SHOW TRANSACTION - display current transaction properties
 // %Category: Cfg
-// %Text: SHOW TRANSACTION {ISOLATION LEVEL | PRIORITY | STATUS}
-// %SeeAlso: WEBDOCS/show-transaction.html
+// %Text: SHOW TRANSACTION {ISOLATION LEVEL | PRIORITY | STATUS | DETAILS}
+// %SeeAlso: SHOW SAVEPOINT, WEBDOCS/show-transaction.html
 show_transaction_stmt:
   SHOW TRANSACTION ISOLATION LEVEL
   {
@@ -5029,21 +5309,36 @@ show_transaction_stmt:
     /* SKIP DOC */
     $$.val = &tree.ShowTransactionStatus{}
   }
+| SHOW TRANSACTION DETAILS
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTransactionDetails{}
+  }
 | SHOW TRANSACTION error // SHOW HELP: SHOW TRANSACTION
 
 // %Help: SHOW CREATE - display the CREATE statement for a table, sequence or view
 // %Category: DDL
-// %Text: SHOW CREATE [ TABLE | SEQUENCE | VIEW ] <tablename>
+// %Text:
+// SHOW CREATE [ TABLE | SEQUENCE | VIEW ] <tablename>
+// SHOW CREATE [ VIEW ] <viewname> WITH ORIGINAL
+// SHOW CREATE ALL TABLES
+//
+// WITH ORIGINAL shows the CREATE VIEW statement of a view as it was
+// originally typed, including its formatting and comments.
 // %SeeAlso: WEBDOCS/show-create-table.html
 show_create_stmt:
-  SHOW CREATE table_name
+  SHOW CREATE table_name with_original
   {
-    $$.val = &tree.ShowCreate{Name: $3.unresolvedObjectName()}
+    $$.val = &tree.ShowCreate{Name: $3.unresolvedObjectName(), Original: $4.bool()}
   }
-| SHOW CREATE create_kw table_name
+| SHOW CREATE create_kw table_name with_original
   {
     /* SKIP DOC */
-    $$.val = &tree.ShowCreate{Name: $4.unresolvedObjectName()}
+    $$.val = &tree.ShowCreate{Name: $4.unresolvedObjectName(), Original: $5.bool()}
+  }
+| SHOW CREATE ALL TABLES
+  {
+    $$.val = &tree.ShowCreateAllTables{}
   }
 | SHOW CREATE error // SHOW HELP: SHOW CREATE
 
@@ -5063,6 +5358,26 @@ show_users_stmt:
   }
 | SHOW USERS error // SHOW HELP: SHOW USERS
 
+// %Help: SHOW WEB SESSIONS - list DB Console login sessions
+// %Category: Priv
+// %Text: SHOW [ALL] WEB SESSIONS
+//
+// Lists the sessions used to log into the DB Console and the HTTP APIs.
+// Expired and revoked sessions are only listed if ALL is specified. Users
+// without the admin role can only see their own sessions.
+// %SeeAlso: REVOKE WEB SESSIONS
+show_web_sessions_stmt:
+  SHOW WEB SESSIONS
+  {
+    $$.val = &tree.ShowWebSessions{}
+  }
+| SHOW WEB SESSIONS error // SHOW HELP: SHOW WEB SESSIONS
+| SHOW ALL WEB SESSIONS
+  {
+    $$.val = &tree.ShowWebSessions{All: true}
+  }
+| SHOW ALL WEB SESSIONS error // SHOW HELP: SHOW WEB SESSIONS
+
 // %Help: SHOW ROLES - list defined roles
 // %Category: Priv
 // %Text: SHOW ROLES
@@ -5637,6 +5952,7 @@ alter_schema_stmt:
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //   COLLATE <collationname>
+//   ON UPDATE <expr>
 //   AS ( <expr> ) STORED
 //
 // Interleave clause:
@@ -5887,7 +6203,7 @@ like_table_option_list:
   }
 
 like_table_option:
-  COMMENTS			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding comments") }
+  COMMENTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptComments} }
 | CONSTRAINTS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptConstraints} }
 | DEFAULTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptDefaults} }
 | IDENTITY	  	{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding identity") }
@@ -6111,6 +6427,10 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnDefault{Expr: $2.expr()}
   }
+| ON UPDATE b_expr
+  {
+    $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
+  }
 | REFERENCES table_name opt_name_parens key_match reference_actions
  {
     name := $2.unresolvedObjectName().ToTableName()
@@ -6222,16 +6542,17 @@ constraint_elem:
     }
   }
 | UNIQUE opt_without_index '(' index_params ')'
-    opt_storing opt_interleave opt_partition_by opt_deferrable opt_where_clause
+    opt_hash_sharded opt_storing opt_interleave opt_partition_by opt_deferrable opt_where_clause
   {
     $$.val = &tree.UniqueConstraintTableDef{
       WithoutIndex: $2.bool(),
       IndexTableDef: tree.IndexTableDef{
         Columns: $4.idxElems(),
-        Storing: $6.nameList(),
-        Interleave: $7.interleave(),
-        PartitionBy: $8.partitionBy(),
-        Predicate: $10.expr(),
+        Sharded: $6.shardedIndexDef(),
+        Storing: $7.nameList(),
+        Interleave: $8.interleave(),
+        PartitionBy: $9.partitionBy(),
+        Predicate: $11.expr(),
       },
     }
   }
@@ -6282,6 +6603,16 @@ create_as_table_defs:
       return setErr(sqllex, err)
     }
 
+    var colToTableDef tree.TableDef = tableDef
+    $$.val = tree.TableDefs{colToTableDef}
+  }
+| column_name typename create_as_col_qual_list
+  {
+    tableDef, err := tree.NewColumnTableDef(tree.Name($1), $2.typeReference(), false, $3.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+
     var colToTableDef tree.TableDef = tableDef
     $$.val = tree.TableDefs{colToTableDef}
   }
@@ -6294,6 +6625,17 @@ create_as_table_defs:
 
     var colToTableDef tree.TableDef = tableDef
 
+    $$.val = append($1.tblDefs(), colToTableDef)
+  }
+| create_as_table_defs ',' column_name typename create_as_col_qual_list
+  {
+    tableDef, err := tree.NewColumnTableDef(tree.Name($3), $4.typeReference(), false, $5.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+
+    var colToTableDef tree.TableDef = tableDef
+
     $$.val = append($1.tblDefs(), colToTableDef)
   }
 | create_as_table_defs ',' family_def
@@ -6477,13 +6819,13 @@ reference_actions:
   }
 
 reference_on_update:
-  ON UPDATE reference_action
+  ON_LA UPDATE reference_action
   {
     $$.val = $3.referenceAction()
   }
 
 reference_on_delete:
-  ON DELETE reference_action
+  ON_LA DELETE reference_action
   {
     $$.val = $3.referenceAction()
   }
@@ -6600,6 +6942,16 @@ truncate_stmt:
   }
 | TRUNCATE error // SHOW HELP: TRUNCATE
 
+set_or_reset_clause:
+  SET var_name to_or_eq var_list
+  {
+    $$.val = &tree.SetVar{Name: strings.Join($2.strs(), "."), Values: $4.exprs()}
+  }
+| RESET session_var
+  {
+    $$.val = &tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}}
+  }
+
 password_clause:
   PASSWORD string_or_placeholder
   {
@@ -6627,7 +6979,10 @@ create_role_stmt:
 
 // %Help: ALTER ROLE - alter a role
 // %Category: Priv
-// %Text: ALTER ROLE <name> [WITH] <options...>
+// %Text:
+// ALTER ROLE <name> [WITH] <options...>
+// ALTER ROLE { <name> | ALL } [ IN DATABASE <database_name> ] SET <var> { TO | = } <value>
+// ALTER ROLE { <name> | ALL } [ IN DATABASE <database_name> ] RESET { <var> | ALL }
 // %SeeAlso: CREATE ROLE, DROP ROLE, SHOW ROLES
 alter_role_stmt:
   ALTER role_or_group_or_user string_or_placeholder opt_role_options
@@ -6638,8 +6993,30 @@ alter_role_stmt:
 {
   $$.val = &tree.AlterRole{Name: $5.expr(), IfExists: true, KVOptions: $6.kvOptions(), IsRole: $2.bool()}
 }
+| ALTER role_or_group_or_user string_or_placeholder opt_in_database set_or_reset_clause
+{
+  $$.val = &tree.AlterRoleSet{Name: $3.expr(), IsRole: $2.bool(), DatabaseName: tree.Name($4), SetOrReset: $5.setVar()}
+}
+| ALTER role_or_group_or_user IF EXISTS string_or_placeholder opt_in_database set_or_reset_clause
+{
+  $$.val = &tree.AlterRoleSet{Name: $5.expr(), IfExists: true, IsRole: $2.bool(), DatabaseName: tree.Name($6), SetOrReset: $7.setVar()}
+}
+| ALTER role_or_group_or_user ALL opt_in_database set_or_reset_clause
+{
+  $$.val = &tree.AlterRoleSet{AllRoles: true, IsRole: $2.bool(), DatabaseName: tree.Name($4), SetOrReset: $5.setVar()}
+}
 | ALTER role_or_group_or_user error // SHOW HELP: ALTER ROLE
 
+opt_in_database:
+  IN DATABASE database_name
+  {
+    $$ = $3
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
 // "CREATE GROUP is now an alias for CREATE ROLE"
 // https://www.postgresql.org/docs/10/static/sql-creategroup.html
 role_or_group_or_user:
@@ -7394,6 +7771,42 @@ release_stmt:
   }
 | RELEASE error // SHOW HELP: RELEASE
 
+// %Help: ALTER JOB - change the settings of a background job
+// %Category: Misc
+// %Text:
+// ALTER JOB <jobid> SET <option> = <value> [, ...]
+//
+// Options:
+//   backfill_batch_size = '<rows>'
+//   backfill_max_rows_per_second = '<rows>'
+//
+// The options apply to schema change jobs. A value of '0' restores the
+// default behavior.
+//
+// %SeeAlso: SHOW JOBS, PAUSE JOBS, RESUME JOBS
+alter_job_stmt:
+  ALTER JOB a_expr SET kv_option_list
+  {
+    $$.val = &tree.AlterJob{Job: $3.expr(), Options: $5.kvOptions()}
+  }
+| ALTER JOB error // SHOW HELP: ALTER JOB
+
+// %Help: ALTER SCHEDULE - change the recurrence of a schedule
+// %Category: Misc
+// %Text:
+// ALTER SCHEDULE <scheduleid> SET RECURRING '<cron expression>'
+//
+// The next run of the schedule is recomputed from the new recurrence, unless
+// the schedule is paused.
+//
+// %SeeAlso: SHOW SCHEDULES, PAUSE SCHEDULES, RESUME SCHEDULES
+alter_schedule_stmt:
+  ALTER SCHEDULE a_expr SET cron_expr
+  {
+    $$.val = &tree.AlterSchedule{Schedule: $3.expr(), Recurrence: $5.expr()}
+  }
+| ALTER SCHEDULE error // SHOW HELP: ALTER SCHEDULE
+
 // %Help: RESUME JOBS - resume background jobs
 // %Category: Misc
 // %Text:
@@ -7968,6 +8381,11 @@ returning_clause:
 //        [ORDER BY <exprs...>]
 //        [LIMIT <expr>]
 //        [RETURNING <exprs...>]
+//        [WITH BATCH [<batch_option> [, ...]]]
+//
+// Batch options:
+//    SIZE <expr>     the maximum number of rows updated per transaction
+//    PACING <expr>   the delay between consecutive batches (an interval)
 // %SeeAlso: INSERT, UPSERT, DELETE, WEBDOCS/update.html
 update_stmt:
   opt_with_clause UPDATE table_expr_opt_alias_idx
@@ -8345,7 +8763,7 @@ table_clause:
 // WITH [ RECURSIVE ] <query name> [ (<column> [, ...]) ]
 //        AS [ [ NOT ] MATERIALIZED ] (query) [ SEARCH or CYCLE clause ]
 //
-// We don't currently support the SEARCH or CYCLE clause.
+// We don't currently support the SEARCH clause.
 //
 // Recognizing WITH_LA here allows a CTE to be named TIME or ORDINALITY.
 with_clause:
@@ -8384,7 +8802,7 @@ materialize_clause:
   }
 
 common_table_expr:
-  table_alias_name opt_column_list AS '(' preparable_stmt ')'
+  table_alias_name opt_column_list AS '(' preparable_stmt ')' opt_cycle_clause
     {
       $$.val = &tree.CTE{
         Name: tree.AliasClause{Alias: tree.Name($1), Cols: $2.nameList() },
@@ -8392,9 +8810,10 @@ common_table_expr:
           Set: false,
         },
         Stmt: $5.stmt(),
+        Cycle: $7.cycleClause(),
       }
     }
-| table_alias_name opt_column_list AS materialize_clause '(' preparable_stmt ')'
+| table_alias_name opt_column_list AS materialize_clause '(' preparable_stmt ')' opt_cycle_clause
     {
       $$.val = &tree.CTE{
         Name: tree.AliasClause{Alias: tree.Name($1), Cols: $2.nameList() },
@@ -8403,9 +8822,38 @@ common_table_expr:
           Set: true,
         },
         Stmt: $6.stmt(),
+        Cycle: $8.cycleClause(),
       }
     }
 
+// The CYCLE clause of a recursive CTE:
+//
+// CYCLE <column> [, ...] SET <mark column> [ TO <value> DEFAULT <default> ]
+//   USING <path column>
+opt_cycle_clause:
+  CYCLE name_list SET name USING name
+  {
+    $$.val = &tree.CycleClause{
+      Columns: $2.nameList(),
+      MarkColumn: tree.Name($4),
+      PathColumn: tree.Name($6),
+    }
+  }
+| CYCLE name_list SET name TO d_expr DEFAULT d_expr USING name
+  {
+    $$.val = &tree.CycleClause{
+      Columns: $2.nameList(),
+      MarkColumn: tree.Name($4),
+      MarkValue: $6.expr(),
+      MarkDefault: $8.expr(),
+      PathColumn: tree.Name($10),
+    }
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.CycleClause)(nil)
+  }
+
 opt_with:
   WITH {}
 | /* EMPTY */ {}
@@ -12000,11 +12448,13 @@ unreserved_keyword:
 | AGGREGATE
 | ALTER
 | ALWAYS
+| APPLICATION
 | AT
 | ATTRIBUTE
 | AUTOMATIC
 | BACKUP
 | BACKUPS
+| BATCH
 | BEFORE
 | BEGIN
 | BINARY
@@ -12024,12 +12474,14 @@ unreserved_keyword:
 | COMMIT
 | COMMITTED
 | COMPACT
+| COMPACTIONS
 | COMPLETE
 | CONFLICT
 | CONFIGURATION
 | CONFIGURATIONS
 | CONFIGURE
 | CONNECTION
+| CONSISTENCY
 | CONSTRAINTS
 | CONTROLCHANGEFEED
 | CONTROLJOB
@@ -12054,10 +12506,12 @@ unreserved_keyword:
 | DEFERRED
 | DESTINATION
 | DETACHED
+| DETAILS
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| DRY
 | ENCODING
 | ENCRYPTION_PASSPHRASE
 | ENUM
@@ -12185,10 +12639,12 @@ unreserved_keyword:
 | OPTION
 | OPTIONS
 | ORDINALITY
+| ORIGINAL
 | OTHERS
 | OVER
 | OWNED
 | OWNER
+| PACING
 | PARENT
 | PARTIAL
 | PARTITION
@@ -12243,6 +12699,7 @@ unreserved_keyword:
 | ROLLUP
 | ROWS
 | RULE
+| RUN
 | RUNNING
 | SCHEDULE
 | SCHEDULES
@@ -12267,6 +12724,7 @@ unreserved_keyword:
 | SHARE
 | SHOW
 | SIMPLE
+| SIZE
 | SKIP
 | SKIP_MISSING_FOREIGN_KEYS
 | SKIP_MISSING_SEQUENCES
@@ -12313,6 +12771,7 @@ unreserved_keyword:
 | UNSPLIT
 | UNTIL
 | UPDATE
+| UPGRADE
 | UPSERT
 | USE
 | USERS
@@ -12320,8 +12779,10 @@ unreserved_keyword:
 | VALIDATE
 | VALUE
 | VARYING
+| VERIFY
 | VIEW
 | VIEWACTIVITY
+| WEB
 | WITHIN
 | WITHOUT
 | WRITE
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -37,10 +38,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/vtable"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 	"golang.org/x/text/collate"
@@ -189,8 +193,6 @@ var pgCatalog = virtualSchema{
 		"pg_subscription_rel",
 		"pg_tables",
 		"pg_tablespace",
-		"pg_timezone_abbrevs",
-		"pg_timezone_names",
 		"pg_transform",
 		"pg_trigger",
 		"pg_ts_config",
@@ -251,6 +253,8 @@ var pgCatalog = virtualSchema{
 		catconstants.PgCatalogUserMappingTableID:         pgCatalogUserMappingTable,
 		catconstants.PgCatalogTablesTableID:              pgCatalogTablesTable,
 		catconstants.PgCatalogTablespaceTableID:          pgCatalogTablespaceTable,
+		catconstants.PgCatalogTimezoneAbbrevsTableID:     pgCatalogTimezoneAbbrevsTable,
+		catconstants.PgCatalogTimezoneNamesTableID:       pgCatalogTimezoneNamesTable,
 		catconstants.PgCatalogTriggerTableID:             pgCatalogTriggerTable,
 		catconstants.PgCatalogTypeTableID:                pgCatalogTypeTable,
 		catconstants.PgCatalogViewsTableID:               pgCatalogViewsTable,
@@ -2042,9 +2046,17 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 		// need to do the same. This shouldn't be an issue, because pg_roles doesn't
 		// include sensitive information such as password hashes.
 		h := makeOidHasher()
+		roleConfigs, err := getRoleConfigs(ctx, p)
+		if err != nil {
+			return err
+		}
 		return forEachRole(ctx, p,
 			func(username security.SQLUsername, isRole bool, noLogin bool, rolValidUntil *time.Time) error {
 				isRoot := tree.DBool(username.IsRootUser() || username.IsAdminRole())
+				roleConfig := tree.DNull
+				if c, ok := roleConfigs[username.Normalized()]; ok {
+					roleConfig = c
+				}
 				isRoleDBool := tree.DBool(isRole)
 				roleCanLogin := tree.DBool(!noLogin)
 				roleValidUntilValue := tree.DNull
@@ -2070,12 +2082,37 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					passwdStarString,                     // rolpassword
 					roleValidUntilValue,                  // rolvaliduntil
 					tree.DBoolFalse,                      // rolbypassrls
-					tree.DNull,                           // rolconfig
+					roleConfig,                           // rolconfig
 				)
 			})
 	},
 }
 
+// getRoleConfigs returns the session variable defaults configured for each
+// role in all databases with ALTER ROLE ... SET, keyed by role name. No
+// defaults are returned until system.database_role_settings exists.
+func getRoleConfigs(ctx context.Context, p *planner) (map[string]tree.Datum, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettings) {
+		return map[string]tree.Datum{}, nil
+	}
+	rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryEx(
+		ctx, "read-role-configs", p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		fmt.Sprintf(
+			`SELECT role_name, settings FROM %s WHERE database_id = 0 AND role_name != ''`,
+			DatabaseRoleSettingsTableName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]tree.Datum, len(rows))
+	for _, row := range rows {
+		configs[string(tree.MustBeDString(row[0]))] = row[1]
+	}
+	return configs, nil
+}
+
 var pgCatalogSecLabelsTable = virtualSchemaTable{
 	comment: `security labels (empty)
 https://www.postgresql.org/docs/9.6/view-pg-seclabels.html`,
@@ -2096,6 +2133,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-sequence.html`,
 					return nil
 				}
 				opts := table.GetSequenceOpts()
+				cacheSize := opts.CacheSize
+				if cacheSize < 1 {
+					cacheSize = 1
+				}
 				return addRow(
 					tableOid(table.GetID()),                 // seqrelid
 					tree.NewDOid(tree.DInt(oid.T_int8)),     // seqtypid
@@ -2103,7 +2144,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-sequence.html`,
 					tree.NewDInt(tree.DInt(opts.Increment)), // seqincrement
 					tree.NewDInt(tree.DInt(opts.MaxValue)),  // seqmax
 					tree.NewDInt(tree.DInt(opts.MinValue)),  // seqmin
-					tree.NewDInt(1),                         // seqcache
+					tree.NewDInt(tree.DInt(cacheSize)),      // seqcache
 					tree.DBoolFalse,                         // seqcycle
 				)
 			})
@@ -2221,6 +2262,99 @@ https://www.postgresql.org/docs/9.5/catalog-pg-tablespace.html`,
 	},
 }
 
+var pgCatalogTimezoneAbbrevsTable = virtualSchemaTable{
+	comment: `time zone abbreviations in use by the time zone database
+https://www.postgresql.org/docs/9.5/view-pg-timezone-abbrevs.html`,
+	schema: vtable.PGCatalogTimezoneAbbrevs,
+	populate: func(ctx context.Context, p *planner, dbContext *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		// Postgres lists the abbreviations of its timezone_abbreviations
+		// configuration file. We list those in use in the time zone database
+		// around the current time instead, taking the offset of the first time
+		// zone (in name order) that uses each one.
+		now := p.EvalContext().GetStmtTimestamp()
+		seen := make(map[string]struct{})
+		for _, name := range timeutil.TimeZoneNames() {
+			loc, err := timeutil.LoadLocation(name)
+			if err != nil {
+				// The time zone database in use may not know all the time zones
+				// of the embedded one.
+				continue
+			}
+			// Look at both halves of the year to find the standard and the
+			// daylight saving time abbreviations.
+			for _, t := range []time.Time{
+				time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc),
+				time.Date(now.Year(), time.July, 1, 0, 0, 0, 0, loc),
+			} {
+				abbrev, offset, isDST := timeZoneAt(t, loc)
+				if _, ok := seen[abbrev]; ok {
+					continue
+				}
+				seen[abbrev] = struct{}{}
+				// Zones without an abbreviation are reported by their numeric
+				// offset, such as "+03".
+				if strings.HasPrefix(abbrev, "+") || strings.HasPrefix(abbrev, "-") {
+					continue
+				}
+				if err := addRow(
+					tree.NewDString(abbrev),           // abbrev
+					offset,                            // utc_offset
+					tree.MakeDBool(tree.DBool(isDST)), // is_dst
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var pgCatalogTimezoneNamesTable = virtualSchemaTable{
+	comment: `time zones of the time zone database
+https://www.postgresql.org/docs/9.5/view-pg-timezone-names.html`,
+	schema: vtable.PGCatalogTimezoneNames,
+	populate: func(ctx context.Context, p *planner, dbContext *dbdesc.Immutable, addRow func(...tree.Datum) error) error {
+		now := p.EvalContext().GetStmtTimestamp()
+		for _, name := range timeutil.TimeZoneNames() {
+			loc, err := timeutil.LoadLocation(name)
+			if err != nil {
+				continue
+			}
+			abbrev, offset, isDST := timeZoneAt(now.In(loc), loc)
+			if err := addRow(
+				tree.NewDString(name),             // name
+				tree.NewDString(abbrev),           // abbrev
+				offset,                            // utc_offset
+				tree.MakeDBool(tree.DBool(isDST)), // is_dst
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// timeZoneAt returns the abbreviation and UTC offset of the time zone loc at
+// time t, and whether daylight saving time is in effect. The time package
+// doesn't expose the DST flag of the time zone database, so daylight saving
+// time is taken to be in effect when the offset is ahead of the smallest of
+// the offsets in January and July.
+func timeZoneAt(t time.Time, loc *time.Location) (string, *tree.DInterval, bool) {
+	abbrev, offset := t.In(loc).Zone()
+	_, janOffset := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, julOffset := time.Date(t.Year(), time.July, 1, 0, 0, 0, 0, loc).Zone()
+	stdOffset := janOffset
+	if julOffset < stdOffset {
+		stdOffset = julOffset
+	}
+	return abbrev,
+		tree.NewDInterval(
+			duration.MakeDuration(int64(offset)*int64(time.Second), 0, 0),
+			types.DefaultIntervalTypeMetadata,
+		),
+		offset != stdOffset
+}
+
 var pgCatalogTriggerTable = virtualSchemaTable{
 	comment: `triggers (empty - feature does not exist)
 https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
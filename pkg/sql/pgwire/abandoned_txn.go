@@ -0,0 +1,132 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cmux"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+)
+
+// abandonedTxnDetectionInterval is the TCP keep-alive period used for client
+// connections while their session is in an open transaction.
+//
+// A client that goes away without closing its connection (for example because
+// its host crashed or the network was partitioned) is only noticed once TCP
+// keep-alive probes fail. Until then, the session's transaction stays open and
+// its intents block other writers. Probing more frequently while a transaction
+// is open bounds how long such an abandoned transaction can linger.
+var abandonedTxnDetectionInterval = settings.RegisterDurationSetting(
+	"sql.txn.abandoned_detection_interval",
+	"TCP keep-alive period of client connections that are in an open transaction, "+
+		"used to detect and abort transactions whose client went away without "+
+		"closing its connection; set to 0 to use the default keep-alive period",
+	5*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// MetaAbandonedTxns is the metadata for the counter of transactions aborted
+// because their client connection was lost.
+var MetaAbandonedTxns = metric.Metadata{
+	Name:        "sql.txn.abandoned.count",
+	Help:        "Number of SQL transactions aborted because their client connection was lost",
+	Measurement: "SQL Transactions",
+	Unit:        metric.Unit_COUNT,
+}
+
+// tcpConnOf returns the TCP connection underlying a client connection, or nil
+// if the client is not connected over TCP.
+func tcpConnOf(netConn net.Conn) *net.TCPConn {
+	if muxConn, ok := netConn.(*cmux.MuxConn); ok {
+		netConn = muxConn.Conn
+	}
+	tcpConn, _ := netConn.(*net.TCPConn)
+	return tcpConn
+}
+
+// abandonedTxnDetector tracks whether the session of a client connection is
+// in an open transaction and tightens the connection's TCP keep-alive probing
+// while it is.
+type abandonedTxnDetector struct {
+	// tcpConn is the connection's underlying TCP connection. If nil, the
+	// keep-alive probing cannot be adjusted.
+	tcpConn *net.TCPConn
+	// defaultKeepAlive is the keep-alive period the connection was set up
+	// with, which is restored once the session leaves its transaction. Zero
+	// means that keep-alives were disabled.
+	defaultKeepAlive time.Duration
+	sv               *settings.Values
+
+	// inTxn is 1 while the session is in an open transaction, as last reported
+	// to the client. It is written by the command processing goroutine and
+	// read by the connection's reader goroutine, so it is accessed atomically.
+	inTxn int32
+	// tightened is set while the keep-alive period is lowered to the
+	// abandoned transaction detection interval. It is only accessed by the
+	// command processing goroutine.
+	tightened bool
+}
+
+// inOpenTxn returns whether the session was in an open transaction when it
+// last reported its transaction status to the client.
+func (d *abandonedTxnDetector) inOpenTxn() bool {
+	return atomic.LoadInt32(&d.inTxn) == 1
+}
+
+// onReadyForQuery is called with the transaction status sent to the client in
+// each ReadyForQuery message.
+func (d *abandonedTxnDetector) onReadyForQuery(ctx context.Context, txnStatus byte) {
+	var inTxn int32
+	if txnStatus != byte(sql.IdleTxnBlock) {
+		inTxn = 1
+	}
+	if atomic.SwapInt32(&d.inTxn, inTxn) == inTxn || d.tcpConn == nil {
+		return
+	}
+
+	if inTxn == 1 {
+		interval := abandonedTxnDetectionInterval.Get(d.sv)
+		if interval == 0 || (d.defaultKeepAlive != 0 && interval >= d.defaultKeepAlive) {
+			return
+		}
+		if err := d.tcpConn.SetKeepAlive(true); err != nil {
+			log.VEventf(ctx, 2, "failed to enable TCP keep-alive: %v", err)
+			return
+		}
+		if err := d.tcpConn.SetKeepAlivePeriod(interval); err != nil {
+			log.VEventf(ctx, 2, "failed to set TCP keep-alive period: %v", err)
+			return
+		}
+		d.tightened = true
+		return
+	}
+
+	if !d.tightened {
+		return
+	}
+	d.tightened = false
+	var err error
+	if d.defaultKeepAlive == 0 {
+		err = d.tcpConn.SetKeepAlive(false)
+	} else {
+		err = d.tcpConn.SetKeepAlivePeriod(d.defaultKeepAlive)
+	}
+	if err != nil {
+		log.VEventf(ctx, 2, "failed to restore TCP keep-alive: %v", err)
+	}
+}
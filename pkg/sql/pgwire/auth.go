@@ -30,6 +30,15 @@ const (
 	// authCleartextPassword is the pgwire auth response code to request
 	// a plaintext password during the connection handshake.
 	authCleartextPassword int32 = 3
+	// authSASL is the pgwire auth response code to request a SASL
+	// authentication exchange, with the list of supported mechanisms.
+	authSASL int32 = 10
+	// authSASLContinue is the pgwire auth response code carrying the
+	// server's challenge during a SASL exchange.
+	authSASLContinue int32 = 11
+	// authSASLFinal is the pgwire auth response code carrying the
+	// server's final message of a successful SASL exchange.
+	authSASLFinal int32 = 12
 )
 
 type authOptions struct {
@@ -125,6 +134,22 @@ func (c *conn) handleAuthentication(
 
 	ac.Logf(ctx, "authentication succeeded")
 
+	// Apply the session variable defaults configured for the user and the
+	// database, unless the client overrode them in its connection parameters.
+	// The defaults are best-effort: if they can't be retrieved, the session is
+	// opened with the cluster defaults rather than failing the login.
+	roleDefaults, err := sql.GetRoleSessionDefaults(
+		ctx, authOpt.ie, c.sessionArgs.User, c.sessionArgs.SessionDefaults["database"],
+	)
+	if err != nil {
+		ac.Logf(ctx, "session defaults retrieval failed for user=%q: %v", c.sessionArgs.User, err)
+	}
+	for name, value := range roleDefaults {
+		if _, ok := c.sessionArgs.SessionDefaults[name]; !ok {
+			c.sessionArgs.SessionDefaults[name] = value
+		}
+	}
+
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgAuth)
 	c.msgBuilder.putInt32(authOK)
 	return connClose, c.msgBuilder.finishMsg(c.conn)
@@ -256,6 +281,9 @@ type AuthConn interface {
 	// Logf logs a message on the authentication log, if auth logs
 	// are enabled.
 	Logf(ctx context.Context, format string, args ...interface{})
+	// User returns the user name given by the client in the startup
+	// message.
+	User() security.SQLUsername
 }
 
 // authPipe is the implementation for the authenticator and AuthConn interfaces.
@@ -334,6 +362,11 @@ func (p *authPipe) Logf(ctx context.Context, format string, args ...interface{})
 	}
 }
 
+// User is part of the AuthConn interface.
+func (p *authPipe) User() security.SQLUsername {
+	return p.c.sessionArgs.User
+}
+
 // authResult is part of the authenticator interface.
 func (p *authPipe) authResult() (unqualifiedIntSizer, error) {
 	p.noMorePwdData()
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -53,6 +54,13 @@ func loadDefaultMethods() {
 	// a cleartext password.
 	RegisterAuthMethod("cert-password", authCertPassword, hba.ConnAny, nil)
 
+	// The "scram-sha-256" method performs a SCRAM-SHA-256 SASL exchange,
+	// which proves that the client knows the password without sending it
+	// to the server. It requires the user's password to be stored as a
+	// SCRAM verifier, see the server.user_login.password_encryption cluster
+	// setting.
+	RegisterAuthMethod("scram-sha-256", authScram, hba.ConnAny, nil)
+
 	// The "reject" method rejects any connection attempt that matches
 	// the current rule.
 	RegisterAuthMethod("reject", authReject, hba.ConnAny, nil)
@@ -109,20 +117,140 @@ func authPassword(
 		c.Logf(ctx, "user has no password defined")
 	}
 
+	if err := checkPasswordNotExpired(ctx, c, pwValidUntilFn); err != nil {
+		return nil, err
+	}
+
+	return security.UserAuthPasswordHook(
+		false /*insecure*/, password, hashedPassword,
+	), nil
+}
+
+func checkPasswordNotExpired(
+	ctx context.Context, c AuthConn, pwValidUntilFn PasswordValidUntilFn,
+) error {
 	validUntil, err := pwValidUntilFn(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if validUntil != nil {
 		if validUntil.Sub(timeutil.Now()) < 0 {
 			c.Logf(ctx, "password is expired")
-			return nil, errors.New("password is expired")
+			return errors.New("password is expired")
 		}
 	}
+	return nil
+}
 
-	return security.UserAuthPasswordHook(
-		false /*insecure*/, password, hashedPassword,
-	), nil
+func authScram(
+	ctx context.Context,
+	c AuthConn,
+	_ tls.ConnectionState,
+	pwRetrieveFn PasswordRetrievalFn,
+	pwValidUntilFn PasswordValidUntilFn,
+	execCfg *sql.ExecutorConfig,
+	_ *hba.Entry,
+) (security.UserAuthHook, error) {
+	hashedPassword, err := pwRetrieveFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var conv *security.ScramServerConversation
+	if len(hashedPassword) == 0 {
+		c.Logf(ctx, "user has no password defined")
+	} else if verifier, err := security.ParseScramVerifier(hashedPassword); err != nil {
+		c.Logf(ctx, "user password cannot be used for SCRAM authentication: %v", err)
+	} else {
+		conv = security.NewScramServerConversation(verifier)
+	}
+	if conv == nil {
+		// Go through the exchange with a mock verifier, which always fails at
+		// the end, so that the client cannot tell this user apart from a user
+		// with a SCRAM verifier. The mock salt is derived from the cluster ID
+		// so that it doesn't change across attempts.
+		conv = security.NewMockScramServerConversation(
+			c.User().Normalized(), execCfg.ClusterID().GetBytes())
+	}
+
+	// Offer the only supported mechanism. The list of mechanisms is
+	// terminated by an empty string.
+	if err := c.SendAuthRequest(
+		authSASL, []byte(security.ScramMechanism+"\x00\x00"),
+	); err != nil {
+		return nil, err
+	}
+	initialResponse, err := c.GetPwdData()
+	if err != nil {
+		return nil, err
+	}
+	clientFirst, err := parseSASLInitialResponse(initialResponse)
+	if err != nil {
+		return nil, err
+	}
+	serverFirst, err := conv.ServerFirst(clientFirst)
+	if err != nil {
+		return nil, pgwirebase.NewProtocolViolationErrorf("%v", err)
+	}
+
+	if err := c.SendAuthRequest(authSASLContinue, []byte(serverFirst)); err != nil {
+		return nil, err
+	}
+	clientFinal, err := c.GetPwdData()
+	if err != nil {
+		return nil, err
+	}
+	serverFinal, err := conv.ServerFinal(string(clientFinal))
+	if err != nil {
+		c.Logf(ctx, "SCRAM authentication failed: %v", err)
+		return scramFailedHook, nil
+	}
+
+	if err := checkPasswordNotExpired(ctx, c, pwValidUntilFn); err != nil {
+		return nil, err
+	}
+	if err := c.SendAuthRequest(authSASLFinal, []byte(serverFinal)); err != nil {
+		return nil, err
+	}
+	return func(_ security.SQLUsername, clientConnection bool) (func(), error) {
+		if !clientConnection {
+			return nil, errors.New("password authentication is only available for client connections")
+		}
+		return nil, nil
+	}, nil
+}
+
+// scramFailedHook is the authentication hook returned when the SCRAM
+// exchange fails, so that the client receives the same error as when the
+// password is incorrect.
+func scramFailedHook(requestedUser security.SQLUsername, _ bool) (func(), error) {
+	return nil, errors.Errorf(security.ErrPasswordUserAuthFailed, requestedUser)
+}
+
+// parseSASLInitialResponse parses the SASLInitialResponse message sent by the
+// client after an authSASL request, and returns the initial response of the
+// mechanism.
+func parseSASLInitialResponse(data []byte) (string, error) {
+	buf := pgwirebase.ReadBuffer{Msg: data}
+	mechanism, err := buf.GetString()
+	if err != nil {
+		return "", err
+	}
+	if mechanism != security.ScramMechanism {
+		return "", pgwirebase.NewProtocolViolationErrorf(
+			"client selected an invalid SASL authentication mechanism: %q", mechanism)
+	}
+	n, err := buf.GetUint32()
+	if err != nil {
+		return "", err
+	}
+	if int32(n) < 0 {
+		return "", pgwirebase.NewProtocolViolationErrorf("SCRAM requires an initial response")
+	}
+	response, err := buf.GetBytes(int(n))
+	if err != nil {
+		return "", err
+	}
+	return string(response), nil
 }
 
 func passwordString(pwdData []byte) (string, error) {
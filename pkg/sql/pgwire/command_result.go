@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -88,6 +89,14 @@ type commandResult struct {
 	// bufferingDisabled is conditionally set during planning of certain
 	// statements.
 	bufferingDisabled bool
+	// bufferingExtended is set for statements executed in implicit
+	// transactions, whose results are buffered up to
+	// ConnImplicitTxnResultsBufferSize, or further if streamingDisabled is
+	// set. The results buffered beyond ConnResultsBufferSize are accounted for
+	// in bufferAcc.
+	bufferingExtended bool
+	streamingDisabled bool
+	bufferAcc         *mon.BoundAccount
 
 	// released is set when the command result has been released so that its
 	// memory can be reused. It is also used to assert against use-after-free
@@ -152,6 +161,7 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		r.conn.bufferCloseComplete()
 	case readyForQuery:
 		r.conn.bufferReadyForQuery(byte(t))
+		r.conn.abandonedTxns.onReadyForQuery(ctx, byte(t))
 		// The error is saved on conn.err.
 		_ /* err */ = r.conn.Flush(r.pos)
 	case emptyQueryResponse:
@@ -207,6 +217,8 @@ func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	var err error
 	if r.bufferingDisabled {
 		err = r.conn.Flush(r.pos)
+	} else if r.bufferingExtended {
+		err = r.maybeFlushExtended(ctx)
 	} else {
 		_ /* flushed */, err = r.conn.maybeFlush(r.pos)
 	}
@@ -219,6 +231,40 @@ func (r *commandResult) DisableBuffering() {
 	r.bufferingDisabled = true
 }
 
+// ExtendBuffering is part of the CommandResult interface.
+func (r *commandResult) ExtendBuffering(acc *mon.BoundAccount, streamingDisabled bool) {
+	r.assertNotReleased()
+	if r.conn.sessionArgs.ConnImplicitTxnResultsBufferSize <= r.conn.sessionArgs.ConnResultsBufferSize {
+		return
+	}
+	r.bufferingExtended = true
+	r.streamingDisabled = streamingDisabled
+	r.bufferAcc = acc
+}
+
+// maybeFlushExtended is the counterpart of conn.maybeFlush for results whose
+// buffering was extended. Once the buffer exceeds
+// ConnImplicitTxnResultsBufferSize, sql.ErrImplicitTxnResultsOverflow is
+// returned so that the transaction is restarted with streaming disabled. If
+// the session's memory budget cannot hold the buffer, the results are flushed
+// instead, at the cost of not being able to retry the transaction
+// automatically anymore.
+func (r *commandResult) maybeFlushExtended(ctx context.Context) error {
+	size := int64(r.conn.writerState.buf.Len())
+	if size <= r.conn.sessionArgs.ConnResultsBufferSize {
+		return nil
+	}
+	if !r.streamingDisabled && size > r.conn.sessionArgs.ConnImplicitTxnResultsBufferSize {
+		return sql.ErrImplicitTxnResultsOverflow
+	}
+	if err := r.bufferAcc.ResizeTo(ctx, size-r.conn.sessionArgs.ConnResultsBufferSize); err == nil {
+		return nil
+	}
+	r.bufferingExtended = false
+	r.bufferAcc.Clear(ctx)
+	return r.conn.Flush(r.pos)
+}
+
 // BufferParamStatusUpdate is part of the CommandResult interface.
 func (r *commandResult) BufferParamStatusUpdate(param string, val string) {
 	r.buffer.paramStatusUpdates = append(
@@ -421,6 +467,11 @@ func (r *limitedCommandResult) AddRow(ctx context.Context, row tree.Datums) erro
 	return nil
 }
 
+// ExtendBuffering is part of the CommandResult interface. The results of
+// portals with a row limit are flushed whenever the limit is reached, so their
+// buffering is never extended.
+func (r *limitedCommandResult) ExtendBuffering(*mon.BoundAccount, bool) {}
+
 // moreResultsNeeded is a restricted connection handler that waits for more
 // requests for rows from the active portal, during the "execute portal" flow
 // when a limit has been specified.
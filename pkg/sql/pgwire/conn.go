@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -90,6 +91,10 @@ type conn struct {
 
 	sv *settings.Values
 
+	// abandonedTxns tracks whether the session is in an open transaction, to
+	// detect promptly when the client goes away while it is.
+	abandonedTxns abandonedTxnDetector
+
 	// testingLogEnabled is used in unit tests in this package to
 	// force-enable auth logging without dancing around the
 	// asynchronicity of cluster settings.
@@ -137,6 +142,7 @@ type conn struct {
 func (s *Server) serveConn(
 	ctx context.Context,
 	netConn net.Conn,
+	tcpConn *net.TCPConn,
 	sArgs sql.SessionArgs,
 	reserved mon.BoundAccount,
 	authOpt authOptions,
@@ -149,6 +155,8 @@ func (s *Server) serveConn(
 
 	c := newConn(netConn, sArgs, &s.metrics, &s.execCfg.Settings.SV)
 	c.testingLogEnabled = atomic.LoadInt32(&s.testingLogEnabled) > 0
+	c.abandonedTxns.tcpConn = tcpConn
+	c.abandonedTxns.defaultKeepAlive = time.Duration(atomic.LoadInt64(&s.tcpKeepAlive))
 
 	// Do the reading of commands from the network.
 	c.serveImpl(ctx, s.IsDraining, s.SQLServer, reserved, authOpt)
@@ -165,6 +173,7 @@ func newConn(
 		sv:          sv,
 		readBuf:     pgwirebase.MakeReadBuffer(pgwirebase.ReadBufferOptionWithClusterSettings(sv)),
 	}
+	c.abandonedTxns.sv = sv
 	c.stmtBuf.Init()
 	c.res.released = true
 	c.writerState.fi.buf = &c.writerState.buf
@@ -459,6 +468,21 @@ Loop:
 		}
 	}
 
+	// If the client went away while its session was in an open transaction,
+	// the transaction is aborted below when the command processor stops. Make
+	// these abandoned transactions visible.
+	if err != nil && !terminateSeen && ctx.Err() == nil && c.abandonedTxns.inOpenTxn() {
+		c.metrics.AbandonedTxns.Inc(1)
+		ev := &eventpb.AbandonedTxnAborted{
+			User:  c.sessionArgs.User.Normalized(),
+			Error: err.Error(),
+		}
+		if c.sessionArgs.RemoteAddr != nil {
+			ev.ClientAddress = c.sessionArgs.RemoteAddr.String()
+		}
+		log.StructuredEvent(ctx, ev)
+	}
+
 	// We're done reading data from the client, so make the communication
 	// goroutine stop. Depending on what that goroutine is currently doing (or
 	// blocked on), we cancel and close all the possible channels to make sure we
@@ -48,6 +48,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/jackc/pgproto3/v2"
 	"github.com/jackc/pgx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 )
@@ -1202,6 +1203,104 @@ func TestConnResultsBufferSize(t *testing.T) {
 	require.False(t, b)
 }
 
+// TestConnImplicitTxnResultsBufferSize checks that implicit transactions whose
+// results don't fit in the regular results buffer can still be retried
+// automatically.
+func TestConnImplicitTxnResultsBufferSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE SEQUENCE s`)
+
+	// The query produces about 100 KiB of results, more than the regular
+	// results buffer holds, before its transaction is forced to restart once.
+	const query = `
+SELECT g, repeat('x', 100),
+       if(g = 1000, CASE nextval('s') WHEN 1 THEN crdb_internal.force_retry('1h') ELSE 0 END, 0)
+  FROM generate_series(1, 1000) AS g`
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanup()
+	// runQuery runs the query on a new connection, so that it picks up the
+	// current cluster settings.
+	runQuery := func() (int, error) {
+		conn, err := gosql.Open("postgres", pgURL.String())
+		require.NoError(t, err)
+		defer conn.Close()
+		rows, err := conn.Query(query)
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		return n, rows.Err()
+	}
+
+	n, err := runQuery()
+	require.NoError(t, err)
+	require.Equal(t, 1000, n)
+
+	// With a smaller extended buffer, the results overflow it before the
+	// transaction is forced to restart. The transaction is then restarted with
+	// streaming disabled, so it can still be retried automatically.
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.defaults.results_buffer.implicit_txn_size = '32KiB'`)
+	sqlDB.Exec(t, `SELECT setval('s', 1, false)`)
+	n, err = runQuery()
+	require.NoError(t, err)
+	require.Equal(t, 1000, n)
+
+	// Without the extended buffer, some results are flushed to the client
+	// before the transaction restarts, so the retriable error reaches the
+	// client.
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.defaults.results_buffer.implicit_txn_size = '0'`)
+	sqlDB.Exec(t, `SELECT setval('s', 1, false)`)
+	_, err = runQuery()
+	require.Regexp(t, `forced by crdb_internal.force_retry\(\)`, err)
+}
+
+// TestConnOptionsParameter checks that the cluster name and session variables
+// can be specified through the options connection parameter.
+func TestConnOptionsParameter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanup()
+	q := pgURL.Query()
+	q.Add(`options`, `--cluster=tenant-cluster-28 -c application_name=options_test`)
+	pgURL.RawQuery = q.Encode()
+
+	db, err := gosql.Open("postgres", pgURL.String())
+	require.NoError(t, err)
+	defer db.Close()
+
+	var appName, clusterName string
+	require.NoError(t, db.QueryRow(
+		`SELECT application_name, cluster_name FROM [SHOW SESSIONS] WHERE application_name = 'options_test'`,
+	).Scan(&appName, &clusterName))
+	require.Equal(t, `options_test`, appName)
+	require.Equal(t, `tenant-cluster-28`, clusterName)
+
+	q.Set(`options`, `cluster=tenant-cluster-28`)
+	pgURL.RawQuery = q.Encode()
+	errDB, err := gosql.Open("postgres", pgURL.String())
+	require.NoError(t, err)
+	defer errDB.Close()
+	_, err = errDB.Exec(`SELECT 1`)
+	require.EqualError(t, err, `pq: invalid option "cluster=tenant-cluster-28" in options parameter`)
+	var pqErr *pq.Error
+	require.True(t, errors.As(err, &pqErr))
+	require.Equal(t, pgcode.ProtocolViolation.String(), string(pqErr.Code))
+}
+
 // Test that closing a connection while authentication was ongoing cancels the
 // auhentication process. In other words, this checks that the server is reading
 // from the connection while authentication is ongoing and so it reacts to the
@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -1924,6 +1926,76 @@ func TestCancelRequest(t *testing.T) {
 	})
 }
 
+// TestAbandonedTxnCounted checks that losing a client connection while its
+// session is in an open transaction is counted as an abandoned transaction,
+// whereas a connection closed outside of a transaction or with a Terminate
+// message is not.
+func TestAbandonedTxnCounted(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{Insecure: true})
+	defer s.Stopper().Stop(context.Background())
+	metrics := s.(*server.TestServer).PGServer().Metrics()[0].(*pgwire.ServerMetrics)
+
+	// runAndClose opens a connection, runs the given query to completion and
+	// closes the connection without terminating the session.
+	runAndClose := func(query string, expTxnStatus byte) {
+		conn, err := net.Dial("tcp", s.ServingSQLAddr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fe := pgproto3.NewFrontend(pgproto3.NewChunkReader(conn), conn)
+		waitForReady := func() byte {
+			for {
+				msg, err := fe.Receive()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ready, ok := msg.(*pgproto3.ReadyForQuery); ok {
+					return ready.TxStatus
+				}
+			}
+		}
+		if err := fe.Send(&pgproto3.StartupMessage{
+			ProtocolVersion: pgproto3.ProtocolVersionNumber,
+			Parameters:      map[string]string{"user": security.RootUser},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		waitForReady()
+		if err := fe.Send(&pgproto3.Query{String: query}); err != nil {
+			t.Fatal(err)
+		}
+		if txnStatus := waitForReady(); txnStatus != expTxnStatus {
+			t.Fatalf("expected transaction status %q, got %q", expTxnStatus, txnStatus)
+		}
+		if err := conn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runAndClose("SELECT 1", 'I')
+	runAndClose("BEGIN; SELECT 1", 'T')
+	testutils.SucceedsSoon(t, func() error {
+		if count := metrics.AbandonedTxns.Count(); count != 1 {
+			return errors.Errorf("expected 1 abandoned transaction, got %d", count)
+		}
+		return nil
+	})
+
+	// The abandoned transaction is also recorded as a structured event.
+	log.Flush()
+	entries, err := log.FetchEntriesFromFiles(0, math.MaxInt64, 10000,
+		regexp.MustCompile(`"EventType":"abandoned_txn_aborted"`), log.WithMarkedSensitiveData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 abandoned_txn_aborted event, got %d", len(entries))
+	}
+}
+
 func TestFailPrepareFailsTxn(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -0,0 +1,102 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwirebase
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// ClusterNameOption is the name of the entry in the options connection
+// parameter that identifies the cluster the client wants to connect to, as
+// in options=--cluster=<name>. It lets a routing layer in front of
+// multi-tenant clusters pick a backend without relying on the SNI header.
+const ClusterNameOption = "cluster"
+
+// ParseOptions parses the value of the options connection parameter into a
+// map of option names to values. As in PostgreSQL, the value is a list of
+// whitespace-separated command-line style arguments, where a backslash
+// escapes the following character. Each option is specified as
+// "-c name=value", "-cname=value" or "--name=value". Option names are
+// case-folded and dashes in them are turned into underscores.
+func ParseOptions(options string) (map[string]string, error) {
+	res := make(map[string]string)
+	args, err := splitOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(args); i++ {
+		var opt string
+		switch arg := args[i]; {
+		case arg == "-c":
+			if i+1 >= len(args) {
+				return nil, pgerror.Newf(pgcode.ProtocolViolation,
+					"option %q requires an argument", arg)
+			}
+			i++
+			opt = args[i]
+		case strings.HasPrefix(arg, "--"):
+			opt = arg[2:]
+		case strings.HasPrefix(arg, "-c"):
+			opt = arg[2:]
+		default:
+			return nil, pgerror.Newf(pgcode.ProtocolViolation,
+				"invalid option %q in options parameter", arg)
+		}
+		eq := strings.IndexByte(opt, '=')
+		if eq <= 0 {
+			return nil, pgerror.Newf(pgcode.ProtocolViolation,
+				"option %q must be of the form name=value", opt)
+		}
+		name := strings.ReplaceAll(strings.ToLower(opt[:eq]), "-", "_")
+		res[name] = opt[eq+1:]
+	}
+	return res, nil
+}
+
+// splitOptions splits the value of the options connection parameter into
+// whitespace-separated arguments, handling backslash escapes.
+func splitOptions(options string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	escaped := false
+	for _, r := range options {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			inArg = true
+			escaped = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, pgerror.New(pgcode.ProtocolViolation,
+			"invalid trailing backslash in options parameter")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
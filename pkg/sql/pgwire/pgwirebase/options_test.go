@@ -0,0 +1,56 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwirebase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestParseOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		options  string
+		expected map[string]string
+		err      string
+	}{
+		{options: "", expected: map[string]string{}},
+		{options: "--cluster=tenant-1", expected: map[string]string{"cluster": "tenant-1"}},
+		{options: "-c cluster=a", expected: map[string]string{"cluster": "a"}},
+		{options: "-ccluster=a", expected: map[string]string{"cluster": "a"}},
+		{
+			options:  "  --cluster=a   -c search_path=public  --Application-Name=x ",
+			expected: map[string]string{"cluster": "a", "search_path": "public", "application_name": "x"},
+		},
+		{options: `-c application_name=a\ b`, expected: map[string]string{"application_name": "a b"}},
+		{options: "--cluster=", expected: map[string]string{"cluster": ""}},
+		{options: "-c", err: `option "-c" requires an argument`},
+		{options: "cluster=a", err: `invalid option "cluster=a"`},
+		{options: "--cluster", err: `must be of the form name=value`},
+		{options: "--=a", err: `must be of the form name=value`},
+		{options: `--cluster=a\`, err: `invalid trailing backslash`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.options, func(t *testing.T) {
+			res, err := ParseOptions(tc.options)
+			if !testutils.IsError(err, tc.err) {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+			if err == nil && !reflect.DeepEqual(res, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, res)
+			}
+		})
+	}
+}
@@ -65,6 +65,22 @@ var connResultsBufferSize = settings.RegisterByteSizeSetting(
 	16<<10, // 16 KiB
 ).WithPublic()
 
+// ATTENTION: Like sql.defaults.results_buffer.size, this only affects new
+// connections.
+var connImplicitTxnResultsBufferSize = settings.RegisterByteSizeSetting(
+	"sql.defaults.results_buffer.implicit_txn_size",
+	"size up to which the results of statements executed in implicit transactions "+
+		"are buffered before they are sent to the client, if larger than "+
+		"sql.defaults.results_buffer.size. Buffering these results lets the server "+
+		"automatically retry the transactions when they need to restart instead of "+
+		"returning retriable errors to the client. Transactions whose results exceed "+
+		"this size are restarted with all their results buffered, as far as the "+
+		"session's memory budget allows. Connections that override "+
+		"the buffer size with the 'results_buffer_size' parameter don't buffer "+
+		"results further. Updating the setting only affects new connections.",
+	512<<10, // 512 KiB
+).WithPublic()
+
 var logConnAuth = settings.RegisterBoolSetting(
 	sql.ConnAuditingClusterSettingName,
 	"if set, log SQL client connect and disconnect events (note: may hinder performance on loaded nodes)",
@@ -181,6 +197,10 @@ type Server struct {
 	sqlMemoryPool *mon.BytesMonitor
 	connMonitor   *mon.BytesMonitor
 
+	// tcpKeepAlive is the TCP keep-alive period configured on client
+	// connections, as a time.Duration. Accessed atomically.
+	tcpKeepAlive int64
+
 	// testingLogEnabled is used in unit tests in this package to
 	// force-enable conn/auth logging without dancing around the
 	// asynchronicity of cluster settings.
@@ -193,6 +213,7 @@ type ServerMetrics struct {
 	BytesOutCount  *metric.Counter
 	Conns          *metric.Gauge
 	NewConns       *metric.Counter
+	AbandonedTxns  *metric.Counter
 	ConnMemMetrics sql.BaseMemoryMetrics
 	SQLMemMetrics  sql.MemoryMetrics
 }
@@ -205,6 +226,7 @@ func makeServerMetrics(
 		BytesOutCount:  metric.NewCounter(MetaBytesOut),
 		Conns:          metric.NewGauge(MetaConns),
 		NewConns:       metric.NewCounter(MetaNewConns),
+		AbandonedTxns:  metric.NewCounter(MetaAbandonedTxns),
 		ConnMemMetrics: sql.MakeBaseMemMetrics("conns", histogramWindow),
 		SQLMemMetrics:  sqlMemMetrics,
 	}
@@ -291,6 +313,13 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.SQLServer.Start(ctx, stopper)
 }
 
+// SetTCPKeepAlive informs the server of the TCP keep-alive period configured
+// on incoming client connections. Connections are switched back to this period
+// when their session leaves a transaction; see abandonedTxnDetectionInterval.
+func (s *Server) SetTCPKeepAlive(period time.Duration) {
+	atomic.StoreInt64(&s.tcpKeepAlive, int64(period))
+}
+
 // IsDraining returns true if the server is not currently accepting
 // connections.
 func (s *Server) IsDraining() bool {
@@ -532,6 +561,10 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 		return err
 	}
 
+	// Keep hold of the TCP connection before it is possibly wrapped by TLS,
+	// to adjust its keep-alive probing later on.
+	tcpConn := tcpConnOf(conn)
+
 	// If the client requests SSL, upgrade the connection to use TLS.
 	var clientErr error
 	conn, connType, version, clientErr, err = s.maybeUpgradeToSecureConn(ctx, conn, connType, version, &buf)
@@ -587,7 +620,7 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 	// Defer the rest of the processing to the connection handler.
 	// This includes authentication.
 	s.serveConn(
-		ctx, conn, sArgs,
+		ctx, conn, tcpConn, sArgs,
 		reserved,
 		authOptions{
 			connType:        connType,
@@ -658,23 +691,26 @@ func parseClientProvidedSessionParameters(
 			}
 			foundBufferSize = true
 
-		default:
-			exists, configurable := sql.IsSessionVariableConfigurable(key)
-
-			switch {
-			case exists && configurable:
-				args.SessionDefaults[key] = value
-
-			case !exists:
-				if _, ok := sql.UnsupportedVars[key]; ok {
-					counter := sqltelemetry.UnimplementedClientStatusParameterCounter(key)
-					telemetry.Inc(counter)
+		case "options":
+			// The options parameter carries command-line style arguments, which
+			// are used to specify the cluster name and session variables.
+			opts, err := pgwirebase.ParseOptions(value)
+			if err != nil {
+				return sql.SessionArgs{}, err
+			}
+			for optKey, optValue := range opts {
+				if optKey == pgwirebase.ClusterNameOption {
+					args.ClusterName = optValue
+					continue
 				}
-				log.Warningf(ctx, "unknown configuration parameter: %q", key)
+				if err := loadParameter(ctx, optKey, optValue, &args); err != nil {
+					return sql.SessionArgs{}, err
+				}
+			}
 
-			case !configurable:
-				return sql.SessionArgs{}, pgerror.Newf(pgcode.CantChangeRuntimeParam,
-					"parameter %q cannot be changed", key)
+		default:
+			if err := loadParameter(ctx, key, value, &args); err != nil {
+				return sql.SessionArgs{}, err
 			}
 		}
 	}
@@ -682,6 +718,7 @@ func parseClientProvidedSessionParameters(
 	if !foundBufferSize && sv != nil {
 		// The client did not provide buffer_size; use the cluster setting as default.
 		args.ConnResultsBufferSize = connResultsBufferSize.Get(sv)
+		args.ConnImplicitTxnResultsBufferSize = connImplicitTxnResultsBufferSize.Get(sv)
 	}
 
 	if _, ok := args.SessionDefaults["database"]; !ok {
@@ -693,6 +730,29 @@ func parseClientProvidedSessionParameters(
 	return args, nil
 }
 
+// loadParameter sets the default value of the session variable with the given
+// name in args, if the variable can be configured by the client.
+func loadParameter(ctx context.Context, key, value string, args *sql.SessionArgs) error {
+	exists, configurable := sql.IsSessionVariableConfigurable(key)
+
+	switch {
+	case exists && configurable:
+		args.SessionDefaults[key] = value
+
+	case !exists:
+		if _, ok := sql.UnsupportedVars[key]; ok {
+			counter := sqltelemetry.UnimplementedClientStatusParameterCounter(key)
+			telemetry.Inc(counter)
+		}
+		log.Warningf(ctx, "unknown configuration parameter: %q", key)
+
+	case !configurable:
+		return pgerror.Newf(pgcode.CantChangeRuntimeParam,
+			"parameter %q cannot be changed", key)
+	}
+	return nil
+}
+
 // maybeUpgradeToSecureConn upgrades the connection to TLS/SSL if
 // requested by the client, and available in the server configuration.
 func (s *Server) maybeUpgradeToSecureConn(
@@ -137,15 +137,19 @@ type planNodeReadingOwnWrites interface {
 }
 
 var _ planNode = &alterIndexNode{}
+var _ planNode = &alterJobNode{}
+var _ planNode = &alterScheduleNode{}
 var _ planNode = &alterSchemaNode{}
 var _ planNode = &alterSequenceNode{}
 var _ planNode = &alterTableNode{}
 var _ planNode = &alterTableSetSchemaNode{}
 var _ planNode = &alterTypeNode{}
+var _ planNode = &batchedDMLNode{}
 var _ planNode = &bufferNode{}
 var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &changePrivilegesNode{}
+var _ planNode = &compactNode{}
 var _ planNode = &createDatabaseNode{}
 var _ planNode = &createIndexNode{}
 var _ planNode = &createSequenceNode{}
@@ -191,6 +195,7 @@ var _ planNode = &renameTableNode{}
 var _ planNode = &reparentDatabaseNode{}
 var _ planNode = &renderNode{}
 var _ planNode = &RevokeRoleNode{}
+var _ planNode = &revokeWebSessionsNode{}
 var _ planNode = &rowCountNode{}
 var _ planNode = &scanBufferNode{}
 var _ planNode = &scanNode{}
@@ -209,16 +214,19 @@ var _ planNode = &unionNode{}
 var _ planNode = &updateNode{}
 var _ planNode = &upsertNode{}
 var _ planNode = &valuesNode{}
+var _ planNode = &verifyConsistencyNode{}
 var _ planNode = &virtualTableNode{}
 var _ planNode = &windowNode{}
 var _ planNode = &zeroNode{}
 
+var _ planNodeFastPath = &batchedDMLNode{}
 var _ planNodeFastPath = &deleteRangeNode{}
 var _ planNodeFastPath = &rowCountNode{}
 var _ planNodeFastPath = &serializeNode{}
 var _ planNodeFastPath = &setZoneConfigNode{}
 var _ planNodeFastPath = &controlJobsNode{}
 var _ planNodeFastPath = &controlSchedulesNode{}
+var _ planNodeFastPath = &revokeWebSessionsNode{}
 
 var _ planNodeReadingOwnWrites = &alterIndexNode{}
 var _ planNodeReadingOwnWrites = &alterSchemaNode{}
@@ -585,6 +593,11 @@ const (
 	// planFlagContainsFullIndexScan is set if the plan involves an unconstrained
 	// secondary index scan.
 	planFlagContainsFullIndexScan
+
+	// planFlagStableFolded is set if stable operators (like now()) were
+	// constant-folded during planning, which makes the plan specific to the
+	// current statement.
+	planFlagStableFolded
 )
 
 func (pf planFlags) IsSet(flag planFlags) bool {
@@ -113,6 +113,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.getColumns(mut, colinfo.AlterTableUnsplitColumns)
 	case *unsplitAllNode:
 		return n.getColumns(mut, colinfo.AlterTableUnsplitColumns)
+	case *verifyConsistencyNode:
+		return n.getColumns(mut, colinfo.VerifyConsistencyColumns)
 	case *showTraceReplicaNode:
 		return n.getColumns(mut, colinfo.ShowReplicaTraceColumns)
 	case *sequenceSelectNode:
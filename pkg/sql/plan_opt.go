@@ -438,6 +438,9 @@ func (opc *optPlanningCtx) reuseMemo(cachedMemo *memo.Memo) (*memo.Memo, error)
 	if _, err := opc.optimizer.Optimize(); err != nil {
 		return nil, err
 	}
+	if f.FoldingControl().PermittedStableFold() {
+		opc.flags.Set(planFlagStableFolded)
+	}
 	return f.Memo(), nil
 }
 
@@ -512,6 +515,9 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			return nil, err
 		}
 	}
+	if f.FoldingControl().PermittedStableFold() {
+		opc.flags.Set(planFlagStableFolded)
+	}
 
 	// If this statement doesn't have placeholders and we have not constant-folded
 	// any VolatilityStable operators, add it to the cache.
@@ -17,9 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv"
-	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
-	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -38,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
@@ -85,6 +84,11 @@ type extendedEvalContext struct {
 
 	TxnModesSetter txnModesSetter
 
+	// StmtDeadline, if set, is the time at which the statement being executed
+	// times out because of statement_timeout or transaction_timeout. It is
+	// passed along to the flows set up on remote nodes.
+	StmtDeadline time.Time
+
 	// Jobs refers to jobs in extraTxnState. Jobs is a pointer to a jobsCollection
 	// which is a slice because we need calls to resetExtraTxnState to reset the
 	// jobsCollection.
@@ -93,6 +97,9 @@ type extendedEvalContext struct {
 	// SchemaChangeJobCache refers to schemaChangeJobsCache in extraTxnState.
 	SchemaChangeJobCache map[descpb.ID]*jobs.Job
 
+	// TablesWritten refers to tablesWritten in extraTxnState.
+	TablesWritten map[descpb.ID]struct{}
+
 	schemaAccessors *schemaInterface
 
 	sqlStatsCollector *sqlStatsCollector
@@ -726,18 +733,21 @@ func (p *planner) CompactEngineSpan(
 	if !p.ExecCfg().Codec.ForSystemTenant() {
 		return errorutil.UnsupportedWithMultiTenancy(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
 	}
-	conn, err := p.ExecCfg().DistSender.NodeDialer().Dial(ctx, roachpb.NodeID(nodeID), rpc.DefaultClass)
-	if err != nil {
-		return errors.Wrapf(err, "could not dial node ID %d", nodeID)
+	return compactEngineSpan(
+		ctx, p.ExecCfg(), roachpb.NodeID(nodeID), roachpb.StoreID(storeID),
+		roachpb.Span{Key: roachpb.Key(startKey), EndKey: roachpb.Key(endKey)},
+	)
+}
+
+// QueryTimeSeries is part of the EvalPlanner interface.
+func (p *planner) QueryTimeSeries(
+	ctx context.Context, req *tspb.TimeSeriesQueryRequest,
+) (*tspb.TimeSeriesQueryResponse, error) {
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, errorutil.UnsupportedWithMultiTenancy(errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
 	}
-	client := kvserver.NewPerStoreClient(conn)
-	req := &kvserver.CompactEngineSpanRequest{
-		StoreRequestHeader: kvserver.StoreRequestHeader{
-			NodeID:  roachpb.NodeID(nodeID),
-			StoreID: roachpb.StoreID(storeID),
-		},
-		Span: roachpb.Span{Key: roachpb.Key(startKey), EndKey: roachpb.Key(endKey)},
+	if err := p.RequireAdminRole(ctx, "query time series"); err != nil {
+		return nil, err
 	}
-	_, err = client.CompactEngineSpan(ctx, req)
-	return err
+	return p.ExecCfg().TimeSeriesServer.Query(ctx, req)
 }
@@ -188,5 +188,11 @@ func (p *PreparedPortal) decRef(
 }
 
 func (p PreparedPortal) size(portalName string) int64 {
-	return int64(uintptr(len(portalName)) + unsafe.Sizeof(p))
+	size := int64(uintptr(len(portalName)) + unsafe.Sizeof(p))
+	for _, arg := range p.Qargs {
+		if d, ok := arg.(tree.Datum); ok {
+			size += int64(d.Size())
+		}
+	}
+	return size
 }
@@ -16,7 +16,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 )
 
 // recursiveCTENode implements the logic for a recursive CTE:
@@ -29,6 +31,10 @@ import (
 //       working table.
 // The recursive query tree is regenerated each time using a callback
 // (implemented by the execbuilder).
+//
+// If deduplicate is set (WITH RECURSIVE ... UNION), rows that were already
+// emitted (by the initial query or by any previous iteration) are discarded
+// and are not added to the working table.
 type recursiveCTENode struct {
 	initial planNode
 
@@ -36,6 +42,8 @@ type recursiveCTENode struct {
 
 	label string
 
+	deduplicate bool
+
 	recursiveCTERun
 }
 
@@ -49,6 +57,13 @@ type recursiveCTERun struct {
 
 	initialDone bool
 	done        bool
+
+	// seen contains the fingerprints of all the rows emitted so far; only used
+	// if deduplicate is set.
+	seen    map[string]struct{}
+	seenAcc mon.BoundAccount
+	alloc   rowenc.DatumAlloc
+	scratch []byte
 }
 
 func (n *recursiveCTENode) startExec(params runParams) error {
@@ -57,9 +72,39 @@ func (n *recursiveCTENode) startExec(params runParams) error {
 		colinfo.ColTypeInfoFromResCols(getPlanColumns(n.initial, false /* mut */)),
 	)
 	n.nextRowIdx = 0
+	if n.deduplicate {
+		n.seen = make(map[string]struct{})
+		n.seenAcc = params.EvalContext().Mon.MakeBoundAccount()
+	}
 	return nil
 }
 
+// isDuplicate returns true if deduplication is enabled and the given row was
+// already emitted. Otherwise, the row is remembered as emitted.
+func (n *recursiveCTENode) isDuplicate(ctx context.Context, row tree.Datums) (bool, error) {
+	if !n.deduplicate {
+		return false, nil
+	}
+	cols := planColumns(n.initial)
+	n.scratch = n.scratch[:0]
+	for i := range row {
+		ed := rowenc.DatumToEncDatum(cols[i].Typ, row[i])
+		var err error
+		n.scratch, err = ed.Fingerprint(ctx, cols[i].Typ, &n.alloc, n.scratch, &n.seenAcc)
+		if err != nil {
+			return false, err
+		}
+	}
+	if _, ok := n.seen[string(n.scratch)]; ok {
+		return true, nil
+	}
+	if err := n.seenAcc.Grow(ctx, int64(len(n.scratch))); err != nil {
+		return false, err
+	}
+	n.seen[string(n.scratch)] = struct{}{}
+	return false, nil
+}
+
 func (n *recursiveCTENode) Next(params runParams) (bool, error) {
 	if err := params.p.cancelChecker.Check(); err != nil {
 		return false, err
@@ -68,11 +113,19 @@ func (n *recursiveCTENode) Next(params runParams) (bool, error) {
 	n.nextRowIdx++
 
 	if !n.initialDone {
-		ok, err := n.initial.Next(params)
-		if err != nil {
-			return false, err
-		}
-		if ok {
+		for {
+			ok, err := n.initial.Next(params)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				break
+			}
+			if dup, err := n.isDuplicate(params.ctx, n.initial.Values()); err != nil {
+				return false, err
+			} else if dup {
+				continue
+			}
 			if _, err = n.workingRows.AddRow(params.ctx, n.initial.Values()); err != nil {
 				return false, err
 			}
@@ -119,8 +172,32 @@ func (n *recursiveCTENode) Next(params runParams) (bool, error) {
 		return false, err
 	}
 
-	if err := runPlanInsidePlan(params, newPlan.(*planComponents), n.workingRows); err != nil {
-		return false, err
+	if !n.deduplicate {
+		if err := runPlanInsidePlan(params, newPlan.(*planComponents), n.workingRows); err != nil {
+			return false, err
+		}
+	} else {
+		// Run the iteration into a temporary container and only keep the rows
+		// that were not emitted before.
+		iterationRows := rowcontainer.NewRowContainer(
+			params.EvalContext().Mon.MakeBoundAccount(),
+			colinfo.ColTypeInfoFromResCols(getPlanColumns(n.initial, false /* mut */)),
+		)
+		defer iterationRows.Close(params.ctx)
+		if err := runPlanInsidePlan(params, newPlan.(*planComponents), iterationRows); err != nil {
+			return false, err
+		}
+		for i := 0; i < iterationRows.Len(); i++ {
+			row := iterationRows.At(i)
+			if dup, err := n.isDuplicate(params.ctx, row); err != nil {
+				return false, err
+			} else if dup {
+				continue
+			}
+			if _, err := n.workingRows.AddRow(params.ctx, row); err != nil {
+				return false, err
+			}
+		}
 	}
 	n.nextRowIdx = 1
 	return n.workingRows.Len() > 0, nil
@@ -133,4 +210,7 @@ func (n *recursiveCTENode) Values() tree.Datums {
 func (n *recursiveCTENode) Close(ctx context.Context) {
 	n.initial.Close(ctx)
 	n.workingRows.Close(ctx)
+	if n.deduplicate {
+		n.seenAcc.Close(ctx)
+	}
 }
@@ -0,0 +1,175 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/resultcache"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+var resultCacheEnabledClusterMode = settings.RegisterBoolSetting(
+	"sql.defaults.result_cache.enabled",
+	"default value for enable_result_cache session setting; allows the results of "+
+		"read-only queries to be served from a per-node cache",
+	false,
+)
+
+// resultCacheKey returns the key under which the result of the statement that
+// was just planned can be cached. The second return value is false if the
+// statement is not eligible for the result cache. Eligible statements are
+// SELECT statements run in implicit transactions that only read from regular
+// tables and only contain immutable operations (including any stable
+// operations that were constant-folded during planning), so that their result
+// is determined by the data they read.
+func (ex *connExecutor) resultCacheKey(p *planner) (resultcache.Key, bool) {
+	if !ex.sessionData.ResultCacheEnabled ||
+		ex.executorType != executorTypeExec ||
+		!ex.implicitTxn() ||
+		p.semaCtx.AsOfTimestamp != nil ||
+		p.curPlan.mem == nil ||
+		p.curPlan.flags.IsSet(planFlagStableFolded) {
+		return resultcache.Key{}, false
+	}
+	if _, ok := p.stmt.AST.(*tree.Select); !ok {
+		return resultcache.Key{}, false
+	}
+	root, ok := p.curPlan.mem.RootExpr().(memo.RelExpr)
+	if !ok {
+		return resultcache.Key{}, false
+	}
+	props := root.Relational()
+	if props.CanMutate || props.VolatilitySet.HasStable() || props.VolatilitySet.HasVolatile() {
+		return resultcache.Key{}, false
+	}
+	for _, t := range p.curPlan.mem.Metadata().AllTables() {
+		if t.Table.IsVirtualTable() {
+			return resultcache.Key{}, false
+		}
+	}
+
+	key := resultcache.Key{
+		Statement: tree.AsStringWithFlags(p.stmt.AST, tree.FmtParsable),
+		Database:  ex.sessionData.Database,
+	}
+	if placeholders := p.EvalContext().Placeholders; placeholders != nil &&
+		len(placeholders.Values) > 0 {
+		var b strings.Builder
+		for i, v := range placeholders.Values {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(tree.Serialize(v))
+		}
+		key.Placeholders = b.String()
+	}
+	return key, true
+}
+
+// addCachedResult adds the rows recorded while executing the current statement
+// to the result cache.
+func (ex *connExecutor) addCachedResult(
+	p *planner, key resultcache.Key, recorder *resultCacheRecorder,
+) {
+	if recorder.overflowed || recorder.Err() != nil {
+		return
+	}
+	// The memo is reused by later statements, so we keep a copy of its
+	// metadata, which records the objects the result depends on.
+	md := &opt.Metadata{}
+	md.CopyFrom(p.curPlan.mem.Metadata())
+	ex.server.cfg.ResultCache.Add(key, md, recorder.rows, p.txn.ReadTimestamp(), timeutil.Now())
+}
+
+// recordTableWrite records that the current transaction writes to the given
+// table, so that the cached results read from the table are invalidated when
+// the transaction commits.
+func (p *planner) recordTableWrite(id descpb.ID) {
+	if w := p.extendedEvalCtx.TablesWritten; w != nil {
+		w[id] = struct{}{}
+	}
+}
+
+// invalidateCachedResults invalidates the cached results read from the tables
+// written by the transaction that just committed.
+func (ex *connExecutor) invalidateCachedResults() {
+	if len(ex.extraTxnState.tablesWritten) == 0 {
+		return
+	}
+	ids := make([]descpb.ID, 0, len(ex.extraTxnState.tablesWritten))
+	for id := range ex.extraTxnState.tablesWritten {
+		ids = append(ids, id)
+	}
+	// The transaction committed before the current time of the clock, which
+	// is updated with the timestamps of the responses to the transaction's
+	// requests.
+	ex.server.cfg.ResultCache.InvalidateTables(ids, ex.server.cfg.Clock.Now())
+}
+
+// findCachedResult looks up the result of the current statement in the result
+// cache. Results whose dependencies have changed since they were cached (for
+// example, because of a schema change or new table statistics) are purged.
+func (ex *connExecutor) findCachedResult(
+	ctx context.Context, p *planner, key resultcache.Key,
+) (*resultcache.CachedResult, bool) {
+	cached, ok := ex.server.cfg.ResultCache.Find(key, timeutil.Now())
+	if !ok {
+		return nil, false
+	}
+	upToDate, err := cached.Metadata.CheckDependencies(ctx, p.curPlan.catalog)
+	if err != nil || !upToDate {
+		if err != nil {
+			log.VEventf(ctx, 1, "result cache staleness check failed: %v", err)
+		}
+		ex.server.cfg.ResultCache.Purge(key)
+		return nil, false
+	}
+	log.VEvent(ctx, 2, "serving result from the result cache")
+	return cached, true
+}
+
+// resultCacheRecorder wraps a RestrictedCommandResult and keeps a copy of the
+// rows added to it, so that they can be added to the result cache once the
+// statement completes. Recording stops if the rows become too large to be
+// cached.
+type resultCacheRecorder struct {
+	RestrictedCommandResult
+
+	rows    []tree.Datums
+	size    int64
+	maxSize int64
+	// overflowed is set if the rows were too large to be cached.
+	overflowed bool
+}
+
+var _ RestrictedCommandResult = &resultCacheRecorder{}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (r *resultCacheRecorder) AddRow(ctx context.Context, row tree.Datums) error {
+	if !r.overflowed {
+		r.size += resultcache.EstimateRowSize(row)
+		if r.size > r.maxSize {
+			r.overflowed = true
+			r.rows = nil
+		} else {
+			r.rows = append(r.rows, append(tree.Datums(nil), row...))
+		}
+	}
+	return r.RestrictedCommandResult.AddRow(ctx, row)
+}
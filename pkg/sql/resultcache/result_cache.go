@@ -0,0 +1,234 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package resultcache
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// MaxSize is the maximum amount of memory used by the result cache of a node.
+var MaxSize = settings.RegisterByteSizeSetting(
+	"sql.result_cache.max_size",
+	"maximum amount of memory used by the per-node cache of query results",
+	64<<20, /* 64 MiB */
+	settings.NonNegativeInt,
+)
+
+// TTL is the amount of time for which a cached result can be served.
+var TTL = settings.RegisterDurationSetting(
+	"sql.result_cache.ttl",
+	"maximum amount of time a cached query result is served for; results served "+
+		"from the cache may not reflect writes committed on other nodes during this interval",
+	5*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// maxEntryFraction limits the size of a single cached result to a fraction of
+// the size of the cache, so that one large result can't evict everything else.
+const maxEntryFraction = 16
+
+// rowOverhead is the estimated overhead of each cached row, in addition to the
+// size of its datums.
+const rowOverhead = 24
+
+// Key identifies a cached result.
+type Key struct {
+	// Statement is the statement in its canonical format, so that statements
+	// that only differ in their formatting share entries. Unlike in statement
+	// fingerprints, constants are kept, since they determine the result. The
+	// statement can contain placeholders.
+	Statement string
+	// Database is the current database, which is used to resolve the names in
+	// the statement.
+	Database string
+	// Placeholders contains the serialized values of the placeholders.
+	Placeholders string
+}
+
+// CachedResult is the data associated with a cache entry.
+type CachedResult struct {
+	// Metadata is a copy of the metadata of the memo that was used to plan the
+	// statement. It is used to check that the objects the result depends on
+	// (and their statistics) haven't changed since the result was computed.
+	Metadata *opt.Metadata
+	// Rows contains the result rows. They must not be modified.
+	Rows []tree.Datums
+
+	// tables contains the IDs of the tables the result was read from.
+	tables []descpb.ID
+
+	expiration time.Time
+	size       int64
+}
+
+// C is a per-node cache of the results of read-only queries. It is safe for
+// concurrent use.
+type C struct {
+	st *cluster.Settings
+
+	mu struct {
+		syncutil.Mutex
+
+		cache *cache.UnorderedCache
+		// size is the total size of the cached results.
+		size int64
+		// invalidated maps the IDs of the tables written by transactions that
+		// committed on this node to the time at which the results read from them
+		// were last invalidated. Results read at or before that time are not
+		// added to the cache, since they may not reflect the writes.
+		invalidated map[descpb.ID]hlc.Timestamp
+	}
+}
+
+// New creates a result cache whose size is controlled by the MaxSize cluster
+// setting.
+func New(st *cluster.Settings) *C {
+	c := &C{st: st}
+	c.mu.invalidated = make(map[descpb.ID]hlc.Timestamp)
+	c.mu.cache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(_ int, _, _ interface{}) bool {
+			return c.mu.size > MaxSize.Get(&st.SV)
+		},
+		OnEvicted: func(_, value interface{}) {
+			c.mu.size -= value.(*CachedResult).size
+		},
+	})
+	return c
+}
+
+// Find returns the cached result for the given key, if there is one that has
+// not expired at the given time. The caller is responsible for checking that
+// the result is not stale using its metadata; stale results should be purged.
+func (c *C) Find(key Key, now time.Time) (_ *CachedResult, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.mu.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	res := v.(*CachedResult)
+	if !now.Before(res.expiration) {
+		c.mu.cache.Del(key)
+		return nil, false
+	}
+	return res, true
+}
+
+// Add adds the given result, which was read at the given timestamp, to the
+// cache (possibly evicting other entries), replacing any existing entry with
+// the same key. The result expires after the TTL cluster setting. The metadata
+// and rows must not be modified after this call.
+func (c *C) Add(
+	key Key, md *opt.Metadata, rows []tree.Datums, readTS hlc.Timestamp, now time.Time,
+) {
+	ttl := TTL.Get(&c.st.SV)
+	if ttl == 0 {
+		return
+	}
+	res := &CachedResult{
+		Metadata:   md,
+		Rows:       rows,
+		expiration: now.Add(ttl),
+		size:       int64(len(key.Statement) + len(key.Database) + len(key.Placeholders)),
+	}
+	for _, row := range rows {
+		res.size += EstimateRowSize(row)
+	}
+	if res.size > c.MaxEntrySize() {
+		return
+	}
+	for _, t := range md.AllTables() {
+		res.tables = append(res.tables, descpb.ID(t.Table.ID()))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range res.tables {
+		if ts, ok := c.mu.invalidated[id]; ok && readTS.LessEq(ts) {
+			return
+		}
+	}
+	// Delete any existing entry first so that its size is accounted for.
+	c.mu.cache.Del(key)
+	c.mu.size += res.size
+	c.mu.cache.Add(key, res)
+}
+
+// InvalidateTables removes the results read from any of the given tables,
+// which were written by a transaction that committed before the given
+// timestamp. Results read from these tables at or before the timestamp are not
+// added to the cache afterwards.
+func (c *C) InvalidateTables(ids []descpb.ID, ts hlc.Timestamp) {
+	if len(ids) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		if c.mu.invalidated[id].Less(ts) {
+			c.mu.invalidated[id] = ts
+		}
+	}
+	var keys []interface{}
+	c.mu.cache.Do(func(e *cache.Entry) {
+		res := e.Value.(*CachedResult)
+		for _, t := range res.tables {
+			for _, id := range ids {
+				if t == id {
+					keys = append(keys, e.Key)
+					return
+				}
+			}
+		}
+	})
+	for _, k := range keys {
+		c.mu.cache.Del(k)
+	}
+}
+
+// Purge removes the entry for the given key, if it exists.
+func (c *C) Purge(key Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.cache.Del(key)
+}
+
+// Clear removes all the entries from the cache.
+func (c *C) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.cache.Clear()
+}
+
+// MaxEntrySize returns the size of the largest result that can be cached.
+func (c *C) MaxEntrySize() int64 {
+	return MaxSize.Get(&c.st.SV) / maxEntryFraction
+}
+
+// EstimateRowSize returns the estimated memory usage of a cached row.
+func EstimateRowSize(row tree.Datums) int64 {
+	size := int64(rowOverhead)
+	for _, d := range row {
+		size += int64(d.Size())
+	}
+	return size
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package resultcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func rows(n int) []tree.Datums {
+	res := make([]tree.Datums, n)
+	for i := range res {
+		res[i] = tree.Datums{tree.NewDInt(tree.DInt(i))}
+	}
+	return res
+}
+
+// TestCache tests the main operations of the cache.
+func TestCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	st := cluster.MakeTestingClusterSettings()
+	TTL.Override(&st.SV, time.Minute)
+	c := New(st)
+
+	now := time.Unix(0, 0)
+	a := Key{Statement: "SELECT a FROM t", Database: "defaultdb"}
+	b := Key{Statement: "SELECT a FROM t", Database: "db"}
+
+	if _, ok := c.Find(a, now); ok {
+		t.Fatal("found entry in empty cache")
+	}
+	c.Add(a, &opt.Metadata{}, rows(2), hlc.Timestamp{}, now)
+	c.Add(b, &opt.Metadata{}, rows(3), hlc.Timestamp{}, now)
+	if res, ok := c.Find(a, now); !ok || len(res.Rows) != 2 {
+		t.Fatalf("expected entry with 2 rows, got %v", res)
+	}
+	if res, ok := c.Find(b, now); !ok || len(res.Rows) != 3 {
+		t.Fatalf("expected entry with 3 rows, got %v", res)
+	}
+
+	// Entries expire after the TTL.
+	if _, ok := c.Find(a, now.Add(time.Minute)); ok {
+		t.Error("found expired entry")
+	}
+	if _, ok := c.Find(a, now); ok {
+		t.Error("expired entry was not removed")
+	}
+
+	c.Purge(b)
+	if _, ok := c.Find(b, now); ok {
+		t.Error("found purged entry")
+	}
+	if c.mu.size != 0 {
+		t.Errorf("expected size 0, got %d", c.mu.size)
+	}
+
+	// Results are not cached when the TTL is zero.
+	TTL.Override(&st.SV, 0)
+	c.Add(a, &opt.Metadata{}, rows(1), hlc.Timestamp{}, now)
+	if _, ok := c.Find(a, now); ok {
+		t.Error("found entry added with zero TTL")
+	}
+}
+
+// TestCacheSize tests that the cache respects the MaxSize setting.
+func TestCacheSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	st := cluster.MakeTestingClusterSettings()
+	TTL.Override(&st.SV, time.Minute)
+	c := New(st)
+	now := time.Unix(0, 0)
+
+	rowSize := EstimateRowSize(rows(1)[0])
+	MaxSize.Override(&st.SV, 100*maxEntryFraction*rowSize)
+
+	// Results larger than the maximum entry size are not cached.
+	big := Key{Statement: "big"}
+	c.Add(big, &opt.Metadata{}, rows(100), hlc.Timestamp{}, now)
+	if _, ok := c.Find(big, now); ok {
+		t.Error("found entry larger than the maximum entry size")
+	}
+
+	// Adding more results than fit in the cache evicts the least recently
+	// used ones.
+	keys := make([]Key, 2*maxEntryFraction)
+	for i := range keys {
+		keys[i] = Key{Statement: "q", Placeholders: tree.NewDInt(tree.DInt(i)).String()}
+		c.Add(keys[i], &opt.Metadata{}, rows(90), hlc.Timestamp{}, now)
+		if c.mu.size > MaxSize.Get(&st.SV) {
+			t.Fatalf("cache size %d exceeds maximum", c.mu.size)
+		}
+	}
+	if _, ok := c.Find(keys[0], now); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if _, ok := c.Find(keys[len(keys)-1], now); !ok {
+		t.Error("most recently used entry was evicted")
+	}
+
+	c.Clear()
+	if c.mu.size != 0 {
+		t.Errorf("expected size 0 after Clear, got %d", c.mu.size)
+	}
+}
+
+// TestCacheInvalidateTables tests that writes to a table invalidate the results
+// read from it.
+func TestCacheInvalidateTables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	st := cluster.MakeTestingClusterSettings()
+	TTL.Override(&st.SV, time.Minute)
+	c := New(st)
+	now := time.Unix(0, 0)
+
+	metadata := func(id cat.StableID) *opt.Metadata {
+		md := &opt.Metadata{}
+		md.AddTable(&testcat.Table{TabID: id}, &tree.TableName{})
+		return md
+	}
+	a := Key{Statement: "SELECT * FROM a"}
+	b := Key{Statement: "SELECT * FROM b"}
+	c.Add(a, metadata(1), rows(1), hlc.Timestamp{WallTime: 10}, now)
+	c.Add(b, metadata(2), rows(1), hlc.Timestamp{WallTime: 10}, now)
+
+	c.InvalidateTables([]descpb.ID{1}, hlc.Timestamp{WallTime: 20})
+	if _, ok := c.Find(a, now); ok {
+		t.Error("found result read from a table that was written")
+	}
+	if _, ok := c.Find(b, now); !ok {
+		t.Error("result read from a table that was not written was invalidated")
+	}
+
+	// Results read before the write can't be added after the invalidation.
+	c.Add(a, metadata(1), rows(1), hlc.Timestamp{WallTime: 15}, now)
+	if _, ok := c.Find(a, now); ok {
+		t.Error("found result read before the write")
+	}
+	c.Add(a, metadata(1), rows(1), hlc.Timestamp{WallTime: 25}, now)
+	if _, ok := c.Find(a, now); !ok {
+		t.Error("result read after the write was not cached")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type revokeWebSessionsNode struct {
+	// Either session or user is set.
+	session tree.TypedExpr
+	user    security.SQLUsername
+	isAdmin bool
+
+	numRevoked int
+}
+
+// RevokeWebSessions revokes the sessions used to log into the DB Console and
+// the HTTP APIs.
+// (`REVOKE WEB SESSION` and `REVOKE WEB SESSIONS` statements)
+// Privileges: admin role to revoke the sessions of other users.
+func (p *planner) RevokeWebSessions(
+	ctx context.Context, n *tree.RevokeWebSessions,
+) (planNode, error) {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node := &revokeWebSessionsNode{user: n.User, isAdmin: isAdmin}
+	if n.Session != nil {
+		node.session, err = p.analyzeExpr(
+			ctx, n.Session, nil, tree.IndexedVarHelper{}, types.Int, true /* requireType */, "REVOKE WEB SESSION",
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else if !isAdmin && n.User != p.User() {
+		return nil, pgerror.New(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to revoke the web sessions of other users")
+	}
+	return node, nil
+}
+
+func (n *revokeWebSessionsNode) startExec(params runParams) error {
+	var filter string
+	var args []interface{}
+	if n.session != nil {
+		sessionIDDatum, err := n.session.Eval(params.EvalContext())
+		if err != nil {
+			return err
+		}
+		if sessionIDDatum == tree.DNull {
+			return pgerror.New(pgcode.InvalidParameterValue, "web session ID cannot be NULL")
+		}
+		filter = "id = $1"
+		args = append(args, int64(tree.MustBeDInt(sessionIDDatum)))
+		// Users without the admin role only see their own sessions.
+		if !n.isAdmin {
+			filter += " AND username = $2"
+			args = append(args, params.p.User().Normalized())
+		}
+	} else {
+		filter = "username = $1"
+		args = append(args, n.user.Normalized())
+	}
+
+	ie := params.ExecCfg().InternalExecutor
+	override := sessiondata.InternalExecutorOverride{User: security.RootUserName()}
+	// Sessions which were already revoked keep their original revocation
+	// time, and aren't counted.
+	rows, err := ie.QueryEx(
+		params.ctx, "revoke-web-sessions", params.p.txn, override,
+		fmt.Sprintf(`UPDATE system.web_sessions SET "revokedAt" = now()
+WHERE %s AND "revokedAt" IS NULL RETURNING id`, filter),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	n.numRevoked = len(rows)
+
+	if n.session != nil && n.numRevoked == 0 {
+		row, err := ie.QueryRowEx(
+			params.ctx, "check-web-session", params.p.txn, override,
+			fmt.Sprintf(`SELECT 1 FROM system.web_sessions WHERE %s`, filter),
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			return pgerror.Newf(pgcode.UndefinedObject, "web session %d does not exist", args[0])
+		}
+	}
+	return nil
+}
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *revokeWebSessionsNode) FastPathResults() (int, bool) {
+	return n.numRevoked, true
+}
+
+func (*revokeWebSessionsNode) Next(runParams) (bool, error) { return false, nil }
+func (*revokeWebSessionsNode) Values() tree.Datums          { return nil }
+func (*revokeWebSessionsNode) Close(context.Context)        {}
@@ -206,7 +206,7 @@ func TestJobBackedSeqChunkProvider(t *testing.T) {
 					curVal:          0,
 				}
 				require.NoError(t, j.RequestChunk(evalCtx, annot, seqMetadata))
-				getJobProgressQuery := `SELECT progress FROM system.jobs J WHERE J.id = $1`
+				getJobProgressQuery := `SELECT ` + jobs.JobInfoProgressColumn + ` FROM system.jobs WHERE id = $1`
 
 				var progressBytes []byte
 				require.NoError(t, sqlDB.QueryRow(getJobProgressQuery, *job.ID()).Scan(&progressBytes))
@@ -11,18 +11,43 @@
 package row
 
 import (
+	"context"
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
+var maxRowSizeLog = settings.RegisterByteSizeSetting(
+	"sql.guardrails.max_row_size_log",
+	"maximum size of a single KV written for a row (the row itself, or one of its "+
+		"column families or secondary index entries) before a warning is logged, "+
+		"naming the table and index; use 0 to disable",
+	64<<20, /* 64 MiB */
+	settings.NonNegativeInt,
+).WithPublic()
+
+var maxRowSizeErr = settings.RegisterByteSizeSetting(
+	"sql.guardrails.max_row_size_err",
+	"maximum size of a single KV written for a row (the row itself, or one of its "+
+		"column families or secondary index entries) before the write is rejected "+
+		"with an error naming the table and index; use 0 to disable",
+	512<<20, /* 512 MiB */
+	settings.NonNegativeInt,
+).WithPublic()
+
 // rowHelper has the common methods for table row manipulations.
 type rowHelper struct {
 	Codec keys.SQLCodec
@@ -40,12 +65,25 @@ type rowHelper struct {
 	primaryIndexKeyPrefix []byte
 	primaryIndexCols      catalog.TableColSet
 	sortedColumnFamilies  map[descpb.FamilyID][]descpb.ColumnID
+
+	// Row size guardrails, see checkKVSize. Zero disables a guardrail.
+	maxRowSizeLog int64
+	maxRowSizeErr int64
 }
 
+// newRowHelper creates a rowHelper. sv is used to read the row size
+// guardrails; it can be nil, in which case they are disabled.
 func newRowHelper(
-	codec keys.SQLCodec, desc catalog.TableDescriptor, indexes []descpb.IndexDescriptor,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	indexes []descpb.IndexDescriptor,
+	sv *settings.Values,
 ) rowHelper {
 	rh := rowHelper{Codec: codec, TableDesc: desc, Indexes: indexes}
+	if sv != nil {
+		rh.maxRowSizeLog = maxRowSizeLog.Get(sv)
+		rh.maxRowSizeErr = maxRowSizeErr.Get(sv)
+	}
 
 	// Pre-compute the encoding directions of the index key values for
 	// pretty-printing in traces.
@@ -168,3 +206,36 @@ func (rh *rowHelper) sortedColumnFamily(famID descpb.FamilyID) ([]descpb.ColumnI
 	colIDs, ok := rh.sortedColumnFamilies[famID]
 	return colIDs, ok
 }
+
+// checkKVSize checks the size of a KV about to be written for a row against
+// the sql.guardrails.max_row_size_log and sql.guardrails.max_row_size_err
+// settings. Very large KVs cause problems for raft and snapshots long after
+// they are written, so we'd rather flag them when they are written.
+func (rh *rowHelper) checkKVSize(
+	ctx context.Context, key roachpb.Key, value *roachpb.Value, family descpb.FamilyID,
+) error {
+	size := int64(len(key)) + int64(len(value.RawBytes))
+	shouldLog := rh.maxRowSizeLog != 0 && size > rh.maxRowSizeLog
+	shouldErr := rh.maxRowSizeErr != 0 && size > rh.maxRowSizeErr
+	if !shouldLog && !shouldErr {
+		return nil
+	}
+	indexName := "<unknown>"
+	if _, _, indexID, err := rh.Codec.DecodeIndexPrefix(key); err == nil {
+		if index, err := rh.TableDesc.FindIndexByID(descpb.IndexID(indexID)); err == nil {
+			indexName = index.Name
+		}
+	}
+	if shouldErr {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"row larger than max row size: table %q index %q family %d size %s (%d bytes) "+
+				"exceeds sql.guardrails.max_row_size_err",
+			rh.TableDesc.GetName(), indexName, family, humanizeutil.IBytes(size), size)
+	}
+	log.Warningf(ctx,
+		"large row: table %q (%d) index %q family %d key %s size %s (%d bytes) "+
+			"exceeds sql.guardrails.max_row_size_log",
+		rh.TableDesc.GetName(), rh.TableDesc.GetID(), indexName, family, key,
+		humanizeutil.IBytes(size), size)
+	return nil
+}
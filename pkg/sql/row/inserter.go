@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -41,7 +42,8 @@ type Inserter struct {
 
 // MakeInserter creates a Inserter for the given table.
 //
-// insertCols must contain every column in the primary key.
+// insertCols must contain every column in the primary key. sv is used to
+// enforce the row size guardrails and can be nil to disable them.
 func MakeInserter(
 	ctx context.Context,
 	txn *kv.Txn,
@@ -49,9 +51,10 @@ func MakeInserter(
 	tableDesc *tabledesc.Immutable,
 	insertCols []descpb.ColumnDescriptor,
 	alloc *rowenc.DatumAlloc,
+	sv *settings.Values,
 ) (Inserter, error) {
 	ri := Inserter{
-		Helper:                newRowHelper(codec, tableDesc, tableDesc.WritableIndexes()),
+		Helper:                newRowHelper(codec, tableDesc, tableDesc.WritableIndexes(), sv),
 		InsertCols:            insertCols,
 		InsertColIDtoRowIndex: ColIDtoRowIndexFromCols(insertCols),
 		marshaled:             make([]roachpb.Value, len(insertCols)),
@@ -175,6 +178,9 @@ func (ri *Inserter) InsertRow(
 	putFn = insertInvertedPutFn
 	for i := range secondaryIndexEntries {
 		e := &secondaryIndexEntries[i]
+		if err := ri.Helper.checkKVSize(ctx, e.Key, &e.Value, e.Family); err != nil {
+			return err
+		}
 		putFn(ctx, b, &e.Key, &e.Value, traceKV)
 	}
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -333,6 +334,10 @@ func NewDatumRowConverter(
 		return nil, errors.Wrap(err, "process default and computed columns")
 	}
 
+	var sv *settings.Values
+	if evalCtx.Settings != nil {
+		sv = &evalCtx.Settings.SV
+	}
 	ri, err := MakeInserter(
 		ctx,
 		nil, /* txn */
@@ -340,6 +345,7 @@ func NewDatumRowConverter(
 		tableDesc,
 		cols,
 		&rowenc.DatumAlloc{},
+		sv,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "make row inserter")
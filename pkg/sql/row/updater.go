@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -80,7 +81,8 @@ var returnTruePseudoError error = returnTrue{}
 // The returned Updater contains a FetchCols field that defines the
 // expectation of which values are passed as oldValues to UpdateRow.
 // requestedCols must be non-nil and define the schema that determines
-// FetchCols.
+// FetchCols. sv is used to enforce the row size guardrails and can be nil to
+// disable them.
 func MakeUpdater(
 	ctx context.Context,
 	txn *kv.Txn,
@@ -90,6 +92,7 @@ func MakeUpdater(
 	requestedCols []descpb.ColumnDescriptor,
 	updateType rowUpdaterType,
 	alloc *rowenc.DatumAlloc,
+	sv *settings.Values,
 ) (Updater, error) {
 	if requestedCols == nil {
 		return Updater{}, errors.AssertionFailedf("requestedCols is nil in MakeUpdater")
@@ -165,12 +168,12 @@ func MakeUpdater(
 
 	var deleteOnlyHelper *rowHelper
 	if len(deleteOnlyIndexes) > 0 {
-		rh := newRowHelper(codec, tableDesc, deleteOnlyIndexes)
+		rh := newRowHelper(codec, tableDesc, deleteOnlyIndexes, nil /* sv */)
 		deleteOnlyHelper = &rh
 	}
 
 	ru := Updater{
-		Helper:                newRowHelper(codec, tableDesc, includeIndexes),
+		Helper:                newRowHelper(codec, tableDesc, includeIndexes, sv),
 		DeleteHelper:          deleteOnlyHelper,
 		UpdateCols:            updateCols,
 		UpdateColIDtoRowIndex: updateColIDtoRowIndex,
@@ -189,7 +192,7 @@ func MakeUpdater(
 		ru.FetchCols = ru.rd.FetchCols
 		ru.FetchColIDtoRowIndex = ColIDtoRowIndexFromCols(ru.FetchCols)
 		if ru.ri, err = MakeInserter(
-			ctx, txn, codec, tableDesc, tableCols, alloc,
+			ctx, txn, codec, tableDesc, tableCols, alloc, sv,
 		); err != nil {
 			return Updater{}, err
 		}
@@ -440,6 +443,17 @@ func (ru *Updater) UpdateRow(
 		return nil, err
 	}
 
+	// Check the size of the new secondary index entries before writing any of
+	// them; entries identical to the old ones were already removed above.
+	for i := range ru.newIndexEntries {
+		for j := range ru.newIndexEntries[i] {
+			e := &ru.newIndexEntries[i][j]
+			if err := ru.Helper.checkKVSize(ctx, e.Key, &e.Value, e.Family); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Update secondary indexes.
 	// We're iterating through all of the indexes, which should have corresponding entries
 	// in the new and old values.
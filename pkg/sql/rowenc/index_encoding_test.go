@@ -633,6 +633,22 @@ func TestArrayEncoding(t *testing.T) {
 				HasNulls: true,
 			},
 			[]byte{17, 3, 9, 6, 1, 2, 4, 6, 8, 10, 12},
+		}, {
+			"tuple array",
+			tree.DArray{
+				ParamTyp: types.MakeTuple([]*types.T{types.Int, types.String}),
+				Array: tree.Datums{
+					tree.NewDTuple(
+						types.MakeTuple([]*types.T{types.Int, types.String}),
+						tree.NewDInt(1), tree.NewDString("a"),
+					),
+					tree.NewDTuple(
+						types.MakeTuple([]*types.T{types.Int, types.String}),
+						tree.NewDInt(2), tree.NewDString("b"),
+					),
+				},
+			},
+			[]byte{1, 15, 16, 2, 2, 3, 2, 6, 1, 97, 2, 3, 4, 6, 1, 98},
 		},
 	}
 
@@ -698,6 +698,12 @@ func init() {
 			continue
 		}
 
+		// Don't include tuples, since arrays of tuples can't be stored in
+		// columns.
+		if typ.Family() == types.TupleFamily {
+			continue
+		}
+
 		// Don't include reg types, since parser currently doesn't allow them to
 		// be declared as array element types.
 		if typ.Family() == types.OidFamily && typ.Oid() != oid.T_oid {
@@ -13,6 +13,7 @@ package rowexec
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -187,6 +188,9 @@ func (b *backfiller) mainLoop(
 				return nil, err
 			}
 			chunks++
+			if err := b.throttle(ctx, start, int64(totalChunks+chunks)*b.spec.ChunkSize); err != nil {
+				return nil, err
+			}
 			running := timeutil.Since(start)
 			if running > opportunisticCheckpointAfter && b.chunks.CurrentBufferFill() > opportunisticCheckpointThreshold {
 				break
@@ -221,6 +225,31 @@ func (b *backfiller) mainLoop(
 	return finishedSpans, nil
 }
 
+// throttle waits as long as needed for the given number of rows processed
+// since start to not exceed the maximum rate of the spec, if any. The number
+// of rows processed is approximated by the number of chunks processed times
+// the chunk size.
+func (b *backfiller) throttle(ctx context.Context, start time.Time, rows int64) error {
+	if b.spec.MaxRowsPerSecond <= 0 {
+		return nil
+	}
+	wait := time.Duration(float64(rows)/float64(b.spec.MaxRowsPerSecond)*float64(time.Second)) -
+		timeutil.Since(start)
+	if wait <= 0 {
+		return nil
+	}
+	var timer timeutil.Timer
+	defer timer.Stop()
+	timer.Reset(wait)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		timer.Read = true
+		return nil
+	}
+}
+
 // GetResumeSpans returns a ResumeSpanList from a job.
 func GetResumeSpans(
 	ctx context.Context,
@@ -293,12 +322,24 @@ func GetResumeSpans(
 func SetResumeSpansInJob(
 	ctx context.Context, spans []roachpb.Span, mutationIdx int, txn *kv.Txn, job *jobs.Job,
 ) error {
-	details, ok := job.Details().(jobspb.SchemaChangeDetails)
-	if !ok {
-		return errors.Errorf("expected SchemaChangeDetails job type, got %T", job.Details())
-	}
-	details.ResumeSpanList[mutationIdx].ResumeSpans = spans
-	return job.WithTxn(txn).SetDetails(ctx, details)
+	return job.WithTxn(txn).Update(ctx, func(
+		_ *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		if err := md.CheckRunningOrReverting(); err != nil {
+			return err
+		}
+		// Start from the persisted details rather than the ones cached in the
+		// job, so as to not overwrite the backfill limits if they were altered
+		// while the job was running.
+		details, ok := md.Payload.UnwrapDetails().(jobspb.SchemaChangeDetails)
+		if !ok {
+			return errors.Errorf("expected SchemaChangeDetails job type, got %T", md.Payload.UnwrapDetails())
+		}
+		details.ResumeSpanList[mutationIdx].ResumeSpans = spans
+		md.Payload.Details = jobspb.WrapPayloadDetails(details)
+		ju.UpdatePayload(md.Payload)
+		return nil
+	})
 }
 
 // WriteResumeSpan writes a checkpoint for the backfill work on origSpan.
@@ -12,6 +12,7 @@ package rowexec
 
 import (
 	"context"
+	"math"
 	"sort"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -99,6 +101,24 @@ type joinReader struct {
 	// Batch size for fetches. Not a constant so we can lower for testing.
 	batchSizeBytes    int64
 	curBatchSizeBytes int64
+	// minBatchSizeBytes and maxBatchSizeBytes bound batchSizeBytes. When they
+	// differ, batchSizeBytes is adapted after each lookup; see adaptBatchSize.
+	minBatchSizeBytes int64
+	maxBatchSizeBytes int64
+
+	// curLookup describes the lookup of the current batch of input rows. The
+	// lookup time and the size of the looked up rows are only tracked when
+	// the batch size is adaptive.
+	curLookup struct {
+		inputBytes    int64
+		lookedUpBytes int64
+		lookupTime    time.Duration
+	}
+
+	// numLookups and lookupInputBytes are the number of lookups performed and
+	// the total size of the batches of input rows they were performed for.
+	numLookups       int64
+	lookupInputBytes int64
 
 	// rowsRead is the total number of rows that this fetcher read from
 	// disk.
@@ -314,7 +334,7 @@ func newJoinReader(
 	}
 
 	jr.initJoinReaderStrategy(flowCtx, columnTypes, len(columnIDs), rightCols, readerType)
-	jr.batchSizeBytes = jr.strategy.getLookupRowsBatchSizeHint()
+	jr.initBatchSize(flowCtx)
 
 	// TODO(radu): verify the input types match the index key types
 	return jr, nil
@@ -405,9 +425,78 @@ func getIndexColSet(
 	return cols, err
 }
 
-// SetBatchSizeBytes sets the desired batch size. It should only be used in tests.
+// joinReaderTargetLookupLatency is the latency of the lookup of a batch of
+// input rows that adaptive batch sizing aims for. Slower lookups keep the
+// joinReader from emitting rows for too long, while much faster lookups don't
+// amortize the overhead of each KV request well.
+const joinReaderTargetLookupLatency = 20 * time.Millisecond
+
+// initBatchSize initializes the batch size and its bounds. The session can
+// override either bound; the default for both is the batch size hint of the
+// strategy, in which case the batch size is fixed.
+func (jr *joinReader) initBatchSize(flowCtx *execinfra.FlowCtx) {
+	hint := jr.strategy.getLookupRowsBatchSizeHint()
+	jr.minBatchSizeBytes, jr.maxBatchSizeBytes = hint, hint
+	if sd := flowCtx.EvalCtx.SessionData; sd != nil {
+		if sd.JoinReaderMinBatchSize > 0 {
+			jr.minBatchSizeBytes = sd.JoinReaderMinBatchSize
+		}
+		if sd.JoinReaderMaxBatchSize > 0 {
+			jr.maxBatchSizeBytes = sd.JoinReaderMaxBatchSize
+		}
+	}
+	if jr.maxBatchSizeBytes < jr.minBatchSizeBytes {
+		jr.maxBatchSizeBytes = jr.minBatchSizeBytes
+	}
+	jr.batchSizeBytes = jr.clampBatchSize(hint)
+}
+
+// adaptiveBatchSize returns whether the batch size is adapted after each
+// lookup.
+func (jr *joinReader) adaptiveBatchSize() bool {
+	return jr.minBatchSizeBytes < jr.maxBatchSizeBytes
+}
+
+func (jr *joinReader) clampBatchSize(size int64) int64 {
+	if size < jr.minBatchSizeBytes {
+		return jr.minBatchSizeBytes
+	}
+	if size > jr.maxBatchSizeBytes {
+		return jr.maxBatchSizeBytes
+	}
+	return size
+}
+
+// adaptBatchSize adjusts the batch size after the lookup of a batch of input
+// rows. The batch size is scaled so that the lookup takes about
+// joinReaderTargetLookupLatency and, when the looked up rows are wide, so that
+// they take up about maxBatchSizeBytes. The batch size changes by at most a
+// factor of 2 after each lookup.
+func (jr *joinReader) adaptBatchSize() {
+	if !jr.adaptiveBatchSize() || jr.curLookup.inputBytes < jr.batchSizeBytes {
+		// The batch was cut short by the end of the input, so the lookup says
+		// little about batches of the current size.
+		return
+	}
+	factor := 2.0
+	if t := jr.curLookup.lookupTime; t > 0 {
+		factor = math.Min(factor, float64(joinReaderTargetLookupLatency)/float64(t))
+	}
+	if b := jr.curLookup.lookedUpBytes; b > 0 {
+		factor = math.Min(factor, float64(jr.maxBatchSizeBytes)/float64(b))
+	}
+	factor = math.Max(factor, 0.5)
+	jr.batchSizeBytes = jr.clampBatchSize(int64(float64(jr.batchSizeBytes) * factor))
+	log.VEventf(jr.Ctx, 2, "lookup of %d bytes of input rows took %s and returned %d bytes, "+
+		"next batch size is %d bytes", jr.curLookup.inputBytes, jr.curLookup.lookupTime,
+		jr.curLookup.lookedUpBytes, jr.batchSizeBytes)
+}
+
+// SetBatchSizeBytes sets the desired batch size, which is then fixed. It should
+// only be used in tests.
 func (jr *joinReader) SetBatchSizeBytes(batchSize int64) {
 	jr.batchSizeBytes = batchSize
+	jr.minBatchSizeBytes, jr.maxBatchSizeBytes = batchSize, batchSize
 }
 
 // Spilled returns whether the joinReader spilled to disk.
@@ -553,6 +642,7 @@ func (jr *joinReader) readInput() (
 		return jrStateUnknown, nil, jr.DrainHelper()
 	}
 	jr.scratchInputRows = jr.scratchInputRows[:0]
+	inputBytes := jr.curBatchSizeBytes
 	jr.curBatchSizeBytes = 0
 	if len(spans) == 0 {
 		// All of the input rows were filtered out. Skip the index lookup.
@@ -575,12 +665,24 @@ func (jr *joinReader) readInput() (
 	}
 
 	log.VEventf(jr.Ctx, 1, "scanning %d spans", len(spans))
+	jr.numLookups++
+	jr.lookupInputBytes += inputBytes
+	jr.curLookup.inputBytes = inputBytes
+	jr.curLookup.lookedUpBytes = 0
+	jr.curLookup.lookupTime = 0
+	var start time.Time
+	if jr.adaptiveBatchSize() {
+		start = timeutil.Now()
+	}
 	if err := jr.fetcher.StartScan(
 		jr.Ctx, jr.FlowCtx.Txn, spans, jr.shouldLimitBatches, 0, /* limitHint */
 		jr.FlowCtx.TraceKV); err != nil {
 		jr.MoveToDraining(err)
 		return jrStateUnknown, nil, jr.DrainHelper()
 	}
+	if jr.adaptiveBatchSize() {
+		jr.curLookup.lookupTime += timeutil.Since(start)
+	}
 
 	return jrPerformingLookup, outRow, nil
 }
@@ -606,16 +708,27 @@ func (jr *joinReader) performLookup() (joinReaderState, *execinfrapb.ProducerMet
 		}
 
 		// Fetch the next row and tell the strategy to process it.
+		adaptive := jr.adaptiveBatchSize()
+		var start time.Time
+		if adaptive {
+			start = timeutil.Now()
+		}
 		lookedUpRow, _, _, err := jr.fetcher.NextRow(jr.Ctx)
 		if err != nil {
 			jr.MoveToDraining(scrub.UnwrapScrubError(err))
 			return jrStateUnknown, jr.DrainHelper()
 		}
+		if adaptive {
+			jr.curLookup.lookupTime += timeutil.Since(start)
+		}
 		if lookedUpRow == nil {
 			// Done with this input batch.
 			break
 		}
 		jr.rowsRead++
+		if adaptive {
+			jr.curLookup.lookedUpBytes += int64(lookedUpRow.Size())
+		}
 
 		if nextState, err := jr.strategy.processLookedUpRow(jr.Ctx, lookedUpRow, key); err != nil {
 			jr.MoveToDraining(err)
@@ -625,6 +738,7 @@ func (jr *joinReader) performLookup() (joinReaderState, *execinfrapb.ProducerMet
 		}
 	}
 	log.VEvent(jr.Ctx, 1, "done joining rows")
+	jr.adaptBatchSize()
 	jr.strategy.prepareToEmit(jr.Ctx)
 
 	return jrEmittingRows, nil
@@ -689,9 +803,11 @@ func (jr *joinReader) execStatsForTrace() *execinfrapb.ComponentStats {
 	return &execinfrapb.ComponentStats{
 		Inputs: []execinfrapb.InputStats{is},
 		KV: execinfrapb.KVStats{
-			TuplesRead:     fis.NumTuples,
-			KVTime:         fis.WaitTime,
-			ContentionTime: optional.MakeTimeValue(jr.GetCumulativeContentionTime()),
+			TuplesRead:       fis.NumTuples,
+			KVTime:           fis.WaitTime,
+			ContentionTime:   optional.MakeTimeValue(jr.GetCumulativeContentionTime()),
+			LookupBatches:    optional.MakeUint(uint64(jr.numLookups)),
+			LookupBatchBytes: optional.MakeUint(uint64(jr.lookupInputBytes)),
 		},
 		Output: jr.Out.Stats(),
 	}
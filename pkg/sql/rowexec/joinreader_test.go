@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -790,6 +791,85 @@ func TestJoinReader(t *testing.T) {
 	}
 }
 
+func TestJoinReaderAdaptBatchSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const minSize, maxSize = 1 << 10, 1 << 20
+	testCases := []struct {
+		desc          string
+		batchSize     int64
+		inputBytes    int64
+		lookedUpBytes int64
+		lookupTime    time.Duration
+		expected      int64
+	}{
+		{
+			desc:       "fast lookup doubles the batch size",
+			batchSize:  64 << 10,
+			inputBytes: 64 << 10,
+			lookupTime: time.Millisecond,
+			expected:   128 << 10,
+		},
+		{
+			desc:       "slow lookup halves the batch size",
+			batchSize:  64 << 10,
+			inputBytes: 64 << 10,
+			lookupTime: time.Second,
+			expected:   32 << 10,
+		},
+		{
+			desc:       "moderately slow lookup scales the batch size",
+			batchSize:  64 << 10,
+			inputBytes: 64 << 10,
+			lookupTime: 40 * time.Millisecond,
+			expected:   32 << 10,
+		},
+		{
+			desc:          "wide looked up rows limit the batch size",
+			batchSize:     64 << 10,
+			inputBytes:    64 << 10,
+			lookedUpBytes: 2 << 20,
+			lookupTime:    time.Millisecond,
+			expected:      32 << 10,
+		},
+		{
+			desc:       "batch size doesn't grow beyond the maximum",
+			batchSize:  maxSize,
+			inputBytes: maxSize,
+			lookupTime: time.Millisecond,
+			expected:   maxSize,
+		},
+		{
+			desc:       "batch size doesn't shrink below the minimum",
+			batchSize:  minSize,
+			inputBytes: minSize,
+			lookupTime: time.Second,
+			expected:   minSize,
+		},
+		{
+			desc:       "partial batch doesn't change the batch size",
+			batchSize:  64 << 10,
+			inputBytes: 1 << 10,
+			lookupTime: time.Second,
+			expected:   64 << 10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			jr := &joinReader{}
+			jr.Ctx = context.Background()
+			jr.minBatchSizeBytes, jr.maxBatchSizeBytes = minSize, maxSize
+			jr.batchSizeBytes = tc.batchSize
+			jr.curLookup.inputBytes = tc.inputBytes
+			jr.curLookup.lookedUpBytes = tc.lookedUpBytes
+			jr.curLookup.lookupTime = tc.lookupTime
+			jr.adaptBatchSize()
+			require.Equal(t, tc.expected, jr.batchSizeBytes)
+		})
+	}
+}
+
 func TestJoinReaderDiskSpill(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	ctx := context.Background()
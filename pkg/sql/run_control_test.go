@@ -15,8 +15,10 @@ import (
 	gosql "database/sql"
 	gosqldriver "database/sql/driver"
 	"fmt"
+	"math"
 	"math/rand"
 	"net/url"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -939,6 +941,75 @@ func TestStatementTimeoutRetryableErrors(t *testing.T) {
 		})
 }
 
+// TestQueryTimeoutsRecorded verifies that statements interrupted by the
+// statement_timeout or the transaction_timeout of their session are told apart
+// from statements canceled by a client, both in the statement statistics and
+// in the event log.
+func TestQueryTimeoutsRecorded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, `SET statement_timeout = '100ms'`)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, `SELECT pg_sleep(10)`)
+	require.Regexp(t, "query execution canceled due to statement timeout", err)
+
+	_, err = conn.ExecContext(ctx, `SET statement_timeout = 0; SET transaction_timeout = '100ms'`)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, `SELECT pg_sleep(10)`)
+	require.Regexp(t, "query execution canceled due to transaction timeout", err)
+
+	_, err = conn.ExecContext(ctx, `SET transaction_timeout = 0`)
+	require.NoError(t, err)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := conn.ExecContext(ctx, `SELECT pg_sleep(10)`)
+		errCh <- err
+	}()
+	const cancelQuery = `CANCEL QUERIES SELECT query_id FROM [SHOW CLUSTER QUERIES] WHERE query = 'SELECT pg_sleep(10)'`
+	testutils.SucceedsSoon(t, func() error {
+		var n int
+		sqlDB.QueryRow(t, `SELECT count(*) FROM [SHOW CLUSTER QUERIES] WHERE query = 'SELECT pg_sleep(10)'`).Scan(&n)
+		if n == 0 {
+			return errors.New("query not running yet")
+		}
+		return nil
+	})
+	sqlDB.Exec(t, cancelQuery)
+	require.True(t, isClientsideQueryCanceledErr(<-errCh))
+
+	var timedOut, canceled int
+	sqlDB.QueryRow(t, `
+SELECT sum(timed_out_count), sum(canceled_count)
+  FROM crdb_internal.node_statement_statistics
+ WHERE key = 'SELECT pg_sleep(_)'`).Scan(&timedOut, &canceled)
+	require.Equal(t, 2, timedOut)
+	require.Equal(t, 1, canceled)
+
+	log.Flush()
+	entries, err := log.FetchEntriesFromFiles(0, math.MaxInt64, 10000,
+		regexp.MustCompile(`"EventType":"query_canceled"`), log.WithMarkedSensitiveData)
+	require.NoError(t, err)
+	var reasons []string
+	for _, e := range entries {
+		for _, reason := range []string{"statement_timeout", "transaction_timeout", "canceled"} {
+			if strings.Contains(e.Message, `"Reason":"`+reason+`"`) {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	require.ElementsMatch(t, []string{"statement_timeout", "transaction_timeout", "canceled"}, reasons)
+}
+
 func getUserConn(t *testing.T, username string, server serverutils.TestServerInterface) *gosql.DB {
 	pgURL := url.URL{
 		Scheme:   "postgres",
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
@@ -40,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -234,38 +237,22 @@ func (sc *SchemaChanger) refreshMaterializedView(
 func (sc *SchemaChanger) backfillQueryIntoTable(
 	ctx context.Context, table *descpb.TableDescriptor, query string, ts hlc.Timestamp, desc string,
 ) error {
-	if fn := sc.testingKnobs.RunBeforeQueryBackfill; fn != nil {
-		if err := fn(); err != nil {
-			return err
-		}
+	if err := sc.startQueryBackfill(ctx); err != nil {
+		return err
+	}
+
+	// The table is not public yet, so any data in its indexes was written by a
+	// previous attempt at running the query which did not complete, for
+	// instance because the node running the job crashed. The data is cleared so
+	// that the rows it contains are not duplicated when the query is run again
+	// after the job is resumed.
+	if err := sc.clearQueryBackfillIndexes(ctx, table); err != nil {
+		return err
 	}
 
 	return sc.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 		txn.SetFixedTimestamp(ctx, ts)
 
-		// Create an internal planner as the planner used to serve the user query
-		// would have committed by this point.
-		p, cleanup := NewInternalPlanner(desc, txn, security.RootUserName(), &MemoryMetrics{}, sc.execCfg, sessiondatapb.SessionData{})
-		defer cleanup()
-		localPlanner := p.(*planner)
-		stmt, err := parser.ParseOne(query)
-		if err != nil {
-			return err
-		}
-
-		// Construct an optimized logical plan of the AS source stmt.
-		localPlanner.stmt = makeStatement(stmt, ClusterWideID{} /* queryID */)
-		localPlanner.optPlanningCtx.init(localPlanner)
-
-		localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
-			err = localPlanner.makeOptimizerPlan(ctx)
-		})
-
-		if err != nil {
-			return err
-		}
-		defer localPlanner.curPlan.close(ctx)
-
 		res := roachpb.BulkOpSummary{}
 		rw := newCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
 			// TODO(adityamaru): Use the BulkOpSummary for either telemetry or to
@@ -277,60 +264,151 @@ func (sc *SchemaChanger) backfillQueryIntoTable(
 			res.Add(counts)
 			return nil
 		})
-		recv := MakeDistSQLReceiver(
-			ctx,
-			rw,
-			tree.Rows,
-			sc.execCfg.RangeDescriptorCache,
-			txn,
-			sc.clock,
-			// Make a session tracing object on-the-fly. This is OK
-			// because it sets "enabled: false" and thus none of the
-			// other fields are used.
-			&SessionTracing{},
-		)
-		defer recv.Release()
-
-		var planAndRunErr error
-		localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
-			// Resolve subqueries before running the queries' physical plan.
-			if len(localPlanner.curPlan.subqueryPlans) != 0 {
-				if !sc.distSQLPlanner.PlanAndRunSubqueries(
-					ctx, localPlanner, localPlanner.ExtendedEvalContextCopy,
-					localPlanner.curPlan.subqueryPlans, recv,
-				) {
-					if planAndRunErr = rw.Err(); planAndRunErr != nil {
-						return
-					}
-					if planAndRunErr = recv.commErr; planAndRunErr != nil {
-						return
-					}
-				}
-			}
-
-			isLocal := !getPlanDistribution(
-				ctx, localPlanner, localPlanner.execCfg.NodeID,
-				localPlanner.extendedEvalCtx.SessionData.DistSQLMode,
-				localPlanner.curPlan.main,
-			).WillDistribute()
+		return sc.planAndRunBackfillQuery(ctx, txn, query, desc, rw, func(
+			localPlanner *planner, isLocal bool, recv *DistSQLReceiver,
+		) {
 			out := execinfrapb.ProcessorCoreUnion{BulkRowWriter: &execinfrapb.BulkRowWriterSpec{
 				Table: *table,
 			}}
-
 			PlanAndRunCTAS(ctx, sc.distSQLPlanner, localPlanner,
 				txn, isLocal, localPlanner.curPlan.main, out, recv)
-			if planAndRunErr = rw.Err(); planAndRunErr != nil {
-				return
-			}
-			if planAndRunErr = recv.commErr; planAndRunErr != nil {
-				return
-			}
 		})
+	})
+}
+
+// startQueryBackfill runs the testing knob and updates the running status of
+// the job before a query based backfill.
+func (sc *SchemaChanger) startQueryBackfill(ctx context.Context) error {
+	if fn := sc.testingKnobs.RunBeforeQueryBackfill; fn != nil {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	if sc.job != nil {
+		if err := sc.job.RunningStatus(ctx, func(
+			context.Context, jobspb.Details,
+		) (jobs.RunningStatus, error) {
+			return RunningStatusBackfill, nil
+		}); err != nil {
+			return errors.Wrapf(err, "failed to update running status of job %d", errors.Safe(*sc.job.ID()))
+		}
+	}
+	return nil
+}
+
+// clearQueryBackfillIndexes removes all the data in the indexes of table. Only
+// the indexes of the descriptor are cleared, as a materialized view being
+// refreshed is backfilled into new indexes while its current indexes are
+// still being read.
+func (sc *SchemaChanger) clearQueryBackfillIndexes(
+	ctx context.Context, table *descpb.TableDescriptor,
+) error {
+	indexes := append([]descpb.IndexDescriptor{table.PrimaryIndex}, table.Indexes...)
+	for i := range indexes {
+		prefix := sc.execCfg.Codec.IndexPrefix(uint32(table.ID), uint32(indexes[i].ID))
+		// ClearRange cannot be batched with other requests.
+		b := &kv.Batch{}
+		b.AddRawRequest(&roachpb.ClearRangeRequest{
+			RequestHeader: roachpb.RequestHeader{
+				Key:    prefix,
+				EndKey: prefix.PrefixEnd(),
+			},
+		})
+		if err := sc.db.Run(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		return planAndRunErr
+// planAndRunBackfillQuery plans query using an internal planner bound to txn,
+// runs its subqueries and then calls runMain to run the main plan. The results
+// are sent to rw.
+func (sc *SchemaChanger) planAndRunBackfillQuery(
+	ctx context.Context,
+	txn *kv.Txn,
+	query string,
+	desc string,
+	rw *callbackResultWriter,
+	runMain func(localPlanner *planner, isLocal bool, recv *DistSQLReceiver),
+) error {
+	// Create an internal planner as the planner used to serve the user query
+	// would have committed by this point.
+	p, cleanup := NewInternalPlanner(desc, txn, security.RootUserName(), &MemoryMetrics{}, sc.execCfg, sessiondatapb.SessionData{})
+	defer cleanup()
+	localPlanner := p.(*planner)
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+
+	// Construct an optimized logical plan of the AS source stmt.
+	localPlanner.stmt = makeStatement(stmt, ClusterWideID{} /* queryID */)
+	localPlanner.optPlanningCtx.init(localPlanner)
+
+	localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
+		err = localPlanner.makeOptimizerPlan(ctx)
 	})
+
+	if err != nil {
+		return err
+	}
+	defer localPlanner.curPlan.close(ctx)
+
+	recv := MakeDistSQLReceiver(
+		ctx,
+		rw,
+		tree.Rows,
+		sc.execCfg.RangeDescriptorCache,
+		txn,
+		sc.clock,
+		// Make a session tracing object on-the-fly. This is OK
+		// because it sets "enabled: false" and thus none of the
+		// other fields are used.
+		&SessionTracing{},
+	)
+	defer recv.Release()
+
+	var planAndRunErr error
+	localPlanner.runWithOptions(resolveFlags{skipCache: true}, func() {
+		// Resolve subqueries before running the queries' physical plan.
+		if len(localPlanner.curPlan.subqueryPlans) != 0 {
+			if !sc.distSQLPlanner.PlanAndRunSubqueries(
+				ctx, localPlanner, localPlanner.ExtendedEvalContextCopy,
+				localPlanner.curPlan.subqueryPlans, recv,
+			) {
+				if planAndRunErr = rw.Err(); planAndRunErr != nil {
+					return
+				}
+				if planAndRunErr = recv.commErr; planAndRunErr != nil {
+					return
+				}
+			}
+		}
+
+		isLocal := !getPlanDistribution(
+			ctx, localPlanner, localPlanner.execCfg.NodeID,
+			localPlanner.extendedEvalCtx.SessionData.DistSQLMode,
+			localPlanner.curPlan.main,
+		).WillDistribute()
+
+		runMain(localPlanner, isLocal, recv)
+		if planAndRunErr = rw.Err(); planAndRunErr != nil {
+			return
+		}
+		if planAndRunErr = recv.commErr; planAndRunErr != nil {
+			return
+		}
+	})
+
+	return planAndRunErr
 }
 
+// ctasBackfillChunkSize is the maximum number of rows of the AS query of a
+// CREATE TABLE AS statement written to the new table in one transaction.
+const ctasBackfillChunkSize = 1000
+
 // maybe backfill a created table by executing the AS query. Return nil if
 // successfully backfilled.
 //
@@ -346,7 +424,184 @@ func (sc *SchemaChanger) maybeBackfillCreateTableAs(
 	}
 	log.Infof(ctx, "starting backfill for CREATE TABLE AS with query %q", table.CreateQuery)
 
-	return sc.backfillQueryIntoTable(ctx, table.TableDesc(), table.CreateQuery, table.CreateAsOfTime, "ctasBackfill")
+	if err := sc.startQueryBackfill(ctx); err != nil {
+		return err
+	}
+	return sc.backfillCreateTableAs(ctx, table)
+}
+
+// backfillCreateTableAs populates the table created by a CREATE TABLE AS
+// statement with the results of its AS query, read as of the time of the
+// creation of the table.
+//
+// The rows are written in chunks of ctasBackfillChunkSize rows, each in its
+// own transaction which also checkpoints the number of rows written so far in
+// the progress of the job. When all the columns of the query can be ordered,
+// the query is run with an ORDER BY on all of them so that it produces its
+// rows in the same order every time, and a resumed backfill skips the rows
+// which were checkpointed. Otherwise, the table is cleared and the backfill
+// starts over.
+func (sc *SchemaChanger) backfillCreateTableAs(
+	ctx context.Context, table *tabledesc.Immutable,
+) error {
+	cols := table.VisibleColumns()
+	resumable := sc.job != nil && len(cols) > 0
+	for i := range cols {
+		if !colinfo.ColumnTypeIsIndexable(cols[i].Type) {
+			resumable = false
+		}
+	}
+
+	var written int64
+	if resumable {
+		// The progress is loaded from the job record rather than from the
+		// in-memory job, which may reflect a checkpoint whose transaction
+		// failed to commit.
+		job, err := sc.jobRegistry.LoadJob(ctx, *sc.job.ID())
+		if err != nil {
+			return err
+		}
+		if progress := job.Progress().GetSchemaChange(); progress != nil {
+			written = progress.CTASRowsBackfilled
+		}
+	}
+	if written == 0 {
+		// Any data in the table was written by a previous attempt at the
+		// backfill which cannot be resumed.
+		if err := sc.clearQueryBackfillIndexes(ctx, table.TableDesc()); err != nil {
+			return err
+		}
+	} else {
+		log.Infof(ctx, "resuming backfill for CREATE TABLE AS after %d rows", written)
+	}
+
+	query := table.CreateQuery
+	if resumable {
+		ordinals := make([]string, len(cols))
+		for i := range ordinals {
+			ordinals[i] = strconv.Itoa(i + 1)
+		}
+		query = fmt.Sprintf("SELECT * FROM (%s) AS q ORDER BY %s", query, strings.Join(ordinals, ", "))
+	}
+
+	chunkSize := sc.getChunkSize(ctasBackfillChunkSize)
+	return sc.fixedTimestampTxn(ctx, table.CreateAsOfTime, func(ctx context.Context, txn *kv.Txn) error {
+		if !resumable && written > 0 {
+			// The transaction was retried after some chunks were written, but the
+			// rows of the query may come out in a different order this time.
+			if err := sc.clearQueryBackfillIndexes(ctx, table.TableDesc()); err != nil {
+				return err
+			}
+			written = 0
+		}
+		// The rows which were written by a previous attempt are skipped.
+		skip := written
+		chunk := make([]tree.Datums, 0, chunkSize)
+		var evalCtx *tree.EvalContext
+		flush := func(ctx context.Context) error {
+			if len(chunk) == 0 {
+				return nil
+			}
+			if err := sc.writeCreateTableAsChunk(
+				ctx, table, evalCtx, chunk, written, resumable,
+			); err != nil {
+				return err
+			}
+			written += int64(len(chunk))
+			chunk = chunk[:0]
+			if fn := sc.testingKnobs.RunAfterCTASBackfillChunk; fn != nil {
+				return fn(written)
+			}
+			return nil
+		}
+		rw := newCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+			if skip > 0 {
+				skip--
+				return nil
+			}
+			// The row is reused by the receiver, so it is copied.
+			chunk = append(chunk, append(tree.Datums(nil), row...))
+			if int64(len(chunk)) < chunkSize {
+				return nil
+			}
+			return flush(ctx)
+		})
+		return sc.planAndRunBackfillQuery(ctx, txn, query, "ctasBackfill", rw, func(
+			localPlanner *planner, isLocal bool, recv *DistSQLReceiver,
+		) {
+			evalCtx = &localPlanner.extendedEvalCtx.EvalContext
+			planCtx := sc.distSQLPlanner.NewPlanningCtx(
+				ctx, localPlanner.ExtendedEvalContext(), localPlanner, txn, !isLocal,
+			)
+			planCtx.stmtType = tree.Rows
+			sc.distSQLPlanner.PlanAndRun(
+				ctx, localPlanner.ExtendedEvalContextCopy(), planCtx, txn, localPlanner.curPlan.main, recv,
+			)()
+			if rw.Err() != nil || recv.commErr != nil {
+				return
+			}
+			if err := flush(ctx); err != nil {
+				rw.SetError(err)
+			}
+		})
+	})
+}
+
+// writeCreateTableAsChunk writes rows, which start at row firstRow of the AS
+// query, to the table created by a CREATE TABLE AS statement in a single
+// transaction. If checkpoint is set, the number of rows written so far is
+// recorded in the progress of the job in the same transaction.
+func (sc *SchemaChanger) writeCreateTableAsChunk(
+	ctx context.Context,
+	table *tabledesc.Immutable,
+	evalCtx *tree.EvalContext,
+	rows []tree.Datums,
+	firstRow int64,
+	checkpoint bool,
+) error {
+	return sc.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		// The converter sends at most one batch of KVs for each row, which is
+		// consumed before the next row is converted.
+		kvCh := make(chan row.KVBatch, 1)
+		conv, err := row.NewDatumRowConverter(
+			ctx, table, nil /* targetColNames */, evalCtx, kvCh, nil, /* seqChunkProvider */
+		)
+		if err != nil {
+			return err
+		}
+		b := txn.NewBatch()
+		addKVs := func() {
+			select {
+			case kvBatch := <-kvCh:
+				for i := range kvBatch.KVs {
+					b.CPut(kvBatch.KVs[i].Key, &kvBatch.KVs[i].Value, nil /* expValue */)
+				}
+			default:
+			}
+		}
+		for i, datums := range rows {
+			copy(conv.Datums, datums)
+			// The row index is used to generate the hidden primary key, if any, so
+			// a row gets the same key every time it is backfilled.
+			if err := conv.Row(ctx, 0 /* sourceID */, firstRow+int64(i)); err != nil {
+				return err
+			}
+			addKVs()
+		}
+		if err := conv.SendBatch(ctx); err != nil {
+			return err
+		}
+		addKVs()
+		if err := txn.Run(ctx, b); err != nil {
+			return row.ConvertBatchError(ctx, table, b)
+		}
+		if !checkpoint {
+			return nil
+		}
+		return sc.job.WithTxn(txn).SetProgress(ctx, jobspb.SchemaChangeProgress{
+			CTASRowsBackfilled: firstRow + int64(len(rows)),
+		})
+	})
 }
 
 func (sc *SchemaChanger) maybeBackfillMaterializedView(
@@ -1862,6 +2117,12 @@ func CreateGCJobRecord(
 // dependencies.
 type GCJobTestingKnobs struct {
 	RunBeforeResume func(jobID int64) error
+
+	// TimeSource, if set, replaces the wall clock consulted by the GC job to
+	// determine whether the GC TTL of the dropped tables and indexes has
+	// expired, and to schedule its next check. Tests can use a
+	// timeutil.ManualTime to trigger the GC deterministically.
+	TimeSource timeutil.TimeSource
 }
 
 // ModuleTestingKnobs is part of the base.ModuleTestingKnobs interface.
@@ -1886,6 +2147,10 @@ type SchemaChangerTestingKnobs struct {
 	// RunBeforeQueryBackfill is called before a query based backfill.
 	RunBeforeQueryBackfill func() error
 
+	// RunAfterCTASBackfillChunk is called after each chunk of rows of a CREATE
+	// TABLE AS backfill is committed, with the number of rows written so far.
+	RunAfterCTASBackfillChunk func(rowsBackfilled int64) error
+
 	// RunBeforeIndexBackfill is called just before starting the index backfill, after
 	// fixing the index backfill scan timestamp.
 	RunBeforeIndexBackfill func()
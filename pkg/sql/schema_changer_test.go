@@ -504,6 +504,68 @@ func TestRollbackOfAddingTable(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestCreateTableAsBackfillResumes tests that the backfill of a CREATE TABLE AS
+// statement which fails after writing some chunks of rows resumes after the
+// last checkpointed chunk, without duplicating any rows.
+func TestCreateTableAsBackfillResumes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numRows = 100
+	const chunkSize = 10
+
+	// Protects checkpoints and shouldError.
+	var mu syncutil.Mutex
+	var checkpoints []int64
+	shouldError := true
+
+	params, _ := tests.CreateTestServerParams()
+	params.Knobs = base.TestingKnobs{
+		SQLSchemaChanger: &sql.SchemaChangerTestingKnobs{
+			BackfillChunkSize: chunkSize,
+			RunAfterCTASBackfillChunk: func(rowsBackfilled int64) error {
+				mu.Lock()
+				defer mu.Unlock()
+				checkpoints = append(checkpoints, rowsBackfilled)
+				if shouldError && rowsBackfilled == numRows/2 {
+					shouldError = false
+					return context.DeadlineExceeded
+				}
+				return nil
+			},
+		},
+	}
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE d.src (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `INSERT INTO d.src SELECT i, i::STRING FROM generate_series(1, $1) AS g(i)`, numRows)
+	sqlDB.Exec(t, `CREATE TABLE d.dst AS SELECT v, k FROM d.src`)
+
+	// The backfill resumed after the chunk at which it failed rather than
+	// starting over.
+	var expected []int64
+	for i := int64(chunkSize); i <= numRows; i += chunkSize {
+		expected = append(expected, i)
+	}
+	mu.Lock()
+	require.Equal(t, expected, checkpoints)
+	mu.Unlock()
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*), count(DISTINCT k), count(DISTINCT rowid) FROM d.dst`,
+		[][]string{{"100", "100", "100"}},
+	)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM d.src FULL JOIN d.dst USING (k, v) WHERE src.k IS NULL OR dst.k IS NULL`,
+		[][]string{{"0"}},
+	)
+}
+
 // Test schema change backfills are not affected by various operations
 // that run simultaneously.
 func TestRaceWithBackfill(t *testing.T) {
@@ -5993,6 +6055,11 @@ func TestMultipleRevert(t *testing.T) {
 	db = sqlDB
 	defer s.Stopper().Stop(context.Background())
 
+	// Retry the job right away rather than after a backoff.
+	if _, err := sqlDB.Exec(`SET CLUSTER SETTING jobs.registry.retry.initial_delay = '0s'`); err != nil {
+		t.Fatal(err)
+	}
+
 	// Disable strict GC TTL enforcement because we're going to shove a zero-value
 	// TTL into the system with AddImmediateGCZoneConfig.
 	defer sqltestutils.DisableGCTTLStrictEnforcement(t, sqlDB)()
@@ -0,0 +1,268 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// The schema guardrails limit the number and size of schema objects, which
+// protects shared clusters from schemas which grow out of hand, for example
+// when generated by an ORM. All of them are disabled by default. They are
+// only enforced when objects are created or added to, so existing schemas
+// which exceed them remain usable and can be shrunk.
+
+var maxDatabases = settings.RegisterIntSetting(
+	"sql.guardrails.max_databases",
+	"maximum number of databases in the cluster, excluding the system "+
+		"database; use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+var maxTables = settings.RegisterIntSetting(
+	"sql.guardrails.max_tables",
+	"maximum number of tables, views and sequences in the cluster, excluding "+
+		"those in the system database; use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+var maxColumnsPerTable = settings.RegisterIntSetting(
+	"sql.guardrails.max_columns_per_table",
+	"maximum number of visible columns in a table; use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+var maxIndexesPerTable = settings.RegisterIntSetting(
+	"sql.guardrails.max_indexes_per_table",
+	"maximum number of secondary indexes on a table; use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+var maxNameLength = settings.RegisterIntSetting(
+	"sql.guardrails.max_name_length",
+	"maximum length in bytes of the names of new databases, tables, views, "+
+		"sequences, columns and indexes; use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+).WithPublic()
+
+// withGuardrailHint adds a hint to err pointing at the setting controlling
+// the guardrail which was hit.
+func withGuardrailHint(err error, setting string) error {
+	return errors.WithHintf(err,
+		"The limit is controlled by the %s cluster setting.", setting)
+}
+
+// checkNameLength checks the name of a new schema object of the given kind
+// against the sql.guardrails.max_name_length setting.
+func checkNameLength(sv *settings.Values, kind, name string) error {
+	if limit := maxNameLength.Get(sv); limit > 0 && int64(len(name)) > limit {
+		return withGuardrailHint(pgerror.Newf(pgcode.NameTooLong,
+			"%s name %q is %d bytes long, which exceeds the limit of %d bytes",
+			kind, name, len(name), limit,
+		), "sql.guardrails.max_name_length")
+	}
+	return nil
+}
+
+// checkDatabaseCountLimit checks that a database can be created without
+// exceeding the sql.guardrails.max_databases setting.
+func (p *planner) checkDatabaseCountLimit(ctx context.Context, name string) error {
+	limit := maxDatabases.Get(&p.ExecCfg().Settings.SV)
+	if limit == 0 {
+		return nil
+	}
+	dbs, err := p.Descriptors().GetAllDatabaseDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	var count int64
+	for _, db := range dbs {
+		if db.GetID() != keys.SystemDatabaseID && !db.Dropped() {
+			count++
+		}
+	}
+	if count >= limit {
+		return withGuardrailHint(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"cannot create database %q: the cluster already has %d databases, "+
+				"which is the limit", name, count,
+		), "sql.guardrails.max_databases")
+	}
+	return nil
+}
+
+// checkTableCountLimit checks that a table, view or sequence can be created
+// without exceeding the sql.guardrails.max_tables setting. The number of
+// tables is read from the TableCountCache, so the check is approximate.
+func (p *planner) checkTableCountLimit(ctx context.Context, name string) error {
+	limit := maxTables.Get(&p.ExecCfg().Settings.SV)
+	if limit == 0 {
+		return nil
+	}
+	count, err := p.ExecCfg().TableCountCache.get(ctx, p.ExecCfg())
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return withGuardrailHint(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"cannot create relation %q: the cluster already has %d tables, views "+
+				"and sequences, which is the limit", name, count,
+		), "sql.guardrails.max_tables")
+	}
+	return nil
+}
+
+var tableCountRefreshInterval = settings.RegisterDurationSetting(
+	"sql.guardrails.max_tables.count_refresh_interval",
+	"how long the number of tables checked against sql.guardrails.max_tables "+
+		"is cached before being counted again",
+	30*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// TableCountCache caches the number of tables, views and sequences in the
+// cluster, excluding those in the system database, for the
+// sql.guardrails.max_tables guardrail. Counting them requires reading all the
+// descriptors, which is too expensive to do in every transaction creating a
+// table and would make these transactions conflict with every other schema
+// change. Instead, the tables are counted in a separate transaction at most
+// once per sql.guardrails.max_tables.count_refresh_interval, and the tables
+// created or dropped in the meantime are not accounted for.
+type TableCountCache struct {
+	mu struct {
+		syncutil.Mutex
+		count     int64
+		refreshed time.Time
+	}
+}
+
+// NewTableCountCache returns an empty TableCountCache.
+func NewTableCountCache() *TableCountCache {
+	return &TableCountCache{}
+}
+
+// get returns the cached number of tables, counting them again if the count
+// is older than the refresh interval. A nil cache counts the tables every
+// time.
+func (c *TableCountCache) get(ctx context.Context, execCfg *ExecutorConfig) (int64, error) {
+	if c != nil {
+		c.mu.Lock()
+		count, refreshed := c.mu.count, c.mu.refreshed
+		c.mu.Unlock()
+		interval := tableCountRefreshInterval.Get(&execCfg.Settings.SV)
+		if !refreshed.IsZero() && timeutil.Since(refreshed) < interval {
+			return count, nil
+		}
+	}
+
+	refreshed := timeutil.Now()
+	var count int64
+	if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		count = 0
+		descs, err := catalogkv.GetAllDescriptors(ctx, txn, execCfg.Codec)
+		if err != nil {
+			return err
+		}
+		for _, desc := range descs {
+			if _, ok := desc.(catalog.TableDescriptor); ok &&
+				desc.GetParentID() != keys.SystemDatabaseID && !desc.Dropped() {
+				count++
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if c != nil {
+		c.mu.Lock()
+		if c.mu.refreshed.Before(refreshed) {
+			c.mu.count, c.mu.refreshed = count, refreshed
+		}
+		c.mu.Unlock()
+	}
+	return count, nil
+}
+
+// checkTableSchemaLimits checks the columns and indexes of a table against
+// the sql.guardrails.max_columns_per_table, max_indexes_per_table and
+// max_name_length settings. Only columns and indexes whose IDs are at least
+// firstNewColumnID and firstNewIndexID respectively are considered new; the
+// limits are only enforced if the table gained new columns or indexes. IDs
+// must have been allocated on desc.
+func checkTableSchemaLimits(
+	sv *settings.Values,
+	desc *tabledesc.Mutable,
+	firstNewColumnID descpb.ColumnID,
+	firstNewIndexID descpb.IndexID,
+) error {
+	var numColumns int64
+	var addedColumn bool
+	for _, col := range desc.AllNonDropColumns() {
+		if col.Hidden {
+			continue
+		}
+		numColumns++
+		if col.ID >= firstNewColumnID {
+			addedColumn = true
+			if err := checkNameLength(sv, "column", col.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if limit := maxColumnsPerTable.Get(sv); limit > 0 && addedColumn && numColumns > limit {
+		return withGuardrailHint(pgerror.Newf(pgcode.TooManyColumns,
+			"table %q would have %d columns, which exceeds the limit of %d",
+			desc.Name, numColumns, limit,
+		), "sql.guardrails.max_columns_per_table")
+	}
+
+	var numIndexes int64
+	var addedIndex bool
+	for _, idx := range desc.AllNonDropIndexes() {
+		if idx.ID >= firstNewIndexID {
+			addedIndex = true
+			if err := checkNameLength(sv, "index", idx.Name); err != nil {
+				return err
+			}
+		}
+		// Besides the primary index itself, this skips the new primary index
+		// added by ALTER PRIMARY KEY.
+		if idx.ID != desc.PrimaryIndex.ID && idx.EncodingType != descpb.PrimaryIndexEncoding {
+			numIndexes++
+		}
+	}
+	if limit := maxIndexesPerTable.Get(sv); limit > 0 && addedIndex && numIndexes > limit {
+		return withGuardrailHint(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"table %q would have %d secondary indexes, which exceeds the limit of %d",
+			desc.Name, numIndexes, limit,
+		), "sql.guardrails.max_indexes_per_table")
+	}
+	return nil
+}
@@ -2768,7 +2768,7 @@ may increase either contention or retry errors, or both.`,
 		},
 	),
 
-	"array_append": setProps(arrayPropsNullableArgs(), arrayBuiltin(func(typ *types.T) tree.Overload {
+	"array_append": setProps(arrayPropsNullableArgs(), withOverloads(arrayBuiltin(func(typ *types.T) tree.Overload {
 		return tree.Overload{
 			Types:      tree.ArgTypes{{"array", types.MakeArray(typ)}, {"elem", typ}},
 			ReturnType: tree.FixedReturnType(types.MakeArray(typ)),
@@ -2778,6 +2778,20 @@ may increase either contention or retry errors, or both.`,
 			Info:       "Appends `elem` to `array`, returning the result.",
 			Volatility: tree.VolatilityImmutable,
 		}
+	}), tree.Overload{
+		// Arrays of tuples are used to track the path of a recursive CTE with a
+		// CYCLE clause.
+		Types:      tree.ArgTypes{{"array", types.MakeArray(types.AnyTuple)}, {"elem", types.AnyTuple}},
+		ReturnType: tree.IdentityReturnType(0),
+		Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			typ := args[1].ResolvedType()
+			if args[0] != tree.DNull {
+				typ = tree.MustBeDArray(args[0]).ParamTyp
+			}
+			return tree.AppendToMaybeNullArray(typ, args[0], args[1])
+		},
+		Info:       "Appends `elem` to `array`, returning the result.",
+		Volatility: tree.VolatilityImmutable,
 	})),
 
 	"array_prepend": setProps(arrayPropsNullableArgs(), arrayBuiltin(func(typ *types.T) tree.Overload {
@@ -3127,17 +3141,6 @@ may increase either contention or retry errors, or both.`,
 	"crdb_internal.pb_to_json": makeBuiltin(
 		jsonProps(),
 		func() []tree.Overload {
-			pbToJSON := func(typ string, data []byte, emitDefaults bool) (tree.Datum, error) {
-				msg, err := protoreflect.DecodeMessage(typ, data)
-				if err != nil {
-					return nil, err
-				}
-				j, err := protoreflect.MessageToJSON(msg, emitDefaults)
-				if err != nil {
-					return nil, err
-				}
-				return tree.NewDJSON(j), nil
-			}
 			returnType := tree.FixedReturnType(types.Jsonb)
 			const info = "Converts protocol message to its JSONB representation."
 			volatility := tree.VolatilityImmutable
@@ -3202,6 +3205,24 @@ may increase either contention or retry errors, or both.`,
 			Volatility: tree.VolatilityImmutable,
 		}),
 
+	"crdb_internal.job_payload_to_json": makePBToJSONBuiltin(
+		"cockroach.sql.jobs.jobspb.Payload",
+		"Decodes the payload of a job, as stored in the payload column of system.jobs, "+
+			"into its JSONB representation.",
+	),
+
+	"crdb_internal.job_progress_to_json": makePBToJSONBuiltin(
+		"cockroach.sql.jobs.jobspb.Progress",
+		"Decodes the progress of a job, as stored in the progress column of system.jobs, "+
+			"into its JSONB representation.",
+	),
+
+	"crdb_internal.descriptor_to_json": makePBToJSONBuiltin(
+		"cockroach.sql.sqlbase.Descriptor",
+		"Decodes a descriptor, as stored in the descriptor column of system.descriptor, "+
+			"into its JSONB representation.",
+	),
+
 	// Enum functions.
 	"enum_first": makeBuiltin(
 		tree.FunctionProperties{NullableArgs: true, Category: categoryEnum},
@@ -3949,6 +3970,18 @@ may increase either contention or retry errors, or both.`,
 		tree.FunctionProperties{
 			Category: categorySystemInfo,
 		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"raw_key", types.Bytes},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDString(roachpb.Key(tree.MustBeDBytes(args[0])).String()), nil
+			},
+			Info: "Returns the human-readable representation of a raw key, such as the keys " +
+				"reported by crdb_internal.ranges or by debug zip, e.g. /Table/53/1/1.",
+			Volatility: tree.VolatilityImmutable,
+		},
 		tree.Overload{
 			Types: tree.ArgTypes{
 				{"raw_key", types.Bytes},
@@ -3961,7 +3994,41 @@ may increase either contention or retry errors, or both.`,
 					roachpb.Key(tree.MustBeDBytes(args[0])),
 					int(tree.MustBeDInt(args[1])))), nil
 			},
-			Info:       "This function is used only by CockroachDB's developers for testing purposes.",
+			Info: "Returns the human-readable representation of a raw key with its first " +
+				"skip_fields + 1 fields omitted, e.g. /1 for the key /Table/53/1/1 and a skip_fields of 2.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	// Used by debug zip to redact the SQL statements it collects.
+	"crdb_internal.hide_sql_constants": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"val", types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				stmts, err := parser.Parse(string(tree.MustBeDString(args[0])))
+				if err != nil {
+					// The constants can't be told apart from the rest of the text, so
+					// hide all of it.
+					return tree.NewDString(""), nil
+				}
+				var buf strings.Builder
+				for i, stmt := range stmts {
+					if i > 0 {
+						buf.WriteString("; ")
+					}
+					buf.WriteString(tree.AsStringWithFlags(stmt.AST, tree.FmtHideConstants))
+				}
+				return tree.NewDString(buf.String()), nil
+			},
+			Info: "Replaces the constants in the given SQL statements with placeholders, " +
+				"e.g. SELECT * FROM t WHERE k = _ for SELECT * FROM t WHERE k = 1. " +
+				"Returns an empty string if the statements cannot be parsed.",
 			Volatility: tree.VolatilityImmutable,
 		},
 	),
@@ -3998,7 +4065,8 @@ may increase either contention or retry errors, or both.`,
 				}
 				return jsonDatum, nil
 			},
-			Info:       "This function is used to retrieve range statistics information as a JSON object.",
+			Info: "Returns the MVCC statistics of the range containing key as a JSONB object, " +
+				"e.g. its live_bytes, key_count and intent_count.",
 			Volatility: tree.VolatilityVolatile,
 		},
 	),
@@ -4299,6 +4367,38 @@ may increase either contention or retry errors, or both.`,
 		},
 	),
 
+	"crdb_internal.reset_feature_usage": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         categorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(evalCtx *tree.EvalContext, _ tree.Datums) (tree.Datum, error) {
+				if evalCtx.SessionAccessor == nil {
+					return nil, errors.AssertionFailedf("session accessor not set")
+				}
+				isAdmin, err := evalCtx.SessionAccessor.HasAdminRole(evalCtx.Ctx())
+				if err != nil {
+					return nil, err
+				}
+				if !isAdmin {
+					return nil, pgerror.New(pgcode.InsufficientPrivilege,
+						"only users with the admin role are allowed to reset crdb_internal.feature_usage")
+				}
+				// The counters are reset the same way as when a diagnostics report is
+				// sent, so the reset counts are not part of the next report.
+				reset := telemetry.GetFeatureCounts(telemetry.Raw, telemetry.ResetCounts)
+				return tree.NewDInt(tree.DInt(len(reset))), nil
+			},
+			Info: "Resets the feature usage counters of the gateway node, shown in " +
+				"crdb_internal.feature_usage, and returns the number of counters that were reset. " +
+				"The reset counts are not included in the next diagnostics report.",
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
 	"crdb_internal.round_decimal_values": makeBuiltin(
 		tree.FunctionProperties{
 			Category: categorySystemInfo,
@@ -5353,6 +5453,39 @@ func jsonProps() tree.FunctionProperties {
 	}
 }
 
+// pbToJSON decodes data as the protocol message typ and returns its JSONB
+// representation.
+func pbToJSON(typ string, data []byte, emitDefaults bool) (tree.Datum, error) {
+	msg, err := protoreflect.DecodeMessage(typ, data)
+	if err != nil {
+		return nil, err
+	}
+	j, err := protoreflect.MessageToJSON(msg, emitDefaults)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDJSON(j), nil
+}
+
+// makePBToJSONBuiltin returns a builtin decoding its argument as the protocol
+// message pbName. Unlike crdb_internal.pb_to_json, callers don't need to know
+// the name of the message, which is not part of the stable interface.
+func makePBToJSONBuiltin(pbName string, info string) builtinDefinition {
+	return makeBuiltin(
+		jsonProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"data", types.Bytes}},
+			ReturnType: tree.FixedReturnType(types.Jsonb),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				const emitDefaults = true
+				return pbToJSON(pbName, []byte(tree.MustBeDBytes(args[0])), emitDefaults)
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		},
+	)
+}
+
 func jsonPropsNullableArgs() tree.FunctionProperties {
 	d := jsonProps()
 	d.NullableArgs = true
@@ -5556,6 +5689,13 @@ func arrayBuiltin(impl func(*types.T) tree.Overload) builtinDefinition {
 	}
 }
 
+// withOverloads returns the given builtin definition with the given overloads
+// added to it.
+func withOverloads(d builtinDefinition, overloads ...tree.Overload) builtinDefinition {
+	d.overloads = append(d.overloads, overloads...)
+	return d
+}
+
 func setProps(props tree.FunctionProperties, d builtinDefinition) builtinDefinition {
 	d.props = props
 	return d
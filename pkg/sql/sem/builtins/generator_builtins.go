@@ -13,6 +13,8 @@ package builtins
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -23,10 +25,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/arith"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -309,6 +313,126 @@ var generators = map[string]builtinDefinition{
 			tree.VolatilityVolatile,
 		),
 	),
+
+	"crdb_internal.timeseries": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "name", Typ: types.String},
+				{Name: "start", Typ: types.TimestampTZ},
+				{Name: "end", Typ: types.TimestampTZ},
+			},
+			timeseriesGeneratorType,
+			makeTimeseriesGenerator,
+			"Returns the datapoints recorded for the named metric in the internal "+
+				"time series database between start and end, averaged over 10 second "+
+				"periods and summed across all of its sources (nodes or stores).\n\n"+
+				"Example usage:\n"+
+				"SELECT * FROM crdb_internal.timeseries('cr.node.sql.query.count', now() - '1h', now())",
+			tree.VolatilityVolatile,
+		),
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "name", Typ: types.String},
+				{Name: "start", Typ: types.TimestampTZ},
+				{Name: "end", Typ: types.TimestampTZ},
+				{Name: "sample_period", Typ: types.Interval},
+			},
+			timeseriesGeneratorType,
+			makeTimeseriesGenerator,
+			"Returns the datapoints recorded for the named metric in the internal "+
+				"time series database between start and end, averaged over periods "+
+				"of sample_period and summed across all of its sources (nodes or stores). "+
+				"sample_period must be a multiple of 10 seconds.",
+			tree.VolatilityVolatile,
+		),
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "name", Typ: types.String},
+				{Name: "start", Typ: types.TimestampTZ},
+				{Name: "end", Typ: types.TimestampTZ},
+				{Name: "sample_period", Typ: types.Interval},
+				{Name: "source", Typ: types.String},
+			},
+			timeseriesGeneratorType,
+			makeTimeseriesGenerator,
+			"Returns the datapoints recorded for the named metric in the internal "+
+				"time series database between start and end by the given source (a node "+
+				"or store ID), averaged over periods of sample_period. sample_period must "+
+				"be a multiple of 10 seconds.",
+			tree.VolatilityVolatile,
+		),
+	),
+
+	"crdb_internal.show_create_all": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "database_name", Typ: types.String},
+			},
+			showCreateAllGeneratorType,
+			makeShowCreateAllGenerator,
+			fmt.Sprintf("Returns the statements needed to recreate the tables, views and "+
+				"sequences of the given database, one statement per row, in a canonical "+
+				"order suitable for comparing schemas programmatically.\n\n"+
+				"All CREATE statements are returned first, ordered so that every "+
+				"descriptor follows the descriptors it depends on, including the tables "+
+				"it references through foreign keys (ties are broken by descriptor ID), followed by the ALTER statements that add foreign keys "+
+				"and interleaved indexes, followed by the statements that validate "+
+				"those foreign keys. Statements use the SHOW CREATE formatting, qualify "+
+				"object names with their schema and carry no trailing semicolon.\n\n"+
+				"The format_version column identifies these guarantees and is bumped "+
+				"whenever the ordering or formatting of the output changes. The current "+
+				"version is %d.", ShowCreateAllFormatVersion),
+			tree.VolatilityVolatile,
+		),
+	),
+
+	"crdb_internal.schema_diff": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "source_database", Typ: types.String},
+				{Name: "target_database", Typ: types.String},
+			},
+			schemaDiffGeneratorType,
+			makeSchemaDiffGenerator,
+			"Compares the tables, views and sequences of two databases and returns "+
+				"one row per difference. "+schemaDiffInfo,
+			tree.VolatilityVolatile,
+		),
+	),
+
+	"crdb_internal.schema_diff_ddl": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "source_database", Typ: types.String},
+				{Name: "target_ddl", Typ: types.String},
+			},
+			schemaDiffGeneratorType,
+			makeSchemaDiffScriptGenerator,
+			"Compares the tables, views and sequences of a database with those "+
+				"defined by a script of DDL statements and returns one row per "+
+				"difference. Statements in the script that do not define tables, "+
+				"views, sequences, columns, indexes or constraints are ignored. "+
+				"Views and sequences are compared as written, so the script is best "+
+				"produced by crdb_internal.show_create_all. "+schemaDiffInfo,
+			tree.VolatilityVolatile,
+		),
+	),
 }
 
 func makeGeneratorOverload(
@@ -1224,3 +1348,246 @@ func (c *checkConsistencyGenerator) Values() (tree.Datums, error) {
 
 // Close is part of the tree.ValueGenerator interface.
 func (c *checkConsistencyGenerator) Close() {}
+
+// timeseriesGenerator supports the execution of crdb_internal.timeseries.
+type timeseriesGenerator struct {
+	planner tree.EvalPlanner
+	req     tspb.TimeSeriesQueryRequest
+	// datapoints is populated by Start(). Each Next() call advances idx.
+	datapoints []tspb.TimeSeriesDatapoint
+	idx        int
+}
+
+var _ tree.ValueGenerator = &timeseriesGenerator{}
+
+func makeTimeseriesGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	if !ctx.Codec.ForSystemTenant() {
+		return nil, errorutil.UnsupportedWithMultiTenancy(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+	}
+
+	name := string(tree.MustBeDString(args[0]))
+	start := tree.MustBeDTimestampTZ(args[1]).UnixNano()
+	end := tree.MustBeDTimestampTZ(args[2]).UnixNano()
+	if start >= end {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"start must be less than end")
+	}
+	var sampleNanos int64
+	if len(args) > 3 {
+		sampleNanos = tree.MustBeDInterval(args[3]).Duration.Nanos()
+		if sampleNanos <= 0 {
+			return nil, pgerror.New(pgcode.InvalidParameterValue,
+				"sample_period must be positive")
+		}
+	}
+	query := tspb.Query{Name: name}
+	if len(args) > 4 {
+		query.Sources = []string{string(tree.MustBeDString(args[4]))}
+	}
+
+	return &timeseriesGenerator{
+		planner: ctx.Planner,
+		req: tspb.TimeSeriesQueryRequest{
+			StartNanos:  start,
+			EndNanos:    end,
+			Queries:     []tspb.Query{query},
+			SampleNanos: sampleNanos,
+		},
+	}, nil
+}
+
+var timeseriesGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.TimestampTZ, types.Float},
+	[]string{"time", "value"},
+)
+
+// ResolvedType is part of the tree.ValueGenerator interface.
+func (*timeseriesGenerator) ResolvedType() *types.T {
+	return timeseriesGeneratorType
+}
+
+// Start is part of the tree.ValueGenerator interface.
+func (g *timeseriesGenerator) Start(ctx context.Context, _ *kv.Txn) error {
+	resp, err := g.planner.QueryTimeSeries(ctx, &g.req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Results) > 0 {
+		g.datapoints = resp.Results[0].Datapoints
+	}
+	g.idx = -1
+	return nil
+}
+
+// Next is part of the tree.ValueGenerator interface.
+func (g *timeseriesGenerator) Next(_ context.Context) (bool, error) {
+	g.idx++
+	return g.idx < len(g.datapoints), nil
+}
+
+// Values is part of the tree.ValueGenerator interface.
+func (g *timeseriesGenerator) Values() (tree.Datums, error) {
+	dp := g.datapoints[g.idx]
+	ts, err := tree.MakeDTimestampTZ(timeutil.Unix(0, dp.TimestampNanos), time.Microsecond)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Datums{ts, tree.NewDFloat(tree.DFloat(dp.Value))}, nil
+}
+
+// Close is part of the tree.ValueGenerator interface.
+func (g *timeseriesGenerator) Close() {}
+
+// ShowCreateAllFormatVersion is reported by crdb_internal.show_create_all
+// alongside every statement. It must be bumped whenever a change alters the
+// order or the formatting of the statements, so that tools comparing schemas
+// across versions can detect the difference.
+//
+// Version history:
+//   1: initial format.
+//   2: tables referenced by foreign keys are created before the tables
+//      referencing them.
+const ShowCreateAllFormatVersion = 2
+
+var showCreateAllGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.Int, types.String, types.String, types.String, types.String},
+	[]string{"format_version", "schema_name", "descriptor_name", "descriptor_type", "statement"},
+)
+
+// showCreateAllRow is a single statement returned by
+// crdb_internal.show_create_all.
+type showCreateAllRow struct {
+	schemaName, descName, descType, stmt tree.Datum
+}
+
+type showCreateAllGenerator struct {
+	ie     tree.InternalExecutor
+	dbName string
+	// rows is populated by Start(). Each Next() call peels off the first row
+	// and moves it to curRow.
+	rows   []showCreateAllRow
+	curRow showCreateAllRow
+}
+
+var _ tree.ValueGenerator = &showCreateAllGenerator{}
+
+func makeShowCreateAllGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	return &showCreateAllGenerator{
+		ie:     ctx.InternalExecutor,
+		dbName: string(tree.MustBeDString(args[0])),
+	}, nil
+}
+
+// ResolvedType is part of the tree.ValueGenerator interface.
+func (*showCreateAllGenerator) ResolvedType() *types.T {
+	return showCreateAllGeneratorType
+}
+
+// Start is part of the tree.ValueGenerator interface.
+func (s *showCreateAllGenerator) Start(ctx context.Context, txn *kv.Txn) error {
+	dbName := tree.NameString(s.dbName)
+	descs, err := s.ie.Query(ctx, "crdb-internal-show-create-all", txn, fmt.Sprintf(`
+SELECT descriptor_id, schema_name, descriptor_name, descriptor_type,
+       create_nofks, alter_statements, validate_statements
+  FROM %s.crdb_internal.create_statements
+ WHERE database_name = $1 AND state = 'PUBLIC'
+ ORDER BY descriptor_id`, dbName), s.dbName)
+	if err != nil {
+		return err
+	}
+	deps, err := s.ie.Query(ctx, "crdb-internal-show-create-all-deps", txn, fmt.Sprintf(`
+SELECT DISTINCT descriptor_id, dependson_id
+  FROM %s.crdb_internal.backward_dependencies
+ WHERE dependson_type IN ('fk', 'view', 'sequence', 'interleave')`, dbName))
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[tree.DInt]tree.Datums, len(descs))
+	for _, desc := range descs {
+		byID[tree.MustBeDInt(desc[0])] = desc
+	}
+	// Foreign keys are added by ALTER statements once every descriptor exists,
+	// so the CREATE statements could be replayed in any order with respect to
+	// them. Referenced tables are still created before the tables referencing
+	// them, which keeps the output readable and replayable one table at a time.
+	// Cycles of foreign keys are broken by descriptor ID.
+	dependsOn := make(map[tree.DInt][]tree.DInt)
+	for _, dep := range deps {
+		id, parentID := tree.MustBeDInt(dep[0]), tree.MustBeDInt(dep[1])
+		if _, ok := byID[parentID]; !ok || id == parentID {
+			continue
+		}
+		dependsOn[id] = append(dependsOn[id], parentID)
+	}
+
+	ordered := make([]tree.Datums, 0, len(descs))
+	visited := make(map[tree.DInt]bool, len(descs))
+	var visit func(id tree.DInt)
+	visit = func(id tree.DInt) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		parents := dependsOn[id]
+		sort.Slice(parents, func(i, j int) bool { return parents[i] < parents[j] })
+		for _, parentID := range parents {
+			visit(parentID)
+		}
+		ordered = append(ordered, byID[id])
+	}
+	for _, desc := range descs {
+		visit(tree.MustBeDInt(desc[0]))
+	}
+
+	s.rows = make([]showCreateAllRow, 0, len(ordered))
+	addRows := func(desc tree.Datums, stmts ...tree.Datum) {
+		for _, stmt := range stmts {
+			s.rows = append(s.rows, showCreateAllRow{
+				schemaName: desc[1],
+				descName:   desc[2],
+				descType:   desc[3],
+				stmt:       stmt,
+			})
+		}
+	}
+	for _, desc := range ordered {
+		addRows(desc, desc[4])
+	}
+	for _, desc := range ordered {
+		addRows(desc, tree.MustBeDArray(desc[5]).Array...)
+	}
+	for _, desc := range ordered {
+		addRows(desc, tree.MustBeDArray(desc[6]).Array...)
+	}
+	return nil
+}
+
+// Next is part of the tree.ValueGenerator interface.
+func (s *showCreateAllGenerator) Next(_ context.Context) (bool, error) {
+	if len(s.rows) == 0 {
+		return false, nil
+	}
+	s.curRow = s.rows[0]
+	s.rows = s.rows[1:]
+	return true, nil
+}
+
+// Values is part of the tree.ValueGenerator interface.
+func (s *showCreateAllGenerator) Values() (tree.Datums, error) {
+	return tree.Datums{
+		tree.NewDInt(ShowCreateAllFormatVersion),
+		s.curRow.schemaName,
+		s.curRow.descName,
+		s.curRow.descType,
+		s.curRow.stmt,
+	}, nil
+}
+
+// Close is part of the tree.ValueGenerator interface.
+func (s *showCreateAllGenerator) Close() {}
@@ -0,0 +1,603 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// The kinds of differences reported by the schema diff builtins. Elements
+// are "missing" when only the source schema defines them and "extra" when
+// only the target schema does.
+const (
+	schemaDiffMissing            = "missing"
+	schemaDiffExtra              = "extra"
+	schemaDiffTypeMismatch       = "type_mismatch"
+	schemaDiffDefinitionMismatch = "definition_mismatch"
+)
+
+// schemaDiffInfo describes the output of the schema diff builtins.
+const schemaDiffInfo = "Elements defined only by the source are reported as " +
+	"missing and elements defined only by the target as extra. Columns are " +
+	"matched by name and reported as type_mismatch or definition_mismatch " +
+	"when their type or their nullability, default or computed expression " +
+	"differ. Indexes and constraints are matched by definition regardless of " +
+	"their names. Views and sequences whose definitions differ are reported " +
+	"as definition_mismatch."
+
+var schemaDiffGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{
+		types.String, types.String, types.String, types.String,
+		types.String, types.String, types.String,
+	},
+	[]string{
+		"schema_name", "object_name", "element_type", "element_name",
+		"diff", "source_definition", "target_definition",
+	},
+)
+
+// schemaDiffKey identifies a table, view or sequence within a schema.
+type schemaDiffKey struct {
+	schema, name string
+}
+
+// schemaDiffColumn summarizes a column definition.
+type schemaDiffColumn struct {
+	name    string
+	typ     string
+	notNull bool
+	// extra holds the DEFAULT and computed expressions of the column.
+	extra string
+}
+
+func (c *schemaDiffColumn) definition() string {
+	def := c.typ
+	if c.notNull {
+		def += " NOT NULL"
+	} else {
+		def += " NULL"
+	}
+	return def + c.extra
+}
+
+// schemaDiffObject summarizes a table, view or sequence.
+type schemaDiffObject struct {
+	kind string
+	// definition is the normalized definition of views and sequences, which
+	// are compared as a whole.
+	definition string
+	// columns are kept in definition order so that differences are reported
+	// in a natural order.
+	columns []*schemaDiffColumn
+	// indexes and constraints map normalized definitions to element names.
+	// They are matched by definition rather than by name because names are
+	// frequently generated.
+	indexes     map[string]string
+	constraints map[string]string
+	// primaryKey holds the primary key columns, which are implicitly NOT NULL.
+	primaryKey tree.NameList
+}
+
+func (o *schemaDiffObject) column(name string) *schemaDiffColumn {
+	for _, c := range o.columns {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// schemaDiffSnapshot is the set of objects defined by a database or a DDL
+// script.
+type schemaDiffSnapshot map[schemaDiffKey]*schemaDiffObject
+
+// schemaDiffRow is a single difference between two snapshots. Empty names
+// and definitions are reported as NULL.
+type schemaDiffRow struct {
+	schemaName, objectName, elementType, elementName string
+	diff, sourceDefinition, targetDefinition         string
+}
+
+type schemaDiffGenerator struct {
+	ie             tree.InternalExecutor
+	source, target string
+	targetIsScript bool
+	remainingRows  []schemaDiffRow
+	curRow         schemaDiffRow
+}
+
+var _ tree.ValueGenerator = &schemaDiffGenerator{}
+
+func makeSchemaDiffGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	return &schemaDiffGenerator{
+		ie:     ctx.InternalExecutor,
+		source: string(tree.MustBeDString(args[0])),
+		target: string(tree.MustBeDString(args[1])),
+	}, nil
+}
+
+func makeSchemaDiffScriptGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	g, err := makeSchemaDiffGenerator(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	g.(*schemaDiffGenerator).targetIsScript = true
+	return g, nil
+}
+
+// ResolvedType is part of the tree.ValueGenerator interface.
+func (*schemaDiffGenerator) ResolvedType() *types.T {
+	return schemaDiffGeneratorType
+}
+
+// Start is part of the tree.ValueGenerator interface.
+func (g *schemaDiffGenerator) Start(ctx context.Context, txn *kv.Txn) error {
+	source, err := loadDatabaseSchema(ctx, g.ie, txn, g.source)
+	if err != nil {
+		return err
+	}
+	var target schemaDiffSnapshot
+	if g.targetIsScript {
+		target, err = parseSchemaScript(g.target)
+	} else {
+		target, err = loadDatabaseSchema(ctx, g.ie, txn, g.target)
+	}
+	if err != nil {
+		return err
+	}
+	g.remainingRows = diffSchemas(source, target)
+	return nil
+}
+
+// Next is part of the tree.ValueGenerator interface.
+func (g *schemaDiffGenerator) Next(_ context.Context) (bool, error) {
+	if len(g.remainingRows) == 0 {
+		return false, nil
+	}
+	g.curRow = g.remainingRows[0]
+	g.remainingRows = g.remainingRows[1:]
+	return true, nil
+}
+
+// Values is part of the tree.ValueGenerator interface.
+func (g *schemaDiffGenerator) Values() (tree.Datums, error) {
+	r := &g.curRow
+	return tree.Datums{
+		tree.NewDString(r.schemaName),
+		tree.NewDString(r.objectName),
+		tree.NewDString(r.elementType),
+		stringOrNull(r.elementName),
+		tree.NewDString(r.diff),
+		stringOrNull(r.sourceDefinition),
+		stringOrNull(r.targetDefinition),
+	}, nil
+}
+
+// Close is part of the tree.ValueGenerator interface.
+func (g *schemaDiffGenerator) Close() {}
+
+func stringOrNull(s string) tree.Datum {
+	if s == "" {
+		return tree.DNull
+	}
+	return tree.NewDString(s)
+}
+
+// loadDatabaseSchema builds a snapshot of the given database from the output
+// of crdb_internal.show_create_all.
+func loadDatabaseSchema(
+	ctx context.Context, ie tree.InternalExecutor, txn *kv.Txn, dbName string,
+) (schemaDiffSnapshot, error) {
+	rows, err := ie.Query(ctx, "crdb-internal-schema-diff", txn,
+		`SELECT statement FROM crdb_internal.show_create_all($1)`, dbName)
+	if err != nil {
+		return nil, err
+	}
+	s := make(schemaDiffSnapshot)
+	for _, row := range rows {
+		stmt, err := parser.ParseOne(string(tree.MustBeDString(row[0])))
+		if err != nil {
+			return nil, err
+		}
+		if err := s.add(stmt.AST); err != nil {
+			return nil, err
+		}
+	}
+	s.finish()
+	return s, nil
+}
+
+// parseSchemaScript builds a snapshot from a DDL script. Statements that do
+// not define tables, views, sequences, columns, indexes or constraints are
+// ignored.
+func parseSchemaScript(script string) (schemaDiffSnapshot, error) {
+	stmts, err := parser.Parse(script)
+	if err != nil {
+		return nil, err
+	}
+	s := make(schemaDiffSnapshot)
+	for _, stmt := range stmts {
+		if err := s.add(stmt.AST); err != nil {
+			return nil, err
+		}
+	}
+	s.finish()
+	return s, nil
+}
+
+func (s schemaDiffSnapshot) add(stmt tree.Statement) error {
+	switch t := stmt.(type) {
+	case *tree.CreateTable:
+		o, err := s.define(&t.Table, "table")
+		if err != nil {
+			return err
+		}
+		for _, def := range t.Defs {
+			o.addTableDef(def)
+		}
+
+	case *tree.CreateView:
+		o, err := s.define(&t.Name, "view")
+		if err != nil {
+			return err
+		}
+		o.definition = formatSchemaNode(t.AsSource)
+
+	case *tree.CreateSequence:
+		o, err := s.define(&t.Name, "sequence")
+		if err != nil {
+			return err
+		}
+		o.definition = strings.TrimSpace(formatSchemaNode(&t.Options))
+
+	case *tree.CreateIndex:
+		o, err := s.lookup(&t.Table)
+		if err != nil {
+			return err
+		}
+		o.addIndex(&tree.IndexTableDef{
+			Name:        t.Name,
+			Columns:     t.Columns,
+			Sharded:     t.Sharded,
+			Storing:     t.Storing,
+			Inverted:    t.Inverted,
+			PartitionBy: t.PartitionBy,
+			Predicate:   t.Predicate,
+		}, t.Unique, false /* primary */)
+
+	case *tree.AlterTable:
+		tn := t.Table.ToTableName()
+		o, err := s.lookup(&tn)
+		if err != nil {
+			return err
+		}
+		for _, cmd := range t.Cmds {
+			switch c := cmd.(type) {
+			case *tree.AlterTableAddColumn:
+				o.addTableDef(c.ColumnDef)
+			case *tree.AlterTableAddConstraint:
+				o.addTableDef(c.ConstraintDef)
+			}
+		}
+	}
+	return nil
+}
+
+func (s schemaDiffSnapshot) define(tn *tree.TableName, kind string) (*schemaDiffObject, error) {
+	key := makeSchemaDiffKey(tn)
+	if _, ok := s[key]; ok {
+		return nil, pgerror.Newf(pgcode.DuplicateRelation,
+			"relation %q is defined more than once", tree.ErrString(tn))
+	}
+	o := &schemaDiffObject{
+		kind:        kind,
+		indexes:     make(map[string]string),
+		constraints: make(map[string]string),
+	}
+	s[key] = o
+	return o, nil
+}
+
+func (s schemaDiffSnapshot) lookup(tn *tree.TableName) (*schemaDiffObject, error) {
+	o, ok := s[makeSchemaDiffKey(tn)]
+	if !ok || o.kind != "table" {
+		return nil, pgerror.Newf(pgcode.UndefinedTable,
+			"table %q is not defined by the schema", tree.ErrString(tn))
+	}
+	return o, nil
+}
+
+// finish applies the properties that are implied by other parts of a table
+// definition once all the statements have been added.
+func (s schemaDiffSnapshot) finish() {
+	for _, o := range s {
+		for _, name := range o.primaryKey {
+			if c := o.column(string(name)); c != nil {
+				c.notNull = true
+			}
+		}
+	}
+}
+
+// addTableDef records a column, index or constraint definition.
+func (o *schemaDiffObject) addTableDef(def tree.TableDef) {
+	switch d := def.(type) {
+	case *tree.ColumnTableDef:
+		c := &schemaDiffColumn{
+			name:    string(d.Name),
+			typ:     d.Type.SQLString(),
+			notNull: d.Nullable.Nullability == tree.NotNull,
+		}
+		if d.DefaultExpr.Expr != nil {
+			c.extra += " DEFAULT " + formatSchemaNode(stripTypeAnnotations(d.DefaultExpr.Expr))
+		}
+		if d.Computed.Computed {
+			c.extra += " AS (" + formatSchemaNode(stripTypeAnnotations(d.Computed.Expr)) + ")"
+			if d.Computed.Virtual {
+				c.extra += " VIRTUAL"
+			} else {
+				c.extra += " STORED"
+			}
+		}
+		o.columns = append(o.columns, c)
+
+		cols := tree.IndexElemList{{Column: d.Name}}
+		if d.PrimaryKey.IsPrimaryKey {
+			o.addIndex(&tree.IndexTableDef{Name: "primary", Columns: cols}, true /* unique */, true /* primary */)
+		}
+		if d.Unique.IsUnique {
+			o.addTableDef(&tree.UniqueConstraintTableDef{
+				IndexTableDef: tree.IndexTableDef{Name: d.Unique.ConstraintName, Columns: cols},
+				WithoutIndex:  d.Unique.WithoutIndex,
+			})
+		}
+		for _, check := range d.CheckExprs {
+			o.addTableDef(&tree.CheckConstraintTableDef{Name: check.ConstraintName, Expr: check.Expr})
+		}
+		if d.References.Table != nil {
+			var toCols tree.NameList
+			if d.References.Col != "" {
+				toCols = tree.NameList{d.References.Col}
+			}
+			o.addTableDef(&tree.ForeignKeyConstraintTableDef{
+				Name:     d.References.ConstraintName,
+				Table:    *d.References.Table,
+				FromCols: tree.NameList{d.Name},
+				ToCols:   toCols,
+				Actions:  d.References.Actions,
+				Match:    d.References.Match,
+			})
+		}
+
+	case *tree.IndexTableDef:
+		o.addIndex(d, false /* unique */, false /* primary */)
+
+	case *tree.UniqueConstraintTableDef:
+		if !d.WithoutIndex {
+			o.addIndex(&d.IndexTableDef, true /* unique */, d.PrimaryKey)
+			return
+		}
+		norm := *d
+		norm.Name = ""
+		norm.Columns = normalizeSchemaDiffIndexElems(d.Columns)
+		o.constraints[formatSchemaNode(&norm)] = string(d.Name)
+
+	case *tree.ForeignKeyConstraintTableDef:
+		norm := *d
+		norm.Name = ""
+		o.constraints[formatSchemaNode(&norm)] = string(d.Name)
+
+	case *tree.CheckConstraintTableDef:
+		norm := tree.CheckConstraintTableDef{Expr: stripTypeAnnotations(d.Expr)}
+		o.constraints[formatSchemaNode(&norm)] = string(d.Name)
+	}
+}
+
+// addIndex records an index. Primary keys, unique indexes and unique
+// constraints are all described as UNIQUE or PRIMARY KEY constraints so that
+// equivalent indexes match regardless of the syntax used to create them.
+func (o *schemaDiffObject) addIndex(def *tree.IndexTableDef, unique, primary bool) {
+	norm := tree.UniqueConstraintTableDef{IndexTableDef: *def, PrimaryKey: primary}
+	norm.Name = ""
+	norm.Columns = normalizeSchemaDiffIndexElems(def.Columns)
+	if norm.Predicate != nil {
+		norm.Predicate = stripTypeAnnotations(norm.Predicate)
+	}
+	var definition string
+	if unique {
+		definition = formatSchemaNode(&norm)
+	} else {
+		definition = formatSchemaNode(&norm.IndexTableDef)
+	}
+	if primary {
+		o.primaryKey = make(tree.NameList, 0, len(def.Columns))
+		for _, elem := range def.Columns {
+			o.primaryKey = append(o.primaryKey, elem.Column)
+		}
+	}
+	o.indexes[definition] = string(def.Name)
+}
+
+// normalizeSchemaDiffIndexElems makes the default ascending direction
+// explicit, as SHOW CREATE does.
+func normalizeSchemaDiffIndexElems(elems tree.IndexElemList) tree.IndexElemList {
+	norm := make(tree.IndexElemList, len(elems))
+	for i, elem := range elems {
+		if elem.Direction == tree.DefaultDirection {
+			elem.Direction = tree.Ascending
+		}
+		if elem.Expr != nil {
+			elem.Expr = stripTypeAnnotations(elem.Expr)
+		}
+		norm[i] = elem
+	}
+	return norm
+}
+
+func makeSchemaDiffKey(tn *tree.TableName) schemaDiffKey {
+	norm := normalizeSchemaDiffTableName(tn)
+	return schemaDiffKey{schema: string(norm.SchemaName), name: string(norm.ObjectName)}
+}
+
+// normalizeSchemaDiffTableName drops the database from a table name and
+// resolves unqualified names to the public schema, so that objects can be
+// matched across databases.
+func normalizeSchemaDiffTableName(tn *tree.TableName) tree.TableName {
+	norm := *tn
+	norm.CatalogName = ""
+	norm.ExplicitCatalog = false
+	if !norm.ExplicitSchema {
+		norm.SchemaName = tree.PublicSchemaName
+		norm.ExplicitSchema = true
+	}
+	return norm
+}
+
+// formatSchemaNode formats a node with its table names normalized.
+func formatSchemaNode(n tree.NodeFormatter) string {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.SetReformatTableNames(func(ctx *tree.FmtCtx, tn *tree.TableName) {
+		norm := normalizeSchemaDiffTableName(tn)
+		ctx.WithReformatTableNames(nil, func() {
+			ctx.FormatNode(&norm)
+		})
+	})
+	f.FormatNode(n)
+	return f.CloseAndGetString()
+}
+
+// stripTypeAnnotations removes the type annotations that SHOW CREATE adds to
+// expressions, which scripts written by hand usually omit.
+func stripTypeAnnotations(expr tree.Expr) tree.Expr {
+	stripped, err := tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if a, ok := expr.(*tree.AnnotateTypeExpr); ok {
+			return true, a.Expr, nil
+		}
+		return true, expr, nil
+	})
+	if err != nil {
+		return expr
+	}
+	return stripped
+}
+
+// diffSchemas returns the differences between two snapshots, ordered by
+// object and then by element.
+func diffSchemas(source, target schemaDiffSnapshot) []schemaDiffRow {
+	keys := make([]schemaDiffKey, 0, len(source)+len(target))
+	for k := range source {
+		keys = append(keys, k)
+	}
+	for k := range target {
+		if _, ok := source[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].schema != keys[j].schema {
+			return keys[i].schema < keys[j].schema
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	var rows []schemaDiffRow
+	for _, k := range keys {
+		add := func(elementType, elementName, diff, sourceDef, targetDef string) {
+			rows = append(rows, schemaDiffRow{
+				schemaName:       k.schema,
+				objectName:       k.name,
+				elementType:      elementType,
+				elementName:      elementName,
+				diff:             diff,
+				sourceDefinition: sourceDef,
+				targetDefinition: targetDef,
+			})
+		}
+		s, t := source[k], target[k]
+		switch {
+		case t == nil:
+			add(s.kind, "", schemaDiffMissing, s.definition, "")
+		case s == nil:
+			add(t.kind, "", schemaDiffExtra, "", t.definition)
+		case s.kind != t.kind:
+			add(s.kind, "", schemaDiffTypeMismatch, s.kind, t.kind)
+		case s.definition != t.definition:
+			add(s.kind, "", schemaDiffDefinitionMismatch, s.definition, t.definition)
+		default:
+			diffColumns(s, t, add)
+			diffElements("index", s.indexes, t.indexes, add)
+			diffElements("constraint", s.constraints, t.constraints, add)
+		}
+	}
+	return rows
+}
+
+func diffColumns(
+	source, target *schemaDiffObject,
+	add func(elementType, elementName, diff, sourceDef, targetDef string),
+) {
+	for _, s := range source.columns {
+		t := target.column(s.name)
+		switch {
+		case t == nil:
+			add("column", s.name, schemaDiffMissing, s.definition(), "")
+		case s.typ != t.typ:
+			add("column", s.name, schemaDiffTypeMismatch, s.definition(), t.definition())
+		case s.definition() != t.definition():
+			add("column", s.name, schemaDiffDefinitionMismatch, s.definition(), t.definition())
+		}
+	}
+	for _, t := range target.columns {
+		if source.column(t.name) == nil {
+			add("column", t.name, schemaDiffExtra, "", t.definition())
+		}
+	}
+}
+
+func diffElements(
+	elementType string,
+	source, target map[string]string,
+	add func(elementType, elementName, diff, sourceDef, targetDef string),
+) {
+	defs := make([]string, 0, len(source)+len(target))
+	for def := range source {
+		defs = append(defs, def)
+	}
+	for def := range target {
+		if _, ok := source[def]; !ok {
+			defs = append(defs, def)
+		}
+	}
+	sort.Strings(defs)
+	for _, def := range defs {
+		sourceName, inSource := source[def]
+		targetName, inTarget := target[def]
+		switch {
+		case !inTarget:
+			add(elementType, sourceName, schemaDiffMissing, def, "")
+		case !inSource:
+			add(elementType, targetName, schemaDiffExtra, "", def)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/errors"
+
+// BatchedDML represents a DELETE or UPDATE statement with a WITH BATCH
+// clause, which is executed by a job as a sequence of bounded transactions:
+//
+//   DELETE FROM t WHERE ... WITH BATCH SIZE 1000, PACING '1s'
+type BatchedDML struct {
+	// Statement is either a *Delete or an *Update.
+	Statement Statement
+	Options   BatchOptions
+}
+
+var _ Statement = &BatchedDML{}
+
+// Format implements the NodeFormatter interface.
+func (node *BatchedDML) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.Statement)
+	ctx.WriteString(" WITH BATCH")
+	if !node.Options.IsDefault() {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// BatchOptions describes options for the WITH BATCH clause of a DELETE or
+// UPDATE statement.
+type BatchOptions struct {
+	// Size is the maximum number of rows modified by each transaction.
+	Size Expr
+	// Pacing is the delay between consecutive transactions.
+	Pacing Expr
+}
+
+var _ NodeFormatter = &BatchOptions{}
+
+// Format implements the NodeFormatter interface.
+func (o *BatchOptions) Format(ctx *FmtCtx) {
+	var addSep bool
+	maybeAddSep := func() {
+		if addSep {
+			ctx.WriteString(", ")
+		}
+		addSep = true
+	}
+	if o.Size != nil {
+		maybeAddSep()
+		ctx.WriteString("SIZE ")
+		ctx.FormatNode(o.Size)
+	}
+	if o.Pacing != nil {
+		maybeAddSep()
+		ctx.WriteString("PACING ")
+		ctx.FormatNode(o.Pacing)
+	}
+}
+
+// CombineWith merges other batch options into this batch options struct.
+// An error is returned if the same option merged multiple times.
+func (o *BatchOptions) CombineWith(other *BatchOptions) error {
+	if o.Size == nil {
+		o.Size = other.Size
+	} else if other.Size != nil {
+		return errors.New("size option specified multiple times")
+	}
+
+	if o.Pacing == nil {
+		o.Pacing = other.Pacing
+	} else if other.Pacing != nil {
+		return errors.New("pacing option specified multiple times")
+	}
+
+	return nil
+}
+
+// IsDefault returns true if this batch options struct has default value.
+func (o BatchOptions) IsDefault() bool {
+	return o.Size == nil && o.Pacing == nil
+}
@@ -399,6 +399,9 @@ type ColumnTableDef struct {
 		Expr           Expr
 		ConstraintName Name
 	}
+	OnUpdateExpr struct {
+		Expr Expr
+	}
 	CheckExprs []ColumnTableDefCheckExpr
 	References struct {
 		Table          *TableName
@@ -493,6 +496,12 @@ func NewColumnTableDef(
 			}
 			d.DefaultExpr.Expr = t.Expr
 			d.DefaultExpr.ConstraintName = c.Name
+		case *ColumnOnUpdate:
+			if d.HasOnUpdateExpr() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"multiple ON UPDATE expressions specified for column %q", name)
+			}
+			d.OnUpdateExpr.Expr = t.Expr
 		case NotNullConstraint:
 			if d.Nullable.Nullability == Null {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -559,6 +568,11 @@ func (node *ColumnTableDef) HasDefaultExpr() bool {
 	return node.DefaultExpr.Expr != nil
 }
 
+// HasOnUpdateExpr returns if the ColumnTableDef has an ON UPDATE expression.
+func (node *ColumnTableDef) HasOnUpdateExpr() bool {
+	return node.OnUpdateExpr.Expr != nil
+}
+
 // HasFKConstraint returns if the ColumnTableDef has a foreign key constraint.
 func (node *ColumnTableDef) HasFKConstraint() bool {
 	return node.References.Table != nil
@@ -626,6 +640,10 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 		ctx.WriteString(" DEFAULT ")
 		ctx.FormatNode(node.DefaultExpr.Expr)
 	}
+	if node.HasOnUpdateExpr() {
+		ctx.WriteString(" ON UPDATE ")
+		ctx.FormatNode(node.OnUpdateExpr.Expr)
+	}
 	for _, checkExpr := range node.CheckExprs {
 		if checkExpr.ConstraintName != "" {
 			ctx.WriteString(" CONSTRAINT ")
@@ -710,6 +728,7 @@ type ColumnQualification interface {
 
 func (ColumnCollation) columnQualification()             {}
 func (*ColumnDefault) columnQualification()              {}
+func (*ColumnOnUpdate) columnQualification()             {}
 func (NotNullConstraint) columnQualification()           {}
 func (NullConstraint) columnQualification()              {}
 func (PrimaryKeyConstraint) columnQualification()        {}
@@ -728,6 +747,11 @@ type ColumnDefault struct {
 	Expr Expr
 }
 
+// ColumnOnUpdate represents an ON UPDATE clause for a column.
+type ColumnOnUpdate struct {
+	Expr Expr
+}
+
 // NotNullConstraint represents NOT NULL on a column.
 type NotNullConstraint struct{}
 
@@ -1532,6 +1556,7 @@ const (
 	LikeTableOptDefaults
 	LikeTableOptGenerated
 	LikeTableOptIndexes
+	LikeTableOptComments
 
 	// Make sure this field stays last!
 	likeTableOptInvalid
@@ -1555,6 +1580,8 @@ func (o LikeTableOpt) String() string {
 		return "GENERATED"
 	case LikeTableOptIndexes:
 		return "INDEXES"
+	case LikeTableOptComments:
+		return "COMMENTS"
 	case LikeTableOptAll:
 		return "ALL"
 	default:
@@ -1686,6 +1713,67 @@ func (node *AlterRole) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterRoleSet represents an `ALTER ROLE ... SET` or `ALTER ROLE ... RESET`
+// statement, which changes the default value of a session variable for a
+// role, optionally only in a given database.
+type AlterRoleSet struct {
+	// Name is nil if AllRoles is set.
+	Name     Expr
+	AllRoles bool
+	IfExists bool
+	IsRole   bool
+	// DatabaseName is empty if the default applies to all databases.
+	DatabaseName Name
+	// SetOrReset is the session variable and its new default value. The value
+	// is DefaultVal for RESET, and the name is "all" for RESET ALL.
+	SetOrReset *SetVar
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterRoleSet) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER")
+	if node.IsRole {
+		ctx.WriteString(" ROLE ")
+	} else {
+		ctx.WriteString(" USER ")
+	}
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	if node.AllRoles {
+		ctx.WriteString("ALL")
+	} else {
+		ctx.FormatNode(node.Name)
+	}
+	if node.DatabaseName != "" {
+		ctx.WriteString(" IN DATABASE ")
+		ctx.FormatNode(&node.DatabaseName)
+	}
+	ctx.WriteByte(' ')
+	if node.IsReset() {
+		ctx.WriteString("RESET ")
+		if node.SetOrReset.Name == "all" {
+			ctx.WriteString("ALL")
+			return
+		}
+		ctx.WithFlags(ctx.flags & ^FmtAnonymize, func() {
+			ctx.FormatNameP(&node.SetOrReset.Name)
+		})
+		return
+	}
+	ctx.FormatNode(node.SetOrReset)
+}
+
+// IsReset returns true if the statement resets the default value of the
+// session variable.
+func (node *AlterRoleSet) IsReset() bool {
+	if len(node.SetOrReset.Values) != 1 {
+		return false
+	}
+	_, ok := node.SetOrReset.Values[0].(DefaultVal)
+	return ok
+}
+
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
 	Name         TableName
@@ -44,6 +44,9 @@ type DropDatabase struct {
 	Name         Name
 	IfExists     bool
 	DropBehavior DropBehavior
+	// DryRun indicates that the objects that would be dropped are listed
+	// instead of being dropped.
+	DryRun bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -57,6 +60,9 @@ func (node *DropDatabase) Format(ctx *FmtCtx) {
 		ctx.WriteByte(' ')
 		ctx.WriteString(node.DropBehavior.String())
 	}
+	if node.DryRun {
+		ctx.WriteString(" DRY RUN")
+	}
 }
 
 // DropIndex represents a DROP INDEX statement.
@@ -88,6 +94,9 @@ type DropTable struct {
 	Names        TableNames
 	IfExists     bool
 	DropBehavior DropBehavior
+	// DryRun indicates that the objects that would be dropped are listed
+	// instead of being dropped.
+	DryRun bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -101,6 +110,9 @@ func (node *DropTable) Format(ctx *FmtCtx) {
 		ctx.WriteByte(' ')
 		ctx.WriteString(node.DropBehavior.String())
 	}
+	if node.DryRun {
+		ctx.WriteString(" DRY RUN")
+	}
 }
 
 // DropView represents a DROP VIEW statement.
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
 	"github.com/cockroachdb/cockroach/pkg/util/arith"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -3072,6 +3073,11 @@ type EvalPlanner interface {
 	CompactEngineSpan(
 		ctx context.Context, nodeID int32, storeID int32, startKey []byte, endKey []byte,
 	) error
+
+	// QueryTimeSeries runs a query against the internal time series database.
+	QueryTimeSeries(
+		ctx context.Context, req *tspb.TimeSeriesQueryRequest,
+	) (*tspb.TimeSeriesQueryResponse, error)
 }
 
 // EvalSessionAccessor is a limited interface to access session variables.
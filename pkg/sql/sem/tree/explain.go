@@ -39,8 +39,9 @@ type ExplainAnalyze struct {
 // ExplainOptions contains information about the options passed to an EXPLAIN
 // statement.
 type ExplainOptions struct {
-	Mode  ExplainMode
-	Flags [numExplainFlags + 1]bool
+	Mode   ExplainMode
+	Flags  [numExplainFlags + 1]bool
+	Format ExplainFormat
 }
 
 // ExplainMode indicates the mode of the explain. Currently there are two modes:
@@ -69,6 +70,11 @@ const (
 	// EXPLAIN ANALYZE.
 	ExplainDebug
 
+	// ExplainDDL shows the stages, backfills and validations the schema
+	// changer executes for a schema change statement. See sql/explain_ddl.go
+	// for details.
+	ExplainDDL
+
 	numExplainModes = iota
 )
 
@@ -78,6 +84,7 @@ var explainModeStrings = [...]string{
 	ExplainOpt:     "OPT",
 	ExplainVec:     "VEC",
 	ExplainDebug:   "DEBUG",
+	ExplainDDL:     "DDL",
 }
 
 var explainModeStringMap = func() map[string]ExplainMode {
@@ -131,6 +138,48 @@ func (f ExplainFlag) String() string {
 	return explainFlagStrings[f]
 }
 
+// ExplainFormat is the output format of an EXPLAIN statement, set with the
+// FORMAT option.
+type ExplainFormat uint8
+
+const (
+	// ExplainFormatText is the default, human-readable output format.
+	ExplainFormatText ExplainFormat = iota
+
+	// ExplainFormatJSON returns the plan as a single JSON document, for use by
+	// external tools. With DISTSQL, the document describes the processors of
+	// the physical plan, the nodes they are placed on and the streams between
+	// them.
+	ExplainFormatJSON
+
+	// ExplainFormatDOT returns the plan as a single graph in the DOT language of
+	// Graphviz.
+	ExplainFormatDOT
+
+	numExplainFormats = iota
+)
+
+var explainFormatStrings = [...]string{
+	ExplainFormatText: "TEXT",
+	ExplainFormatJSON: "JSON",
+	ExplainFormatDOT:  "DOT",
+}
+
+var explainFormatStringMap = func() map[string]ExplainFormat {
+	m := make(map[string]ExplainFormat, numExplainFormats)
+	for i := ExplainFormat(0); i < numExplainFormats; i++ {
+		m[explainFormatStrings[i]] = i
+	}
+	return m
+}()
+
+func (f ExplainFormat) String() string {
+	if f >= numExplainFormats {
+		panic(errors.AssertionFailedf("invalid ExplainFormat %d", f))
+	}
+	return explainFormatStrings[f]
+}
+
 // Format implements the NodeFormatter interface.
 func (node *Explain) Format(ctx *FmtCtx) {
 	ctx.WriteString("EXPLAIN ")
@@ -144,6 +193,9 @@ func (node *Explain) Format(ctx *FmtCtx) {
 			b.Add(ctx, f.String())
 		}
 	}
+	if node.Format != ExplainFormatText {
+		b.Add(ctx, "FORMAT "+node.Format.String())
+	}
 	b.Finish(ctx)
 	ctx.FormatNode(node.Statement)
 }
@@ -160,6 +212,9 @@ func (node *Explain) doc(p *PrettyCfg) pretty.Doc {
 			opts = append(opts, pretty.Keyword(f.String()))
 		}
 	}
+	if node.Format != ExplainFormatText {
+		opts = append(opts, pretty.Keyword("FORMAT "+node.Format.String()))
+	}
 	if len(opts) > 0 {
 		d = pretty.ConcatSpace(
 			d,
@@ -182,6 +237,9 @@ func (node *ExplainAnalyze) Format(ctx *FmtCtx) {
 			b.Add(ctx, f.String())
 		}
 	}
+	if node.Format != ExplainFormatText {
+		b.Add(ctx, "FORMAT "+node.Format.String())
+	}
 	b.Finish(ctx)
 	ctx.FormatNode(node.Statement)
 }
@@ -198,6 +256,9 @@ func (node *ExplainAnalyze) doc(p *PrettyCfg) pretty.Doc {
 			opts = append(opts, pretty.Keyword(f.String()))
 		}
 	}
+	if node.Format != ExplainFormatText {
+		opts = append(opts, pretty.Keyword("FORMAT "+node.Format.String()))
+	}
 	if len(opts) > 0 {
 		d = pretty.ConcatSpace(
 			d,
@@ -214,9 +275,20 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 		options[i] = strings.ToUpper(options[i])
 	}
 	var opts ExplainOptions
-	var analyze bool
+	var analyze, hasFormat bool
 	for _, opt := range options {
 		opt = strings.ToUpper(opt)
+		if strings.HasPrefix(opt, "FORMAT ") {
+			f, ok := explainFormatStringMap[strings.TrimPrefix(opt, "FORMAT ")]
+			if !ok {
+				return nil, pgerror.Newf(pgcode.Syntax, "unsupported EXPLAIN format: %s", opt)
+			}
+			if hasFormat {
+				return nil, pgerror.Newf(pgcode.Syntax, "cannot set EXPLAIN format more than once: %s", opt)
+			}
+			opts.Format, hasFormat = f, true
+			continue
+		}
 		if m, ok := explainModeStringMap[opt]; ok {
 			if opts.Mode != 0 {
 				return nil, pgerror.Newf(pgcode.Syntax, "cannot set EXPLAIN mode more than once: %s", opt)
@@ -246,6 +318,18 @@ func MakeExplain(options []string, stmt Statement) (Statement, error) {
 			return nil, pgerror.Newf(pgcode.Syntax, "the JSON flag cannot be used with ANALYZE")
 		}
 	}
+	if opts.Format != ExplainFormatText {
+		if opts.Mode != ExplainPlan && opts.Mode != ExplainDistSQL {
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"FORMAT %s can only be used with PLAN or DISTSQL", opts.Format)
+		}
+		if analyze {
+			return nil, pgerror.Newf(pgcode.Syntax, "FORMAT %s cannot be used with ANALYZE", opts.Format)
+		}
+		if opts.Flags[ExplainFlagJSON] {
+			return nil, pgerror.Newf(pgcode.Syntax, "the JSON flag cannot be used with FORMAT")
+		}
+	}
 
 	if analyze {
 		if opts.Mode != ExplainDistSQL && opts.Mode != ExplainDebug && opts.Mode != ExplainPlan {
@@ -635,6 +635,9 @@ func (node *With) docRow(p *PrettyCfg) pretty.TableRow {
 			p.Doc(&cte.Name),
 			p.bracketKeyword(asString, " (", p.Doc(cte.Stmt), ")", ""),
 		)
+		if cte.Cycle != nil {
+			d[i] = pretty.ConcatSpace(d[i], p.Doc(cte.Cycle))
+		}
 	}
 	kw := "WITH"
 	if node.Recursive {
@@ -1857,6 +1860,12 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 			pretty.ConcatSpace(pretty.Keyword("DEFAULT"), p.Doc(node.DefaultExpr.Expr))))
 	}
 
+	// ON UPDATE expression.
+	if node.HasOnUpdateExpr() {
+		clauses = append(clauses,
+			pretty.ConcatSpace(pretty.Keyword("ON UPDATE"), p.Doc(node.OnUpdateExpr.Expr)))
+	}
+
 	// NULL/NOT NULL constraint.
 	nConstraint := pretty.Nil
 	switch node.Nullable.Nullability {
@@ -19,7 +19,10 @@
 
 package tree
 
-import "github.com/cockroachdb/cockroach/pkg/sql/privilege"
+import (
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+)
 
 // Revoke represents a REVOKE statement.
 // PrivilegeList and TargetList are defined in grant.go
@@ -56,3 +59,23 @@ func (node *RevokeRole) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	ctx.FormatNode(&node.Members)
 }
+
+// RevokeWebSessions represents a REVOKE WEB SESSION or REVOKE WEB SESSIONS
+// statement. Either Session or User is set.
+type RevokeWebSessions struct {
+	// Session is the ID of the session to revoke.
+	Session Expr
+	// User is the user whose sessions are all revoked.
+	User security.SQLUsername
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RevokeWebSessions) Format(ctx *FmtCtx) {
+	if node.Session != nil {
+		ctx.WriteString("REVOKE WEB SESSION ")
+		ctx.FormatNode(node.Session)
+		return
+	}
+	ctx.WriteString("REVOKE WEB SESSIONS FOR USER ")
+	ctx.FormatUsername(node.User)
+}
@@ -74,3 +74,19 @@ func (node *ScheduledBackup) Format(ctx *FmtCtx) {
 		node.ScheduleOptions.Format(ctx)
 	}
 }
+
+// AlterSchedule represents an ALTER SCHEDULE statement.
+type AlterSchedule struct {
+	Schedule   Expr
+	Recurrence Expr
+}
+
+var _ Statement = &AlterSchedule{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterSchedule) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER SCHEDULE ")
+	ctx.FormatNode(node.Schedule)
+	ctx.WriteString(" SET RECURRING ")
+	ctx.FormatNode(node.Recurrence)
+}
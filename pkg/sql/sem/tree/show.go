@@ -22,6 +22,7 @@ package tree
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 )
 
@@ -72,6 +73,14 @@ func (node *ShowClusterSettingList) Format(ctx *FmtCtx) {
 	ctx.WriteString(" CLUSTER SETTINGS")
 }
 
+// ShowClusterUpgradeStatus represents a SHOW CLUSTER UPGRADE STATUS statement.
+type ShowClusterUpgradeStatus struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowClusterUpgradeStatus) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW CLUSTER UPGRADE STATUS")
+}
+
 // BackupDetails represents the type of details to display for a SHOW BACKUP
 // statement.
 type BackupDetails int
@@ -231,6 +240,11 @@ func (node *ShowDatabaseIndexes) Format(ctx *FmtCtx) {
 type ShowQueries struct {
 	All     bool
 	Cluster bool
+	// If defined, only the queries of this user are shown.
+	User security.SQLUsername
+	// If non-nil, only the queries of sessions with this application name are
+	// shown.
+	Application *string
 }
 
 // Format implements the NodeFormatter interface.
@@ -244,6 +258,14 @@ func (node *ShowQueries) Format(ctx *FmtCtx) {
 	} else {
 		ctx.WriteString("LOCAL QUERIES")
 	}
+	if !node.User.Undefined() {
+		ctx.WriteString(" FOR USER ")
+		ctx.FormatUsername(node.User)
+	}
+	if node.Application != nil {
+		ctx.WriteString(" FOR APPLICATION ")
+		lex.EncodeSQLStringWithFlags(&ctx.Buffer, *node.Application, ctx.flags.EncodeFlags())
+	}
 }
 
 // ShowJobs represents a SHOW JOBS statement
@@ -262,6 +284,10 @@ type ShowJobs struct {
 	// If non-nil, only display jobs started by the specified
 	// schedules.
 	Schedules *Select
+
+	// Whether to also display the job-specific details of the selected jobs,
+	// such as the schema conversion report of an IMPORT.
+	Details bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -277,6 +303,9 @@ func (node *ShowJobs) Format(ctx *FmtCtx) {
 	if node.Jobs != nil {
 		ctx.WriteString(" ")
 		ctx.FormatNode(node.Jobs)
+		if node.Details {
+			ctx.WriteString(" WITH DETAILS")
+		}
 	}
 	if node.Schedules != nil {
 		ctx.WriteString(" FOR SCHEDULES ")
@@ -335,6 +364,14 @@ func (node *ShowRegions) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowCompactions represents a SHOW COMPACTIONS statement.
+type ShowCompactions struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowCompactions) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW COMPACTIONS")
+}
+
 // ShowSessions represents a SHOW SESSIONS statement
 type ShowSessions struct {
 	All     bool
@@ -354,9 +391,25 @@ func (node *ShowSessions) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowWebSessions represents a SHOW WEB SESSIONS statement.
+type ShowWebSessions struct {
+	// If set, the expired and revoked sessions are shown as well.
+	All bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowWebSessions) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW ")
+	if node.All {
+		ctx.WriteString("ALL ")
+	}
+	ctx.WriteString("WEB SESSIONS")
+}
+
 // ShowSchemas represents a SHOW SCHEMAS statement.
 type ShowSchemas struct {
-	Database Name
+	Database       Name
+	WithPrivileges bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -366,6 +419,9 @@ func (node *ShowSchemas) Format(ctx *FmtCtx) {
 		ctx.WriteString(" FROM ")
 		ctx.FormatNode(&node.Database)
 	}
+	if node.WithPrivileges {
+		ctx.WriteString(" WITH PRIVILEGES")
+	}
 }
 
 // ShowSequences represents a SHOW SEQUENCES statement.
@@ -385,7 +441,15 @@ func (node *ShowSequences) Format(ctx *FmtCtx) {
 // ShowTables represents a SHOW TABLES statement.
 type ShowTables struct {
 	ObjectNamePrefix
+	ShowTablesOptions
+}
+
+// ShowTablesOptions represents the WITH options of a SHOW TABLES statement.
+type ShowTablesOptions struct {
+	// WithComment adds the comment of each table to the output.
 	WithComment bool
+	// WithDetails adds size estimates for each table to the output.
+	WithDetails bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -396,8 +460,13 @@ func (node *ShowTables) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.ObjectNamePrefix)
 	}
 
-	if node.WithComment {
+	switch {
+	case node.WithComment && node.WithDetails:
+		ctx.WriteString(" WITH COMMENT, DETAILS")
+	case node.WithComment:
 		ctx.WriteString(" WITH COMMENT")
+	case node.WithDetails:
+		ctx.WriteString(" WITH DETAILS")
 	}
 }
 
@@ -473,12 +542,26 @@ func (node *ShowRoleGrants) Format(ctx *FmtCtx) {
 // ShowCreate represents a SHOW CREATE statement.
 type ShowCreate struct {
 	Name *UnresolvedObjectName
+	// Original is set when the CREATE VIEW statement of a view should be
+	// shown as it was originally issued.
+	Original bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *ShowCreate) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW CREATE ")
 	ctx.FormatNode(node.Name)
+	if node.Original {
+		ctx.WriteString(" WITH ORIGINAL")
+	}
+}
+
+// ShowCreateAllTables represents a SHOW CREATE ALL TABLES statement.
+type ShowCreateAllTables struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowCreateAllTables) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW CREATE ALL TABLES")
 }
 
 // ShowSyntax represents a SHOW SYNTAX statement.
@@ -505,6 +588,15 @@ func (node *ShowTransactionStatus) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW TRANSACTION STATUS")
 }
 
+// ShowTransactionDetails represents a SHOW TRANSACTION DETAILS statement.
+type ShowTransactionDetails struct {
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowTransactionDetails) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW TRANSACTION DETAILS")
+}
+
 // ShowLastQueryStatistics represents a SHOW LAST QUERY STATS statement.
 type ShowLastQueryStatistics struct{}
 
@@ -121,3 +121,41 @@ func (node *Scatter) Format(ctx *FmtCtx) {
 		ctx.WriteString(")")
 	}
 }
+
+// Compact represents an `ALTER TABLE/INDEX .. EXPERIMENTAL COMPACT` statement.
+type Compact struct {
+	TableOrIndex TableIndexName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Compact) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER ")
+	if node.TableOrIndex.Index != "" {
+		ctx.WriteString("INDEX ")
+	} else {
+		ctx.WriteString("TABLE ")
+	}
+	ctx.FormatNode(&node.TableOrIndex)
+	ctx.WriteString(" EXPERIMENTAL COMPACT")
+}
+
+// VerifyConsistency represents an `ALTER TABLE/RANGE .. EXPERIMENTAL VERIFY
+// CONSISTENCY` statement.
+type VerifyConsistency struct {
+	// Table is the table whose ranges are checked. It is nil if a single range
+	// is checked.
+	Table *UnresolvedObjectName
+	// RangeID is the ID of the range to check if Table is nil.
+	RangeID int64
+}
+
+// Format implements the NodeFormatter interface.
+func (node *VerifyConsistency) Format(ctx *FmtCtx) {
+	if node.Table != nil {
+		ctx.WriteString("ALTER TABLE ")
+		ctx.FormatNode(node.Table)
+	} else {
+		ctx.Printf("ALTER RANGE %d", node.RangeID)
+	}
+	ctx.WriteString(" EXPERIMENTAL VERIFY CONSISTENCY")
+}
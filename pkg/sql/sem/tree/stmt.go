@@ -99,7 +99,7 @@ func CanModifySchema(stmt Statement) bool {
 func CanWriteData(stmt Statement) bool {
 	switch stmt.(type) {
 	// Normal write operations.
-	case *Insert, *Delete, *Update, *Truncate:
+	case *Insert, *Delete, *Update, *Truncate, *BatchedDML:
 		return true
 	// Import operations.
 	case *CopyFrom, *Import, *Restore:
@@ -198,6 +198,18 @@ func (*AlterIndex) StatementTag() string { return "ALTER INDEX" }
 
 func (*AlterIndex) hiddenFromShowQueries() {}
 
+// StatementType implements the Statement interface.
+func (*AlterJob) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterJob) StatementTag() string { return "ALTER JOB" }
+
+// StatementType implements the Statement interface.
+func (*AlterSchedule) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterSchedule) StatementTag() string { return "ALTER SCHEDULE" }
+
 // StatementType implements the Statement interface.
 func (*AlterTable) StatementType() StatementType { return DDL }
 
@@ -254,6 +266,12 @@ func (*AlterRole) cclOnlyStatement() {}
 
 func (*AlterRole) hiddenFromShowQueries() {}
 
+// StatementType implements the Statement interface.
+func (*AlterRoleSet) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterRoleSet) StatementTag() string { return "ALTER ROLE" }
+
 // StatementType implements the Statement interface.
 func (*Analyze) StatementType() StatementType { return DDL }
 
@@ -280,6 +298,12 @@ func (*ScheduledBackup) cclOnlyStatement() {}
 
 func (*ScheduledBackup) hiddenFromShowQueries() {}
 
+// StatementType implements the Statement interface.
+func (*BatchedDML) StatementType() StatementType { return RowsAffected }
+
+// StatementTag returns a short string identifying the type of statement.
+func (n *BatchedDML) StatementTag() string { return n.Statement.StatementTag() }
+
 // StatementType implements the Statement interface.
 func (*BeginTransaction) StatementType() StatementType { return Ack }
 
@@ -358,6 +382,12 @@ func (*CommitTransaction) StatementType() StatementType { return Ack }
 // StatementTag returns a short string identifying the type of statement.
 func (*CommitTransaction) StatementTag() string { return "COMMIT" }
 
+// StatementType implements the Statement interface.
+func (*Compact) StatementType() StatementType { return Ack }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Compact) StatementTag() string { return "COMPACT" }
+
 // StatementType implements the Statement interface.
 func (*CopyFrom) StatementType() StatementType { return CopyIn }
 
@@ -479,7 +509,12 @@ func (n *Delete) StatementType() StatementType { return n.Returning.statementTyp
 func (*Delete) StatementTag() string { return "DELETE" }
 
 // StatementType implements the Statement interface.
-func (*DropDatabase) StatementType() StatementType { return DDL }
+func (n *DropDatabase) StatementType() StatementType {
+	if n.DryRun {
+		return Rows
+	}
+	return DDL
+}
 
 // StatementTag returns a short string identifying the type of statement.
 func (*DropDatabase) StatementTag() string { return "DROP DATABASE" }
@@ -491,7 +526,12 @@ func (*DropIndex) StatementType() StatementType { return DDL }
 func (*DropIndex) StatementTag() string { return "DROP INDEX" }
 
 // StatementType implements the Statement interface.
-func (*DropTable) StatementType() StatementType { return DDL }
+func (n *DropTable) StatementType() StatementType {
+	if n.DryRun {
+		return Rows
+	}
+	return DDL
+}
 
 // StatementTag returns a short string identifying the type of statement.
 func (*DropTable) StatementTag() string { return "DROP TABLE" }
@@ -688,6 +728,12 @@ func (*RevokeRole) StatementType() StatementType { return DDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*RevokeRole) StatementTag() string { return "REVOKE" }
 
+// StatementType implements the Statement interface.
+func (*RevokeWebSessions) StatementType() StatementType { return RowsAffected }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*RevokeWebSessions) StatementTag() string { return "REVOKE WEB SESSIONS" }
+
 // StatementType implements the Statement interface.
 func (*RollbackToSavepoint) StatementType() StatementType { return Ack }
 
@@ -793,18 +839,36 @@ func (*ShowClusterSettingList) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowClusterSettingList) StatementTag() string { return "SHOW" }
 
+// StatementType implements the Statement interface.
+func (*ShowClusterUpgradeStatus) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowClusterUpgradeStatus) StatementTag() string { return "SHOW CLUSTER UPGRADE STATUS" }
+
 // StatementType implements the Statement interface.
 func (*ShowColumns) StatementType() StatementType { return Rows }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowColumns) StatementTag() string { return "SHOW COLUMNS" }
 
+// StatementType implements the Statement interface.
+func (*ShowCompactions) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCompactions) StatementTag() string { return "SHOW COMPACTIONS" }
+
 // StatementType implements the Statement interface.
 func (*ShowCreate) StatementType() StatementType { return Rows }
 
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowCreate) StatementTag() string { return "SHOW CREATE" }
 
+// StatementType implements the Statement interface.
+func (*ShowCreateAllTables) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCreateAllTables) StatementTag() string { return "SHOW CREATE ALL TABLES" }
+
 // StatementType implements the Statement interface.
 func (*ShowBackup) StatementType() StatementType { return Rows }
 
@@ -885,6 +949,12 @@ func (*ShowSessions) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowSessions) StatementTag() string { return "SHOW SESSIONS" }
 
+// StatementType implements the Statement interface.
+func (*ShowWebSessions) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowWebSessions) StatementTag() string { return "SHOW WEB SESSIONS" }
+
 // StatementType implements the Statement interface.
 func (*ShowTableStats) StatementType() StatementType { return Rows }
 
@@ -919,6 +989,14 @@ func (*ShowTransactionStatus) StatementTag() string { return "SHOW TRANSACTION S
 
 func (*ShowTransactionStatus) observerStatement() {}
 
+// StatementType implements the Statement interface.
+func (*ShowTransactionDetails) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowTransactionDetails) StatementTag() string { return "SHOW TRANSACTION DETAILS" }
+
+func (*ShowTransactionDetails) observerStatement() {}
+
 // StatementType implements the Statement interface.
 func (*ShowSavepointStatus) StatementType() StatementType { return Rows }
 
@@ -1052,7 +1130,15 @@ func (*ValuesClause) StatementType() StatementType { return Rows }
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementType implements the Statement interface.
+func (*VerifyConsistency) StatementType() StatementType { return Rows }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*VerifyConsistency) StatementTag() string { return "VERIFY CONSISTENCY" }
+
 func (n *AlterIndex) String() string                     { return AsString(n) }
+func (n *AlterJob) String() string                       { return AsString(n) }
+func (n *AlterSchedule) String() string                  { return AsString(n) }
 func (n *AlterDatabaseOwner) String() string             { return AsString(n) }
 func (n *AlterDatabaseAddRegion) String() string         { return AsString(n) }
 func (n *AlterDatabaseDropRegion) String() string        { return AsString(n) }
@@ -1074,9 +1160,11 @@ func (n *AlterTableSetNotNull) String() string           { return AsString(n) }
 func (n *AlterTableSetSchema) String() string            { return AsString(n) }
 func (n *AlterType) String() string                      { return AsString(n) }
 func (n *AlterRole) String() string                      { return AsString(n) }
+func (n *AlterRoleSet) String() string                   { return AsString(n) }
 func (n *AlterSequence) String() string                  { return AsString(n) }
 func (n *Analyze) String() string                        { return AsString(n) }
 func (n *Backup) String() string                         { return AsString(n) }
+func (n *BatchedDML) String() string                     { return AsString(n) }
 func (n *BeginTransaction) String() string               { return AsString(n) }
 func (n *ControlJobs) String() string                    { return AsString(n) }
 func (n *ControlSchedules) String() string               { return AsString(n) }
@@ -1089,6 +1177,7 @@ func (n *CommentOnDatabase) String() string              { return AsString(n) }
 func (n *CommentOnIndex) String() string                 { return AsString(n) }
 func (n *CommentOnTable) String() string                 { return AsString(n) }
 func (n *CommitTransaction) String() string              { return AsString(n) }
+func (n *Compact) String() string                        { return AsString(n) }
 func (n *CopyFrom) String() string                       { return AsString(n) }
 func (n *CreateChangefeed) String() string               { return AsString(n) }
 func (n *CreateDatabase) String() string                 { return AsString(n) }
@@ -1133,6 +1222,7 @@ func (n *RenameTable) String() string                    { return AsString(n) }
 func (n *Restore) String() string                        { return AsString(n) }
 func (n *Revoke) String() string                         { return AsString(n) }
 func (n *RevokeRole) String() string                     { return AsString(n) }
+func (n *RevokeWebSessions) String() string              { return AsString(n) }
 func (n *RollbackToSavepoint) String() string            { return AsString(n) }
 func (n *RollbackTransaction) String() string            { return AsString(n) }
 func (n *Savepoint) String() string                      { return AsString(n) }
@@ -1151,9 +1241,12 @@ func (n *SetVar) String() string                         { return AsString(n) }
 func (n *ShowBackup) String() string                     { return AsString(n) }
 func (n *ShowClusterSetting) String() string             { return AsString(n) }
 func (n *ShowClusterSettingList) String() string         { return AsString(n) }
+func (n *ShowClusterUpgradeStatus) String() string       { return AsString(n) }
 func (n *ShowColumns) String() string                    { return AsString(n) }
+func (n *ShowCompactions) String() string                { return AsString(n) }
 func (n *ShowConstraints) String() string                { return AsString(n) }
 func (n *ShowCreate) String() string                     { return AsString(n) }
+func (n *ShowCreateAllTables) String() string            { return AsString(n) }
 func (n *ShowDatabases) String() string                  { return AsString(n) }
 func (n *ShowDatabaseIndexes) String() string            { return AsString(n) }
 func (n *ShowEnums) String() string                      { return AsString(n) }
@@ -1180,10 +1273,12 @@ func (n *ShowTables) String() string                     { return AsString(n) }
 func (n *ShowTypes) String() string                      { return AsString(n) }
 func (n *ShowTraceForSession) String() string            { return AsString(n) }
 func (n *ShowTransactionStatus) String() string          { return AsString(n) }
+func (n *ShowTransactionDetails) String() string         { return AsString(n) }
 func (n *ShowTransactions) String() string               { return AsString(n) }
 func (n *ShowLastQueryStatistics) String() string        { return AsString(n) }
 func (n *ShowUsers) String() string                      { return AsString(n) }
 func (n *ShowVar) String() string                        { return AsString(n) }
+func (n *ShowWebSessions) String() string                { return AsString(n) }
 func (n *ShowZoneConfig) String() string                 { return AsString(n) }
 func (n *ShowFingerprints) String() string               { return AsString(n) }
 func (n *Split) String() string                          { return AsString(n) }
@@ -1192,3 +1287,4 @@ func (n *Truncate) String() string                       { return AsString(n) }
 func (n *UnionClause) String() string                    { return AsString(n) }
 func (n *Update) String() string                         { return AsString(n) }
 func (n *ValuesClause) String() string                   { return AsString(n) }
+func (n *VerifyConsistency) String() string              { return AsString(n) }
@@ -21,6 +21,28 @@ type CTE struct {
 	Name AliasClause
 	Mtr  MaterializeClause
 	Stmt Statement
+	// Cycle is the optional CYCLE clause of a recursive CTE.
+	Cycle *CycleClause
+}
+
+// CycleClause represents the CYCLE clause of a recursive common table
+// expression:
+//
+//   CYCLE <column> [, ...] SET <mark column> [ TO <value> DEFAULT <default> ]
+//     USING <path column>
+type CycleClause struct {
+	// Columns are the columns used to detect a cycle.
+	Columns NameList
+	// MarkColumn is the name of the output column that indicates whether a
+	// cycle was detected.
+	MarkColumn Name
+	// MarkValue and MarkDefault are the values of the mark column when a cycle
+	// was or was not detected, respectively. If nil, TRUE and FALSE are used.
+	MarkValue   Expr
+	MarkDefault Expr
+	// PathColumn is the name of the output column that tracks the rows visited
+	// so far.
+	PathColumn Name
 }
 
 // MaterializeClause represents a materialize clause inside of a WITH clause.
@@ -56,6 +78,26 @@ func (node *With) Format(ctx *FmtCtx) {
 		ctx.WriteString("(")
 		ctx.FormatNode(cte.Stmt)
 		ctx.WriteString(")")
+		if cte.Cycle != nil {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(cte.Cycle)
+		}
 	}
 	ctx.WriteByte(' ')
 }
+
+// Format implements the NodeFormatter interface.
+func (node *CycleClause) Format(ctx *FmtCtx) {
+	ctx.WriteString("CYCLE ")
+	ctx.FormatNode(&node.Columns)
+	ctx.WriteString(" SET ")
+	ctx.FormatNode(&node.MarkColumn)
+	if node.MarkValue != nil {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(node.MarkValue)
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.MarkDefault)
+	}
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(&node.PathColumn)
+}
@@ -93,8 +93,27 @@ func (p *planner) IncrementSequence(ctx context.Context, seqName *tree.TableName
 		val = int64(rowid)
 	} else {
 		seqValueKey := p.ExecCfg().Codec.SequenceKey(uint32(descriptor.ID))
-		val, err = kv.IncrementValRetryable(
-			ctx, p.txn.DB(), seqValueKey, seqOpts.Increment)
+		if seqOpts.CacheSize > 1 {
+			// Allocate CacheSize values at once, and hand them out to this
+			// session until they run out.
+			val, err = p.SessionData().SequenceState.NextCachedValue(
+				uint32(descriptor.ID), uint64(descriptor.Version),
+				func() (first, increment, count int64, err error) {
+					inc := seqOpts.Increment * seqOpts.CacheSize
+					if inc/seqOpts.CacheSize != seqOpts.Increment {
+						return 0, 0, 0, boundsExceededError(descriptor)
+					}
+					last, err := kv.IncrementValRetryable(ctx, p.txn.DB(), seqValueKey, inc)
+					if err != nil {
+						return 0, 0, 0, err
+					}
+					first = last - inc + seqOpts.Increment
+					return first, seqOpts.Increment, seqOpts.CacheSize, nil
+				})
+		} else {
+			val, err = kv.IncrementValRetryable(
+				ctx, p.txn.DB(), seqValueKey, seqOpts.Increment)
+		}
 		if err != nil {
 			if errors.HasType(err, (*roachpb.IntegerOverflowError)(nil)) {
 				return 0, boundsExceededError(descriptor)
@@ -181,6 +200,8 @@ func (p *planner) SetSequenceValue(
 	if err != nil {
 		return err
 	}
+	// The values cached by this session no longer follow the new value.
+	p.SessionData().SequenceState.DiscardCachedValues(uint32(descriptor.ID))
 
 	// TODO(vilterp): not supposed to mix usage of Inc and Put on a key,
 	// according to comments on Inc operation. Switch to Inc if `desired-current`
@@ -281,17 +302,11 @@ func assignSequenceOptions(
 		case tree.SeqOptNoCycle:
 			// Do nothing; this is the default.
 		case tree.SeqOptCache:
-			v := *option.IntVal
-			switch {
-			case v < 1:
+			if v := *option.IntVal; v < 1 {
 				return pgerror.Newf(pgcode.InvalidParameterValue,
 					"CACHE (%d) must be greater than zero", v)
-			case v == 1:
-				// Do nothing; this is the default.
-			case v > 1:
-				return unimplemented.NewWithIssuef(32567,
-					"CACHE values larger than 1 are not supported, found %d", v)
 			}
+			opts.CacheSize = *option.IntVal
 		case tree.SeqOptIncrement:
 			// Do nothing; this has already been set.
 		case tree.SeqOptMinValue:
@@ -465,9 +480,11 @@ func addSequenceOwner(
 }
 
 // maybeAddSequenceDependencies adds references between the column and sequence descriptors,
-// if the column has a DEFAULT expression that uses one or more sequences. (Usually just one,
-// e.g. `DEFAULT nextval('my_sequence')`.
+// if the column has a DEFAULT or ON UPDATE expression that uses one or more sequences.
+// (Usually just one, e.g. `DEFAULT nextval('my_sequence')`.
 // The passed-in column descriptor is mutated, and the modified sequence descriptors are returned.
+// Sequences the column already references are skipped, since the DEFAULT and ON UPDATE
+// expressions of a column can use the same sequence.
 func maybeAddSequenceDependencies(
 	ctx context.Context,
 	sc resolver.SchemaResolver,
@@ -508,6 +525,9 @@ func maybeAddSequenceDependencies(
 		if prev, ok := backrefs[seqDesc.ID]; ok {
 			seqDesc = prev
 		}
+		if usesSequenceID(col, seqDesc.ID) {
+			continue
+		}
 		col.UsesSequenceIds = append(col.UsesSequenceIds, seqDesc.ID)
 		// Add reference from sequence descriptor to column.
 		refIdx := -1
@@ -529,6 +549,16 @@ func maybeAddSequenceDependencies(
 	return seqDescs, nil
 }
 
+// usesSequenceID returns whether the column references the given sequence.
+func usesSequenceID(col *descpb.ColumnDescriptor, seqID descpb.ID) bool {
+	for _, id := range col.UsesSequenceIds {
+		if id == seqID {
+			return true
+		}
+	}
+	return false
+}
+
 // dropSequencesOwnedByCol drops all the sequences from col.OwnsSequenceIDs.
 // Called when the respective column (or the whole table) is being dropped.
 func (p *planner) dropSequencesOwnedByCol(
@@ -30,9 +30,28 @@ type SequenceState struct {
 		// lastSequenceIncremented records the descriptor id of the last sequence
 		// nextval() was called on in this session.
 		lastSequenceIncremented uint32
+
+		// cachedValues stores, by descriptor id, the values of sequences with a
+		// CACHE larger than 1 which were allocated to this session but not yet
+		// returned by nextval().
+		cachedValues map[uint32]*cachedSequenceValues
 	}
 }
 
+// cachedSequenceValues is a range of values of a sequence allocated to a
+// session.
+type cachedSequenceValues struct {
+	// version is the version of the sequence descriptor the values were
+	// allocated with. The values are discarded once the descriptor changes,
+	// e.g. when the increment of the sequence is altered.
+	version uint64
+	// next is the next value to return, and increment the difference between
+	// consecutive values.
+	next, increment int64
+	// remaining is the number of values left, including next.
+	remaining int64
+}
+
 // NewSequenceState creates a SequenceState.
 func NewSequenceState() *SequenceState {
 	ss := SequenceState{}
@@ -54,6 +73,43 @@ func (ss *SequenceState) RecordValue(seqID uint32, val int64) {
 	ss.mu.Unlock()
 }
 
+// NextCachedValue returns the next value of the given sequence from the
+// values cached by this session. If there are none left, or the values were
+// allocated with a different version of the sequence descriptor, allocate is
+// called to allocate count values, the first of which is first, separated by
+// increment.
+func (ss *SequenceState) NextCachedValue(
+	seqID uint32, version uint64, allocate func() (first, increment, count int64, err error),
+) (int64, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	c := ss.mu.cachedValues[seqID]
+	if c == nil || c.version != version || c.remaining == 0 {
+		first, increment, count, err := allocate()
+		if err != nil {
+			return 0, err
+		}
+		if ss.mu.cachedValues == nil {
+			ss.mu.cachedValues = make(map[uint32]*cachedSequenceValues)
+		}
+		c = &cachedSequenceValues{version: version, next: first, increment: increment, remaining: count}
+		ss.mu.cachedValues[seqID] = c
+	}
+	val := c.next
+	c.next += c.increment
+	c.remaining--
+	return val, nil
+}
+
+// DiscardCachedValues discards the values of the given sequence cached by
+// this session, e.g. because setval() was called.
+func (ss *SequenceState) DiscardCachedValues(seqID uint32) {
+	ss.mu.Lock()
+	delete(ss.mu.cachedValues, seqID)
+	ss.mu.Unlock()
+}
+
 // SetLastSequenceIncremented sets the id of the last incremented sequence.
 // Usually this id is set through RecordValue().
 func (ss *SequenceState) SetLastSequenceIncremented(seqID uint32) {
@@ -116,6 +116,9 @@ type LocalOnlySessionData struct {
 	SaveTablesPrefix string
 	// RemoteAddr is used to generate logging events.
 	RemoteAddr net.Addr
+	// ClusterName is the name of the cluster the client asked to connect to
+	// with the --cluster option of the options connection parameter.
+	ClusterName string
 	// VectorizeRowCountThreshold indicates the row count above which the
 	// vectorized execution engine will be used if possible.
 	VectorizeRowCountThreshold uint64
@@ -143,6 +146,10 @@ type LocalOnlySessionData struct {
 	// StmtTimeout is the duration a query is permitted to run before it is
 	// canceled by the session. If set to 0, there is no timeout.
 	StmtTimeout time.Duration
+	// TransactionTimeout is the duration a transaction is permitted to run
+	// before the statement it is executing is canceled by the session. If set
+	// to 0, there is no timeout.
+	TransactionTimeout time.Duration
 	// IdleInSessionTimeout is the duration a session is permitted to idle before
 	// the session is canceled. If set to 0, there is no timeout.
 	IdleInSessionTimeout time.Duration
@@ -206,6 +213,9 @@ type LocalOnlySessionData struct {
 	// DisallowFullTableScans indicates whether queries that plan full table scans
 	// should be rejected.
 	DisallowFullTableScans bool
+	// ResultCacheEnabled indicates whether the results of eligible read-only
+	// queries can be served from (and added to) the per-node result cache.
+	ResultCacheEnabled bool
 	// ImplicitSelectForUpdate is true if FOR UPDATE locking may be used during
 	// the row-fetch phase of mutation statements.
 	ImplicitSelectForUpdate bool
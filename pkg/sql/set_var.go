@@ -12,6 +12,7 @@ package sql
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -304,6 +306,16 @@ func stmtTimeoutVarSet(ctx context.Context, m *sessionDataMutator, s string) err
 	return nil
 }
 
+func transactionTimeoutVarSet(ctx context.Context, m *sessionDataMutator, s string) error {
+	timeout, err := validateTimeoutVar(s, "transaction_timeout")
+	if err != nil {
+		return err
+	}
+
+	m.SetTransactionTimeout(timeout)
+	return nil
+}
+
 func idleInSessionTimeoutVarSet(ctx context.Context, m *sessionDataMutator, s string) error {
 	timeout, err := validateTimeoutVar(s, "idle_in_session_timeout")
 	if err != nil {
@@ -327,6 +339,75 @@ func idleInTransactionSessionTimeoutVarSet(
 	return nil
 }
 
+// makeByteSizeGetStringValFn returns a getStringValFn for variables that
+// accept either a number of bytes or a human-readable size such as '64MiB'.
+func makeByteSizeGetStringValFn(varName string) getStringValFn {
+	return func(
+		_ context.Context, evalCtx *extendedEvalContext, values []tree.TypedExpr,
+	) (string, error) {
+		if len(values) != 1 {
+			return "", newSingleArgVarError(varName)
+		}
+		d, err := values[0].Eval(&evalCtx.EvalContext)
+		if err != nil {
+			return "", err
+		}
+		switch v := tree.UnwrapDatum(&evalCtx.EvalContext, d).(type) {
+		case *tree.DString:
+			return string(*v), nil
+		case *tree.DInt:
+			return strconv.FormatInt(int64(*v), 10), nil
+		}
+		return "", newVarValueError(varName, values[0].String())
+	}
+}
+
+// parseByteSizeVar parses a value of the given variable, which must be a
+// non-negative byte size, into a number of bytes.
+func parseByteSizeVar(varName, s string) (int64, error) {
+	size, err := humanizeutil.ParseBytes(s)
+	if err != nil {
+		return 0, wrapSetVarError(varName, s, "%v", err)
+	}
+	if size < 0 {
+		return 0, wrapSetVarError(varName, s, "%s cannot be negative", varName)
+	}
+	return size, nil
+}
+
+// parseMaxQueryMemory parses a value of the max_query_memory variable into
+// a number of bytes.
+func parseMaxQueryMemory(s string) (int64, error) {
+	return parseByteSizeVar("max_query_memory", s)
+}
+
+func maxQueryMemoryVarSet(_ context.Context, m *sessionDataMutator, s string) error {
+	size, err := parseMaxQueryMemory(s)
+	if err != nil {
+		return err
+	}
+	m.SetMaxQueryMemory(size)
+	return nil
+}
+
+func joinReaderMinBatchSizeVarSet(_ context.Context, m *sessionDataMutator, s string) error {
+	size, err := parseByteSizeVar("join_reader_min_batch_size", s)
+	if err != nil {
+		return err
+	}
+	m.SetJoinReaderMinBatchSize(size)
+	return nil
+}
+
+func joinReaderMaxBatchSizeVarSet(_ context.Context, m *sessionDataMutator, s string) error {
+	size, err := parseByteSizeVar("join_reader_max_batch_size", s)
+	if err != nil {
+		return err
+	}
+	m.SetJoinReaderMaxBatchSize(size)
+	return nil
+}
+
 func intervalToDuration(interval *tree.DInterval) (time.Duration, error) {
 	nanos, _, _, err := interval.Encode()
 	if err != nil {
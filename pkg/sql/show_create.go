@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 )
 
 type shouldOmitFKClausesFromCreate int
@@ -244,14 +245,22 @@ func (p *planner) ShowCreate(
 	tn := tree.MakeUnqualifiedTableName(tree.Name(desc.Name))
 	if desc.IsView() {
 		stmt, err = ShowCreateView(ctx, &tn, desc)
-	} else if desc.IsSequence() {
-		stmt, err = ShowCreateSequence(ctx, &tn, desc)
 	} else {
 		lCtx, lErr := newInternalLookupCtxFromDescriptors(ctx, allDescs, nil /* want all tables */)
 		if lErr != nil {
 			return "", lErr
 		}
-		stmt, err = ShowCreateTable(ctx, p, &tn, dbPrefix, desc, lCtx, displayOptions)
+		if desc.IsSequence() {
+			// The table owning the sequence may not be among the descriptors,
+			// e.g. when only the sequence was backed up.
+			owner, oErr := showSequenceOwner(desc, lCtx, dbPrefix)
+			if oErr != nil && !sqlerrors.IsUndefinedRelationError(oErr) {
+				return "", oErr
+			}
+			stmt, err = ShowCreateSequence(ctx, &tn, desc, owner)
+		} else {
+			stmt, err = ShowCreateTable(ctx, p, &tn, dbPrefix, desc, lCtx, displayOptions)
+		}
 	}
 
 	return stmt, err
@@ -222,9 +222,11 @@ func showForeignKeyConstraint(
 }
 
 // ShowCreateSequence returns a valid SQL representation of the
-// CREATE SEQUENCE statement used to create the given sequence.
+// CREATE SEQUENCE statement used to create the given sequence. If owner is
+// not nil, it is the column owning the sequence, as returned by
+// showSequenceOwner, and an OWNED BY clause is included.
 func ShowCreateSequence(
-	ctx context.Context, tn *tree.TableName, desc catalog.TableDescriptor,
+	ctx context.Context, tn *tree.TableName, desc catalog.TableDescriptor, owner *tree.ColumnItem,
 ) (string, error) {
 	f := tree.NewFmtCtx(tree.FmtSimple)
 	f.WriteString("CREATE ")
@@ -238,12 +240,57 @@ func ShowCreateSequence(
 	f.Printf(" MAXVALUE %d", opts.MaxValue)
 	f.Printf(" INCREMENT %d", opts.Increment)
 	f.Printf(" START %d", opts.Start)
+	if opts.CacheSize > 1 {
+		f.Printf(" CACHE %d", opts.CacheSize)
+	}
 	if opts.Virtual {
 		f.Printf(" VIRTUAL")
 	}
+	if owner != nil {
+		f.WriteString(" OWNED BY ")
+		f.FormatNode(owner)
+	}
 	return f.CloseAndGetString(), nil
 }
 
+// showSequenceOwner returns the column owning the given sequence, named as
+// in the OWNED BY clause of a CREATE SEQUENCE statement, or nil if the
+// sequence isn't owned. The name of the table is prefixed by its database
+// name unless it is equal to dbPrefix.
+func showSequenceOwner(
+	desc catalog.TableDescriptor, lCtx simpleSchemaResolver, dbPrefix string,
+) (*tree.ColumnItem, error) {
+	owner := desc.GetSequenceOpts().SequenceOwner
+	if owner.OwnerTableID == descpb.InvalidID {
+		return nil, nil
+	}
+	table, err := lCtx.getTableByID(owner.OwnerTableID)
+	if err != nil {
+		return nil, err
+	}
+	tn, err := getTableNameFromTableDescriptor(lCtx, table, dbPrefix)
+	if err != nil {
+		return nil, err
+	}
+	col, err := table.FindColumnByID(owner.OwnerColumnID)
+	if err != nil {
+		return nil, err
+	}
+	return &tree.ColumnItem{TableName: tn.ToUnresolvedObjectName(), ColumnName: col.ColName()}, nil
+}
+
+// showAlterSequenceOwner returns the ALTER SEQUENCE statement setting the
+// owner of the given sequence, which is emitted once the owning table has
+// been created.
+func showAlterSequenceOwner(tn *tree.TableName, owner *tree.ColumnItem) string {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	f.WriteString("ALTER SEQUENCE ")
+	f.FormatNode(tn)
+	f.WriteString(" OWNED BY ")
+	f.FormatNode(owner)
+	return f.CloseAndGetString()
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
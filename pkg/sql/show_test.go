@@ -285,6 +285,27 @@ func TestShowCreateTable(t *testing.T) {
 	FAMILY "primary" (i, j, k, l, rowid)
 )`,
 		},
+		// Check that comments are included, so that they survive the round trip.
+		{
+			stmt: `CREATE TABLE %[1]s (
+	a INT8 NULL,
+	b INT8 NULL,
+	INDEX idx_a (a ASC),
+	FAMILY "primary" (a, b, rowid)
+);
+COMMENT ON TABLE %[1]s IS 'it''s a table';
+COMMENT ON COLUMN %[1]s.b IS 'column b';
+COMMENT ON INDEX %[1]s@idx_a IS e'index\non a'`,
+			expect: `CREATE TABLE public.%[1]s (
+	a INT8 NULL,
+	b INT8 NULL,
+	INDEX idx_a (a ASC),
+	FAMILY "primary" (a, b, rowid)
+);
+COMMENT ON TABLE public.%[1]s IS e'it\'s a table';
+COMMENT ON COLUMN public.%[1]s.b IS 'column b';
+COMMENT ON INDEX public.%[1]s@idx_a IS e'index\non a'`,
+		},
 	}
 	for i, test := range tests {
 		name := fmt.Sprintf("t%d", i)
@@ -437,6 +458,7 @@ func TestShowCreateSequence(t *testing.T) {
 	if _, err := sqlDB.Exec(`
 		CREATE DATABASE d;
 		SET DATABASE = d;
+		CREATE TABLE seq_owner (a INT);
 	`); err != nil {
 		t.Fatal(err)
 	}
@@ -461,6 +483,22 @@ func TestShowCreateSequence(t *testing.T) {
 			`CREATE SEQUENCE %s INCREMENT 5 MAXVALUE 10000 START 10 MINVALUE 0`,
 			`CREATE SEQUENCE public.%s MINVALUE 0 MAXVALUE 10000 INCREMENT 5 START 10`,
 		},
+		{
+			`CREATE SEQUENCE %s CACHE 10`,
+			`CREATE SEQUENCE public.%s MINVALUE 1 MAXVALUE 9223372036854775807 INCREMENT 1 START 1 CACHE 10`,
+		},
+		{
+			`CREATE SEQUENCE %s CACHE 1`,
+			`CREATE SEQUENCE public.%s MINVALUE 1 MAXVALUE 9223372036854775807 INCREMENT 1 START 1`,
+		},
+		{
+			`CREATE SEQUENCE %s OWNED BY seq_owner.a`,
+			`CREATE SEQUENCE public.%s MINVALUE 1 MAXVALUE 9223372036854775807 INCREMENT 1 START 1 OWNED BY public.seq_owner.a`,
+		},
+		{
+			`CREATE SEQUENCE %s INCREMENT 2 CACHE 5 OWNED BY seq_owner.a`,
+			`CREATE SEQUENCE public.%s MINVALUE 1 MAXVALUE 9223372036854775807 INCREMENT 2 START 1 CACHE 5 OWNED BY public.seq_owner.a`,
+		},
 	}
 	for i, test := range tests {
 		t.Run(fmt.Sprint(i), func(t *testing.T) {
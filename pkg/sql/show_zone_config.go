@@ -13,13 +13,19 @@ package sql
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/constraint"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
@@ -159,6 +165,10 @@ func getShowZoneConfigRow(
 	zone.Subzones = nil
 	zone.SubzoneSpans = nil
 
+	if err := p.noticeZoneConstraintStores(ctx, zone); err != nil {
+		return nil, err
+	}
+
 	vals := make(tree.Datums, len(showZoneConfigColumns))
 	if err := generateZoneConfigIntrospectionValues(
 		vals, tree.NewDInt(tree.DInt(zoneID)), tree.NewDInt(tree.DInt(subZoneIdx)), &zs, zone, nil,
@@ -168,6 +178,60 @@ func getShowZoneConfigRow(
 	return vals, nil
 }
 
+// noticeZoneConstraintStores sends a notice to the client for each set of
+// replica constraints of the zone, listing the stores that currently satisfy
+// it according to the gossiped store descriptors. This helps verify that
+// store attributes route replicas as intended, for example when SSD and HDD
+// stores are mixed in a tiered-storage deployment.
+func (p *planner) noticeZoneConstraintStores(ctx context.Context, zone *zonepb.ZoneConfig) error {
+	if len(zone.Constraints) == 0 {
+		return nil
+	}
+	g, ok := p.ExecCfg().Gossip.Optional(47899)
+	if !ok {
+		return nil
+	}
+	var stores []roachpb.StoreDescriptor
+	if err := g.IterateInfos(gossip.KeyStorePrefix, func(key string, i gossip.Info) error {
+		bytes, err := i.Value.GetBytes()
+		if err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err,
+				"failed to extract bytes for key %q", key)
+		}
+		var desc roachpb.StoreDescriptor
+		if err := protoutil.Unmarshal(bytes, &desc); err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err,
+				"failed to parse value for key %q", key)
+		}
+		stores = append(stores, desc)
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].StoreID < stores[j].StoreID
+	})
+
+	for _, conjunction := range zone.Constraints {
+		var matched []string
+		for _, store := range stores {
+			if constraint.ConjunctionsCheck(store, conjunction.Constraints) {
+				matched = append(matched, fmt.Sprintf("s%d (n%d)", store.StoreID, store.Node.NodeID))
+			}
+		}
+		if len(matched) == 0 {
+			p.BufferClientNotice(ctx, errors.WithHint(
+				pgnotice.Newf("constraints [%s] match no stores", conjunction),
+				"Replicas subject to these constraints cannot be placed. "+
+					"Check the node localities and the node and store attributes."))
+			continue
+		}
+		p.BufferClientNotice(ctx, pgnotice.Newf("constraints [%s] match %d store(s): %s",
+			conjunction, len(matched), strings.Join(matched, ", ")))
+	}
+	return nil
+}
+
 // zoneConfigToSQL pretty prints a zone configuration as a SQL string.
 func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, error) {
 	constraints, err := yamlMarshalFlow(zonepb.ConstraintsList{
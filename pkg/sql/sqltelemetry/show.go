@@ -52,6 +52,12 @@ const (
 	Roles
 	// Schedules represents the SHOW SCHEDULE command.
 	Schedules
+	// ClusterUpgradeStatus represents the SHOW CLUSTER UPGRADE STATUS command.
+	ClusterUpgradeStatus
+	// CreateAllTables represents the SHOW CREATE ALL TABLES command.
+	CreateAllTables
+	// WebSessions represents the SHOW WEB SESSIONS command.
+	WebSessions
 )
 
 var showTelemetryNameMap = map[ShowTelemetryType]string{
@@ -70,6 +76,9 @@ var showTelemetryNameMap = map[ShowTelemetryType]string{
 	Jobs:                    "jobs",
 	Roles:                   "roles",
 	Schedules:               "schedules",
+	ClusterUpgradeStatus:    "cluster_upgrade_status",
+	CreateAllTables:         "create_all_tables",
+	WebSessions:             "web_sessions",
 }
 
 func (s ShowTelemetryType) String() string {
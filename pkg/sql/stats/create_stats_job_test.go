@@ -186,7 +186,7 @@ func TestCreateStatsLivenessWithRestart(t *testing.T) {
 	{
 		var expectedLeaseBytes []byte
 		sqlDB.QueryRow(
-			t, `SELECT id, progress FROM system.jobs ORDER BY created DESC LIMIT 1`,
+			t, `SELECT id, `+jobs.JobInfoProgressColumn+` FROM system.jobs ORDER BY created DESC LIMIT 1`,
 		).Scan(&jobID, &expectedLeaseBytes)
 		if err := protoutil.Unmarshal(expectedLeaseBytes, originalLease); err != nil {
 			t.Fatal(err)
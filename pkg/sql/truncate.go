@@ -12,15 +12,18 @@ package sql
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
@@ -33,6 +36,21 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
+// truncatePreservedSplitCount is the maximum number of range split points of
+// a truncated table's indexes that are copied onto the replacement indexes.
+var truncatePreservedSplitCount = settings.RegisterIntSetting(
+	"sql.truncate.preserved_split_count",
+	"maximum number of range splits of a table's indexes that TRUNCATE carries "+
+		"over to the new, empty indexes, so that writes following a TRUNCATE are "+
+		"not all served by a single range; set to 0 to disable",
+	0,
+	settings.NonNegativeInt,
+)
+
+// truncatePreservedSplitExpiration is how long the split points preserved by
+// TRUNCATE are enforced before the ranges become eligible for merging again.
+const truncatePreservedSplitExpiration = time.Hour
+
 type truncateNode struct {
 	n *tree.Truncate
 }
@@ -259,9 +277,14 @@ func (p *planner) truncateTable(
 		details.InterleavedIndexes = droppedInterleaves
 	}
 	record := CreateGCJobRecord(jobDesc, p.User(), details)
-	if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(ctx, record, p.txn); err != nil {
+	job, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(ctx, record, p.txn)
+	if err != nil {
 		return err
 	}
+	p.BufferClientNotice(ctx, pgnotice.Newf(
+		"the data of table %q will be removed asynchronously by job %d",
+		tableDesc.Name, *job.ID(),
+	))
 
 	// Unsplit all manually split ranges in the table so they can be
 	// automatically merged by the merge queue.
@@ -269,6 +292,12 @@ func (p *planner) truncateTable(
 		return err
 	}
 
+	// Carry the split points of the old indexes over to the new ones, so that
+	// the load on the table after it is truncated remains distributed.
+	if err := p.copySplitPointsToNewIndexes(ctx, tableDesc.ID, indexIDMapping); err != nil {
+		return err
+	}
+
 	// Reassign any referenced index ID's from other tables.
 	if err := p.reassignInterleaveIndexReferences(ctx, allRefs, tableDesc.ID, indexIDMapping); err != nil {
 		return err
@@ -310,6 +339,69 @@ func (p *planner) truncateTable(
 	return p.writeSchemaChange(ctx, tableDesc, descpb.InvalidMutationID, jobDesc)
 }
 
+// copySplitPointsToNewIndexes splits the new indexes of a truncated table at
+// the logical positions where the old indexes, given by the keys of
+// indexIDMapping, were split. At most sql.truncate.preserved_split_count
+// evenly spaced split points are copied.
+func (p *planner) copySplitPointsToNewIndexes(
+	ctx context.Context, tableID descpb.ID, indexIDMapping map[descpb.IndexID]descpb.IndexID,
+) error {
+	execCfg := p.ExecCfg()
+	// Secondary tenants can neither scan the meta ranges nor split ranges.
+	if !execCfg.Codec.ForSystemTenant() {
+		return nil
+	}
+	maxSplits := int(truncatePreservedSplitCount.Get(&execCfg.Settings.SV))
+	if maxSplits == 0 {
+		return nil
+	}
+
+	tablePrefix := execCfg.Codec.TablePrefix(uint32(tableID))
+	ranges, err := ScanMetaKVs(ctx, p.txn, roachpb.Span{
+		Key:    tablePrefix,
+		EndKey: tablePrefix.PrefixEnd(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Translate the start key of every range of an old index into the keyspace
+	// of the corresponding new index. The resulting key may not correspond to
+	// a complete row, which is fine for a split point.
+	splitPoints := make([]roachpb.Key, 0, len(ranges))
+	for _, r := range ranges {
+		var desc roachpb.RangeDescriptor
+		if err := r.ValueProto(&desc); err != nil {
+			return err
+		}
+		rest, _, indexID, err := execCfg.Codec.DecodeIndexPrefix(desc.StartKey.AsRawKey())
+		if err != nil {
+			// The range starts before the first index of the table.
+			continue
+		}
+		newIndexID, ok := indexIDMapping[descpb.IndexID(indexID)]
+		if !ok || len(rest) == 0 {
+			// Either the range does not start within an old index, or it starts
+			// at the beginning of one, which is a split point already.
+			continue
+		}
+		newKey := execCfg.Codec.IndexPrefix(uint32(tableID), uint32(newIndexID))
+		splitPoints = append(splitPoints, append(newKey, rest...))
+	}
+
+	step := 1
+	if len(splitPoints) > maxSplits {
+		step = len(splitPoints) / maxSplits
+	}
+	expirationTime := execCfg.Clock.Now().Add(truncatePreservedSplitExpiration.Nanoseconds(), 0)
+	for i := 0; i < len(splitPoints) && i/step < maxSplits; i += step {
+		if err := execCfg.DB.AdminSplit(ctx, splitPoints[i], expirationTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ClearTableDataInChunks truncates the data of a table in chunks. It deletes a
 // range of data for the table, which includes the PK and all indexes.
 // The table has already been marked for deletion and has been purged from the
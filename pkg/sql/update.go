@@ -130,6 +130,7 @@ func (u *updateNode) startExec(params runParams) error {
 			colinfo.ColTypeInfoFromResCols(u.columns),
 		)
 	}
+	params.p.recordTableWrite(u.run.tu.tableDesc().GetID())
 	return u.run.tu.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -58,6 +58,7 @@ func (n *upsertNode) startExec(params runParams) error {
 	// cache traceKV during execution, to avoid re-evaluating it for every row.
 	n.run.traceKV = params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
 
+	params.p.recordTableWrite(n.run.tw.tableDesc().GetID())
 	return n.run.tw.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
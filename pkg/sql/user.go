@@ -12,8 +12,11 @@ package sql
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -181,6 +184,73 @@ func retrieveUserAndPassword(
 	return exists, canLogin, hashedPassword, validUntil, err
 }
 
+// GetRoleSessionDefaults returns the default values of session variables that
+// were configured with ALTER ROLE ... SET and apply to the given user when
+// connecting to the given database, keyed by the name of the variable.
+//
+// Like in Postgres, the defaults configured for the user in the database take
+// precedence over the defaults configured for the user in all databases, which
+// take precedence over the defaults configured for all users in the database,
+// which take precedence over the defaults configured for all users in all
+// databases.
+//
+// The defaults are never looked up for root, so that root can log in even if
+// system.database_role_settings is unavailable. For other users, the lookup
+// fails after the same timeout as the user lookup, and the caller should open
+// the session without the defaults.
+func GetRoleSessionDefaults(
+	ctx context.Context, ie *InternalExecutor, username security.SQLUsername, dbName string,
+) (map[string]string, error) {
+	if username.IsRootUser() ||
+		!ie.s.cfg.Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettings) {
+		return nil, nil
+	}
+
+	var defaults map[string]string
+	getDefaults := func(ctx context.Context) error {
+		// The rows are ordered from the lowest to the highest precedence.
+		query := fmt.Sprintf(`
+SELECT settings FROM %s
+ WHERE (database_id = 0 OR database_id = (
+          SELECT id::OID FROM system.namespace
+           WHERE "parentID" = 0 AND "parentSchemaID" = 0 AND name = $2))
+   AND (role_name = '' OR role_name = $1)
+ ORDER BY role_name != '', database_id != 0`,
+			DatabaseRoleSettingsTableName,
+		)
+		rows, err := ie.QueryEx(
+			ctx, "get-role-session-defaults", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			query, username, dbName,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error looking up session defaults for user %s", username)
+		}
+		defaults = make(map[string]string)
+		for _, row := range rows {
+			for _, d := range tree.MustBeDArray(row[0]).Array {
+				setting := string(tree.MustBeDString(d))
+				if i := strings.IndexByte(setting, '='); i > 0 {
+					defaults[setting[:i]] = setting[i+1:]
+				}
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if timeout := userLoginTimeout.Get(&ie.s.cfg.Settings.SV); timeout != 0 {
+		err = contextutil.RunWithTimeout(ctx, "get-role-session-defaults-timeout", timeout, getDefaults)
+	} else {
+		err = getDefaults(ctx)
+	}
+	if err != nil {
+		log.Warningf(ctx, "session defaults lookup for %q failed: %v", username, err)
+		return nil, errors.Wrap(errors.Handled(err), "internal error while retrieving session defaults")
+	}
+	return defaults, nil
+}
+
 var userLoginTimeout = settings.RegisterDurationSetting(
 	"server.user_login.timeout",
 	"timeout after which client authentication times out if some system range is unavailable (0 = no timeout)",
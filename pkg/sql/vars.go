@@ -754,6 +754,31 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-INTERVALSTYLE
 	`intervalstyle`: makeCompatStringVar(`IntervalStyle`, "postgres"),
 
+	// CockroachDB extension.
+	// Bounds on the size of the batches of input rows that lookup and index
+	// joins use to perform lookups; 0 stands for the default batch size of the
+	// join. When the bounds differ, the batch size adapts to the latency of the
+	// lookups and to the width of the looked up rows.
+	`join_reader_min_batch_size`: {
+		GetStringVal: makeByteSizeGetStringValFn(`join_reader_min_batch_size`),
+		Set:          joinReaderMinBatchSizeVarSet,
+		Get: func(evalCtx *extendedEvalContext) string {
+			return strconv.FormatInt(evalCtx.SessionData.JoinReaderMinBatchSize, 10)
+		},
+		GlobalDefault: func(sv *settings.Values) string { return "0" },
+	},
+
+	// CockroachDB extension.
+	// See join_reader_min_batch_size.
+	`join_reader_max_batch_size`: {
+		GetStringVal: makeByteSizeGetStringValFn(`join_reader_max_batch_size`),
+		Set:          joinReaderMaxBatchSizeVarSet,
+		Get: func(evalCtx *extendedEvalContext) string {
+			return strconv.FormatInt(evalCtx.SessionData.JoinReaderMaxBatchSize, 10)
+		},
+		GlobalDefault: func(sv *settings.Values) string { return "0" },
+	},
+
 	// CockroachDB extension.
 	`locality`: {
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -773,6 +798,19 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html#GUC-MAX-INDEX-KEYS
 	`max_index_keys`: makeReadOnlyVar("32"),
 
+	// CockroachDB extension.
+	// The memory that a query may allocate on each node. Operators that can
+	// spill to disk do so when the limit is reached; other queries fail with
+	// an error that details the memory usage of the query.
+	`max_query_memory`: {
+		GetStringVal: makeByteSizeGetStringValFn(`max_query_memory`),
+		Set:          maxQueryMemoryVarSet,
+		Get: func(evalCtx *extendedEvalContext) string {
+			return strconv.FormatInt(evalCtx.SessionData.MaxQueryMemory, 10)
+		},
+		GlobalDefault: func(sv *settings.Values) string { return "0" },
+	},
+
 	// CockroachDB extension.
 	`node_id`: {
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -943,6 +981,16 @@ var varGen = map[string]sessionVar{
 		GlobalDefault: func(sv *settings.Values) string { return "0" },
 	},
 
+	`transaction_timeout`: {
+		GetStringVal: makeTimeoutVarGetter(`transaction_timeout`),
+		Set:          transactionTimeoutVarSet,
+		Get: func(evalCtx *extendedEvalContext) string {
+			ms := evalCtx.SessionData.TransactionTimeout.Nanoseconds() / int64(time.Millisecond)
+			return strconv.FormatInt(ms, 10)
+		},
+		GlobalDefault: func(sv *settings.Values) string { return "0" },
+	},
+
 	`idle_in_session_timeout`: {
 		GetStringVal: makeTimeoutVarGetter(`idle_in_session_timeout`),
 		Set:          idleInSessionTimeoutVarSet,
@@ -1124,6 +1172,25 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	`enable_result_cache`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`enable_result_cache`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar(`enable_result_cache`, s)
+			if err != nil {
+				return err
+			}
+			m.SetResultCacheEnabled(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData.ResultCacheEnabled)
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return formatBoolAsPostgresSetting(resultCacheEnabledClusterMode.Get(sv))
+		},
+	},
+
 	// CockroachDB extension.
 	`enable_experimental_alter_column_type_general`: {
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_experimental_alter_column_type_general`),
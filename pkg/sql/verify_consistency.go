@@ -0,0 +1,141 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+)
+
+type verifyConsistencyNode struct {
+	optColumnsSlot
+
+	run verifyConsistencyRun
+}
+
+// VerifyConsistency runs a consistency check on the ranges of a table or on a
+// single range.
+// (`ALTER TABLE/RANGE ... EXPERIMENTAL VERIFY CONSISTENCY` statement)
+// Privileges: admin role.
+func (p *planner) VerifyConsistency(
+	ctx context.Context, n *tree.VerifyConsistency,
+) (planNode, error) {
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, errorutil.UnsupportedWithMultiTenancy(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+	}
+	if err := p.RequireAdminRole(ctx, "verify consistency"); err != nil {
+		return nil, err
+	}
+
+	var span roachpb.Span
+	if n.Table != nil {
+		tableDesc, _, err := p.getTableAndIndex(
+			ctx, &tree.TableIndexName{Table: n.Table.ToTableName()}, privilege.SELECT,
+		)
+		if err != nil {
+			return nil, err
+		}
+		span = tableDesc.TableSpan(p.ExecCfg().Codec)
+	} else {
+		desc, err := p.lookupRangeDescriptor(ctx, roachpb.RangeID(n.RangeID))
+		if err != nil {
+			return nil, err
+		}
+		span = desc.RSpan().AsRawSpanWithNoLocals()
+		// Consistency checks only address global keys.
+		if span.Key.Compare(keys.LocalMax) < 0 {
+			span.Key = keys.LocalMax
+		}
+	}
+
+	return &verifyConsistencyNode{
+		run: verifyConsistencyRun{
+			span: span,
+		},
+	}, nil
+}
+
+// lookupRangeDescriptor returns the descriptor of the range with the given ID.
+func (p *planner) lookupRangeDescriptor(
+	ctx context.Context, rangeID roachpb.RangeID,
+) (roachpb.RangeDescriptor, error) {
+	ranges, err := ScanMetaKVs(ctx, p.txn, roachpb.Span{
+		Key:    keys.MinKey,
+		EndKey: keys.MaxKey,
+	})
+	if err != nil {
+		return roachpb.RangeDescriptor{}, err
+	}
+	var desc roachpb.RangeDescriptor
+	for _, r := range ranges {
+		if err := r.ValueProto(&desc); err != nil {
+			return roachpb.RangeDescriptor{}, err
+		}
+		if desc.RangeID == rangeID {
+			return desc, nil
+		}
+	}
+	return roachpb.RangeDescriptor{}, pgerror.Newf(pgcode.UndefinedObject,
+		"range %d does not exist", rangeID)
+}
+
+// verifyConsistencyRun contains the run-time state of verifyConsistencyNode
+// during local execution.
+type verifyConsistencyRun struct {
+	span roachpb.Span
+
+	resultIdx int
+	results   []roachpb.CheckConsistencyResponse_Result
+}
+
+func (n *verifyConsistencyNode) startExec(params runParams) error {
+	var b kv.Batch
+	b.AddRawRequest(&roachpb.CheckConsistencyRequest{
+		RequestHeader: roachpb.RequestHeader{Key: n.run.span.Key, EndKey: n.run.span.EndKey},
+		Mode:          roachpb.ChecksumMode_CHECK_FULL,
+		WithDiff:      true,
+	})
+	// NB: DistSender checks the ranges one at a time for CHECK_FULL requests.
+	if err := params.p.ExecCfg().DB.Run(params.ctx, &b); err != nil {
+		return err
+	}
+	resp := b.RawResponse().Responses[0].GetInner().(*roachpb.CheckConsistencyResponse)
+	n.run.resultIdx = -1
+	n.run.results = resp.Result
+	return nil
+}
+
+func (n *verifyConsistencyNode) Next(params runParams) (bool, error) {
+	n.run.resultIdx++
+	return n.run.resultIdx < len(n.run.results), nil
+}
+
+func (n *verifyConsistencyNode) Values() tree.Datums {
+	r := n.run.results[n.run.resultIdx]
+	return tree.Datums{
+		tree.NewDInt(tree.DInt(r.RangeID)),
+		tree.NewDBytes(tree.DBytes(r.StartKey)),
+		tree.NewDString(keys.PrettyPrint(nil /* valDirs */, r.StartKey)),
+		tree.NewDString(r.Status.String()),
+		tree.NewDString(r.Detail),
+	}
+}
+
+func (*verifyConsistencyNode) Close(ctx context.Context) {}
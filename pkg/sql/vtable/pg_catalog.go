@@ -732,6 +732,27 @@ CREATE TABLE pg_catalog.pg_tablespace (
 	spcoptions TEXT[]
 )`
 
+// PGCatalogTimezoneAbbrevs describes the schema of the
+// pg_catalog.pg_timezone_abbrevs table.
+// https://www.postgresql.org/docs/9.5/view-pg-timezone-abbrevs.html,
+const PGCatalogTimezoneAbbrevs = `
+CREATE TABLE pg_catalog.pg_timezone_abbrevs (
+	abbrev TEXT,
+	utc_offset INTERVAL,
+	is_dst BOOL
+)`
+
+// PGCatalogTimezoneNames describes the schema of the
+// pg_catalog.pg_timezone_names table.
+// https://www.postgresql.org/docs/9.5/view-pg-timezone-names.html,
+const PGCatalogTimezoneNames = `
+CREATE TABLE pg_catalog.pg_timezone_names (
+	name TEXT,
+	abbrev TEXT,
+	utc_offset INTERVAL,
+	is_dst BOOL
+)`
+
 // PGCatalogTrigger describes the schema of the pg_catalog.pg_trigger table.
 // https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html,
 const PGCatalogTrigger = `
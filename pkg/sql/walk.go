@@ -325,13 +325,17 @@ func nodeName(plan planNode) string {
 var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabaseOwnerNode{}):      "alter database owner",
 	reflect.TypeOf(&alterIndexNode{}):              "alter index",
+	reflect.TypeOf(&alterJobNode{}):                "alter job",
+	reflect.TypeOf(&alterScheduleNode{}):           "alter schedule",
 	reflect.TypeOf(&alterSequenceNode{}):           "alter sequence",
 	reflect.TypeOf(&alterSchemaNode{}):             "alter schema",
 	reflect.TypeOf(&alterTableNode{}):              "alter table",
 	reflect.TypeOf(&alterTableSetSchemaNode{}):     "alter table set schema",
 	reflect.TypeOf(&alterTypeNode{}):               "alter type",
 	reflect.TypeOf(&alterRoleNode{}):               "alter role",
+	reflect.TypeOf(&alterRoleSetNode{}):            "alter role",
 	reflect.TypeOf(&applyJoinNode{}):               "apply join",
+	reflect.TypeOf(&batchedDMLNode{}):              "batched dml",
 	reflect.TypeOf(&bufferNode{}):                  "buffer",
 	reflect.TypeOf(&cancelQueriesNode{}):           "cancel queries",
 	reflect.TypeOf(&cancelSessionsNode{}):          "cancel sessions",
@@ -340,6 +344,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnDatabaseNode{}):       "comment on database",
 	reflect.TypeOf(&commentOnIndexNode{}):          "comment on index",
 	reflect.TypeOf(&commentOnTableNode{}):          "comment on table",
+	reflect.TypeOf(&compactNode{}):                 "compact",
 	reflect.TypeOf(&controlJobsNode{}):             "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):        "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):          "create database",
@@ -395,6 +400,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&reparentDatabaseNode{}):        "reparent database",
 	reflect.TypeOf(&renderNode{}):                  "render",
 	reflect.TypeOf(&RevokeRoleNode{}):              "revoke role",
+	reflect.TypeOf(&revokeWebSessionsNode{}):       "revoke web sessions",
 	reflect.TypeOf(&rowCountNode{}):                "count",
 	reflect.TypeOf(&rowSourceToPlanNode{}):         "row source to plan node",
 	reflect.TypeOf(&saveTableNode{}):               "save table",
@@ -421,6 +427,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&updateNode{}):                  "update",
 	reflect.TypeOf(&upsertNode{}):                  "upsert",
 	reflect.TypeOf(&valuesNode{}):                  "values",
+	reflect.TypeOf(&verifyConsistencyNode{}):       "verify consistency",
 	reflect.TypeOf(&virtualTableNode{}):            "virtual table values",
 	reflect.TypeOf(&vTableLookupJoinNode{}):        "virtual table lookup join",
 	reflect.TypeOf(&windowNode{}):                  "window",
@@ -330,6 +330,20 @@ var backwardCompatibleMigrations = []migrationDescriptor{
 		// Introduced in v20.2.
 		name: "mark non-terminal schema change jobs with a pre-20.1 format version as failed",
 	},
+	{
+		// Introduced in v21.1.
+		name:                "create new system.database_role_settings table",
+		workFn:              createDatabaseRoleSettingsTable,
+		includedInBootstrap: clusterversion.ByKey(clusterversion.DatabaseRoleSettings),
+		newDescriptorIDs:    staticIDs(keys.DatabaseRoleSettingsTableID),
+	},
+	{
+		// Introduced in v21.1.
+		name:                "create new system.job_info table",
+		workFn:              createJobInfoTable,
+		includedInBootstrap: clusterversion.ByKey(clusterversion.JobInfoTable),
+		newDescriptorIDs:    staticIDs(keys.JobInfoTableID),
+	},
 }
 
 func staticIDs(
@@ -1263,6 +1277,14 @@ func createTenantsTable(ctx context.Context, r runner) error {
 	return createSystemTable(ctx, r, systemschema.TenantsTable)
 }
 
+func createDatabaseRoleSettingsTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, systemschema.DatabaseRoleSettingsTable)
+}
+
+func createJobInfoTable(ctx context.Context, r runner) error {
+	return createSystemTable(ctx, r, systemschema.JobInfoTable)
+}
+
 func alterSystemScheduledJobsFixTableSchema(ctx context.Context, r runner) error {
 	setOwner := "UPDATE system.scheduled_jobs SET owner='root' WHERE owner IS NULL"
 	asNode := sessiondata.InternalExecutorOverride{User: security.NodeUserName()}
@@ -0,0 +1,118 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/util/sysutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+)
+
+// maxDefaultBallastSize is the upper bound of the default ballast size,
+// which is otherwise 1% of the total disk space.
+const maxDefaultBallastSize = 1 << 30 // 1 GiB
+
+// BallastFile returns the path of the emergency ballast file of the store
+// with the given auxiliary directory.
+//
+// The ballast file reserves disk space that is released when the node is
+// restarted with a full disk, which allows the node to start and free up
+// space without manual intervention.
+func BallastFile(auxDir string) string {
+	return filepath.Join(auxDir, "EMERGENCY_BALLAST")
+}
+
+// BallastSizeBytes returns the size in bytes of the ballast file of the
+// given store on a disk of the given total size. Unless configured in the
+// store spec, it is 1% of the total disk space, up to 1 GiB.
+func BallastSizeBytes(spec base.StoreSpec, totalBytes int64) int64 {
+	if spec.BallastSize != nil {
+		if spec.BallastSize.Percent > 0 {
+			return int64(float64(totalBytes) * spec.BallastSize.Percent / 100)
+		}
+		return spec.BallastSize.InBytes
+	}
+	size := totalBytes / 100
+	if size > maxDefaultBallastSize {
+		size = maxDefaultBallastSize
+	}
+	return size
+}
+
+// ballastFileSize returns the current size of the ballast file at path, or
+// zero if it does not exist.
+func ballastFileSize(path string) (int64, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if oserror.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
+// MaybeEstablishBallast creates, resizes or removes the ballast file at path
+// so that its size is sizeBytes, given the number of bytes currently
+// available on the disk. The ballast is only created or extended if at least
+// sizeBytes remain available afterwards, so that it never fills up the disk
+// by itself. It returns the resulting size of the ballast file.
+func MaybeEstablishBallast(path string, sizeBytes, availBytes int64) (int64, error) {
+	current, err := ballastFileSize(path)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case current == sizeBytes:
+		return current, nil
+	case sizeBytes == 0:
+		if err := os.Remove(path); err != nil && !oserror.IsNotExist(err) {
+			return current, errors.Wrap(err, "removing ballast file")
+		}
+		return 0, nil
+	case current > sizeBytes:
+		if err := os.Truncate(path, sizeBytes); err != nil {
+			return current, errors.Wrap(err, "truncating ballast file")
+		}
+		return sizeBytes, nil
+	case availBytes-(sizeBytes-current) < sizeBytes:
+		// Not enough disk space to extend the ballast.
+		return current, nil
+	default:
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return current, err
+		}
+		if err := sysutil.CreateLargeFile(path, sizeBytes); err != nil {
+			return 0, errors.Wrap(err, "creating ballast file")
+		}
+		return sizeBytes, nil
+	}
+}
+
+// MaybeReleaseBallast removes the ballast file at path if fewer than
+// sizeBytes are available on the disk, i.e. if the disk is full or nearly
+// full. It returns whether the ballast was removed.
+func MaybeReleaseBallast(path string, sizeBytes, availBytes int64) (bool, error) {
+	if availBytes >= sizeBytes {
+		return false, nil
+	}
+	if err := os.Remove(path); err != nil {
+		if oserror.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "removing ballast file")
+	}
+	return true, nil
+}
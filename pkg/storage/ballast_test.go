@@ -0,0 +1,114 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBallastSizeBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const gib = 1 << 30
+	testCases := []struct {
+		ballastSize *base.SizeSpec
+		totalBytes  int64
+		expected    int64
+	}{
+		{nil, 10 * gib, 10 * gib / 100},
+		{nil, 1000 * gib, gib},
+		{&base.SizeSpec{InBytes: 5 * gib}, 1000 * gib, 5 * gib},
+		{&base.SizeSpec{Percent: 2}, 1000 * gib, 20 * gib},
+		{&base.SizeSpec{}, 1000 * gib, 0},
+	}
+	for _, tc := range testCases {
+		spec := base.StoreSpec{Path: "/mnt/data", BallastSize: tc.ballastSize}
+		require.Equal(t, tc.expected, BallastSizeBytes(spec, tc.totalBytes), "spec: %s", spec)
+	}
+}
+
+func TestMaybeEstablishBallast(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	path := BallastFile(dir + "/auxiliary")
+
+	requireSize := func(expected int64) {
+		t.Helper()
+		size, err := ballastFileSize(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, size)
+	}
+
+	// The ballast is not created if it would leave less than its size
+	// available.
+	size, err := MaybeEstablishBallast(path, 1024, 2047)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+	requireSize(0)
+
+	size, err = MaybeEstablishBallast(path, 1024, 2048)
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), size)
+	requireSize(1024)
+
+	// Extending the ballast only requires space for the difference.
+	size, err = MaybeEstablishBallast(path, 1536, 2048)
+	require.NoError(t, err)
+	require.Equal(t, int64(1536), size)
+	requireSize(1536)
+
+	// Shrinking and removing the ballast do not depend on available space.
+	size, err = MaybeEstablishBallast(path, 512, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(512), size)
+	requireSize(512)
+
+	size, err = MaybeEstablishBallast(path, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+	requireSize(0)
+}
+
+func TestMaybeReleaseBallast(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	path := BallastFile(dir)
+
+	released, err := MaybeReleaseBallast(path, 1024, 0)
+	require.NoError(t, err)
+	require.False(t, released)
+
+	_, err = MaybeEstablishBallast(path, 1024, 4096)
+	require.NoError(t, err)
+
+	released, err = MaybeReleaseBallast(path, 1024, 1024)
+	require.NoError(t, err)
+	require.False(t, released)
+
+	released, err = MaybeReleaseBallast(path, 1024, 1023)
+	require.NoError(t, err)
+	require.True(t, released)
+	size, err := ballastFileSize(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), size)
+}
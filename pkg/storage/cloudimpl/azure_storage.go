@@ -35,6 +35,9 @@ func azureQueryParams(conf *roachpb.ExternalStorage_Azure) string {
 	if conf.AccountKey != "" {
 		q.Set(AzureAccountKeyParam, conf.AccountKey)
 	}
+	if conf.SASToken != "" {
+		q.Set(AzureSASTokenParam, conf.SASToken)
+	}
 	return q.Encode()
 }
 
@@ -54,15 +57,26 @@ func makeAzureStorage(
 	if conf == nil {
 		return nil, errors.Errorf("azure upload requested but info missing")
 	}
-	credential, err := azblob.NewSharedKeyCredential(conf.AccountName, conf.AccountKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "azure credential")
-	}
-	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
 	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net", conf.AccountName))
 	if err != nil {
 		return nil, errors.Wrap(err, "azure: account name is not valid")
 	}
+	// A SAS token authorizes requests by itself, so it is sent as the query
+	// string of every request instead of being used to sign them.
+	var credential azblob.Credential
+	if conf.SASToken != "" {
+		if _, err := url.ParseQuery(strings.TrimPrefix(conf.SASToken, "?")); err != nil {
+			return nil, errors.Wrapf(err, "azure: %s is not valid", AzureSASTokenParam)
+		}
+		u.RawQuery = strings.TrimPrefix(conf.SASToken, "?")
+		credential = azblob.NewAnonymousCredential()
+	} else {
+		credential, err = azblob.NewSharedKeyCredential(conf.AccountName, conf.AccountKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "azure: %s is not valid", AzureAccountKeyParam)
+		}
+	}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
 	serviceURL := azblob.NewServiceURL(*u, p)
 	return &azureStorage{
 		conf:      conf,
@@ -11,15 +11,18 @@
 package cloudimpltests
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloudimpl"
 	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPutAzure(t *testing.T) {
@@ -50,3 +53,67 @@ func TestPutAzure(t *testing.T) {
 		security.RootUserName(), nil, nil,
 	)
 }
+
+func TestPutAzureSASToken(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	accountName := os.Getenv("AZURE_ACCOUNT_NAME")
+	sasToken := os.Getenv("AZURE_SAS_TOKEN")
+	if accountName == "" || sasToken == "" {
+		skip.IgnoreLint(t, "AZURE_ACCOUNT_NAME and AZURE_SAS_TOKEN env vars must be set")
+	}
+	bucket := os.Getenv("AZURE_CONTAINER")
+	if bucket == "" {
+		skip.IgnoreLint(t, "AZURE_CONTAINER env var must be set")
+	}
+
+	testExportStore(t, fmt.Sprintf("azure://%s/%s?%s=%s&%s=%s",
+		bucket, "backup-test-sas",
+		cloudimpl.AzureAccountNameParam, url.QueryEscape(accountName),
+		cloudimpl.AzureSASTokenParam, url.QueryEscape(sasToken),
+	), false, security.RootUserName(), nil, nil)
+}
+
+func TestAzureCredentialParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	user := security.RootUserName()
+	for _, tc := range []struct {
+		name   string
+		params string
+		err    string
+	}{
+		{
+			name:   "account-key",
+			params: "AZURE_ACCOUNT_NAME=acct&AZURE_ACCOUNT_KEY=a2V5",
+		},
+		{
+			name:   "sas-token",
+			params: "AZURE_ACCOUNT_NAME=acct&AZURE_SAS_TOKEN=" + url.QueryEscape("sv=2019-12-12&sig=c2ln"),
+		},
+		{
+			name:   "no-credentials",
+			params: "AZURE_ACCOUNT_NAME=acct",
+			err:    `azure uri missing "AZURE_ACCOUNT_KEY" or "AZURE_SAS_TOKEN" parameter`,
+		},
+		{
+			name:   "both-credentials",
+			params: "AZURE_ACCOUNT_NAME=acct&AZURE_ACCOUNT_KEY=a2V5&AZURE_SAS_TOKEN=sig%3Dc2ln",
+			err:    `azure uri cannot specify both "AZURE_ACCOUNT_KEY" and "AZURE_SAS_TOKEN" parameters`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := cloudimpl.ExternalStorageConfFromURI("azure://container/path?"+tc.params, user)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			s, err := cloudimpl.MakeExternalStorage(context.Background(), conf,
+				base.ExternalIODirConfig{}, testSettings, nil, nil, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, conf, s.Conf())
+			require.NoError(t, s.Close())
+		})
+	}
+}
@@ -77,7 +77,7 @@ func storeFromURI(
 	}
 	// Setup a sink for the given args.
 	s, err := cloudimpl.MakeExternalStorage(ctx, conf, base.ExternalIODirConfig{}, testSettings,
-		clientFactory, ie, kvDB)
+		clientFactory, ie, kvDB, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -114,7 +114,7 @@ func testExportStoreWithExternalIOConfig(
 
 	// Setup a sink for the given args.
 	clientFactory := blobs.TestBlobServiceClient(testSettings.ExternalIODir)
-	s, err := cloudimpl.MakeExternalStorage(ctx, conf, ioConf, testSettings, clientFactory, ie, kvDB)
+	s, err := cloudimpl.MakeExternalStorage(ctx, conf, ioConf, testSettings, clientFactory, ie, kvDB, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -541,7 +541,7 @@ func TestWorkloadStorage(t *testing.T) {
 
 	{
 		s, err := cloudimpl.ExternalStorageFromURI(ctx, bankURL().String(), base.ExternalIODirConfig{},
-			settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+			settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 		require.NoError(t, err)
 		r, err := s.ReadFile(ctx, ``)
 		require.NoError(t, err)
@@ -559,7 +559,7 @@ func TestWorkloadStorage(t *testing.T) {
 		params := map[string]string{
 			`row-start`: `1`, `row-end`: `3`, `payload-bytes`: `14`, `batch-size`: `1`}
 		s, err := cloudimpl.ExternalStorageFromURI(ctx, bankURL(params).String(), base.ExternalIODirConfig{},
-			settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+			settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 		require.NoError(t, err)
 		r, err := s.ReadFile(ctx, ``)
 		require.NoError(t, err)
@@ -572,21 +572,21 @@ func TestWorkloadStorage(t *testing.T) {
 	}
 
 	_, err := cloudimpl.ExternalStorageFromURI(ctx, `workload:///nope`, base.ExternalIODirConfig{}, settings,
-		blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `path must be of the form /<format>/<generator>/<table>: /nope`)
 	_, err = cloudimpl.ExternalStorageFromURI(ctx, `workload:///fmt/bank/bank?version=`,
-		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `unsupported format: fmt`)
 	_, err = cloudimpl.ExternalStorageFromURI(ctx, `workload:///csv/nope/nope?version=`,
-		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `unknown generator: nope`)
 	_, err = cloudimpl.ExternalStorageFromURI(ctx, `workload:///csv/bank/bank`, base.ExternalIODirConfig{},
-		settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `parameter version is required`)
 	_, err = cloudimpl.ExternalStorageFromURI(ctx, `workload:///csv/bank/bank?version=`,
-		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `expected bank version "" but got "1.0.0"`)
 	_, err = cloudimpl.ExternalStorageFromURI(ctx, `workload:///csv/bank/bank?version=nope`,
-		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil)
+		base.ExternalIODirConfig{}, settings, blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 	require.EqualError(t, err, `expected bank version "nope" but got "1.0.0"`)
 }
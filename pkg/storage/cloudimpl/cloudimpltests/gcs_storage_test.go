@@ -111,7 +111,7 @@ func TestAntagonisticRead(t *testing.T) {
 
 	s, err := cloudimpl.MakeExternalStorage(
 		context.Background(), conf, base.ExternalIODirConfig{}, testSettings,
-		nil, nil, nil)
+		nil, nil, nil, nil)
 	require.NoError(t, err)
 	stream, err := s.ReadFile(context.Background(), "")
 	require.NoError(t, err)
@@ -139,7 +139,7 @@ func TestFileDoesNotExist(t *testing.T) {
 
 		s, err := cloudimpl.MakeExternalStorage(
 			context.Background(), conf, base.ExternalIODirConfig{}, testSettings,
-			nil, nil, nil)
+			nil, nil, nil, nil)
 		require.NoError(t, err)
 		_, err = s.ReadFile(context.Background(), "")
 		require.Error(t, err, "")
@@ -154,7 +154,7 @@ func TestFileDoesNotExist(t *testing.T) {
 
 		s, err := cloudimpl.MakeExternalStorage(
 			context.Background(), conf, base.ExternalIODirConfig{}, testSettings, nil,
-			nil, nil)
+			nil, nil, nil)
 		require.NoError(t, err)
 		_, err = s.ReadFile(context.Background(), "")
 		require.Error(t, err, "")
@@ -184,9 +184,9 @@ func TestCompressedGCS(t *testing.T) {
 	conf2, err := cloudimpl.ExternalStorageConfFromURI(gsFile2, user)
 	require.NoError(t, err)
 
-	s1, err := cloudimpl.MakeExternalStorage(ctx, conf1, base.ExternalIODirConfig{}, testSettings, nil, nil, nil)
+	s1, err := cloudimpl.MakeExternalStorage(ctx, conf1, base.ExternalIODirConfig{}, testSettings, nil, nil, nil, nil)
 	require.NoError(t, err)
-	s2, err := cloudimpl.MakeExternalStorage(ctx, conf2, base.ExternalIODirConfig{}, testSettings, nil, nil, nil)
+	s2, err := cloudimpl.MakeExternalStorage(ctx, conf2, base.ExternalIODirConfig{}, testSettings, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	reader1, err := s1.ReadFile(context.Background(), "")
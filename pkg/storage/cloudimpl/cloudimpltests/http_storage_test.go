@@ -154,7 +154,7 @@ func TestPutHttp(t *testing.T) {
 			t.Fatal(err)
 		}
 		s, err := cloudimpl.MakeExternalStorage(ctx, conf, base.ExternalIODirConfig{},
-			testSettings, blobs.TestEmptyBlobClientFactory, nil, nil)
+			testSettings, blobs.TestEmptyBlobClientFactory, nil, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -247,7 +247,7 @@ func TestHttpGet(t *testing.T) {
 				return nil
 			})
 
-			store, err := cloudimpl.MakeHTTPStorage(s.URL, testSettings, base.ExternalIODirConfig{})
+			store, err := cloudimpl.MakeHTTPStorage(s.URL, testSettings, base.ExternalIODirConfig{}, nil)
 			require.NoError(t, err)
 
 			var file io.ReadCloser
@@ -282,7 +282,7 @@ func TestHttpGetWithCancelledContext(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	defer s.Close()
 
-	store, err := cloudimpl.MakeHTTPStorage(s.URL, testSettings, base.ExternalIODirConfig{})
+	store, err := cloudimpl.MakeHTTPStorage(s.URL, testSettings, base.ExternalIODirConfig{}, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -303,7 +303,7 @@ func TestCanDisableHttp(t *testing.T) {
 	s, err := cloudimpl.MakeExternalStorage(
 		context.Background(),
 		roachpb.ExternalStorage{Provider: roachpb.ExternalStorageProvider_Http},
-		conf, testSettings, blobs.TestEmptyBlobClientFactory, nil, nil)
+		conf, testSettings, blobs.TestEmptyBlobClientFactory, nil, nil, nil)
 	require.Nil(t, s)
 	require.Error(t, err)
 }
@@ -330,7 +330,7 @@ func TestExternalStorageCanUseHTTPProxy(t *testing.T) {
 	require.NoError(t, err)
 	s, err := cloudimpl.MakeExternalStorage(
 		context.Background(), conf, base.ExternalIODirConfig{}, testSettings, nil,
-		nil, nil)
+		nil, nil, nil)
 	require.NoError(t, err)
 	stream, err := s.ReadFile(context.Background(), "file")
 	require.NoError(t, err)
@@ -378,7 +378,7 @@ func TestExhaustRetries(t *testing.T) {
 	cloudimpl.HTTPRetryOptions.MaxRetries = 10
 
 	store, err := cloudimpl.MakeHTTPStorage(
-		"http://does.not.matter", testSettings, base.ExternalIODirConfig{})
+		"http://does.not.matter", testSettings, base.ExternalIODirConfig{}, nil)
 	require.NoError(t, err)
 	defer func() {
 		require.NoError(t, store.Close())
@@ -387,3 +387,79 @@ func TestExhaustRetries(t *testing.T) {
 	_, err = store.ReadFile(context.Background(), "/something")
 	require.Error(t, err)
 }
+
+func TestHttpGetRetriesUnavailable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	data := []byte("served after a while")
+
+	defer func(opts retry.Options) {
+		cloudimpl.HTTPRetryOptions = opts
+	}(cloudimpl.HTTPRetryOptions)
+	cloudimpl.HTTPRetryOptions.InitialBackoff = 1 * time.Microsecond
+	cloudimpl.HTTPRetryOptions.MaxBackoff = 10 * time.Millisecond
+
+	const unavailable = 3
+	var requests int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= unavailable {
+			http.Error(w, "try again later", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer s.Close()
+
+	metrics := cloudimpl.MakeHTTPMetrics()
+	store, err := cloudimpl.MakeHTTPStorage(s.URL, testSettings, base.ExternalIODirConfig{}, metrics)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	file, err := store.ReadFile(context.Background(), "/something")
+	require.NoError(t, err)
+	defer file.Close()
+	b, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	require.EqualValues(t, data, b)
+	require.Equal(t, unavailable+1, requests)
+	require.EqualValues(t, unavailable+1, metrics.Requests.Count())
+	require.EqualValues(t, unavailable, metrics.Retries.Count())
+}
+
+func TestHttpCustomCABundle(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	srv1 := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv1.Close()
+	srv2 := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv2.Close()
+
+	u := testSettings.MakeUpdater()
+	defer func() {
+		require.NoError(t, u.Set(cloudimpl.CloudstorageHTTPCASetting, "", "s"))
+	}()
+
+	// Malformed bundles are rejected when the setting is changed.
+	err := u.Set(cloudimpl.CloudstorageHTTPCASetting, "not a certificate", "s")
+	require.True(t, testutils.IsError(err, "no PEM-encoded certificates found"), "%v", err)
+	err = u.Set(cloudimpl.CloudstorageHTTPCASetting,
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})), "s")
+	require.True(t, testutils.IsError(err, "certificate 1"), "%v", err)
+
+	// A bundle can hold several certificates, each of which is trusted.
+	var bundle []byte
+	for _, srv := range []*httptest.Server{srv1, srv2} {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})...)
+	}
+	require.NoError(t, u.Set(cloudimpl.CloudstorageHTTPCASetting, string(bundle), "s"))
+	for _, srv := range []*httptest.Server{srv1, srv2} {
+		store, err := cloudimpl.MakeHTTPStorage(srv.URL, testSettings, base.ExternalIODirConfig{}, nil)
+		require.NoError(t, err)
+		file, err := store.ReadFile(context.Background(), "/something")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.NoError(t, store.Close())
+	}
+}
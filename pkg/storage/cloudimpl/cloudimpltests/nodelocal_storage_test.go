@@ -48,7 +48,7 @@ func TestLocalIOLimits(t *testing.T) {
 	user := security.RootUserName()
 
 	baseDir, err := cloudimpl.ExternalStorageFromURI(ctx, "nodelocal://0/", base.ExternalIODirConfig{},
-		testSettings, clientFactory, user, nil, nil)
+		testSettings, clientFactory, user, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,7 +56,7 @@ func TestLocalIOLimits(t *testing.T) {
 	for dest, expected := range map[string]string{allowed: "", "/../../blah": "not allowed"} {
 		u := fmt.Sprintf("nodelocal://0%s", dest)
 		e, err := cloudimpl.ExternalStorageFromURI(ctx, u, base.ExternalIODirConfig{}, testSettings,
-			clientFactory, user, nil, nil)
+			clientFactory, user, nil, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -43,7 +43,7 @@ func makeS3Storage(
 	// Setup a sink for the given args.
 	clientFactory := blobs.TestBlobServiceClient(testSettings.ExternalIODir)
 	s, err := cloudimpl.MakeExternalStorage(ctx, conf, base.ExternalIODirConfig{}, testSettings,
-		clientFactory, nil, nil)
+		clientFactory, nil, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func TestPutS3(t *testing.T) {
 	t.Run("auth-empty-no-cred", func(t *testing.T) {
 		_, err := cloudimpl.ExternalStorageFromURI(ctx, fmt.Sprintf("s3://%s/%s", bucket,
 			"backup-test-default"), base.ExternalIODirConfig{}, testSettings,
-			blobs.TestEmptyBlobClientFactory, user, nil, nil)
+			blobs.TestEmptyBlobClientFactory, user, nil, nil, nil)
 		require.EqualError(t, err, fmt.Sprintf(
 			`%s is set to '%s', but %s is not set`,
 			cloudimpl.AuthParam,
@@ -116,6 +116,20 @@ func TestPutS3(t *testing.T) {
 		)
 	})
 
+	t.Run("auth-specified-assume-role", func(t *testing.T) {
+		roleARN := os.Getenv("AWS_ASSUME_ROLE")
+		if roleARN == "" {
+			skip.IgnoreLint(t, "AWS_ASSUME_ROLE env var must be set")
+		}
+		testExportStore(t, fmt.Sprintf(
+			"s3://%s/%s?%s=%s&%s=%s&%s=%s",
+			bucket, "backup-test-assume-role",
+			cloudimpl.AWSAccessKeyParam, url.QueryEscape(creds.AccessKeyID),
+			cloudimpl.AWSSecretParam, url.QueryEscape(creds.SecretAccessKey),
+			cloudimpl.AWSAssumeRoleParam, url.QueryEscape(roleARN),
+		), false, user, nil, nil)
+	})
+
 	// Tests that we can put an object with server side encryption specified.
 	t.Run("server-side-encryption", func(t *testing.T) {
 		// You can create an IAM that can access S3
@@ -279,7 +293,7 @@ func TestS3BucketDoesNotExist(t *testing.T) {
 	// Setup a sink for the given args.
 	clientFactory := blobs.TestBlobServiceClient(testSettings.ExternalIODir)
 	s, err := cloudimpl.MakeExternalStorage(ctx, conf, base.ExternalIODirConfig{}, testSettings,
-		clientFactory, nil, nil)
+		clientFactory, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -13,6 +13,7 @@ package cloudimpl
 import (
 	"context"
 	"io"
+	"net"
 	"net/url"
 	"path"
 	"strconv"
@@ -57,6 +58,10 @@ const (
 	// S3RegionParam is the query parameter for the 'endpoint' in an S3 URI.
 	S3RegionParam = "AWS_REGION"
 
+	// AWSAssumeRoleParam is the query parameter for the ARN of a role to be
+	// assumed, using the credentials selected by AUTH, when accessing S3.
+	AWSAssumeRoleParam = "ASSUME_ROLE"
+
 	// KMSRegionParam is the query parameter for the 'region' in every KMS URI.
 	KMSRegionParam = "REGION"
 
@@ -64,6 +69,9 @@ const (
 	AzureAccountNameParam = "AZURE_ACCOUNT_NAME"
 	// AzureAccountKeyParam is the query parameter for account_key in an azure URI.
 	AzureAccountKeyParam = "AZURE_ACCOUNT_KEY"
+	// AzureSASTokenParam is the query parameter for a shared access signature
+	// in an azure URI, which may be used instead of the account key.
+	AzureSASTokenParam = "AZURE_SAS_TOKEN"
 
 	// GoogleBillingProjectParam is the query parameter for the billing project
 	// in a gs URI.
@@ -111,6 +119,7 @@ var redactedQueryParams = map[string]struct{}{
 	AWSSecretParam:       {},
 	AWSTempTokenParam:    {},
 	AzureAccountKeyParam: {},
+	AzureSASTokenParam:   {},
 	CredentialsParam:     {},
 }
 
@@ -149,6 +158,7 @@ func ExternalStorageConfFromURI(
 			Auth:          uri.Query().Get(AuthParam),
 			ServerEncMode: uri.Query().Get(AWSServerSideEncryptionMode),
 			ServerKMSID:   uri.Query().Get(AWSServerSideEncryptionKMSID),
+			RoleARN:       uri.Query().Get(AWSAssumeRoleParam),
 			/* NB: additions here should also update s3QueryParams() serializer */
 		}
 		conf.S3Config.Prefix = strings.TrimLeft(conf.S3Config.Prefix, "/")
@@ -178,13 +188,19 @@ func ExternalStorageConfFromURI(
 			Prefix:      uri.Path,
 			AccountName: uri.Query().Get(AzureAccountNameParam),
 			AccountKey:  uri.Query().Get(AzureAccountKeyParam),
+			SASToken:    uri.Query().Get(AzureSASTokenParam),
 			/* NB: additions here should also update azureQueryParams() serializer */
 		}
 		if conf.AzureConfig.AccountName == "" {
 			return conf, errors.Errorf("azure uri missing %q parameter", AzureAccountNameParam)
 		}
-		if conf.AzureConfig.AccountKey == "" {
-			return conf, errors.Errorf("azure uri missing %q parameter", AzureAccountKeyParam)
+		if conf.AzureConfig.AccountKey == "" && conf.AzureConfig.SASToken == "" {
+			return conf, errors.Errorf("azure uri missing %q or %q parameter",
+				AzureAccountKeyParam, AzureSASTokenParam)
+		}
+		if conf.AzureConfig.AccountKey != "" && conf.AzureConfig.SASToken != "" {
+			return conf, errors.Errorf("azure uri cannot specify both %q and %q parameters",
+				AzureAccountKeyParam, AzureSASTokenParam)
 		}
 		conf.AzureConfig.Prefix = strings.TrimLeft(conf.AzureConfig.Prefix, "/")
 	case "http", "https":
@@ -251,12 +267,13 @@ func ExternalStorageFromURI(
 	user security.SQLUsername,
 	ie *sql.InternalExecutor,
 	kvDB *kv.DB,
+	httpMetrics *HTTPMetrics,
 ) (cloud.ExternalStorage, error) {
 	conf, err := ExternalStorageConfFromURI(uri, user)
 	if err != nil {
 		return nil, err
 	}
-	return MakeExternalStorage(ctx, conf, externalConfig, settings, blobClientFactory, ie, kvDB)
+	return MakeExternalStorage(ctx, conf, externalConfig, settings, blobClientFactory, ie, kvDB, httpMetrics)
 }
 
 // SanitizeExternalStorageURI returns the external storage URI with with some
@@ -294,6 +311,7 @@ func SanitizeExternalStorageURI(path string, extraParams []string) (string, erro
 }
 
 // MakeExternalStorage creates an ExternalStorage from the given config.
+// Requests made by HTTP storage are recorded in httpMetrics, if non-nil.
 func MakeExternalStorage(
 	ctx context.Context,
 	dest roachpb.ExternalStorage,
@@ -302,6 +320,7 @@ func MakeExternalStorage(
 	blobClientFactory blobs.BlobClientFactory,
 	ie *sql.InternalExecutor,
 	kvDB *kv.DB,
+	httpMetrics *HTTPMetrics,
 ) (cloud.ExternalStorage, error) {
 	switch dest.Provider {
 	case roachpb.ExternalStorageProvider_LocalFile:
@@ -315,7 +334,7 @@ func MakeExternalStorage(
 			return nil, errors.New("external http access disabled")
 		}
 		telemetry.Count("external-io.http")
-		return MakeHTTPStorage(dest.HttpPath.BaseUri, settings, conf)
+		return MakeHTTPStorage(dest.HttpPath.BaseUri, settings, conf, httpMetrics)
 	case roachpb.ExternalStorageProvider_S3:
 		telemetry.Count("external-io.s3")
 		return MakeS3Storage(ctx, conf, dest.S3Config, settings)
@@ -410,10 +429,14 @@ var (
 		"if set, JSON key to use during Google Cloud Storage operations",
 		"",
 	).WithPublic()
-	httpCustomCA = settings.RegisterStringSetting(
+	httpCustomCA = settings.RegisterValidatedStringSetting(
 		CloudstorageHTTPCASetting,
 		"custom root CA (appended to system's default CAs) for verifying certificates when interacting with HTTPS storage",
 		"",
+		func(_ *settings.Values, pem string) error {
+			_, err := parseCustomCABundle(pem)
+			return err
+		},
 	).WithPublic()
 	timeoutSetting = settings.RegisterDurationSetting(
 		cloudStorageTimeout,
@@ -470,11 +493,14 @@ func delayedRetry(ctx context.Context, fn func() error) error {
 //   the stream ends when the server terminates connection.
 // In addition, we treat connection reset by peer errors (which can
 // happen if we didn't read from the connection too long due to e.g. load),
-// the same as unexpected eof errors.
+// and network timeouts (which proxies sitting between us and the server
+// can cause by stalling a connection), the same as unexpected eof errors.
 func isResumableHTTPError(err error) bool {
+	var netErr net.Error
 	return errors.Is(err, io.ErrUnexpectedEOF) ||
 		sysutil.IsErrConnectionReset(err) ||
-		sysutil.IsErrConnectionRefused(err)
+		sysutil.IsErrConnectionRefused(err) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
 }
 
 func getPrefixBeforeWildcard(p string) string {
@@ -14,6 +14,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"hash/fnv"
 	"io"
@@ -31,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/errors"
 )
@@ -41,6 +43,7 @@ type httpStorage struct {
 	hosts    []string
 	settings *cluster.Settings
 	ioConf   base.ExternalIODirConfig
+	metrics  *HTTPMetrics
 }
 
 var _ cloud.ExternalStorage = &httpStorage{}
@@ -62,15 +65,88 @@ var HTTPRetryOptions = retry.Options{
 	Multiplier:     4,
 }
 
+var (
+	metaHTTPRequests = metric.Metadata{
+		Name:        "cloudstorage.http.requests",
+		Help:        "Number of requests sent to HTTP external storage",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaHTTPRetries = metric.Metadata{
+		Name:        "cloudstorage.http.retries",
+		Help:        "Number of requests to HTTP external storage retried after a transient error",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaHTTPResumes = metric.Metadata{
+		Name:        "cloudstorage.http.resumes",
+		Help:        "Number of reads from HTTP external storage resumed after a disconnect",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+// HTTPMetrics contains the metrics for requests made by HTTP external storage.
+type HTTPMetrics struct {
+	Requests *metric.Counter
+	Retries  *metric.Counter
+	Resumes  *metric.Counter
+}
+
+// MetricStruct implements the metric.Struct interface.
+func (HTTPMetrics) MetricStruct() {}
+
+var _ metric.Struct = (*HTTPMetrics)(nil)
+
+// MakeHTTPMetrics instantiates the metrics for HTTP external storage.
+func MakeHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		Requests: metric.NewCounter(metaHTTPRequests),
+		Retries:  metric.NewCounter(metaHTTPRetries),
+		Resumes:  metric.NewCounter(metaHTTPResumes),
+	}
+}
+
+// parseCustomCABundle parses the PEM-encoded certificates in bundle, which may
+// contain any number of them, e.g. the roots of a TLS-intercepting proxy along
+// with those of the servers behind it.
+func parseCustomCABundle(bundle string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.Errorf("custom CA bundle: unexpected PEM block of type %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "custom CA bundle: certificate %d", len(certs)+1)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 && strings.TrimSpace(bundle) != "" {
+		return nil, errors.New("custom CA bundle: no PEM-encoded certificates found")
+	}
+	return certs, nil
+}
+
 func makeHTTPClient(settings *cluster.Settings) (*http.Client, error) {
 	var tlsConf *tls.Config
-	if pem := httpCustomCA.Get(&settings.SV); pem != "" {
+	if bundle := httpCustomCA.Get(&settings.SV); bundle != "" {
+		certs, err := parseCustomCABundle(bundle)
+		if err != nil {
+			return nil, err
+		}
 		roots, err := x509.SystemCertPool()
 		if err != nil {
 			return nil, errors.Wrap(err, "could not load system root CA pool")
 		}
-		if !roots.AppendCertsFromPEM([]byte(pem)) {
-			return nil, errors.Errorf("failed to parse root CA certificate from %q", pem)
+		for _, cert := range certs {
+			roots.AddCert(cert)
 		}
 		tlsConf = &tls.Config{RootCAs: roots}
 	}
@@ -92,9 +168,13 @@ func makeHTTPClient(settings *cluster.Settings) (*http.Client, error) {
 	}}, nil
 }
 
-// MakeHTTPStorage returns an instance of HTTPStorage ExternalStorage.
+// MakeHTTPStorage returns an instance of HTTPStorage ExternalStorage. The
+// requests it makes are recorded in metrics, if non-nil.
 func MakeHTTPStorage(
-	base string, settings *cluster.Settings, ioConf base.ExternalIODirConfig,
+	base string,
+	settings *cluster.Settings,
+	ioConf base.ExternalIODirConfig,
+	metrics *HTTPMetrics,
 ) (cloud.ExternalStorage, error) {
 	if base == "" {
 		return nil, errors.Errorf("HTTP storage requested but prefix path not provided")
@@ -108,12 +188,16 @@ func MakeHTTPStorage(
 	if err != nil {
 		return nil, err
 	}
+	if metrics == nil {
+		metrics = MakeHTTPMetrics()
+	}
 	return &httpStorage{
 		base:     uri,
 		client:   client,
 		hosts:    strings.Split(uri.Host, ","),
 		settings: settings,
 		ioConf:   ioConf,
+		metrics:  metrics,
 	}, nil
 }
 
@@ -212,6 +296,7 @@ func (r *resumingHTTPReader) sendRequest(
 		if !errors.HasType(err, (*retryableHTTPError)(nil)) {
 			return nil, err
 		}
+		r.client.metrics.Retries.Inc(1)
 	}
 	if r.ctx.Err() == nil {
 		return nil, errors.New("too many retries; giving up")
@@ -228,6 +313,7 @@ func (r *resumingHTTPReader) requestNextRange() (err error) {
 	}
 
 	r.body = nil
+	r.client.metrics.Resumes.Inc(1)
 	var resp *http.Response
 	resp, err = r.sendRequest(map[string]string{"Range": fmt.Sprintf("bytes=%d-", r.pos)})
 
@@ -349,6 +435,7 @@ func (h *httpStorage) req(
 		req.Header.Add(key, val)
 	}
 
+	h.metrics.Requests.Inc(1)
 	resp, err := h.client.Do(req)
 	if err != nil {
 		// We failed to establish connection to the server (we don't even have
@@ -368,6 +455,13 @@ func (h *httpStorage) req(
 		if err != nil && resp.StatusCode == 404 {
 			err = errors.Wrapf(ErrFileDoesNotExist, "http storage file does not exist: %s", err.Error())
 		}
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			// The server, or a proxy in front of it, is overloaded or temporarily
+			// unable to reach the origin, so the request may succeed if retried.
+			return nil, &retryableHTTPError{err}
+		}
 		return nil, err
 	}
 	return resp, nil
@@ -12,6 +12,7 @@ package cloudimpl
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/url"
 	"path"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -64,6 +66,7 @@ func s3QueryParams(conf *roachpb.ExternalStorage_S3) string {
 	setIf(AuthParam, conf.Auth)
 	setIf(AWSServerSideEncryptionMode, conf.ServerEncMode)
 	setIf(AWSServerSideEncryptionKMSID, conf.ServerKMSID)
+	setIf(AWSAssumeRoleParam, conf.RoleARN)
 
 	return q.Encode()
 }
@@ -97,8 +100,11 @@ func MakeS3Storage(
 
 	// "specified": use credentials provided in URI params; error if not present.
 	// "implicit": enable SharedConfig, which loads in credentials from environment.
+	//             This includes web identity credentials, e.g. from IRSA, when
+	//             AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE are set.
 	//             Detailed in https://docs.aws.amazon.com/sdk-for-go/api/aws/session/
 	// "": default to `specified`.
+	// In either mode, ASSUME_ROLE names a role to assume with those credentials.
 	opts := session.Options{}
 	switch conf.Auth {
 	case "", AuthParamSpecified:
@@ -170,7 +176,21 @@ func MakeS3Storage(
 func (s *s3Storage) newS3Client(ctx context.Context) (*s3.S3, error) {
 	sess, err := session.NewSessionWithOptions(s.opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "new aws session")
+		return nil, errors.Wrapf(err, "new aws session using %s", s.credentialSource())
+	}
+	if s.conf.RoleARN != "" {
+		// STS needs a region even though it is reachable through a global
+		// endpoint, and the bucket's region may not be known yet.
+		stsSess := sess
+		if aws.StringValue(sess.Config.Region) == "" {
+			stsSess = sess.Copy(&aws.Config{Region: aws.String("us-east-1")})
+		}
+		sess.Config.Credentials = stscreds.NewCredentials(stsSess, s.conf.RoleARN)
+	}
+	// Resolve the credentials up front so that a failure is reported against
+	// its source instead of as an opaque error from the first S3 request.
+	if _, err := sess.Config.Credentials.GetWithContext(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to get aws credentials from %s", s.credentialSource())
 	}
 	if s.conf.Region == "" {
 		if err := delayedRetry(ctx, func() error {
@@ -185,6 +205,19 @@ func (s *s3Storage) newS3Client(ctx context.Context) (*s3.S3, error) {
 	return s3.New(sess), nil
 }
 
+// credentialSource describes where the credentials used to access the bucket
+// come from, for use in error messages.
+func (s *s3Storage) credentialSource() string {
+	src := fmt.Sprintf("the %s and %s parameters", AWSAccessKeyParam, AWSSecretParam)
+	if s.conf.Auth == AuthParamImplicit {
+		src = "implicit credentials (environment, shared config, web identity or instance role)"
+	}
+	if s.conf.RoleARN != "" {
+		src = fmt.Sprintf("role %s assumed using %s", s.conf.RoleARN, src)
+	}
+	return src
+}
+
 func (s *s3Storage) Conf() roachpb.ExternalStorage {
 	return roachpb.ExternalStorage{
 		Provider: roachpb.ExternalStorageProvider_S3,
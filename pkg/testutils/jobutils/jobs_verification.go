@@ -216,7 +216,7 @@ func GetLastJobID(t testing.TB, db *sqlutils.SQLRunner) int64 {
 func GetJobProgress(t *testing.T, db *sqlutils.SQLRunner, jobID int64) *jobspb.Progress {
 	ret := &jobspb.Progress{}
 	var buf []byte
-	db.QueryRow(t, `SELECT progress FROM system.jobs WHERE id = $1`, jobID).Scan(&buf)
+	db.QueryRow(t, `SELECT `+jobs.JobInfoProgressColumn+` FROM system.jobs WHERE id = $1`, jobID).Scan(&buf)
 	if err := protoutil.Unmarshal(buf, ret); err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,124 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package testcluster
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server"
+)
+
+// MultiRegionTestClusterArgs configures a TestCluster started with
+// StartMultiRegionTestCluster.
+type MultiRegionTestClusterArgs struct {
+	// Regions are the regions of the cluster. Nodes are assigned to the
+	// regions in order: the first NodesPerRegion nodes are in the first
+	// region, and so on.
+	Regions []string
+	// NodesPerRegion is the number of nodes in each region. Defaults to 1.
+	NodesPerRegion int
+	// Latency, if set, returns the artificial latency injected on the RPC
+	// connections from the nodes of one region to the nodes of another. No
+	// latency is injected between the nodes of the same region. The latency
+	// is rounded down to the millisecond.
+	Latency func(fromRegion, toRegion string) time.Duration
+	// ClusterArgs are the arguments of the TestCluster. The localities and
+	// listeners of the servers are overridden.
+	ClusterArgs base.TestClusterArgs
+}
+
+// StartMultiRegionTestCluster creates and starts up a TestCluster whose nodes
+// are spread across the given regions, with the locality tiers
+// "region=<region>,zone=<region>-<n>", and with artificial latencies injected
+// on the RPC connections between regions. The cluster should be stopped using
+// TestCluster.Stopper().Stop().
+func StartMultiRegionTestCluster(t testing.TB, args MultiRegionTestClusterArgs) *TestCluster {
+	if len(args.Regions) == 0 {
+		t.Fatal("no regions specified")
+	}
+	nodesPerRegion := args.NodesPerRegion
+	if nodesPerRegion == 0 {
+		nodesPerRegion = 1
+	}
+	numNodes := len(args.Regions) * nodesPerRegion
+
+	// The listeners are bound before any server is created so that the RPC
+	// address of every node, and therefore its region, is known before the
+	// nodes start dialing each other. The servers become responsible for
+	// closing them.
+	listeners := make([]net.Listener, numNodes)
+	addrToRegion := make(map[string]string, numNodes)
+	for i := range listeners {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			for _, ln := range listeners[:i] {
+				_ = ln.Close()
+			}
+			t.Fatal(err)
+		}
+		listeners[i] = ln
+		addrToRegion[ln.Addr().String()] = args.Regions[i/nodesPerRegion]
+	}
+
+	clusterArgs := args.ClusterArgs
+	clusterArgs.ServerArgsPerNode = make(map[int]base.TestServerArgs, numNodes)
+	for i := 0; i < numNodes; i++ {
+		serverArgs := args.ClusterArgs.ServerArgs
+		if perNodeServerArgs, ok := args.ClusterArgs.ServerArgsPerNode[i]; ok {
+			serverArgs = perNodeServerArgs
+		}
+		region := args.Regions[i/nodesPerRegion]
+		serverArgs.Locality = roachpb.Locality{Tiers: []roachpb.Tier{
+			{Key: "region", Value: region},
+			{Key: "zone", Value: fmt.Sprintf("%s-%d", region, i%nodesPerRegion+1)},
+		}}
+		serverArgs.Listener = listeners[i]
+		if i != 0 {
+			serverArgs.JoinAddr = listeners[0].Addr().String()
+		}
+
+		// Copy the knobs so the struct with the latency function is not
+		// reused for other nodes.
+		var knobs server.TestingKnobs
+		if serverArgs.Knobs.Server != nil {
+			knobs = *serverArgs.Knobs.Server.(*server.TestingKnobs)
+		}
+		if args.Latency != nil {
+			knobs.ContextTestingKnobs.ArtificialLatencyFn = func(target string) int {
+				targetRegion, ok := addrToRegion[target]
+				if !ok || targetRegion == region {
+					return 0
+				}
+				return int(args.Latency(region, targetRegion) / time.Millisecond)
+			}
+		}
+		serverArgs.Knobs.Server = &knobs
+		clusterArgs.ServerArgsPerNode[i] = serverArgs
+	}
+
+	tc := NewTestCluster(t, numNodes, clusterArgs)
+	tc.Start(t)
+	return tc
+}
+
+// UniformLatency returns a function, suitable for
+// MultiRegionTestClusterArgs.Latency, which injects the same latency between
+// all the regions.
+func UniformLatency(latency time.Duration) func(fromRegion, toRegion string) time.Duration {
+	return func(string, string) time.Duration {
+		return latency
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
+	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -260,3 +261,56 @@ func TestStopServer(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestStartMultiRegionTestCluster(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	latencies := map[[2]string]time.Duration{
+		{"us-east1", "us-west1"}: 30 * time.Millisecond,
+		{"us-west1", "us-east1"}: 40 * time.Millisecond,
+	}
+	tc := StartMultiRegionTestCluster(t, MultiRegionTestClusterArgs{
+		Regions:        []string{"us-east1", "us-west1"},
+		NodesPerRegion: 2,
+		Latency: func(fromRegion, toRegion string) time.Duration {
+			return latencies[[2]string{fromRegion, toRegion}]
+		},
+	})
+	defer tc.Stopper().Stop(context.Background())
+
+	expLocalities := []string{
+		"us-east1/us-east1-1",
+		"us-east1/us-east1-2",
+		"us-west1/us-west1-1",
+		"us-west1/us-west1-2",
+	}
+	for i, exp := range expLocalities {
+		var locality string
+		sqlutils.MakeSQLRunner(tc.Conns[i]).QueryRow(t,
+			`SELECT crdb_internal.locality_value('region') || '/' || crdb_internal.locality_value('zone')`,
+		).Scan(&locality)
+		if locality != exp {
+			t.Errorf("node %d: expected locality %s, got %s", i+1, exp, locality)
+		}
+	}
+
+	latencyFn := func(from, to int) int {
+		knobs := tc.Servers[from].Cfg.TestingKnobs.Server.(*server.TestingKnobs)
+		return knobs.ContextTestingKnobs.ArtificialLatencyFn(tc.Servers[to].ServingRPCAddr())
+	}
+	for _, c := range []struct {
+		from, to int
+		exp      int
+	}{
+		{from: 0, to: 1, exp: 0},
+		{from: 0, to: 2, exp: 30},
+		{from: 3, to: 1, exp: 40},
+		{from: 3, to: 2, exp: 0},
+	} {
+		if latency := latencyFn(c.from, c.to); latency != c.exp {
+			t.Errorf("expected a latency of %dms from node %d to node %d, got %dms",
+				c.exp, c.from+1, c.to+1, latency)
+		}
+	}
+}
@@ -1522,6 +1522,10 @@ var charts = []sectionDescription{
 				Title:   "Queued",
 				Metrics: []string{"sql.distsql.flows.queued"},
 			},
+			{
+				Title:   "Rejected",
+				Metrics: []string{"sql.distsql.flows.rejected"},
+			},
 			{
 				Title:   "Total",
 				Metrics: []string{"sql.distsql.flows.total"},
@@ -1640,6 +1644,19 @@ var charts = []sectionDescription{
 			},
 		},
 	},
+	{
+		Organization: [][]string{{SQLLayer, "Bulk", "External Storage"}},
+		Charts: []chartDescription{
+			{
+				Title: "HTTP Requests",
+				Metrics: []string{
+					"cloudstorage.http.requests",
+					"cloudstorage.http.retries",
+					"cloudstorage.http.resumes",
+				},
+			},
+		},
+	},
 	{
 		Organization: [][]string{{SQLLayer, "Optimizer"}},
 		Charts: []chartDescription{
@@ -1850,6 +1867,12 @@ var charts = []sectionDescription{
 					"sql.new_conns",
 				},
 			},
+			{
+				Title: "Abandoned Transactions",
+				Metrics: []string{
+					"sql.txn.abandoned.count",
+				},
+			},
 			{
 				Title: "Byte I/O",
 				Metrics: []string{
@@ -2188,6 +2211,14 @@ var charts = []sectionDescription{
 					"capacity.used",
 				},
 			},
+			{
+				Title:   "Emergency Ballast",
+				Metrics: []string{"capacity.ballast"},
+			},
+			{
+				Title:   "Read-only",
+				Metrics: []string{"capacity.read_only"},
+			},
 			{
 				Title: "Disk Health",
 				Metrics: []string{
@@ -2283,7 +2314,9 @@ var charts = []sectionDescription{
 				Metrics: []string{
 					"jobs.auto_create_stats.currently_running",
 					"jobs.backup.currently_running",
+					"jobs.batched_dml.currently_running",
 					"jobs.changefeed.currently_running",
+					"jobs.compaction.currently_running",
 					"jobs.create_stats.currently_running",
 					"jobs.import.currently_running",
 					"jobs.restore.currently_running",
@@ -2316,6 +2349,18 @@ var charts = []sectionDescription{
 				},
 				Rate: DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
 			},
+			{
+				Title: "Batched DML",
+				Metrics: []string{
+					"jobs.batched_dml.fail_or_cancel_completed",
+					"jobs.batched_dml.fail_or_cancel_failed",
+					"jobs.batched_dml.fail_or_cancel_retry_error",
+					"jobs.batched_dml.resume_completed",
+					"jobs.batched_dml.resume_failed",
+					"jobs.batched_dml.resume_retry_error",
+				},
+				Rate: DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
+			},
 			{
 				Title: "Changefeed",
 				Metrics: []string{
@@ -2328,6 +2373,18 @@ var charts = []sectionDescription{
 				},
 				Rate: DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
 			},
+			{
+				Title: "Compaction",
+				Metrics: []string{
+					"jobs.compaction.fail_or_cancel_completed",
+					"jobs.compaction.fail_or_cancel_failed",
+					"jobs.compaction.fail_or_cancel_retry_error",
+					"jobs.compaction.resume_completed",
+					"jobs.compaction.resume_failed",
+					"jobs.compaction.resume_retry_error",
+				},
+				Rate: DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
+			},
 			{
 				Title: "Create Stats",
 				Metrics: []string{
@@ -312,23 +312,63 @@ func (s *Server) Query(
 // server. Only data from the 10-second resolution is returned; rollup data is
 // not currently returned. Data is returned in the order it is read from disk,
 // and will thus not be totally organized by series.
+//
+// The request may restrict the dump to a set of metric names and to a time
+// range; the server only scans the keys of the requested series in that case.
 func (s *Server) Dump(req *tspb.DumpRequest, stream tspb.TimeSeries_DumpServer) error {
 	ctx := stream.Context()
-	span := &roachpb.Span{
-		Key:    roachpb.Key(firstTSRKey),
-		EndKey: roachpb.Key(lastTSRKey),
+	for _, span := range dumpSpans(req) {
+		if err := s.dumpSpan(ctx, span, req, stream); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	for span != nil {
+// dumpSpans returns the key spans containing the data requested by a
+// DumpRequest.
+func dumpSpans(req *tspb.DumpRequest) []roachpb.Span {
+	if len(req.Names) == 0 {
+		return []roachpb.Span{{
+			Key:    roachpb.Key(firstTSRKey),
+			EndKey: roachpb.Key(lastTSRKey),
+		}}
+	}
+	spans := make([]roachpb.Span, 0, len(req.Names))
+	for _, name := range req.Names {
+		prefix := makeDataKeySeriesPrefix(name, Resolution10s)
+		span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+		// Keys sort by slab before source, so the span can be narrowed to the
+		// slabs overlapping the requested time range.
+		if req.StartNanos > 0 {
+			span.Key = MakeDataKey(name, "" /* source */, Resolution10s, req.StartNanos)
+		}
+		if req.EndNanos > 0 {
+			span.EndKey = MakeDataKey(
+				name, "" /* source */, Resolution10s, req.EndNanos-1+Resolution10s.SlabDuration(),
+			)
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// dumpSpan streams the data stored in the given span that matches the time
+// range of the request.
+func (s *Server) dumpSpan(
+	ctx context.Context, span roachpb.Span, req *tspb.DumpRequest, stream tspb.TimeSeries_DumpServer,
+) error {
+	resumeSpan := &span
+	for resumeSpan != nil {
 		b := &kv.Batch{}
 		b.Header.MaxSpanRequestKeys = dumpBatchSize
-		b.Scan(span.Key, span.EndKey)
+		b.Scan(resumeSpan.Key, resumeSpan.EndKey)
 		err := s.db.db.Run(ctx, b)
 		if err != nil {
 			return err
 		}
 		result := b.Results[0]
-		span = result.ResumeSpan
+		resumeSpan = result.ResumeSpan
 		for i := range result.Rows {
 			row := &result.Rows[i]
 			name, source, resolution, _, err := DecodeDataKey(row.Key)
@@ -347,16 +387,25 @@ func (s *Server) Dump(req *tspb.DumpRequest, stream tspb.TimeSeries_DumpServer)
 			tsdata := &tspb.TimeSeriesData{
 				Name:       name,
 				Source:     source,
-				Datapoints: make([]tspb.TimeSeriesDatapoint, idata.SampleCount()),
+				Datapoints: make([]tspb.TimeSeriesDatapoint, 0, idata.SampleCount()),
 			}
 			for i := 0; i < idata.SampleCount(); i++ {
+				var dp tspb.TimeSeriesDatapoint
 				if idata.IsColumnar() {
-					tsdata.Datapoints[i].TimestampNanos = idata.TimestampForOffset(idata.Offset[i])
-					tsdata.Datapoints[i].Value = idata.Last[i]
+					dp.TimestampNanos = idata.TimestampForOffset(idata.Offset[i])
+					dp.Value = idata.Last[i]
 				} else {
-					tsdata.Datapoints[i].TimestampNanos = idata.TimestampForOffset(idata.Samples[i].Offset)
-					tsdata.Datapoints[i].Value = idata.Samples[i].Sum
+					dp.TimestampNanos = idata.TimestampForOffset(idata.Samples[i].Offset)
+					dp.Value = idata.Samples[i].Sum
 				}
+				if dp.TimestampNanos < req.StartNanos ||
+					(req.EndNanos > 0 && dp.TimestampNanos >= req.EndNanos) {
+					continue
+				}
+				tsdata.Datapoints = append(tsdata.Datapoints, dp)
+			}
+			if len(tsdata.Datapoints) == 0 {
+				continue
 			}
 			if err := stream.Send(tsdata); err != nil {
 				return err
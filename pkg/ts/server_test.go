@@ -440,6 +440,86 @@ func TestServerDump(t *testing.T) {
 	}
 }
 
+func TestServerDumpFiltered(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{
+				DisableTimeSeriesMaintenanceQueue: true,
+			},
+		},
+	})
+	defer s.Stopper().Stop(context.Background())
+	tsrv := s.(*server.TestServer)
+
+	seriesCount := 5
+	sourceCount := 3
+	slabCount := 3
+	valueCount := int(ts.Resolution10s.SlabDuration()/(100*1e9)) * slabCount
+
+	if err := populateSeries(seriesCount, sourceCount, valueCount, tsrv.TsDB()); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := tsrv.RPCContext().GRPCDialNode(tsrv.Cfg.Addr, tsrv.NodeID(),
+		rpc.DefaultClass).Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := tspb.NewTimeSeriesClient(conn)
+
+	// Request two of the series, over a time range that starts in the middle
+	// of the first slab and ends shortly after the start of the last one.
+	req := &tspb.DumpRequest{
+		Names:      []string{seriesName(1), seriesName(3)},
+		StartNanos: ts.Resolution10s.SlabDuration()/2 + 50*1e9,
+		EndNanos:   2*ts.Resolution10s.SlabDuration() + 250*1e9,
+	}
+	dumpClient, err := client.Dump(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resultMap := make(map[string]map[string][]tspb.TimeSeriesDatapoint)
+	for {
+		msg, err := dumpClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		sourceMap, ok := resultMap[msg.Name]
+		if !ok {
+			sourceMap = make(map[string][]tspb.TimeSeriesDatapoint)
+			resultMap[msg.Name] = sourceMap
+		}
+		sourceMap[msg.Source] = append(sourceMap[msg.Source], msg.Datapoints...)
+	}
+
+	var expectedDatapoints []tspb.TimeSeriesDatapoint
+	for _, dp := range generateTimeSeriesDatapoints(valueCount) {
+		if dp.TimestampNanos >= req.StartNanos && dp.TimestampNanos < req.EndNanos {
+			expectedDatapoints = append(expectedDatapoints, dp)
+		}
+	}
+	expectedMap := make(map[string]map[string][]tspb.TimeSeriesDatapoint)
+	for _, name := range req.Names {
+		sourceMap := make(map[string][]tspb.TimeSeriesDatapoint)
+		expectedMap[name] = sourceMap
+		for source := 0; source < sourceCount; source++ {
+			sourceMap[sourceName(source)] = expectedDatapoints
+		}
+	}
+
+	if a, e := resultMap, expectedMap; !reflect.DeepEqual(a, e) {
+		for _, diff := range pretty.Diff(a, e) {
+			t.Error(diff)
+		}
+	}
+}
+
 func BenchmarkServerQuery(b *testing.B) {
 	s, _, _ := serverutils.StartServer(b, base.TestServerArgs{})
 	defer s.Stopper().Stop(context.Background())
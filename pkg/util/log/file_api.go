@@ -123,6 +123,25 @@ func ListLogFiles() (logFiles []logpb.FileInfo, err error) {
 	return logFiles, err
 }
 
+// GetLogDirs returns the sorted list of the distinct directories in
+// which the configured file sinks write their log files.
+func GetLogDirs() []string {
+	seen := make(map[string]struct{})
+	var dirs []string
+	_ = allSinkInfos.iterFileSinks(func(l *fileSink) error {
+		l.mu.Lock()
+		dir := l.mu.logDir
+		l.mu.Unlock()
+		if _, ok := seen[dir]; dir != "" && !ok {
+			seen[dir] = struct{}{}
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+	return dirs
+}
+
 func (l *fileSink) listLogFiles() (string, []logpb.FileInfo, error) {
 	var results []logpb.FileInfo
 	l.mu.Lock()
@@ -15,11 +15,14 @@ import (
 	"fmt"
 	"math"
 	"math/bits"
+	"strings"
+	"sync/atomic"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -193,11 +196,27 @@ type BytesMonitor struct {
 		// maxBytesHist is the metric object used to track the high watermark of bytes
 		// allocated by the monitor during its lifetime.
 		maxBytesHist *metric.Histogram
+
+		// head is the first of the monitors that were started with this monitor
+		// as their pool and have not been stopped yet. The others are linked
+		// through their parentMu.nextSibling fields.
+		head *BytesMonitor
+	}
+
+	// parentMu holds the fields that are protected by the mutex of the pool
+	// of this monitor.
+	parentMu struct {
+		// prevSibling and nextSibling link this monitor into the list of
+		// children of its pool.
+		prevSibling, nextSibling *BytesMonitor
 	}
 
 	// name identifies this monitor in logging messages.
 	name string
 
+	// id uniquely identifies this monitor within the process.
+	id int64
+
 	// resource specifies what kind of resource the monitor is tracking
 	// allocations for. Specific behavior is delegated to this resource (e.g.
 	// budget exceeded errors).
@@ -219,6 +238,12 @@ type BytesMonitor struct {
 	// monitors are affected by this limit.
 	limit int64
 
+	// limitBreakdown, if set, causes the errors returned when the limit is
+	// exceeded to detail the usage of the monitors drawing from this one, and
+	// to carry limitHint if not empty. See EnableLimitBreakdown().
+	limitBreakdown bool
+	limitHint      string
+
 	// poolAllocationSize specifies the allocation unit for requests to the
 	// pool.
 	poolAllocationSize int64
@@ -243,6 +268,10 @@ var maxAllocatedButUnusedBlocks = envutil.EnvOrDefaultInt("COCKROACH_MAX_ALLOCAT
 // to reserve and release bytes to a pool.
 var DefaultPoolAllocationSize = envutil.EnvOrDefaultInt64("COCKROACH_ALLOCATION_CHUNK_SIZE", 10*1024)
 
+// lastMonitorID is the ID of the most recently created monitor. To be
+// accessed using atomic ops.
+var lastMonitorID int64
+
 // NewMonitor creates a new monitor.
 // Arguments:
 // - name is used to annotate log messages, can be used to distinguish
@@ -294,6 +323,7 @@ func NewMonitorWithLimit(
 	}
 	m := &BytesMonitor{
 		name:                 name,
+		id:                   atomic.AddInt64(&lastMonitorID, 1),
 		resource:             res,
 		limit:                limit,
 		noteworthyUsageBytes: noteworthy,
@@ -340,6 +370,16 @@ func (mm *BytesMonitor) Start(ctx context.Context, pool *BytesMonitor, reserved
 	mm.mu.maxAllocated = 0
 	mm.mu.curBudget = pool.MakeBoundAccount()
 	mm.reserved = reserved
+	if pool != nil {
+		pool.mu.Lock()
+		mm.parentMu.prevSibling = nil
+		mm.parentMu.nextSibling = pool.mu.head
+		if pool.mu.head != nil {
+			pool.mu.head.parentMu.prevSibling = mm
+		}
+		pool.mu.head = mm
+		pool.mu.Unlock()
+	}
 	if log.V(2) {
 		poolname := "(none)"
 		if pool != nil {
@@ -369,6 +409,7 @@ func NewUnlimitedMonitor(
 	}
 	m := &BytesMonitor{
 		name:                 name,
+		id:                   atomic.AddInt64(&lastMonitorID, 1),
 		resource:             res,
 		limit:                math.MaxInt64,
 		noteworthyUsageBytes: noteworthy,
@@ -397,6 +438,19 @@ const bytesMaxUsageLoggingThreshold = 100 * 1024
 func (mm *BytesMonitor) doStop(ctx context.Context, check bool) {
 	// NB: No need to lock mm.mu here, when StopMonitor() is called the
 	// monitor is not shared any more.
+	if pool := mm.mu.curBudget.mon; pool != nil {
+		pool.mu.Lock()
+		if prev := mm.parentMu.prevSibling; prev != nil {
+			prev.parentMu.nextSibling = mm.parentMu.nextSibling
+		} else {
+			pool.mu.head = mm.parentMu.nextSibling
+		}
+		if next := mm.parentMu.nextSibling; next != nil {
+			next.parentMu.prevSibling = mm.parentMu.prevSibling
+		}
+		mm.parentMu.prevSibling, mm.parentMu.nextSibling = nil, nil
+		pool.mu.Unlock()
+	}
 	if log.V(1) && mm.mu.maxAllocated >= bytesMaxUsageLoggingThreshold {
 		log.InfofDepth(ctx, 1, "%s, bytes usage max %s",
 			mm.name,
@@ -457,6 +511,130 @@ func (mm *BytesMonitor) Resource() Resource {
 	return mm.resource
 }
 
+// ID returns the identifier of the monitor, which is unique within the
+// process.
+func (mm *BytesMonitor) ID() int64 {
+	return mm.id
+}
+
+// EnableLimitBreakdown causes the errors returned when the limit of the
+// monitor is exceeded to include, as detail, the usage of the monitor and of
+// the monitors drawing from it, so that the consumer responsible for the
+// error can be identified. The errors also carry the given hint, if not
+// empty. It must be called before the monitor is started.
+func (mm *BytesMonitor) EnableLimitBreakdown(hint string) {
+	mm.limitBreakdown = true
+	mm.limitHint = hint
+}
+
+// limitExceededError is returned by reserveBytes when the limit of a monitor
+// with the limit breakdown enabled is exceeded. It does not escape this
+// package: Grow replaces it by its cause annotated with the breakdown.
+type limitExceededError struct {
+	cause error
+	mon   *BytesMonitor
+}
+
+func (e *limitExceededError) Error() string { return e.cause.Error() }
+func (e *limitExceededError) Cause() error  { return e.cause }
+func (e *limitExceededError) Unwrap() error { return e.cause }
+
+// maxLimitBreakdownMonitors is the maximum number of monitors listed in the
+// breakdown attached to the errors of monitors with EnableLimitBreakdown.
+const maxLimitBreakdownMonitors = 20
+
+// withLimitBreakdown annotates the error returned when the limit of the
+// monitor is exceeded with the usage of the monitors drawing from it.
+func (mm *BytesMonitor) withLimitBreakdown(err error) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "usage of %s (limit %s):", mm.name, humanizeutil.IBytes(mm.limit))
+	n := 0
+	_ = mm.TraverseTree(func(s MonitorState) error {
+		if s.Level > 0 && s.Used == 0 {
+			return nil
+		}
+		if n == maxLimitBreakdownMonitors {
+			buf.WriteString("\n...")
+			return iterutil.StopIteration()
+		}
+		n++
+		fmt.Fprintf(&buf, "\n%s%s: %s",
+			strings.Repeat("  ", s.Level), s.Name, humanizeutil.IBytes(s.Used))
+		return nil
+	})
+	err = errors.WithDetail(err, buf.String())
+	if mm.limitHint != "" {
+		err = errors.WithHint(err, mm.limitHint)
+	}
+	return err
+}
+
+// MonitorState describes the state of a monitor at the time it was visited
+// by TraverseTree.
+type MonitorState struct {
+	// Level is the depth of the monitor in the traversed tree; the monitor
+	// TraverseTree is called on is at level 0.
+	Level int
+	// Name is the name of the monitor.
+	Name string
+	// ID is the identifier of the monitor, and ParentID that of its pool, or
+	// zero for the monitor TraverseTree is called on.
+	ID, ParentID int64
+	// Used is the number of bytes currently allocated by the clients of the
+	// monitor, and Peak its high water mark since the monitor was started.
+	Used, Peak int64
+	// PoolBudget is the number of bytes the monitor reserved from its pool, and
+	// Reserved the size of the budget it was pre-reserved when started.
+	PoolBudget, Reserved int64
+	// Limit is the limit local to the monitor, or math.MaxInt64 if unlimited.
+	Limit int64
+}
+
+// TraverseTree calls visit with the state of this monitor and then, in
+// depth-first order, with the state of every started monitor that draws from
+// it, directly or transitively. The traversal stops at the first error
+// returned by visit.
+func (mm *BytesMonitor) TraverseTree(visit func(MonitorState) error) error {
+	return mm.traverseTree(0 /* level */, 0 /* parentID */, visit)
+}
+
+func (mm *BytesMonitor) traverseTree(
+	level int, parentID int64, visit func(MonitorState) error,
+) error {
+	// The mutex of a monitor is acquired while holding the mutex of its
+	// children when growing their budget, so the children are collected
+	// first and visited once the mutex of this monitor is released.
+	state, children := func() (MonitorState, []*BytesMonitor) {
+		mm.mu.Lock()
+		defer mm.mu.Unlock()
+		state := MonitorState{
+			Level:      level,
+			Name:       mm.name,
+			ID:         mm.id,
+			ParentID:   parentID,
+			Used:       mm.mu.curAllocated,
+			Peak:       mm.mu.maxAllocated,
+			PoolBudget: mm.mu.curBudget.used,
+			Reserved:   mm.reserved.used,
+			Limit:      mm.limit,
+		}
+		var children []*BytesMonitor
+		for c := mm.mu.head; c != nil; c = c.parentMu.nextSibling {
+			children = append(children, c)
+		}
+		return state, children
+	}()
+	if err := visit(state); err != nil {
+		return err
+	}
+	for _, c := range children {
+		if err := c.traverseTree(level+1, mm.id, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // BoundAccount tracks the cumulated allocations for one client of a pool or
 // monitor. BytesMonitor has an account to its pool; BytesMonitor clients have
 // an account to the monitor. This allows each client to release all the bytes
@@ -568,6 +746,17 @@ func (b *BoundAccount) ResizeTo(ctx context.Context, newSz int64) error {
 
 // Grow is an accessor for b.mon.GrowAccount.
 func (b *BoundAccount) Grow(ctx context.Context, x int64) error {
+	err := b.grow(ctx, x)
+	var lerr *limitExceededError
+	if errors.As(err, &lerr) {
+		return lerr.mon.withLimitBreakdown(lerr.cause)
+	}
+	return err
+}
+
+// grow implements Grow. Unlike Grow, it is called by monitors to request
+// budget from their pool, while their mutex is held.
+func (b *BoundAccount) grow(ctx context.Context, x int64) error {
 	if b.reserved < x {
 		minExtra := b.mon.roundSize(x)
 		if err := b.mon.reserveBytes(ctx, minExtra); err != nil {
@@ -609,9 +798,16 @@ func (mm *BytesMonitor) reserveBytes(ctx context.Context, x int64) error {
 	// TODO(knz): make the monitor name reportable in telemetry, after checking
 	// that the name is never constructed from user data.
 	if mm.mu.curAllocated > mm.limit-x {
-		return errors.Wrapf(
+		err := errors.Wrapf(
 			mm.resource.NewBudgetExceededError(x, mm.mu.curAllocated, mm.limit), "%s", mm.name,
 		)
+		if mm.limitBreakdown {
+			// The breakdown cannot be collected here, since the mutexes of the
+			// monitors drawing from this one may be held. It is added once the
+			// error reaches the account that requested the allocation.
+			return &limitExceededError{cause: err, mon: mm}
+		}
+		return err
 	}
 	// Check whether we need to request an increase of our budget.
 	if mm.mu.curAllocated > mm.mu.curBudget.used+mm.reserved.used-x {
@@ -690,7 +886,7 @@ func (mm *BytesMonitor) increaseBudget(ctx context.Context, minExtra int64) erro
 		log.Infof(ctx, "%s: requesting %d bytes from the pool", mm.name, minExtra)
 	}
 
-	return mm.mu.curBudget.Grow(ctx, minExtra)
+	return mm.mu.curBudget.grow(ctx, minExtra)
 }
 
 // roundSize rounds its argument to the smallest greater or equal
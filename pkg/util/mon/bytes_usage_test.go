@@ -18,9 +18,12 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/errors"
 )
 
 // randomSize generates a size greater or equal to zero, with a random
@@ -360,3 +363,105 @@ func BenchmarkBoundAccountGrow(b *testing.B) {
 		_ = a.Grow(ctx, 1)
 	}
 }
+
+func TestBytesMonitorTraverseTree(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	newMonitor := func(name string) *BytesMonitor {
+		return NewMonitor(name, MemoryResource,
+			nil /* curCount */, nil /* maxHist */, 1 /* increment */, math.MaxInt64 /* noteworthy */, st)
+	}
+	root := newMonitor("root")
+	root.Start(ctx, nil, MakeStandaloneBudget(1000))
+	defer root.Stop(ctx)
+	session := newMonitor("session")
+	session.Start(ctx, root, BoundAccount{})
+	txn := newMonitor("txn")
+	txn.Start(ctx, session, BoundAccount{})
+	other := newMonitor("other")
+	other.Start(ctx, root, BoundAccount{})
+
+	acc := txn.MakeBoundAccount()
+	if err := acc.Grow(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	traverse := func() []string {
+		var res []string
+		if err := root.TraverseTree(func(s MonitorState) error {
+			res = append(res, fmt.Sprintf("%d %s used=%d", s.Level, s.Name, s.Used))
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	expected := []string{
+		"0 root used=10",
+		"1 other used=0",
+		"1 session used=10",
+		"2 txn used=10",
+	}
+	if res := traverse(); fmt.Sprint(res) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, res)
+	}
+
+	// Stopped monitors are no longer part of the tree.
+	acc.Close(ctx)
+	txn.Stop(ctx)
+	other.Stop(ctx)
+	expected = []string{
+		"0 root used=0",
+		"1 session used=0",
+	}
+	if res := traverse(); fmt.Sprint(res) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, res)
+	}
+	session.Stop(ctx)
+}
+
+func TestBytesMonitorLimitBreakdown(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	root := NewMonitor("root", MemoryResource,
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, math.MaxInt64 /* noteworthy */, st)
+	root.Start(ctx, nil, MakeStandaloneBudget(1000))
+	defer root.Stop(ctx)
+	flow := NewMonitorWithLimit("flow", MemoryResource, 100, /* limit */
+		nil /* curCount */, nil /* maxHist */, 1 /* increment */, math.MaxInt64 /* noteworthy */, st)
+	flow.EnableLimitBreakdown("some hint")
+	flow.Start(ctx, root, BoundAccount{})
+	defer flow.Stop(ctx)
+	sorter := NewMonitorInheritWithLimit("sorter", 0 /* limit */, flow)
+	sorter.Start(ctx, flow, BoundAccount{})
+	defer sorter.Stop(ctx)
+	joiner := NewMonitorInheritWithLimit("joiner", 0 /* limit */, flow)
+	joiner.Start(ctx, flow, BoundAccount{})
+	defer joiner.Stop(ctx)
+
+	sorterAcc := sorter.MakeBoundAccount()
+	defer sorterAcc.Close(ctx)
+	if err := sorterAcc.Grow(ctx, 60); err != nil {
+		t.Fatal(err)
+	}
+	joinerAcc := joiner.MakeBoundAccount()
+	defer joinerAcc.Close(ctx)
+	err := joinerAcc.Grow(ctx, 50)
+	if err == nil {
+		t.Fatal("expected the limit of the flow monitor to be exceeded")
+	}
+	if code := pgerror.GetPGCode(err); code != pgcode.OutOfMemory {
+		t.Fatalf("expected code %s, got %s", pgcode.OutOfMemory, code)
+	}
+	const expectedDetail = "usage of flow (limit 100 B):\nflow: 60 B\n  sorter: 60 B"
+	if detail := errors.FlattenDetails(err); detail != expectedDetail {
+		t.Fatalf("expected detail %q, got %q", expectedDetail, detail)
+	}
+	if hint := errors.FlattenHints(err); hint != "some hint" {
+		t.Fatalf("expected hint %q, got %q", "some hint", hint)
+	}
+}
@@ -11,14 +11,64 @@
 package timeutil
 
 import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 	// embed tzdata in case system tzdata is not available.
 	_ "time/tzdata"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 )
 
 //go:generate go run generate_lowercase_timezones.go
 
+// systemTZDataDirs are the locations of the system time zone database the
+// time package looks at on Unix systems.
+var systemTZDataDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+}
+
+var tzdata struct {
+	syncutil.Mutex
+	// dir, if set, is a directory laid out like the IANA Time Zone database
+	// (such as /usr/share/zoneinfo) that time zones are loaded from before
+	// falling back to the lookup of the time package.
+	dir string
+	// locations caches the time zones loaded so far, by name.
+	locations map[string]*time.Location
+	// generation is incremented every time the cache is cleared, so that
+	// loads racing with a reload don't repopulate it with stale entries.
+	generation int
+}
+
+// SetTZDataDir sets the directory time zones are preferably loaded from, and
+// reloads the time zone database. An empty dir restores the lookup of the time
+// package: the ZONEINFO environment variable, the system time zone database,
+// then the copy embedded in the binary.
+func SetTZDataDir(dir string) {
+	tzdata.Lock()
+	defer tzdata.Unlock()
+	tzdata.dir = dir
+	tzdata.locations = nil
+	tzdata.generation++
+}
+
+// ReloadTZData drops the time zones loaded so far, so that they are read
+// again from the time zone database on their next use.
+func ReloadTZData() {
+	tzdata.Lock()
+	defer tzdata.Unlock()
+	tzdata.locations = nil
+	tzdata.generation++
+}
+
 // LoadLocation returns the time.Location with the given name.
 // The name is taken to be a location name corresponding to a file
 // in the IANA Time Zone database, such as "America/New_York".
@@ -33,14 +83,125 @@ func LoadLocation(name string) (*time.Location, error) {
 		name = "UTC"
 	}
 	// If we know this is a lowercase name in tzdata, use the uppercase form.
-	if v, ok := lowercaseTimezones[loweredName]; ok {
+	canonicalName, ok := lowercaseTimezones[loweredName]
+	if !ok {
+		canonicalName = name
+	}
+
+	tzdata.Lock()
+	loc, ok := tzdata.locations[canonicalName]
+	dir, generation := tzdata.dir, tzdata.generation
+	tzdata.Unlock()
+	if ok {
+		return loc, nil
+	}
+
+	loc, err := loadLocation(dir, name, canonicalName)
+	if err != nil {
+		return nil, err
+	}
+
+	tzdata.Lock()
+	defer tzdata.Unlock()
+	if tzdata.generation == generation {
+		if tzdata.locations == nil {
+			tzdata.locations = make(map[string]*time.Location)
+		}
+		tzdata.locations[canonicalName] = loc
+	}
+	return loc, nil
+}
+
+func loadLocation(dir, name, canonicalName string) (*time.Location, error) {
+	if dir != "" {
+		if loc, err := loadLocationFromDir(dir, canonicalName); err == nil {
+			return loc, nil
+		}
+	}
+	if canonicalName != name {
 		// If this location is not found, we may have a case where the tzdata names
 		// have different values than the system tz names.
 		// If this is the case, allback onto the default logic, where the name is read
 		// off other sources before tzdata.
-		if loc, err := time.LoadLocation(v); err == nil {
+		if loc, err := time.LoadLocation(canonicalName); err == nil {
 			return loc, nil
 		}
 	}
 	return time.LoadLocation(name)
 }
+
+// loadLocationFromDir loads the time zone with the given name from the time
+// zone database in dir.
+func loadLocationFromDir(dir, name string) (*time.Location, error) {
+	if name == "" || filepath.IsAbs(name) || strings.Contains(name, "..") {
+		return nil, os.ErrNotExist
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocationFromTZData(name, data)
+}
+
+// TZDataSource describes the time zone database time zones are loaded from.
+// It returns the directory of the database, or "embedded" for the copy
+// embedded in the binary, and the release of the database (e.g. "2021a"), or
+// "unknown" if it can't be determined.
+func TZDataSource() (source string, version string) {
+	tzdata.Lock()
+	dir := tzdata.dir
+	tzdata.Unlock()
+
+	dirs := systemTZDataDirs
+	if dir != "" {
+		dirs = []string{dir}
+	} else if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+		dirs = append([]string{zoneinfo}, dirs...)
+	}
+	for _, d := range dirs {
+		if _, err := os.Stat(filepath.Join(d, "UTC")); err != nil {
+			continue
+		}
+		if v, ok := readTZDataVersion(d); ok {
+			return d, v
+		}
+		return d, "unknown"
+	}
+	// The time package embeds the time zone database of the Go release the
+	// binary was built with.
+	return "embedded", "unknown (" + runtime.Version() + ")"
+}
+
+// readTZDataVersion reads the release of the time zone database in dir from
+// the +VERSION file written by the IANA distribution, or from the header of
+// the tzdata.zi file installed by most Linux distributions.
+func readTZDataVersion(dir string) (string, bool) {
+	if data, err := ioutil.ReadFile(filepath.Join(dir, "+VERSION")); err == nil {
+		if v := strings.TrimSpace(string(data)); v != "" {
+			return v, true
+		}
+	}
+	f, err := os.Open(filepath.Join(dir, "tzdata.zi"))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	if s.Scan() {
+		if v := strings.TrimPrefix(s.Text(), "# version "); v != s.Text() {
+			return strings.TrimSpace(v), true
+		}
+	}
+	return "", false
+}
+
+// TimeZoneNames returns the names of the time zones of the IANA Time Zone
+// database, in sorted order.
+func TimeZoneNames() []string {
+	names := make([]string, 0, len(lowercaseTimezones))
+	for _, name := range lowercaseTimezones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package timeutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTZDataVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tzdata")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	_, ok := readTZDataVersion(dir)
+	require.False(t, ok)
+
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "tzdata.zi"), []byte("# version 2021a\n# This zic input file is in the public domain.\n"), 0644,
+	))
+	v, ok := readTZDataVersion(dir)
+	require.True(t, ok)
+	require.Equal(t, "2021a", v)
+
+	// +VERSION takes precedence.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "+VERSION"), []byte("2021b\n"), 0644))
+	v, ok = readTZDataVersion(dir)
+	require.True(t, ok)
+	require.Equal(t, "2021b", v)
+}
+
+func TestTZDataDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tzdata")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	defer SetTZDataDir("")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "UTC"), []byte("not a zone"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "+VERSION"), []byte("2021b"), 0644))
+	SetTZDataDir(dir)
+
+	source, version := TZDataSource()
+	require.Equal(t, dir, source)
+	require.Equal(t, "2021b", version)
+
+	// Time zones missing from, or invalid in, the configured directory are
+	// loaded from the default sources.
+	for _, name := range []string{"UTC", "America/New_York", "america/new_york"} {
+		loc, err := LoadLocation(name)
+		require.NoError(t, err)
+		require.Equal(t, canonicalTZName(name), loc.String())
+	}
+
+	// Names escaping the directory are never read from it.
+	_, err = loadLocationFromDir(dir, "../UTC")
+	require.Error(t, err)
+	_, err = loadLocationFromDir(dir, "/UTC")
+	require.Error(t, err)
+}
+
+func TestTimeZoneNames(t *testing.T) {
+	names := TimeZoneNames()
+	require.True(t, sort.StringsAreSorted(names))
+	require.Contains(t, names, "America/New_York")
+	require.Contains(t, names, "UTC")
+}
+
+func canonicalTZName(name string) string {
+	if n, ok := lowercaseTimezones[name]; ok {
+		return n
+	}
+	return name
+}
@@ -0,0 +1,310 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hotspot
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/cockroachdb/cockroach-go/crdb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/workload"
+	"github.com/cockroachdb/cockroach/pkg/workload/histogram"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/pflag"
+)
+
+// Every table is keyed by the tenant ID first, so that the data of each
+// tenant lives under its own key prefix.
+const (
+	warehouseSchema = `(
+		tenant_id INT NOT NULL,
+		w_id INT NOT NULL,
+		w_ytd INT NOT NULL,
+		PRIMARY KEY (tenant_id, w_id)
+	)`
+	districtSchema = `(
+		tenant_id INT NOT NULL,
+		w_id INT NOT NULL,
+		d_id INT NOT NULL,
+		d_ytd INT NOT NULL,
+		d_next_o_id INT NOT NULL,
+		PRIMARY KEY (tenant_id, w_id, d_id)
+	)`
+	ordersSchema = `(
+		tenant_id INT NOT NULL,
+		w_id INT NOT NULL,
+		d_id INT NOT NULL,
+		o_id INT NOT NULL,
+		o_amount INT NOT NULL,
+		PRIMARY KEY (tenant_id, w_id, d_id, o_id)
+	)`
+
+	maxAmount = 5000
+)
+
+var (
+	warehouseTypes = []*types.T{types.Int, types.Int, types.Int}
+	districtTypes  = []*types.T{types.Int, types.Int, types.Int, types.Int, types.Int}
+)
+
+type hotspot struct {
+	flags     workload.Flags
+	connFlags *workload.ConnFlags
+
+	seed                int64
+	tenants             int
+	warehouses          int
+	districts           int
+	tenantZipfS         float64
+	warehouseZipfS      float64
+	paymentPercent      int
+	histogramsPerTenant bool
+}
+
+func init() {
+	workload.Register(hotspotMeta)
+}
+
+var hotspotMeta = workload.Meta{
+	Name: `hotspot`,
+	Description: `Hotspot runs a TPC-C-like order and payment mix with tunable key skew, ` +
+		`optionally across several tenants`,
+	Version: `1.0.0`,
+	New: func() workload.Generator {
+		g := &hotspot{}
+		g.flags.FlagSet = pflag.NewFlagSet(`hotspot`, pflag.ContinueOnError)
+		g.flags.Meta = map[string]workload.FlagMeta{
+			`tenant-zipf-s`:         {RuntimeOnly: true},
+			`warehouse-zipf-s`:      {RuntimeOnly: true},
+			`payment-percent`:       {RuntimeOnly: true},
+			`histograms-per-tenant`: {RuntimeOnly: true},
+		}
+		g.flags.Int64Var(&g.seed, `seed`, 1, `Random number generator seed.`)
+		g.flags.IntVar(&g.tenants, `tenants`, 1,
+			`Number of tenants. The data of each tenant lives under its own key prefix.`)
+		g.flags.IntVar(&g.warehouses, `warehouses`, 10, `Number of warehouses per tenant.`)
+		g.flags.IntVar(&g.districts, `districts`, 10, `Number of districts per warehouse.`)
+		g.flags.Float64Var(&g.tenantZipfS, `tenant-zipf-s`, 0,
+			`Zipfian skew (s > 1) of the tenant chosen by each transaction. 0 picks tenants uniformly.`)
+		g.flags.Float64Var(&g.warehouseZipfS, `warehouse-zipf-s`, 0,
+			`Zipfian skew (s > 1) of the warehouse chosen by each transaction. 0 picks warehouses uniformly.`)
+		g.flags.IntVar(&g.paymentPercent, `payment-percent`, 50,
+			`Percentage of transactions that are payments; the rest are new orders.`)
+		g.flags.BoolVar(&g.histogramsPerTenant, `histograms-per-tenant`, false,
+			`Also record the latencies of each tenant in separate histograms.`)
+		g.connFlags = workload.NewConnFlags(&g.flags)
+		return g
+	},
+}
+
+// Meta implements the Generator interface.
+func (*hotspot) Meta() workload.Meta { return hotspotMeta }
+
+// Flags implements the Flagser interface.
+func (w *hotspot) Flags() workload.Flags { return w.flags }
+
+// Hooks implements the Hookser interface.
+func (w *hotspot) Hooks() workload.Hooks {
+	return workload.Hooks{
+		Validate: func() error {
+			if w.tenants <= 0 || w.warehouses <= 0 || w.districts <= 0 {
+				return errors.Errorf(
+					`--tenants, --warehouses and --districts must be positive; got %d, %d and %d`,
+					w.tenants, w.warehouses, w.districts)
+			}
+			for _, s := range []float64{w.tenantZipfS, w.warehouseZipfS} {
+				if s != 0 && s <= 1 {
+					return errors.Errorf(`Zipfian skew must be 0 or greater than 1; got %g`, s)
+				}
+			}
+			if w.paymentPercent < 0 || w.paymentPercent > 100 {
+				return errors.Errorf(`--payment-percent must be between 0 and 100; got %d`,
+					w.paymentPercent)
+			}
+			return nil
+		},
+	}
+}
+
+// Tables implements the Generator interface.
+func (w *hotspot) Tables() []workload.Table {
+	// Split every table at the start of each tenant's key prefix.
+	tenantSplits := workload.Tuples(
+		w.tenants-1,
+		func(splitIdx int) []interface{} {
+			return []interface{}{splitIdx + 1}
+		},
+	)
+	warehouse := workload.Table{
+		Name:   `warehouse`,
+		Schema: warehouseSchema,
+		InitialRows: workload.TypedTuples(
+			w.tenants*w.warehouses,
+			warehouseTypes,
+			func(rowIdx int) []interface{} {
+				return []interface{}{rowIdx / w.warehouses, rowIdx % w.warehouses, 0}
+			},
+		),
+		Splits: tenantSplits,
+	}
+	district := workload.Table{
+		Name:   `district`,
+		Schema: districtSchema,
+		InitialRows: workload.TypedTuples(
+			w.tenants*w.warehouses*w.districts,
+			districtTypes,
+			func(rowIdx int) []interface{} {
+				wIdx := rowIdx / w.districts
+				return []interface{}{
+					wIdx / w.warehouses, wIdx % w.warehouses, rowIdx % w.districts, 0, 0,
+				}
+			},
+		),
+		Splits: tenantSplits,
+	}
+	// New orders are only inserted by the workload itself.
+	orders := workload.Table{
+		Name:   `orders`,
+		Schema: ordersSchema,
+		Splits: tenantSplits,
+	}
+	return []workload.Table{warehouse, district, orders}
+}
+
+// Ops implements the Opser interface.
+func (w *hotspot) Ops(
+	ctx context.Context, urls []string, reg *histogram.Registry,
+) (workload.QueryLoad, error) {
+	sqlDatabase, err := workload.SanitizeUrls(w, w.connFlags.DBOverride, urls)
+	if err != nil {
+		return workload.QueryLoad{}, err
+	}
+	db, err := gosql.Open(`cockroach`, strings.Join(urls, ` `))
+	if err != nil {
+		return workload.QueryLoad{}, err
+	}
+	// Allow a maximum of concurrency+1 connections to the database.
+	db.SetMaxOpenConns(w.connFlags.Concurrency + 1)
+	db.SetMaxIdleConns(w.connFlags.Concurrency + 1)
+
+	ql := workload.QueryLoad{SQLDatabase: sqlDatabase}
+	for i := 0; i < w.connFlags.Concurrency; i++ {
+		// Each worker gets its own deterministic source of randomness, so that
+		// the sequence of keys accessed by a run only depends on the flags.
+		rng := rand.New(rand.NewSource(w.seed + int64(i)))
+		wk := &worker{
+			config:          w,
+			db:              db,
+			hists:           reg.GetHandle(),
+			rng:             rng,
+			tenantPicker:    makeSkewedPicker(rng, w.tenantZipfS, w.tenants),
+			warehousePicker: makeSkewedPicker(rng, w.warehouseZipfS, w.warehouses),
+		}
+		ql.WorkerFns = append(ql.WorkerFns, wk.run)
+	}
+	return ql, nil
+}
+
+// skewedPicker picks integers in [0, n), either uniformly or following a
+// Zipfian distribution in which 0 is the most frequent value.
+type skewedPicker struct {
+	rng  *rand.Rand
+	zipf *rand.Zipf
+	n    int
+}
+
+// makeSkewedPicker returns a skewedPicker for [0, n). If s is 0, values are
+// picked uniformly; otherwise s is the Zipfian exponent, which must be
+// greater than 1.
+func makeSkewedPicker(rng *rand.Rand, s float64, n int) skewedPicker {
+	p := skewedPicker{rng: rng, n: n}
+	if s != 0 {
+		p.zipf = rand.NewZipf(rng, s, 1 /* v */, uint64(n-1))
+	}
+	return p
+}
+
+func (p skewedPicker) next() int {
+	if p.zipf != nil {
+		return int(p.zipf.Uint64())
+	}
+	return p.rng.Intn(p.n)
+}
+
+type worker struct {
+	config *hotspot
+	db     *gosql.DB
+	hists  *histogram.Histograms
+	rng    *rand.Rand
+
+	tenantPicker    skewedPicker
+	warehousePicker skewedPicker
+}
+
+func (wk *worker) run(ctx context.Context) error {
+	tenantID := wk.tenantPicker.next()
+	wID := wk.warehousePicker.next()
+	dID := wk.rng.Intn(wk.config.districts)
+	amount := 1 + wk.rng.Intn(maxAmount)
+
+	opName := `newOrder`
+	txFn := func(tx *gosql.Tx) error {
+		var oID int
+		if err := tx.QueryRowContext(ctx, `
+			UPDATE district SET d_next_o_id = d_next_o_id + 1
+			WHERE tenant_id = $1 AND w_id = $2 AND d_id = $3
+			RETURNING d_next_o_id - 1`,
+			tenantID, wID, dID,
+		).Scan(&oID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO orders (tenant_id, w_id, d_id, o_id, o_amount)
+			VALUES ($1, $2, $3, $4, $5)`,
+			tenantID, wID, dID, oID, amount,
+		)
+		return err
+	}
+	if wk.rng.Intn(100) < wk.config.paymentPercent {
+		opName = `payment`
+		txFn = func(tx *gosql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE warehouse SET w_ytd = w_ytd + $3
+				WHERE tenant_id = $1 AND w_id = $2`,
+				tenantID, wID, amount,
+			); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `
+				UPDATE district SET d_ytd = d_ytd + $4
+				WHERE tenant_id = $1 AND w_id = $2 AND d_id = $3`,
+				tenantID, wID, dID, amount,
+			)
+			return err
+		}
+	}
+
+	start := timeutil.Now()
+	if err := crdb.ExecuteTx(ctx, wk.db, nil /* txopts */, txFn); err != nil {
+		return err
+	}
+	elapsed := timeutil.Since(start)
+	wk.hists.Get(opName).Record(elapsed)
+	if wk.config.histogramsPerTenant {
+		wk.hists.Get(fmt.Sprintf(`tenant-%d.%s`, tenantID, opName)).Record(elapsed)
+	}
+	return nil
+}
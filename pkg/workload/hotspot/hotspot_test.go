@@ -0,0 +1,73 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hotspot
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/workload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkewedPicker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const n, draws = 10, 10000
+	counts := func(s float64) []int {
+		p := makeSkewedPicker(rand.New(rand.NewSource(1)), s, n)
+		res := make([]int, n)
+		for i := 0; i < draws; i++ {
+			v := p.next()
+			require.True(t, v >= 0 && v < n, "value %d out of range", v)
+			res[v]++
+		}
+		return res
+	}
+
+	// With a uniform distribution, no value gets a disproportionate share.
+	for v, c := range counts(0) {
+		require.Less(t, c, 2*draws/n, "value %d picked too often", v)
+	}
+
+	// With a Zipfian distribution, the first value is the hottest.
+	skewed := counts(2)
+	for v := 1; v < n; v++ {
+		require.Greater(t, skewed[0], skewed[v])
+	}
+	require.Greater(t, skewed[0], draws/2)
+
+	// The same seed yields the same sequence.
+	require.Equal(t, skewed, counts(2))
+}
+
+func TestTenantPrefixes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	gen := workload.FromFlags(hotspotMeta, `--tenants=3`, `--warehouses=2`, `--districts=4`)
+	tables := gen.Tables()
+	require.Len(t, tables, 3)
+
+	warehouses := tables[0].InitialRows
+	require.Equal(t, 6, warehouses.NumBatches)
+	require.Equal(t, []interface{}{int64(2), int64(1), int64(0)}, warehouses.BatchRows(5)[0])
+
+	districts := tables[1].InitialRows
+	require.Equal(t, 24, districts.NumBatches)
+	require.Equal(t, []interface{}{int64(1), int64(0), int64(3), int64(0), int64(0)}, districts.BatchRows(11)[0])
+
+	// Every table is split at the start of each tenant's prefix.
+	for _, table := range tables {
+		require.Equal(t, 2, table.Splits.NumBatches)
+		require.Equal(t, []interface{}{int64(2)}, table.Splits.BatchRows(1)[0])
+	}
+}